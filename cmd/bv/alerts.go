@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// computeDriftAlerts runs the drift calculator the way --robot-alerts does.
+// Without a saved baseline the stats are compared with themselves, so only
+// cycle, staleness and blocking-cascade alerts can fire. warn, if set, is
+// told about an unreadable baseline.
+func computeDriftAlerts(issues []model.Issue, driftConfig *drift.Config, baselinePath string, warn func(error)) *drift.Result {
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	openCount, closedCount, blockedCount := 0, 0, 0
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusClosed:
+			closedCount++
+		case model.StatusBlocked:
+			blockedCount++
+		case model.StatusOpen, model.StatusInProgress:
+			openCount++
+		default:
+			// Ignore tombstones and any unknown statuses for summary counts.
+		}
+	}
+	actionableCount := len(analyzer.GetActionableIssues())
+	cycles := stats.Cycles()
+	curStats := baseline.GraphStats{
+		NodeCount:       stats.NodeCount,
+		EdgeCount:       stats.EdgeCount,
+		Density:         stats.Density,
+		OpenCount:       openCount,
+		ClosedCount:     closedCount,
+		BlockedCount:    blockedCount,
+		CycleCount:      len(cycles),
+		ActionableCount: actionableCount,
+	}
+
+	// Default behavior (no baseline): drift comparisons are suppressed by using
+	// baseline=current for stats, while still allowing cycle/staleness/cascade alerts.
+	bl := &baseline.Baseline{Stats: curStats}
+	cur := &baseline.Baseline{Stats: curStats, Cycles: cycles}
+
+	// If a baseline exists, compare against it for real drift deltas.
+	if baseline.Exists(baselinePath) {
+		loaded, err := baseline.Load(baselinePath)
+		if err != nil {
+			if warn != nil {
+				warn(err)
+			}
+		} else {
+			bl = loaded
+			topMetrics := baseline.TopMetrics{
+				PageRank:     buildMetricItems(stats.PageRank(), 10),
+				Betweenness:  buildMetricItems(stats.Betweenness(), 10),
+				CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
+				Hubs:         buildMetricItems(stats.Hubs(), 10),
+				Authorities:  buildMetricItems(stats.Authorities(), 10),
+			}
+			cur = &baseline.Baseline{Stats: curStats, TopMetrics: topMetrics, Cycles: cycles}
+		}
+	}
+
+	calc := drift.NewCalculator(bl, cur, driftConfig)
+	calc.SetIssues(issues)
+	calc.SetPolicyInput(issues, &stats)
+	return calc.Calculate()
+}
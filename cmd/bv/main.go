@@ -32,24 +32,38 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/query"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/scenario"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workflow"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// `bv serve` and `bv mcp` are the subcommands; they have their own flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case "mcp":
+			os.Exit(runMCP(os.Args[2:]))
+		}
+	}
+
 	cpuProfile := flag.String("cpu-profile", "", "Write CPU profile to file")
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
@@ -60,7 +74,7 @@ func main() {
 	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
-	robotDocs := flag.String("robot-docs", "", "Machine-readable JSON docs for AI agents. Topics: guide, commands, examples, env, exit-codes, all")
+	robotDocs := flag.String("robot-docs", "", "Machine-readable JSON docs for AI agents. Topics: guide, commands, examples, env, exit-codes, query, all")
 	outputFormat := flag.String("format", "", "Structured output format for --robot-* commands: json or toon (env: BV_OUTPUT_FORMAT, TOON_DEFAULT_FORMAT)")
 	toonStats := flag.Bool("stats", false, "Show JSON vs TOON token estimates on stderr (env: TOON_STATS=1)")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
@@ -70,10 +84,12 @@ func main() {
 	robotTriageByTrack := flag.Bool("robot-triage-by-track", false, "Group triage recommendations by execution track (bv-87)")
 	robotTriageByLabel := flag.Bool("robot-triage-by-label", false, "Group triage recommendations by label (bv-87)")
 	robotNext := flag.Bool("robot-next", false, "Output only the top pick recommendation as JSON (minimal triage)")
+	scoringProfile := flag.String("scoring-profile", "", "Triage scoring profile from .bv/scoring.yaml (default: the file's default, else built-in weights)")
 	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since)")
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
+	robotWorkspaceTriage := flag.Bool("robot-workspace-triage", false, "Output workspace analysis (per-repo health, repo blocking matrix, cross-repo paths, dangling references) as JSON")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
@@ -102,6 +118,7 @@ func main() {
 	robotByAssignee := flag.String("robot-by-assignee", "", "Filter robot outputs by assignee (exact match)")
 	// Label subgraph scoping (bv-122)
 	labelScope := flag.String("label", "", "Scope analysis to label's subgraph (affects --robot-insights, --robot-plan, --robot-priority)")
+	whereExpr := flag.String("where", "", "Filter issues with a query expression (e.g. 'status:open AND label:api AND pagerank>0.01')")
 	alertSeverity := flag.String("severity", "", "Filter robot alerts by severity (info|warning|critical)")
 	alertType := flag.String("alert-type", "", "Filter robot alerts by alert type (e.g., stale_issue)")
 	alertLabel := flag.String("alert-label", "", "Filter robot alerts by label match")
@@ -112,6 +129,7 @@ func main() {
 	searchMode := flag.String("search-mode", "", "Search ranking mode: text or hybrid (default: BV_SEARCH_MODE or text)")
 	searchPreset := flag.String("search-preset", "", "Hybrid preset name (default: BV_SEARCH_PRESET or default)")
 	searchWeights := flag.String("search-weights", "", "Hybrid weights JSON (overrides preset; keys: text,pagerank,status,impact,priority,recency)")
+	searchLexicalWeight := flag.String("search-lexical-weight", "", "Share of text relevance from BM25 keyword scoring, 0-1 (default: BV_SEARCH_LEXICAL_WEIGHT or 0 = vectors only)")
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
@@ -121,6 +139,8 @@ func main() {
 	workspaceConfig := flag.String("workspace", "", "Load issues from workspace config file (.bv/workspace.yaml)")
 	beadsURL := flag.String("beads-url", "", "Load issues from Gas Town daemon URL (e.g., http://localhost:8443)")
 	beadsAPIKey := flag.String("beads-api-key", "", "API key for daemon authentication (or set BD_API_KEY)")
+	beadsStatus := flag.String("beads-status", "", "Comma-separated statuses to fetch from --beads-url (filtered server-side)")
+	beadsLabel := flag.String("beads-label", "", "Comma-separated labels to fetch from --beads-url (filtered server-side)")
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
@@ -146,12 +166,17 @@ func main() {
 	fileBeadsLimit := flag.Int("file-beads-limit", 20, "Max closed beads to show (use with --robot-file-beads)")
 	fileHotspots := flag.Bool("robot-file-hotspots", false, "Output files touched by most beads as JSON")
 	hotspotsLimit := flag.Int("hotspots-limit", 10, "Max hotspots to show (use with --robot-file-hotspots)")
+	robotFileRisk := flag.Bool("robot-file-risk", false, "Output per-file risk scores (bug density, reopens, churn, coupling) as JSON")
+	fileRiskLimit := flag.Int("file-risk-limit", 20, "Max files and directories to show (use with --robot-file-risk, 0 = all)")
+	exportFileTreemap := flag.String("export-file-treemap", "", "Export a file risk treemap as SVG (area = churn, colour = risk)")
 	// Impact analysis flag (bv-19pq)
 	robotImpact := flag.String("robot-impact", "", "Analyze impact of modifying files (comma-separated paths)")
 	// Co-change detection flag (bv-7a2f)
 	robotFileRelations := flag.String("robot-file-relations", "", "Output files that frequently co-change with the given file path")
 	relationsThreshold := flag.Float64("relations-threshold", 0.5, "Minimum correlation threshold (0.0-1.0) for related files")
 	relationsLimit := flag.Int("relations-limit", 10, "Max related files to show")
+	// Line-level bead attribution
+	robotBlame := flag.String("robot-blame", "", "Output the beads behind each line range of a file (FILE[:START-END]) as JSON")
 	// Related work discovery flag (bv-jtdl)
 	robotRelatedWork := flag.String("robot-related", "", "Output beads related to a specific bead ID as JSON")
 	relatedMinRelevance := flag.Int("related-min-relevance", 20, "Minimum relevance score (0-100) for related work")
@@ -164,6 +189,10 @@ func main() {
 	networkDepth := flag.Int("network-depth", 2, "Depth of subnetwork when querying specific bead (1-3)")
 	// Temporal causality analysis flag (bv-j74w)
 	robotCausality := flag.String("robot-causality", "", "Output causal chain analysis for bead ID as JSON")
+	// Flow metrics flags
+	robotFlowMetrics := flag.Bool("robot-flow-metrics", false, "Output lead/cycle time percentiles, throughput, flow efficiency and aging WIP as JSON")
+	flowWeeks := flag.Int("flow-weeks", 12, "Weeks of weekly throughput for --robot-flow-metrics")
+	robotCFD := flag.Bool("robot-cfd", false, "Output a cumulative flow diagram (daily counts per status) reconstructed from git history as JSON")
 	// Sprint flags (bv-156)
 	robotSprintList := flag.Bool("robot-sprint-list", false, "Output sprints as JSON")
 	robotSprintShow := flag.String("robot-sprint-show", "", "Output specific sprint details as JSON")
@@ -172,10 +201,21 @@ func main() {
 	forecastLabel := flag.String("forecast-label", "", "Filter forecast by label")
 	forecastSprint := flag.String("forecast-sprint", "", "Filter forecast by sprint ID")
 	forecastAgents := flag.Int("forecast-agents", 1, "Number of parallel agents for capacity calculation")
+	forecastTrials := flag.Int("forecast-trials", analysis.DefaultMonteCarloTrials, "Monte Carlo trials for --robot-forecast percentiles (0 = disable)")
+	forecastSeed := flag.Int64("forecast-seed", analysis.DefaultMonteCarloSeed, "RNG seed for Monte Carlo forecasting (same seed = same output)")
 	// Capacity simulation flags (bv-160)
 	robotCapacity := flag.Bool("robot-capacity", false, "Output capacity simulation and completion projection as JSON")
 	capacityAgents := flag.Int("agents", 1, "Number of parallel agents for capacity simulation")
 	capacityLabel := flag.String("capacity-label", "", "Filter capacity simulation by label")
+	// Resource-constrained schedule flags
+	robotSchedule := flag.Bool("robot-schedule", false, "Output resource-constrained multi-agent schedule (Gantt) as JSON")
+	scheduleAssignees := flag.String("schedule-assignees", "", "Named agents with capacities for --robot-schedule (e.g., alice=1,bob=0.5)")
+	scheduleHours := flag.Float64("schedule-hours", 8, "Working hours per day for --robot-schedule")
+	// Cycle-breaking assistant flags
+	robotCycleFix := flag.Bool("robot-cycle-fix", false, "Output per-component cycle-break plan with impact simulation and bd dep remove commands as JSON")
+	cycleFixCandidates := flag.Int("cycle-fix-candidates", 8, "Max candidate edges simulated per cycle component for --robot-cycle-fix")
+	// What-if scenario flags
+	robotScenario := flag.String("robot-scenario", "", "Apply hypothetical mutations from a YAML/JSON file and output triage/plan/forecast diff vs. baseline as JSON")
 	// Burndown flags (bv-159)
 	robotBurndown := flag.String("robot-burndown", "", "Output burndown data for sprint ID, or 'current' for active sprint")
 	// Action script emission flags (bv-89)
@@ -218,6 +258,8 @@ func main() {
 	// Override pflag's default usage so -h/--help prints our custom header.
 	flag.Usage = func() {
 		fmt.Println("Usage: bv [options]")
+		fmt.Println("       bv serve [--addr HOST:PORT | --socket PATH]   (see 'bv serve --help')")
+		fmt.Println("       bv mcp                                        (MCP server over stdio)")
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
 	}
@@ -254,6 +296,8 @@ func main() {
 	_ = forecastLabel
 	_ = forecastSprint
 	_ = forecastAgents
+	_ = forecastTrials
+	_ = forecastSeed
 	_ = robotCapacity
 	_ = capacityAgents
 	_ = capacityLabel
@@ -285,6 +329,7 @@ func main() {
 		*robotRecipes ||
 		*robotLabelHealth ||
 		*robotLabelFlow ||
+		*robotWorkspaceTriage ||
 		*robotLabelAttention ||
 		*robotAlerts ||
 		*robotMetrics ||
@@ -302,6 +347,10 @@ func main() {
 		*robotBlockerChain != "" ||
 		*robotImpactNetwork != "" ||
 		*robotCausality != "" ||
+		*robotFlowMetrics ||
+		*robotFileRisk ||
+		*robotBlame != "" ||
+		*robotCFD ||
 		*robotSprintList ||
 		*robotSprintShow != "" ||
 		*robotForecast != "" ||
@@ -309,6 +358,9 @@ func main() {
 		*robotByLabel != "" ||
 		*robotByAssignee != "" ||
 		*robotCapacity ||
+		*robotSchedule ||
+		*robotCycleFix ||
+		*robotScenario != "" ||
 		*robotDocs != "" ||
 		// When stdout is non-TTY, --diff-since auto-enables JSON output. Mark this
 		// as robot mode early so parsers keep stdout JSON clean.
@@ -329,8 +381,18 @@ func main() {
 		os.Exit(2)
 	}
 
+	// Custom statuses from .bv/workflow.yaml must be registered before any
+	// issues are loaded, or the loader rejects them as invalid.
+	if cwd, err := os.Getwd(); err == nil {
+		if err := workflow.Install(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", workflow.ConfigPath(cwd), err)
+		}
+	}
+
 	if *help {
 		fmt.Println("Usage: bv [options]")
+		fmt.Println("       bv serve [--addr HOST:PORT | --socket PATH]   (see 'bv serve --help')")
+		fmt.Println("       bv mcp                                        (MCP server over stdio)")
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
 		os.Exit(0)
@@ -388,6 +450,8 @@ func main() {
 		fmt.Println("      - blockers_to_clear: Items that unblock the most downstream work")
 		fmt.Println("      - project_health: Counts, graph metrics, overall status")
 		fmt.Println("      - commands: Copy-paste commands for common next steps")
+		fmt.Println("      Ranking weights come from --scoring-profile NAME (.bv/scoring.yaml);")
+		fmt.Println("      meta.scoring_profile records which profile was used.")
 		fmt.Println("")
 		fmt.Println("  --robot-next")
 		fmt.Println("      Minimal triage: returns only the single top recommendation.")
@@ -398,6 +462,9 @@ func main() {
 		fmt.Println("      Semantic vector search over issue titles/descriptions.")
 		fmt.Println("      Builds/updates a local on-disk vector index on first run.")
 		fmt.Println("      Use --robot-search to emit JSON for automation.")
+		fmt.Println("      Optional BM25 keyword blending over title, labels, description and comments")
+		fmt.Println("      (\"exact phrase\", prefix*, typos are tolerated):")
+		fmt.Println("      - --search-lexical-weight=0.35 (default: BV_SEARCH_LEXICAL_WEIGHT or 0 = vectors only; 1 = keywords only)")
 		fmt.Println("      Optional hybrid re-ranking:")
 		fmt.Println("      - --search-mode=text|hybrid (default: BV_SEARCH_MODE or text)")
 		fmt.Println("      - --search-preset=default|bug-hunting|sprint-planning|impact-first|text-only")
@@ -426,6 +493,29 @@ func main() {
 		fmt.Println("      Example: bv --robot-history --history-since '30 days ago'")
 		fmt.Println("      Example: bv --robot-history --min-confidence 0.7")
 		fmt.Println("")
+		fmt.Println("  --robot-flow-metrics")
+		fmt.Println("      Outputs aggregate flow metrics from git history as JSON.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - overall, by_label, by_type, by_assignee: lead_time and cycle_time")
+		fmt.Println("        percentiles (p50/p85/p95 days), weekly throughput, flow_efficiency")
+		fmt.Println("        (time in progress vs. blocked)")
+		fmt.Println("      - samples: Completed beads with lead/cycle days, oldest close first")
+		fmt.Println("      - aging_wip: In-progress beads older than the P85 cycle time")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --flow-weeks <n>: Weeks of throughput history (default: 12)")
+		fmt.Println("      - --history-since <ref>, --history-limit <n>: Bound the git history scanned")
+		fmt.Println("      Example: bv --robot-flow-metrics --history-since '90 days ago'")
+		fmt.Println("")
+		fmt.Println("  --robot-cfd")
+		fmt.Println("      Outputs a cumulative flow diagram as JSON: beads per status at the end of each day.")
+		fmt.Println("      Replays the beads file's git history in one pass instead of loading each day.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - statuses: Band order, bottom (done) to top (open)")
+		fmt.Println("      - days: date, counts (per status), by_class, total")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --history-since <ref>: Window start (default: 90 days ago)")
+		fmt.Println("      Example: bv --robot-cfd --history-since=90d")
+		fmt.Println("")
 		fmt.Println("  --robot-file-beads <path>")
 		fmt.Println("      Outputs beads that have touched a file path as JSON.")
 		fmt.Println("      Answers: 'What beads have touched this file, and why?'")
@@ -450,6 +540,22 @@ func main() {
 		fmt.Println("      - --hotspots-limit <n>: Max hotspots to show (default: 10)")
 		fmt.Println("      Example: bv --robot-file-hotspots")
 		fmt.Println("")
+		fmt.Println("  --robot-file-risk")
+		fmt.Println("      Outputs a combined risk score (0-1) per file from git history as JSON.")
+		fmt.Println("      Signals: bug-bead density, reopen events, churn (lines added + deleted),")
+		fmt.Println("      and co-change coupling, each normalized against the riskiest file.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - files: {file_path, risk_score, risk_level, components, bug_beads,")
+		fmt.Println("        reopens, insertions, deletions, coupled_files, bead_ids}, riskiest first")
+		fmt.Println("      - directories: Rolled-up risk (max of files) with the hotspot file")
+		fmt.Println("      - weights, stats: Component weights and critical/high file counts")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --file-risk-limit <n>: Max files/directories (default: 20, 0 = all)")
+		fmt.Println("      - --relations-threshold <0.0-1.0>: Min co-change correlation for coupling")
+		fmt.Println("      - --history-since <ref>, --history-limit <n>: Bound the git history scanned")
+		fmt.Println("      Example: bv --robot-file-risk --history-since '90 days ago'")
+		fmt.Println("      Treemap: bv --export-file-treemap risk.svg")
+		fmt.Println("")
 		fmt.Println("  --robot-impact <files>")
 		fmt.Println("      Analyzes impact of modifying files - what beads might be affected?")
 		fmt.Println("      Critical for agents: check before making changes to avoid conflicts.")
@@ -476,6 +582,19 @@ func main() {
 		fmt.Println("      Example: bv --robot-file-relations pkg/auth/token.go")
 		fmt.Println("      Example: bv --robot-file-relations pkg/auth/token.go --relations-threshold 0.3")
 		fmt.Println("")
+		fmt.Println("  --robot-blame <file>[:<start>-<end>]")
+		fmt.Println("      Outputs which beads last touched each line of a file, from git blame.")
+		fmt.Println("      Each line's commit is joined against the history commit index.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - ranges: Runs of lines from one commit {start_line, end_line, sha,")
+		fmt.Println("        author, summary, beads: [{bead_id, title, status, confidence, method}]}")
+		fmt.Println("      - beads: Per-bead totals {bead_id, lines, ranges}, most lines first")
+		fmt.Println("      - stats: total_lines, attributed_lines, coverage, commits, beads")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --history-since <ref>, --history-limit <n>: Bound the git history correlated")
+		fmt.Println("      Example: bv --robot-blame pkg/auth/token.go")
+		fmt.Println("      Example: bv --robot-blame pkg/auth/token.go:40-80")
+		fmt.Println("")
 		fmt.Println("  --robot-related <bead-id>")
 		fmt.Println("      Outputs beads related to a specific bead as JSON.")
 		fmt.Println("      Discovers related work across multiple dimensions:")
@@ -528,6 +647,8 @@ func main() {
 		fmt.Println("        --forecast-label=X    Filter by label")
 		fmt.Println("        --forecast-sprint=Y   Filter by sprint")
 		fmt.Println("        --forecast-agents=N   Parallel agents (default: 1)")
+		fmt.Println("        --forecast-trials=N   Monte Carlo trials for P50/P85/P95 (default: 1000, 0 = off)")
+		fmt.Println("        --forecast-seed=S     RNG seed for reproducible Monte Carlo output")
 		fmt.Println("      Example: bv --robot-forecast bv-123")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-label=backend")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-agents=2")
@@ -547,6 +668,50 @@ func main() {
 		fmt.Println("      Example: bv --robot-capacity --agents=3")
 		fmt.Println("      Example: bv --robot-capacity --capacity-label=backend")
 		fmt.Println("")
+		fmt.Println("  --robot-schedule [--agents=N] [--schedule-assignees=alice=1,bob=0.5]")
+		fmt.Println("      Outputs a list-scheduled timeline for every open bead as JSON.")
+		fmt.Println("      Respects blocking deps, estimated_minutes, and agent capacities.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - items: start/end time and agent per bead")
+		fmt.Println("        - makespan_minutes / makespan_days: total schedule length")
+		fmt.Println("        - critical_path: chain of beads that determines the makespan")
+		fmt.Println("        - agents: per-agent load and utilization")
+		fmt.Println("        - gantt: Mermaid gantt chart of the schedule")
+		fmt.Println("      Options:")
+		fmt.Println("        --agents=N                Anonymous agents (default: 1)")
+		fmt.Println("        --schedule-assignees=...  Named agents; beads assigned to them are pinned")
+		fmt.Println("        --schedule-hours=H        Working hours per day (default: 8)")
+		fmt.Println("      Example: bv --robot-schedule --agents=3")
+		fmt.Println("      Example: bv --robot-schedule --schedule-assignees=alice=1,bob=0.5")
+		fmt.Println("")
+		fmt.Println("  --robot-cycle-fix [--cycle-fix-candidates=N]")
+		fmt.Println("      Plans how to break every dependency cycle, one strongly connected")
+		fmt.Println("      component at a time, and simulates each cut before you make it.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - components[].feedback_arc_set: minimal edges to remove per component")
+		fmt.Println("        - components[].candidates: per-edge impact on actionable count,")
+		fmt.Println("          top picks and longest path (delta vs. current graph)")
+		fmt.Println("        - before / after: triage summary without and with all cuts")
+		fmt.Println("        - commands: ready-to-run `bd dep remove` commands")
+		fmt.Println("      Example: bv --robot-cycle-fix | jq -r '.commands[]'")
+		fmt.Println("")
+		fmt.Println("  --robot-scenario <file> [--forecast-agents=N] [--forecast-trials=N] [--forecast-seed=N]")
+		fmt.Println("      Applies a list of hypothetical mutations to a copy of the issues and")
+		fmt.Println("      diffs the result against the baseline. Nothing is written back.")
+		fmt.Println("      Ops: close, reopen, add_dep, remove_dep, estimate (minutes or add: 2w).")
+		fmt.Println("      Selectors: id, ids, label, assignee, status (all must match).")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - mutations: issues each mutation changed or skipped")
+		fmt.Println("        - diff: snapshot diff (same shape as --diff-since)")
+		fmt.Println("        - triage: open/actionable/blocked deltas, top picks added/dropped")
+		fmt.Println("        - plan: newly actionable / no longer actionable beads")
+		fmt.Println("        - forecast: Monte Carlo p50/p85/p95 deltas for all open work")
+		fmt.Println("      Example scenario (YAML):")
+		fmt.Println("        mutations:")
+		fmt.Println("          - {op: close, label: auth}")
+		fmt.Println("          - {op: estimate, id: bd-12, add: 2w}")
+		fmt.Println("      Example: bv --robot-scenario what-if.yaml | jq '.forecast.p85_delta_days'")
+		fmt.Println("")
 		fmt.Println("  --emit-script [--script-limit=N] [--script-format=bash|fish|zsh]")
 		fmt.Println("      Emits a shell script for top-N priority recommendations.")
 		fmt.Println("      Useful for agent workflows and automation.")
@@ -575,6 +740,11 @@ func main() {
 		fmt.Println("      - post-export: Notifications, uploads (failure logged only)")
 		fmt.Println("      Environment variables: BV_EXPORT_PATH, BV_EXPORT_FORMAT,")
 		fmt.Println("        BV_ISSUE_COUNT, BV_TIMESTAMP")
+		fmt.Println("      Lifecycle events (run by 'bv serve' and 'bv mcp' on reload; failure logged only):")
+		fmt.Println("      - bead-created, status-changed, became-actionable (last blocker closed),")
+		fmt.Println("        cycle-introduced, drift-alert (newly raised --robot-alerts alert)")
+		fmt.Println("      Event hooks get the event as JSON on stdin plus BV_EVENT, BV_ISSUE_ID,")
+		fmt.Println("        BV_STATUS, BV_PREVIOUS_STATUS, BV_CLEARED_BLOCKERS, BV_CYCLE, BV_ALERT_TYPE, ...")
 		fmt.Println("")
 		fmt.Println("  --diff-since <commit|date>")
 		fmt.Println("      Shows changes since a historical point.")
@@ -627,6 +797,18 @@ func main() {
 		fmt.Println("                  bottleneck_labels (highest outgoing), total_cross_label_deps.")
 		fmt.Println("      Use when you need to see which labels are blocking others at a glance.")
 		fmt.Println("")
+		fmt.Println("  --robot-workspace-triage [--workspace .bv/workspace.yaml]")
+		fmt.Println("      Outputs workspace-aware analysis of a multi-repo workspace as JSON.")
+		fmt.Println("      Without --workspace, .bv/workspace.yaml is searched upward from the current directory.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - repos: Per-repo health (0-100), open/blocked/actionable counts, load errors,")
+		fmt.Println("        and the repos blocking it (upstream_repos) or blocked by it (downstream_repos)")
+		fmt.Println("      - flow: repos[], blocking_matrix[from][to], dependencies with blocking pairs,")
+		fmt.Println("        bottleneck_repos; the repo counterpart of --robot-label-flow")
+		fmt.Println("      - cross_repo_paths: Longest open blocker chains crossing repos, blocker first")
+		fmt.Println("      - dangling_refs: Dependencies on repos missing from workspace.yaml or disabled")
+		fmt.Println("      Example: bv --robot-workspace-triage | jq '.workspace.summary.missing_repos'")
+		fmt.Println("")
 		fmt.Println("  --robot-label-attention [--attention-limit=N]")
 		fmt.Println("      Outputs attention-ranked labels as JSON (default limit: 5).")
 		fmt.Println("      Labels ranked by attention score = (pagerank * staleness * block_impact) / velocity.")
@@ -719,9 +901,23 @@ func main() {
 		fmt.Println("      Includes label_scope and label_context in output with health metrics.")
 		fmt.Println("      Example: bv --robot-insights --label api")
 		fmt.Println("")
+		fmt.Println("  --where EXPR")
+		fmt.Println("      Filter issues with a query before any robot command (or the TUI) runs.")
+		fmt.Println("      Terms: field:value, field<n, field~substr, bare words; combine with AND/OR/NOT and ().")
+		fmt.Println("      Fields include status, label, assignee, type, priority, blocks, blockers,")
+		fmt.Println("      created/updated (dates or ages like 14d), is:ready, has:assignee, and graph")
+		fmt.Println("      metrics (pagerank, betweenness, critical, slack, core). See --robot-docs query.")
+		fmt.Println("      Example: bv --robot-triage --where 'label:api AND (priority<=1 OR blocks>3) AND updated<14d'")
+		fmt.Println("")
 		fmt.Println("  --robot-triage / --robot-next")
 		fmt.Println("      Unified triage (mega command) or single top pick. QuickRef includes top picks, quick_wins, blockers_to_clear.")
 		fmt.Println("")
+		fmt.Println("  --scoring-profile NAME")
+		fmt.Println("      Rank triage with a named profile from .bv/scoring.yaml (weights for pagerank,")
+		fmt.Println("      betweenness, blocker_ratio, staleness, priority_boost, time_to_impact, urgency,")
+		fmt.Println("      risk, plus label_multipliers). Applies to --robot-triage, --robot-next and")
+		fmt.Println("      --robot-priority; in the TUI press % to switch profiles live.")
+		fmt.Println("")
 		fmt.Println("  --recipe NAME, -r NAME")
 		fmt.Println("      Apply a named recipe to filter and sort issues.")
 		fmt.Println("      Example: bv --recipe actionable")
@@ -762,11 +958,13 @@ func main() {
 		fmt.Println("        0 = No critical or warning alerts (info-only OK)")
 		fmt.Println("        1 = Critical alerts (new cycles detected)")
 		fmt.Println("        2 = Warning alerts (blocked increase, density growth)")
+		fmt.Println("      Policy rules from .bv/drift.yaml count at their own severity and")
+		fmt.Println("      run even without a baseline.")
 		fmt.Println("      Human-readable output by default, use --robot-drift for JSON.")
 		fmt.Println("")
 		fmt.Println("  --robot-drift")
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
-		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
+		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, policies, baseline}")
 		fmt.Println("")
 		fmt.Println("  Static Site Export & GitHub Pages (bv-7pu):")
 		fmt.Println("      --pages")
@@ -795,10 +993,35 @@ func main() {
 		fmt.Println("      --pages-include-closed=false")
 		fmt.Println("          Exclude closed issues from export (default: include all)")
 		fmt.Println("")
+		fmt.Println("  Long-Running Server:")
+		fmt.Println("      bv serve [--addr 127.0.0.1:9210 | --socket PATH] [--format json|toon]")
+		fmt.Println("          Keep issues and analysis warm and answer over local HTTP instead of")
+		fmt.Println("          forking bv per question. Reloads when the beads file changes.")
+		fmt.Println("          GET /triage /plan /insights /search?q= /graph /history /forecast /diff?since=")
+		fmt.Println("          Responses carry an ETag on the data hash; send If-None-Match for a 304.")
+		fmt.Println("          GET /events streams Server-Sent Events when the data changes.")
+		fmt.Println("          Example: curl -s localhost:9210/triage | jq '.triage.quick_ref'")
+		fmt.Println("")
+		fmt.Println("  MCP Server:")
+		fmt.Println("      bv mcp [--history-limit N] [--no-watch]")
+		fmt.Println("          Register bv as a Model Context Protocol server over stdio instead of")
+		fmt.Println("          shelling out: {\"mcpServers\": {\"bv\": {\"command\": \"bv\", \"args\": [\"mcp\"]}}}")
+		fmt.Println("          Tools: triage, next, plan, blocker-chain, impact, related, search, forecast")
+		fmt.Println("          (output schemas match --robot-schema; search is BM25 keyword lookup only).")
+		fmt.Println("          Resources: bv://issues, bv://issues/{id}.")
+		fmt.Println("          State stays warm between calls and reloads when the beads file changes.")
+		fmt.Println("")
 		fmt.Println("  Drift Detection Configuration (.bv/drift.yaml)")
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
 		fmt.Println("      - blocked_increase_threshold: 5   # Warn if 5+ more blocked")
+		fmt.Println("      Policy rules use --where expressions over issues and graph metrics:")
+		fmt.Println("      rules:")
+		fmt.Println("        - name: p0-bugs-unblocked-by-p3")
+		fmt.Println("          severity: critical          # critical, warning or info")
+		fmt.Println("          labels: [backend]           # optional per-label scope")
+		fmt.Println("          where: \"type:bug priority:0 is:open\"")
+		fmt.Println("          blocked_by: \"priority:3\"    # or require: \"has:assignee\"")
 		fmt.Println("      Run 'bv --baseline-info' to see current baseline state.")
 		os.Exit(0)
 	}
@@ -1299,9 +1522,22 @@ func main() {
 	loadStart := time.Now()
 	var issues []model.Issue
 	var beadsPath string
+	var beadsFilter *loader.DaemonListOptions // set when loading from --beads-url
+	var beadsLoaded []model.Issue             // unfiltered --beads-url load, seeds the poller
 	var workspaceInfo *workspace.LoadSummary
+	var workspaceResults []workspace.LoadResult
 	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
 
+	// --robot-workspace-triage finds the workspace config itself when not given
+	if *robotWorkspaceTriage && *workspaceConfig == "" && *asOf == "" {
+		configPath, err := workspace.FindWorkspaceConfig("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --robot-workspace-triage needs a workspace: no .bv/workspace.yaml found (use --workspace)\n")
+			os.Exit(1)
+		}
+		*workspaceConfig = configPath
+	}
+
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
 		// Note: --as-of takes precedence over --workspace (can't combine historical + multi-repo)
@@ -1338,6 +1574,7 @@ func main() {
 			os.Exit(1)
 		}
 		issues = loadedIssues
+		workspaceResults = results
 		summary := workspace.Summarize(results)
 		workspaceInfo = &summary
 
@@ -1360,11 +1597,16 @@ func main() {
 	} else if *beadsURL != "" {
 		// Load from Gas Town daemon via datasource layer (enables HTTP discovery + polling)
 		var err error
-		issues, err = datasource.LoadIssuesFromHTTP(*beadsURL, *beadsAPIKey)
+		filter := loader.DaemonListOptions{Labels: splitCommaList(*beadsLabel), IncludeComments: true}
+		for _, st := range splitCommaList(*beadsStatus) {
+			filter.Statuses = append(filter.Statuses, model.Status(st))
+		}
+		issues, err = datasource.LoadIssuesFromHTTPFiltered(*beadsURL, *beadsAPIKey, filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads from %s: %v\n", *beadsURL, err)
 			os.Exit(1)
 		}
+		beadsFilter, beadsLoaded = &filter, issues
 		beadsPath = "" // no file-based live reload (HTTP uses polling)
 	} else {
 		// Load from single repo (original behavior)
@@ -1428,6 +1670,22 @@ func main() {
 		}
 	}
 
+	// Query filter (--where) narrows the issue set for every robot command and the TUI.
+	var whereQuery *query.Query
+	if *whereExpr != "" {
+		q, err := query.Parse(*whereExpr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --where: %v\n", err)
+			var qerr *query.Error
+			if errors.As(err, &qerr) {
+				fmt.Fprintf(os.Stderr, "  %s\n", strings.ReplaceAll(qerr.Context(), "\n", "\n  "))
+			}
+			os.Exit(1)
+		}
+		issues = applyWhereFilter(issues, q)
+		whereQuery = q
+	}
+
 	// Apply recipe filtering early for robot modes (bv-93)
 	// This ensures --recipe filters are applied before robot modes exit.
 	// dataHash uses pre-filtered issues for stability.
@@ -1448,7 +1706,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
+		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights, *searchLexicalWeight)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -1477,7 +1735,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Building semantic index (%d issues)...\n", len(docs))
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), embedCfg.IndexBuildTimeout())
 		defer cancel()
 
 		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
@@ -1485,7 +1743,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error building semantic index: %v\n", err)
 			os.Exit(1)
 		}
-		if !loaded || syncStats.Changed() {
+		if !loaded || syncStats.NeedsSave() {
 			if err := idx.Save(indexPath); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving semantic index: %v\n", err)
 				os.Exit(1)
@@ -1493,6 +1751,7 @@ func main() {
 		}
 
 		qvecs, err := embedder.Embed(ctx, []string{*semanticQuery})
+		_ = search.CloseEmbedder(embedder) // Nothing else to embed; stop any subprocess now
 		if err != nil || len(qvecs) != 1 {
 			if err == nil {
 				err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
@@ -1514,6 +1773,27 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
 			os.Exit(1)
 		}
+		lexicalPath := search.DefaultLexicalIndexPath(projectDir)
+		var lexicalStats *search.IndexSyncStats
+		var lexicalScores map[string]float64
+		if searchCfg.LexicalWeight > 0 {
+			lexical, stats, err := runLexicalSearch(lexicalPath, issuesForSearch, *semanticQuery, fetchLimit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			lexicalStats = &stats
+			lexicalScores = make(map[string]float64, len(lexical))
+			for _, r := range lexical {
+				lexicalScores[r.IssueID] = r.Score
+			}
+			results = search.BlendTextScores(results, lexical, searchCfg.LexicalWeight, func(id string) (float64, bool) {
+				return idx.Score(qvecs[0], id)
+			})
+			if len(results) > fetchLimit {
+				results = results[:fetchLimit]
+			}
+		}
 		results = search.ApplyShortQueryLexicalBoost(results, *semanticQuery, docs)
 		if isLikelyIssueID(*semanticQuery) {
 			results = promoteExactSearchResult(*semanticQuery, results)
@@ -1571,6 +1851,12 @@ func main() {
 				Loaded:      loaded,
 				Limit:       limit,
 				Mode:        searchCfg.Mode,
+
+				LexicalWeight: searchCfg.LexicalWeight,
+				LexicalIndex:  lexicalStats,
+			}
+			if lexicalStats != nil {
+				out.LexicalIndexPath = lexicalPath
 			}
 			if searchCfg.Mode == search.SearchModeHybrid {
 				out.Preset = resolvedPreset
@@ -1583,6 +1869,7 @@ func main() {
 						IssueID:         r.IssueID,
 						Score:           r.FinalScore,
 						TextScore:       r.TextScore,
+						LexicalScore:    lexicalScores[r.IssueID],
 						Title:           titleByID[r.IssueID],
 						ComponentScores: r.ComponentScores,
 					})
@@ -1595,13 +1882,15 @@ func main() {
 			} else {
 				for _, r := range results {
 					out.Results = append(out.Results, robotSearchResult{
-						IssueID: r.IssueID,
-						Score:   r.Score,
-						Title:   titleByID[r.IssueID],
+						IssueID:      r.IssueID,
+						Score:        r.Score,
+						LexicalScore: lexicalScores[r.IssueID],
+						Title:        titleByID[r.IssueID],
 					})
 				}
 				out.UsageHints = []string{
 					"jq '.results[] | {id: .issue_id, score: .score, title: .title}' - Extract results",
+					"jq '.results[] | {id: .issue_id, keyword: .lexical_score}' - BM25 keyword scores before blending",
 					"jq '.index' - Index update stats (added/updated/removed/embedded)",
 				}
 			}
@@ -1768,6 +2057,17 @@ func main() {
 				} else if err != nil {
 					fmt.Printf("  → Warning: failed to generate history: %v\n", err)
 				}
+				if flow, err := generateCFDForExport(); err == nil {
+					if cfdJSON, err := json.MarshalIndent(flow, "", "  "); err == nil {
+						if err := os.WriteFile(filepath.Join(*exportPages, "data", "cfd.json"), cfdJSON, 0644); err != nil {
+							fmt.Printf("  → Warning: failed to write cfd.json: %v\n", err)
+						} else {
+							fmt.Printf("  → cfd.json (%d days)\n", len(flow.Days))
+						}
+					}
+				} else {
+					fmt.Printf("  → Warning: failed to generate cumulative flow: %v\n", err)
+				}
 			}
 
 			// Run post-export hooks (bv-qjc.3)
@@ -1991,6 +2291,40 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-workspace-triage
+	if *robotWorkspaceTriage {
+		if workspaceInfo == nil {
+			fmt.Fprintf(os.Stderr, "Error: --robot-workspace-triage cannot be combined with --as-of\n")
+			os.Exit(1)
+		}
+		wsConfig, err := workspace.LoadConfig(*workspaceConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading workspace config: %v\n", err)
+			os.Exit(1)
+		}
+		result := workspace.Analyze(issues, wsConfig, workspaceResults, workspace.DefaultAnalysisOptions())
+		output := struct {
+			RobotEnvelope
+			Workspace  workspace.Analysis `json:"workspace"`
+			UsageHints []string           `json:"usage_hints"`
+		}{
+			RobotEnvelope: NewRobotEnvelope(dataHash),
+			Workspace:     result,
+			UsageHints: []string{
+				"jq '.workspace.repos[] | {repo, health, health_level, blocked_by_other_repos}' - per-repo health",
+				"jq '.workspace.flow.blocking_matrix' - raw matrix (row=from, col=to, align with .workspace.flow.repos)",
+				"jq '.workspace.cross_repo_paths[0].issue_ids' - longest chain crossing repos, start with the first",
+				"jq '.workspace.dangling_refs[] | {issue_id, reference, reason}' - references to repos bv cannot see",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding workspace triage: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-label-attention (bv-121)
 	if *robotLabelAttention {
 		cfg := analysis.DefaultLabelHealthConfig()
@@ -2203,63 +2537,11 @@ func main() {
 			os.Exit(1)
 		}
 
-		analyzer := analysis.NewAnalyzer(issues)
-		stats := analyzer.Analyze()
-
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			case model.StatusOpen, model.StatusInProgress:
-				openCount++
-			default:
-				// Ignore tombstones and any unknown statuses for summary counts.
-			}
-		}
-		actionableCount := len(analyzer.GetActionableIssues())
-		cycles := stats.Cycles()
-		curStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(cycles),
-			ActionableCount: actionableCount,
-		}
-
-		// Default behavior (no baseline): drift comparisons are suppressed by using
-		// baseline=current for stats, while still allowing cycle/staleness/cascade alerts.
-		bl := &baseline.Baseline{Stats: curStats}
-		cur := &baseline.Baseline{Stats: curStats, Cycles: cycles}
-
-		// If a baseline exists, compare against it for real drift deltas.
-		if baseline.Exists(baselinePath) {
-			loaded, err := baseline.Load(baselinePath)
-			if err != nil {
-				if !envRobot {
-					fmt.Fprintf(os.Stderr, "Warning: Error loading baseline: %v\n", err)
-				}
-			} else {
-				bl = loaded
-				topMetrics := baseline.TopMetrics{
-					PageRank:     buildMetricItems(stats.PageRank(), 10),
-					Betweenness:  buildMetricItems(stats.Betweenness(), 10),
-					CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
-					Hubs:         buildMetricItems(stats.Hubs(), 10),
-					Authorities:  buildMetricItems(stats.Authorities(), 10),
-				}
-				cur = &baseline.Baseline{Stats: curStats, TopMetrics: topMetrics, Cycles: cycles}
+		driftResult := computeDriftAlerts(issues, driftConfig, baselinePath, func(err error) {
+			if !envRobot {
+				fmt.Fprintf(os.Stderr, "Warning: Error loading baseline: %v\n", err)
 			}
-		}
-
-		calc := drift.NewCalculator(bl, cur, driftConfig)
-		calc.SetIssues(issues)
-		driftResult := calc.Calculate()
+		})
 
 		// Apply optional filters
 		filtered := driftResult.Alerts[:0]
@@ -2348,6 +2630,10 @@ func main() {
 			os.Exit(1)
 		}
 
+		if config.FilterType == "" || config.FilterType == analysis.SuggestionPotentialDuplicate {
+			config.SemanticDuplicates = semanticDuplicates(search.EmbeddingConfigFromEnv())
+		}
+
 		output := analysis.GenerateRobotSuggestOutput(issues, config, dataHash)
 
 		encoder := newRobotEncoder(os.Stdout)
@@ -2428,16 +2714,28 @@ func main() {
 
 	// Handle --check-drift
 	if *checkDrift {
-		if !baseline.Exists(baselinePath) {
+		// A broken drift.yaml must not pass CI on default thresholds
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Policy rules don't need a baseline; without one only they can fire
+		hasBaseline := baseline.Exists(baselinePath)
+		if !hasBaseline && len(driftConfig.Rules) == 0 {
 			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
 			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
 			os.Exit(1)
 		}
 
-		bl, err := baseline.Load(baselinePath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
+		var bl *baseline.Baseline
+		if hasBaseline {
+			bl, err = baseline.Load(baselinePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		// Run analysis on current issues
@@ -2482,17 +2780,12 @@ func main() {
 			Authorities:  buildMetricItems(stats.Authorities(), 10),
 		}
 		current := baseline.New(currentStats, currentMetrics, cycles, "current")
-
-		// Load drift config and run calculator
-		driftConfig, err := drift.LoadConfig(projectDir)
-		if err != nil {
-			if !envRobot {
-				fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
-			}
-			driftConfig = drift.DefaultConfig()
+		if bl == nil {
+			bl = current
 		}
 
 		calc := drift.NewCalculator(bl, current, driftConfig)
+		calc.SetPolicyInput(issues, &stats)
 		result := calc.Calculate()
 
 		if *robotDriftCheck {
@@ -2506,8 +2799,9 @@ func main() {
 					Warning  int `json:"warning"`
 					Info     int `json:"info"`
 				} `json:"summary"`
-				Alerts   []drift.Alert `json:"alerts"`
-				Baseline struct {
+				Alerts   []drift.Alert        `json:"alerts"`
+				Policies []drift.PolicyResult `json:"policies,omitempty"`
+				Baseline *struct {
 					CreatedAt string `json:"created_at"`
 					CommitSHA string `json:"commit_sha,omitempty"`
 				} `json:"baseline"`
@@ -2516,12 +2810,20 @@ func main() {
 				HasDrift:    result.HasDrift,
 				ExitCode:    result.ExitCode(),
 				Alerts:      result.Alerts,
+				Policies:    result.Policies,
 			}
 			output.Summary.Critical = result.CriticalCount
 			output.Summary.Warning = result.WarningCount
 			output.Summary.Info = result.InfoCount
-			output.Baseline.CreatedAt = bl.CreatedAt.Format(time.RFC3339)
-			output.Baseline.CommitSHA = bl.CommitSHA
+			if hasBaseline {
+				output.Baseline = &struct {
+					CreatedAt string `json:"created_at"`
+					CommitSHA string `json:"commit_sha,omitempty"`
+				}{
+					CreatedAt: bl.CreatedAt.Format(time.RFC3339),
+					CommitSHA: bl.CommitSHA,
+				}
+			}
 
 			encoder := newRobotEncoder(os.Stdout)
 			if err := encoder.Encode(output); err != nil {
@@ -2767,7 +3069,13 @@ func main() {
 	}
 
 	if *robotPriority {
+		_, profile, err := loadScoringProfile(*scoringProfile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading scoring profile: %v\n", err)
+			os.Exit(1)
+		}
 		analyzer := analysis.NewAnalyzer(issues)
+		analyzer.SetScoringProfile(profile)
 		cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
 		if *forceFullAnalysis {
 			cfg = analysis.FullAnalysisConfig()
@@ -2901,6 +3209,12 @@ func main() {
 	}
 
 	if *robotTriage || *robotNext || *robotTriageByTrack || *robotTriageByLabel {
+		_, profile, err := loadScoringProfile(*scoringProfile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading scoring profile: %v\n", err)
+			os.Exit(1)
+		}
+
 		// Attempt to load history for staleness analysis
 		// We use a best-effort approach here - if history isn't available or fails,
 		// we just proceed without staleness data.
@@ -2932,9 +3246,11 @@ func main() {
 							beadInfos := make([]correlation.BeadInfo, len(issues))
 							for i, issue := range issues {
 								beadInfos[i] = correlation.BeadInfo{
-									ID:     issue.ID,
-									Title:  issue.Title,
-									Status: string(issue.Status),
+									ID:                 issue.ID,
+									Title:              issue.Title,
+									Status:             string(issue.Status),
+									Description:        issue.Description,
+									AcceptanceCriteria: issue.AcceptanceCriteria,
 								}
 							}
 
@@ -2953,11 +3269,12 @@ func main() {
 
 		// bv-87: Support track/label-aware grouping for multi-agent coordination
 		opts := analysis.TriageOptions{
-			GroupByTrack:  *robotTriageByTrack,
-			GroupByLabel:  *robotTriageByLabel,
-			WaitForPhase2: true, // Triage needs full graph metrics
-			UseFastConfig: true, // Use minimal Phase 2 config for robot mode (bv-t1js)
-			History:       historyReport,
+			GroupByTrack:   *robotTriageByTrack,
+			GroupByLabel:   *robotTriageByLabel,
+			WaitForPhase2:  true, // Triage needs full graph metrics
+			UseFastConfig:  true, // Use minimal Phase 2 config for robot mode (bv-t1js)
+			History:        historyReport,
+			ScoringProfile: profile,
 		}
 		triage := analysis.ComputeTriageWithOptions(issues, opts)
 
@@ -3005,6 +3322,7 @@ func main() {
 				Unblocks   int      `json:"unblocks"`
 				ClaimCmd   string   `json:"claim_command"`
 				ShowCmd    string   `json:"show_command"`
+				Scoring    string   `json:"scoring_profile"`
 			}{
 				RobotEnvelope: envelope,
 				AsOf:          *asOf,
@@ -3016,6 +3334,7 @@ func main() {
 				Unblocks:      top.Unblocks,
 				ClaimCmd:      fmt.Sprintf("br update %s --status=in_progress", top.ID),
 				ShowCmd:       fmt.Sprintf("br show %s", top.ID),
+				Scoring:       triage.Meta.ScoringProfile,
 			}
 
 			encoder := newRobotEncoder(os.Stdout)
@@ -3303,9 +3622,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -3348,6 +3669,329 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-flow-metrics flag
+	if *robotFlowMetrics {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := correlation.CorrelatorOptions{Limit: *historyLimit}
+		if *historySince != "" {
+			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
+				os.Exit(1)
+			}
+			if !since.IsZero() {
+				opts.Since = &since
+			}
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
+			}
+		}
+
+		report, err := correlation.NewCorrelator(cwd, beadsPath).GenerateReport(beadInfos, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		flowOpts := correlation.DefaultFlowOptions()
+		flowOpts.Weeks = *flowWeeks
+		metrics := report.BuildFlowMetrics(issues, flowOpts)
+		metrics.DataHash = dataHash
+
+		type FlowMetricsEnvelope struct {
+			*correlation.FlowMetrics
+			OutputFormat string `json:"output_format,omitempty"`
+			Version      string `json:"version,omitempty"`
+		}
+		output := FlowMetricsEnvelope{
+			FlowMetrics:  metrics,
+			OutputFormat: robotOutputFormat,
+			Version:      version.Version,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding flow metrics: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-file-risk and --export-file-treemap flags
+	if *robotFileRisk || *exportFileTreemap != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := correlation.CorrelatorOptions{Limit: *historyLimit}
+		if *historySince != "" {
+			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
+				os.Exit(1)
+			}
+			if !since.IsZero() {
+				opts.Since = &since
+			}
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
+			}
+		}
+
+		report, err := correlation.NewCorrelator(cwd, beadsPath).GenerateReport(beadInfos, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		riskOpts := correlation.DefaultFileRiskOptions()
+		riskOpts.CouplingThreshold = *relationsThreshold
+
+		if *exportFileTreemap != "" {
+			// The treemap always covers every file; the limit only trims JSON
+			risk := report.BuildFileRisk(issues, riskOpts)
+			tree := correlation.BuildFileRiskTree(risk.Files)
+			err := export.SaveFileRiskTreemap(export.FileTreemapOptions{
+				Path:     *exportFileTreemap,
+				Title:    fmt.Sprintf("File Risk Treemap — %s", filepath.Base(cwd)),
+				Tree:     tree,
+				DataHash: dataHash,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting file treemap: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✓ File risk treemap exported to %s (%d files, %d critical)\n", *exportFileTreemap, risk.Stats.TotalFiles, risk.Stats.CriticalFiles)
+			os.Exit(0)
+		}
+
+		riskOpts.Limit = *fileRiskLimit
+		risk := report.BuildFileRisk(issues, riskOpts)
+		risk.DataHash = dataHash
+
+		type FileRiskEnvelope struct {
+			*correlation.FileRiskReport
+			OutputFormat string `json:"output_format,omitempty"`
+			Version      string `json:"version,omitempty"`
+		}
+		output := FileRiskEnvelope{
+			FileRiskReport: risk,
+			OutputFormat:   robotOutputFormat,
+			Version:        version.Version,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding file risk: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-blame flag
+	if *robotBlame != "" {
+		blamePath, startLine, endLine, err := correlation.ParseBlameTarget(*robotBlame)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --robot-blame: %v\n", err)
+			os.Exit(1)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := correlation.CorrelatorOptions{Limit: *historyLimit}
+		if *historySince != "" {
+			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
+				os.Exit(1)
+			}
+			if !since.IsZero() {
+				opts.Since = &since
+			}
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
+			}
+		}
+
+		report, err := correlation.NewCorrelator(cwd, beadsPath).GenerateReport(beadInfos, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		blame, err := report.Blame(cwd, correlation.BlameOptions{
+			Path:      blamePath,
+			StartLine: startLine,
+			EndLine:   endLine,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		blame.DataHash = dataHash
+
+		type BlameEnvelope struct {
+			*correlation.BlameReport
+			OutputFormat string `json:"output_format,omitempty"`
+			Version      string `json:"version,omitempty"`
+		}
+		output := BlameEnvelope{
+			BlameReport:  blame,
+			OutputFormat: robotOutputFormat,
+			Version:      version.Version,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding blame: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-cfd flag
+	if *robotCFD {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		var opts correlation.CFDOptions
+		if *historySince != "" {
+			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
+				os.Exit(1)
+			}
+			opts.Since = since
+		}
+
+		flow, err := correlation.NewExtractor(cwd, beadsPath).CumulativeFlow(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error building cumulative flow: %v\n", err)
+			os.Exit(1)
+		}
+		flow.DataHash = dataHash
+
+		type CFDEnvelope struct {
+			*correlation.CumulativeFlow
+			OutputFormat string `json:"output_format,omitempty"`
+			Version      string `json:"version,omitempty"`
+		}
+		output := CFDEnvelope{
+			CumulativeFlow: flow,
+			OutputFormat:   robotOutputFormat,
+			Version:        version.Version,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding cumulative flow: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle correlation audit commands (bv-e1u6)
 	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats {
 		beadsDir, err := loader.GetBeadsDir("")
@@ -3406,14 +4050,16 @@ func main() {
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
 				beadInfos[i] = correlation.BeadInfo{
-					ID:     issue.ID,
-					Title:  issue.Title,
-					Status: string(issue.Status),
+					ID:                 issue.ID,
+					Title:              issue.Title,
+					Status:             string(issue.Status),
+					Description:        issue.Description,
+					AcceptanceCriteria: issue.AcceptanceCriteria,
 				}
 			}
 
-			opts := correlation.CorrelatorOptions{BeadID: beadID}
-			report, err := correlator.GenerateReport(beadInfos, opts)
+			// No bead filter: splitting a shared commit needs every bead it updated
+			report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
 				os.Exit(1)
@@ -3484,7 +4130,7 @@ func main() {
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
-				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Description: issue.Description, AcceptanceCriteria: issue.AcceptanceCriteria}
 			}
 
 			opts := correlation.CorrelatorOptions{BeadID: beadID}
@@ -3554,7 +4200,7 @@ func main() {
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
-				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Description: issue.Description, AcceptanceCriteria: issue.AcceptanceCriteria}
 			}
 
 			opts := correlation.CorrelatorOptions{BeadID: beadID}
@@ -3627,9 +4273,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -3725,9 +4373,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -3825,9 +4475,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -3894,6 +4546,9 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			os.Exit(1)
 		}
+		if whereQuery != nil {
+			issues = applyWhereFilter(issues, whereQuery)
+		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
@@ -3909,9 +4564,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -3969,6 +4626,9 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			os.Exit(1)
 		}
+		if whereQuery != nil {
+			issues = applyWhereFilter(issues, whereQuery)
+		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
@@ -3984,9 +4644,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -4058,6 +4720,9 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			os.Exit(1)
 		}
+		if whereQuery != nil {
+			issues = applyWhereFilter(issues, whereQuery)
+		}
 
 		an := analysis.NewAnalyzer(issues)
 		result := an.GetBlockerChain(*robotBlockerChain)
@@ -4115,14 +4780,19 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			os.Exit(1)
 		}
+		if whereQuery != nil {
+			issues = applyWhereFilter(issues, whereQuery)
+		}
 
 		// Convert to BeadInfo slice
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -4195,6 +4865,9 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			os.Exit(1)
 		}
+		if whereQuery != nil {
+			issues = applyWhereFilter(issues, whereQuery)
+		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
@@ -4210,9 +4883,11 @@ func main() {
 		beadInfos := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beadInfos[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -4457,11 +5132,12 @@ func main() {
 		}
 		type ForecastOutput struct {
 			RobotEnvelope
-			Agents        int                    `json:"agents"`
-			Filters       map[string]string      `json:"filters,omitempty"`
-			ForecastCount int                    `json:"forecast_count"`
-			Forecasts     []analysis.ETAEstimate `json:"forecasts"`
-			Summary       *ForecastSummary       `json:"summary,omitempty"`
+			Agents        int                          `json:"agents"`
+			Filters       map[string]string            `json:"filters,omitempty"`
+			ForecastCount int                          `json:"forecast_count"`
+			Forecasts     []analysis.ETAEstimate       `json:"forecasts"`
+			Summary       *ForecastSummary             `json:"summary,omitempty"`
+			MonteCarlo    *analysis.MonteCarloForecast `json:"monte_carlo,omitempty"`
 		}
 
 		var forecasts []analysis.ETAEstimate
@@ -4515,6 +5191,41 @@ func main() {
 			}
 		}
 
+		// Probabilistic completion dates from the dependency DAG and historical throughput
+		var monteCarlo *analysis.MonteCarloForecast
+		if *forecastTrials > 0 {
+			target := "all"
+			var targetIDs []string
+			switch {
+			case *robotForecast != "all":
+				target = "bead:" + *robotForecast
+				targetIDs = []string{*robotForecast}
+			default:
+				if *forecastSprint != "" {
+					target = "sprint:" + *forecastSprint
+				} else if *forecastLabel != "" {
+					target = "label:" + *forecastLabel
+				}
+				for _, iss := range targetIssues {
+					if iss.Status != model.StatusClosed {
+						targetIDs = append(targetIDs, iss.ID)
+					}
+				}
+			}
+			mc, err := analysis.ForecastMonteCarlo(issues, target, targetIDs, analysis.MonteCarloOptions{
+				Trials: *forecastTrials,
+				Seed:   *forecastSeed,
+				Agents: agents,
+				Label:  *forecastLabel,
+				Now:    now,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			monteCarlo = mc
+		}
+
 		// Build output
 		filters := make(map[string]string)
 		if *forecastLabel != "" {
@@ -4530,6 +5241,7 @@ func main() {
 			ForecastCount: len(forecasts),
 			Forecasts:     forecasts,
 			Summary:       summary,
+			MonteCarlo:    monteCarlo,
 		}
 		if len(filters) > 0 {
 			output.Filters = filters
@@ -4748,6 +5460,102 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-schedule flag: resource-constrained list schedule + Mermaid gantt
+	if *robotSchedule {
+		var assignees []analysis.ScheduleAgent
+		if *scheduleAssignees != "" {
+			parsed, err := analysis.ParseScheduleAssignees(*scheduleAssignees)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --schedule-assignees: %v\n", err)
+				os.Exit(1)
+			}
+			assignees = parsed
+		}
+
+		analyzer := analysis.NewAnalyzer(issues)
+		schedule := analyzer.GetSchedule(analysis.ScheduleOptions{
+			Agents:      *capacityAgents,
+			Assignees:   assignees,
+			HoursPerDay: *scheduleHours,
+			Start:       time.Now(),
+		})
+
+		type ScheduleOutput struct {
+			RobotEnvelope
+			analysis.Schedule
+			Gantt string `json:"gantt"`
+		}
+		output := ScheduleOutput{
+			RobotEnvelope: NewRobotEnvelope(analysis.ComputeDataHash(issues)),
+			Schedule:      schedule,
+			Gantt:         export.GenerateMermaidGantt(schedule, export.GanttConfig{}),
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding schedule: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-cycle-fix flag: feedback arc set + triage impact per cut
+	if *robotCycleFix {
+		opts := analysis.DefaultCycleFixOptions()
+		opts.MaxCandidates = *cycleFixCandidates
+		plan := analysis.GenerateCycleFix(issues, opts)
+
+		type CycleFixOutput struct {
+			RobotEnvelope
+			*analysis.CycleFixPlan
+		}
+		output := CycleFixOutput{
+			RobotEnvelope: NewRobotEnvelope(analysis.ComputeDataHash(issues)),
+			CycleFixPlan:  plan,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding cycle fix plan: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-scenario flag: compound what-if against a cloned issue set
+	if *robotScenario != "" {
+		sc, err := scenario.Load(*robotScenario)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading scenario: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := scenario.Compare(sc, issues, scenario.Options{
+			Agents: *forecastAgents,
+			Trials: *forecastTrials,
+			Seed:   *forecastSeed,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error applying scenario: %v\n", err)
+			os.Exit(1)
+		}
+
+		type ScenarioOutput struct {
+			RobotEnvelope
+			*scenario.Result
+		}
+		output := ScenarioOutput{
+			RobotEnvelope: NewRobotEnvelope(analysis.ComputeDataHash(issues)),
+			Result:        result,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding scenario: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-metrics flag (bv-84tp)
 	if *robotMetrics {
 		output := metrics.GetAllMetrics()
@@ -4836,6 +5644,7 @@ func main() {
 		// Launch TUI with historical issues (already loaded, no live reload)
 		m := ui.NewModel(issues, activeRecipe, "")
 		defer m.Stop()
+		configureTUIScoring(&m, *scoringProfile)
 		if err := runTUIProgram(m); err != nil {
 			fmt.Printf("Error running beads viewer: %v\n", err)
 			os.Exit(1)
@@ -4928,15 +5737,69 @@ func main() {
 	// Initial Model with live reload support
 	m := ui.NewModel(issues, activeRecipe, beadsPath)
 	defer m.Stop() // Clean up file watcher
+	configureTUIScoring(&m, *scoringProfile)
+
+	// Live reload for --beads-url: poll the daemon with the same filter
+	if beadsFilter != nil {
+		updates := make(chan []model.Issue, 1)
+		poller := datasource.NewHTTPPoller(datasource.NewHTTPSource(*beadsURL, *beadsAPIKey), nil, datasource.HTTPPollerOptions{
+			Filter: *beadsFilter,
+			OnUpdate: func(u datasource.HTTPUpdate) {
+				synced := u.Issues
+				if *repoFilter != "" {
+					synced = filterByRepo(synced, *repoFilter)
+				}
+				if whereQuery != nil {
+					synced = applyWhereFilter(synced, whereQuery)
+				}
+				// Only the latest set matters if the TUI hasn't caught up
+				select {
+				case <-updates:
+				default:
+				}
+				updates <- synced
+			},
+		})
+		poller.Seed(beadsLoaded)
+		poller.Start()
+		defer poller.Stop()
+		m.EnableRemoteSync(updates)
+	}
+
+	// Lifecycle hooks fire on live reloads, as under bv serve. Output would
+	// corrupt the screen, so hook failures go to the debug log.
+	if !*noHooks {
+		if monitor := loadEventHooks(issuesForSearch, func(msg string) { debug.Log("hooks: %s", msg) }); monitor != nil {
+			reloads := make(chan []model.Issue, 1)
+			go func() {
+				for reloaded := range reloads {
+					monitor.Observe(reloaded)
+				}
+			}()
+			defer close(reloads)
+			m.SetReloadObserver(func(reloaded []model.Issue) {
+				// Hooks run one load at a time; a newer load replaces one still queued
+				select {
+				case <-reloads:
+				default:
+				}
+				reloads <- reloaded
+			})
+		}
+	}
 
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
+		// Already parsed once by the loader, so an error here is unexpected;
+		// without the config the graph view just has no repo lanes
+		wsConfig, _ := workspace.LoadConfig(*workspaceConfig)
 		m.EnableWorkspaceMode(ui.WorkspaceInfo{
 			Enabled:      true,
 			RepoCount:    workspaceInfo.TotalRepos,
 			FailedCount:  workspaceInfo.FailedRepos,
 			TotalIssues:  workspaceInfo.TotalIssues,
 			RepoPrefixes: workspaceInfo.RepoPrefixes,
+			Config:       wsConfig,
 		})
 	}
 
@@ -4954,6 +5817,41 @@ func main() {
 	}
 }
 
+// loadScoringProfile loads .bv/scoring.yaml from the working directory and
+// resolves the named profile ("" selects the config's default).
+func loadScoringProfile(name string) (*analysis.ScoringConfig, *analysis.ScoringProfile, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := analysis.LoadScoringConfig(cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, profile, nil
+}
+
+// configureTUIScoring installs the scoring profiles so % can switch them
+// live. An explicit --scoring-profile that cannot be resolved is fatal; a
+// broken scoring.yaml otherwise only costs the feature.
+func configureTUIScoring(m *ui.Model, name string) {
+	cfg, _, err := loadScoringProfile(name)
+	if err == nil {
+		err = m.SetScoringProfiles(cfg, name)
+	}
+	if err != nil {
+		if name != "" {
+			fmt.Fprintf(os.Stderr, "Error loading scoring profile: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: scoring profiles disabled: %v\n", err)
+	}
+}
+
 func runTUIProgram(m ui.Model) error {
 	p := tea.NewProgram(
 		m,
@@ -5028,7 +5926,7 @@ func countEdges(issues []model.Issue) int {
 	count := 0
 	for _, issue := range issues {
 		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepBlocks {
+			if dep != nil && dep.Type.IsBlocking() {
 				count++
 			}
 		}
@@ -5242,6 +6140,14 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	f := r.Filters
 	now := time.Now()
 
+	// Where expressions were validated by the recipe loader; an invalid one
+	// here (e.g. from a hand-built recipe) is ignored like other bad filters.
+	where, _ := f.WhereQuery()
+	var whereEnv *query.Env
+	if where != nil {
+		whereEnv = newQueryEnv(issues, where, now)
+	}
+
 	// Build a set of open blocker IDs for actionable filtering
 	openBlockers := make(map[string]bool)
 	for _, issue := range issues {
@@ -5356,7 +6262,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		if f.HasBlockers != nil {
 			hasOpenBlockers := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+				if dep.Type.IsBlocking() && openBlockers[dep.DependsOnID] {
 					hasOpenBlockers = true
 					break
 				}
@@ -5370,7 +6276,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		if f.Actionable != nil && *f.Actionable {
 			hasOpenBlockers := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+				if dep.Type.IsBlocking() && openBlockers[dep.DependsOnID] {
 					hasOpenBlockers = true
 					break
 				}
@@ -5394,12 +6300,34 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 			}
 		}
 
+		// Where expression
+		if where != nil && !where.Match(&issue, whereEnv) {
+			continue
+		}
+
 		result = append(result, issue)
 	}
 
 	return result
 }
 
+// applyWhereFilter keeps the issues matching a --where query.
+func applyWhereFilter(issues []model.Issue, q *query.Query) []model.Issue {
+	return q.Filter(issues, newQueryEnv(issues, q, time.Now()))
+}
+
+// newQueryEnv builds the evaluation environment for q. Graph metrics are
+// only computed when the query references them, and always over the
+// unfiltered set so that filtering doesn't shift the scores it filters on.
+func newQueryEnv(issues []model.Issue, q *query.Query, now time.Time) *query.Env {
+	var stats *analysis.GraphStats
+	if q.UsesGraphMetrics() {
+		s := analysis.NewAnalyzer(issues).Analyze()
+		stats = &s
+	}
+	return query.NewEnv(issues, stats, now)
+}
+
 // applyRecipeSort sorts issues based on recipe configuration
 func applyRecipeSort(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	if r == nil || r.Sort.Field == "" {
@@ -6414,6 +7342,17 @@ func runPagesWizard(beadsPath string) error {
 		} else if err != nil {
 			fmt.Printf("  -> Warning: failed to generate history: %v\n", err)
 		}
+		if flow, err := generateCFDForExport(); err == nil {
+			if cfdJSON, err := json.MarshalIndent(flow, "", "  "); err == nil {
+				if err := os.WriteFile(filepath.Join(bundlePath, "data", "cfd.json"), cfdJSON, 0644); err != nil {
+					fmt.Printf("  -> Warning: failed to write cfd.json: %v\n", err)
+				} else {
+					fmt.Printf("  -> cfd.json (%d days)\n", len(flow.Days))
+				}
+			}
+		} else {
+			fmt.Printf("  -> Warning: failed to generate cumulative flow: %v\n", err)
+		}
 	}
 
 	fmt.Printf("  -> Bundle created: %s\n", bundlePath)
@@ -7058,6 +7997,17 @@ func parseSprintJSONLine(line string) (sprintSnapshot, bool) {
 	return snap, true
 }
 
+// splitCommaList splits a comma-separated flag value, dropping empty entries.
+func splitCommaList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
 func setDifference(a, b []string) []string {
 	if len(a) == 0 {
 		return nil
@@ -7358,9 +8308,11 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	beadInfos := make([]correlation.BeadInfo, len(issues))
 	for i, issue := range issues {
 		beadInfos[i] = correlation.BeadInfo{
-			ID:     issue.ID,
-			Title:  issue.Title,
-			Status: string(issue.Status),
+			ID:                 issue.ID,
+			Title:              issue.Title,
+			Status:             string(issue.Status),
+			Description:        issue.Description,
+			AcceptanceCriteria: issue.AcceptanceCriteria,
 		}
 	}
 
@@ -7429,6 +8381,27 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	}, nil
 }
 
+// generateCFDForExport reconstructs the default cumulative flow window for
+// the static viewer's charts dashboard
+func generateCFDForExport() (*correlation.CumulativeFlow, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := correlation.ValidateRepository(cwd); err != nil {
+		return nil, err
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil, err
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	return correlation.NewExtractor(cwd, beadsPath).CumulativeFlow(correlation.CFDOptions{})
+}
+
 var robotOutputFormat = "json"
 var robotToonEncodeOptions = toon.DefaultEncodeOptions()
 var robotShowToonStats bool
@@ -7567,7 +8540,7 @@ func estimateTokens(s string) int {
 }
 
 // generateRobotDocs returns machine-readable documentation for AI agents (bd-2v50).
-// Topics: guide, commands, examples, env, exit-codes, all.
+// Topics: guide, commands, examples, env, exit-codes, query, all.
 func generateRobotDocs(topic string) map[string]interface{} {
 	now := time.Now().UTC().Format(time.RFC3339)
 	result := map[string]interface{}{
@@ -7605,12 +8578,14 @@ func generateRobotDocs(topic string) map[string]interface{} {
 	commands := map[string]cmdDoc{
 		"robot-triage": {
 			Flag: "--robot-triage", Description: "Unified triage: top picks, recommendations, quick wins, blockers, project health, velocity.",
-			KeyFields:   []string{"triage.quick_ref.top_picks", "triage.recommendations", "triage.quick_wins", "triage.blockers_to_clear", "triage.project_health"},
+			Params:      []string{"--scoring-profile <name>"},
+			KeyFields:   []string{"triage.meta.scoring_profile", "triage.quick_ref.top_picks", "triage.recommendations", "triage.quick_wins", "triage.blockers_to_clear", "triage.project_health"},
 			NeedsIssues: true,
 		},
 		"robot-next": {
 			Flag: "--robot-next", Description: "Single top recommendation with claim/show commands.",
-			KeyFields:   []string{"id", "title", "score", "reasons", "unblocks", "claim_command", "show_command"},
+			Params:      []string{"--scoring-profile <name>"},
+			KeyFields:   []string{"id", "title", "score", "reasons", "unblocks", "claim_command", "show_command", "scoring_profile"},
 			NeedsIssues: true,
 		},
 		"robot-plan": {
@@ -7645,8 +8620,8 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			NeedsIssues: true,
 		},
 		"robot-suggest": {
-			Flag: "--robot-suggest", Description: "Smart suggestions: potential duplicates, missing dependencies, label assignments, cycle warnings.",
-			KeyFields:   []string{"suggestions", "type", "confidence"},
+			Flag: "--robot-suggest", Description: "Smart suggestions: potential duplicates (keyword and embedding similarity), missing dependencies, label assignments, cycle warnings.",
+			KeyFields:   []string{"suggestions", "type", "confidence", "metadata.method"},
 			Params:      []string{"--suggest-type duplicate|dependency|label|cycle", "--suggest-confidence 0.0-1.0", "--suggest-bead <id>"},
 			NeedsIssues: true,
 		},
@@ -7657,7 +8632,7 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			NeedsIssues: false,
 		},
 		"robot-docs": {
-			Flag: "--robot-docs <topic>", Description: "Machine-readable JSON documentation. Topics: guide, commands, examples, env, exit-codes, all.",
+			Flag: "--robot-docs <topic>", Description: "Machine-readable JSON documentation. Topics: guide, commands, examples, env, exit-codes, query, all.",
 			NeedsIssues: false,
 		},
 		"robot-history": {
@@ -7672,8 +8647,8 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			NeedsIssues: true,
 		},
 		"robot-search": {
-			Flag: "--robot-search", Description: "Semantic vector search over issue titles and descriptions.",
-			Params:      []string{"--search <query>", "--search-limit <n>", "--search-mode text|hybrid"},
+			Flag: "--robot-search", Description: "Semantic vector search; --search-lexical-weight > 0 blends in BM25 keyword scoring (phrases, prefix*, typo tolerance).",
+			Params:      []string{"--search <query>", "--search-limit <n>", "--search-mode text|hybrid", "--search-lexical-weight <0-1>"},
 			NeedsIssues: true,
 		},
 		"robot-label-health": {
@@ -7684,6 +8659,11 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Flag: "--robot-label-flow", Description: "Cross-label dependency flow analysis.",
 			NeedsIssues: true,
 		},
+		"robot-workspace-triage": {
+			Flag: "--robot-workspace-triage", Description: "Multi-repo workspace analysis: per-repo health, repo blocking matrix, cross-repo critical paths and dangling cross-repo references.",
+			Params:      []string{"--workspace <path>"},
+			NeedsIssues: true,
+		},
 		"robot-label-attention": {
 			Flag: "--robot-label-attention", Description: "Attention-ranked labels requiring focus.",
 			Params:      []string{"--attention-limit <n>"},
@@ -7736,6 +8716,30 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Flag: "--robot-causality <id>", Description: "Causal chain analysis for a bead.",
 			NeedsIssues: true,
 		},
+		"robot-flow-metrics": {
+			Flag: "--robot-flow-metrics", Description: "Lead/cycle time percentiles, weekly throughput, flow efficiency and aging WIP by label, type and assignee.",
+			KeyFields:   []string{"overall", "by_label", "by_type", "by_assignee", "aging_wip"},
+			Params:      []string{"--flow-weeks <n>", "--history-since <date>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-blame": {
+			Flag: "--robot-blame <file>[:<start>-<end>]", Description: "Line-level attribution: the beads behind each range of lines, from git blame joined with the history commit index.",
+			KeyFields:   []string{"ranges", "beads", "stats"},
+			Params:      []string{"--history-since <date>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-file-risk": {
+			Flag: "--robot-file-risk", Description: "Per-file risk from bug-bead density, reopens, churn and co-change coupling, rolled up by directory.",
+			KeyFields:   []string{"files", "directories", "stats"},
+			Params:      []string{"--file-risk-limit <n>", "--relations-threshold <0.0-1.0>", "--history-since <date>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-cfd": {
+			Flag: "--robot-cfd", Description: "Cumulative flow diagram: daily bead counts per status, replayed from the beads file's git history.",
+			KeyFields:   []string{"statuses", "days"},
+			Params:      []string{"--history-since <date>"},
+			NeedsIssues: true,
+		},
 		"robot-sprint-list": {
 			Flag: "--robot-sprint-list", Description: "List all sprints as JSON.",
 			NeedsIssues: true,
@@ -7746,7 +8750,7 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		},
 		"robot-forecast": {
 			Flag: "--robot-forecast <id|all>", Description: "ETA predictions for bead completion.",
-			Params:      []string{"--forecast-label <label>", "--forecast-sprint <id>", "--forecast-agents <n>"},
+			Params:      []string{"--forecast-label <label>", "--forecast-sprint <id>", "--forecast-agents <n>", "--forecast-trials <n>", "--forecast-seed <n>"},
 			NeedsIssues: true,
 		},
 		"robot-capacity": {
@@ -7754,12 +8758,27 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Params:      []string{"--agents <n>", "--capacity-label <label>"},
 			NeedsIssues: true,
 		},
+		"robot-schedule": {
+			Flag: "--robot-schedule", Description: "Resource-constrained multi-agent schedule with Mermaid gantt.",
+			Params:      []string{"--agents <n>", "--schedule-assignees <name=cap,...>", "--schedule-hours <h>"},
+			NeedsIssues: true,
+		},
+		"robot-cycle-fix": {
+			Flag: "--robot-cycle-fix", Description: "Cycle-break plan per component with triage impact and bd dep remove commands.",
+			Params:      []string{"--cycle-fix-candidates <n>"},
+			NeedsIssues: true,
+		},
+		"robot-scenario": {
+			Flag: "--robot-scenario <file>", Description: "Apply hypothetical mutations and diff triage, plan and forecast against the baseline.",
+			Params:      []string{"--forecast-agents <n>", "--forecast-trials <n>", "--forecast-seed <n>"},
+			NeedsIssues: true,
+		},
 		"robot-burndown": {
 			Flag: "--robot-burndown <sprint|current>", Description: "Sprint burndown data.",
 			NeedsIssues: true,
 		},
 		"robot-drift": {
-			Flag: "--robot-drift", Description: "Drift detection from saved baseline.",
+			Flag: "--robot-drift", Description: "Drift detection from saved baseline, plus policy rule results from .bv/drift.yaml.",
 			NeedsIssues: true,
 		},
 	}
@@ -7775,6 +8794,17 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		{"description": "Get TOON output (saves tokens)", "command": "bv --robot-triage --format toon"},
 		{"description": "Use env for default format", "command": "BV_OUTPUT_FORMAT=toon bv --robot-triage"},
 		{"description": "Show token savings estimate", "command": "bv --robot-triage --format toon --stats"},
+		{"description": "Triage only hot API work", "command": "bv --robot-triage --where 'label:api AND (priority<=1 OR blocks>3) AND updated<14d'"},
+	}
+
+	queryDocs := map[string]interface{}{
+		"flag":       "--where <expr>",
+		"applies":    "Every --robot-* command, the TUI / filter bar, and recipe filters.where",
+		"operators":  []string{": (equals, * wildcard)", "= (equals)", "!= (not equal)", "~ (contains)", "< <= > >= (numbers and dates)"},
+		"boolean":    "AND, OR, NOT (or &&, ||, !) with parentheses; adjacent terms are ANDed",
+		"values":     "Comma lists match any value (label:api,ui); quote values with spaces; dates are 2025-01-31 or ages (14d, 2w, 3m, 1y) where updated<14d means within 14 days",
+		"bare_words": "Match ID, title and description",
+		"fields":     query.Fields(),
 	}
 
 	envVars := map[string]string{
@@ -7806,15 +8836,18 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		result["environment_variables"] = envVars
 	case "exit-codes":
 		result["exit_codes"] = exitCodes
+	case "query":
+		result["query"] = queryDocs
 	case "all":
 		result["guide"] = guide
 		result["commands"] = commands
 		result["examples"] = examples
 		result["environment_variables"] = envVars
 		result["exit_codes"] = exitCodes
+		result["query"] = queryDocs
 	default:
 		result["error"] = "Unknown topic: " + topic
-		result["available_topics"] = []string{"guide", "commands", "examples", "env", "exit-codes", "all"}
+		result["available_topics"] = []string{"guide", "commands", "examples", "env", "exit-codes", "query", "all"}
 	}
 
 	return result
@@ -7873,10 +8906,11 @@ func generateRobotSchemas() RobotSchemas {
 						"meta": map[string]interface{}{
 							"type": "object",
 							"properties": map[string]interface{}{
-								"version":      map[string]interface{}{"type": "string"},
-								"generated_at": map[string]interface{}{"type": "string"},
-								"phase2_ready": map[string]interface{}{"type": "boolean"},
-								"issue_count":  map[string]interface{}{"type": "integer"},
+								"version":         map[string]interface{}{"type": "string"},
+								"generated_at":    map[string]interface{}{"type": "string"},
+								"phase2_ready":    map[string]interface{}{"type": "boolean"},
+								"issue_count":     map[string]interface{}{"type": "integer"},
+								"scoring_profile": map[string]interface{}{"type": "string"},
 							},
 						},
 						"quick_ref": map[string]interface{}{
@@ -8074,6 +9108,57 @@ func generateRobotSchemas() RobotSchemas {
 				"at_risk":       map[string]interface{}{"type": "array"},
 			},
 		},
+		"robot-schedule": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Schedule Output",
+			"description": "Resource-constrained list schedule of open beads with Mermaid gantt chart",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":     map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":        map[string]interface{}{"type": "string"},
+				"start":            map[string]interface{}{"type": "string", "format": "date-time"},
+				"end":              map[string]interface{}{"type": "string", "format": "date-time"},
+				"makespan_minutes": map[string]interface{}{"type": "number"},
+				"makespan_days":    map[string]interface{}{"type": "number"},
+				"items":            map[string]interface{}{"type": "array"},
+				"agents":           map[string]interface{}{"type": "array"},
+				"critical_path":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"gantt":            map[string]interface{}{"type": "string"},
+			},
+		},
+		"robot-cycle-fix": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Cycle Fix Output",
+			"description": "Minimal feedback arc set per cyclic component with simulated triage impact",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":    map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":       map[string]interface{}{"type": "string"},
+				"status":          map[string]interface{}{"type": "object"},
+				"component_count": map[string]interface{}{"type": "integer"},
+				"components":      map[string]interface{}{"type": "array"},
+				"before":          map[string]interface{}{"type": "object"},
+				"after":           map[string]interface{}{"type": "object"},
+				"delta":           map[string]interface{}{"type": "object"},
+				"commands":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+		},
+		"robot-scenario": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Scenario Output",
+			"description": "What-if comparison of triage, execution plan and forecast between baseline and a mutated issue set",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":    map[string]interface{}{"type": "string"},
+				"scenario":     map[string]interface{}{"type": "string"},
+				"mutations":    map[string]interface{}{"type": "array"},
+				"diff":         map[string]interface{}{"type": "object"},
+				"triage":       map[string]interface{}{"type": "object"},
+				"plan":         map[string]interface{}{"type": "object"},
+				"forecast":     map[string]interface{}{"type": "object"},
+			},
+		},
 		"robot-forecast": {
 			"$schema":     "https://json-schema.org/draft/2020-12/schema",
 			"title":       "Robot Forecast Output",
@@ -8084,7 +9169,208 @@ func generateRobotSchemas() RobotSchemas {
 				"data_hash":    map[string]interface{}{"type": "string"},
 				"forecasts":    map[string]interface{}{"type": "array"},
 				"methodology":  map[string]interface{}{"type": "object"},
+				"monte_carlo": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"target":             map[string]interface{}{"type": "string"},
+						"trials":             map[string]interface{}{"type": "integer"},
+						"seed":               map[string]interface{}{"type": "integer"},
+						"p50":                map[string]interface{}{"type": "object"},
+						"p85":                map[string]interface{}{"type": "object"},
+						"p95":                map[string]interface{}{"type": "object"},
+						"histogram":          map[string]interface{}{"type": "array"},
+						"throughput_samples": map[string]interface{}{"type": "array"},
+					},
+				},
+			},
+		},
+		"robot-flow-metrics": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Flow Metrics Output",
+			"description": "Lead time, cycle time, throughput and flow efficiency by label, type and assignee, plus aging WIP",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":         map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":            map[string]interface{}{"type": "string"},
+				"weeks":                map[string]interface{}{"type": "integer"},
+				"overall":              map[string]interface{}{"type": "object"},
+				"by_label":             map[string]interface{}{"type": "array"},
+				"by_type":              map[string]interface{}{"type": "array"},
+				"by_assignee":          map[string]interface{}{"type": "array"},
+				"samples":              map[string]interface{}{"type": "array"},
+				"aging_threshold_days": map[string]interface{}{"type": "number"},
+				"aging_wip":            map[string]interface{}{"type": "array"},
+			},
+		},
+		"robot-blame": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Blame Output",
+			"description": "Beads behind each range of lines in a file, from git blame joined with commit correlations",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":    map[string]interface{}{"type": "string"},
+				"file_path":    map[string]interface{}{"type": "string"},
+				"start_line":   map[string]interface{}{"type": "integer"},
+				"end_line":     map[string]interface{}{"type": "integer"},
+				"stats":        map[string]interface{}{"type": "object"},
+				"ranges":       map[string]interface{}{"type": "array"},
+				"beads":        map[string]interface{}{"type": "array"},
+			},
+		},
+		"robot-workspace-triage": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Workspace Triage Output",
+			"description": "Workspace-aware analysis of a multi-repo workspace: per-repo health, repo-to-repo blocking matrix, cross-repo critical paths and dangling cross-repo references",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":    map[string]interface{}{"type": "string"},
+				"workspace": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+						"workspace":    map[string]interface{}{"type": "string"},
+						"summary": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"repo_count":       map[string]interface{}{"type": "integer"},
+								"failed_repos":     map[string]interface{}{"type": "integer"},
+								"issue_count":      map[string]interface{}{"type": "integer"},
+								"open_count":       map[string]interface{}{"type": "integer"},
+								"cross_repo_deps":  map[string]interface{}{"type": "integer"},
+								"dangling_refs":    map[string]interface{}{"type": "integer"},
+								"missing_repos":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+								"attention_needed": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+							},
+						},
+						"repos": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"repo":                   map[string]interface{}{"type": "string"},
+									"prefix":                 map[string]interface{}{"type": "string"},
+									"load_error":             map[string]interface{}{"type": "string"},
+									"open_count":             map[string]interface{}{"type": "integer"},
+									"blocked_count":          map[string]interface{}{"type": "integer"},
+									"actionable_count":       map[string]interface{}{"type": "integer"},
+									"health":                 map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 100},
+									"health_level":           map[string]interface{}{"type": "string", "enum": []string{"healthy", "warning", "critical"}},
+									"blocked_by_other_repos": map[string]interface{}{"type": "integer"},
+									"blocking_other_repos":   map[string]interface{}{"type": "integer"},
+									"upstream_repos":         map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+									"downstream_repos":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+								},
+							},
+						},
+						"flow": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"repos":                 map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+								"blocking_matrix":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}}},
+								"dependencies":          map[string]interface{}{"type": "array"},
+								"bottleneck_repos":      map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+								"total_cross_repo_deps": map[string]interface{}{"type": "integer"},
+							},
+						},
+						"cross_repo_paths": map[string]interface{}{"type": "array"},
+						"dangling_refs":    map[string]interface{}{"type": "array"},
+					},
+				},
+				"usage_hints": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+			"required": []string{"generated_at", "data_hash", "workspace"},
+		},
+		"robot-file-risk": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot File Risk Output",
+			"description": "Combined per-file risk score from bug density, reopens, churn and coupling, with directory rollups",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":       map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":          map[string]interface{}{"type": "string"},
+				"weights":            map[string]interface{}{"type": "object"},
+				"coupling_threshold": map[string]interface{}{"type": "number"},
+				"stats":              map[string]interface{}{"type": "object"},
+				"files":              map[string]interface{}{"type": "array"},
+				"directories":        map[string]interface{}{"type": "array"},
+			},
+		},
+		"robot-cfd": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot CFD Output",
+			"description": "Cumulative flow diagram: beads per status at the end of each day",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":     map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":        map[string]interface{}{"type": "string"},
+				"since":            map[string]interface{}{"type": "string", "format": "date"},
+				"until":            map[string]interface{}{"type": "string", "format": "date"},
+				"statuses":         map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"days":             map[string]interface{}{"type": "array"},
+				"baseline_commit":  map[string]interface{}{"type": "string"},
+				"commits_replayed": map[string]interface{}{"type": "integer"},
+			},
+		},
+		"robot-blocker-chain": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Blocker Chain Output",
+			"description": "Chain of open blockers in front of an issue, down to the roots that can be worked on now",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":    map[string]interface{}{"type": "string"},
+				"result": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"target_id":     map[string]interface{}{"type": "string"},
+						"target_title":  map[string]interface{}{"type": "string"},
+						"is_blocked":    map[string]interface{}{"type": "boolean"},
+						"chain_length":  map[string]interface{}{"type": "integer"},
+						"root_blockers": map[string]interface{}{"type": "array"},
+						"chain":         map[string]interface{}{"type": "array"},
+						"has_cycle":     map[string]interface{}{"type": "boolean"},
+						"cycle_ids":     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					},
+				},
+			},
+			"required": []string{"generated_at", "data_hash", "result"},
+		},
+		"robot-impact": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Impact Output",
+			"description": "Beads that touched the given files and the risk of modifying them",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":   map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":      map[string]interface{}{"type": "string"},
+				"files":          map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"risk_level":     map[string]interface{}{"type": "string"},
+				"risk_score":     map[string]interface{}{"type": "number"},
+				"summary":        map[string]interface{}{"type": "string"},
+				"warnings":       map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"affected_beads": map[string]interface{}{"type": "array"},
+			},
+			"required": []string{"generated_at", "data_hash", "risk_level"},
+		},
+		"robot-related": {
+			"$schema":     "https://json-schema.org/draft/2020-12/schema",
+			"title":       "Robot Related Output",
+			"description": "Beads related to a bead through shared files, shared commits, dependencies or concurrent work",
+			"type":        "object",
+			"properties": map[string]interface{}{
+				"generated_at":       map[string]interface{}{"type": "string", "format": "date-time"},
+				"data_hash":          map[string]interface{}{"type": "string"},
+				"target_bead_id":     map[string]interface{}{"type": "string"},
+				"target_title":       map[string]interface{}{"type": "string"},
+				"file_overlap":       map[string]interface{}{"type": "array"},
+				"commit_overlap":     map[string]interface{}{"type": "array"},
+				"dependency_cluster": map[string]interface{}{"type": "array"},
+				"concurrent":         map[string]interface{}{"type": "array"},
+				"total_related":      map[string]interface{}{"type": "integer"},
 			},
+			"required": []string{"target_bead_id", "total_related"},
 		},
 	}
 
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mcp"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// issuesURI is the issue list resource; single beads live under it
+const issuesURI = "bv://issues"
+
+const mcpInstructions = `bv analyzes the beads issue tracker of this project.
+Start with "triage" (or "next" for a single pick), use "plan" for parallel tracks,
+"blocker-chain" to see why something is blocked, "search" for keyword lookup, and
+"impact"/"related" before editing files. Read bv://issues for the issue list and
+bv://issues/{id} for a full bead. bv never modifies beads; claim work with br.`
+
+// runMCP implements `bv mcp`: an MCP server on stdin/stdout that keeps the
+// issues and analysis warm between tool calls. It returns the exit code.
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	historyLimit := fs.Int("history-limit", server.DefaultHistoryLimit, "Max commits to correlate for impact, related and triage staleness")
+	noWatch := fs.Bool("no-watch", false, "Do not reload when the beads file changes")
+	noHooks := fs.Bool("no-hooks", false, "Do not run lifecycle event hooks from .bv/hooks.yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: bv mcp [options]")
+		fmt.Fprintln(os.Stderr, "\nRun a Model Context Protocol server over stdio. Register it with your agent,")
+		fmt.Fprintln(os.Stderr, `e.g. {"mcpServers": {"bv": {"command": "bv", "args": ["mcp"]}}}`)
+		fmt.Fprintln(os.Stderr, "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	// stdout carries the protocol; everything else goes to stderr
+	svc, beadsPath, err := openService(*historyLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	srv := newMCPServer(svc)
+
+	if !*noWatch {
+		var monitor *hooks.Monitor
+		if !*noHooks {
+			monitor = loadEventHooks(svc.Issues(), stderrLogger("bv mcp"))
+		}
+		stop := watchBeads("bv mcp", beadsPath, func() {
+			ev, err := svc.Reload()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "bv mcp: reload failed: %v\n", err)
+				return
+			}
+			if ev != nil {
+				_ = srv.NotifyResourcesChanged()
+				if monitor != nil {
+					monitor.Observe(svc.Issues())
+				}
+			}
+		})
+		defer stop()
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err := srv.Serve(ctx, os.Stdin, os.Stdout); err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// lexicalSearchSchema is the output schema of the search tool. The tool
+// answers from the service's BM25 index alone, so unlike the other tools it
+// does not share --robot-search's output (vector similarity, hybrid
+// re-ranking and index stats).
+var lexicalSearchSchema = map[string]any{
+	"$schema":     "https://json-schema.org/draft/2020-12/schema",
+	"title":       "Lexical Search Output",
+	"description": `BM25 keyword matches over IDs, titles, labels, descriptions and comments, best first. Supports "quoted phrases", prefix* terms and typo tolerance; no semantic or graph-aware ranking.`,
+	"type":        "object",
+	"properties": map[string]any{
+		"generated_at": map[string]any{"type": "string", "format": "date-time"},
+		"data_hash":    map[string]any{"type": "string"},
+		"query":        map[string]any{"type": "string"},
+		"mode":         map[string]any{"type": "string", "enum": []string{"lexical"}},
+		"limit":        map[string]any{"type": "integer"},
+		"results": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"issue_id": map[string]any{"type": "string"},
+					"score":    map[string]any{"type": "number", "description": "Raw BM25 score"},
+					"title":    map[string]any{"type": "string"},
+					"status":   map[string]any{"type": "string"},
+				},
+				"required": []string{"issue_id", "score"},
+			},
+		},
+	},
+	"required": []string{"generated_at", "data_hash", "query", "mode", "results"},
+}
+
+// newMCPServer exposes the robot commands backed by svc as MCP tools and the
+// issues as resources. Tool output schemas are the --robot-schema ones,
+// except search's (see lexicalSearchSchema).
+func newMCPServer(svc *server.Service) *mcp.Server {
+	schemas := generateRobotSchemas().Commands
+	// --robot-search has no schema of its own; the search tool is lexical-only
+	schemas["robot-search"] = lexicalSearchSchema
+	tool := func(name string, input map[string]any, handler func(args json.RawMessage) (any, error)) mcp.Tool {
+		schema := schemas["robot-"+name]
+		title, _ := schema["title"].(string)
+		description, _ := schema["description"].(string)
+		return mcp.Tool{
+			Name:         name,
+			Title:        strings.TrimSuffix(strings.TrimPrefix(title, "Robot "), " Output"),
+			Description:  description,
+			InputSchema:  input,
+			OutputSchema: schema,
+			Handler:      handler,
+		}
+	}
+
+	tools := []mcp.Tool{
+		tool("triage", objectSchema(nil), func(json.RawMessage) (any, error) {
+			return svc.Triage()
+		}),
+		tool("next", objectSchema(nil), func(json.RawMessage) (any, error) {
+			return svc.Next()
+		}),
+		tool("plan", objectSchema(nil), func(json.RawMessage) (any, error) {
+			return svc.Plan()
+		}),
+		tool("blocker-chain", objectSchema(map[string]any{
+			"id": map[string]any{"type": "string", "description": "Issue ID to explain"},
+		}, "id"), func(raw json.RawMessage) (any, error) {
+			var args struct {
+				ID string `json:"id"`
+			}
+			if err := decodeToolArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			return svc.BlockerChain(args.ID)
+		}),
+		tool("impact", objectSchema(map[string]any{
+			"files": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"minItems":    1,
+				"description": "Repository-relative paths you intend to modify",
+			},
+		}, "files"), func(raw json.RawMessage) (any, error) {
+			var args struct {
+				Files []string `json:"files"`
+			}
+			if err := decodeToolArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			return svc.Impact(args.Files)
+		}),
+		tool("related", objectSchema(map[string]any{
+			"id":             map[string]any{"type": "string", "description": "Bead ID"},
+			"min_relevance":  map[string]any{"type": "integer", "minimum": 0, "maximum": 100, "description": "Minimum relevance score (default 20)"},
+			"max_results":    map[string]any{"type": "integer", "minimum": 0, "description": "Maximum results per category (default 10)"},
+			"include_closed": map[string]any{"type": "boolean", "description": "Include closed beads"},
+		}, "id"), func(raw json.RawMessage) (any, error) {
+			var args struct {
+				ID            string `json:"id"`
+				MinRelevance  int    `json:"min_relevance"`
+				MaxResults    int    `json:"max_results"`
+				IncludeClosed bool   `json:"include_closed"`
+			}
+			if err := decodeToolArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			return svc.Related(args.ID, correlation.RelatedWorkOptions{
+				MinRelevance:  args.MinRelevance,
+				MaxResults:    args.MaxResults,
+				IncludeClosed: args.IncludeClosed,
+			})
+		}),
+		tool("search", objectSchema(map[string]any{
+			"query": map[string]any{"type": "string", "description": `Words, "quoted phrases" and prefix* terms; typos are tolerated`},
+			"limit": map[string]any{"type": "integer", "minimum": 1, "description": fmt.Sprintf("Maximum results (default %d)", server.DefaultSearchLimit)},
+		}, "query"), func(raw json.RawMessage) (any, error) {
+			var args struct {
+				Query string `json:"query"`
+				Limit int    `json:"limit"`
+			}
+			if err := decodeToolArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			return svc.Search(args.Query, args.Limit)
+		}),
+		tool("forecast", objectSchema(map[string]any{
+			"id":     map[string]any{"type": "string", "description": `Issue ID, or "all" for every open issue (default)`},
+			"agents": map[string]any{"type": "integer", "minimum": 1, "description": "Parallel agents working (default 1)"},
+			"trials": map[string]any{"type": "integer", "minimum": 0, "description": "Monte Carlo trials; 0 skips the simulation"},
+		}), func(raw json.RawMessage) (any, error) {
+			var args struct {
+				ID     string `json:"id"`
+				Agents int    `json:"agents"`
+				Trials int    `json:"trials"`
+			}
+			if err := decodeToolArgs(raw, &args); err != nil {
+				return nil, err
+			}
+			return svc.Forecast(args.ID, args.Agents, args.Trials)
+		}),
+	}
+
+	return mcp.NewServer(
+		mcp.Implementation{Name: "bv", Title: "beads_viewer", Version: version.Version},
+		mcp.WithInstructions(mcpInstructions),
+		mcp.WithTools(tools...),
+		mcp.WithResources(issueResources{svc: svc}),
+	)
+}
+
+// objectSchema builds a tool input schema
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func decodeToolArgs(raw json.RawMessage, v any) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// issueResources serves bv://issues and bv://issues/{id} from the warm service
+type issueResources struct {
+	svc *server.Service
+}
+
+// issueSummary is one entry of the bv://issues resource
+type issueSummary struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Status    model.Status    `json:"status"`
+	Priority  int             `json:"priority"`
+	IssueType model.IssueType `json:"issue_type"`
+	Assignee  string          `json:"assignee,omitempty"`
+	Labels    []string        `json:"labels,omitempty"`
+	URI       string          `json:"uri"`
+}
+
+func issueURI(id string) string {
+	return issuesURI + "/" + url.PathEscape(id)
+}
+
+func (r issueResources) ListResources() []mcp.Resource {
+	issues := r.svc.Issues()
+	resources := make([]mcp.Resource, 0, len(issues)+1)
+	resources = append(resources, mcp.Resource{
+		URI:         issuesURI,
+		Name:        "issues",
+		Title:       "Issues",
+		Description: fmt.Sprintf("Summary of all %d issues", len(issues)),
+		MimeType:    "application/json",
+	})
+	for _, issue := range issues {
+		resources = append(resources, mcp.Resource{
+			URI:      issueURI(issue.ID),
+			Name:     issue.ID,
+			Title:    issue.Title,
+			MimeType: "application/json",
+		})
+	}
+	return resources
+}
+
+func (r issueResources) ResourceTemplates() []mcp.ResourceTemplate {
+	return []mcp.ResourceTemplate{{
+		URITemplate: issuesURI + "/{id}",
+		Name:        "issue",
+		Title:       "Issue",
+		Description: "A single bead with description, dependencies and comments",
+		MimeType:    "application/json",
+	}}
+}
+
+func (r issueResources) ReadResource(uri string) (*mcp.ResourceContents, error) {
+	var v any
+	if uri == issuesURI {
+		issues := r.svc.Issues()
+		summaries := make([]issueSummary, 0, len(issues))
+		for _, issue := range issues {
+			summaries = append(summaries, issueSummary{
+				ID:        issue.ID,
+				Title:     issue.Title,
+				Status:    issue.Status,
+				Priority:  issue.Priority,
+				IssueType: issue.IssueType,
+				Assignee:  issue.Assignee,
+				Labels:    issue.Labels,
+				URI:       issueURI(issue.ID),
+			})
+		}
+		v = map[string]any{
+			"generated_at": time.Now().UTC().Format(time.RFC3339),
+			"data_hash":    r.svc.DataHash(),
+			"issue_count":  len(summaries),
+			"issues":       summaries,
+		}
+	} else {
+		escaped, ok := strings.CutPrefix(uri, issuesURI+"/")
+		if !ok {
+			return nil, mcp.ErrResourceNotFound
+		}
+		id, err := url.PathUnescape(escaped)
+		if err != nil {
+			return nil, mcp.ErrResourceNotFound
+		}
+		issues := r.svc.Issues()
+		for i := range issues {
+			if issues[i].ID == id {
+				v = &issues[i]
+				break
+			}
+		}
+		if v == nil {
+			return nil, mcp.ErrResourceNotFound
+		}
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ResourceContents{URI: uri, MimeType: "application/json", Text: string(data)}, nil
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mcp"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+)
+
+// newMCPFixture commits two beads to a git repo, SV-1 in progress on
+// pkg/auth.go, and connects an in-process client to bv's MCP server for it
+func newMCPFixture(t *testing.T) (*mcp.Client, context.Context) {
+	t.Helper()
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	beads := func(status string) string {
+		return fmt.Sprintf(`{"id":"SV-1","title":"Login page","status":%q,"priority":1,"issue_type":"feature","created_at":"2025-06-01T00:00:00Z","updated_at":"2025-06-01T00:00:00Z"}
+{"id":"SV-2","title":"Session storage","status":"open","priority":2,"issue_type":"task","created_at":"2025-06-01T00:00:00Z","updated_at":"2025-06-01T00:00:00Z","dependencies":[{"issue_id":"SV-2","depends_on_id":"SV-1","type":"blocks"}]}
+`, status)
+	}
+
+	git("init", "-q")
+	write(".beads/beads.jsonl", beads("open"))
+	git("add", "-A")
+	git("commit", "-qm", "Add beads")
+	write(".beads/beads.jsonl", beads("in_progress"))
+	write("pkg/auth.go", "package pkg\n")
+	git("add", "-A")
+	git("commit", "-qm", "SV-1: start login page")
+
+	beadsPath := filepath.Join(dir, ".beads", "beads.jsonl")
+	svc, err := server.NewService(server.Options{
+		Load: func() ([]model.Issue, error) {
+			return loader.LoadIssuesFromFile(beadsPath)
+		},
+		RepoPath:  dir,
+		BeadsPath: beadsPath,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	client := mcp.Connect(ctx, newMCPServer(svc))
+	t.Cleanup(func() { _ = client.Close() })
+	if _, err := client.Initialize(ctx, mcp.Implementation{Name: "test", Version: "0"}); err != nil {
+		t.Fatal(err)
+	}
+	return client, ctx
+}
+
+// checkSchema reports where v violates the type, properties, items and
+// required keywords of schema
+func checkSchema(path string, schema map[string]any, v any) []string {
+	var problems []string
+	switch schema["type"] {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: want object, got %T", path, v)}
+		}
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				if _, ok := obj[name.(string)]; !ok {
+					problems = append(problems, fmt.Sprintf("%s: missing required %q", path, name))
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		for name, sub := range props {
+			if val, ok := obj[name]; ok {
+				problems = append(problems, checkSchema(path+"."+name, sub.(map[string]any), val)...)
+			}
+		}
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: want array, got %T", path, v)}
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range arr {
+				problems = append(problems, checkSchema(fmt.Sprintf("%s[%d]", path, i), items, item)...)
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: want string, got %T", path, v))
+		}
+	case "number", "integer":
+		if _, ok := v.(float64); !ok {
+			problems = append(problems, fmt.Sprintf("%s: want number, got %T", path, v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("%s: want boolean, got %T", path, v))
+		}
+	}
+	return problems
+}
+
+func TestMCPToolsMatchRobotSchemas(t *testing.T) {
+	client, ctx := newMCPFixture(t)
+
+	var list mcp.ListToolsResult
+	if err := client.Call(ctx, "tools/list", nil, &list); err != nil {
+		t.Fatal(err)
+	}
+	tools := make(map[string]mcp.Tool)
+	var names []string
+	for _, tool := range list.Tools {
+		tools[tool.Name] = tool
+		names = append(names, tool.Name)
+	}
+	if got := strings.Join(names, ","); got != "triage,next,plan,blocker-chain,impact,related,search,forecast" {
+		t.Fatalf("tools = %s", got)
+	}
+
+	calls := []struct {
+		tool  string
+		args  map[string]any
+		check func(t *testing.T, out map[string]any)
+	}{
+		{"triage", nil, func(t *testing.T, out map[string]any) {
+			picks := out["triage"].(map[string]any)["quick_ref"].(map[string]any)["top_picks"].([]any)
+			if len(picks) == 0 {
+				t.Error("no top picks")
+			}
+		}},
+		{"next", nil, func(t *testing.T, out map[string]any) {
+			if out["id"] != "SV-1" {
+				t.Errorf("next = %v, want SV-1", out["id"])
+			}
+		}},
+		{"plan", nil, nil},
+		{"blocker-chain", map[string]any{"id": "SV-2"}, func(t *testing.T, out map[string]any) {
+			if out["result"].(map[string]any)["is_blocked"] != true {
+				t.Errorf("SV-2 should be blocked: %v", out["result"])
+			}
+		}},
+		{"impact", map[string]any{"files": []string{"pkg/auth.go"}}, func(t *testing.T, out map[string]any) {
+			beads := out["affected_beads"].([]any)
+			if len(beads) != 1 || beads[0].(map[string]any)["bead_id"] != "SV-1" {
+				t.Errorf("affected beads = %v, want SV-1", beads)
+			}
+		}},
+		{"related", map[string]any{"id": "SV-2"}, func(t *testing.T, out map[string]any) {
+			if out["total_related"].(float64) < 1 {
+				t.Errorf("SV-2 should be related to its blocker: %v", out)
+			}
+		}},
+		{"search", map[string]any{"query": "login"}, func(t *testing.T, out map[string]any) {
+			results := out["results"].([]any)
+			if len(results) == 0 || results[0].(map[string]any)["issue_id"] != "SV-1" {
+				t.Errorf("search results = %v", results)
+			}
+			if out["mode"] != "lexical" || tools["search"].Title != "Lexical Search" || !strings.Contains(tools["search"].Description, "BM25") {
+				t.Errorf("search should be described as lexical: mode=%v title=%q description=%q", out["mode"], tools["search"].Title, tools["search"].Description)
+			}
+		}},
+		{"forecast", map[string]any{"id": "SV-2"}, nil},
+	}
+	for _, call := range calls {
+		t.Run(call.tool, func(t *testing.T) {
+			res, err := client.CallTool(ctx, call.tool, call.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.IsError {
+				t.Fatalf("tool error: %s", res.Content[0].Text)
+			}
+			out, ok := res.StructuredContent.(map[string]any)
+			if !ok {
+				t.Fatalf("structured content = %T", res.StructuredContent)
+			}
+			var text map[string]any
+			if err := json.Unmarshal([]byte(res.Content[0].Text), &text); err != nil || text["data_hash"] != out["data_hash"] {
+				t.Errorf("text content should carry the same JSON: %v", err)
+			}
+			for _, problem := range checkSchema("$", tools[call.tool].OutputSchema, out) {
+				t.Error(problem)
+			}
+			if call.check != nil {
+				call.check(t, out)
+			}
+		})
+	}
+
+	res, err := client.CallTool(ctx, "blocker-chain", map[string]any{"id": "NOPE-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.IsError {
+		t.Errorf("unknown issue should be a tool error, got %+v", res)
+	}
+}
+
+func TestMCPIssueResources(t *testing.T) {
+	client, ctx := newMCPFixture(t)
+
+	var list mcp.ListResourcesResult
+	if err := client.Call(ctx, "resources/list", nil, &list); err != nil {
+		t.Fatal(err)
+	}
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	if got := strings.Join(uris, " "); got != "bv://issues bv://issues/SV-1 bv://issues/SV-2" {
+		t.Errorf("resources = %s", got)
+	}
+
+	read, err := client.ReadResource(ctx, "bv://issues")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary struct {
+		IssueCount int `json:"issue_count"`
+		Issues     []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+			URI    string `json:"uri"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.IssueCount != 2 || summary.Issues[0].Status != "in_progress" || summary.Issues[1].URI != "bv://issues/SV-2" {
+		t.Errorf("issue list = %+v", summary)
+	}
+
+	read, err = client.ReadResource(ctx, "bv://issues/SV-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var issue model.Issue
+	if err := json.Unmarshal([]byte(read.Contents[0].Text), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID != "SV-2" || len(issue.Dependencies) != 1 {
+		t.Errorf("issue = %+v", issue)
+	}
+
+	if _, err := client.ReadResource(ctx, "bv://issues/NOPE-1"); err == nil {
+		t.Error("reading an unknown bead should fail")
+	}
+}
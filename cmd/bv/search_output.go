@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -15,26 +19,30 @@ type robotSearchResult struct {
 	IssueID         string             `json:"issue_id"`
 	Score           float64            `json:"score"`
 	TextScore       float64            `json:"text_score,omitempty"`
+	LexicalScore    float64            `json:"lexical_score,omitempty"`
 	Title           string             `json:"title,omitempty"`
 	ComponentScores map[string]float64 `json:"component_scores,omitempty"`
 }
 
 type robotSearchOutput struct {
-	GeneratedAt string                `json:"generated_at"`
-	DataHash    string                `json:"data_hash"`
-	Query       string                `json:"query"`
-	Provider    search.Provider       `json:"provider"`
-	Model       string                `json:"model,omitempty"`
-	Dim         int                   `json:"dim"`
-	IndexPath   string                `json:"index_path"`
-	Index       search.IndexSyncStats `json:"index"`
-	Loaded      bool                  `json:"loaded"`
-	Limit       int                   `json:"limit"`
-	Mode        search.SearchMode     `json:"mode"`
-	Preset      search.PresetName     `json:"preset,omitempty"`
-	Weights     *search.Weights       `json:"weights,omitempty"`
-	Results     []robotSearchResult   `json:"results"`
-	UsageHints  []string              `json:"usage_hints,omitempty"`
+	GeneratedAt      string                 `json:"generated_at"`
+	DataHash         string                 `json:"data_hash"`
+	Query            string                 `json:"query"`
+	Provider         search.Provider        `json:"provider"`
+	Model            string                 `json:"model,omitempty"`
+	Dim              int                    `json:"dim"`
+	IndexPath        string                 `json:"index_path"`
+	Index            search.IndexSyncStats  `json:"index"`
+	Loaded           bool                   `json:"loaded"`
+	Limit            int                    `json:"limit"`
+	Mode             search.SearchMode      `json:"mode"`
+	Preset           search.PresetName      `json:"preset,omitempty"`
+	Weights          *search.Weights        `json:"weights,omitempty"`
+	LexicalWeight    float64                `json:"lexical_weight"`
+	LexicalIndexPath string                 `json:"lexical_index_path,omitempty"`
+	LexicalIndex     *search.IndexSyncStats `json:"lexical_index,omitempty"`
+	Results          []robotSearchResult    `json:"results"`
+	UsageHints       []string               `json:"usage_hints,omitempty"`
 }
 
 func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
@@ -43,7 +51,7 @@ func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
 	return enc.Encode(out)
 }
 
-func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, weightsFlag string) (search.SearchConfig, error) {
+func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, weightsFlag, lexicalWeightFlag string) (search.SearchConfig, error) {
 	if modeFlag != "" {
 		switch search.SearchMode(strings.ToLower(modeFlag)) {
 		case search.SearchModeText, search.SearchModeHybrid:
@@ -70,9 +78,60 @@ func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, w
 		cfg.HasWeights = true
 	}
 
+	if lexicalWeightFlag != "" {
+		weight, err := search.ParseLexicalWeight(lexicalWeightFlag)
+		if err != nil {
+			return search.SearchConfig{}, fmt.Errorf("invalid --search-lexical-weight: %w", err)
+		}
+		cfg.LexicalWeight = weight
+	}
+
 	return cfg, nil
 }
 
+// semanticDuplicates returns the embedding-based duplicate detector for
+// --robot-suggest. It syncs the semantic index first; if that fails the
+// error goes to stderr and only keyword duplicates are reported.
+func semanticDuplicates(cfg search.EmbeddingConfig) func([]model.Issue) []analysis.Suggestion {
+	return func(issues []model.Issue) []analysis.Suggestion {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: semantic duplicate detection skipped: %v\n", err)
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.IndexBuildTimeout())
+		defer cancel()
+		synced, err := search.OpenSyncedIndex(ctx, projectDir, cfg, nil, issues)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: semantic duplicate detection skipped: %v\n", err)
+			return nil
+		}
+		defer search.CloseEmbedder(synced.Embedder)
+		dupCfg := search.DefaultSemanticDuplicateConfig()
+		dupCfg.Threshold = search.DuplicateThreshold(cfg.Provider)
+		return search.DetectSemanticDuplicates(synced.Index, issues, dupCfg)
+	}
+}
+
+// runLexicalSearch brings the BM25 index at path up to date with issues,
+// saving it when it changed, and returns the top k keyword matches.
+func runLexicalSearch(path string, issues []model.Issue, query string, k int) ([]search.SearchResult, search.IndexSyncStats, error) {
+	idx, loaded, err := search.LoadOrNewLexicalIndex(path)
+	if err != nil {
+		return nil, search.IndexSyncStats{}, err
+	}
+	stats, err := search.SyncLexicalIndex(idx, issues)
+	if err != nil {
+		return nil, stats, fmt.Errorf("building lexical index: %w", err)
+	}
+	if !loaded || stats.Changed() {
+		if err := idx.Save(path); err != nil {
+			return nil, stats, fmt.Errorf("saving lexical index: %w", err)
+		}
+	}
+	return idx.Search(query, k), stats, nil
+}
+
 func resolveSearchWeights(cfg search.SearchConfig) (search.Weights, search.PresetName, error) {
 	if cfg.HasWeights {
 		return cfg.Weights, search.PresetName("custom"), nil
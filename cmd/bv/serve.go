@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/Dicklesworthstone/beads_viewer/internal/datasource"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+)
+
+// runServe implements `bv serve`: load the issues once, keep them warm
+// with a file watcher and answer the robot commands over local HTTP.
+// It returns the process exit code.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", server.DefaultAddr, "TCP address to listen on (loopback only)")
+	socket := fs.String("socket", "", "Listen on a Unix socket at this path instead of TCP")
+	format := fs.String("format", "", "Default response format: json or toon (env: BV_OUTPUT_FORMAT, TOON_DEFAULT_FORMAT)")
+	historyLimit := fs.Int("history-limit", server.DefaultHistoryLimit, "Max commits to correlate for /history and triage staleness")
+	noWatch := fs.Bool("no-watch", false, "Do not reload when the beads file changes")
+	noHooks := fs.Bool("no-hooks", false, "Do not run lifecycle event hooks from .bv/hooks.yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: bv serve [--addr HOST:PORT | --socket PATH] [options]")
+		fmt.Fprintln(os.Stderr, "\nServe triage, plan, insights, search, graph, history, forecast and diff")
+		fmt.Fprintln(os.Stderr, "as JSON (or TOON) from a long-running process, with change events on /events.")
+		fmt.Fprintln(os.Stderr, "")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	outFormat := resolveRobotOutputFormat(*format)
+	if outFormat != "json" && outFormat != "toon" {
+		fmt.Fprintf(os.Stderr, "Error: invalid --format %q (expected json|toon)\n", outFormat)
+		return 2
+	}
+
+	listener, where, err := serveListener(*addr, *socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer listener.Close()
+
+	svc, beadsPath, err := openService(*historyLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	toonOpts := resolveToonEncodeOptionsFromEnv()
+	cfg := server.Config{DefaultFormat: outFormat, ToonOptions: &toonOpts}
+	if *socket == "" {
+		cfg.ListenAddr = listener.Addr().String()
+	}
+	srv := server.New(svc, cfg)
+
+	if !*noWatch {
+		var monitor *hooks.Monitor
+		if !*noHooks {
+			monitor = loadEventHooks(svc.Issues(), stderrLogger("bv serve"))
+		}
+		stop := watchBeads("bv serve", beadsPath, func() {
+			ev, err := srv.Reload()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "bv serve: reload failed: %v\n", err)
+				return
+			}
+			if ev != nil {
+				fmt.Fprintf(os.Stderr, "bv serve: reloaded %d issues (data hash %s)\n", ev.IssueCount, ev.DataHash)
+				if monitor != nil {
+					monitor.Observe(svc.Issues())
+				}
+			}
+		})
+		defer stop()
+	}
+
+	// Request contexts derive from baseCtx so open /events streams end on shutdown
+	baseCtx, cancelStreams := context.WithCancel(context.Background())
+	defer cancelStreams()
+	httpServer := &http.Server{
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+
+	health := svc.Health()
+	fmt.Fprintf(os.Stderr, "bv serve: %d issues (data hash %s) on %s\n", health.IssueCount, health.DataHash, where)
+	fmt.Fprintln(os.Stderr, "bv serve: press Ctrl+C to stop")
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpServer.Serve(listener) }()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case err := <-errCh:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	case <-sigCh:
+	}
+	cancelStreams()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error shutting down: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// openService loads the current project's issues into a server.Service,
+// correlating with git history when the working directory is a repository.
+// It also returns the beads file path ("" when none was found).
+func openService(historyLimit int) (*server.Service, string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "", fmt.Errorf("getting current directory: %w", err)
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil, "", fmt.Errorf("getting beads directory: %w", err)
+	}
+	beadsPath, _ := loader.FindJSONLPath(beadsDir)
+
+	opts := server.Options{
+		Load: func() ([]model.Issue, error) {
+			return datasource.LoadIssues("")
+		},
+		BeadsPath:    beadsPath,
+		HistoryLimit: historyLimit,
+	}
+	if correlation.ValidateRepository(cwd) == nil {
+		opts.RepoPath = cwd
+	}
+	svc, err := server.NewService(opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading beads: %w (is this a project initialized with 'bd init'?)", err)
+	}
+	return svc, beadsPath, nil
+}
+
+// watchBeads calls onChange (debounced) whenever the beads file changes and
+// returns a function that stops watching. Failures only disable live reload.
+func watchBeads(name, beadsPath string, onChange func()) (stop func()) {
+	if beadsPath == "" {
+		return func() {}
+	}
+	w, err := watcher.NewWatcher(beadsPath,
+		watcher.WithDebounceDuration(500*time.Millisecond),
+		watcher.WithOnChange(onChange),
+		watcher.WithOnError(func(err error) {
+			fmt.Fprintf(os.Stderr, "%s: watch error: %v\n", name, err)
+		}),
+	)
+	if err == nil {
+		err = w.Start()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: live reload disabled: %v\n", err)
+		return func() {}
+	}
+	return w.Stop
+}
+
+// loadEventHooks returns a monitor for the lifecycle hooks in
+// .bv/hooks.yaml, primed with issues, or nil when none are configured.
+// Load problems go to stderr; logf receives hook failures after that.
+func loadEventHooks(issues []model.Issue, logf func(msg string)) *hooks.Monitor {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	loader := hooks.NewLoader(hooks.WithProjectDir(projectDir))
+	if err := loader.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: event hooks disabled: %v\n", err)
+		return nil
+	}
+	for _, w := range loader.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if !loader.HasEventHooks() {
+		return nil
+	}
+
+	driftConfig, err := drift.LoadConfig(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
+		driftConfig = drift.DefaultConfig()
+	}
+	baselinePath := baseline.DefaultPath(projectDir)
+	monitor := hooks.NewMonitor(loader.Config(),
+		hooks.WithDriftAlerts(func(issues []model.Issue) []drift.Alert {
+			return computeDriftAlerts(issues, driftConfig, baselinePath, nil).Alerts
+		}),
+		hooks.WithMonitorLogger(logf),
+	)
+	monitor.Observe(issues)
+	return monitor
+}
+
+// stderrLogger prefixes log lines with name on stderr
+func stderrLogger(name string) func(msg string) {
+	return func(msg string) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", name, msg)
+	}
+}
+
+// serveListener opens the Unix socket when one is given, otherwise the TCP
+// address, which must be loopback: the API has no authentication.
+func serveListener(addr, socket string) (net.Listener, string, error) {
+	if socket != "" {
+		// A socket file left by a crashed server would make Listen fail
+		if info, err := os.Lstat(socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(socket)
+		}
+		l, err := net.Listen("unix", socket)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := os.Chmod(socket, 0o600); err != nil {
+			l.Close()
+			return nil, "", err
+		}
+		return l, "unix:" + socket, nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid --addr %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		return nil, "", fmt.Errorf("refusing to listen on %q: bv serve only binds to loopback addresses (use --socket for other local clients)", addr)
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", err
+	}
+	return l, "http://" + l.Addr().String(), nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
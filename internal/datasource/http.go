@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,38 +20,59 @@ type HTTPReader struct {
 	baseURL string
 	apiKey  string
 	client  *http.Client
+	daemon  *loader.DaemonClient
 }
 
 // NewHTTPReader creates a reader for a daemon HTTP endpoint.
 func NewHTTPReader(baseURL, apiKey string) *HTTPReader {
+	client := &http.Client{
+		Timeout: loader.DefaultHTTPTimeout,
+	}
 	return &HTTPReader{
 		baseURL: baseURL,
 		apiKey:  apiKey,
-		client: &http.Client{
-			Timeout: loader.DefaultHTTPTimeout,
-		},
+		client:  client,
+		daemon:  loader.NewDaemonClient(baseURL, apiKey, client),
 	}
 }
 
 // LoadIssues fetches all issues from the daemon.
 func (r *HTTPReader) LoadIssues() ([]model.Issue, error) {
+	return r.LoadIssuesFiltered(loader.DaemonListOptions{})
+}
+
+// LoadIssuesFiltered fetches issues matching opts, letting the daemon do the
+// status/label/updated_since filtering.
+func (r *HTTPReader) LoadIssuesFiltered(opts loader.DaemonListOptions) ([]model.Issue, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), loader.DefaultHTTPTimeout)
 	defer cancel()
-	return loader.LoadIssuesFromURL(ctx, r.baseURL, r.apiKey, loader.ParseOptions{})
+	return r.daemon.ListIssues(ctx, opts, loader.ParseOptions{})
 }
 
-// Ping performs a lightweight connectivity check against the daemon.
-// Returns the number of issues available without loading them all.
+// Ping performs a connectivity check against the daemon and returns the
+// number of issues it serves.
 func (r *HTTPReader) Ping() (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	issues, err := loader.LoadIssuesFromURL(ctx, r.baseURL, r.apiKey, loader.ParseOptions{})
+	issues, err := r.daemon.ListIssues(ctx, loader.DaemonListOptions{}, loader.ParseOptions{})
 	if err != nil {
 		return 0, err
 	}
 	return len(issues), nil
 }
 
+// NewHTTPSource describes a daemon endpoint as an (unvalidated) data source,
+// e.g. to hand to NewHTTPPoller.
+func NewHTTPSource(url, apiKey string) DataSource {
+	return DataSource{
+		Type:     SourceTypeHTTP,
+		Path:     strings.TrimRight(url, "/"),
+		Priority: PriorityHTTP,
+		ModTime:  time.Now(), // HTTP sources are always "current"
+		apiKey:   apiKey,
+	}
+}
+
 // discoverHTTPSources checks for HTTP daemon endpoints via options or env vars.
 func discoverHTTPSources(opts DiscoveryOptions) []DataSource {
 	url := opts.HTTPEndpoint
@@ -66,21 +89,12 @@ func discoverHTTPSources(opts DiscoveryOptions) []DataSource {
 		return nil
 	}
 
-	// Normalize URL
-	url = strings.TrimRight(url, "/")
-
+	source := NewHTTPSource(url, opts.HTTPAPIKey)
 	if opts.Verbose {
-		opts.Logger(fmt.Sprintf("Found HTTP source: %s", url))
+		opts.Logger(fmt.Sprintf("Found HTTP source: %s", source.Path))
 	}
 
-	return []DataSource{{
-		Type:     SourceTypeHTTP,
-		Path:     url,
-		Priority: PriorityHTTP,
-		ModTime:  time.Now(), // HTTP sources are always "current"
-		Valid:    false,      // Must be validated
-		apiKey:   opts.HTTPAPIKey,
-	}}
+	return []DataSource{source}
 }
 
 // validateHTTP validates an HTTP daemon source by performing a connectivity check.
@@ -105,23 +119,62 @@ func validateHTTP(source *DataSource, opts ValidationOptions) error {
 	return nil
 }
 
+// DefaultHTTPFullSyncEvery is how many polls pass between full listings.
+// Incremental polls only see issues whose updated_at moved, so hard deletes
+// are picked up on the next full sync.
+const DefaultHTTPFullSyncEvery = 20
+
+// HTTPUpdate describes what changed on a poll.
+type HTTPUpdate struct {
+	// Issues is the complete merged issue set, sorted by ID.
+	Issues []model.Issue
+	// Changed lists IDs that were added or modified.
+	Changed []string
+	// Removed lists IDs that disappeared (only detected on full syncs).
+	Removed []string
+	// Full reports whether this poll fetched the complete issue list.
+	Full bool
+}
+
 // HTTPPoller monitors a daemon for changes and triggers callbacks.
+//
+// After an initial full listing the poller keeps an updated_at cursor and
+// asks the daemon only for issues updated since then, merging the results
+// into its cached issue set. Every FullSyncEvery polls it does a full listing
+// to reconcile deletions.
+//
+// Filter narrows every sync. Full listings send it to the daemon; incremental
+// polls fetch everything updated since the cursor and apply it locally, so an
+// issue that stops matching (say it was closed under --beads-status open)
+// drops out right away rather than at the next full sync.
 type HTTPPoller struct {
-	reader    *HTTPReader
-	interval  time.Duration
-	callback  func(DataSource)
-	lastCount int
-	source    DataSource
-	done      chan struct{}
-	mu        sync.Mutex
-	verbose   bool
-	logger    func(msg string)
+	reader        *HTTPReader
+	interval      time.Duration
+	callback      func(DataSource)
+	onUpdate      func(HTTPUpdate)
+	fullSyncEvery int
+	filter        loader.DaemonListOptions
+	source        DataSource
+	done          chan struct{}
+	mu            sync.Mutex
+	issues        map[string]model.Issue
+	cursor        time.Time
+	polls         int
+	verbose       bool
+	logger        func(msg string)
 }
 
 // HTTPPollerOptions configures the HTTP poller.
 type HTTPPollerOptions struct {
 	// Interval is the polling frequency. Default: 30s.
 	Interval time.Duration
+	// FullSyncEvery forces a full listing every N polls. Default: DefaultHTTPFullSyncEvery.
+	FullSyncEvery int
+	// Filter restricts the synced issues (statuses, labels, inline comments).
+	// UpdatedSince is managed by the poller and ignored here.
+	Filter loader.DaemonListOptions
+	// OnUpdate receives the merged issue set and the IDs that changed.
+	OnUpdate func(HTTPUpdate)
 	// Verbose enables logging.
 	Verbose bool
 	// Logger receives log messages.
@@ -133,21 +186,49 @@ func NewHTTPPoller(source DataSource, callback func(DataSource), opts HTTPPoller
 	if opts.Interval == 0 {
 		opts.Interval = 30 * time.Second
 	}
+	if opts.FullSyncEvery <= 0 {
+		opts.FullSyncEvery = DefaultHTTPFullSyncEvery
+	}
 	if opts.Logger == nil {
 		opts.Logger = func(string) {}
 	}
+	opts.Filter.UpdatedSince = time.Time{}
 
 	return &HTTPPoller{
-		reader:   NewHTTPReader(source.Path, source.apiKey),
-		interval: opts.Interval,
-		callback: callback,
-		source:   source,
-		done:     make(chan struct{}),
-		verbose:  opts.Verbose,
-		logger:   opts.Logger,
+		reader:        NewHTTPReader(source.Path, source.apiKey),
+		interval:      opts.Interval,
+		callback:      callback,
+		onUpdate:      opts.OnUpdate,
+		fullSyncEvery: opts.FullSyncEvery,
+		filter:        opts.Filter,
+		source:        source,
+		done:          make(chan struct{}),
+		issues:        make(map[string]model.Issue),
+		verbose:       opts.Verbose,
+		logger:        opts.Logger,
 	}
 }
 
+// Seed primes the cache with issues that were already loaded, so the first
+// poll can be incremental instead of refetching everything.
+func (p *HTTPPoller) Seed(issues []model.Issue) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.issues = make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		p.issues[issue.ID] = issue
+	}
+	p.cursor = maxUpdatedAt(p.issues)
+	p.polls = 1 // next poll is incremental
+}
+
+// Issues returns the cached issue set, sorted by ID.
+func (p *HTTPPoller) Issues() []model.Issue {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return sortedIssues(p.issues)
+}
+
 // Start begins polling the daemon for changes.
 func (p *HTTPPoller) Start() {
 	go p.run()
@@ -173,7 +254,17 @@ func (p *HTTPPoller) run() {
 }
 
 func (p *HTTPPoller) poll() {
-	count, err := p.reader.Ping()
+	p.mu.Lock()
+	full := p.cursor.IsZero() || p.polls%p.fullSyncEvery == 0
+	cursor := p.cursor
+	p.polls++
+	p.mu.Unlock()
+
+	opts := p.filter
+	if !full {
+		opts = loader.DaemonListOptions{UpdatedSince: cursor, IncludeComments: p.filter.IncludeComments}
+	}
+	fetched, err := p.reader.LoadIssuesFiltered(opts)
 	if err != nil {
 		if p.verbose {
 			p.logger(fmt.Sprintf("HTTP poll failed: %v", err))
@@ -181,20 +272,90 @@ func (p *HTTPPoller) poll() {
 		return
 	}
 
+	update := p.merge(fetched, full)
+	if len(update.Changed) == 0 && len(update.Removed) == 0 {
+		return
+	}
+
+	if p.verbose {
+		p.logger(fmt.Sprintf("HTTP source changed: %d updated, %d removed (%d issues, full=%v)",
+			len(update.Changed), len(update.Removed), len(update.Issues), full))
+	}
+	p.source.IssueCount = len(update.Issues)
+	p.source.ModTime = time.Now()
+	if p.callback != nil {
+		p.callback(p.source)
+	}
+	if p.onUpdate != nil {
+		p.onUpdate(update)
+	}
+}
+
+// merge folds fetched issues into the cache. Fetched issues that fail the
+// filter are dropped, as are (on a full sync) cached issues missing from
+// fetched.
+func (p *HTTPPoller) merge(fetched []model.Issue, full bool) HTTPUpdate {
 	p.mu.Lock()
-	changed := count != p.lastCount
-	oldCount := p.lastCount
-	p.lastCount = count
-	p.mu.Unlock()
+	defer p.mu.Unlock()
 
-	if changed {
-		if p.verbose {
-			p.logger(fmt.Sprintf("HTTP source changed: %d issues (was %d)", count, oldCount))
+	update := HTTPUpdate{Full: full}
+	seen := make(map[string]bool, len(fetched))
+	var latest time.Time
+	for _, issue := range fetched {
+		if issue.UpdatedAt.After(latest) {
+			latest = issue.UpdatedAt
+		}
+		if !p.filter.Matches(&issue) {
+			if _, ok := p.issues[issue.ID]; ok {
+				delete(p.issues, issue.ID)
+				update.Removed = append(update.Removed, issue.ID)
+			}
+			continue
 		}
-		p.source.IssueCount = count
-		p.source.ModTime = time.Now()
-		if p.callback != nil {
-			p.callback(p.source)
+		seen[issue.ID] = true
+		if old, ok := p.issues[issue.ID]; ok && reflect.DeepEqual(old, issue) {
+			continue
 		}
+		p.issues[issue.ID] = issue
+		update.Changed = append(update.Changed, issue.ID)
+	}
+	if full {
+		for id := range p.issues {
+			if !seen[id] {
+				delete(p.issues, id)
+				update.Removed = append(update.Removed, id)
+			}
+		}
+	}
+	sort.Strings(update.Changed)
+	sort.Strings(update.Removed)
+
+	// Filtered-out issues still advance the cursor so they aren't refetched
+	if c := maxUpdatedAt(p.issues); c.After(latest) {
+		latest = c
+	}
+	if latest.After(p.cursor) || full {
+		p.cursor = latest
+	}
+	update.Issues = sortedIssues(p.issues)
+	return update
+}
+
+func maxUpdatedAt(issues map[string]model.Issue) time.Time {
+	var latest time.Time
+	for _, issue := range issues {
+		if issue.UpdatedAt.After(latest) {
+			latest = issue.UpdatedAt
+		}
+	}
+	return latest
+}
+
+func sortedIssues(issues map[string]model.Issue) []model.Issue {
+	out := make([]model.Issue, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, issue)
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
 }
@@ -0,0 +1,135 @@
+package datasource
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+func TestHTTPPoller_IncrementalSync(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	d := testutil.NewFakeDaemon([]model.Issue{
+		{ID: "bd-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base},
+		{ID: "bd-2", Title: "Two", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base.Add(time.Minute)},
+	})
+	defer d.Close()
+
+	var updates []HTTPUpdate
+	callbacks := 0
+	source := DataSource{Type: SourceTypeHTTP, Path: d.URL()}
+	p := NewHTTPPoller(source, func(DataSource) { callbacks++ }, HTTPPollerOptions{
+		FullSyncEvery: 3,
+		OnUpdate:      func(u HTTPUpdate) { updates = append(updates, u) },
+	})
+
+	// Poll 0: full listing.
+	p.poll()
+	if len(updates) != 1 || !updates[0].Full || strings.Join(updates[0].Changed, ",") != "bd-1,bd-2" {
+		t.Fatalf("initial poll = %+v", updates)
+	}
+
+	// Poll 1: incremental and nothing changed, so no callback.
+	p.poll()
+	if len(updates) != 1 || callbacks != 1 {
+		t.Fatalf("unchanged poll fired callbacks: %d updates, %d callbacks", len(updates), callbacks)
+	}
+	reqs := d.Requests()
+	if since := reqs[len(reqs)-1].Body["updated_since"]; since != "2025-03-01T12:01:00Z" {
+		t.Errorf("incremental poll cursor = %v", since)
+	}
+
+	// Poll 2: an edit and a hard delete; only the edit is visible incrementally.
+	d.Upsert(model.Issue{ID: "bd-1", Title: "One (edited)", Status: model.StatusInProgress, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base.Add(5 * time.Minute)})
+	d.Delete("bd-2")
+	p.poll()
+	if len(updates) != 2 || updates[1].Full || strings.Join(updates[1].Changed, ",") != "bd-1" || len(updates[1].Removed) != 0 {
+		t.Fatalf("incremental poll = %+v", updates[len(updates)-1])
+	}
+	if got := p.Issues(); len(got) != 2 || got[0].Title != "One (edited)" {
+		t.Errorf("merged issues = %+v", got)
+	}
+
+	// Poll 3: periodic full sync reconciles the delete.
+	p.poll()
+	if len(updates) != 3 || !updates[2].Full || strings.Join(updates[2].Removed, ",") != "bd-2" {
+		t.Fatalf("full resync = %+v", updates[len(updates)-1])
+	}
+	if got := p.Issues(); len(got) != 1 || got[0].ID != "bd-1" {
+		t.Errorf("issues after resync = %+v", got)
+	}
+	if callbacks != 3 {
+		t.Errorf("callbacks = %d, want 3", callbacks)
+	}
+}
+
+func TestHTTPPoller_SeedSkipsInitialFullListing(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	issues := []model.Issue{{ID: "bd-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base}}
+	d := testutil.NewFakeDaemon(issues)
+	defer d.Close()
+
+	reader := NewHTTPReader(d.URL(), "")
+	loaded, err := reader.LoadIssues()
+	if err != nil {
+		t.Fatalf("LoadIssues: %v", err)
+	}
+
+	fired := false
+	p := NewHTTPPoller(DataSource{Type: SourceTypeHTTP, Path: d.URL()}, func(DataSource) { fired = true }, HTTPPollerOptions{})
+	p.Seed(loaded)
+	p.poll()
+
+	if fired {
+		t.Error("seeded poller reported a change for unchanged data")
+	}
+	reqs := d.Requests()
+	if _, ok := reqs[len(reqs)-1].Body["updated_since"]; !ok {
+		t.Errorf("first poll after Seed was not incremental: %v", reqs[len(reqs)-1].Body)
+	}
+}
+
+func TestHTTPPoller_AppliesFilterOnEverySync(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	d := testutil.NewFakeDaemon([]model.Issue{
+		{ID: "bd-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base},
+		{ID: "bd-2", Title: "Two", Status: model.StatusClosed, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: base, ClosedAt: &base},
+	})
+	defer d.Close()
+
+	var updates []HTTPUpdate
+	p := NewHTTPPoller(NewHTTPSource(d.URL(), ""), nil, HTTPPollerOptions{
+		Filter:   loader.DaemonListOptions{Statuses: []model.Status{model.StatusOpen}, IncludeComments: true},
+		OnUpdate: func(u HTTPUpdate) { updates = append(updates, u) },
+	})
+
+	// Full listing: the filter goes to the daemon.
+	p.poll()
+	reqs := d.Requests()
+	if body := reqs[len(reqs)-1].Body; body["status"] != "open" || body["include_comments"] != true {
+		t.Errorf("full sync request = %v", body)
+	}
+	if got := p.Issues(); len(got) != 1 || got[0].ID != "bd-1" {
+		t.Fatalf("issues after full sync = %+v", got)
+	}
+
+	// Incremental: bd-1 is closed and bd-2 reopened; the filter applies locally.
+	closed := base.Add(time.Minute)
+	d.Upsert(model.Issue{ID: "bd-1", Title: "One", Status: model.StatusClosed, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: closed, ClosedAt: &closed})
+	d.Upsert(model.Issue{ID: "bd-2", Title: "Two", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base, UpdatedAt: closed})
+	p.poll()
+	reqs = d.Requests()
+	if body := reqs[len(reqs)-1].Body; body["updated_since"] == nil || body["include_comments"] != true {
+		t.Errorf("incremental request = %v", body)
+	}
+	last := updates[len(updates)-1]
+	if last.Full || strings.Join(last.Changed, ",") != "bd-2" || strings.Join(last.Removed, ",") != "bd-1" {
+		t.Fatalf("incremental update = %+v", last)
+	}
+	if got := p.Issues(); len(got) != 1 || got[0].ID != "bd-2" {
+		t.Errorf("issues after incremental sync = %+v", got)
+	}
+}
@@ -66,9 +66,15 @@ func LoadIssuesFromDir(beadsDir string) ([]model.Issue, error) {
 }
 
 // LoadIssuesFromHTTP loads issues via the datasource layer using an HTTP daemon endpoint.
-// This integrates --beads-url into the datasource discovery/selection framework,
-// enabling future live-reload via polling.
+// This integrates --beads-url into the datasource discovery/selection framework;
+// the TUI live-reloads it with an HTTPPoller.
 func LoadIssuesFromHTTP(httpURL, apiKey string) ([]model.Issue, error) {
+	return LoadIssuesFromHTTPFiltered(httpURL, apiKey, loader.DaemonListOptions{})
+}
+
+// LoadIssuesFromHTTPFiltered is LoadIssuesFromHTTP with server-side filtering
+// (status, labels, updated_since) pushed down to the daemon.
+func LoadIssuesFromHTTPFiltered(httpURL, apiKey string, filter loader.DaemonListOptions) ([]model.Issue, error) {
 	sources, err := DiscoverSources(DiscoveryOptions{
 		HTTPEndpoint:           httpURL,
 		HTTPAPIKey:             apiKey,
@@ -87,6 +93,9 @@ func LoadIssuesFromHTTP(httpURL, apiKey string) ([]model.Issue, error) {
 		return nil, err
 	}
 
+	if best.Type == SourceTypeHTTP {
+		return NewHTTPReader(best.Path, best.apiKey).LoadIssuesFiltered(filter)
+	}
 	return LoadFromSource(best)
 }
 
@@ -0,0 +1,608 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// CycleFixOptions bounds the cycle-breaking assistant. Every candidate it
+// evaluates re-runs triage on a modified graph, so the caps matter.
+type CycleFixOptions struct {
+	MaxComponents  int       // Strongly connected components to plan (default 10)
+	MaxCandidates  int       // Edges simulated per component (default 8)
+	ExactEdgeLimit int       // Components with at most this many edges get an exact arc set (default 16)
+	Now            time.Time // Reference time for triage scoring (default time.Now)
+}
+
+// DefaultCycleFixOptions returns the caps used by --robot-cycle-fix.
+func DefaultCycleFixOptions() CycleFixOptions {
+	return CycleFixOptions{
+		MaxComponents:  10,
+		MaxCandidates:  8,
+		ExactEdgeLimit: 16,
+	}
+}
+
+// CycleFixImpact summarises the project state the triage engine sees for one
+// version of the dependency graph.
+type CycleFixImpact struct {
+	ActionableCount  int      `json:"actionable_count"`
+	TopPicks         []string `json:"top_picks"`
+	LongestPath      int      `json:"longest_path"`               // Open beads on the longest dependency chain
+	LongestPathIDs   []string `json:"longest_path_ids,omitempty"` // That chain, blockers first
+	CyclicComponents int      `json:"cyclic_components"`
+}
+
+// CycleFixDelta compares an impact against the unmodified graph.
+type CycleFixDelta struct {
+	Actionable       int      `json:"actionable"`
+	LongestPath      int      `json:"longest_path"`
+	CyclicComponents int      `json:"cyclic_components"`
+	TopPicksAdded    []string `json:"top_picks_added,omitempty"`
+	TopPicksDropped  []string `json:"top_picks_dropped,omitempty"`
+}
+
+// CycleFixCut is one blocking dependency to remove: From depends on To.
+type CycleFixCut struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Command string `json:"command"`
+}
+
+// CycleFixCandidate is the simulated effect of removing a single edge.
+type CycleFixCandidate struct {
+	CycleFixCut
+	InFeedbackArcSet bool           `json:"in_feedback_arc_set"`
+	RemainingCuts    int            `json:"remaining_cuts"` // Minimal cuts still needed in the component afterwards
+	Collateral       int            `json:"collateral"`     // Dependents of the edge target
+	Impact           CycleFixImpact `json:"impact"`
+	Delta            CycleFixDelta  `json:"delta"`
+}
+
+// CycleFixComponent is the plan for one strongly connected component.
+type CycleFixComponent struct {
+	Index            int                 `json:"index"`
+	Members          []string            `json:"members"`
+	EdgeCount        int                 `json:"edge_count"`
+	FeedbackArcSet   []CycleFixCut       `json:"feedback_arc_set"`
+	Exact            bool                `json:"exact"` // False when the arc set came from the greedy heuristic
+	Candidates       []CycleFixCandidate `json:"candidates"`
+	CandidatesCapped bool                `json:"candidates_capped,omitempty"`
+	Impact           CycleFixImpact      `json:"impact"` // After cutting this component's arc set only
+	Delta            CycleFixDelta       `json:"delta"`
+}
+
+// CycleFixPlan is the full cycle-breaking assistant output.
+type CycleFixPlan struct {
+	Status         FeatureStatus       `json:"status"`
+	ComponentCount int                 `json:"component_count"`
+	Components     []CycleFixComponent `json:"components"`
+	Before         CycleFixImpact      `json:"before"`
+	After          CycleFixImpact      `json:"after"` // With every planned cut applied
+	Delta          CycleFixDelta       `json:"delta"`
+	Commands       []string            `json:"commands"`
+	HowToUse       string              `json:"how_to_use"`
+}
+
+// cycleFixEdge is an edge inside one component, in local node indices.
+type cycleFixEdge struct {
+	u, v       int
+	collateral int
+}
+
+// GenerateCycleFix plans how to break every dependency cycle in issues.
+//
+// For each strongly connected component it finds a minimum feedback arc set
+// (exact for small components, Eades–Lin–Smyth plus pruning otherwise) and
+// simulates removing each candidate edge: triage and the longest dependency
+// chain are recomputed on the modified graph so the cost of a cut is visible
+// before anyone runs the resulting `bd dep remove` commands.
+func GenerateCycleFix(issues []model.Issue, opts CycleFixOptions) *CycleFixPlan {
+	defaults := DefaultCycleFixOptions()
+	if opts.MaxComponents <= 0 {
+		opts.MaxComponents = defaults.MaxComponents
+	}
+	if opts.MaxCandidates <= 0 {
+		opts.MaxCandidates = defaults.MaxCandidates
+	}
+	if opts.ExactEdgeLimit <= 0 {
+		opts.ExactEdgeLimit = defaults.ExactEdgeLimit
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	analyzer := NewAnalyzer(issues)
+	plan := &CycleFixPlan{
+		Before:   simulateCycleFix(issues, nil, opts.Now),
+		Commands: []string{},
+		HowToUse: "Run commands to remove the minimal set of dependencies that makes the graph acyclic. Review candidates first: delta shows what each cut does to triage.",
+	}
+
+	components := analyzer.cyclicComponents()
+	plan.ComponentCount = len(components)
+	if len(components) > opts.MaxComponents {
+		components = components[:opts.MaxComponents]
+	}
+	plan.Status = FeatureStatus{
+		State:   "available",
+		Count:   len(components),
+		Capped:  plan.ComponentCount > len(components),
+		Limited: plan.ComponentCount,
+	}
+
+	allCuts := make(map[GraphEdge]bool)
+	for i, members := range components {
+		comp := analyzer.planCycleComponent(issues, members, plan.Before, opts)
+		comp.Index = i + 1
+		for _, cut := range comp.FeedbackArcSet {
+			allCuts[GraphEdge{From: cut.From, To: cut.To}] = true
+			plan.Commands = append(plan.Commands, cut.Command)
+		}
+		plan.Components = append(plan.Components, comp)
+	}
+
+	if len(allCuts) == 0 {
+		plan.After = plan.Before
+	} else {
+		plan.After = simulateCycleFix(issues, allCuts, opts.Now)
+	}
+	plan.Delta = compareCycleFixImpact(plan.Before, plan.After)
+	return plan
+}
+
+// planCycleComponent computes the arc set and candidate simulations for one SCC.
+func (a *Analyzer) planCycleComponent(issues []model.Issue, members []string, before CycleFixImpact, opts CycleFixOptions) CycleFixComponent {
+	index := make(map[string]int, len(members))
+	for i, id := range members {
+		index[id] = i
+	}
+	var edges []cycleFixEdge
+	for i, id := range members {
+		from := a.g.From(a.idToNode[id])
+		for from.Next() {
+			if j, ok := index[a.nodeToID[from.Node().ID()]]; ok {
+				edges = append(edges, cycleFixEdge{u: i, v: j, collateral: a.countDependents(members[j])})
+			}
+		}
+	}
+	// Cheapest edges first, so ties between minimal arc sets favour low collateral.
+	sort.Slice(edges, func(x, y int) bool {
+		if edges[x].collateral != edges[y].collateral {
+			return edges[x].collateral < edges[y].collateral
+		}
+		if edges[x].u != edges[y].u {
+			return edges[x].u < edges[y].u
+		}
+		return edges[x].v < edges[y].v
+	})
+
+	cutOf := func(e cycleFixEdge) CycleFixCut {
+		from, to := members[e.u], members[e.v]
+		return CycleFixCut{From: from, To: to, Command: fmt.Sprintf("bd dep remove %s %s", from, to)}
+	}
+
+	fas, exact := minFeedbackArcSet(len(members), edges, -1, opts.ExactEdgeLimit)
+	comp := CycleFixComponent{
+		Members:        members,
+		EdgeCount:      len(edges),
+		Exact:          exact,
+		FeedbackArcSet: make([]CycleFixCut, 0, len(fas)),
+	}
+	inFAS := make(map[int]bool, len(fas))
+	compCuts := make(map[GraphEdge]bool, len(fas))
+	for _, ei := range fas {
+		inFAS[ei] = true
+		cut := cutOf(edges[ei])
+		comp.FeedbackArcSet = append(comp.FeedbackArcSet, cut)
+		compCuts[GraphEdge{From: cut.From, To: cut.To}] = true
+	}
+	sort.Slice(comp.FeedbackArcSet, func(i, j int) bool {
+		if comp.FeedbackArcSet[i].From != comp.FeedbackArcSet[j].From {
+			return comp.FeedbackArcSet[i].From < comp.FeedbackArcSet[j].From
+		}
+		return comp.FeedbackArcSet[i].To < comp.FeedbackArcSet[j].To
+	})
+	comp.Impact = simulateCycleFix(issues, compCuts, opts.Now)
+	comp.Delta = compareCycleFixImpact(before, comp.Impact)
+
+	// Rank every edge structurally (cheap), then simulate the best few (expensive).
+	candidates := make([]CycleFixCandidate, 0, len(edges))
+	for ei, e := range edges {
+		remaining, _ := minFeedbackArcSet(len(members), edges, ei, opts.ExactEdgeLimit)
+		candidates = append(candidates, CycleFixCandidate{
+			CycleFixCut:      cutOf(e),
+			InFeedbackArcSet: inFAS[ei],
+			RemainingCuts:    len(remaining),
+			Collateral:       e.collateral,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.InFeedbackArcSet != cj.InFeedbackArcSet {
+			return ci.InFeedbackArcSet
+		}
+		if ci.RemainingCuts != cj.RemainingCuts {
+			return ci.RemainingCuts < cj.RemainingCuts
+		}
+		return false // edges are already ordered by collateral, then position
+	})
+	if len(candidates) > opts.MaxCandidates {
+		candidates = candidates[:opts.MaxCandidates]
+		comp.CandidatesCapped = true
+	}
+	for i := range candidates {
+		cut := map[GraphEdge]bool{{From: candidates[i].From, To: candidates[i].To}: true}
+		candidates[i].Impact = simulateCycleFix(issues, cut, opts.Now)
+		candidates[i].Delta = compareCycleFixImpact(before, candidates[i].Impact)
+	}
+	comp.Candidates = candidates
+	return comp
+}
+
+// cyclicComponents returns the member IDs (sorted) of every strongly connected
+// component that contains a cycle, largest first.
+func (a *Analyzer) cyclicComponents() [][]string {
+	var out [][]string
+	for _, scc := range topo.TarjanSCC(a.g) {
+		if len(scc) == 1 && !a.g.HasEdgeFromTo(scc[0].ID(), scc[0].ID()) {
+			continue
+		}
+		ids := make([]string, 0, len(scc))
+		for _, n := range scc {
+			ids = append(ids, a.nodeToID[n.ID()])
+		}
+		sort.Strings(ids)
+		out = append(out, ids)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) > len(out[j])
+		}
+		return out[i][0] < out[j][0]
+	})
+	return out
+}
+
+// simulateCycleFix re-runs triage and the longest-path computation with the
+// given blocking dependencies removed. A nil cut set measures issues as-is.
+func simulateCycleFix(issues []model.Issue, cuts map[GraphEdge]bool, now time.Time) CycleFixImpact {
+	modified := issues
+	if len(cuts) > 0 {
+		modified = make([]model.Issue, len(issues))
+		for i, issue := range issues {
+			modified[i] = withoutCuts(issue, cuts)
+		}
+	}
+
+	analyzer := NewAnalyzer(modified)
+	stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), TriageConfig())
+	stats.WaitForPhase2()
+	triage := ComputeTriageFromAnalyzer(analyzer, stats, modified, TriageOptions{}, now)
+
+	impact := CycleFixImpact{
+		ActionableCount:  triage.QuickRef.ActionableCount,
+		TopPicks:         make([]string, 0, len(triage.QuickRef.TopPicks)),
+		CyclicComponents: len(analyzer.cyclicComponents()),
+	}
+	for _, pick := range triage.QuickRef.TopPicks {
+		impact.TopPicks = append(impact.TopPicks, pick.ID)
+	}
+	impact.LongestPathIDs = analyzer.longestOpenPath()
+	impact.LongestPath = len(impact.LongestPathIDs)
+	return impact
+}
+
+// withoutCuts returns issue with the cut blocking dependencies dropped. The
+// input is returned unchanged (not copied) when nothing is cut from it.
+func withoutCuts(issue model.Issue, cuts map[GraphEdge]bool) model.Issue {
+	hit := false
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type.IsBlocking() && cuts[GraphEdge{From: issue.ID, To: dep.DependsOnID}] {
+			hit = true
+			break
+		}
+	}
+	if !hit {
+		return issue
+	}
+	deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type.IsBlocking() && cuts[GraphEdge{From: issue.ID, To: dep.DependsOnID}] {
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	issue.Dependencies = deps
+	return issue
+}
+
+// longestOpenPath returns the longest chain of open beads, blockers first.
+// Cycles are condensed: every open member of a strongly connected component
+// has to be finished together, so the component counts once per member.
+func (a *Analyzer) longestOpenPath() []string {
+	open := func(id int64) bool {
+		issue, ok := a.issueMap[a.nodeToID[id]]
+		return ok && !isClosedLikeStatus(issue.Status)
+	}
+
+	sccs := topo.TarjanSCC(a.g)
+	compOf := make(map[int64]int, len(a.nodeToID))
+	members := make([][]string, len(sccs))
+	for c, scc := range sccs {
+		for _, n := range scc {
+			compOf[n.ID()] = c
+			if open(n.ID()) {
+				members[c] = append(members[c], a.nodeToID[n.ID()])
+			}
+		}
+		sort.Strings(members[c])
+	}
+
+	// Edge u -> v means u depends on v, so v comes first in the chain.
+	next := make([][]int, len(sccs))
+	for c, scc := range sccs {
+		for _, n := range scc {
+			if !open(n.ID()) {
+				continue
+			}
+			succ := a.g.To(n.ID())
+			for succ.Next() {
+				d := succ.Node().ID()
+				if dc := compOf[d]; dc != c && open(d) {
+					next[c] = append(next[c], dc)
+				}
+			}
+		}
+	}
+
+	best := make([]int, len(sccs))
+	via := make([]int, len(sccs))
+	done := make([]bool, len(sccs))
+	var visit func(c int) int
+	visit = func(c int) int {
+		if done[c] {
+			return best[c]
+		}
+		done[c] = true
+		via[c] = -1
+		tail := 0
+		for _, nc := range next[c] {
+			l := visit(nc)
+			if l > tail || (l == tail && via[c] >= 0 && len(members[nc]) > 0 && members[nc][0] < members[via[c]][0]) {
+				tail, via[c] = l, nc
+			}
+		}
+		best[c] = len(members[c]) + tail
+		return best[c]
+	}
+
+	start := -1
+	for c := range sccs {
+		if len(members[c]) == 0 {
+			continue
+		}
+		l := visit(c)
+		if start < 0 || l > best[start] || (l == best[start] && members[c][0] < members[start][0]) {
+			start = c
+		}
+	}
+	var path []string
+	for c := start; c >= 0; c = via[c] {
+		path = append(path, members[c]...)
+	}
+	return path
+}
+
+// compareCycleFixImpact reports how after differs from before.
+func compareCycleFixImpact(before, after CycleFixImpact) CycleFixDelta {
+	delta := CycleFixDelta{
+		Actionable:       after.ActionableCount - before.ActionableCount,
+		LongestPath:      after.LongestPath - before.LongestPath,
+		CyclicComponents: after.CyclicComponents - before.CyclicComponents,
+	}
+	was := make(map[string]bool, len(before.TopPicks))
+	for _, id := range before.TopPicks {
+		was[id] = true
+	}
+	is := make(map[string]bool, len(after.TopPicks))
+	for _, id := range after.TopPicks {
+		is[id] = true
+		if !was[id] {
+			delta.TopPicksAdded = append(delta.TopPicksAdded, id)
+		}
+	}
+	for _, id := range before.TopPicks {
+		if !is[id] {
+			delta.TopPicksDropped = append(delta.TopPicksDropped, id)
+		}
+	}
+	return delta
+}
+
+// minFeedbackArcSet returns the indices of a minimum set of edges whose
+// removal leaves the component acyclic, ignoring edge skip (-1 for none).
+// Graphs with more than exactLimit edges fall back to a greedy ordering, in
+// which case the set is minimal (no edge can be restored) but not minimum.
+func minFeedbackArcSet(n int, edges []cycleFixEdge, skip int, exactLimit int) ([]int, bool) {
+	removed := make([]bool, len(edges))
+	if skip >= 0 {
+		removed[skip] = true
+	}
+	// Self-loops are in every feedback arc set.
+	var forced, free []int
+	for i, e := range edges {
+		if i == skip {
+			continue
+		}
+		if e.u == e.v {
+			forced = append(forced, i)
+			removed[i] = true
+		} else {
+			free = append(free, i)
+		}
+	}
+	if isAcyclicLocal(n, edges, removed) {
+		return forced, true
+	}
+
+	if len(free) <= exactLimit {
+		combo := make([]int, 0, len(free))
+		var search func(start, k int) bool
+		search = func(start, k int) bool {
+			if k == 0 {
+				return isAcyclicLocal(n, edges, removed)
+			}
+			for i := start; i <= len(free)-k; i++ {
+				removed[free[i]] = true
+				combo = append(combo, free[i])
+				if search(i+1, k-1) {
+					return true
+				}
+				combo = combo[:len(combo)-1]
+				removed[free[i]] = false
+			}
+			return false
+		}
+		for k := 1; k <= len(free); k++ {
+			if search(0, k) {
+				return append(forced, combo...), true
+			}
+		}
+	}
+
+	cut := greedyFeedbackArcs(n, edges, removed)
+	for _, i := range cut {
+		removed[i] = true
+	}
+	// Prune: restore any arc that does not close a cycle on its own.
+	kept := forced
+	for _, i := range cut {
+		removed[i] = false
+		if !isAcyclicLocal(n, edges, removed) {
+			removed[i] = true
+			kept = append(kept, i)
+		}
+	}
+	return kept, false
+}
+
+// greedyFeedbackArcs orders nodes with the Eades–Lin–Smyth heuristic (sinks
+// last, sources first, otherwise the node with the largest out-in surplus)
+// and returns the edges that point backwards in that order.
+func greedyFeedbackArcs(n int, edges []cycleFixEdge, removed []bool) []int {
+	in := make([]int, n)
+	out := make([]int, n)
+	adjOut := make([][]int, n)
+	adjIn := make([][]int, n)
+	for i, e := range edges {
+		if removed[i] {
+			continue
+		}
+		out[e.u]++
+		in[e.v]++
+		adjOut[e.u] = append(adjOut[e.u], i)
+		adjIn[e.v] = append(adjIn[e.v], i)
+	}
+	gone := make([]bool, n)
+	drop := func(x int) {
+		gone[x] = true
+		for _, i := range adjOut[x] {
+			in[edges[i].v]--
+		}
+		for _, i := range adjIn[x] {
+			out[edges[i].u]--
+		}
+	}
+
+	var head, tail []int
+	for left := n; left > 0; {
+		progress := true
+		for progress {
+			progress = false
+			for x := 0; x < n; x++ {
+				if gone[x] {
+					continue
+				}
+				if out[x] == 0 {
+					tail = append(tail, x)
+					drop(x)
+					left--
+					progress = true
+				} else if in[x] == 0 {
+					head = append(head, x)
+					drop(x)
+					left--
+					progress = true
+				}
+			}
+		}
+		if left == 0 {
+			break
+		}
+		pick := -1
+		for x := 0; x < n; x++ {
+			if !gone[x] && (pick < 0 || out[x]-in[x] > out[pick]-in[pick]) {
+				pick = x
+			}
+		}
+		head = append(head, pick)
+		drop(pick)
+		left--
+	}
+
+	pos := make([]int, n)
+	for i, x := range head {
+		pos[x] = i
+	}
+	for i, x := range tail {
+		pos[x] = n - 1 - i
+	}
+	var back []int
+	for i, e := range edges {
+		if !removed[i] && pos[e.u] >= pos[e.v] {
+			back = append(back, i)
+		}
+	}
+	return back
+}
+
+// isAcyclicLocal runs Kahn's algorithm over the edges not marked removed.
+func isAcyclicLocal(n int, edges []cycleFixEdge, removed []bool) bool {
+	in := make([]int, n)
+	adj := make([][]int, n)
+	for i, e := range edges {
+		if removed[i] {
+			continue
+		}
+		if e.u == e.v {
+			return false
+		}
+		adj[e.u] = append(adj[e.u], e.v)
+		in[e.v]++
+	}
+	queue := make([]int, 0, n)
+	for x := 0; x < n; x++ {
+		if in[x] == 0 {
+			queue = append(queue, x)
+		}
+	}
+	seen := 0
+	for len(queue) > 0 {
+		x := queue[0]
+		queue = queue[1:]
+		seen++
+		for _, y := range adj[x] {
+			in[y]--
+			if in[y] == 0 {
+				queue = append(queue, y)
+			}
+		}
+	}
+	return seen == n
+}
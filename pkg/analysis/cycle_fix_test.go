@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+var cycleFixNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestGenerateCycleFix_Triangle(t *testing.T) {
+	// A -> B -> C -> A, and D waits on A.
+	issues := []model.Issue{
+		incIssue("A", "B"), incIssue("B", "C"), incIssue("C", "A"), incIssue("D", "A"), incIssue("E"),
+	}
+	plan := GenerateCycleFix(issues, CycleFixOptions{Now: cycleFixNow})
+
+	if plan.ComponentCount != 1 || len(plan.Components) != 1 {
+		t.Fatalf("components = %d, want 1", plan.ComponentCount)
+	}
+	comp := plan.Components[0]
+	if fmt.Sprint(comp.Members) != "[A B C]" || comp.EdgeCount != 3 || !comp.Exact {
+		t.Errorf("component = %+v", comp)
+	}
+	// Every edge breaks the triangle; A->B wins on collateral (only A waits on B).
+	if len(comp.FeedbackArcSet) != 1 || comp.FeedbackArcSet[0].Command != "bd dep remove A B" {
+		t.Errorf("feedback arc set = %+v", comp.FeedbackArcSet)
+	}
+	if fmt.Sprint(plan.Commands) != "[bd dep remove A B]" {
+		t.Errorf("commands = %v", plan.Commands)
+	}
+	if len(comp.Candidates) != 3 || !comp.Candidates[0].InFeedbackArcSet {
+		t.Fatalf("candidates = %+v", comp.Candidates)
+	}
+	for _, c := range comp.Candidates {
+		if c.RemainingCuts != 0 || c.Impact.CyclicComponents != 0 || c.Delta.Actionable != 1 {
+			t.Errorf("candidate %s->%s: %+v", c.From, c.To, c)
+		}
+	}
+
+	if plan.Before.CyclicComponents != 1 || plan.Before.ActionableCount != 1 || plan.Before.LongestPath != 4 {
+		t.Errorf("before = %+v", plan.Before)
+	}
+	// Without A->B, A is actionable and the chain is A <- C <- B.
+	if plan.After.CyclicComponents != 0 || plan.After.ActionableCount != 2 {
+		t.Errorf("after = %+v", plan.After)
+	}
+	if fmt.Sprint(plan.After.LongestPathIDs) != "[A C B]" || plan.Delta.LongestPath != -1 {
+		t.Errorf("longest path after = %v (delta %d)", plan.After.LongestPathIDs, plan.Delta.LongestPath)
+	}
+	if fmt.Sprint(plan.Delta.TopPicksAdded) != "[A]" {
+		t.Errorf("top picks added = %v", plan.Delta.TopPicksAdded)
+	}
+}
+
+func TestGenerateCycleFix_AcyclicAndSelfLoop(t *testing.T) {
+	plan := GenerateCycleFix([]model.Issue{incIssue("A", "B"), incIssue("B")}, CycleFixOptions{Now: cycleFixNow})
+	if plan.ComponentCount != 0 || len(plan.Commands) != 0 || plan.Before.LongestPath != 2 {
+		t.Errorf("acyclic plan = %+v", plan)
+	}
+
+	plan = GenerateCycleFix([]model.Issue{incIssue("A", "A"), incIssue("B", "A")}, CycleFixOptions{Now: cycleFixNow})
+	if fmt.Sprint(plan.Commands) != "[bd dep remove A A]" {
+		t.Errorf("self-loop commands = %v", plan.Commands)
+	}
+}
+
+func TestGenerateCycleFix_CapsComponentsAndCandidates(t *testing.T) {
+	var issues []model.Issue
+	for i := 0; i < 3; i++ {
+		a, b := fmt.Sprintf("x%d", i), fmt.Sprintf("y%d", i)
+		issues = append(issues, incIssue(a, b), incIssue(b, a))
+	}
+	// A 4-clique needs several cuts and has 12 candidate edges.
+	clique := []string{"k1", "k2", "k3", "k4"}
+	for _, id := range clique {
+		var deps []string
+		for _, other := range clique {
+			if other != id {
+				deps = append(deps, other)
+			}
+		}
+		issues = append(issues, incIssue(id, deps...))
+	}
+
+	plan := GenerateCycleFix(issues, CycleFixOptions{MaxComponents: 2, MaxCandidates: 4, Now: cycleFixNow})
+	if plan.ComponentCount != 4 || len(plan.Components) != 2 || !plan.Status.Capped {
+		t.Fatalf("component caps: count=%d planned=%d status=%+v", plan.ComponentCount, len(plan.Components), plan.Status)
+	}
+	k := plan.Components[0]
+	if len(k.Members) != 4 || len(k.FeedbackArcSet) != 6 {
+		t.Errorf("clique plan: members=%v fas=%d", k.Members, len(k.FeedbackArcSet))
+	}
+	if len(k.Candidates) != 4 || !k.CandidatesCapped {
+		t.Errorf("candidate cap: %d capped=%v", len(k.Candidates), k.CandidatesCapped)
+	}
+	if k.Impact.CyclicComponents != 3 {
+		t.Errorf("cutting the clique should leave the other 3 cycles, got %d", k.Impact.CyclicComponents)
+	}
+}
+
+// TestMinFeedbackArcSet_GreedyIsValid checks that the greedy fallback always
+// yields an acyclic graph and never beats the exact search.
+func TestMinFeedbackArcSet_GreedyIsValid(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 200; trial++ {
+		n := 2 + rng.Intn(6)
+		var edges []cycleFixEdge
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if u != v && rng.Float64() < 0.35 {
+					edges = append(edges, cycleFixEdge{u: u, v: v})
+				}
+			}
+		}
+		if len(edges) > 14 {
+			edges = edges[:14]
+		}
+		exact, isExact := minFeedbackArcSet(n, edges, -1, 16)
+		greedy, isGreedyExact := minFeedbackArcSet(n, edges, -1, 0)
+		if !isExact || (isGreedyExact && len(greedy) > 0) {
+			t.Fatalf("trial %d: exact flags wrong", trial)
+		}
+		for _, cut := range [][]int{exact, greedy} {
+			removed := make([]bool, len(edges))
+			for _, i := range cut {
+				removed[i] = true
+			}
+			if !isAcyclicLocal(n, edges, removed) {
+				t.Fatalf("trial %d: cut %v leaves a cycle in %v", trial, cut, edges)
+			}
+		}
+		if len(greedy) < len(exact) {
+			t.Fatalf("trial %d: greedy %d beat exact %d", trial, len(greedy), len(exact))
+		}
+	}
+}
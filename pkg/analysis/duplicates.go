@@ -203,6 +203,50 @@ func DetectDuplicates(issues []model.Issue, config DuplicateConfig) []Suggestion
 	return suggestions
 }
 
+// MergeDuplicateSuggestions combines duplicate suggestions from several
+// methods. A pair reported more than once keeps its highest-confidence
+// suggestion, with the methods joined in its "method" tag (e.g.
+// "jaccard+semantic").
+func MergeDuplicateSuggestions(first, second []Suggestion) []Suggestion {
+	pairKey := func(s Suggestion) [2]string {
+		if s.TargetBead < s.RelatedBead {
+			return [2]string{s.TargetBead, s.RelatedBead}
+		}
+		return [2]string{s.RelatedBead, s.TargetBead}
+	}
+	methodOf := func(s Suggestion) string {
+		m, _ := s.Metadata["method"].(string)
+		return m
+	}
+
+	merged := make([]Suggestion, 0, len(first)+len(second))
+	byPair := make(map[[2]string]int)
+	for _, sug := range append(append([]Suggestion{}, first...), second...) {
+		key := pairKey(sug)
+		i, ok := byPair[key]
+		if !ok {
+			byPair[key] = len(merged)
+			merged = append(merged, sug)
+			continue
+		}
+		methods := methodOf(merged[i])
+		if m := methodOf(sug); m != "" && !strings.Contains(methods, m) {
+			methods += "+" + m
+		}
+		if sug.Confidence > merged[i].Confidence {
+			merged[i] = sug
+		}
+		// Copy so the inputs' shared metadata maps aren't modified
+		meta := make(map[string]interface{}, len(merged[i].Metadata)+1)
+		for k, v := range merged[i].Metadata {
+			meta[k] = v
+		}
+		meta["method"] = methods
+		merged[i].Metadata = meta
+	}
+	return merged
+}
+
 // intersectKeywords finds common strings between two sorted/unsorted slices.
 // Since extractKeywords returns unsorted unique lists, we can use a map or loops.
 // Since we only call this on high-similarity pairs, performance is less critical than the main loop.
@@ -636,3 +636,41 @@ func TestDetectDuplicates_LargeIssueSet(t *testing.T) {
 		t.Error("Should find at least one duplicate pair")
 	}
 }
+
+// ============================================================================
+// MergeDuplicateSuggestions Tests
+// ============================================================================
+
+func TestMergeDuplicateSuggestions(t *testing.T) {
+	jaccard := []Suggestion{
+		NewSuggestion(SuggestionPotentialDuplicate, "A", "dup", "keywords", 0.8).
+			WithRelatedBead("B").WithMetadata("method", "jaccard"),
+		NewSuggestion(SuggestionPotentialDuplicate, "C", "dup", "keywords", 0.75).
+			WithRelatedBead("D").WithMetadata("method", "jaccard"),
+	}
+	semantic := []Suggestion{
+		// Same pair as A-B, reported the other way round
+		NewSuggestion(SuggestionPotentialDuplicate, "B", "dup", "embedding", 0.93).
+			WithRelatedBead("A").WithMetadata("method", "semantic"),
+		NewSuggestion(SuggestionPotentialDuplicate, "E", "dup", "embedding", 0.91).
+			WithRelatedBead("F").WithMetadata("method", "semantic"),
+	}
+
+	merged := MergeDuplicateSuggestions(jaccard, semantic)
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %d", len(merged))
+	}
+	ab := merged[0]
+	if ab.Confidence != 0.93 || ab.TargetBead != "B" {
+		t.Errorf("Expected the higher-confidence semantic suggestion for A-B, got %+v", ab)
+	}
+	if ab.Metadata["method"] != "jaccard+semantic" {
+		t.Errorf("Expected method jaccard+semantic, got %v", ab.Metadata["method"])
+	}
+	if merged[1].Metadata["method"] != "jaccard" || merged[2].Metadata["method"] != "semantic" {
+		t.Errorf("Unmatched pairs should keep their method: %v, %v", merged[1].Metadata, merged[2].Metadata)
+	}
+	if semantic[0].Metadata["method"] != "semantic" {
+		t.Error("Inputs should not be modified")
+	}
+}
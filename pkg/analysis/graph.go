@@ -1173,6 +1173,7 @@ type Analyzer struct {
 	blockerCounts    []int
 	blockerCountsMax int
 	config           *AnalysisConfig // Optional custom config, nil means use size-based defaults
+	scoring          *ScoringProfile // Optional scoring profile, nil means the built-in weights
 }
 
 // SetConfig sets a custom analysis configuration.
@@ -1181,6 +1182,12 @@ func (a *Analyzer) SetConfig(config *AnalysisConfig) {
 	a.config = config
 }
 
+// SetScoringProfile sets the weights and label multipliers used for impact
+// scoring. Pass nil to use the built-in weights.
+func (a *Analyzer) SetScoringProfile(profile *ScoringProfile) {
+	a.scoring = profile
+}
+
 func (a *Analyzer) graphStructureHash() string {
 	if a == nil || a.g == nil {
 		return "none"
@@ -1327,15 +1334,15 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 // If SetConfig was called, uses that config. Otherwise uses ConfigForSize() to
 // automatically select appropriate algorithms based on graph size.
 func (a *Analyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
-	var config AnalysisConfig
+	return a.AnalyzeAsyncWithConfig(ctx, a.effectiveConfig())
+}
+
+// effectiveConfig returns the SetConfig override or the size-based default.
+func (a *Analyzer) effectiveConfig() AnalysisConfig {
 	if a.config != nil {
-		config = *a.config
-	} else {
-		nodeCount := len(a.issueMap)
-		edgeCount := a.g.Edges().Len()
-		config = ConfigForSize(nodeCount, edgeCount)
+		return *a.config
 	}
-	return a.AnalyzeAsyncWithConfig(ctx, config)
+	return ConfigForSize(len(a.issueMap), a.g.Edges().Len())
 }
 
 // AnalyzeAsyncWithConfig performs graph analysis with a custom configuration.
@@ -1425,19 +1432,7 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	// This avoids goroutine overhead when Phase 2 results aren't needed
 	// (e.g., all issues are closed, so no triage scoring required).
 	if config.AllPhase2Disabled() {
-		stats.status = MetricStatus{
-			PageRank:     statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Betweenness:  statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Eigenvector:  statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			HITS:         statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Critical:     statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Cycles:       statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			KCore:        statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Articulation: statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-			Slack:        statusEntry{State: "skipped", Reason: "all phase 2 disabled"},
-		}
-		stats.phase2Ready = true
-		close(stats.phase2Done)
+		stats.skipPhase2()
 		return stats
 	}
 
@@ -1902,6 +1897,52 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	stats.mu.Unlock()
 }
 
+// skipPhase2 marks every Phase 2 metric skipped and releases waiters.
+func (s *GraphStats) skipPhase2() {
+	skipped := statusEntry{State: "skipped", Reason: "all phase 2 disabled"}
+	s.status = MetricStatus{
+		PageRank:     skipped,
+		Betweenness:  skipped,
+		Eigenvector:  skipped,
+		HITS:         skipped,
+		Critical:     skipped,
+		Cycles:       skipped,
+		KCore:        skipped,
+		Articulation: skipped,
+		Slack:        skipped,
+	}
+	s.phase2Ready = true
+	close(s.phase2Done)
+}
+
+// recoverPhase2Panic marks every metric failed when a Phase 2 goroutine
+// panics, so waiters see a terminal state. It must be deferred directly.
+func (s *GraphStats) recoverPhase2Panic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reason := fmt.Sprintf("panic: %v", r)
+	failEntry := statusEntry{State: "panic", Reason: reason}
+
+	// Mark all as failed so UI knows
+	s.status = MetricStatus{
+		PageRank:     failEntry,
+		Betweenness:  failEntry,
+		Eigenvector:  failEntry,
+		HITS:         failEntry,
+		Critical:     failEntry,
+		Cycles:       failEntry,
+		KCore:        failEntry,
+		Articulation: failEntry,
+		Slack:        failEntry,
+	}
+	s.phase2Ready = true
+}
+
 // computePhase1 calculates fast metrics synchronously.
 func (a *Analyzer) computePhase1(stats *GraphStats) {
 	nodes := a.g.Nodes()
@@ -1922,14 +1963,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 	}
 
 	// Topological Sort (execution order)
-	// Note: In our graph model, edge u -> v means u depends on v, so we reverse
-	// topo.Sort's output to get dependencies-first ordering.
-	sorted, err := topo.Sort(a.g)
-	if err == nil {
-		for i := len(sorted) - 1; i >= 0; i-- {
-			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
-		}
-	}
+	stats.TopologicalOrder = a.topologicalOrder()
 
 	// Density
 	n := float64(len(a.issueMap))
@@ -1943,6 +1977,22 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 	stats.outDegreeRank = computeIntRanks(stats.OutDegree)
 }
 
+// topologicalOrder returns issue IDs dependencies-first, or nil if the graph
+// has a cycle.
+// Note: In our graph model, edge u -> v means u depends on v, so we reverse
+// topo.Sort's output to get dependencies-first ordering.
+func (a *Analyzer) topologicalOrder() []string {
+	sorted, err := topo.Sort(a.g)
+	if err != nil {
+		return nil
+	}
+	order := make([]string, 0, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		order = append(order, a.nodeToID[sorted[i].ID()])
+	}
+	return order
+}
+
 // computePhase2 calculates expensive metrics in background.
 // Computes to local variables first, then atomically assigns under lock.
 // Respects the config to skip expensive algorithms for large graphs.
@@ -1950,29 +2000,7 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 	defer close(stats.phase2Done)
 
 	// Recover from panics to prevent crashing the entire application
-	defer func() {
-		if r := recover(); r != nil {
-			stats.mu.Lock()
-			defer stats.mu.Unlock()
-
-			reason := fmt.Sprintf("panic: %v", r)
-			failEntry := statusEntry{State: "panic", Reason: reason}
-
-			// Mark all as failed so UI knows
-			stats.status = MetricStatus{
-				PageRank:     failEntry,
-				Betweenness:  failEntry,
-				Eigenvector:  failEntry,
-				HITS:         failEntry,
-				Critical:     failEntry,
-				Cycles:       failEntry,
-				KCore:        failEntry,
-				Articulation: failEntry,
-				Slack:        failEntry,
-			}
-			stats.phase2Ready = true
-		}
-	}()
+	defer stats.recoverPhase2Panic()
 
 	// Use the profiled version logic to avoid duplication
 	// We discard the profile data as this is the standard run
@@ -2356,13 +2384,13 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 	}
 
 	// Phase 2: Build parent→children index for transitive propagation.
-	// In the dependency model, a child issue has a dep with Type=="parent-child"
-	// and DependsOnID pointing to the parent. So we invert: for each such dep,
+	// In the dependency model, a child issue has a hierarchy dep (parent-child
+	// or a custom hierarchy type) with DependsOnID pointing to the parent. So we invert: for each such dep,
 	// the parent (DependsOnID) has the child (issue.ID).
 	childrenOf := make(map[string][]string)
 	for _, issue := range a.issueMap {
 		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepParentChild {
+			if dep != nil && dep.Type.IsHierarchy() {
 				if _, exists := a.issueMap[dep.DependsOnID]; exists {
 					childrenOf[dep.DependsOnID] = append(childrenOf[dep.DependsOnID], issue.ID)
 				}
@@ -2621,6 +2649,14 @@ func (a *Analyzer) countBlockedBy(issueID string) int {
 // It uses a deterministic power iteration with damping factor damp and terminates
 // when the L2 norm of the delta is below tol (or after a hard iteration cap).
 func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
+	return computePageRankWarm(g, damp, tol, nil)
+}
+
+// computePageRankWarm is computePageRank seeded with init instead of the
+// uniform vector. Nodes missing from init start at 1/n and the seed is
+// renormalized, so a previous result for a slightly different graph converges
+// in a handful of iterations.
+func computePageRankWarm(g graph.Directed, damp, tol float64, init map[int64]float64) map[int64]float64 {
 	nodes := graph.NodesOf(g.Nodes())
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
 	if len(nodes) == 0 {
@@ -2682,6 +2718,18 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	for i := range rank {
 		rank[i] = uniform
 	}
+	if init != nil {
+		sum := 0.0
+		for i, node := range nodes {
+			if v, ok := init[node.ID()]; ok && v > 0 {
+				rank[i] = v
+			}
+			sum += rank[i]
+		}
+		for i := range rank {
+			rank[i] /= sum
+		}
+	}
 	next := make([]float64, len(nodes))
 
 	base := (1 - damp) / n
@@ -2732,12 +2780,21 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 
 // computeEigenvector runs a simple power-iteration to estimate eigenvector centrality.
 func computeEigenvector(g graph.Directed) map[int64]float64 {
+	res, _ := computeEigenvectorWarm(g, nil, 0)
+	return res
+}
+
+// computeEigenvectorWarm runs the eigenvector power iteration starting from
+// init (uniform when nil). With tol > 0 it stops as soon as the L2 change
+// between iterations drops below tol and reports whether that happened; with
+// tol == 0 it always runs the fixed iteration count, like computeEigenvector.
+func computeEigenvectorWarm(g graph.Directed, init map[int64]float64, tol float64) (map[int64]float64, bool) {
 	nodeList := graph.NodesOf(g.Nodes())
 	sort.Slice(nodeList, func(i, j int) bool {
 		return nodeList[i].ID() < nodeList[j].ID()
 	})
 	if len(nodeList) == 0 {
-		return nil
+		return nil, true
 	}
 
 	// In this codebase, node IDs are densely allocated by gonum (0..n-1), so we
@@ -2794,8 +2851,16 @@ func computeEigenvector(g graph.Directed) map[int64]float64 {
 	for i := range vec {
 		vec[i] = 1.0 / float64(n)
 	}
+	if init != nil {
+		// Keep the uniform floor so every node has a positive start: a seed
+		// that is zero on some component would converge to the wrong vector.
+		for i, node := range nodeList {
+			vec[i] += init[node.ID()]
+		}
+	}
 	work := make([]float64, n)
 
+	converged := false
 	const iterations = 50
 	for iter := 0; iter < iterations; iter++ {
 		for i := range work {
@@ -2814,8 +2879,16 @@ func computeEigenvector(g graph.Directed) map[int64]float64 {
 			break
 		}
 		norm := 1 / math.Sqrt(sum)
+		diff := 0.0
 		for i := range work {
-			vec[i] = work[i] * norm
+			next := work[i] * norm
+			d := next - vec[i]
+			diff += d * d
+			vec[i] = next
+		}
+		if tol > 0 && math.Sqrt(diff) < tol {
+			converged = true
+			break
 		}
 	}
 
@@ -2823,7 +2896,7 @@ func computeEigenvector(g graph.Directed) map[int64]float64 {
 	for i, node := range nodeList {
 		res[node.ID()] = vec[i]
 	}
-	return res
+	return res, converged
 }
 
 // computeFloatRanks computes rankings for a float map (descending).
@@ -269,6 +269,39 @@ func TestGetActionableIssuesParentChildDoesntBlock(t *testing.T) {
 	}
 }
 
+func TestGetActionableIssuesCustomDependencyTypes(t *testing.T) {
+	// "waits-for" blocks, "tracks" is a hierarchy, "duplicates" is informational.
+	model.SetWorkflow(&model.Workflow{DependencyTypes: map[model.DependencyType]model.DependencyTypeDef{
+		"waits-for":  {Blocking: true},
+		"tracks":     {Hierarchy: true},
+		"duplicates": {},
+	}})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: "waits-for"},
+		}},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "A", Type: "tracks"},
+		}},
+		{ID: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: "duplicates"},
+		}},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+	ids := getIDs(an.GetActionableIssues())
+	// A waits for B; C inherits A's blocked state through the hierarchy.
+	if len(ids) != 2 || ids[0] != "B" || ids[1] != "D" {
+		t.Errorf("Expected B and D actionable, got %v", ids)
+	}
+	if got := an.Analyze().OutDegree["A"]; got != 1 {
+		t.Errorf("waits-for should be a graph edge, A out-degree = %d", got)
+	}
+}
+
 func TestGetActionableIssuesCycle(t *testing.T) {
 	// Cycle: A -> B -> C -> A (all block each other)
 	// All are blocked (none actionable)
@@ -0,0 +1,767 @@
+package analysis
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultIncrementalMaxDeltaRatio is the share of nodes+edges a delta may touch
+// before IncrementalAnalyzer gives up and recomputes from scratch.
+const DefaultIncrementalMaxDeltaRatio = 0.25
+
+// GraphEdge is a blocking dependency edge: From depends on To.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphDelta is the issue-level difference between two analyzer inputs.
+// Only blocking dependencies are edges, matching NewAnalyzer.
+type GraphDelta struct {
+	AddedNodes   []string    `json:"added_nodes,omitempty"`
+	RemovedNodes []string    `json:"removed_nodes,omitempty"`
+	ChangedNodes []string    `json:"changed_nodes,omitempty"` // content-only changes
+	AddedEdges   []GraphEdge `json:"added_edges,omitempty"`
+	RemovedEdges []GraphEdge `json:"removed_edges,omitempty"`
+}
+
+// Structural reports whether the graph shape changed. Graph metrics depend
+// only on structure, so a non-structural delta leaves every metric intact.
+func (d GraphDelta) Structural() bool {
+	return len(d.AddedNodes)+len(d.RemovedNodes)+len(d.AddedEdges)+len(d.RemovedEdges) > 0
+}
+
+// Size returns the number of structural changes in the delta.
+func (d GraphDelta) Size() int {
+	return len(d.AddedNodes) + len(d.RemovedNodes) + len(d.AddedEdges) + len(d.RemovedEdges)
+}
+
+// ComputeGraphDelta compares the dependency graphs of prev and next. When diff
+// is non-nil its ContentChanged IDs (present in both graphs) become
+// ChangedNodes; otherwise ChangedNodes is left empty.
+func ComputeGraphDelta(prev, next *Analyzer, diff *IssueDiff) GraphDelta {
+	var d GraphDelta
+	if prev == nil || next == nil {
+		return d
+	}
+
+	for id := range next.idToNode {
+		if _, ok := prev.idToNode[id]; !ok {
+			d.AddedNodes = append(d.AddedNodes, id)
+		}
+	}
+	for id, u := range prev.idToNode {
+		if _, ok := next.idToNode[id]; ok {
+			continue
+		}
+		d.RemovedNodes = append(d.RemovedNodes, id)
+		for _, dep := range prev.dependencyIDs(u) {
+			d.RemovedEdges = append(d.RemovedEdges, GraphEdge{From: id, To: dep})
+		}
+	}
+
+	for id, u := range next.idToNode {
+		nextDeps := next.dependencyIDs(u)
+		var prevDeps []string
+		if pu, ok := prev.idToNode[id]; ok {
+			prevDeps = prev.dependencyIDs(pu)
+		}
+		if slices.Equal(nextDeps, prevDeps) {
+			continue
+		}
+		i, j := 0, 0
+		for i < len(prevDeps) || j < len(nextDeps) {
+			switch {
+			case j == len(nextDeps) || (i < len(prevDeps) && prevDeps[i] < nextDeps[j]):
+				d.RemovedEdges = append(d.RemovedEdges, GraphEdge{From: id, To: prevDeps[i]})
+				i++
+			case i == len(prevDeps) || nextDeps[j] < prevDeps[i]:
+				d.AddedEdges = append(d.AddedEdges, GraphEdge{From: id, To: nextDeps[j]})
+				j++
+			default:
+				i++
+				j++
+			}
+		}
+	}
+
+	if diff != nil {
+		for _, id := range diff.ContentChanged {
+			_, inPrev := prev.idToNode[id]
+			_, inNext := next.idToNode[id]
+			if inPrev && inNext {
+				d.ChangedNodes = append(d.ChangedNodes, id)
+			}
+		}
+	}
+
+	sort.Strings(d.AddedNodes)
+	sort.Strings(d.RemovedNodes)
+	sort.Strings(d.ChangedNodes)
+	sortEdges(d.AddedEdges)
+	sortEdges(d.RemovedEdges)
+	return d
+}
+
+func sortEdges(edges []GraphEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+}
+
+// dependencyIDs returns the sorted IDs node u has blocking edges to.
+func (a *Analyzer) dependencyIDs(u int64) []string {
+	it := a.g.From(u)
+	if it.Len() == 0 {
+		return nil
+	}
+	ids := make([]string, 0, it.Len())
+	for it.Next() {
+		ids = append(ids, a.nodeToID[it.Node().ID()])
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// MetricChanges reports which metrics an incremental update may have changed,
+// so consumers can skip refreshing views whose inputs did not move.
+// Phase 1 flags and CriticalPath/Cycles are exact; the remaining Phase 2 flags
+// are set whenever the metric had to be recomputed.
+type MetricChanges struct {
+	// Full is true when the update fell back to a from-scratch analysis.
+	Full  bool       `json:"full"`
+	Delta GraphDelta `json:"delta"`
+
+	Degree           bool     `json:"degree"`
+	DegreeChanged    []string `json:"degree_changed,omitempty"`
+	TopologicalOrder bool     `json:"topological_order"`
+	Cycles           bool     `json:"cycles"`
+	CriticalPath     bool     `json:"critical_path"`
+	PageRank         bool     `json:"pagerank"`
+	Betweenness      bool     `json:"betweenness"`
+	Eigenvector      bool     `json:"eigenvector"`
+	HITS             bool     `json:"hits"`
+	// Advanced covers k-core, articulation points and slack.
+	Advanced bool `json:"advanced"`
+}
+
+// Phase1Changed reports whether any Phase 1 metric changed.
+func (c MetricChanges) Phase1Changed() bool {
+	return c.Full || c.Degree || c.TopologicalOrder
+}
+
+// Phase2Changed reports whether any Phase 2 metric changed.
+func (c MetricChanges) Phase2Changed() bool {
+	return c.Full || c.Cycles || c.CriticalPath || c.PageRank || c.Betweenness ||
+		c.Eigenvector || c.HITS || c.Advanced
+}
+
+// Any reports whether any metric changed.
+func (c MetricChanges) Any() bool {
+	return c.Phase1Changed() || c.Phase2Changed()
+}
+
+func fullMetricChanges(delta GraphDelta) MetricChanges {
+	return MetricChanges{
+		Full:             true,
+		Delta:            delta,
+		Degree:           true,
+		TopologicalOrder: true,
+		Cycles:           true,
+		CriticalPath:     true,
+		PageRank:         true,
+		Betweenness:      true,
+		Eigenvector:      true,
+		HITS:             true,
+		Advanced:         true,
+	}
+}
+
+// IncrementalAnalyzer carries graph metrics from one analyzer to the next.
+//
+// Given a new Analyzer it diffs the dependency graph against the previous one
+// and then:
+//   - reuses the previous GraphStats outright when only issue content changed;
+//   - patches degrees, repairs the topological order (Pearce-Kelly) and
+//     re-propagates critical-path heights from the touched nodes only;
+//   - proves acyclicity from the repaired order, skipping cycle enumeration;
+//   - warm-starts PageRank and eigenvector from the previous vectors.
+//
+// Betweenness, HITS, k-core, articulation points and slack have no cheap
+// incremental form and are recomputed when the structure changes. Large
+// deltas, config changes and a previous analysis still in flight fall back to
+// a full AnalyzeAsyncWithConfig.
+//
+// IncrementalAnalyzer is safe for concurrent use, but updates are serialized.
+type IncrementalAnalyzer struct {
+	// MaxDeltaRatio overrides DefaultIncrementalMaxDeltaRatio when positive.
+	MaxDeltaRatio float64
+
+	mu         sync.Mutex
+	prev       *Analyzer
+	prevStats  *GraphStats
+	configHash string
+}
+
+// NewIncrementalAnalyzer returns an empty incremental analyzer. The first
+// Update always performs a full analysis.
+func NewIncrementalAnalyzer() *IncrementalAnalyzer {
+	return &IncrementalAnalyzer{}
+}
+
+// Reset drops the carried state so the next Update is a full analysis.
+func (ia *IncrementalAnalyzer) Reset() {
+	ia.mu.Lock()
+	defer ia.mu.Unlock()
+	ia.prev = nil
+	ia.prevStats = nil
+	ia.configHash = ""
+}
+
+// Update analyzes next using its effective configuration (SetConfig or the
+// size-based default). diff is optional and only feeds MetricChanges.Delta.
+func (ia *IncrementalAnalyzer) Update(ctx context.Context, next *Analyzer, diff *IssueDiff) (*GraphStats, MetricChanges) {
+	return ia.UpdateWithConfig(ctx, next, next.effectiveConfig(), diff)
+}
+
+// UpdateWithConfig is Update with an explicit configuration. As with
+// AnalyzeAsync, Phase 2 may still be running when it returns.
+func (ia *IncrementalAnalyzer) UpdateWithConfig(ctx context.Context, next *Analyzer, config AnalysisConfig, diff *IssueDiff) (*GraphStats, MetricChanges) {
+	ia.mu.Lock()
+	defer ia.mu.Unlock()
+
+	configHash := ComputeConfigHash(&config)
+	var (
+		stats   *GraphStats
+		changes MetricChanges
+		ok      bool
+	)
+	if ia.canReuse(next, configHash) {
+		delta := ComputeGraphDelta(ia.prev, next, diff)
+		switch {
+		case !delta.Structural():
+			stats, changes, ok = ia.prevStats, MetricChanges{Delta: delta}, true
+		case !ia.deltaTooLarge(next, delta):
+			stats, changes, ok = next.analyzeDelta(ctx, ia.prevStats, config, delta)
+		}
+		if !ok {
+			changes = fullMetricChanges(delta)
+		}
+	} else {
+		changes = fullMetricChanges(GraphDelta{})
+	}
+	if !ok {
+		stats = next.AnalyzeAsyncWithConfig(ctx, config)
+	}
+
+	ia.prev = next
+	ia.prevStats = stats
+	ia.configHash = configHash
+	return stats, changes
+}
+
+func (ia *IncrementalAnalyzer) canReuse(next *Analyzer, configHash string) bool {
+	if ia.prev == nil || ia.prevStats == nil || next == nil {
+		return false
+	}
+	if configHash != ia.configHash || !ia.prevStats.IsPhase2Ready() {
+		return false
+	}
+	return len(ia.prev.issueMap) > 0 && len(next.issueMap) > 0
+}
+
+func (ia *IncrementalAnalyzer) deltaTooLarge(next *Analyzer, delta GraphDelta) bool {
+	ratio := ia.MaxDeltaRatio
+	if ratio <= 0 {
+		ratio = DefaultIncrementalMaxDeltaRatio
+	}
+	total := len(next.issueMap) + next.g.Edges().Len()
+	return float64(delta.Size()) > ratio*float64(total)
+}
+
+// analyzeDelta derives stats for a (structurally changed) a from prev and the
+// delta between them. It returns ok=false when the delta cannot be applied
+// (e.g. duplicate issue IDs) and the caller must do a full analysis.
+func (a *Analyzer) analyzeDelta(ctx context.Context, prev *GraphStats, config AnalysisConfig, delta GraphDelta) (*GraphStats, MetricChanges, bool) {
+	nodeCount := len(a.issueMap)
+	if a.g.Nodes().Len() != nodeCount {
+		return nil, MetricChanges{}, false
+	}
+	edgeCount := a.g.Edges().Len()
+
+	order, acyclic, ok := a.repairTopologicalOrder(prev.TopologicalOrder, delta)
+	if !ok {
+		return nil, MetricChanges{}, false
+	}
+
+	stats := &GraphStats{
+		NodeCount:  nodeCount,
+		EdgeCount:  edgeCount,
+		Config:     config,
+		phase2Done: make(chan struct{}),
+		status: MetricStatus{
+			PageRank:     statusEntry{State: "pending"},
+			Betweenness:  statusEntry{State: "pending"},
+			Eigenvector:  statusEntry{State: "pending"},
+			HITS:         statusEntry{State: "pending"},
+			Critical:     statusEntry{State: "pending"},
+			Cycles:       statusEntry{State: "pending"},
+			KCore:        statusEntry{State: "pending"},
+			Articulation: statusEntry{State: "pending"},
+			Slack:        statusEntry{State: "pending"},
+		},
+	}
+	changes := MetricChanges{Delta: delta}
+
+	// Phase 1: degrees, topological order, density.
+	stats.InDegree, stats.OutDegree, changes.DegreeChanged = updateDegrees(prev, delta)
+	changes.Degree = len(changes.DegreeChanged) > 0 || len(delta.RemovedNodes) > 0
+	if changes.Degree {
+		stats.inDegreeRank = computeIntRanks(stats.InDegree)
+		stats.outDegreeRank = computeIntRanks(stats.OutDegree)
+	} else {
+		stats.inDegreeRank = prev.inDegreeRank
+		stats.outDegreeRank = prev.outDegreeRank
+	}
+	if acyclic {
+		stats.TopologicalOrder = order
+	}
+	changes.TopologicalOrder = !slices.Equal(stats.TopologicalOrder, prev.TopologicalOrder)
+	if nodeCount > 1 {
+		stats.Density = float64(edgeCount) / (float64(nodeCount) * float64(nodeCount-1))
+	}
+
+	if config.AllPhase2Disabled() {
+		stats.skipPhase2()
+		return stats, changes, true
+	}
+
+	prev.mu.RLock()
+	prevCritical := prev.criticalPathScore
+	prevCycles := len(prev.cycles)
+	prev.mu.RUnlock()
+
+	// Critical path heights only move along the dependency chains below the
+	// touched nodes, so they are cheap enough to settle synchronously.
+	criticalPath := make(map[string]float64)
+	if config.ComputeCriticalPath && acyclic {
+		criticalPath = a.updateHeights(order, prevCritical, delta)
+	}
+	changes.CriticalPath = config.ComputeCriticalPath && !floatMapsEqual(criticalPath, prevCritical)
+
+	// A valid topological order proves there are no multi-node cycles; only
+	// self-loops (which topo.Sort tolerates) still need enumeration.
+	cyclic := !acyclic || a.hasSelfLoop()
+	changes.Cycles = config.ComputeCycles && (cyclic || prevCycles > 0)
+
+	changes.PageRank = config.ComputePageRank
+	changes.Betweenness = config.ComputeBetweenness
+	changes.Eigenvector = config.ComputeEigenvector
+	changes.HITS = config.ComputeHITS
+	changes.Advanced = config.ComputeKCore || config.ComputeArticulation || config.ComputeSlack
+
+	go a.computePhase2Incremental(ctx, stats, prev, config, criticalPath, cyclic)
+
+	return stats, changes, true
+}
+
+// updateDegrees patches prev's degree maps with the delta and returns the IDs
+// whose degree changed (including added nodes).
+func updateDegrees(prev *GraphStats, delta GraphDelta) (in, out map[string]int, changed []string) {
+	in = make(map[string]int, len(prev.InDegree)+len(delta.AddedNodes))
+	out = make(map[string]int, len(prev.OutDegree)+len(delta.AddedNodes))
+	for id, v := range prev.InDegree {
+		in[id] = v
+	}
+	for id, v := range prev.OutDegree {
+		out[id] = v
+	}
+	for _, id := range delta.RemovedNodes {
+		delete(in, id)
+		delete(out, id)
+	}
+	for _, id := range delta.AddedNodes {
+		in[id] = 0
+		out[id] = 0
+	}
+
+	touched := make(map[string]bool)
+	for _, e := range delta.RemovedEdges {
+		if _, ok := out[e.From]; ok {
+			out[e.From]--
+			touched[e.From] = true
+		}
+		if _, ok := in[e.To]; ok {
+			in[e.To]--
+			touched[e.To] = true
+		}
+	}
+	for _, e := range delta.AddedEdges {
+		out[e.From]++
+		in[e.To]++
+		touched[e.From] = true
+		touched[e.To] = true
+	}
+	for _, id := range delta.AddedNodes {
+		touched[id] = true
+	}
+
+	for id := range touched {
+		pi, hadIn := prev.InDegree[id]
+		po, hadOut := prev.OutDegree[id]
+		if !hadIn || !hadOut || pi != in[id] || po != out[id] {
+			changed = append(changed, id)
+		}
+	}
+	sort.Strings(changed)
+	return in, out, changed
+}
+
+// repairTopologicalOrder updates a dependencies-first order for the delta
+// using the Pearce-Kelly dynamic topological sort: surviving nodes keep their
+// relative order, new nodes are appended, and each added edge that violates
+// the order reorders only the nodes between its endpoints.
+//
+// acyclic is false when an added edge closes a cycle. ok is false when the
+// previous order cannot be used (e.g. it was empty because of a cycle), in
+// which case the order is recomputed from scratch.
+func (a *Analyzer) repairTopologicalOrder(prevOrder []string, delta GraphDelta) (order []string, acyclic bool, ok bool) {
+	n := len(a.nodeToID)
+	if len(prevOrder) == 0 {
+		fresh := a.topologicalOrder()
+		return fresh, fresh != nil, true
+	}
+
+	nodes := make([]int64, 0, n)
+	for _, id := range prevOrder {
+		if u, exists := a.idToNode[id]; exists {
+			nodes = append(nodes, u)
+		}
+	}
+	for _, id := range delta.AddedNodes {
+		nodes = append(nodes, a.idToNode[id])
+	}
+	if len(nodes) != n {
+		return nil, false, false
+	}
+	pos := make([]int, n)
+	for i, u := range nodes {
+		pos[u] = i
+	}
+
+	// Edges not yet inserted are invisible to the searches below, which keeps
+	// the invariant that the order is valid for every edge they traverse.
+	type edgeKey [2]int64
+	pending := make(map[edgeKey]bool, len(delta.AddedEdges))
+	for _, e := range delta.AddedEdges {
+		pending[edgeKey{a.idToNode[e.From], a.idToNode[e.To]}] = true
+	}
+
+	for _, e := range delta.AddedEdges {
+		u, v := a.idToNode[e.From], a.idToNode[e.To]
+		delete(pending, edgeKey{u, v})
+		// u depends on v, so v must come first.
+		if u == v || pos[v] < pos[u] {
+			continue
+		}
+		lb, ub := pos[u], pos[v]
+
+		// Forward: u and everything (transitively) depending on it, up to ub.
+		visited := map[int64]bool{u: true}
+		forward := []int64{u}
+		for stack := []int64{u}; len(stack) > 0; {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			it := a.g.To(w)
+			for it.Next() {
+				x := it.Node().ID()
+				if pending[edgeKey{x, w}] {
+					continue
+				}
+				if x == v {
+					return nil, false, true
+				}
+				if !visited[x] && pos[x] < ub {
+					visited[x] = true
+					forward = append(forward, x)
+					stack = append(stack, x)
+				}
+			}
+		}
+
+		// Backward: v and everything it (transitively) depends on, down to lb.
+		visited[v] = true
+		backward := []int64{v}
+		for stack := []int64{v}; len(stack) > 0; {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			it := a.g.From(w)
+			for it.Next() {
+				y := it.Node().ID()
+				if pending[edgeKey{w, y}] {
+					continue
+				}
+				if !visited[y] && pos[y] > lb {
+					visited[y] = true
+					backward = append(backward, y)
+					stack = append(stack, y)
+				}
+			}
+		}
+
+		byPos := func(s []int64) {
+			sort.Slice(s, func(i, j int) bool { return pos[s[i]] < pos[s[j]] })
+		}
+		byPos(forward)
+		byPos(backward)
+		slots := make([]int, 0, len(forward)+len(backward))
+		for _, w := range backward {
+			slots = append(slots, pos[w])
+		}
+		for _, w := range forward {
+			slots = append(slots, pos[w])
+		}
+		sort.Ints(slots)
+		for i, w := range append(backward, forward...) {
+			nodes[slots[i]] = w
+			pos[w] = slots[i]
+		}
+	}
+
+	order = make([]string, n)
+	for i, u := range nodes {
+		order[i] = a.nodeToID[u]
+	}
+	return order, true, true
+}
+
+// updateHeights recomputes critical-path heights (1 + the tallest dependent)
+// starting from the nodes the delta touched and propagating down dependency
+// chains only while a height actually changes. An empty prev recomputes all.
+func (a *Analyzer) updateHeights(order []string, prev map[string]float64, delta GraphDelta) map[string]float64 {
+	heights := make(map[string]float64, len(order))
+	dirty := make([]bool, len(a.nodeToID))
+	full := len(prev) == 0
+	if full {
+		for i := range dirty {
+			dirty[i] = true
+		}
+	} else {
+		for id, h := range prev {
+			if _, ok := a.idToNode[id]; ok {
+				heights[id] = h
+			}
+		}
+		mark := func(id string) {
+			if u, ok := a.idToNode[id]; ok {
+				dirty[u] = true
+			}
+		}
+		for _, id := range delta.AddedNodes {
+			mark(id)
+		}
+		for _, e := range delta.AddedEdges {
+			mark(e.To)
+		}
+		for _, e := range delta.RemovedEdges {
+			mark(e.To)
+		}
+	}
+
+	// Dependents come last in a dependencies-first order, so walk it backwards.
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		u := a.idToNode[id]
+		if !dirty[u] {
+			continue
+		}
+		h := 1.0
+		it := a.g.To(u)
+		for it.Next() {
+			p := it.Node().ID()
+			if p == u {
+				continue
+			}
+			if ph := heights[a.nodeToID[p]] + 1; ph > h {
+				h = ph
+			}
+		}
+		if old, ok := heights[id]; ok && old == h && !full {
+			continue
+		}
+		heights[id] = h
+		deps := a.g.From(u)
+		for deps.Next() {
+			dirty[deps.Node().ID()] = true
+		}
+	}
+	return heights
+}
+
+func (a *Analyzer) hasSelfLoop() bool {
+	for u := range a.nodeToID {
+		if a.g.HasEdgeFromTo(u, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// computePhase2Incremental finishes an incremental update in the background:
+// PageRank and eigenvector are warm-started from prev, the critical path is
+// already settled, and the remaining metrics are recomputed.
+func (a *Analyzer) computePhase2Incremental(ctx context.Context, stats, prev *GraphStats, config AnalysisConfig, criticalPath map[string]float64, cyclic bool) {
+	defer close(stats.phase2Done)
+	defer stats.recoverPhase2Panic()
+
+	rest := config
+	rest.ComputePageRank = false
+	rest.ComputeEigenvector = false
+	rest.ComputeCriticalPath = false
+	rest.ComputeCycles = config.ComputeCycles && cyclic
+	scratch := &GraphStats{TopologicalOrder: stats.TopologicalOrder}
+	profile := &StartupProfile{}
+	a.computePhase2WithProfile(ctx, scratch, rest, profile)
+	if ctx.Err() != nil {
+		return
+	}
+
+	prev.mu.RLock()
+	prevPageRank := prev.pageRank
+	prevEigenvector := prev.eigenvector
+	prev.mu.RUnlock()
+
+	pageRank := make(map[string]float64)
+	if config.ComputePageRank {
+		start := time.Now()
+		init := a.nodeVector(prevPageRank)
+		prDone := make(chan map[int64]float64, 1)
+		prPanic := make(chan any, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					prPanic <- r
+				}
+			}()
+			prDone <- computePageRankWarm(a.g, 0.85, 1e-6, init)
+		}()
+
+		timer := time.NewTimer(config.PageRankTimeout)
+		select {
+		case r := <-prPanic:
+			// Re-raise on this goroutine so recoverPhase2Panic marks the metrics failed
+			timer.Stop()
+			panic(r)
+		case pr := <-prDone:
+			timer.Stop()
+			for id, score := range pr {
+				pageRank[a.nodeToID[id]] = score
+			}
+		case <-timer.C:
+			profile.PageRankTO = true
+			uniform := 1.0 / float64(len(a.issueMap))
+			for id := range a.issueMap {
+				pageRank[id] = uniform
+			}
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+		profile.PageRank = time.Since(start)
+	}
+
+	eigenvector := make(map[string]float64)
+	if config.ComputeEigenvector {
+		start := time.Now()
+		ev, converged := computeEigenvectorWarm(a.g, a.nodeVector(prevEigenvector), 1e-6)
+		if !converged {
+			// No dominant eigenvector to converge to (e.g. a DAG); match the
+			// cold fixed-iteration result instead.
+			ev = computeEigenvector(a.g)
+		}
+		for id, score := range ev {
+			eigenvector[a.nodeToID[id]] = score
+		}
+		profile.Eigenvector = time.Since(start)
+	}
+
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	stats.pageRank = pageRank
+	stats.eigenvector = eigenvector
+	stats.criticalPathScore = criticalPath
+	stats.betweenness = scratch.betweenness
+	stats.hubs = scratch.hubs
+	stats.authorities = scratch.authorities
+	stats.coreNumber = scratch.coreNumber
+	stats.articulation = scratch.articulation
+	stats.slack = scratch.slack
+	stats.cycles = scratch.cycles
+
+	stats.pageRankRank = computeFloatRanks(pageRank)
+	stats.eigenvectorRank = computeFloatRanks(eigenvector)
+	stats.criticalPathRank = computeFloatRanks(criticalPath)
+	stats.betweennessRank = scratch.betweennessRank
+	stats.hubsRank = scratch.hubsRank
+	stats.authoritiesRank = scratch.authoritiesRank
+
+	status := scratch.status
+	status.PageRank = statusEntry{
+		State:   stateFromTiming(config.ComputePageRank, profile.PageRankTO),
+		Reason:  incrementalReason(config.ComputePageRank, "warm start"),
+		Elapsed: profile.PageRank,
+	}
+	status.Eigenvector = statusEntry{
+		State:   stateFromTiming(config.ComputeEigenvector, false),
+		Reason:  incrementalReason(config.ComputeEigenvector, "warm start"),
+		Elapsed: profile.Eigenvector,
+	}
+	status.Critical = statusEntry{
+		State:  stateFromTiming(config.ComputeCriticalPath, false),
+		Reason: incrementalReason(config.ComputeCriticalPath, "incremental"),
+	}
+	if !rest.ComputeCycles {
+		status.Cycles = statusEntry{State: stateFromTiming(config.ComputeCycles, false), Reason: config.CyclesSkipReason}
+	}
+	stats.status = status
+	stats.phase2Ready = true
+}
+
+// nodeVector maps per-issue scores onto this analyzer's node IDs.
+func (a *Analyzer) nodeVector(scores map[string]float64) map[int64]float64 {
+	if len(scores) == 0 {
+		return nil
+	}
+	vec := make(map[int64]float64, len(scores))
+	for id, score := range scores {
+		if u, ok := a.idToNode[id]; ok {
+			vec[u] = score
+		}
+	}
+	return vec
+}
+
+func incrementalReason(enabled bool, reason string) string {
+	if !enabled {
+		return ""
+	}
+	return reason
+}
+
+func floatMapsEqual(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func incIssue(id string, deps ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask}
+	for _, d := range deps {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: d, Type: model.DepBlocks})
+	}
+	return issue
+}
+
+func TestComputeGraphDelta(t *testing.T) {
+	prev := NewAnalyzer([]model.Issue{incIssue("A", "B"), incIssue("B"), incIssue("C", "B")})
+	next := NewAnalyzer([]model.Issue{incIssue("A", "D"), incIssue("B"), incIssue("D", "B")})
+
+	d := ComputeGraphDelta(prev, next, nil)
+	if fmt.Sprint(d.AddedNodes) != "[D]" || fmt.Sprint(d.RemovedNodes) != "[C]" {
+		t.Errorf("nodes: added=%v removed=%v", d.AddedNodes, d.RemovedNodes)
+	}
+	if fmt.Sprint(d.AddedEdges) != "[{A D} {D B}]" {
+		t.Errorf("added edges = %v", d.AddedEdges)
+	}
+	if fmt.Sprint(d.RemovedEdges) != "[{A B} {C B}]" {
+		t.Errorf("removed edges = %v", d.RemovedEdges)
+	}
+	if !d.Structural() {
+		t.Error("expected structural delta")
+	}
+}
+
+func TestIncrementalAnalyzer_ContentOnlyReusesStats(t *testing.T) {
+	issues := []model.Issue{incIssue("A", "B"), incIssue("B"), incIssue("C", "A")}
+	ia := NewIncrementalAnalyzer()
+	cfg := FullAnalysisConfig()
+
+	first, changes := ia.UpdateWithConfig(context.Background(), NewAnalyzer(issues), cfg, nil)
+	if !changes.Full {
+		t.Fatal("first update should be a full analysis")
+	}
+	first.WaitForPhase2()
+
+	issues[0].Title = "A (renamed)"
+	diff := ComputeIssueDiff(nil, nil)
+	diff.ContentChanged = []string{"A"}
+	second, changes := ia.UpdateWithConfig(context.Background(), NewAnalyzer(issues), cfg, &diff)
+	if second != first {
+		t.Error("content-only change should reuse the previous stats")
+	}
+	if changes.Any() || fmt.Sprint(changes.Delta.ChangedNodes) != "[A]" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestIncrementalAnalyzer_ReportsChangedMetrics(t *testing.T) {
+	ia := NewIncrementalAnalyzer()
+	cfg := FullAnalysisConfig()
+	ctx := context.Background()
+
+	stats, _ := ia.UpdateWithConfig(ctx, NewAnalyzer([]model.Issue{
+		incIssue("A", "B"), incIssue("B", "C"), incIssue("C"), incIssue("D"), incIssue("E"),
+	}), cfg, nil)
+	stats.WaitForPhase2()
+
+	// Adding E -> D touches only E and D: the chain's heights stay put.
+	stats, changes := ia.UpdateWithConfig(ctx, NewAnalyzer([]model.Issue{
+		incIssue("A", "B"), incIssue("B", "C"), incIssue("C"), incIssue("D"), incIssue("E", "D"),
+	}), cfg, nil)
+	stats.WaitForPhase2()
+	if changes.Full {
+		t.Fatal("small delta should be incremental")
+	}
+	if !changes.Degree || fmt.Sprint(changes.DegreeChanged) != "[D E]" {
+		t.Errorf("degree changes = %v", changes.DegreeChanged)
+	}
+	if !changes.CriticalPath || changes.Cycles {
+		t.Errorf("critical=%v cycles=%v", changes.CriticalPath, changes.Cycles)
+	}
+	if got := stats.GetCriticalPathScore("D"); got != 2 {
+		t.Errorf("height(D) = %v, want 2", got)
+	}
+
+	// Closing a cycle empties the topological order and enumerates cycles.
+	stats, changes = ia.UpdateWithConfig(ctx, NewAnalyzer([]model.Issue{
+		incIssue("A", "B"), incIssue("B", "C"), incIssue("C", "A"), incIssue("D"), incIssue("E", "D"),
+	}), cfg, nil)
+	stats.WaitForPhase2()
+	if !changes.Cycles || !changes.TopologicalOrder || stats.TopologicalOrder != nil {
+		t.Errorf("cycle not detected: %+v order=%v", changes, stats.TopologicalOrder)
+	}
+	if len(stats.Cycles()) != 1 {
+		t.Errorf("cycles = %v", stats.Cycles())
+	}
+}
+
+// TestIncrementalAnalyzer_MatchesFullAnalysis applies random mutations and
+// checks every incremental result against a from-scratch analysis.
+func TestIncrementalAnalyzer_MatchesFullAnalysis(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	cfg := FullAnalysisConfig()
+	ctx := context.Background()
+
+	deps := map[string]map[string]bool{}
+	nextID := 0
+	addNode := func() string {
+		id := fmt.Sprintf("n%03d", nextID)
+		nextID++
+		deps[id] = map[string]bool{}
+		return id
+	}
+	for i := 0; i < 60; i++ {
+		addNode()
+	}
+	ids := func() []string {
+		out := make([]string, 0, len(deps))
+		for id := range deps {
+			out = append(out, id)
+		}
+		sort.Strings(out)
+		return out
+	}
+	// Seed a DAG: only depend on lower IDs.
+	for _, id := range ids() {
+		for _, other := range ids() {
+			if other < id && rng.Float64() < 0.05 {
+				deps[id][other] = true
+			}
+		}
+	}
+	build := func() []model.Issue {
+		var issues []model.Issue
+		for _, id := range ids() {
+			var d []string
+			for dep := range deps[id] {
+				d = append(d, dep)
+			}
+			sort.Strings(d)
+			issues = append(issues, incIssue(id, d...))
+		}
+		return issues
+	}
+
+	ia := NewIncrementalAnalyzer()
+	ia.MaxDeltaRatio = 1
+	stats, _ := ia.UpdateWithConfig(ctx, NewAnalyzer(build()), cfg, nil)
+	stats.WaitForPhase2()
+
+	incrementalRuns, cyclicRuns := 0, 0
+	for step := 0; step < 60; step++ {
+		for k := 0; k < 3; k++ {
+			all := ids()
+			switch rng.Intn(6) {
+			case 0:
+				id := addNode()
+				deps[id][all[rng.Intn(len(all))]] = true
+			case 1:
+				delete(deps, all[rng.Intn(len(all))])
+				for _, d := range deps {
+					for dep := range d {
+						if _, ok := deps[dep]; !ok {
+							delete(d, dep)
+						}
+					}
+				}
+			case 2, 3:
+				// Mostly forward edges, occasionally one that may close a cycle.
+				from, to := all[rng.Intn(len(all))], all[rng.Intn(len(all))]
+				if from > to || rng.Float64() < 0.1 {
+					deps[from][to] = true
+				}
+			case 4:
+				from := all[rng.Intn(len(all))]
+				for dep := range deps[from] {
+					delete(deps[from], dep)
+					break
+				}
+			case 5:
+				// Point a dependency back at its dependent: a 2-cycle.
+				from := all[rng.Intn(len(all))]
+				for dep := range deps[from] {
+					deps[dep][from] = true
+					break
+				}
+			}
+		}
+
+		issues := build()
+		got, changes := ia.UpdateWithConfig(ctx, NewAnalyzer(issues), cfg, nil)
+		got.WaitForPhase2()
+		if !changes.Full {
+			incrementalRuns++
+			if got.TopologicalOrder == nil {
+				cyclicRuns++
+			}
+		}
+		want := NewAnalyzer(issues).AnalyzeWithConfig(cfg)
+		label := fmt.Sprintf("step %d (full=%v)", step, changes.Full)
+
+		assertIntMapsEqual(t, label+" in-degree", got.InDegree, want.InDegree)
+		assertIntMapsEqual(t, label+" out-degree", got.OutDegree, want.OutDegree)
+		assertIntMapsEqual(t, label+" in-degree rank", got.InDegreeRank(), want.InDegreeRank())
+		if (got.TopologicalOrder == nil) != (want.TopologicalOrder == nil) {
+			t.Fatalf("%s: topo order nil mismatch", label)
+		}
+		assertValidOrder(t, label, got.TopologicalOrder, issues)
+		assertFloatMapsClose(t, label+" critical path", got.CriticalPathScore(), want.CriticalPathScore(), 0)
+		assertFloatMapsClose(t, label+" pagerank", got.PageRank(), want.PageRank(), 1e-4)
+		assertFloatMapsClose(t, label+" eigenvector", got.Eigenvector(), want.Eigenvector(), 1e-4)
+		assertFloatMapsClose(t, label+" betweenness", got.Betweenness(), want.Betweenness(), 1e-9)
+		if len(got.Cycles()) != len(want.Cycles()) {
+			t.Fatalf("%s: cycles %d vs %d", label, len(got.Cycles()), len(want.Cycles()))
+		}
+	}
+	if incrementalRuns == 0 || cyclicRuns == 0 {
+		t.Fatalf("mutations did not exercise both paths: %d incremental, %d cyclic", incrementalRuns, cyclicRuns)
+	}
+}
+
+func assertIntMapsEqual(t *testing.T, label string, got, want map[string]int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: len %d vs %d", label, len(got), len(want))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("%s[%s] = %d, want %d", label, k, got[k], v)
+		}
+	}
+}
+
+func assertFloatMapsClose(t *testing.T, label string, got, want map[string]float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: len %d vs %d", label, len(got), len(want))
+	}
+	for k, v := range want {
+		if math.Abs(got[k]-v) > tol {
+			t.Fatalf("%s[%s] = %v, want %v", label, k, got[k], v)
+		}
+	}
+}
+
+func assertValidOrder(t *testing.T, label string, order []string, issues []model.Issue) {
+	t.Helper()
+	if order == nil {
+		return
+	}
+	if len(order) != len(issues) {
+		t.Fatalf("%s: order has %d nodes, want %d", label, len(order), len(issues))
+	}
+	pos := make(map[string]int, len(order))
+	for i, id := range order {
+		pos[id] = i
+	}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep.DependsOnID != issue.ID && pos[dep.DependsOnID] > pos[issue.ID] {
+				t.Fatalf("%s: %s placed before its dependency %s", label, issue.ID, dep.DependsOnID)
+			}
+		}
+	}
+}
@@ -242,7 +242,7 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 			continue
 		}
 		for _, dep := range blocked.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
+			if dep == nil || !dep.Type.IsBlocking() {
 				continue
 			}
 			blocker, ok := issueMap[dep.DependsOnID]
@@ -510,7 +510,7 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 	seenOut := make(map[string]struct{})
 	for _, iss := range labeled {
 		for _, dep := range iss.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
+			if dep == nil || !dep.Type.IsBlocking() {
 				continue
 			}
 			blockerLabels := GetLabelsForIssue(issues, dep.DependsOnID)
@@ -1208,7 +1208,7 @@ func computeSingleCascade(sourceLabel string, blockedIssues []model.Issue, flow
 	blockerImpact := make(map[string]int) // issueID -> transitive unblock count
 	for _, blockedIssue := range blockedIssues {
 		for _, dep := range blockedIssue.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
+			if dep == nil || !dep.Type.IsBlocking() {
 				continue
 			}
 			blocker, exists := issueMap[dep.DependsOnID]
@@ -1419,7 +1419,7 @@ func ComputeLabelSubgraph(issues []model.Issue, label string) LabelSubgraph {
 	for _, id := range result.AllIssues {
 		iss := result.IssueMap[id]
 		for _, dep := range iss.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
+			if dep == nil || !dep.Type.IsBlocking() {
 				continue
 			}
 			blockerID := dep.DependsOnID
@@ -2032,7 +2032,7 @@ func computeLabelAttention(label string, issues []model.Issue, issueMap map[stri
 				continue
 			}
 			for _, dep := range other.Dependencies {
-				if dep != nil && dep.DependsOnID == iss.ID && dep.Type == model.DepBlocks {
+				if dep != nil && dep.DependsOnID == iss.ID && dep.Type.IsBlocking() {
 					blockImpact++
 				}
 			}
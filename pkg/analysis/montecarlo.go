@@ -0,0 +1,443 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultMonteCarloTrials is the number of simulated schedules used when
+// MonteCarloOptions.Trials is not set.
+const DefaultMonteCarloTrials = 1000
+
+// DefaultMonteCarloSeed keeps forecasts reproducible across runs unless a
+// caller explicitly asks for a different seed.
+const DefaultMonteCarloSeed int64 = 1
+
+// monteCarloMaxDays bounds a single trial so pathological inputs (zero
+// throughput, unbreakable cycles) cannot spin forever.
+const monteCarloMaxDays = 3650
+
+// MonteCarloOptions configures a probabilistic schedule forecast.
+type MonteCarloOptions struct {
+	Trials       int       // Number of simulated schedules (default: DefaultMonteCarloTrials)
+	Seed         int64     // RNG seed; 0 uses DefaultMonteCarloSeed
+	Agents       int       // Parallel workers; historical throughput is scaled by this (default: 1)
+	HistoryWeeks int       // Weeks of closed-issue history to sample throughput from (default: 8)
+	Label        string    // Sample throughput from this label's history when it has enough data
+	Bins         int       // Histogram bucket count (default: 10)
+	Now          time.Time // Reference time; zero means time.Now()
+
+	// History supplies the closures throughput is sampled from; nil means the
+	// forecast issues themselves. Hypothetical scenarios pass the baseline so
+	// simulated closures do not inflate velocity.
+	History []model.Issue
+}
+
+// ForecastPercentile is a single completion-date percentile.
+type ForecastPercentile struct {
+	Percentile int       `json:"percentile"`
+	Days       float64   `json:"days"`
+	Date       time.Time `json:"date"`
+}
+
+// ForecastBin is a single histogram bucket of simulated completion times.
+type ForecastBin struct {
+	StartDays  float64 `json:"start_days"`
+	EndDays    float64 `json:"end_days"`
+	Count      int     `json:"count"`
+	Cumulative float64 `json:"cumulative"` // Fraction of trials finished by EndDays
+}
+
+// MonteCarloForecast is the probabilistic completion forecast for a target
+// set of beads (a single bead, a label, a sprint, or all open work).
+type MonteCarloForecast struct {
+	Target            string             `json:"target"`
+	TargetIDs         []string           `json:"target_ids"`
+	SimulatedIssues   int                `json:"simulated_issues"` // Targets plus their open transitive blockers
+	Trials            int                `json:"trials"`
+	Seed              int64              `json:"seed"`
+	Agents            int                `json:"agents"`
+	ThroughputSource  string             `json:"throughput_source"`
+	ThroughputSamples []int              `json:"throughput_samples"` // Closed issues per week, newest first
+	MeanDays          float64            `json:"mean_days"`
+	P50               ForecastPercentile `json:"p50"`
+	P85               ForecastPercentile `json:"p85"`
+	P95               ForecastPercentile `json:"p95"`
+	Histogram         []ForecastBin      `json:"histogram"`
+	Warnings          []string           `json:"warnings,omitempty"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+	completionDays    []float64          // Sorted per-trial results, kept for ProbabilityBy
+}
+
+// ProbabilityBy returns the fraction of simulated trials that completed on or
+// before the given date.
+func (f *MonteCarloForecast) ProbabilityBy(date time.Time) float64 {
+	if f == nil || len(f.completionDays) == 0 {
+		return 0
+	}
+	days := date.Sub(f.GeneratedAt).Hours() / 24
+	n := sort.SearchFloat64s(f.completionDays, math.Nextafter(days, math.Inf(1)))
+	return float64(n) / float64(len(f.completionDays))
+}
+
+// ForecastMonteCarlo simulates completing every target bead many times and
+// reports the distribution of completion dates.
+//
+// Each trial runs a day-by-day list schedule over the dependency DAG: a bead
+// can only start once all of its open blockers have finished, at most Agents
+// beads progress on any given day, and each day's progress is drawn from the
+// project's historical weekly throughput (ComputeProjectVelocity, or
+// ComputeHistoricalVelocity when scoped to a label). Bead sizes are relative
+// to the median estimated_minutes and jittered per trial.
+func ForecastMonteCarlo(issues []model.Issue, target string, targetIDs []string, opts MonteCarloOptions) (*MonteCarloForecast, error) {
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultMonteCarloTrials
+	}
+	if opts.Seed == 0 {
+		opts.Seed = DefaultMonteCarloSeed
+	}
+	if opts.Agents <= 0 {
+		opts.Agents = 1
+	}
+	if opts.HistoryWeeks <= 0 {
+		opts.HistoryWeeks = 8
+	}
+	if opts.Bins <= 0 {
+		opts.Bins = 10
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	for _, id := range targetIDs {
+		if _, ok := issueMap[id]; !ok {
+			return nil, fmt.Errorf("issue %q not found", id)
+		}
+	}
+
+	result := &MonteCarloForecast{
+		Target:      target,
+		TargetIDs:   append([]string{}, targetIDs...),
+		Trials:      opts.Trials,
+		Seed:        opts.Seed,
+		Agents:      opts.Agents,
+		GeneratedAt: opts.Now,
+	}
+	sort.Strings(result.TargetIDs)
+
+	history := opts.History
+	if history == nil {
+		history = issues
+	}
+	samples, source := sampleWeeklyThroughput(history, opts)
+	result.ThroughputSamples = samples
+	result.ThroughputSource = source
+
+	nodes := collectForecastNodes(issueMap, result.TargetIDs)
+	result.SimulatedIssues = len(nodes)
+
+	if len(nodes) == 0 {
+		// Everything is already done.
+		result.completionDays = make([]float64, opts.Trials)
+		result.fillStatistics(opts.Bins)
+		return result, nil
+	}
+
+	allZero := true
+	for _, s := range samples {
+		if s > 0 {
+			allZero = false
+			break
+		}
+	}
+	if allZero {
+		// One median-sized bead per week keeps the simulation finite and matches
+		// the conservative default used by EstimateETAForIssue.
+		samples = []int{1}
+		result.Warnings = append(result.Warnings, "no closures in history window; assuming 1 bead/week")
+	}
+
+	sim := newForecastSim(issueMap, nodes, computeMedianEstimatedMinutes(issues))
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	days := make([]float64, opts.Trials)
+	for trial := 0; trial < opts.Trials; trial++ {
+		d, forced := sim.run(rng, samples, opts.Agents)
+		days[trial] = d
+		if forced && trial == 0 {
+			result.Warnings = append(result.Warnings, "dependency cycle among forecast beads; cycle members scheduled in ID order")
+		}
+	}
+	sort.Float64s(days)
+	result.completionDays = days
+	result.fillStatistics(opts.Bins)
+
+	if days[len(days)-1] >= monteCarloMaxDays {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("some trials hit the %d-day simulation cap", monteCarloMaxDays))
+	}
+
+	return result, nil
+}
+
+// sampleWeeklyThroughput returns per-week closure counts (newest first) and
+// a description of where they came from.
+func sampleWeeklyThroughput(issues []model.Issue, opts MonteCarloOptions) ([]int, string) {
+	if opts.Label != "" {
+		hist := ComputeHistoricalVelocity(issues, opts.Label, opts.HistoryWeeks, opts.Now)
+		samples := make([]int, 0, len(hist.WeeklyVelocity))
+		nonZero := 0
+		for _, w := range hist.WeeklyVelocity {
+			samples = append(samples, w.Closed)
+			if w.Closed > 0 {
+				nonZero++
+			}
+		}
+		// Require a few active weeks before trusting a label-specific history.
+		if nonZero >= 3 {
+			return samples, fmt.Sprintf("label:%s (%d weeks)", opts.Label, opts.HistoryWeeks)
+		}
+	}
+
+	velocity := ComputeProjectVelocity(issues, opts.Now, opts.HistoryWeeks)
+	samples := make([]int, 0, len(velocity.Weekly))
+	for _, w := range velocity.Weekly {
+		samples = append(samples, w.Closed)
+	}
+	return samples, fmt.Sprintf("project (%d weeks)", opts.HistoryWeeks)
+}
+
+// collectForecastNodes returns the open targets plus every open bead that
+// transitively blocks them, sorted by ID for deterministic simulation.
+func collectForecastNodes(issueMap map[string]*model.Issue, targetIDs []string) []string {
+	seen := make(map[string]bool)
+	var stack []string
+	for _, id := range targetIDs {
+		if iss := issueMap[id]; iss != nil && !isClosedLikeStatus(iss.Status) && !seen[id] {
+			seen[id] = true
+			stack = append(stack, id)
+		}
+	}
+
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			blocker := issueMap[dep.DependsOnID]
+			if blocker == nil || isClosedLikeStatus(blocker.Status) || seen[blocker.ID] {
+				continue
+			}
+			seen[blocker.ID] = true
+			stack = append(stack, blocker.ID)
+		}
+	}
+
+	nodes := make([]string, 0, len(seen))
+	for id := range seen {
+		nodes = append(nodes, id)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// forecastSim holds the immutable graph for repeated trials.
+type forecastSim struct {
+	size      []float64 // Work units relative to a median-sized bead
+	priority  []int
+	blockers  [][]int // Indices of open blockers within the simulated set
+	dependent [][]int // Reverse edges
+}
+
+func newForecastSim(issueMap map[string]*model.Issue, nodes []string, medianMinutes int) *forecastSim {
+	index := make(map[string]int, len(nodes))
+	for i, id := range nodes {
+		index[id] = i
+	}
+	if medianMinutes <= 0 {
+		medianMinutes = DefaultEstimatedMinutes
+	}
+
+	s := &forecastSim{
+		size:      make([]float64, len(nodes)),
+		priority:  make([]int, len(nodes)),
+		blockers:  make([][]int, len(nodes)),
+		dependent: make([][]int, len(nodes)),
+	}
+	for i, id := range nodes {
+		iss := issueMap[id]
+		s.size[i] = 1.0
+		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+			s.size[i] = max(0.1, float64(*iss.EstimatedMinutes)/float64(medianMinutes))
+		}
+		s.priority[i] = iss.Priority
+		for _, dep := range iss.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			j, ok := index[dep.DependsOnID]
+			if !ok || j == i {
+				continue
+			}
+			s.blockers[i] = append(s.blockers[i], j)
+			s.dependent[j] = append(s.dependent[j], i)
+		}
+	}
+	return s
+}
+
+// run simulates one schedule and returns the number of days until every bead
+// finished, plus whether a dependency cycle had to be broken.
+func (s *forecastSim) run(rng *rand.Rand, weekly []int, agents int) (float64, bool) {
+	n := len(s.size)
+	remaining := make([]float64, n)
+	pending := make([]int, n)
+	done := make([]bool, n)
+	for i := range remaining {
+		// Triangular(0.6, 1.0, 1.8) jitter around the nominal size: work is
+		// more often underestimated than overestimated.
+		remaining[i] = s.size[i] * triangular(rng, 0.6, 1.0, 1.8)
+		pending[i] = len(s.blockers[i])
+	}
+
+	var ready []int
+	for i := 0; i < n; i++ {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	forced := false
+	finished := 0
+	perAgent := 0.0
+	for day := 0; day < monteCarloMaxDays; day++ {
+		if day%7 == 0 {
+			// Resample once per simulated week; spread over 5 working days.
+			perAgent = float64(weekly[rng.Intn(len(weekly))]) / 5.0
+		}
+		if day%7 >= 5 || perAgent <= 0 {
+			continue
+		}
+
+		if len(ready) == 0 {
+			// Only cycles remain: release the lowest-index unfinished bead.
+			for i := 0; i < n; i++ {
+				if !done[i] {
+					ready = append(ready, i)
+					pending[i] = 0
+					forced = true
+					break
+				}
+			}
+		}
+
+		// Work highest priority first, ties by ID order.
+		sort.SliceStable(ready, func(a, b int) bool {
+			if s.priority[ready[a]] != s.priority[ready[b]] {
+				return s.priority[ready[a]] < s.priority[ready[b]]
+			}
+			return ready[a] < ready[b]
+		})
+
+		var unlocked []int
+		active := min(agents, len(ready))
+		kept := ready[:0]
+		for k, idx := range ready {
+			if k < active {
+				remaining[idx] -= perAgent
+				if remaining[idx] <= 0 {
+					done[idx] = true
+					finished++
+					for _, dep := range s.dependent[idx] {
+						pending[dep]--
+						if pending[dep] == 0 && !done[dep] {
+							unlocked = append(unlocked, dep)
+						}
+					}
+					continue
+				}
+			}
+			kept = append(kept, idx)
+		}
+		ready = append(kept, unlocked...)
+
+		if finished == n {
+			// day counts calendar days (weekends included), so no conversion is needed.
+			return float64(day + 1), forced
+		}
+	}
+	return monteCarloMaxDays, forced
+}
+
+// triangular draws from a triangular distribution on [lo, hi] with the given mode.
+func triangular(rng *rand.Rand, lo, mode, hi float64) float64 {
+	u := rng.Float64()
+	c := (mode - lo) / (hi - lo)
+	if u < c {
+		return lo + math.Sqrt(u*(hi-lo)*(mode-lo))
+	}
+	return hi - math.Sqrt((1-u)*(hi-lo)*(hi-mode))
+}
+
+// fillStatistics derives percentiles, mean, and histogram from the sorted
+// per-trial completion days.
+func (f *MonteCarloForecast) fillStatistics(bins int) {
+	days := f.completionDays
+	if len(days) == 0 {
+		return
+	}
+
+	sum := 0.0
+	for _, d := range days {
+		sum += d
+	}
+	f.MeanDays = sum / float64(len(days))
+
+	pct := func(p int) ForecastPercentile {
+		idx := int(math.Ceil(float64(p)/100*float64(len(days)))) - 1
+		idx = max(0, min(idx, len(days)-1))
+		return ForecastPercentile{
+			Percentile: p,
+			Days:       days[idx],
+			Date:       f.GeneratedAt.Add(durationDays(days[idx])),
+		}
+	}
+	f.P50 = pct(50)
+	f.P85 = pct(85)
+	f.P95 = pct(95)
+
+	lo, hi := days[0], days[len(days)-1]
+	if hi == lo {
+		f.Histogram = []ForecastBin{{StartDays: lo, EndDays: hi, Count: len(days), Cumulative: 1}}
+		return
+	}
+	width := (hi - lo) / float64(bins)
+	f.Histogram = make([]ForecastBin, bins)
+	for i := range f.Histogram {
+		f.Histogram[i].StartDays = lo + float64(i)*width
+		f.Histogram[i].EndDays = lo + float64(i+1)*width
+	}
+	for _, d := range days {
+		idx := int((d - lo) / width)
+		if idx >= bins {
+			idx = bins - 1
+		}
+		f.Histogram[idx].Count++
+	}
+	cum := 0
+	for i := range f.Histogram {
+		cum += f.Histogram[i].Count
+		f.Histogram[i].Cumulative = float64(cum) / float64(len(days))
+	}
+}
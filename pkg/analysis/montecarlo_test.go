@@ -0,0 +1,199 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func monteCarloFixture(now time.Time) []model.Issue {
+	closedAt := func(daysAgo int) *time.Time {
+		t := now.Add(-time.Duration(daysAgo) * 24 * time.Hour)
+		return &t
+	}
+	est := func(m int) *int { return &m }
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Labels: []string{"api"}, EstimatedMinutes: est(60)},
+		{ID: "B", Title: "Middle", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Labels: []string{"api"},
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Leaf", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Labels: []string{"ui"},
+			Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "D", Title: "Independent", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask, Labels: []string{"ui"}},
+	}
+	// Two closures per week for the last six weeks.
+	for i := 0; i < 12; i++ {
+		issues = append(issues, model.Issue{
+			ID:        "CLOSED-" + string(rune('a'+i)),
+			Title:     "Done",
+			Status:    model.StatusClosed,
+			IssueType: model.TypeTask,
+			Labels:    []string{"api"},
+			ClosedAt:  closedAt(1 + i*3),
+		})
+	}
+	return issues
+}
+
+func TestForecastMonteCarlo_Reproducible(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	issues := monteCarloFixture(now)
+	opts := MonteCarloOptions{Trials: 200, Seed: 42, Now: now}
+
+	f1, err := ForecastMonteCarlo(issues, "bead:C", []string{"C"}, opts)
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	f2, err := ForecastMonteCarlo(issues, "bead:C", []string{"C"}, opts)
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if !reflect.DeepEqual(f1, f2) {
+		t.Fatalf("expected identical forecasts for the same seed")
+	}
+
+	opts.Seed = 7
+	f3, err := ForecastMonteCarlo(issues, "bead:C", []string{"C"}, opts)
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if reflect.DeepEqual(f1.Histogram, f3.Histogram) && f1.MeanDays == f3.MeanDays {
+		t.Errorf("expected a different seed to change the simulated distribution")
+	}
+}
+
+func TestForecastMonteCarlo_PercentilesOrderedAndHistogram(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	issues := monteCarloFixture(now)
+
+	f, err := ForecastMonteCarlo(issues, "all", []string{"A", "B", "C", "D"}, MonteCarloOptions{Trials: 300, Now: now, Bins: 8})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if f.SimulatedIssues != 4 {
+		t.Errorf("expected 4 simulated issues, got %d", f.SimulatedIssues)
+	}
+	if f.P50.Days <= 0 {
+		t.Errorf("expected positive P50, got %f", f.P50.Days)
+	}
+	if f.P50.Days > f.P85.Days || f.P85.Days > f.P95.Days {
+		t.Errorf("percentiles not ordered: p50=%f p85=%f p95=%f", f.P50.Days, f.P85.Days, f.P95.Days)
+	}
+	if !f.P95.Date.After(now) {
+		t.Errorf("expected P95 date after now, got %v", f.P95.Date)
+	}
+
+	total := 0
+	for _, bin := range f.Histogram {
+		total += bin.Count
+	}
+	if total != 300 {
+		t.Errorf("histogram counts sum to %d, want 300", total)
+	}
+	if last := f.Histogram[len(f.Histogram)-1]; last.Cumulative != 1 {
+		t.Errorf("final cumulative = %f, want 1", last.Cumulative)
+	}
+
+	if p := f.ProbabilityBy(f.P95.Date); p < 0.95 {
+		t.Errorf("ProbabilityBy(P95) = %f, want >= 0.95", p)
+	}
+	if p := f.ProbabilityBy(now); p != 0 {
+		t.Errorf("ProbabilityBy(now) = %f, want 0", p)
+	}
+}
+
+func TestForecastMonteCarlo_IncludesTransitiveBlockers(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	issues := monteCarloFixture(now)
+
+	leaf, err := ForecastMonteCarlo(issues, "bead:C", []string{"C"}, MonteCarloOptions{Trials: 200, Now: now})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if leaf.SimulatedIssues != 3 {
+		t.Errorf("expected C plus blockers A and B, got %d issues", leaf.SimulatedIssues)
+	}
+
+	root, err := ForecastMonteCarlo(issues, "bead:A", []string{"A"}, MonteCarloOptions{Trials: 200, Now: now})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if root.P50.Days >= leaf.P50.Days {
+		t.Errorf("expected root to finish before its dependent chain: root p50=%f leaf p50=%f", root.P50.Days, leaf.P50.Days)
+	}
+}
+
+func TestForecastMonteCarlo_AgentsShortenParallelWork(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	issues := monteCarloFixture(now)
+	for i := 0; i < 8; i++ {
+		issues = append(issues, model.Issue{ID: "P" + string(rune('0'+i)), Title: "Parallel", Status: model.StatusOpen, IssueType: model.TypeTask})
+	}
+	var targets []string
+	for i := 0; i < 8; i++ {
+		targets = append(targets, "P"+string(rune('0'+i)))
+	}
+
+	one, err := ForecastMonteCarlo(issues, "all", targets, MonteCarloOptions{Trials: 200, Now: now, Agents: 1})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	four, err := ForecastMonteCarlo(issues, "all", targets, MonteCarloOptions{Trials: 200, Now: now, Agents: 4})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if four.P50.Days >= one.P50.Days {
+		t.Errorf("expected 4 agents to beat 1 agent: one=%f four=%f", one.P50.Days, four.P50.Days)
+	}
+}
+
+func TestForecastMonteCarlo_EdgeCases(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+
+	if _, err := ForecastMonteCarlo(nil, "bead:X", []string{"X"}, MonteCarloOptions{Now: now}); err == nil {
+		t.Error("expected error for unknown target")
+	}
+
+	// No history: falls back to a default throughput with a warning.
+	longAgo := now.AddDate(-1, 0, 0)
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "Z", Title: "Z", Status: model.StatusClosed, IssueType: model.TypeTask, ClosedAt: &longAgo},
+	}
+	f, err := ForecastMonteCarlo(issues, "bead:A", []string{"A"}, MonteCarloOptions{Trials: 50, Now: now})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if len(f.Warnings) == 0 {
+		t.Error("expected a warning about missing throughput history")
+	}
+	if f.P50.Days <= 0 {
+		t.Errorf("expected positive P50 with fallback throughput, got %f", f.P50.Days)
+	}
+
+	// Closed target: completes immediately.
+	done, err := ForecastMonteCarlo(issues, "bead:Z", []string{"Z"}, MonteCarloOptions{Trials: 50, Now: now})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if done.SimulatedIssues != 0 || done.P95.Days != 0 {
+		t.Errorf("expected closed target to be done now, got %d issues p95=%f", done.SimulatedIssues, done.P95.Days)
+	}
+
+	// Cycles are broken rather than stalling the simulation.
+	cyclic := []model.Issue{
+		{ID: "X", Title: "X", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "X", DependsOnID: "Y", Type: model.DepBlocks}}},
+		{ID: "Y", Title: "Y", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "Y", DependsOnID: "X", Type: model.DepBlocks}}},
+	}
+	c, err := ForecastMonteCarlo(cyclic, "bead:X", []string{"X"}, MonteCarloOptions{Trials: 20, Now: now})
+	if err != nil {
+		t.Fatalf("ForecastMonteCarlo failed: %v", err)
+	}
+	if c.P95.Days >= monteCarloMaxDays {
+		t.Errorf("cycle should not hit the simulation cap, got %f", c.P95.Days)
+	}
+}
@@ -48,6 +48,10 @@ type ScoreBreakdown struct {
 
 	// Detailed risk signals (bv-82)
 	RiskSignals *RiskSignals `json:"risk_signals,omitempty"`
+
+	// LabelMultiplier is the scoring profile's label multiplier applied to
+	// the weighted sum (omitted when 1).
+	LabelMultiplier float64 `json:"label_multiplier,omitempty"`
 }
 
 // Weights for composite score (total = 1.0)
@@ -87,6 +91,12 @@ func (a *Analyzer) ComputeImpactScoresAt(now time.Time) []ImpactScore {
 
 // ComputeImpactScoresFromStats calculates impact scores using provided graph stats
 func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time) []ImpactScore {
+	return a.computeImpactScores(stats, now, a.scoring)
+}
+
+// computeImpactScores scores open issues with the given profile (nil means
+// the built-in weights).
+func (a *Analyzer) computeImpactScores(stats *GraphStats, now time.Time, profile *ScoringProfile) []ImpactScore {
 	// Handle empty issue set
 	if len(a.issueMap) == 0 {
 		return nil
@@ -123,6 +133,11 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 	// Compute median estimated minutes for issues without estimates
 	medianMinutes := a.computeMedianEstimatedMinutes()
 
+	weights := DefaultScoringWeights()
+	if profile != nil {
+		weights = profile.Weights.Normalized()
+	}
+
 	// Compute impact scores from stats
 	var scores []ImpactScore
 
@@ -159,14 +174,14 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 
 		// Compute weighted score
 		breakdown := ScoreBreakdown{
-			PageRank:      prNorm * WeightPageRank,
-			Betweenness:   bwNorm * WeightBetweenness,
-			BlockerRatio:  blockerNorm * WeightBlockerRatio,
-			Staleness:     stalenessNorm * WeightStaleness,
-			PriorityBoost: priorityNorm * WeightPriorityBoost,
-			TimeToImpact:  timeToImpactNorm * WeightTimeToImpact,
-			Urgency:       urgencyNorm * WeightUrgency,
-			Risk:          riskSignals.CompositeRisk * WeightRisk,
+			PageRank:      prNorm * weights.PageRank,
+			Betweenness:   bwNorm * weights.Betweenness,
+			BlockerRatio:  blockerNorm * weights.BlockerRatio,
+			Staleness:     stalenessNorm * weights.Staleness,
+			PriorityBoost: priorityNorm * weights.PriorityBoost,
+			TimeToImpact:  timeToImpactNorm * weights.TimeToImpact,
+			Urgency:       urgencyNorm * weights.Urgency,
+			Risk:          riskSignals.CompositeRisk * weights.Risk,
 
 			PageRankNorm:      prNorm,
 			BetweennessNorm:   bwNorm,
@@ -193,6 +208,11 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 			breakdown.Urgency +
 			breakdown.Risk
 
+		if m := profile.labelMultiplier(issue.Labels); m != 1 {
+			score *= m
+			breakdown.LabelMultiplier = m
+		}
+
 		scores = append(scores, ImpactScore{
 			IssueID:   id,
 			Title:     issue.Title,
@@ -790,7 +810,7 @@ func (a *Analyzer) computeWhatIfDelta(issueID string) *WhatIfDelta {
 	blockedReduction := 0
 	for _, unblockID := range directUnblocks {
 		if issue, ok := a.issueMap[unblockID]; ok {
-			if issue.Status == model.StatusBlocked {
+			if issue.Status.Class() == model.ClassBlocked {
 				blockedReduction++
 			}
 		}
@@ -0,0 +1,428 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ScheduleAgent is a named worker with a relative capacity (1.0 = one full-time agent).
+type ScheduleAgent struct {
+	Name     string  `json:"name"`
+	Capacity float64 `json:"capacity"`
+}
+
+// ScheduleOptions configures the resource-constrained list schedule.
+type ScheduleOptions struct {
+	Agents      int             // Anonymous agents to create when Assignees is empty (default: 1)
+	Assignees   []ScheduleAgent // Named agents; beads assigned to one of them are pinned to it
+	HoursPerDay float64         // Working hours per day (default: 8)
+	Start       time.Time       // Schedule origin; zero means time.Now()
+}
+
+// ScheduledItem is a single bead placed on the timeline.
+type ScheduledItem struct {
+	ID               string    `json:"id"`
+	Title            string    `json:"title"`
+	Priority         int       `json:"priority"`
+	Status           string    `json:"status"`
+	Agent            string    `json:"agent"`
+	EstimatedMinutes int       `json:"estimated_minutes"`
+	StartMinute      float64   `json:"start_minute"` // Working minutes from schedule start
+	EndMinute        float64   `json:"end_minute"`
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	BlockedBy        []string  `json:"blocked_by,omitempty"`
+	Critical         bool      `json:"critical"` // On the longest chain that determines the makespan
+}
+
+// AgentLoad summarizes one agent's share of the schedule.
+type AgentLoad struct {
+	Name        string   `json:"name"`
+	Capacity    float64  `json:"capacity"`
+	Items       []string `json:"items"`
+	BusyMinutes float64  `json:"busy_minutes"`
+	Utilization float64  `json:"utilization"` // Busy time / makespan
+}
+
+// Schedule is a resource-constrained execution timeline for every open bead.
+type Schedule struct {
+	Start           time.Time       `json:"start"`
+	End             time.Time       `json:"end"`
+	HoursPerDay     float64         `json:"hours_per_day"`
+	MakespanMinutes float64         `json:"makespan_minutes"`
+	MakespanDays    float64         `json:"makespan_days"` // Working days
+	Items           []ScheduledItem `json:"items"`
+	Agents          []AgentLoad     `json:"agents"`
+	CriticalPath    []string        `json:"critical_path,omitempty"`
+	Warnings        []string        `json:"warnings,omitempty"`
+}
+
+// ParseScheduleAssignees parses "alice=1,bob=0.5,carol" into named agents.
+// A missing capacity defaults to 1.0.
+func ParseScheduleAssignees(spec string) ([]ScheduleAgent, error) {
+	var agents []ScheduleAgent
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, capStr, hasCap := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty assignee name in %q", part)
+		}
+		capacity := 1.0
+		if hasCap {
+			v, err := strconv.ParseFloat(strings.TrimSpace(capStr), 64)
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("invalid capacity for %s: %q", name, capStr)
+			}
+			capacity = v
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate assignee %q", name)
+		}
+		seen[name] = true
+		agents = append(agents, ScheduleAgent{Name: name, Capacity: capacity})
+	}
+	return agents, nil
+}
+
+// GetSchedule runs a list-scheduling simulation over every open bead.
+//
+// Simulated time advances from one bead completion to the next. A bead is
+// ready once all of its open blockers have finished; whenever an agent is
+// idle it takes the ready bead with the longest remaining downstream chain
+// (ties: priority, then ID). Beads whose assignee matches a named agent are
+// pinned to that agent. Durations come from estimated_minutes (or the project
+// median) divided by the agent's capacity.
+func (a *Analyzer) GetSchedule(opts ScheduleOptions) Schedule {
+	if opts.HoursPerDay <= 0 {
+		opts.HoursPerDay = 8
+	}
+	if opts.Start.IsZero() {
+		opts.Start = time.Now()
+	}
+	agents := opts.Assignees
+	if len(agents) == 0 {
+		n := max(1, opts.Agents)
+		for i := 1; i <= n; i++ {
+			agents = append(agents, ScheduleAgent{Name: fmt.Sprintf("agent-%d", i), Capacity: 1})
+		}
+	}
+	agentIdx := make(map[string]int, len(agents))
+	for i, ag := range agents {
+		agentIdx[ag.Name] = i
+	}
+
+	// Collect open beads in deterministic order.
+	var ids []string
+	for id, iss := range a.issueMap {
+		if !isClosedLikeStatus(iss.Status) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	all := make([]model.Issue, 0, len(a.issueMap))
+	for _, iss := range a.issueMap {
+		all = append(all, iss)
+	}
+	median := computeMedianEstimatedMinutes(all)
+
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	n := len(ids)
+	est := make([]int, n)
+	blockers := make([][]int, n)
+	dependents := make([][]int, n)
+	for i, id := range ids {
+		iss := a.issueMap[id]
+		est[i] = median
+		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
+			est[i] = *iss.EstimatedMinutes
+		}
+		for _, b := range a.GetOpenBlockers(id) {
+			j, ok := index[b]
+			if !ok || j == i {
+				continue
+			}
+			blockers[i] = append(blockers[i], j)
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	rank := scheduleUpwardRank(est, dependents)
+
+	pinned := make([]int, n) // Agent index a bead is pinned to, or -1
+	for i, id := range ids {
+		pinned[i] = -1
+		if len(opts.Assignees) > 0 {
+			if ag, ok := agentIdx[a.issueMap[id].Assignee]; ok {
+				pinned[i] = ag
+			}
+		}
+	}
+
+	pending := make([]int, n)
+	for i := range blockers {
+		pending[i] = len(blockers[i])
+	}
+	var ready []int
+	for i := 0; i < n; i++ {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	sched := Schedule{
+		Start:       opts.Start,
+		HoursPerDay: opts.HoursPerDay,
+	}
+	agentFree := make([]float64, len(agents))
+	agentLast := make([]int, len(agents))
+	for i := range agentLast {
+		agentLast[i] = -1
+	}
+	start := make([]float64, n)
+	end := make([]float64, n)
+	assigned := make([]int, n)
+	placed := make([]bool, n)
+	completed := make([]bool, n)
+	predecessor := make([]int, n) // Bead whose completion released this one (blocker or same-agent)
+	var running []int
+
+	// Agents pick work in capacity order so faster agents take the longest chains.
+	agentOrder := make([]int, len(agents))
+	for i := range agentOrder {
+		agentOrder[i] = i
+	}
+	sort.SliceStable(agentOrder, func(x, y int) bool {
+		return agents[agentOrder[x]].Capacity > agents[agentOrder[y]].Capacity
+	})
+
+	now := 0.0
+	placedCount := 0
+	for placedCount < n {
+		sort.Slice(ready, func(x, y int) bool {
+			a1, b1 := ready[x], ready[y]
+			if rank[a1] != rank[b1] {
+				return rank[a1] > rank[b1]
+			}
+			p1, p2 := a.issueMap[ids[a1]].Priority, a.issueMap[ids[b1]].Priority
+			if p1 != p2 {
+				return p1 < p2
+			}
+			return a1 < b1
+		})
+
+		// Hand ready beads to idle agents.
+		for _, ag := range agentOrder {
+			if agentFree[ag] > now {
+				continue
+			}
+			pick := -1
+			for k, cur := range ready {
+				if pinned[cur] == -1 || pinned[cur] == ag {
+					pick = k
+					break
+				}
+			}
+			if pick == -1 {
+				continue
+			}
+			cur := ready[pick]
+			ready = append(ready[:pick], ready[pick+1:]...)
+
+			predecessor[cur] = -1
+			if now > 0 {
+				for _, b := range blockers[cur] {
+					if completed[b] && end[b] == now {
+						predecessor[cur] = b
+						break
+					}
+				}
+				if predecessor[cur] == -1 && agentLast[ag] >= 0 && end[agentLast[ag]] == now {
+					predecessor[cur] = agentLast[ag]
+				}
+			}
+
+			start[cur] = now
+			end[cur] = now + float64(est[cur])/agents[ag].Capacity
+			assigned[cur] = ag
+			placed[cur] = true
+			placedCount++
+			agentFree[ag] = end[cur]
+			agentLast[ag] = cur
+			running = append(running, cur)
+		}
+
+		if placedCount == n {
+			break
+		}
+
+		if len(running) == 0 {
+			if len(ready) > 0 {
+				// Every agent is idle, so any ready bead would have been placed above.
+				break
+			}
+			// Only dependency cycles remain: release the lowest-ID unplaced bead.
+			for i := 0; i < n; i++ {
+				if !placed[i] {
+					ready = append(ready, i)
+					sched.Warnings = append(sched.Warnings, fmt.Sprintf("dependency cycle: %s scheduled before its blockers", ids[i]))
+					break
+				}
+			}
+			continue
+		}
+
+		// Advance to the next completion and release unblocked dependents.
+		next := end[running[0]]
+		for _, r := range running {
+			next = min(next, end[r])
+		}
+		now = next
+		stillRunning := running[:0]
+		for _, r := range running {
+			if end[r] > now {
+				stillRunning = append(stillRunning, r)
+				continue
+			}
+			completed[r] = true
+			for _, d := range dependents[r] {
+				pending[d]--
+				if pending[d] == 0 && !placed[d] {
+					ready = append(ready, d)
+				}
+			}
+		}
+		running = stillRunning
+	}
+
+	// Makespan and the chain of beads that determines it.
+	last := -1
+	for i := 0; i < n; i++ {
+		if last == -1 || end[i] > end[last] {
+			last = i
+		}
+	}
+	critical := make([]bool, n)
+	if last >= 0 {
+		sched.MakespanMinutes = end[last]
+		for cur, guard := last, 0; cur >= 0 && guard < n; cur, guard = predecessor[cur], guard+1 {
+			critical[cur] = true
+			sched.CriticalPath = append([]string{ids[cur]}, sched.CriticalPath...)
+		}
+	}
+	dayMinutes := opts.HoursPerDay * 60
+	sched.MakespanDays = sched.MakespanMinutes / dayMinutes
+	sched.End = addWorkingMinutes(opts.Start, sched.MakespanMinutes, dayMinutes)
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool {
+		if start[order[x]] != start[order[y]] {
+			return start[order[x]] < start[order[y]]
+		}
+		return order[x] < order[y]
+	})
+
+	loads := make([]AgentLoad, len(agents))
+	for i, ag := range agents {
+		loads[i] = AgentLoad{Name: ag.Name, Capacity: ag.Capacity, Items: []string{}}
+	}
+	sched.Items = make([]ScheduledItem, 0, n)
+	for _, i := range order {
+		iss := a.issueMap[ids[i]]
+		var blockedBy []string
+		for _, b := range blockers[i] {
+			blockedBy = append(blockedBy, ids[b])
+		}
+		sort.Strings(blockedBy)
+		ag := assigned[i]
+		sched.Items = append(sched.Items, ScheduledItem{
+			ID:               iss.ID,
+			Title:            iss.Title,
+			Priority:         iss.Priority,
+			Status:           string(iss.Status),
+			Agent:            agents[ag].Name,
+			EstimatedMinutes: est[i],
+			StartMinute:      start[i],
+			EndMinute:        end[i],
+			Start:            addWorkingMinutes(opts.Start, start[i], dayMinutes),
+			End:              addWorkingMinutes(opts.Start, end[i], dayMinutes),
+			BlockedBy:        blockedBy,
+			Critical:         critical[i],
+		})
+		loads[ag].Items = append(loads[ag].Items, iss.ID)
+		loads[ag].BusyMinutes += end[i] - start[i]
+	}
+	for i := range loads {
+		if sched.MakespanMinutes > 0 {
+			loads[i].Utilization = loads[i].BusyMinutes / sched.MakespanMinutes
+		}
+	}
+	sched.Agents = loads
+
+	return sched
+}
+
+// scheduleUpwardRank computes, for each bead, its own duration plus the
+// longest chain of dependents below it. Cycles are cut by treating a node
+// currently on the DFS stack as having no further downstream work.
+func scheduleUpwardRank(est []int, dependents [][]int) []float64 {
+	rank := make([]float64, len(est))
+	state := make([]uint8, len(est)) // 0 = unvisited, 1 = visiting, 2 = done
+	var visit func(i int) float64
+	visit = func(i int) float64 {
+		switch state[i] {
+		case 1:
+			return 0
+		case 2:
+			return rank[i]
+		}
+		state[i] = 1
+		best := 0.0
+		for _, d := range dependents[i] {
+			best = max(best, visit(d))
+		}
+		rank[i] = float64(est[i]) + best
+		state[i] = 2
+		return rank[i]
+	}
+	for i := range est {
+		visit(i)
+	}
+	return rank
+}
+
+// addWorkingMinutes maps an offset in working minutes onto the calendar,
+// skipping weekends. Working days begin at the origin's time of day.
+func addWorkingMinutes(origin time.Time, minutes, dayMinutes float64) time.Time {
+	if dayMinutes <= 0 {
+		dayMinutes = 8 * 60
+	}
+	day := origin
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, 1)
+	}
+	for minutes >= dayMinutes {
+		minutes -= dayMinutes
+		day = day.AddDate(0, 0, 1)
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+	return day.Add(time.Duration(minutes * float64(time.Minute)))
+}
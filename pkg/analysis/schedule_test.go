@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func scheduleFixture() []model.Issue {
+	est := func(m int) *int { return &m }
+	blocks := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "A", Title: "Foundation", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, EstimatedMinutes: est(120)},
+		{ID: "B", Title: "Build on A", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, EstimatedMinutes: est(60), Dependencies: blocks("B", "A")},
+		{ID: "C", Title: "Also on A", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, EstimatedMinutes: est(60), Dependencies: blocks("C", "A")},
+		{ID: "D", Title: "Independent", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask, EstimatedMinutes: est(30)},
+		{ID: "E", Title: "Done", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeTask, EstimatedMinutes: est(30)},
+	}
+}
+
+func TestGetSchedule_RespectsDependencies(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC) // Monday
+	a := NewAnalyzer(scheduleFixture())
+
+	sched := a.GetSchedule(ScheduleOptions{Agents: 2, Start: start})
+	if len(sched.Items) != 4 {
+		t.Fatalf("expected 4 open items scheduled, got %d", len(sched.Items))
+	}
+
+	byID := make(map[string]ScheduledItem)
+	for _, item := range sched.Items {
+		byID[item.ID] = item
+	}
+	if _, ok := byID["E"]; ok {
+		t.Error("closed issue should not be scheduled")
+	}
+	for _, id := range []string{"B", "C"} {
+		if byID[id].StartMinute < byID["A"].EndMinute {
+			t.Errorf("%s starts at %.0f before blocker A ends at %.0f", id, byID[id].StartMinute, byID["A"].EndMinute)
+		}
+	}
+
+	// With two agents, B and C run in parallel after A: makespan = 120 + 60.
+	if sched.MakespanMinutes != 180 {
+		t.Errorf("makespan = %.0f, want 180", sched.MakespanMinutes)
+	}
+	if sched.MakespanDays != 180.0/480.0 {
+		t.Errorf("makespan days = %f, want %f", sched.MakespanDays, 180.0/480.0)
+	}
+	if !sched.End.Equal(start.Add(180 * time.Minute)) {
+		t.Errorf("end = %v, want %v", sched.End, start.Add(180*time.Minute))
+	}
+	if len(sched.CriticalPath) < 2 || sched.CriticalPath[0] != "A" {
+		t.Errorf("expected critical path to start with A, got %v", sched.CriticalPath)
+	}
+	if !byID["A"].Critical {
+		t.Error("A should be marked critical")
+	}
+	if byID["D"].Critical {
+		t.Error("D should not be critical")
+	}
+}
+
+func TestGetSchedule_SingleAgentIsSerial(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	a := NewAnalyzer(scheduleFixture())
+
+	sched := a.GetSchedule(ScheduleOptions{Agents: 1, Start: start})
+	// All open work back-to-back: 120 + 60 + 60 + 30.
+	if sched.MakespanMinutes != 270 {
+		t.Errorf("makespan = %.0f, want 270", sched.MakespanMinutes)
+	}
+	if len(sched.Agents) != 1 || sched.Agents[0].Utilization != 1 {
+		t.Errorf("expected one fully utilized agent, got %+v", sched.Agents)
+	}
+
+	// Items are listed in start order with no overlap on the single agent.
+	for i := 1; i < len(sched.Items); i++ {
+		if sched.Items[i].StartMinute < sched.Items[i-1].EndMinute {
+			t.Errorf("overlap between %s and %s", sched.Items[i-1].ID, sched.Items[i].ID)
+		}
+	}
+}
+
+func TestGetSchedule_NamedAssigneesWithCapacity(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	issues := scheduleFixture()
+	issues[3].Assignee = "bob" // D pinned to bob
+
+	a := NewAnalyzer(issues)
+	sched := a.GetSchedule(ScheduleOptions{
+		Assignees: []ScheduleAgent{{Name: "alice", Capacity: 2}, {Name: "bob", Capacity: 0.5}},
+		Start:     start,
+	})
+
+	byID := make(map[string]ScheduledItem)
+	for _, item := range sched.Items {
+		byID[item.ID] = item
+	}
+	if byID["D"].Agent != "bob" {
+		t.Errorf("D should be pinned to bob, got %s", byID["D"].Agent)
+	}
+	if got := byID["D"].EndMinute - byID["D"].StartMinute; got != 60 {
+		t.Errorf("bob at 0.5 capacity should take 60m for a 30m bead, got %.0f", got)
+	}
+	if byID["A"].Agent != "alice" {
+		t.Errorf("A should go to the faster agent, got %s", byID["A"].Agent)
+	}
+	if got := byID["A"].EndMinute - byID["A"].StartMinute; got != 60 {
+		t.Errorf("alice at 2x capacity should take 60m for a 120m bead, got %.0f", got)
+	}
+}
+
+func TestGetSchedule_CycleIsBroken(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "X", Title: "X", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "X", DependsOnID: "Y", Type: model.DepBlocks}}},
+		{ID: "Y", Title: "Y", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "Y", DependsOnID: "X", Type: model.DepBlocks}}},
+	}
+	sched := NewAnalyzer(issues).GetSchedule(ScheduleOptions{Agents: 1, Start: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)})
+	if len(sched.Items) != 2 {
+		t.Fatalf("expected both cycle members scheduled, got %d", len(sched.Items))
+	}
+	if len(sched.Warnings) == 0 {
+		t.Error("expected a cycle warning")
+	}
+}
+
+func TestParseScheduleAssignees(t *testing.T) {
+	agents, err := ParseScheduleAssignees("alice=1, bob=0.5,carol")
+	if err != nil {
+		t.Fatalf("ParseScheduleAssignees failed: %v", err)
+	}
+	want := []ScheduleAgent{{"alice", 1}, {"bob", 0.5}, {"carol", 1}}
+	if len(agents) != len(want) {
+		t.Fatalf("got %v, want %v", agents, want)
+	}
+	for i := range want {
+		if agents[i] != want[i] {
+			t.Errorf("agent %d = %+v, want %+v", i, agents[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"alice=0", "alice=x", "=1", "alice,alice"} {
+		if _, err := ParseScheduleAssignees(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestAddWorkingMinutes_SkipsWeekends(t *testing.T) {
+	friday := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	got := addWorkingMinutes(friday, 480+60, 480)
+	want := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC) // Monday 10:00
+	if !got.Equal(want) {
+		t.Errorf("addWorkingMinutes = %v, want %v", got, want)
+	}
+}
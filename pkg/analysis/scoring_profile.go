@@ -0,0 +1,231 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ScoringProfileFile is the name of the scoring profile config under .bv/
+const ScoringProfileFile = "scoring.yaml"
+
+// DefaultScoringProfileName names the built-in profile that uses the
+// compiled-in Weight* constants.
+const DefaultScoringProfileName = "default"
+
+// ScoringWeights are the relative weights of the impact score components.
+// They are normalized to sum to 1 before scoring, so only their ratios matter.
+type ScoringWeights struct {
+	PageRank      float64 `yaml:"pagerank" json:"pagerank"`
+	Betweenness   float64 `yaml:"betweenness" json:"betweenness"`
+	BlockerRatio  float64 `yaml:"blocker_ratio" json:"blocker_ratio"`
+	Staleness     float64 `yaml:"staleness" json:"staleness"`
+	PriorityBoost float64 `yaml:"priority_boost" json:"priority_boost"`
+	TimeToImpact  float64 `yaml:"time_to_impact" json:"time_to_impact"`
+	Urgency       float64 `yaml:"urgency" json:"urgency"`
+	Risk          float64 `yaml:"risk" json:"risk"`
+}
+
+// DefaultScoringWeights returns the compiled-in weights.
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{
+		PageRank:      WeightPageRank,
+		Betweenness:   WeightBetweenness,
+		BlockerRatio:  WeightBlockerRatio,
+		Staleness:     WeightStaleness,
+		PriorityBoost: WeightPriorityBoost,
+		TimeToImpact:  WeightTimeToImpact,
+		Urgency:       WeightUrgency,
+		Risk:          WeightRisk,
+	}
+}
+
+func (w ScoringWeights) sum() float64 {
+	return w.PageRank + w.Betweenness + w.BlockerRatio + w.Staleness +
+		w.PriorityBoost + w.TimeToImpact + w.Urgency + w.Risk
+}
+
+// Normalized returns the weights scaled to sum to 1.
+func (w ScoringWeights) Normalized() ScoringWeights {
+	total := w.sum()
+	if total <= 0 {
+		return DefaultScoringWeights()
+	}
+	if math.Abs(total-1) < 1e-9 {
+		return w
+	}
+	return ScoringWeights{
+		PageRank:      w.PageRank / total,
+		Betweenness:   w.Betweenness / total,
+		BlockerRatio:  w.BlockerRatio / total,
+		Staleness:     w.Staleness / total,
+		PriorityBoost: w.PriorityBoost / total,
+		TimeToImpact:  w.TimeToImpact / total,
+		Urgency:       w.Urgency / total,
+		Risk:          w.Risk / total,
+	}
+}
+
+// ScoringProfile is a named ranking configuration: component weights plus
+// per-label score multipliers.
+type ScoringProfile struct {
+	Name        string         `yaml:"-" json:"name"`
+	Description string         `yaml:"description,omitempty" json:"description,omitempty"`
+	Weights     ScoringWeights `yaml:"weights" json:"weights"`
+
+	// LabelMultipliers scale the composite score of issues carrying the
+	// label (e.g. bug: 1.5). Multiple matching labels multiply together.
+	LabelMultipliers map[string]float64 `yaml:"label_multipliers,omitempty" json:"label_multipliers,omitempty"`
+}
+
+// UnmarshalYAML starts from the default weights so a profile only needs to
+// list the weights it changes.
+func (p *ScoringProfile) UnmarshalYAML(node *yaml.Node) error {
+	type rawProfile ScoringProfile
+	raw := rawProfile{Weights: DefaultScoringWeights()}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	*p = ScoringProfile(raw)
+	return nil
+}
+
+// DefaultScoringProfile returns the built-in profile.
+func DefaultScoringProfile() *ScoringProfile {
+	return &ScoringProfile{
+		Name:        DefaultScoringProfileName,
+		Description: "Built-in weights",
+		Weights:     DefaultScoringWeights(),
+	}
+}
+
+// labelMultiplier returns the combined multiplier for a set of labels.
+func (p *ScoringProfile) labelMultiplier(labels []string) float64 {
+	if p == nil || len(p.LabelMultipliers) == 0 {
+		return 1
+	}
+	m := 1.0
+	for _, label := range labels {
+		if v, ok := p.LabelMultipliers[label]; ok {
+			m *= v
+		}
+	}
+	return m
+}
+
+// Validate checks that weights and multipliers are usable.
+func (p *ScoringProfile) Validate() error {
+	w := p.Weights
+	for name, v := range map[string]float64{
+		"pagerank": w.PageRank, "betweenness": w.Betweenness, "blocker_ratio": w.BlockerRatio,
+		"staleness": w.Staleness, "priority_boost": w.PriorityBoost, "time_to_impact": w.TimeToImpact,
+		"urgency": w.Urgency, "risk": w.Risk,
+	} {
+		if v < 0 {
+			return fmt.Errorf("weight %s must be non-negative", name)
+		}
+	}
+	if w.sum() <= 0 {
+		return fmt.Errorf("at least one weight must be positive")
+	}
+	for label, m := range p.LabelMultipliers {
+		if m < 0 {
+			return fmt.Errorf("label multiplier for %q must be non-negative", label)
+		}
+	}
+	return nil
+}
+
+// ScoringConfig is the contents of .bv/scoring.yaml.
+type ScoringConfig struct {
+	// Default names the profile used when none is requested explicitly.
+	Default  string                     `yaml:"default,omitempty" json:"default,omitempty"`
+	Profiles map[string]*ScoringProfile `yaml:"profiles" json:"profiles"`
+}
+
+// ScoringConfigPath returns the scoring config path for a project.
+func ScoringConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ScoringProfileFile)
+}
+
+// LoadScoringConfig loads .bv/scoring.yaml from projectDir. A missing file
+// yields a config containing only the built-in default profile.
+func LoadScoringConfig(projectDir string) (*ScoringConfig, error) {
+	data, err := os.ReadFile(ScoringConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ParseScoringConfig(nil)
+		}
+		return nil, fmt.Errorf("reading scoring config: %w", err)
+	}
+	return ParseScoringConfig(data)
+}
+
+// ParseScoringConfig decodes and validates scoring profile YAML.
+func ParseScoringConfig(data []byte) (*ScoringConfig, error) {
+	cfg := &ScoringConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing scoring config: %w", err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]*ScoringProfile)
+	}
+	for name, p := range cfg.Profiles {
+		if p == nil {
+			p = &ScoringProfile{Weights: DefaultScoringWeights()}
+			cfg.Profiles[name] = p
+		}
+		p.Name = name
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scoring profile %q: %w", name, err)
+		}
+	}
+	if _, ok := cfg.Profiles[DefaultScoringProfileName]; !ok {
+		cfg.Profiles[DefaultScoringProfileName] = DefaultScoringProfile()
+	}
+	if cfg.Default != "" {
+		if _, ok := cfg.Profiles[cfg.Default]; !ok {
+			return nil, fmt.Errorf("scoring config: default profile %q is not defined", cfg.Default)
+		}
+	}
+	return cfg, nil
+}
+
+// Names returns the profile names, with the active default first and the
+// rest sorted.
+func (c *ScoringConfig) Names() []string {
+	def := c.defaultName()
+	names := []string{def}
+	rest := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		if name != def {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+func (c *ScoringConfig) defaultName() string {
+	if c.Default != "" {
+		return c.Default
+	}
+	return DefaultScoringProfileName
+}
+
+// Profile returns the named profile; an empty name selects the config's
+// default.
+func (c *ScoringConfig) Profile(name string) (*ScoringProfile, error) {
+	if name == "" {
+		name = c.defaultName()
+	}
+	if p, ok := c.Profiles[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("unknown scoring profile %q (available: %s)", name, strings.Join(c.Names(), ", "))
+}
@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestParseScoringConfig(t *testing.T) {
+	cfg, err := ParseScoringConfig([]byte(`
+default: roadmap
+profiles:
+  roadmap:
+    description: Long-range planning
+    weights:
+      pagerank: 0.5
+      staleness: 0
+  bugfix:
+    weights:
+      priority_boost: 0.6
+      urgency: 0.4
+    label_multipliers:
+      bug: 1.5
+`))
+	if err != nil {
+		t.Fatalf("ParseScoringConfig: %v", err)
+	}
+	if got := strings.Join(cfg.Names(), ","); got != "roadmap,bugfix,default" {
+		t.Errorf("Names() = %s", got)
+	}
+
+	roadmap, err := cfg.Profile("")
+	if err != nil || roadmap.Name != "roadmap" {
+		t.Fatalf("default profile = %v, %v", roadmap, err)
+	}
+	// Unlisted weights keep their built-in values.
+	if roadmap.Weights.PageRank != 0.5 || roadmap.Weights.Staleness != 0 || roadmap.Weights.Betweenness != WeightBetweenness {
+		t.Errorf("roadmap weights = %+v", roadmap.Weights)
+	}
+	if n := roadmap.Weights.Normalized(); math.Abs(n.sum()-1) > 1e-9 {
+		t.Errorf("normalized weights sum to %v", n.sum())
+	}
+
+	bugfix, _ := cfg.Profile("bugfix")
+	if bugfix.labelMultiplier([]string{"bug", "ui"}) != 1.5 {
+		t.Errorf("bug multiplier = %v", bugfix.labelMultiplier([]string{"bug"}))
+	}
+	if _, err := cfg.Profile("nope"); err == nil || !strings.Contains(err.Error(), "roadmap, bugfix, default") {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
+
+func TestParseScoringConfig_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"profiles:\n  x:\n    weights:\n      pagerank: -1\n":      "non-negative",
+		"profiles:\n  x:\n    label_multipliers:\n      bug: -2\n": "non-negative",
+		"default: missing\n": "not defined",
+		"profiles:\n  x:\n    weights: {pagerank: 0, betweenness: 0, blocker_ratio: 0, staleness: 0, priority_boost: 0, time_to_impact: 0, urgency: 0, risk: 0}\n": "positive",
+	}
+	for input, want := range cases {
+		if _, err := ParseScoringConfig([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseScoringConfig(%q) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestScoringProfile_ChangesTriageRanking(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	mk := func(id string, priority int, deps ...string) model.Issue {
+		issue := incIssue(id, deps...)
+		issue.Priority = priority
+		issue.CreatedAt = now.Add(-48 * time.Hour)
+		issue.UpdatedAt = now.Add(-24 * time.Hour)
+		return issue
+	}
+	issues := []model.Issue{
+		mk("hub", 4), mk("d1", 4, "hub"), mk("d2", 4, "hub"), mk("d3", 4, "hub"),
+		mk("urgent", 0),
+	}
+	opts := TriageOptions{WaitForPhase2: true}
+
+	base := ComputeTriageWithOptionsAndTime(issues, opts, now)
+	if base.Meta.ScoringProfile != DefaultScoringProfileName {
+		t.Errorf("default meta.scoring_profile = %q", base.Meta.ScoringProfile)
+	}
+	if base.Recommendations[0].ID != "hub" {
+		t.Fatalf("built-in weights should rank the hub first, got %s", base.Recommendations[0].ID)
+	}
+
+	opts.ScoringProfile = &ScoringProfile{Name: "bugfix", Weights: ScoringWeights{PriorityBoost: 1}}
+	bugfix := ComputeTriageWithOptionsAndTime(issues, opts, now)
+	if bugfix.Meta.ScoringProfile != "bugfix" {
+		t.Errorf("meta.scoring_profile = %q", bugfix.Meta.ScoringProfile)
+	}
+	if bugfix.Recommendations[0].ID != "urgent" {
+		t.Errorf("priority-only profile should rank the P0 first, got %s", bugfix.Recommendations[0].ID)
+	}
+}
+
+func TestScoringProfile_LabelMultiplier(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	a, b := incIssue("A"), incIssue("B")
+	b.Labels = []string{"bug"}
+	issues := []model.Issue{a, b}
+
+	analyzer := NewAnalyzer(issues)
+	analyzer.SetScoringProfile(&ScoringProfile{Name: "bugs", Weights: DefaultScoringWeights(), LabelMultipliers: map[string]float64{"bug": 2}})
+	scores := analyzer.ComputeImpactScoresAt(now)
+
+	byID := map[string]ImpactScore{}
+	for _, s := range scores {
+		byID[s.IssueID] = s
+	}
+	if math.Abs(byID["B"].Score-2*byID["A"].Score) > 1e-9 {
+		t.Errorf("B score %v, want twice A's %v", byID["B"].Score, byID["A"].Score)
+	}
+	if byID["B"].Breakdown.LabelMultiplier != 2 || byID["A"].Breakdown.LabelMultiplier != 0 {
+		t.Errorf("label multipliers = %v / %v", byID["A"].Breakdown.LabelMultiplier, byID["B"].Breakdown.LabelMultiplier)
+	}
+}
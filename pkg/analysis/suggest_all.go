@@ -24,6 +24,11 @@ type SuggestAllConfig struct {
 	// EnableDuplicates enables duplicate detection
 	EnableDuplicates bool
 
+	// SemanticDuplicates optionally adds embedding-based duplicate
+	// suggestions (see search.DetectSemanticDuplicates, which this package
+	// can't import). Pairs found by both methods are reported once.
+	SemanticDuplicates func(issues []model.Issue) []Suggestion
+
 	// EnableDependencies enables dependency suggestions
 	EnableDependencies bool
 
@@ -69,6 +74,9 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 	// Run enabled detectors
 	if config.EnableDuplicates && (config.FilterType == "" || config.FilterType == SuggestionPotentialDuplicate) {
 		duplicates := DetectDuplicates(issues, config.Duplicates)
+		if config.SemanticDuplicates != nil {
+			duplicates = MergeDuplicateSuggestions(duplicates, config.SemanticDuplicates(issues))
+		}
 		allSuggestions = append(allSuggestions, duplicates...)
 	}
 
@@ -12,7 +12,7 @@ import (
 )
 
 func isClosedLikeStatus(status model.Status) bool {
-	return status == model.StatusClosed || status == model.StatusTombstone
+	return status.Class().IsResolved()
 }
 
 // TriageResult is the unified output for --robot-triage
@@ -40,6 +40,9 @@ type TriageMeta struct {
 	Phase2Ready   bool      `json:"phase2_ready"`
 	IssueCount    int       `json:"issue_count"`
 	ComputeTimeMs int64     `json:"compute_time_ms"`
+
+	// ScoringProfile names the weight profile recommendations were ranked with
+	ScoringProfile string `json:"scoring_profile"`
 }
 
 // QuickRef provides at-a-glance summary for fast decisions
@@ -111,6 +114,7 @@ type HealthCounts struct {
 	Blocked    int            `json:"blocked"`
 	Actionable int            `json:"actionable"`
 	ByStatus   map[string]int `json:"by_status"`
+	ByClass    map[string]int `json:"by_class"` // Statuses grouped by workflow class
 	ByType     map[string]int `json:"by_type"`
 	ByPriority map[int]int    `json:"by_priority"`
 }
@@ -168,7 +172,7 @@ func ComputeProjectVelocity(issues []model.Issue, now time.Time, weeks int) *Vel
 	monthAgo := now.Add(-30 * 24 * time.Hour)
 
 	for _, iss := range issues {
-		if iss.Status != model.StatusClosed {
+		if !iss.Status.IsClosed() {
 			continue
 		}
 
@@ -303,6 +307,9 @@ type TriageOptions struct {
 
 	// History report for staleness analysis
 	History *correlation.HistoryReport
+
+	// ScoringProfile overrides the analyzer's scoring profile (nil keeps it)
+	ScoringProfile *ScoringProfile
 }
 
 // TrackRecommendationGroup groups recommendations by execution track (bv-87)
@@ -400,7 +407,15 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	triageCtx := NewTriageContext(analyzer)
 
 	// Compute impact scores using the already-computed stats
-	impactScores := analyzer.ComputeImpactScoresFromStats(stats, now)
+	profile := opts.ScoringProfile
+	if profile == nil {
+		profile = analyzer.scoring
+	}
+	impactScores := analyzer.computeImpactScores(stats, now, profile)
+	profileName := DefaultScoringProfileName
+	if profile != nil && profile.Name != "" {
+		profileName = profile.Name
+	}
 
 	// Build unblocks map
 	unblocksMap := buildUnblocksMap(analyzer)
@@ -451,17 +466,18 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 
 	return TriageResult{
 		Meta: TriageMeta{
-			Version:       "1.0.0",
-			GeneratedAt:   now,
-			Phase2Ready:   stats.IsPhase2Ready(),
-			IssueCount:    len(issues),
-			ComputeTimeMs: elapsed.Milliseconds(),
+			Version:        "1.0.0",
+			GeneratedAt:    now,
+			Phase2Ready:    stats.IsPhase2Ready(),
+			IssueCount:     len(issues),
+			ComputeTimeMs:  elapsed.Milliseconds(),
+			ScoringProfile: profileName,
 		},
 		QuickRef: QuickRef{
 			OpenCount:       counts.Open,
 			ActionableCount: counts.Actionable,
 			BlockedCount:    counts.Blocked,
-			InProgressCount: counts.ByStatus["in_progress"],
+			InProgressCount: counts.ByClass[string(model.ClassActive)],
 			TopPicks:        topPicks,
 		},
 		Recommendations:        recommendations,
@@ -488,7 +504,7 @@ func ComputeStaleness(history *correlation.HistoryReport, issues []model.Issue,
 	stalestID := ""
 
 	for _, issue := range issues {
-		if issue.Status == model.StatusClosed || issue.Status == model.StatusTombstone {
+		if isClosedLikeStatus(issue.Status) {
 			continue
 		}
 
@@ -623,6 +639,7 @@ func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 	counts := HealthCounts{
 		Total:      len(issues),
 		ByStatus:   make(map[string]int),
+		ByClass:    make(map[string]int),
 		ByType:     make(map[string]int),
 		ByPriority: make(map[int]int),
 	}
@@ -635,6 +652,7 @@ func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 
 	for _, issue := range issues {
 		counts.ByStatus[string(issue.Status)]++
+		counts.ByClass[string(issue.Status.Class())]++
 		counts.ByType[string(issue.IssueType)]++
 		counts.ByPriority[issue.Priority]++
 
@@ -659,12 +677,14 @@ func computeCountsWithContext(issues []model.Issue, ctx *TriageContext) HealthCo
 	counts := HealthCounts{
 		Total:      len(issues),
 		ByStatus:   make(map[string]int),
+		ByClass:    make(map[string]int),
 		ByType:     make(map[string]int),
 		ByPriority: make(map[int]int),
 	}
 
 	for _, issue := range issues {
 		counts.ByStatus[string(issue.Status)]++
+		counts.ByClass[string(issue.Status.Class())]++
 		counts.ByType[string(issue.IssueType)]++
 		counts.ByPriority[issue.Priority]++
 
@@ -1114,7 +1134,7 @@ func computeSingleTriageScore(base ImpactScore, unblocksMap map[string][]string,
 
 	// Calculate quick-win boost
 	// Quick wins are items with low blocker depth but high impact
-	if issue := analyzer.GetIssue(base.IssueID); issue == nil || issue.Status != model.StatusInProgress {
+	if issue := analyzer.GetIssue(base.IssueID); issue == nil || issue.Status.Class() != model.ClassActive {
 		if blockerDepth <= opts.QuickWinMaxDepth && blockerDepth >= 0 {
 			// Lower depth = higher quick win potential
 			depthFactor := 1.0 - float64(blockerDepth)/float64(opts.QuickWinMaxDepth+1)
@@ -1298,7 +1318,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	var reasons []string
 	primary := ""
 	actionHint := "Start work on this issue"
-	if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+	if ctx.Issue != nil && ctx.Issue.Status.Class() == model.ClassActive {
 		actionHint = "Continue work on this issue"
 	}
 
@@ -1347,13 +1367,13 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	if ctx.DaysSinceUpdate > 14 {
 		reason := fmt.Sprintf("🕐 No activity in %d days - may need review", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
-		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+		if ctx.Issue != nil && ctx.Issue.Status.Class() == model.ClassActive {
 			actionHint = "Check if this is stuck and needs help"
 		}
 	} else if ctx.DaysSinceUpdate > 7 {
 		reason := fmt.Sprintf("📅 Last updated %d days ago", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
-		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+		if ctx.Issue != nil && ctx.Issue.Status.Class() == model.ClassActive {
 			actionHint = "Continue work on this issue"
 		}
 	}
@@ -1367,7 +1387,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 		}
 
 		// Update action hint unless in-progress (keep work/review guidance) or critically stale
-		isInProgress := ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress
+		isInProgress := ctx.Issue != nil && ctx.Issue.Status.Class() == model.ClassActive
 		isCriticalStale := isInProgress && ctx.DaysSinceUpdate > 14
 		if !isInProgress && !isCriticalStale {
 			actionHint = "Quick win - start here for fast progress"
@@ -1375,7 +1395,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	}
 
 	// 6. Agent claim status
-	isInProgress := ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress
+	isInProgress := ctx.Issue != nil && ctx.Issue.Status.Class() == model.ClassActive
 	if isInProgress {
 		if ctx.ClaimedByAgent != "" {
 			reason := fmt.Sprintf("👤 Claimed by %s", ctx.ClaimedByAgent)
@@ -0,0 +1,338 @@
+// Package correlation provides line-level bead attribution via git blame.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uncommittedSHA is what git blame reports for lines changed in the working tree
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// blameRangeSuffix matches the optional ":START-END" or ":LINE" suffix of a blame target
+var blameRangeSuffix = regexp.MustCompile(`:(\d+)(?:-(\d+))?$`)
+
+// BlameOptions selects the file and lines to annotate
+type BlameOptions struct {
+	Path        string // File path relative to the repository root
+	StartLine   int    // First line, 1-based (0 = start of file)
+	EndLine     int    // Last line, inclusive (0 = end of file)
+	IncludeText bool   // Include each range's source lines
+}
+
+// BlameBead is a bead linked to the commit that last changed a range
+type BlameBead struct {
+	BeadID     string            `json:"bead_id"`
+	Title      string            `json:"title"`
+	Status     string            `json:"status"`
+	Confidence float64           `json:"confidence"` // Confidence of the bead's link to the commit
+	Method     CorrelationMethod `json:"method"`
+}
+
+// BlameRange is a run of consecutive lines last changed by the same commit
+type BlameRange struct {
+	StartLine   int         `json:"start_line"`
+	EndLine     int         `json:"end_line"`
+	SHA         string      `json:"sha"`
+	ShortSHA    string      `json:"short_sha"`
+	Author      string      `json:"author"`
+	Timestamp   time.Time   `json:"timestamp"`
+	Summary     string      `json:"summary"`
+	Uncommitted bool        `json:"uncommitted,omitempty"` // Working tree changes not yet committed
+	Beads       []BlameBead `json:"beads"`                 // Highest confidence first; empty when unattributed
+	Text        []string    `json:"text,omitempty"`        // Source lines, with BlameOptions.IncludeText
+}
+
+// Lines returns the number of lines in the range
+func (r BlameRange) Lines() int {
+	return r.EndLine - r.StartLine + 1
+}
+
+// BlameBeadSummary totals how many of the annotated lines a bead owns
+type BlameBeadSummary struct {
+	BlameBead
+	Lines  int `json:"lines"`
+	Ranges int `json:"ranges"`
+}
+
+// BlameStats summarizes attribution coverage for the annotated lines
+type BlameStats struct {
+	TotalLines      int     `json:"total_lines"`
+	AttributedLines int     `json:"attributed_lines"` // Lines whose commit is linked to at least one bead
+	Coverage        float64 `json:"coverage"`         // AttributedLines / TotalLines
+	Commits         int     `json:"commits"`
+	Beads           int     `json:"beads"`
+}
+
+// BlameReport is the output of --robot-blame
+type BlameReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	DataHash    string             `json:"data_hash"`
+	FilePath    string             `json:"file_path"`
+	StartLine   int                `json:"start_line"`
+	EndLine     int                `json:"end_line"`
+	Stats       BlameStats         `json:"stats"`
+	Ranges      []BlameRange       `json:"ranges"`
+	Beads       []BlameBeadSummary `json:"beads"` // Most lines first
+}
+
+// blameLine is one line of git blame --porcelain output
+type blameLine struct {
+	sha  string
+	line int
+	text string
+}
+
+// blameCommit holds the per-commit headers git prints once per SHA
+type blameCommit struct {
+	author    string
+	timestamp time.Time
+	summary   string
+}
+
+// ParseBlameTarget splits a "FILE[:START-END]" argument into its path and
+// line range. A single ":LINE" annotates one line; without a suffix the
+// whole file is annotated and both lines are 0.
+func ParseBlameTarget(target string) (path string, start, end int, err error) {
+	path = target
+	if m := blameRangeSuffix.FindStringSubmatch(target); m != nil {
+		path = strings.TrimSuffix(target, m[0])
+		start, _ = strconv.Atoi(m[1])
+		end = start
+		if m[2] != "" {
+			end, _ = strconv.Atoi(m[2])
+		}
+		if start < 1 || end < start {
+			return "", 0, 0, fmt.Errorf("invalid line range %q (want START-END with 1 <= START <= END)", strings.TrimPrefix(m[0], ":"))
+		}
+	}
+	if path == "" {
+		return "", 0, 0, fmt.Errorf("file path is required")
+	}
+	return path, start, end, nil
+}
+
+// Blame runs git blame on a file and joins each line's commit against the
+// report's CommitIndex, returning the beads behind every range of lines.
+func (hr *HistoryReport) Blame(repoPath string, opts BlameOptions) (*BlameReport, error) {
+	if opts.Path == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+	path := filepath.ToSlash(filepath.Clean(opts.Path))
+
+	args := []string{"blame", "--porcelain"}
+	if opts.StartLine > 0 {
+		if opts.EndLine > 0 {
+			args = append(args, "-L", fmt.Sprintf("%d,%d", opts.StartLine, opts.EndLine))
+		} else {
+			args = append(args, "-L", fmt.Sprintf("%d,", opts.StartLine))
+		}
+	}
+	args = append(args, "--", path)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git blame failed: %s", msg)
+		}
+		return nil, fmt.Errorf("git blame failed: %w", err)
+	}
+
+	lines, commits, err := parseBlamePorcelain(out)
+	if err != nil {
+		return nil, err
+	}
+	return hr.buildBlameReport(path, lines, commits, opts.IncludeText), nil
+}
+
+// parseBlamePorcelain reads git blame --porcelain output. Each line starts
+// with "<sha> <orig> <final> [<count>]"; the commit's headers follow only
+// the first time a SHA appears, then the tab-prefixed source line.
+func parseBlamePorcelain(out []byte) ([]blameLine, map[string]*blameCommit, error) {
+	var lines []blameLine
+	commits := make(map[string]*blameCommit)
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	var cur *blameLine
+	for scanner.Scan() {
+		text := scanner.Text()
+		if strings.HasPrefix(text, "\t") {
+			if cur == nil {
+				return nil, nil, fmt.Errorf("malformed blame output: source line without header")
+			}
+			cur.text = text[1:]
+			lines = append(lines, *cur)
+			cur = nil
+			continue
+		}
+		if cur == nil {
+			fields := strings.Fields(text)
+			if len(fields) < 3 || len(fields[0]) != 40 {
+				return nil, nil, fmt.Errorf("malformed blame header %q", text)
+			}
+			final, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, nil, fmt.Errorf("malformed blame header %q", text)
+			}
+			cur = &blameLine{sha: fields[0], line: final}
+			if commits[cur.sha] == nil {
+				commits[cur.sha] = &blameCommit{}
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(text, " ")
+		c := commits[cur.sha]
+		switch key {
+		case "author":
+			c.author = value
+		case "author-time":
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				c.timestamp = time.Unix(secs, 0).UTC()
+			}
+		case "summary":
+			c.summary = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading blame output: %w", err)
+	}
+	return lines, commits, nil
+}
+
+// buildBlameReport groups blamed lines into per-commit ranges and attaches
+// the beads linked to each commit
+func (hr *HistoryReport) buildBlameReport(path string, lines []blameLine, commits map[string]*blameCommit, includeText bool) *BlameReport {
+	report := &BlameReport{
+		GeneratedAt: time.Now().UTC(),
+		FilePath:    path,
+		Ranges:      []BlameRange{},
+		Beads:       []BlameBeadSummary{},
+	}
+	if len(lines) == 0 {
+		return report
+	}
+	report.StartLine = lines[0].line
+	report.EndLine = lines[len(lines)-1].line
+
+	beadsBySHA := make(map[string][]BlameBead)
+	summaries := make(map[string]*BlameBeadSummary)
+	shas := make(map[string]bool)
+	for _, l := range lines {
+		last := len(report.Ranges) - 1
+		if last >= 0 && report.Ranges[last].SHA == l.sha && report.Ranges[last].EndLine == l.line-1 {
+			report.Ranges[last].EndLine = l.line
+			if includeText {
+				report.Ranges[last].Text = append(report.Ranges[last].Text, l.text)
+			}
+			continue
+		}
+
+		if _, ok := beadsBySHA[l.sha]; !ok {
+			beadsBySHA[l.sha] = hr.blameBeads(l.sha)
+		}
+		c := commits[l.sha]
+		r := BlameRange{
+			StartLine: l.line,
+			EndLine:   l.line,
+			SHA:       l.sha,
+			ShortSHA:  l.sha[:7],
+			Author:    c.author,
+			Timestamp: c.timestamp,
+			Summary:   c.summary,
+			Beads:     beadsBySHA[l.sha],
+		}
+		if l.sha == uncommittedSHA {
+			r.Uncommitted = true
+			r.Beads = nil
+		}
+		if r.Beads == nil {
+			r.Beads = []BlameBead{}
+		}
+		if includeText {
+			r.Text = []string{l.text}
+		}
+		report.Ranges = append(report.Ranges, r)
+	}
+
+	for _, r := range report.Ranges {
+		n := r.Lines()
+		report.Stats.TotalLines += n
+		if !r.Uncommitted {
+			shas[r.SHA] = true
+		}
+		if len(r.Beads) > 0 {
+			report.Stats.AttributedLines += n
+		}
+		for _, b := range r.Beads {
+			s := summaries[b.BeadID]
+			if s == nil {
+				s = &BlameBeadSummary{BlameBead: b}
+				summaries[b.BeadID] = s
+			}
+			s.Lines += n
+			s.Ranges++
+			s.Confidence = max(s.Confidence, b.Confidence)
+		}
+	}
+	report.Stats.Commits = len(shas)
+	report.Stats.Beads = len(summaries)
+	if report.Stats.TotalLines > 0 {
+		report.Stats.Coverage = float64(report.Stats.AttributedLines) / float64(report.Stats.TotalLines)
+	}
+
+	for _, s := range summaries {
+		report.Beads = append(report.Beads, *s)
+	}
+	sort.Slice(report.Beads, func(i, j int) bool {
+		a, b := report.Beads[i], report.Beads[j]
+		if a.Lines != b.Lines {
+			return a.Lines > b.Lines
+		}
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		return a.BeadID < b.BeadID
+	})
+	return report
+}
+
+// blameBeads looks up the beads linked to a commit, highest confidence first
+func (hr *HistoryReport) blameBeads(sha string) []BlameBead {
+	var beads []BlameBead
+	for _, id := range hr.CommitIndex[sha] {
+		hist, ok := hr.Histories[id]
+		if !ok {
+			continue
+		}
+		b := BlameBead{BeadID: id, Title: hist.Title, Status: hist.Status}
+		for _, c := range hist.Commits {
+			if c.SHA == sha {
+				b.Confidence = c.Confidence
+				b.Method = c.Method
+				break
+			}
+		}
+		beads = append(beads, b)
+	}
+	sort.SliceStable(beads, func(i, j int) bool {
+		if beads[i].Confidence != beads[j].Confidence {
+			return beads[i].Confidence > beads[j].Confidence
+		}
+		return beads[i].BeadID < beads[j].BeadID
+	})
+	return beads
+}
@@ -0,0 +1,135 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBlameTarget(t *testing.T) {
+	tests := []struct {
+		in         string
+		path       string
+		start, end int
+		wantErr    bool
+	}{
+		{in: "pkg/a.go", path: "pkg/a.go"},
+		{in: "pkg/a.go:10-20", path: "pkg/a.go", start: 10, end: 20},
+		{in: "pkg/a.go:7", path: "pkg/a.go", start: 7, end: 7},
+		{in: `C:\repo\a.go:3-4`, path: `C:\repo\a.go`, start: 3, end: 4},
+		{in: "pkg/a.go:20-10", wantErr: true},
+		{in: "pkg/a.go:0", wantErr: true},
+		{in: ":1-2", wantErr: true},
+	}
+	for _, tt := range tests {
+		path, start, end, err := ParseBlameTarget(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBlameTarget(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (path != tt.path || start != tt.start || end != tt.end) {
+			t.Errorf("ParseBlameTarget(%q) = %q %d-%d, want %q %d-%d", tt.in, path, start, end, tt.path, tt.start, tt.end)
+		}
+	}
+}
+
+func TestHistoryReportBlame(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date.Format(time.RFC3339), "GIT_COMMITTER_DATE="+date.Format(time.RFC3339),
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(msg string, lines ...string) string {
+		if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-m", msg)
+		return git("rev-parse", "HEAD")
+	}
+
+	git("init")
+	first := commit("scaffold", "package main", "", "func main() {", "}")
+	second := commit("bv-1: print greeting", "package main", "", "func main() {", "\tprintln(\"hi\")", "\tprintln(\"bye\")", "}")
+
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {BeadID: "bv-1", Title: "Greeting", Status: "closed", Commits: []CorrelatedCommit{
+				{SHA: second, Confidence: 0.95, Method: MethodExplicitID},
+			}},
+			"bv-2": {BeadID: "bv-2", Title: "Farewell", Status: "open", Commits: []CorrelatedCommit{
+				{SHA: second, Confidence: 0.4, Method: MethodCoCommitted},
+			}},
+		},
+		CommitIndex: CommitIndex{second: {"bv-2", "bv-1"}},
+	}
+
+	blame, err := report.Blame(repo, BlameOptions{Path: "main.go", IncludeText: true})
+	if err != nil {
+		t.Fatalf("Blame: %v", err)
+	}
+	if len(blame.Ranges) != 3 {
+		t.Fatalf("ranges = %+v, want scaffold 1-3, bv-1 4-5, scaffold 6", blame.Ranges)
+	}
+	r := blame.Ranges[1]
+	if r.StartLine != 4 || r.EndLine != 5 || r.SHA != second || r.Summary != "bv-1: print greeting" || r.Author != "Test" {
+		t.Errorf("range = %+v", r)
+	}
+	if len(r.Beads) != 2 || r.Beads[0].BeadID != "bv-1" || r.Beads[0].Confidence != 0.95 || r.Beads[0].Title != "Greeting" {
+		t.Errorf("beads = %+v, want bv-1 (0.95) before bv-2", r.Beads)
+	}
+	if len(r.Text) != 2 || r.Text[0] != "\tprintln(\"hi\")" {
+		t.Errorf("text = %q", r.Text)
+	}
+	if blame.Ranges[0].SHA != first || len(blame.Ranges[0].Beads) != 0 || blame.Ranges[0].Lines() != 3 {
+		t.Errorf("first range = %+v", blame.Ranges[0])
+	}
+	if s := blame.Stats; s.TotalLines != 6 || s.AttributedLines != 2 || s.Commits != 2 || s.Beads != 2 {
+		t.Errorf("stats = %+v", s)
+	}
+	if len(blame.Beads) != 2 || blame.Beads[0].BeadID != "bv-1" || blame.Beads[0].Lines != 2 {
+		t.Errorf("bead summary = %+v", blame.Beads)
+	}
+
+	// A line range narrows the annotation; text is omitted unless asked for
+	blame, err = report.Blame(repo, BlameOptions{Path: "main.go", StartLine: 5, EndLine: 6})
+	if err != nil {
+		t.Fatalf("Blame range: %v", err)
+	}
+	if blame.StartLine != 5 || blame.EndLine != 6 || len(blame.Ranges) != 2 || blame.Ranges[0].Text != nil {
+		t.Errorf("ranged blame = %+v", blame)
+	}
+
+	// Working tree edits show up as uncommitted
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\n// wip\nfunc main() {\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blame, err = report.Blame(repo, BlameOptions{Path: "main.go", StartLine: 3, EndLine: 3})
+	if err != nil {
+		t.Fatalf("Blame working tree: %v", err)
+	}
+	if len(blame.Ranges) != 1 || !blame.Ranges[0].Uncommitted || len(blame.Ranges[0].Beads) != 0 {
+		t.Errorf("uncommitted range = %+v", blame.Ranges)
+	}
+
+	if _, err := report.Blame(repo, BlameOptions{Path: "missing.go"}); err == nil {
+		t.Error("blaming a missing file should fail")
+	}
+}
@@ -4,6 +4,8 @@ package correlation
 import (
 	"sort"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // CausalEventType categorizes events in the causal chain
@@ -124,10 +126,24 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 	var rawEvents []rawEvent
 
 	// Add lifecycle events
+	var blocked bool
 	for _, event := range history.Events {
 		var causalType CausalEventType
 		var desc string
 
+		// Entering or leaving a blocked-class status brackets a blocked period
+		if event.Status != "" {
+			nowBlocked := model.Status(event.Status).Class() == model.ClassBlocked
+			if nowBlocked != blocked {
+				raw := rawEvent{timestamp: event.Timestamp, eventType: CausalBlocked, description: "Bead blocked"}
+				if !nowBlocked {
+					raw.eventType, raw.description = CausalUnblocked, "Bead unblocked"
+				}
+				rawEvents = append(rawEvents, raw)
+				blocked = nowBlocked
+			}
+		}
+
 		switch event.EventType {
 		case EventCreated:
 			causalType = CausalCreated
@@ -170,8 +186,8 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 		}
 	}
 
-	// Sort by timestamp
-	sort.Slice(rawEvents, func(i, j int) bool {
+	// Sort by timestamp, keeping same-instant events in insertion order
+	sort.SliceStable(rawEvents, func(i, j int) bool {
 		return rawEvents[i].timestamp.Before(rawEvents[j].timestamp)
 	})
 
@@ -251,9 +267,8 @@ func buildInsights(chain *CausalChain, history BeadHistory) *CausalInsights {
 		}
 	}
 
-	// Find blocked periods by looking at status changes
-	// Note: This is a simplified approach - actual blocked state would need
-	// to be inferred from dependency resolution or explicit status
+	// Find blocked periods by looking at status changes into and out of
+	// blocked-class statuses
 	var inBlockedState bool
 	var blockedStart time.Time
 	var currentBlocker string
@@ -275,6 +290,17 @@ func buildInsights(chain *CausalChain, history BeadHistory) *CausalInsights {
 			inBlockedState = false
 		}
 	}
+	if inBlockedState && chain.EndTime.After(blockedStart) {
+		// Still blocked: the period runs to the end of the chain
+		period := BlockedPeriod{
+			StartTime: blockedStart,
+			EndTime:   chain.EndTime,
+			Duration:  chain.EndTime.Sub(blockedStart),
+			BlockerID: currentBlocker,
+		}
+		insights.BlockedPeriods = append(insights.BlockedPeriods, period)
+		insights.BlockedDuration += period.Duration
+	}
 
 	// Calculate active duration and blocked percentage
 	insights.ActiveDuration = insights.TotalDuration - insights.BlockedDuration
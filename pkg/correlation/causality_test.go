@@ -569,3 +569,70 @@ func endsWithEllipsis(s string) bool {
 	last3 := string(runes[len(runes)-3:])
 	return last3 == "..."
 }
+
+func TestBuildCausalityChain_BlockedStatusPeriods(t *testing.T) {
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-blk": {
+				BeadID: "bv-blk",
+				Status: "closed",
+				Events: []BeadEvent{
+					{EventType: EventCreated, Timestamp: testTime(0), Status: "open"},
+					{EventType: EventClaimed, Timestamp: testTime(2), Status: "in_progress"},
+					{EventType: EventModified, Timestamp: testTime(4), Status: "blocked"},
+					{EventType: EventClaimed, Timestamp: testTime(10), Status: "in_progress"},
+					{EventType: EventModified, Timestamp: testTime(11), Status: "blocked"},
+					{EventType: EventClosed, Timestamp: testTime(12), Status: "closed"},
+				},
+			},
+		},
+	}
+
+	result := report.BuildCausalityChain("bv-blk", CausalityOptions{})
+	var types []CausalEventType
+	for _, e := range result.Chain.Events {
+		types = append(types, e.Type)
+	}
+	want := []CausalEventType{CausalCreated, CausalClaimed, CausalBlocked, CausalUnblocked, CausalClaimed, CausalBlocked, CausalUnblocked, CausalClosed}
+	if len(types) != len(want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("events = %v, want %v", types, want)
+		}
+	}
+
+	periods := result.Insights.BlockedPeriods
+	if len(periods) != 2 || periods[0].Duration != 6*time.Hour || periods[1].Duration != time.Hour {
+		t.Errorf("blocked periods = %+v, want 6h and 1h", periods)
+	}
+	if result.Insights.BlockedDuration != 7*time.Hour {
+		t.Errorf("blocked duration = %v, want 7h", result.Insights.BlockedDuration)
+	}
+}
+
+func TestBuildCausalityChain_OpenBlockedPeriodRunsToEnd(t *testing.T) {
+	start := time.Now().Add(-48 * time.Hour)
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-stuck": {
+				BeadID: "bv-stuck",
+				Status: "blocked",
+				Events: []BeadEvent{
+					{EventType: EventCreated, Timestamp: start, Status: "open"},
+					{EventType: EventModified, Timestamp: start.Add(24 * time.Hour), Status: "blocked"},
+				},
+			},
+		},
+	}
+
+	result := report.BuildCausalityChain("bv-stuck", CausalityOptions{})
+	periods := result.Insights.BlockedPeriods
+	if len(periods) != 1 || !periods[0].EndTime.Equal(result.Chain.EndTime) {
+		t.Fatalf("blocked periods = %+v, want one ending at chain end", periods)
+	}
+	if periods[0].Duration < 24*time.Hour {
+		t.Errorf("open blocked period = %v, want at least 24h", periods[0].Duration)
+	}
+}
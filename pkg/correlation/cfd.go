@@ -0,0 +1,316 @@
+// Package correlation provides cumulative flow reconstruction from git history.
+package correlation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultCFDWindowDays is the window used when no start date is given
+const DefaultCFDWindowDays = 90
+
+// CFDOptions controls cumulative flow reconstruction
+type CFDOptions struct {
+	Since time.Time // First day of the window (zero = DefaultCFDWindowDays before Until)
+	Until time.Time // Last day of the window (zero = now)
+}
+
+// CFDDay holds the number of beads in each status at the end of one day
+type CFDDay struct {
+	Date    string         `json:"date"`     // YYYY-MM-DD (UTC)
+	Counts  map[string]int `json:"counts"`   // Status -> beads
+	ByClass map[string]int `json:"by_class"` // Status class -> beads
+	Total   int            `json:"total"`
+}
+
+// CumulativeFlow is the top-level output for --robot-cfd
+type CumulativeFlow struct {
+	GeneratedAt     time.Time `json:"generated_at"`
+	DataHash        string    `json:"data_hash"`
+	Since           string    `json:"since"`                     // First day (YYYY-MM-DD)
+	Until           string    `json:"until"`                     // Last day (YYYY-MM-DD)
+	Statuses        []string  `json:"statuses"`                  // Band order, bottom (done) to top (open)
+	Days            []CFDDay  `json:"days"`                      // One entry per day, oldest first
+	BaselineCommit  string    `json:"baseline_commit,omitempty"` // Beads file revision the replay starts from
+	CommitsReplayed int       `json:"commits_replayed"`
+}
+
+// cfdBandOrder stacks done work at the bottom and new work on top
+var cfdBandOrder = map[model.StatusClass]int{
+	model.ClassDone:    0,
+	model.ClassActive:  1,
+	model.ClassBlocked: 2,
+	model.ClassOpen:    3,
+}
+
+// beadsDelta is the net effect of one commit on the beads file
+type beadsDelta struct {
+	timestamp time.Time
+	removed   []string          // IDs whose line was removed and not re-added
+	upserted  map[string]string // ID -> status after the commit
+}
+
+// CumulativeFlow reconstructs daily status counts over a window. The beads
+// file is read once at the last commit before the window, then every later
+// commit's diff is replayed from a single streamed git log -p.
+func (e *Extractor) CumulativeFlow(opts CFDOptions) (*CumulativeFlow, error) {
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := opts.Since
+	if since.IsZero() {
+		since = until.AddDate(0, 0, -DefaultCFDWindowDays)
+	}
+	firstDay, lastDay := utcDay(since), utcDay(until)
+	if lastDay.Before(firstDay) {
+		return nil, fmt.Errorf("window ends (%s) before it starts (%s)", lastDay.Format("2006-01-02"), firstDay.Format("2006-01-02"))
+	}
+
+	result := &CumulativeFlow{
+		GeneratedAt: time.Now(),
+		Since:       firstDay.Format("2006-01-02"),
+		Until:       lastDay.Format("2006-01-02"),
+		Statuses:    []string{},
+		Days:        []CFDDay{},
+	}
+
+	state := make(map[string]string)
+	baseline, err := e.revisionBefore(firstDay)
+	if err != nil {
+		return nil, err
+	}
+	if baseline != "" {
+		result.BaselineCommit = baseline
+		if err := e.loadSnapshot(baseline, state); err != nil {
+			return nil, err
+		}
+	}
+
+	deltas, err := e.streamDeltas(firstDay, lastDay.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	result.CommitsReplayed = len(deltas)
+
+	seen := make(map[string]bool)
+	next := 0
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		for next < len(deltas) && deltas[next].timestamp.Before(end) {
+			deltas[next].apply(state)
+			next++
+		}
+		snapshot := CFDDay{
+			Date:    day.Format("2006-01-02"),
+			Counts:  make(map[string]int),
+			ByClass: make(map[string]int),
+		}
+		for _, status := range state {
+			if model.Status(status).IsTombstone() {
+				continue
+			}
+			snapshot.Counts[status]++
+			snapshot.ByClass[string(model.Status(status).Class())]++
+			snapshot.Total++
+			seen[status] = true
+		}
+		result.Days = append(result.Days, snapshot)
+	}
+
+	for status := range seen {
+		result.Statuses = append(result.Statuses, status)
+	}
+	sort.Slice(result.Statuses, func(i, j int) bool {
+		ri, rj := cfdBandRank(result.Statuses[i]), cfdBandRank(result.Statuses[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return result.Statuses[i] < result.Statuses[j]
+	})
+
+	return result, nil
+}
+
+// cfdBandRank orders statuses by class, unknown classes last
+func cfdBandRank(status string) int {
+	if rank, ok := cfdBandOrder[model.Status(status).Class()]; ok {
+		return rank
+	}
+	return len(cfdBandOrder)
+}
+
+// apply folds the commit's changes into the per-bead status map
+func (d beadsDelta) apply(state map[string]string) {
+	for _, id := range d.removed {
+		delete(state, id)
+	}
+	for id, status := range d.upserted {
+		state[id] = status
+	}
+}
+
+// revisionBefore returns the last commit touching the beads file before t,
+// or "" when the file has no history that old
+func (e *Extractor) revisionBefore(t time.Time) (string, error) {
+	cmd := exec.Command("git", "rev-list", "-1", "--before="+t.Format(time.RFC3339), "HEAD", "--", e.primaryBeadsFile())
+	cmd.Dir = e.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("finding baseline revision: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// loadSnapshot reads the beads file at a revision into state. A file missing
+// at that revision (e.g. renamed since) leaves state empty.
+func (e *Extractor) loadSnapshot(rev string, state map[string]string) error {
+	cmd := exec.Command("git", "show", rev+":"+e.primaryBeadsFile())
+	cmd.Dir = e.repoPath
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("creating stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting git show: %w", err)
+	}
+
+	reader := bufio.NewReaderSize(stdout, 64*1024)
+	for {
+		line, err := reader.ReadString('\n')
+		if snap, ok := parseBeadJSON(strings.TrimSpace(line)); ok {
+			state[snap.ID] = snapshotStatus(snap)
+		}
+		if err != nil {
+			if err != io.EOF {
+				_ = cmd.Process.Kill()
+				_ = cmd.Wait()
+				return fmt.Errorf("reading baseline: %w", err)
+			}
+			break
+		}
+	}
+	_ = cmd.Wait() // Path not present at this revision: start empty
+	return nil
+}
+
+// streamDeltas parses git log -p for the beads file into per-commit deltas,
+// oldest first
+func (e *Extractor) streamDeltas(since, until time.Time) ([]beadsDelta, error) {
+	args := []string{
+		"-c", "color.ui=false",
+		"log", "-p", "--follow",
+		"--format=" + gitLogHeaderFormat,
+		"--since=" + since.Format(time.RFC3339),
+		"--until=" + until.Format(time.RFC3339),
+		"--", e.primaryBeadsFile(),
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = e.repoPath
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating stdout pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting git log: %w", err)
+	}
+
+	deltas, parseErr := parseBeadsDeltas(stdout)
+	if parseErr != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("parsing git log output: %w", parseErr)
+	}
+	if err := cmd.Wait(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	// git log returns newest first
+	for i, j := 0, len(deltas)-1; i < j; i, j = i+1, j-1 {
+		deltas[i], deltas[j] = deltas[j], deltas[i]
+	}
+	return deltas, nil
+}
+
+// parseBeadsDeltas reads streamed git log -p output. Only the net change per
+// bead is kept, so memory stays proportional to beads touched per commit.
+func parseBeadsDeltas(r io.Reader) ([]beadsDelta, error) {
+	var deltas []beadsDelta
+	var current *beadsDelta
+	var removed map[string]bool
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		for id := range removed {
+			if _, readded := current.upserted[id]; !readded {
+				current.removed = append(current.removed, id)
+			}
+		}
+		sort.Strings(current.removed)
+		deltas = append(deltas, *current)
+	}
+
+	scanner := bufio.NewScanner(r)
+	buf := make([]byte, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if commitPattern.MatchString(line) {
+			finish()
+			info, err := parseCommitHeader(line)
+			if err != nil {
+				current = nil
+				continue
+			}
+			current = &beadsDelta{timestamp: info.Timestamp, upserted: make(map[string]string)}
+			removed = make(map[string]bool)
+			continue
+		}
+		if current == nil || len(line) < 2 || !strings.Contains(line, "{") {
+			continue
+		}
+		switch line[0] {
+		case '-':
+			if snap, ok := parseBeadJSON(strings.TrimSpace(line[1:])); ok {
+				removed[snap.ID] = true
+			}
+		case '+':
+			if snap, ok := parseBeadJSON(strings.TrimSpace(line[1:])); ok {
+				current.upserted[snap.ID] = snapshotStatus(snap)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+	return deltas, nil
+}
+
+// snapshotStatus normalizes the status and defaults a missing one to open,
+// as the loader does, so case variants count as one CFD series
+func snapshotStatus(snap beadSnapshot) string {
+	status := model.NormalizeStatus(model.Status(snap.Status))
+	if strings.TrimSpace(string(status)) == "" {
+		return string(model.StatusOpen)
+	}
+	return string(status)
+}
+
+// utcDay truncates t to midnight UTC
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
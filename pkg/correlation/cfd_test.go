@@ -0,0 +1,145 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBeadsDeltas(t *testing.T) {
+	header := func(sha, date string) string {
+		return strings.Join([]string{sha, date, "Ann", "ann@example.com", "update beads"}, "\x00")
+	}
+	// git log order: newest first
+	input := strings.Join([]string{
+		header("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "2025-03-02T09:00:00Z"),
+		"",
+		"diff --git a/.beads/beads.jsonl b/.beads/beads.jsonl",
+		"--- a/.beads/beads.jsonl",
+		"+++ b/.beads/beads.jsonl",
+		"@@ -1,2 +1 @@",
+		`-{"id":"a-1","status":"open"}`,
+		`+{"id":"a-1","status":"closed"}`,
+		`-{"id":"a-2","status":"open"}`,
+		header("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "2025-03-01T09:00:00Z"),
+		"",
+		"@@ -0,0 +1,2 @@",
+		`+{"id":"a-1","status":"open"}`,
+		`+{"id":"a-2"}`,
+	}, "\n")
+
+	deltas, err := parseBeadsDeltas(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseBeadsDeltas: %v", err)
+	}
+	if len(deltas) != 2 {
+		t.Fatalf("got %d deltas, want 2", len(deltas))
+	}
+	newest, oldest := deltas[0], deltas[1]
+	if !reflect.DeepEqual(oldest.upserted, map[string]string{"a-1": "open", "a-2": "open"}) || len(oldest.removed) != 0 {
+		t.Errorf("oldest delta = %+v", oldest)
+	}
+	if !reflect.DeepEqual(newest.upserted, map[string]string{"a-1": "closed"}) || !reflect.DeepEqual(newest.removed, []string{"a-2"}) {
+		t.Errorf("newest delta = %+v, want a-1 closed and a-2 removed", newest)
+	}
+
+	state := make(map[string]string)
+	oldest.apply(state)
+	newest.apply(state)
+	if !reflect.DeepEqual(state, map[string]string{"a-1": "closed"}) {
+		t.Errorf("replayed state = %v", state)
+	}
+}
+
+func TestParseBeadsDeltas_NormalizesStatus(t *testing.T) {
+	input := strings.Join([]string{
+		strings.Join([]string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "2025-03-01T09:00:00Z", "Ann", "ann@example.com", "import"}, "\x00"),
+		"",
+		"@@ -0,0 +1,3 @@",
+		`+{"id":"a-1","status":"Open"}`,
+		`+{"id":"a-2","status":" open "}`,
+		`+{"id":"a-3","status":"IN_PROGRESS"}`,
+	}, "\n")
+
+	deltas, err := parseBeadsDeltas(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseBeadsDeltas: %v", err)
+	}
+	if len(deltas) != 1 {
+		t.Fatalf("got %d deltas, want 1", len(deltas))
+	}
+	want := map[string]string{"a-1": "open", "a-2": "open", "a-3": "in_progress"}
+	if !reflect.DeepEqual(deltas[0].upserted, want) {
+		t.Errorf("upserted = %v, want %v (one series per status, as the loader sees it)", deltas[0].upserted, want)
+	}
+}
+
+func TestCumulativeFlow_ReplaysHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	day := func(n int) time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, n) }
+	git := func(date time.Time, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date.Format(time.RFC3339), "GIT_COMMITTER_DATE="+date.Format(time.RFC3339),
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(n int, lines ...string) {
+		path := filepath.Join(repo, ".beads", "beads.jsonl")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git(day(n), "add", ".")
+		git(day(n), "commit", "-m", "beads")
+	}
+
+	git(day(0), "init")
+	commit(0, `{"id":"c-1","status":"open"}`, `{"id":"c-2","status":"open"}`)
+	commit(2, `{"id":"c-1","status":"in_progress"}`, `{"id":"c-2","status":"open"}`, `{"id":"c-3","status":"open"}`)
+	commit(4, `{"id":"c-1","status":"closed"}`, `{"id":"c-2","status":"blocked"}`, `{"id":"c-3","status":"tombstone"}`)
+
+	flow, err := NewExtractor(repo).CumulativeFlow(CFDOptions{Since: day(1), Until: day(5)})
+	if err != nil {
+		t.Fatalf("CumulativeFlow: %v", err)
+	}
+	if flow.BaselineCommit == "" || flow.CommitsReplayed != 2 {
+		t.Errorf("baseline %q, replayed %d; want a baseline and 2 replayed commits", flow.BaselineCommit, flow.CommitsReplayed)
+	}
+	if flow.Since != "2025-03-02" || flow.Until != "2025-03-06" || len(flow.Days) != 5 {
+		t.Fatalf("window %s..%s with %d days", flow.Since, flow.Until, len(flow.Days))
+	}
+	if !reflect.DeepEqual(flow.Statuses, []string{"closed", "in_progress", "blocked", "open"}) {
+		t.Errorf("statuses = %v, want band order done→active→blocked→open", flow.Statuses)
+	}
+
+	want := []map[string]int{
+		{"open": 2},
+		{"in_progress": 1, "open": 2},
+		{"in_progress": 1, "open": 2},
+		{"closed": 1, "blocked": 1}, // tombstoned c-3 drops out
+		{"closed": 1, "blocked": 1},
+	}
+	for i, d := range flow.Days {
+		if !reflect.DeepEqual(d.Counts, want[i]) {
+			t.Errorf("%s counts = %v, want %v", d.Date, d.Counts, want[i])
+		}
+	}
+	if got := flow.Days[3].ByClass; got["done"] != 1 || got["blocked"] != 1 || flow.Days[3].Total != 2 {
+		t.Errorf("by_class = %v total %d", got, flow.Days[3].Total)
+	}
+}
@@ -62,6 +62,14 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 	// Build bead histories
 	histories := c.buildHistories(beads, events, commits)
 
+	// Split commits shared by several beads using the file footprint each
+	// bead built up in its other commits
+	if hasSharedCommits(commits) {
+		index := BuildFileIndex(&HistoryReport{Histories: histories})
+		commits = NewHunkAttributor(c.repoPath, index, beads).AttributeShared(commits)
+		histories = c.buildHistories(beads, events, commits)
+	}
+
 	// Apply bead filter if specified
 	if opts.BeadID != "" {
 		filtered := make(map[string]BeadHistory)
@@ -126,6 +134,11 @@ type BeadInfo struct {
 	ID     string
 	Title  string
 	Status string
+
+	// Description and AcceptanceCriteria are optional; hunk attribution
+	// matches them against changed paths and symbols.
+	Description        string
+	AcceptanceCriteria string
 }
 
 // buildHistories constructs BeadHistory for each bead
@@ -181,6 +194,21 @@ func (c *Correlator) buildHistories(beads []BeadInfo, events []BeadEvent, commit
 	return histories
 }
 
+// hasSharedCommits reports whether any commit is linked to more than one bead
+func hasSharedCommits(commits []CorrelatedCommit) bool {
+	beadBySHA := make(map[string]string)
+	for _, c := range commits {
+		if c.BeadID == "" {
+			continue
+		}
+		if prev, ok := beadBySHA[c.SHA]; ok && prev != c.BeadID {
+			return true
+		}
+		beadBySHA[c.SHA] = c.BeadID
+	}
+	return false
+}
+
 // dedupCommits removes duplicate commits by SHA
 func dedupCommits(commits []CorrelatedCommit) []CorrelatedCommit {
 	seen := make(map[string]bool)
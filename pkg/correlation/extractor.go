@@ -12,6 +12,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // ExtractOptions controls which commits and beads to extract events from
@@ -358,6 +360,7 @@ func (e *Extractor) parseDiff(diffData []byte, info commitInfo, filterBeadID str
 			CommitMsg:   info.Message,
 			Author:      info.Author,
 			AuthorEmail: info.AuthorEmail,
+			Status:      newSnap.Status,
 		}
 
 		if !hadOld && hasNew {
@@ -404,15 +407,21 @@ func parseBeadJSON(jsonStr string) (beadSnapshot, bool) {
 	}, true
 }
 
-// determineStatusEvent determines the appropriate event type for a status transition
+// determineStatusEvent determines the appropriate event type for a status transition.
+// Statuses are compared by workflow class, so custom statuses from
+// .bv/workflow.yaml map onto the same lifecycle events as the built-in ones.
 func determineStatusEvent(oldStatus, newStatus string) EventType {
-	switch newStatus {
-	case "in_progress":
+	oldClass := model.Status(oldStatus).Class()
+	switch model.Status(newStatus).Class() {
+	case model.ClassActive:
+		if oldClass == model.ClassActive {
+			return EventModified
+		}
 		return EventClaimed
-	case "closed":
+	case model.ClassDone:
 		return EventClosed
-	case "open":
-		if oldStatus == "closed" {
+	case model.ClassOpen:
+		if oldClass.IsResolved() {
 			return EventReopened
 		}
 		return EventModified
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 func TestParseGitLogOutput(t *testing.T) {
@@ -178,6 +180,33 @@ func TestDetermineStatusEvent(t *testing.T) {
 	}
 }
 
+func TestDetermineStatusEvent_WorkflowClasses(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{Classes: map[model.Status]model.StatusClass{
+		"qa":      model.ClassActive,
+		"staged":  model.ClassDone,
+		"wontfix": model.ClassTerminal,
+	}})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	tests := []struct {
+		oldStatus string
+		newStatus string
+		want      EventType
+	}{
+		{"open", "qa", EventClaimed},
+		{"in_progress", "qa", EventModified},
+		{"qa", "staged", EventClosed},
+		{"staged", "open", EventReopened},
+		{"wontfix", "open", EventReopened},
+		{"open", "wontfix", EventModified},
+	}
+	for _, tt := range tests {
+		if got := determineStatusEvent(tt.oldStatus, tt.newStatus); got != tt.want {
+			t.Errorf("determineStatusEvent(%s, %s) = %v, want %v", tt.oldStatus, tt.newStatus, got, tt.want)
+		}
+	}
+}
+
 func TestReverseEvents(t *testing.T) {
 	events := []BeadEvent{
 		{BeadID: "a", EventType: EventCreated},
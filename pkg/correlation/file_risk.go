@@ -0,0 +1,502 @@
+// Package correlation provides per-file risk scoring from bead history.
+package correlation
+
+import (
+	"math"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FileRiskWeights sets how much each signal contributes to a file's risk
+// score. Weights are normalized, so only their ratios matter.
+type FileRiskWeights struct {
+	BugDensity float64 `json:"bug_density"` // Share of the file's beads that are bugs
+	Reopens    float64 `json:"reopens"`     // Reopen events on beads touching the file
+	Churn      float64 `json:"churn"`       // Lines inserted + deleted
+	Coupling   float64 `json:"coupling"`    // Files that usually change with this one
+}
+
+// FileRiskOptions controls file risk scoring
+type FileRiskOptions struct {
+	Weights FileRiskWeights
+	// CouplingThreshold is the minimum co-change correlation for another
+	// file to count as coupled (default 0.5, as in --robot-file-relations)
+	CouplingThreshold float64
+	// Limit caps the files and directories returned (0 = all)
+	Limit int
+	// Now is the reference time (zero = time.Now())
+	Now time.Time
+}
+
+// DefaultFileRiskOptions returns the default weights and coupling threshold
+func DefaultFileRiskOptions() FileRiskOptions {
+	return FileRiskOptions{
+		Weights: FileRiskWeights{
+			BugDensity: 0.35,
+			Reopens:    0.25,
+			Churn:      0.2,
+			Coupling:   0.2,
+		},
+		CouplingThreshold: 0.5,
+	}
+}
+
+// minCoChanges is how many shared commits a coupled pair needs, so a file
+// with a single commit isn't coupled to everything in it
+const minCoChanges = 2
+
+// FileRiskComponents are the normalized (0-1) inputs to a risk score. Each
+// is scaled against the riskiest file in the report.
+type FileRiskComponents struct {
+	BugDensity float64 `json:"bug_density"`
+	Reopens    float64 `json:"reopens"`
+	Churn      float64 `json:"churn"`
+	Coupling   float64 `json:"coupling"`
+}
+
+// FileRisk is the risk assessment for one file
+type FileRisk struct {
+	FilePath     string             `json:"file_path"`
+	RiskScore    float64            `json:"risk_score"` // 0.0 - 1.0
+	RiskLevel    string             `json:"risk_level"` // low, medium, high, critical
+	Components   FileRiskComponents `json:"components"`
+	TotalBeads   int                `json:"total_beads"`
+	OpenBeads    int                `json:"open_beads"`
+	BugBeads     int                `json:"bug_beads"`
+	BugDensity   float64            `json:"bug_density"` // bug_beads / total_beads
+	Reopens      int                `json:"reopens"`
+	Commits      int                `json:"commits"`
+	Insertions   int                `json:"insertions"`
+	Deletions    int                `json:"deletions"`
+	CoupledFiles []string           `json:"coupled_files"` // Strongest first
+	LastTouch    time.Time          `json:"last_touch"`
+	BeadIDs      []string           `json:"bead_ids"` // Most recently touched first
+}
+
+// Churn is the total number of lines inserted and deleted
+func (f FileRisk) Churn() int {
+	return f.Insertions + f.Deletions
+}
+
+// DirectoryRisk rolls file risk up to a directory. The score is the
+// riskiest file's, so one hotspot isn't diluted by many quiet files.
+type DirectoryRisk struct {
+	Path      string  `json:"path"`
+	RiskScore float64 `json:"risk_score"`
+	RiskLevel string  `json:"risk_level"`
+	Files     int     `json:"files"`
+	Churn     int     `json:"churn"`
+	BugBeads  int     `json:"bug_beads"` // Distinct bug beads across the directory
+	Reopens   int     `json:"reopens"`   // Reopens of distinct beads across the directory
+	Hotspot   string  `json:"hotspot"`   // Riskiest file
+}
+
+// FileRiskStats summarizes a file risk report
+type FileRiskStats struct {
+	TotalFiles    int `json:"total_files"`
+	CriticalFiles int `json:"critical_files"`
+	HighRiskFiles int `json:"high_risk_files"`
+	BugBeads      int `json:"bug_beads"`
+	Reopens       int `json:"reopens"`
+}
+
+// FileRiskReport is the top-level output for --robot-file-risk
+type FileRiskReport struct {
+	GeneratedAt       time.Time       `json:"generated_at"`
+	DataHash          string          `json:"data_hash"`
+	Weights           FileRiskWeights `json:"weights"`
+	CouplingThreshold float64         `json:"coupling_threshold"`
+	Stats             FileRiskStats   `json:"stats"`
+	Files             []FileRisk      `json:"files"`       // Riskiest first
+	Directories       []DirectoryRisk `json:"directories"` // Riskiest first
+}
+
+// BuildFileRisk scores every file touched by a correlated commit. Bug types
+// and current statuses come from issues; beads missing from issues fall back
+// to the status recorded in the history.
+func (hr *HistoryReport) BuildFileRisk(issues []model.Issue, opts FileRiskOptions) *FileRiskReport {
+	defaults := DefaultFileRiskOptions()
+	if opts.Weights == (FileRiskWeights{}) {
+		opts.Weights = defaults.Weights
+	}
+	if opts.CouplingThreshold <= 0 {
+		opts.CouplingThreshold = defaults.CouplingThreshold
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	report := &FileRiskReport{
+		GeneratedAt:       opts.Now.UTC(),
+		Weights:           opts.Weights,
+		CouplingThreshold: opts.CouplingThreshold,
+		Files:             []FileRisk{},
+		Directories:       []DirectoryRisk{},
+	}
+	if hr == nil {
+		return report
+	}
+	report.DataHash = hr.DataHash
+
+	issueByID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueByID[issues[i].ID] = &issues[i]
+	}
+	isBug := func(beadID string) bool {
+		issue, ok := issueByID[beadID]
+		return ok && issue.IssueType == model.TypeBug
+	}
+	statusOf := func(beadID string) string {
+		if issue, ok := issueByID[beadID]; ok {
+			return string(issue.Status)
+		}
+		return hr.Histories[beadID].Status
+	}
+	reopens := make(map[string]int, len(hr.Histories))
+	for beadID, history := range hr.Histories {
+		for _, e := range history.Events {
+			if e.EventType == EventReopened {
+				reopens[beadID]++
+			}
+		}
+	}
+
+	files := make(map[string]*FileRisk)
+	for filePath, refs := range BuildFileIndex(hr).FileToBeads {
+		f := &FileRisk{FilePath: filePath, CoupledFiles: []string{}}
+		for _, ref := range refs {
+			bucket, skip := classifyBeadStatus(statusOf(ref.BeadID))
+			if skip {
+				continue
+			}
+			f.TotalBeads++
+			if bucket == "open" {
+				f.OpenBeads++
+			}
+			if isBug(ref.BeadID) {
+				f.BugBeads++
+			}
+			f.Reopens += reopens[ref.BeadID]
+			f.BeadIDs = append(f.BeadIDs, ref.BeadID)
+			if ref.LastTouch.After(f.LastTouch) {
+				f.LastTouch = ref.LastTouch
+			}
+		}
+		if f.TotalBeads == 0 {
+			continue
+		}
+		f.BugDensity = float64(f.BugBeads) / float64(f.TotalBeads)
+		files[filePath] = f
+	}
+
+	// Churn per unique commit; a commit shared by several beads counts once
+	seen := make(map[string]bool)
+	for _, history := range hr.Histories {
+		for _, commit := range history.Commits {
+			if seen[commit.SHA] {
+				continue
+			}
+			seen[commit.SHA] = true
+			for _, fc := range commit.Files {
+				if f := files[normalizePath(fc.Path)]; f != nil {
+					f.Commits++
+					f.Insertions += fc.Insertions
+					f.Deletions += fc.Deletions
+				}
+			}
+		}
+	}
+
+	coChange := BuildCoChangeMatrix(hr)
+	for filePath, f := range files {
+		total := coChange.FileCommitCounts[filePath]
+		if total == 0 {
+			continue
+		}
+		type coupled struct {
+			path  string
+			count int
+		}
+		var partners []coupled
+		for other, count := range coChange.Matrix[filePath] {
+			if count >= minCoChanges && float64(count)/float64(total) >= opts.CouplingThreshold {
+				partners = append(partners, coupled{other, count})
+			}
+		}
+		sort.Slice(partners, func(i, j int) bool {
+			if partners[i].count != partners[j].count {
+				return partners[i].count > partners[j].count
+			}
+			return partners[i].path < partners[j].path
+		})
+		for _, p := range partners {
+			f.CoupledFiles = append(f.CoupledFiles, p.path)
+		}
+	}
+
+	// Normalize each signal against the maximum across files. Bug density is
+	// smoothed so a file with a single bug bead doesn't max it out, and churn
+	// is log-scaled so one generated file doesn't flatten everything else.
+	smoothedDensity := func(f *FileRisk) float64 {
+		return float64(f.BugBeads) / float64(f.TotalBeads+1)
+	}
+	logChurn := func(f *FileRisk) float64 {
+		return math.Log1p(float64(f.Churn()))
+	}
+	var maxDensity, maxReopens, maxChurn, maxCoupling float64
+	for _, f := range files {
+		maxDensity = math.Max(maxDensity, smoothedDensity(f))
+		maxReopens = math.Max(maxReopens, float64(f.Reopens))
+		maxChurn = math.Max(maxChurn, logChurn(f))
+		maxCoupling = math.Max(maxCoupling, float64(len(f.CoupledFiles)))
+	}
+	scale := func(v, maxV float64) float64 {
+		if maxV == 0 {
+			return 0
+		}
+		return roundRisk(v / maxV)
+	}
+	w := opts.Weights
+	weightSum := w.BugDensity + w.Reopens + w.Churn + w.Coupling
+	for _, f := range files {
+		f.Components = FileRiskComponents{
+			BugDensity: scale(smoothedDensity(f), maxDensity),
+			Reopens:    scale(float64(f.Reopens), maxReopens),
+			Churn:      scale(logChurn(f), maxChurn),
+			Coupling:   scale(float64(len(f.CoupledFiles)), maxCoupling),
+		}
+		if weightSum > 0 {
+			c := f.Components
+			f.RiskScore = roundRisk((c.BugDensity*w.BugDensity + c.Reopens*w.Reopens +
+				c.Churn*w.Churn + c.Coupling*w.Coupling) / weightSum)
+		}
+		f.RiskLevel = RiskLevel(f.RiskScore)
+		f.BugDensity = roundRisk(f.BugDensity)
+
+		report.Files = append(report.Files, *f)
+		switch f.RiskLevel {
+		case "critical":
+			report.Stats.CriticalFiles++
+			report.Stats.HighRiskFiles++
+		case "high":
+			report.Stats.HighRiskFiles++
+		}
+	}
+	sortFileRisks(report.Files)
+
+	report.Directories = rollUpDirectoryRisk(report.Files, isBug, reopens)
+	report.Stats.TotalFiles = len(report.Files)
+	countedBeads := make(map[string]bool)
+	for _, f := range report.Files {
+		for _, id := range f.BeadIDs {
+			if countedBeads[id] {
+				continue
+			}
+			countedBeads[id] = true
+			if isBug(id) {
+				report.Stats.BugBeads++
+			}
+			report.Stats.Reopens += reopens[id]
+		}
+	}
+
+	if opts.Limit > 0 {
+		if len(report.Files) > opts.Limit {
+			report.Files = report.Files[:opts.Limit]
+		}
+		if len(report.Directories) > opts.Limit {
+			report.Directories = report.Directories[:opts.Limit]
+		}
+	}
+	return report
+}
+
+// RiskLevel buckets a 0-1 risk score with the same cut-offs as ImpactAnalysis
+func RiskLevel(score float64) string {
+	switch {
+	case score >= 0.7:
+		return "critical"
+	case score >= 0.4:
+		return "high"
+	case score >= 0.2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// rollUpDirectoryRisk aggregates files into every ancestor directory below
+// the repo root. Bug and reopen counts are per distinct bead, so a bead
+// touching several files in a directory counts once.
+func rollUpDirectoryRisk(files []FileRisk, isBug func(string) bool, reopens map[string]int) []DirectoryRisk {
+	type dirAgg struct {
+		DirectoryRisk
+		beads map[string]bool
+	}
+	dirs := make(map[string]*dirAgg)
+	for _, f := range files {
+		for dir := path.Dir(f.FilePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			d := dirs[dir]
+			if d == nil {
+				d = &dirAgg{DirectoryRisk: DirectoryRisk{Path: dir}, beads: make(map[string]bool)}
+				dirs[dir] = d
+			}
+			d.Files++
+			d.Churn += f.Churn()
+			if f.RiskScore > d.RiskScore || d.Hotspot == "" {
+				d.RiskScore = f.RiskScore
+				d.Hotspot = f.FilePath
+			}
+			for _, id := range f.BeadIDs {
+				if d.beads[id] {
+					continue
+				}
+				d.beads[id] = true
+				if isBug(id) {
+					d.BugBeads++
+				}
+				d.Reopens += reopens[id]
+			}
+		}
+	}
+
+	out := make([]DirectoryRisk, 0, len(dirs))
+	for _, d := range dirs {
+		d.RiskLevel = RiskLevel(d.RiskScore)
+		out = append(out, d.DirectoryRisk)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].RiskScore != out[j].RiskScore {
+			return out[i].RiskScore > out[j].RiskScore
+		}
+		if out[i].Churn != out[j].Churn {
+			return out[i].Churn > out[j].Churn
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
+
+func sortFileRisks(files []FileRisk) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].RiskScore != files[j].RiskScore {
+			return files[i].RiskScore > files[j].RiskScore
+		}
+		if files[i].Churn() != files[j].Churn() {
+			return files[i].Churn() > files[j].Churn()
+		}
+		return files[i].FilePath < files[j].FilePath
+	})
+}
+
+func roundRisk(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+// FileRiskNode is a directory or file in the risk tree. Directories carry
+// the maximum risk and total churn of everything below them.
+type FileRiskNode struct {
+	Name      string          `json:"name"`
+	Path      string          `json:"path"`
+	RiskScore float64         `json:"risk_score"`
+	Churn     int             `json:"churn"`
+	Files     int             `json:"files"`
+	File      *FileRisk       `json:"file,omitempty"` // nil for directories
+	Children  []*FileRiskNode `json:"children,omitempty"`
+}
+
+// IsDir reports whether the node is a directory
+func (n *FileRiskNode) IsDir() bool {
+	return n.File == nil
+}
+
+// BuildFileRiskTree arranges files into a directory tree rooted at the repo
+// root. Directories with a single child directory are merged ("pkg/ui"
+// rather than "pkg" → "ui"), and children are sorted riskiest first.
+func BuildFileRiskTree(files []FileRisk) *FileRiskNode {
+	root := &FileRiskNode{Name: ".", Path: "."}
+	for i := range files {
+		f := &files[i]
+		node := root
+		dir := path.Dir(f.FilePath)
+		if dir != "." {
+			for _, part := range splitPath(dir) {
+				node = node.child(part)
+			}
+		}
+		node.Children = append(node.Children, &FileRiskNode{
+			Name:      path.Base(f.FilePath),
+			Path:      f.FilePath,
+			RiskScore: f.RiskScore,
+			Churn:     f.Churn(),
+			Files:     1,
+			File:      f,
+		})
+	}
+	root.finish()
+	for i, c := range root.Children {
+		root.Children[i] = c.compact()
+	}
+	return root
+}
+
+func splitPath(p string) []string {
+	var parts []string
+	for p != "." && p != "/" && p != "" {
+		parts = append([]string{path.Base(p)}, parts...)
+		p = path.Dir(p)
+	}
+	return parts
+}
+
+func (n *FileRiskNode) child(name string) *FileRiskNode {
+	for _, c := range n.Children {
+		if c.IsDir() && c.Name == name {
+			return c
+		}
+	}
+	p := name
+	if n.Path != "." {
+		p = n.Path + "/" + name
+	}
+	c := &FileRiskNode{Name: name, Path: p}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// finish fills in directory aggregates and sorts children
+func (n *FileRiskNode) finish() {
+	if !n.IsDir() {
+		return
+	}
+	n.RiskScore, n.Churn, n.Files = 0, 0, 0
+	for _, c := range n.Children {
+		c.finish()
+		n.RiskScore = math.Max(n.RiskScore, c.RiskScore)
+		n.Churn += c.Churn
+		n.Files += c.Files
+	}
+	sort.Slice(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if a.RiskScore != b.RiskScore {
+			return a.RiskScore > b.RiskScore
+		}
+		return a.Name < b.Name
+	})
+}
+
+// compact merges chains of single-directory children
+func (n *FileRiskNode) compact() *FileRiskNode {
+	for n.IsDir() && len(n.Children) == 1 && n.Children[0].IsDir() {
+		c := n.Children[0]
+		c.Name = n.Name + "/" + c.Name
+		n = c
+	}
+	for i, c := range n.Children {
+		n.Children[i] = c.compact()
+	}
+	return n
+}
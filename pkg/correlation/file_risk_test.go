@@ -0,0 +1,143 @@
+package correlation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// fileRiskFixture: the auth files carry both bugs and their reopens and
+// always change together; the ui file only sees tasks. Commit c5 is
+// correlated with two beads but its churn must count once.
+func fileRiskFixture(now time.Time) (*HistoryReport, []model.Issue) {
+	commit := func(sha string, ago int, files ...FileChange) CorrelatedCommit {
+		return CorrelatedCommit{SHA: sha, ShortSHA: sha, Timestamp: now.Add(-time.Duration(ago) * time.Hour), Files: files}
+	}
+	change := func(path string, ins, del int) FileChange {
+		return FileChange{Path: path, Action: "M", Insertions: ins, Deletions: del}
+	}
+	reopened := []BeadEvent{{EventType: EventReopened, Timestamp: now.Add(-time.Hour), Status: "open"}}
+	c5 := commit("c5", 1, change("pkg/auth/token.go", 5, 5))
+
+	report := &HistoryReport{DataHash: "risk-hash", Histories: map[string]BeadHistory{
+		"b-1": {BeadID: "b-1", Status: "open", Events: reopened, Commits: []CorrelatedCommit{
+			commit("c1", 10, change("pkg/auth/token.go", 100, 20), change("pkg/auth/session.go", 10, 0)),
+			commit("c2", 9, change("./pkg/auth/token.go", 30, 10), change("pkg/auth/session.go", 5, 5)),
+		}},
+		"b-2": {BeadID: "b-2", Status: "closed", Events: reopened, Commits: []CorrelatedCommit{c5}},
+		"t-1": {BeadID: "t-1", Status: "closed", Commits: []CorrelatedCommit{
+			c5,
+			commit("c3", 8, change("pkg/ui/view.go", 10, 2)),
+		}},
+		"t-2": {BeadID: "t-2", Status: "open", Commits: []CorrelatedCommit{
+			commit("c4", 7, change("pkg/ui/view.go", 3, 1), change("README.md", 1, 1)),
+		}},
+		"gone": {BeadID: "gone", Status: "tombstone", Commits: []CorrelatedCommit{
+			commit("c6", 6, change("pkg/old.go", 50, 50)),
+		}},
+	}}
+	issues := []model.Issue{
+		{ID: "b-1", Status: model.StatusOpen, IssueType: model.TypeBug},
+		{ID: "b-2", Status: model.StatusClosed, IssueType: model.TypeBug},
+		{ID: "t-1", Status: model.StatusClosed, IssueType: model.TypeTask},
+		{ID: "t-2", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	return report, issues
+}
+
+func TestBuildFileRisk_ScoresAndOrder(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	report, issues := fileRiskFixture(now)
+	got := report.BuildFileRisk(issues, FileRiskOptions{Now: now})
+
+	var order []string
+	for _, f := range got.Files {
+		order = append(order, f.FilePath)
+	}
+	want := []string{"pkg/auth/token.go", "pkg/auth/session.go", "pkg/ui/view.go", "README.md"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v (tombstoned pkg/old.go dropped)", order, want)
+	}
+
+	token := got.Files[0]
+	if token.TotalBeads != 3 || token.BugBeads != 2 || token.Reopens != 2 || token.Commits != 3 || token.Churn() != 170 {
+		t.Errorf("token.go = %+v", token)
+	}
+	if token.RiskScore != 1 || token.RiskLevel != "critical" || !reflect.DeepEqual(token.CoupledFiles, []string{"pkg/auth/session.go"}) {
+		t.Errorf("token.go risk %.3f %s coupled %v", token.RiskScore, token.RiskLevel, token.CoupledFiles)
+	}
+
+	// Same bug density and coupling as token.go, half the reopens, less churn
+	session := got.Files[1]
+	if session.Components.BugDensity != 1 || session.Components.Reopens != 0.5 || session.Components.Coupling != 1 {
+		t.Errorf("session.go components = %+v", session.Components)
+	}
+	if session.RiskScore != 0.793 {
+		t.Errorf("session.go risk = %.3f, want 0.793", session.RiskScore)
+	}
+
+	// A single shared commit is not coupling
+	if view := got.Files[2]; len(view.CoupledFiles) != 0 || view.RiskLevel != "low" || view.OpenBeads != 1 {
+		t.Errorf("view.go = %+v", view)
+	}
+
+	if got.Stats != (FileRiskStats{TotalFiles: 4, CriticalFiles: 2, HighRiskFiles: 2, BugBeads: 2, Reopens: 2}) {
+		t.Errorf("stats = %+v", got.Stats)
+	}
+	if got.DataHash != "risk-hash" {
+		t.Errorf("data hash = %q", got.DataHash)
+	}
+}
+
+func TestBuildFileRisk_DirectoriesAndLimit(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	report, issues := fileRiskFixture(now)
+	got := report.BuildFileRisk(issues, FileRiskOptions{Now: now, Limit: 2})
+
+	if len(got.Files) != 2 || got.Stats.TotalFiles != 4 {
+		t.Errorf("limit: %d files, stats %d", len(got.Files), got.Stats.TotalFiles)
+	}
+	if len(got.Directories) != 2 {
+		t.Fatalf("directories = %+v", got.Directories)
+	}
+	// pkg outranks pkg/auth on churn at equal risk; bug beads count once
+	pkg, auth := got.Directories[0], got.Directories[1]
+	if pkg.Path != "pkg" || pkg.Files != 3 || pkg.BugBeads != 2 || pkg.Hotspot != "pkg/auth/token.go" {
+		t.Errorf("pkg = %+v", pkg)
+	}
+	if auth.Path != "pkg/auth" || auth.Churn != 190 || auth.Reopens != 2 {
+		t.Errorf("pkg/auth = %+v", auth)
+	}
+
+	if empty := (*HistoryReport)(nil).BuildFileRisk(nil, FileRiskOptions{}); len(empty.Files) != 0 || empty.Weights != DefaultFileRiskOptions().Weights {
+		t.Errorf("nil report = %+v", empty)
+	}
+}
+
+func TestBuildFileRiskTree(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	report, issues := fileRiskFixture(now)
+	root := BuildFileRiskTree(report.BuildFileRisk(issues, FileRiskOptions{Now: now}).Files)
+
+	if root.Files != 4 || root.Churn != 208 || root.RiskScore != 1 || len(root.Children) != 2 {
+		t.Fatalf("root = %+v", root)
+	}
+	pkg := root.Children[0]
+	if pkg.Name != "pkg" || !pkg.IsDir() || len(pkg.Children) != 2 {
+		t.Fatalf("first child = %+v, want pkg", pkg)
+	}
+	if pkg.Children[0].Path != "pkg/auth" || pkg.Children[0].Children[0].File.FilePath != "pkg/auth/token.go" {
+		t.Errorf("pkg/auth should come first with token.go on top: %+v", pkg.Children[0])
+	}
+	if readme := root.Children[1]; readme.IsDir() || readme.Name != "README.md" {
+		t.Errorf("second child = %+v", readme)
+	}
+
+	// A lone directory chain is merged into one node
+	chain := BuildFileRiskTree([]FileRisk{{FilePath: "cmd/bv/main.go", RiskScore: 0.5}})
+	if len(chain.Children) != 1 || chain.Children[0].Name != "cmd/bv" || chain.Children[0].Path != "cmd/bv" {
+		t.Errorf("chain = %+v", chain.Children[0])
+	}
+}
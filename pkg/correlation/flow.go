@@ -0,0 +1,378 @@
+// Package correlation provides aggregate flow metrics for beads.
+package correlation
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Flow metric dimensions
+const (
+	FlowDimensionOverall  = "overall"
+	FlowDimensionLabel    = "label"
+	FlowDimensionType     = "type"
+	FlowDimensionAssignee = "assignee"
+)
+
+// FlowUnassigned is the assignee key for beads without an assignee
+const FlowUnassigned = "(unassigned)"
+
+// FlowPercentiles summarizes a duration distribution in days
+type FlowPercentiles struct {
+	Count    int     `json:"count"`
+	P50Days  float64 `json:"p50_days"`
+	P85Days  float64 `json:"p85_days"`
+	P95Days  float64 `json:"p95_days"`
+	MeanDays float64 `json:"mean_days"`
+}
+
+// WeeklyThroughput is the number of beads closed in one week
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"week_start"` // Monday 00:00 UTC
+	Closed    int       `json:"closed"`
+}
+
+// FlowGroupMetrics holds flow metrics for one label, type or assignee
+type FlowGroupMetrics struct {
+	Dimension         string             `json:"dimension"`
+	Key               string             `json:"key"`
+	Completed         int                `json:"completed"`
+	InProgress        int                `json:"in_progress"`
+	LeadTime          FlowPercentiles    `json:"lead_time"`  // created -> closed
+	CycleTime         FlowPercentiles    `json:"cycle_time"` // claimed -> closed
+	Throughput        []WeeklyThroughput `json:"throughput"`
+	ThroughputPerWeek float64            `json:"throughput_per_week"`
+	ActiveDays        float64            `json:"active_days"`               // Claimed time not blocked
+	BlockedDays       float64            `json:"blocked_days"`              // Claimed time spent blocked
+	FlowEfficiency    *float64           `json:"flow_efficiency,omitempty"` // active / (active + blocked), nil without claimed work
+}
+
+// FlowSample is one completed bead plotted in the flow charts
+type FlowSample struct {
+	BeadID    string    `json:"bead_id"`
+	ClosedAt  time.Time `json:"closed_at"`
+	LeadDays  float64   `json:"lead_days"`
+	CycleDays *float64  `json:"cycle_days,omitempty"` // nil if never claimed
+}
+
+// AgingWIPItem is an in-progress bead older than the P85 cycle time
+type AgingWIPItem struct {
+	BeadID   string    `json:"bead_id"`
+	Title    string    `json:"title"`
+	Status   string    `json:"status"`
+	Assignee string    `json:"assignee,omitempty"`
+	Since    string    `json:"since"` // "claimed" or "created" when no claim was recorded
+	StartAt  time.Time `json:"start_at"`
+	AgeDays  float64   `json:"age_days"`
+}
+
+// FlowMetrics is the top-level output for --robot-flow-metrics
+type FlowMetrics struct {
+	GeneratedAt        time.Time          `json:"generated_at"`
+	DataHash           string             `json:"data_hash"`
+	Weeks              int                `json:"weeks"`
+	Overall            FlowGroupMetrics   `json:"overall"`
+	ByLabel            []FlowGroupMetrics `json:"by_label"`
+	ByType             []FlowGroupMetrics `json:"by_type"`
+	ByAssignee         []FlowGroupMetrics `json:"by_assignee"`
+	Samples            []FlowSample       `json:"samples"` // Completed beads, oldest close first
+	AgingThresholdDays float64            `json:"aging_threshold_days"`
+	AgingWIP           []AgingWIPItem     `json:"aging_wip"`
+}
+
+// FlowOptions configures flow metric aggregation
+type FlowOptions struct {
+	Now   time.Time // Reference time for open work and throughput (default time.Now())
+	Weeks int       // Throughput window in weeks (default 12)
+}
+
+// DefaultFlowOptions returns sensible defaults
+func DefaultFlowOptions() FlowOptions {
+	return FlowOptions{
+		Now:   time.Now(),
+		Weeks: 12,
+	}
+}
+
+// beadFlow is the per-bead input to group aggregation
+type beadFlow struct {
+	closedAt   *time.Time
+	lead       *time.Duration
+	cycle      *time.Duration
+	active     time.Duration
+	blocked    time.Duration
+	inProgress bool
+}
+
+// BuildFlowMetrics aggregates lead time, cycle time, throughput and flow
+// efficiency across issues, grouped by label, type and assignee.
+func (hr *HistoryReport) BuildFlowMetrics(issues []model.Issue, opts FlowOptions) *FlowMetrics {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultFlowOptions().Weeks
+	}
+
+	result := &FlowMetrics{
+		GeneratedAt: opts.Now,
+		DataHash:    hr.DataHash,
+		Weeks:       opts.Weeks,
+		ByLabel:     []FlowGroupMetrics{},
+		ByType:      []FlowGroupMetrics{},
+		ByAssignee:  []FlowGroupMetrics{},
+		Samples:     []FlowSample{},
+		AgingWIP:    []AgingWIPItem{},
+	}
+
+	firstWeek := weekStart(opts.Now).AddDate(0, 0, -7*(opts.Weeks-1))
+	flows := make([]beadFlow, len(issues))
+	byLabel := make(map[string][]int)
+	byType := make(map[string][]int)
+	byAssignee := make(map[string][]int)
+	all := make([]int, 0, len(issues))
+
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		flows[i] = hr.beadFlow(issue, opts.Now)
+		all = append(all, i)
+		for _, label := range issue.Labels {
+			byLabel[label] = append(byLabel[label], i)
+		}
+		byType[string(issue.IssueType)] = append(byType[string(issue.IssueType)], i)
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = FlowUnassigned
+		}
+		byAssignee[assignee] = append(byAssignee[assignee], i)
+
+		if f := flows[i]; f.closedAt != nil && f.lead != nil {
+			sample := FlowSample{BeadID: issue.ID, ClosedAt: *f.closedAt, LeadDays: toDays(*f.lead)}
+			if f.cycle != nil {
+				d := toDays(*f.cycle)
+				sample.CycleDays = &d
+			}
+			result.Samples = append(result.Samples, sample)
+		}
+	}
+	sort.SliceStable(result.Samples, func(i, j int) bool {
+		return result.Samples[i].ClosedAt.Before(result.Samples[j].ClosedAt)
+	})
+
+	result.Overall = aggregateFlow(FlowDimensionOverall, "all", all, flows, firstWeek, opts.Weeks)
+	result.ByLabel = aggregateFlowGroups(FlowDimensionLabel, byLabel, flows, firstWeek, opts.Weeks)
+	result.ByType = aggregateFlowGroups(FlowDimensionType, byType, flows, firstWeek, opts.Weeks)
+	result.ByAssignee = aggregateFlowGroups(FlowDimensionAssignee, byAssignee, flows, firstWeek, opts.Weeks)
+
+	// Aging WIP: in-progress beads that have already outlived 85% of finished work
+	if result.Overall.CycleTime.Count > 0 {
+		result.AgingThresholdDays = result.Overall.CycleTime.P85Days
+		for _, i := range all {
+			issue := &issues[i]
+			if !flows[i].inProgress {
+				continue
+			}
+			item := AgingWIPItem{
+				BeadID:   issue.ID,
+				Title:    issue.Title,
+				Status:   string(issue.Status),
+				Assignee: issue.Assignee,
+				Since:    "created",
+				StartAt:  issue.CreatedAt,
+			}
+			if history, ok := hr.Histories[issue.ID]; ok && history.Milestones.Claimed != nil {
+				item.Since = "claimed"
+				item.StartAt = history.Milestones.Claimed.Timestamp
+			}
+			item.AgeDays = toDays(opts.Now.Sub(item.StartAt))
+			if item.AgeDays > result.AgingThresholdDays {
+				result.AgingWIP = append(result.AgingWIP, item)
+			}
+		}
+		sort.SliceStable(result.AgingWIP, func(i, j int) bool {
+			return result.AgingWIP[i].AgeDays > result.AgingWIP[j].AgeDays
+		})
+	}
+
+	return result
+}
+
+// beadFlow derives one bead's flow durations from its history, falling back
+// to the issue's own timestamps when git history has no record of it.
+func (hr *HistoryReport) beadFlow(issue *model.Issue, now time.Time) beadFlow {
+	var f beadFlow
+	history, hasHistory := hr.Histories[issue.ID]
+	done := issue.Status.Class() == model.ClassDone
+	f.inProgress = issue.Status.Class() == model.ClassActive
+
+	if done {
+		if hasHistory && history.Milestones.Closed != nil {
+			t := history.Milestones.Closed.Timestamp
+			f.closedAt = &t
+		} else if issue.ClosedAt != nil {
+			t := *issue.ClosedAt
+			f.closedAt = &t
+		}
+		if hasHistory && history.CycleTime != nil {
+			f.lead = history.CycleTime.CreateToClose
+			f.cycle = history.CycleTime.ClaimToClose
+		}
+		if f.lead == nil && f.closedAt != nil && !issue.CreatedAt.IsZero() {
+			d := f.closedAt.Sub(issue.CreatedAt)
+			f.lead = &d
+		}
+	}
+
+	// Flow efficiency only covers claimed work: claimed -> closed, or -> now
+	if !hasHistory || history.Milestones.Claimed == nil || !(done || f.inProgress) {
+		return f
+	}
+	start := history.Milestones.Claimed.Timestamp
+	end := now
+	if done {
+		if f.closedAt == nil {
+			return f
+		}
+		end = *f.closedAt
+	}
+	if !end.After(start) {
+		return f
+	}
+	if chain := hr.BuildCausalityChain(issue.ID, CausalityOptions{}); chain != nil {
+		for _, period := range chain.Insights.BlockedPeriods {
+			f.blocked += overlap(period.StartTime, period.EndTime, start, end)
+		}
+	}
+	f.active = end.Sub(start) - f.blocked
+	return f
+}
+
+// aggregateFlowGroups aggregates each group and orders them by completed
+// count, then key.
+func aggregateFlowGroups(dimension string, groups map[string][]int, flows []beadFlow, firstWeek time.Time, weeks int) []FlowGroupMetrics {
+	result := make([]FlowGroupMetrics, 0, len(groups))
+	for key, members := range groups {
+		result = append(result, aggregateFlow(dimension, key, members, flows, firstWeek, weeks))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Completed != result[j].Completed {
+			return result[i].Completed > result[j].Completed
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// aggregateFlow computes the metrics for one group of beads
+func aggregateFlow(dimension, key string, members []int, flows []beadFlow, firstWeek time.Time, weeks int) FlowGroupMetrics {
+	g := FlowGroupMetrics{
+		Dimension:  dimension,
+		Key:        key,
+		Throughput: make([]WeeklyThroughput, weeks),
+	}
+	for w := range g.Throughput {
+		g.Throughput[w].WeekStart = firstWeek.AddDate(0, 0, 7*w)
+	}
+
+	var lead, cycle []time.Duration
+	var active, blocked time.Duration
+	for _, i := range members {
+		f := flows[i]
+		if f.inProgress {
+			g.InProgress++
+		}
+		if f.closedAt != nil {
+			g.Completed++
+			if !f.closedAt.Before(firstWeek) {
+				if w := int(f.closedAt.Sub(firstWeek) / (7 * 24 * time.Hour)); w < weeks {
+					g.Throughput[w].Closed++
+				}
+			}
+		}
+		if f.lead != nil {
+			lead = append(lead, *f.lead)
+		}
+		if f.cycle != nil {
+			cycle = append(cycle, *f.cycle)
+		}
+		active += f.active
+		blocked += f.blocked
+	}
+
+	g.LeadTime = flowPercentiles(lead)
+	g.CycleTime = flowPercentiles(cycle)
+	closed := 0
+	for _, w := range g.Throughput {
+		closed += w.Closed
+	}
+	g.ThroughputPerWeek = roundDays(float64(closed) / float64(weeks))
+	g.ActiveDays = toDays(active)
+	g.BlockedDays = toDays(blocked)
+	if total := active + blocked; total > 0 {
+		eff := roundDays(float64(active) / float64(total))
+		g.FlowEfficiency = &eff
+	}
+	return g
+}
+
+// flowPercentiles summarizes durations using nearest-rank percentiles
+func flowPercentiles(durations []time.Duration) FlowPercentiles {
+	p := FlowPercentiles{Count: len(durations)}
+	if len(durations) == 0 {
+		return p
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(pct float64) float64 {
+		idx := int(math.Ceil(pct/100*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return toDays(sorted[idx])
+	}
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	p.P50Days = rank(50)
+	p.P85Days = rank(85)
+	p.P95Days = rank(95)
+	p.MeanDays = toDays(sum / time.Duration(len(sorted)))
+	return p
+}
+
+// weekStart returns Monday 00:00 UTC of the week containing t
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// overlap returns how much of [aStart, aEnd) falls inside [bStart, bEnd)
+func overlap(aStart, aEnd, bStart, bEnd time.Time) time.Duration {
+	if aStart.Before(bStart) {
+		aStart = bStart
+	}
+	if aEnd.After(bEnd) {
+		aEnd = bEnd
+	}
+	if !aEnd.After(aStart) {
+		return 0
+	}
+	return aEnd.Sub(aStart)
+}
+
+func toDays(d time.Duration) float64 {
+	return roundDays(d.Hours() / 24)
+}
+
+func roundDays(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,175 @@
+package correlation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// flowFixture returns three closed beads with cycle times of 1, 2 and 8 days,
+// one still in progress for 10 days and one open. The second closed bead
+// spent a day of its cycle blocked.
+func flowFixture(now time.Time) (*HistoryReport, []model.Issue) {
+	day := 24 * time.Hour
+	closed := func(id string, closeAgo, cycle, lead time.Duration) BeadHistory {
+		closeAt := now.Add(-closeAgo)
+		claimAt := closeAt.Add(-cycle)
+		events := []BeadEvent{
+			{EventType: EventCreated, Timestamp: closeAt.Add(-lead), Status: "open"},
+			{EventType: EventClaimed, Timestamp: claimAt, Status: "in_progress"},
+			{EventType: EventClosed, Timestamp: closeAt, Status: "closed"},
+		}
+		return BeadHistory{
+			BeadID: id, Status: "closed", Events: events,
+			Milestones: GetBeadMilestones(events),
+			CycleTime:  CalculateCycleTime(GetBeadMilestones(events)),
+		}
+	}
+
+	report := &HistoryReport{DataHash: "flow-hash", Histories: map[string]BeadHistory{
+		"f-1": closed("f-1", 2*day, 1*day, 3*day),
+		"f-2": closed("f-2", 9*day, 2*day, 4*day),
+		"f-3": closed("f-3", 40*day, 8*day, 10*day),
+	}}
+
+	// f-2 was blocked for a day in the middle of its cycle
+	f2 := report.Histories["f-2"]
+	claim := f2.Milestones.Claimed.Timestamp
+	f2.Events = []BeadEvent{
+		f2.Events[0], f2.Events[1],
+		{EventType: EventModified, Timestamp: claim.Add(12 * time.Hour), Status: "blocked"},
+		{EventType: EventModified, Timestamp: claim.Add(36 * time.Hour), Status: "in_progress"},
+		f2.Events[2],
+	}
+	report.Histories["f-2"] = f2
+
+	wipEvents := []BeadEvent{
+		{EventType: EventCreated, Timestamp: now.Add(-12 * day), Status: "open"},
+		{EventType: EventClaimed, Timestamp: now.Add(-10 * day), Status: "in_progress"},
+	}
+	report.Histories["f-4"] = BeadHistory{BeadID: "f-4", Status: "in_progress", Events: wipEvents, Milestones: GetBeadMilestones(wipEvents)}
+
+	issues := []model.Issue{
+		{ID: "f-1", Status: model.StatusClosed, IssueType: model.TypeBug, Labels: []string{"api"}, Assignee: "ann"},
+		{ID: "f-2", Status: model.StatusClosed, IssueType: model.TypeTask, Labels: []string{"api", "ui"}, Assignee: "bob"},
+		{ID: "f-3", Status: model.StatusClosed, IssueType: model.TypeTask, Labels: []string{"ui"}},
+		{ID: "f-4", Title: "Long runner", Status: model.StatusInProgress, IssueType: model.TypeTask, Assignee: "ann"},
+		{ID: "f-5", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: now.Add(-30 * day)},
+	}
+	return report, issues
+}
+
+func TestBuildFlowMetrics_Overall(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC) // Wednesday
+	report, issues := flowFixture(now)
+
+	got := report.BuildFlowMetrics(issues, FlowOptions{Now: now, Weeks: 4})
+	o := got.Overall
+	if o.Completed != 3 || o.InProgress != 1 {
+		t.Errorf("completed/in_progress = %d/%d, want 3/1", o.Completed, o.InProgress)
+	}
+	if want := (FlowPercentiles{Count: 3, P50Days: 2, P85Days: 8, P95Days: 8, MeanDays: 3.67}); o.CycleTime != want {
+		t.Errorf("cycle time = %+v, want %+v", o.CycleTime, want)
+	}
+	if o.LeadTime.P50Days != 4 || o.LeadTime.Count != 3 {
+		t.Errorf("lead time = %+v", o.LeadTime)
+	}
+
+	// f-3 closed 40 days ago falls outside the four-week window
+	var closed []int
+	for _, w := range o.Throughput {
+		closed = append(closed, w.Closed)
+	}
+	if !reflect.DeepEqual(closed, []int{0, 0, 1, 1}) {
+		t.Errorf("throughput = %v, want [0 0 1 1]", closed)
+	}
+	if o.Throughput[3].WeekStart != time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("last week start = %v, want Monday 2025-06-16", o.Throughput[3].WeekStart)
+	}
+	if o.ThroughputPerWeek != 0.5 {
+		t.Errorf("throughput per week = %v, want 0.5", o.ThroughputPerWeek)
+	}
+
+	// Claimed time: 1 + 2 + 8 days closed plus 10 days in progress; 1 day blocked
+	if o.BlockedDays != 1 || o.ActiveDays != 20 {
+		t.Errorf("active/blocked = %v/%v, want 20/1", o.ActiveDays, o.BlockedDays)
+	}
+	if o.FlowEfficiency == nil || *o.FlowEfficiency != 0.95 {
+		t.Errorf("flow efficiency = %v, want 0.95", o.FlowEfficiency)
+	}
+
+	if len(got.Samples) != 3 || got.Samples[0].BeadID != "f-3" || *got.Samples[0].CycleDays != 8 {
+		t.Errorf("samples = %+v, want f-3 first", got.Samples)
+	}
+}
+
+func TestBuildFlowMetrics_GroupsAndAgingWIP(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	report, issues := flowFixture(now)
+
+	got := report.BuildFlowMetrics(issues, FlowOptions{Now: now})
+	keys := func(groups []FlowGroupMetrics) []string {
+		var out []string
+		for _, g := range groups {
+			out = append(out, g.Key)
+		}
+		return out
+	}
+	if k := keys(got.ByLabel); !reflect.DeepEqual(k, []string{"api", "ui"}) {
+		t.Errorf("labels = %v", k)
+	}
+	if k := keys(got.ByType); !reflect.DeepEqual(k, []string{"task", "bug"}) {
+		t.Errorf("types = %v, want task (2 completed) before bug", k)
+	}
+	if k := keys(got.ByAssignee); !reflect.DeepEqual(k, []string{FlowUnassigned, "ann", "bob"}) {
+		t.Errorf("assignees = %v", k)
+	}
+	if api := got.ByLabel[0]; api.Completed != 2 || api.CycleTime.P50Days != 1 || api.BlockedDays != 1 {
+		t.Errorf("api group = %+v", api)
+	}
+
+	if got.AgingThresholdDays != 8 {
+		t.Errorf("aging threshold = %v, want P85 cycle time 8", got.AgingThresholdDays)
+	}
+	if len(got.AgingWIP) != 1 {
+		t.Fatalf("aging WIP = %+v, want f-4 only", got.AgingWIP)
+	}
+	item := got.AgingWIP[0]
+	if item.BeadID != "f-4" || item.Since != "claimed" || item.AgeDays != 10 || item.Assignee != "ann" {
+		t.Errorf("aging item = %+v", item)
+	}
+}
+
+func TestBuildFlowMetrics_FallsBackToIssueTimestamps(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "x-1", Status: model.StatusClosed, CreatedAt: now.Add(-72 * time.Hour), ClosedAt: &closedAt},
+		{ID: "x-2", Status: model.StatusInProgress, CreatedAt: now.Add(-240 * time.Hour)},
+	}
+	got := (&HistoryReport{}).BuildFlowMetrics(issues, FlowOptions{Now: now})
+	if got.Overall.LeadTime.P50Days != 2 {
+		t.Errorf("lead time = %+v, want 2 days from issue timestamps", got.Overall.LeadTime)
+	}
+	if got.Overall.CycleTime.Count != 0 || got.Overall.FlowEfficiency != nil {
+		t.Errorf("cycle time without claims = %+v, efficiency %v", got.Overall.CycleTime, got.Overall.FlowEfficiency)
+	}
+	if len(got.AgingWIP) != 0 {
+		t.Errorf("aging WIP without a cycle-time baseline = %+v", got.AgingWIP)
+	}
+}
+
+func TestFlowPercentilesNearestRank(t *testing.T) {
+	day := 24 * time.Hour
+	var ds []time.Duration
+	for i := 10; i >= 1; i-- {
+		ds = append(ds, time.Duration(i)*day)
+	}
+	got := flowPercentiles(ds)
+	want := FlowPercentiles{Count: 10, P50Days: 5, P85Days: 9, P95Days: 10, MeanDays: 5.5}
+	if got != want {
+		t.Errorf("flowPercentiles = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,484 @@
+// Package correlation provides hunk-level attribution of commits shared by several beads.
+package correlation
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Attribution weights. Every bead updated in a shared commit starts from
+// attributionPrior so that a bead with no evidence still keeps a small share.
+const (
+	attributionPrior     = 0.10
+	footprintWeight      = 0.60 // fraction of changed lines in files the bead touched before
+	textWeight           = 0.40 // bead text matching changed paths and hunk symbols
+	messageMentionWeight = 0.50 // commit message names the bead
+	dirOwnershipCredit   = 0.5  // credit for a file next to ones the bead touched before
+	textSaturation       = 4    // matching terms needed for a full text score
+)
+
+// FileHunks summarizes the hunks a commit changed in one file
+type FileHunks struct {
+	Path    string   `json:"path"`
+	Hunks   int      `json:"hunks"`
+	Symbols []string `json:"symbols,omitempty"` // Enclosing and declared symbols, deduplicated
+}
+
+// HunkAttributor splits commits linked to several beads. Each bead's share is
+// weighted by how much of the change falls in files it touched in earlier
+// commits and by how well its title, description and acceptance criteria
+// match the changed paths and hunk symbols.
+type HunkAttributor struct {
+	repoPath string
+	index    *FileBeadIndex
+	dirIndex map[string][]BeadReference // directory -> references for files in it
+	beads    map[string]BeadInfo
+}
+
+// NewHunkAttributor creates an attributor over the given historical file index
+func NewHunkAttributor(repoPath string, index *FileBeadIndex, beads []BeadInfo) *HunkAttributor {
+	if index == nil {
+		index = BuildFileIndex(nil)
+	}
+	h := &HunkAttributor{
+		repoPath: repoPath,
+		index:    index,
+		dirIndex: make(map[string][]BeadReference),
+		beads:    make(map[string]BeadInfo, len(beads)),
+	}
+	for path, refs := range index.FileToBeads {
+		dir := filepath.ToSlash(filepath.Dir(path))
+		h.dirIndex[dir] = append(h.dirIndex[dir], refs...)
+	}
+	for _, b := range beads {
+		h.beads[b.ID] = b
+	}
+	return h
+}
+
+// AttributeShared rewrites every commit that is linked to more than one bead
+// as hunk_ownership correlations whose confidences are split between those
+// beads. Commits linked to a single bead are returned unchanged.
+func (h *HunkAttributor) AttributeShared(commits []CorrelatedCommit) []CorrelatedCommit {
+	beadsBySHA := make(map[string]map[string]bool)
+	for _, c := range commits {
+		if c.BeadID == "" {
+			continue
+		}
+		if beadsBySHA[c.SHA] == nil {
+			beadsBySHA[c.SHA] = make(map[string]bool)
+		}
+		beadsBySHA[c.SHA][c.BeadID] = true
+	}
+
+	result := make([]CorrelatedCommit, 0, len(commits))
+	done := make(map[string]bool)
+	for _, c := range commits {
+		if len(beadsBySHA[c.SHA]) < 2 {
+			result = append(result, c)
+			continue
+		}
+		if done[c.SHA] {
+			continue
+		}
+		done[c.SHA] = true
+
+		var shared []CorrelatedCommit
+		for _, other := range commits {
+			if other.SHA == c.SHA && other.BeadID != "" {
+				shared = append(shared, other)
+			}
+		}
+
+		hunks, err := h.CommitHunks(c.SHA)
+		if err != nil {
+			// Non-fatal: fall back to changed paths only
+			hunks = nil
+		}
+		result = append(result, h.Attribute(shared, hunks)...)
+	}
+	return result
+}
+
+// CommitHunks returns the code files a commit changed with their hunk counts and symbols
+func (h *HunkAttributor) CommitHunks(sha string) ([]FileHunks, error) {
+	cmd := exec.Command("git", "show", "-U0", "--no-color", "--no-ext-diff", "--format=", sha)
+	cmd.Dir = h.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show failed: %w", err)
+	}
+
+	var files []FileHunks
+	for _, f := range parseDiffHunks(string(out)) {
+		if isCodeFile(f.Path) && !isExcludedPath(f.Path) {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Attribute splits one commit, given as its correlations to each bead, across
+// those beads. The returned correlations carry the full split in Attribution.
+func (h *HunkAttributor) Attribute(shared []CorrelatedCommit, hunks []FileHunks) []CorrelatedCommit {
+	// Keep one correlation per bead (a bead claimed and closed in the same
+	// commit produces two)
+	var beadOrder []string
+	byBead := make(map[string]CorrelatedCommit)
+	for _, c := range shared {
+		prev, ok := byBead[c.BeadID]
+		if !ok {
+			beadOrder = append(beadOrder, c.BeadID)
+		}
+		if !ok || c.Confidence > prev.Confidence {
+			byBead[c.BeadID] = c
+		}
+	}
+	if len(beadOrder) < 2 {
+		result := make([]CorrelatedCommit, 0, len(beadOrder))
+		for _, id := range beadOrder {
+			result = append(result, byBead[id])
+		}
+		return result
+	}
+
+	first := byBead[beadOrder[0]]
+	base := 0.0
+	for _, id := range beadOrder {
+		base = math.Max(base, byBead[id].Confidence)
+	}
+
+	commitTerms := make(map[string]bool)
+	for _, f := range first.Files {
+		addTerms(commitTerms, pathTerms(f.Path))
+	}
+	for _, fh := range hunks {
+		addTerms(commitTerms, pathTerms(fh.Path))
+		for _, sym := range fh.Symbols {
+			addTerms(commitTerms, splitTerms(sym))
+		}
+	}
+
+	evidence := make(map[string]shareEvidence, len(beadOrder))
+	total := 0.0
+	for _, id := range beadOrder {
+		e := h.scoreBead(id, first, commitTerms)
+		evidence[id] = e
+		total += e.raw
+	}
+
+	attribution := &HunkAttribution{BaseConfidence: base}
+	for _, id := range beadOrder {
+		e := evidence[id]
+		share := e.raw / total
+		attribution.Shares = append(attribution.Shares, AttributionShare{
+			BeadID:     id,
+			Share:      share,
+			Confidence: clamp(base*share, 0.01, 0.99),
+			Evidence:   e.signals(len(beadOrder)),
+		})
+	}
+	sort.SliceStable(attribution.Shares, func(i, j int) bool {
+		if attribution.Shares[i].Share != attribution.Shares[j].Share {
+			return attribution.Shares[i].Share > attribution.Shares[j].Share
+		}
+		return attribution.Shares[i].BeadID < attribution.Shares[j].BeadID
+	})
+
+	result := make([]CorrelatedCommit, 0, len(beadOrder))
+	for _, id := range beadOrder {
+		c := byBead[id]
+		s, _ := attribution.ShareFor(id)
+		c.Method = MethodHunkOwnership
+		c.Confidence = s.Confidence
+		c.Reason = evidence[id].reason(s.Share, len(beadOrder))
+		c.Attribution = attribution
+		result = append(result, c)
+	}
+	return result
+}
+
+// shareEvidence holds the scoring components for one bead's share
+type shareEvidence struct {
+	raw float64
+
+	footprint    float64
+	ownedFiles   []string
+	nearbyFiles  []string
+	ownedLines   int
+	totalLines   int
+	text         float64
+	matchedTerms []string
+	mentioned    bool
+}
+
+// scoreBead computes the unnormalized share components for a bead
+func (h *HunkAttributor) scoreBead(beadID string, commit CorrelatedCommit, commitTerms map[string]bool) shareEvidence {
+	var e shareEvidence
+	short := shortSHA(commit.SHA)
+
+	credited := 0.0
+	for _, f := range commit.Files {
+		lines := f.Insertions + f.Deletions
+		if lines < 1 {
+			lines = 1
+		}
+		e.totalLines += lines
+
+		path := normalizePath(f.Path)
+		switch {
+		case touchedBefore(h.index.FileToBeads[path], beadID, short):
+			e.ownedFiles = append(e.ownedFiles, path)
+			e.ownedLines += lines
+			credited += float64(lines)
+		case touchedBefore(h.dirIndex[filepath.ToSlash(filepath.Dir(path))], beadID, short):
+			e.nearbyFiles = append(e.nearbyFiles, path)
+			credited += dirOwnershipCredit * float64(lines)
+		}
+	}
+	if e.totalLines > 0 {
+		e.footprint = credited / float64(e.totalLines)
+	}
+
+	bead := h.beads[beadID]
+	beadTerms := make(map[string]bool)
+	addTerms(beadTerms, splitTerms(bead.Title))
+	addTerms(beadTerms, splitTerms(bead.Description))
+	addTerms(beadTerms, splitTerms(bead.AcceptanceCriteria))
+	for term := range beadTerms {
+		if termMatches(term, commitTerms) {
+			e.matchedTerms = append(e.matchedTerms, term)
+		}
+	}
+	sort.Strings(e.matchedTerms)
+	e.text = math.Min(1, float64(len(e.matchedTerms))/textSaturation)
+
+	e.mentioned = containsBeadID(commit.Message, beadID)
+
+	e.raw = attributionPrior + footprintWeight*e.footprint + textWeight*e.text
+	if e.mentioned {
+		e.raw += messageMentionWeight
+	}
+	return e
+}
+
+// touchedBefore reports whether beadID touched a referenced file in a commit other than excludeSHA
+func touchedBefore(refs []BeadReference, beadID, excludeSHA string) bool {
+	for _, ref := range refs {
+		if ref.BeadID != beadID {
+			continue
+		}
+		for _, sha := range ref.CommitSHAs {
+			if sha != excludeSHA {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// signals converts the components into weighted explanation signals
+func (e shareEvidence) signals(beadCount int) []CorrelationSignal {
+	weight := func(component float64) int {
+		return int(math.Round(100 * component / e.raw))
+	}
+
+	signals := []CorrelationSignal{{
+		Type:   SignalCoCommit,
+		Weight: weight(attributionPrior),
+		Detail: fmt.Sprintf("Bead was updated in this commit along with %d other bead(s)", beadCount-1),
+	}}
+	if e.footprint > 0 {
+		var parts []string
+		if len(e.ownedFiles) > 0 {
+			parts = append(parts, fmt.Sprintf("touched %s in earlier commits (%d of %d changed lines)",
+				summarizePaths(e.ownedFiles), e.ownedLines, e.totalLines))
+		}
+		if len(e.nearbyFiles) > 0 {
+			parts = append(parts, fmt.Sprintf("touched files next to %s", summarizePaths(e.nearbyFiles)))
+		}
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalFileOverlap,
+			Weight: weight(footprintWeight * e.footprint),
+			Detail: "Bead " + strings.Join(parts, "; "),
+		})
+	}
+	if len(e.matchedTerms) > 0 {
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalSymbolMatch,
+			Weight: weight(textWeight * e.text),
+			Detail: "Bead text matches changed paths and symbols: " + strings.Join(e.matchedTerms, ", "),
+		})
+	}
+	if e.mentioned {
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalMessageMatch,
+			Weight: weight(messageMentionWeight),
+			Detail: "Commit message references bead ID",
+		})
+	}
+	return signals
+}
+
+// reason creates a human-readable explanation for a bead's share
+func (e shareEvidence) reason(share float64, beadCount int) string {
+	parts := []string{fmt.Sprintf("%.0f%% share of a commit updating %d beads", share*100, beadCount)}
+	if e.ownedLines > 0 {
+		parts = append(parts, fmt.Sprintf("bead touched %d of %d changed lines before", e.ownedLines, e.totalLines))
+	} else if len(e.nearbyFiles) > 0 {
+		parts = append(parts, "bead touched neighboring files before")
+	}
+	if len(e.matchedTerms) > 0 {
+		parts = append(parts, fmt.Sprintf("%d matching term(s)", len(e.matchedTerms)))
+	}
+	if e.mentioned {
+		parts = append(parts, "commit message references bead ID")
+	}
+	if len(parts) == 1 {
+		parts = append(parts, "no distinguishing evidence")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// summarizePaths lists up to three paths
+func summarizePaths(paths []string) string {
+	if len(paths) <= 3 {
+		return strings.Join(paths, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:3], ", "), len(paths)-3)
+}
+
+var (
+	// declPattern captures the name in a declaration line across common languages
+	declPattern = regexp.MustCompile(`^\s*(?:export\s+)?(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:func|type|class|def|fn|function|interface|struct|enum|trait|impl|module)\s+(?:\([^)]*\)\s*)?([A-Za-z_][A-Za-z0-9_]*)`)
+	// callPattern captures the first function-like name in a hunk header context
+	callPattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+	// termPattern splits identifiers and prose into words, breaking camelCase
+	termPattern = regexp.MustCompile(`[A-Z]+[a-z0-9]*|[a-z0-9]+`)
+)
+
+// parseDiffHunks parses `git show -U0` output into per-file hunk summaries.
+// Symbols come from hunk header context (the enclosing function git reports)
+// and from declarations on added or removed lines.
+func parseDiffHunks(diff string) []FileHunks {
+	var files []FileHunks
+	var seen map[string]bool
+	inHeader := false
+	addSymbol := func(sym string) {
+		if sym != "" && !seen[sym] {
+			seen[sym] = true
+			files[len(files)-1].Symbols = append(files[len(files)-1].Symbols, sym)
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "diff --git ") {
+			path := line[len("diff --git "):]
+			if i := strings.LastIndex(path, " b/"); i >= 0 {
+				path = path[i+len(" b/"):]
+			}
+			files = append(files, FileHunks{Path: path})
+			seen = make(map[string]bool)
+			inHeader = true
+			continue
+		}
+		if len(files) == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHeader = false
+			files[len(files)-1].Hunks++
+			end := strings.Index(line[2:], "@@")
+			if end < 0 {
+				continue
+			}
+			context := line[2+end+2:]
+			if m := declPattern.FindStringSubmatch(context); m != nil {
+				addSymbol(m[1])
+			} else if m := callPattern.FindStringSubmatch(context); m != nil {
+				addSymbol(m[1])
+			}
+		case inHeader:
+			// Prefer the +++ path, which reflects renames
+			if strings.HasPrefix(line, "+++ b/") {
+				files[len(files)-1].Path = line[len("+++ b/"):]
+			}
+		case strings.HasPrefix(line, "+"), strings.HasPrefix(line, "-"):
+			if m := declPattern.FindStringSubmatch(line[1:]); m != nil {
+				addSymbol(m[1])
+			}
+		}
+	}
+	return files
+}
+
+// stopTerms are words too common in prose or paths to count as evidence
+var stopTerms = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "this": true,
+	"that": true, "into": true, "when": true, "then": true, "than": true, "should": true,
+	"must": true, "will": true, "can": true, "are": true, "was": true, "were": true,
+	"been": true, "have": true, "has": true, "not": true, "but": true, "all": true,
+	"any": true, "each": true, "its": true, "our": true, "your": true, "their": true,
+	"them": true, "they": true, "you": true, "add": true, "fix": true, "use": true,
+	"make": true, "new": true, "get": true, "set": true, "func": true, "return": true,
+	"type": true, "var": true, "const": true, "let": true, "def": true, "class": true,
+	"impl": true, "struct": true, "test": true, "main": true, "pkg": true, "src": true,
+	"lib": true, "internal": true, "cmd": true, "app": true, "index": true, "file": true,
+}
+
+// splitTerms lowercases text into matchable terms: camelCase and snake_case
+// are split, short words and stop words dropped, and a trailing plural s trimmed
+func splitTerms(text string) []string {
+	var terms []string
+	for _, w := range termPattern.FindAllString(text, -1) {
+		w = strings.ToLower(w)
+		if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		if len(w) < 3 || stopTerms[w] {
+			continue
+		}
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// pathTerms returns the terms in a path's directories and file name, without the extension
+func pathTerms(path string) []string {
+	path = filepath.ToSlash(path)
+	return splitTerms(strings.TrimSuffix(path, filepath.Ext(path)))
+}
+
+// termMatches reports whether term is in set, treating terms of five or more
+// letters as matching their extensions (recover matches recovery)
+func termMatches(term string, set map[string]bool) bool {
+	if set[term] {
+		return true
+	}
+	if len(term) < 5 {
+		return false
+	}
+	for other := range set {
+		if len(other) >= 5 && (strings.HasPrefix(other, term) || strings.HasPrefix(term, other)) {
+			return true
+		}
+	}
+	return false
+}
+
+func addTerms(set map[string]bool, terms []string) {
+	for _, t := range terms {
+		set[t] = true
+	}
+}
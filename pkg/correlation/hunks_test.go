@@ -0,0 +1,222 @@
+package correlation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDiffHunks(t *testing.T) {
+	diff := `diff --git a/pkg/query/parser.go b/pkg/query/parser.go
+index 1111111..2222222 100644
+--- a/pkg/query/parser.go
++++ b/pkg/query/parser.go
+@@ -45,0 +46,3 @@ func (p *parser) parseOr() (node, error) {
++	if p.peek().kind == tEOF {
++		return nil, errEmpty
++	}
+@@ -90 +93 @@ func (p *parser) parseUnary() (node, error) {
+-	return p.parsePrimary()
++	return p.parseTerm()
+@@ -120,0 +124,4 @@ type parser struct {
++func (p *parser) recoverFrom(err error) node {
++	return nil
++}
+diff --git a/old/name.sql b/new/name.sql
+similarity index 90%
+rename from old/name.sql
+rename to new/name.sql
+--- a/old/name.sql
++++ b/new/name.sql
+@@ -3 +3 @@ SELECT
+--- removed comment
++-- new comment
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-type Gone struct{}
+`
+	got := parseDiffHunks(diff)
+	want := []FileHunks{
+		{Path: "pkg/query/parser.go", Hunks: 3, Symbols: []string{"parseOr", "parseUnary", "parser", "recoverFrom"}},
+		{Path: "new/name.sql", Hunks: 1},
+		{Path: "gone.go", Hunks: 1, Symbols: []string{"Gone"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDiffHunks =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestSplitTerms(t *testing.T) {
+	got := splitTerms("Fix parseOrExpr error_recovery in the Parsers")
+	want := []string{"parse", "expr", "error", "recovery", "parser"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitTerms = %v, want %v", got, want)
+	}
+	if got := pathTerms("pkg/query/parser.go"); !reflect.DeepEqual(got, []string{"query", "parser"}) {
+		t.Errorf("pathTerms = %v", got)
+	}
+	set := map[string]bool{"recover": true, "load": true}
+	if !termMatches("recovery", set) || termMatches("loader", set) {
+		t.Error("termMatches should extend five-letter terms only")
+	}
+}
+
+// sharedCommitFixture returns an index where bv-1 owns parser.go and bv-2 owns
+// cache.go through earlier commits, and a commit updating both beads that
+// mostly changes parser.go.
+func sharedCommitFixture() (*HunkAttributor, []CorrelatedCommit) {
+	now := time.Now()
+	report := &HistoryReport{Histories: map[string]BeadHistory{
+		"bv-1": {BeadID: "bv-1", Commits: []CorrelatedCommit{
+			{SHA: "aaaaaaa1", ShortSHA: "aaaaaaa", Timestamp: now.Add(-time.Hour),
+				Files: []FileChange{{Path: "pkg/query/parser.go", Insertions: 10}}},
+			{SHA: "ccccccc1", ShortSHA: "ccccccc", Timestamp: now,
+				Files: []FileChange{{Path: "pkg/query/parser.go"}, {Path: "pkg/export/cache.go"}}},
+		}},
+		"bv-2": {BeadID: "bv-2", Commits: []CorrelatedCommit{
+			{SHA: "bbbbbbb1", ShortSHA: "bbbbbbb", Timestamp: now.Add(-time.Hour),
+				Files: []FileChange{{Path: "pkg/export/cache.go", Insertions: 5}}},
+			{SHA: "ccccccc1", ShortSHA: "ccccccc", Timestamp: now,
+				Files: []FileChange{{Path: "pkg/query/parser.go"}, {Path: "pkg/export/cache.go"}}},
+		}},
+		"bv-3": {BeadID: "bv-3", Commits: []CorrelatedCommit{
+			// Only linked through the shared commit itself
+			{SHA: "ccccccc1", ShortSHA: "ccccccc", Timestamp: now,
+				Files: []FileChange{{Path: "pkg/query/parser.go"}, {Path: "pkg/export/cache.go"}}},
+		}},
+	}}
+	beads := []BeadInfo{
+		{ID: "bv-1", Title: "Parser error recovery", AcceptanceCriteria: "parseOr recovers from bad input"},
+		{ID: "bv-2", Title: "Export cache eviction"},
+		{ID: "bv-3", Title: "Unrelated docs"},
+	}
+	files := []FileChange{
+		{Path: "pkg/query/parser.go", Insertions: 30, Deletions: 10},
+		{Path: "pkg/export/cache.go", Insertions: 2},
+	}
+	var shared []CorrelatedCommit
+	for _, id := range []string{"bv-1", "bv-2", "bv-3"} {
+		shared = append(shared, CorrelatedCommit{
+			BeadID: id, SHA: "ccccccc1", ShortSHA: "ccccccc", Message: "close out sprint work",
+			Files: files, Method: MethodCoCommitted, Confidence: 0.95,
+		})
+	}
+	return NewHunkAttributor(".", BuildFileIndex(report), beads), shared
+}
+
+func TestHunkAttributor_SplitsByOwnership(t *testing.T) {
+	h, shared := sharedCommitFixture()
+	hunks := []FileHunks{{Path: "pkg/query/parser.go", Hunks: 2, Symbols: []string{"parseOr", "recoverFrom"}}}
+
+	got := h.Attribute(shared, hunks)
+	if len(got) != 3 {
+		t.Fatalf("got %d correlations, want 3", len(got))
+	}
+
+	byBead := make(map[string]CorrelatedCommit)
+	total := 0.0
+	for _, c := range got {
+		if c.Method != MethodHunkOwnership {
+			t.Errorf("%s method = %s, want %s", c.BeadID, c.Method, MethodHunkOwnership)
+		}
+		share, ok := c.Attribution.ShareFor(c.BeadID)
+		if !ok {
+			t.Fatalf("%s has no share", c.BeadID)
+		}
+		if math.Abs(share.Confidence-c.Confidence) > 1e-9 {
+			t.Errorf("%s confidence %v != share confidence %v", c.BeadID, c.Confidence, share.Confidence)
+		}
+		total += share.Share
+		byBead[c.BeadID] = c
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("shares sum to %v, want 1", total)
+	}
+
+	c1, c2, c3 := byBead["bv-1"], byBead["bv-2"], byBead["bv-3"]
+	if !(c1.Confidence > c2.Confidence && c2.Confidence > c3.Confidence) {
+		t.Errorf("confidences = %.2f, %.2f, %.2f; want bv-1 > bv-2 > bv-3", c1.Confidence, c2.Confidence, c3.Confidence)
+	}
+	if c1.Attribution.Shares[0].BeadID != "bv-1" {
+		t.Errorf("largest share = %s, want bv-1", c1.Attribution.Shares[0].BeadID)
+	}
+	if c1.Attribution.BaseConfidence != 0.95 {
+		t.Errorf("base confidence = %v, want 0.95", c1.Attribution.BaseConfidence)
+	}
+
+	share1, _ := c1.Attribution.ShareFor("bv-1")
+	types := make(map[SignalType]bool)
+	for _, sig := range share1.Evidence {
+		types[sig.Type] = true
+	}
+	for _, want := range []SignalType{SignalCoCommit, SignalFileOverlap, SignalSymbolMatch} {
+		if !types[want] {
+			t.Errorf("bv-1 evidence missing %s: %+v", want, share1.Evidence)
+		}
+	}
+
+	// The shared commit itself is not evidence of ownership
+	share3, _ := c3.Attribution.ShareFor("bv-3")
+	if len(share3.Evidence) != 1 || share3.Evidence[0].Type != SignalCoCommit {
+		t.Errorf("bv-3 evidence = %+v, want only co_commit", share3.Evidence)
+	}
+}
+
+func TestHunkAttributor_EvenSplitWithoutEvidence(t *testing.T) {
+	h := NewHunkAttributor(".", nil, nil)
+	shared := []CorrelatedCommit{
+		{BeadID: "a", SHA: "s1", Confidence: 0.9, Files: []FileChange{{Path: "x.go"}}},
+		{BeadID: "b", SHA: "s1", Confidence: 0.8, Files: []FileChange{{Path: "x.go"}}},
+		{BeadID: "a", SHA: "s1", Confidence: 0.7, Files: []FileChange{{Path: "x.go"}}}, // claimed and closed together
+	}
+	got := h.Attribute(shared, nil)
+	if len(got) != 2 {
+		t.Fatalf("got %d correlations, want one per bead", len(got))
+	}
+	for _, c := range got {
+		if math.Abs(c.Confidence-0.45) > 1e-9 {
+			t.Errorf("%s confidence = %v, want 0.45", c.BeadID, c.Confidence)
+		}
+	}
+}
+
+func TestHunkAttributor_AttributeSharedLeavesSingleBeadCommits(t *testing.T) {
+	h := NewHunkAttributor(".", nil, nil)
+	commits := []CorrelatedCommit{
+		{BeadID: "a", SHA: "solo", Method: MethodCoCommitted, Confidence: 0.95},
+	}
+	got := h.AttributeShared(commits)
+	if !reflect.DeepEqual(got, commits) {
+		t.Errorf("AttributeShared changed a single-bead commit: %+v", got)
+	}
+	if hasSharedCommits(commits) {
+		t.Error("hasSharedCommits = true for a single-bead commit")
+	}
+}
+
+func TestBuildExplanation_SharedCommit(t *testing.T) {
+	h, shared := sharedCommitFixture()
+	got := h.Attribute(shared, nil)
+
+	commit := got[1]   // bv-2
+	commit.BeadID = "" // as loaded from a cached report
+	exp := NewScorer().BuildExplanation(commit, "bv-2")
+	if exp.Method != MethodHunkOwnership {
+		t.Errorf("method = %s", exp.Method)
+	}
+	if len(exp.Shares) != 3 {
+		t.Errorf("shares = %d, want 3", len(exp.Shares))
+	}
+	share, _ := commit.Attribution.ShareFor("bv-2")
+	if !reflect.DeepEqual(exp.Signals, share.Evidence) {
+		t.Errorf("signals = %+v, want the bead's share evidence %+v", exp.Signals, share.Evidence)
+	}
+	if !NewScorer().ValidateConfidence(MethodHunkOwnership, commit.Confidence) {
+		t.Errorf("confidence %v outside method range", commit.Confidence)
+	}
+}
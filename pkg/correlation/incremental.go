@@ -178,6 +178,9 @@ func (ic *IncrementalCorrelator) tryIncrementalUpdate(existing *HistoryReport, b
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
 	}
 
+	// Split new commits shared by several beads against the existing footprint
+	newCorrelatedCommits = NewHunkAttributor(ic.cache.repoPath, BuildFileIndex(existing), beads).AttributeShared(newCorrelatedCommits)
+
 	// Merge new data with existing report
 	merged := mergeReports(existing, beads, newEvents, newCorrelatedCommits)
 
@@ -104,14 +104,14 @@ func (hr *HistoryReport) FindRelatedWork(targetID string, opts RelatedWorkOption
 
 	// 1. File Overlap Detection
 	fileOverlapCandidates := hr.findFileOverlap(targetID, targetFiles, fileLookup, opts, seen)
-	result.FileOverlap = fileOverlapCandidates
+	result.FileOverlap = append(result.FileOverlap, fileOverlapCandidates...)
 	for _, rb := range fileOverlapCandidates {
 		seen[rb.BeadID] = true
 	}
 
 	// 2. Commit Overlap Detection
 	commitOverlapCandidates := hr.findCommitOverlap(targetID, targetCommits, opts, seen)
-	result.CommitOverlap = commitOverlapCandidates
+	result.CommitOverlap = append(result.CommitOverlap, commitOverlapCandidates...)
 	for _, rb := range commitOverlapCandidates {
 		seen[rb.BeadID] = true
 	}
@@ -119,7 +119,7 @@ func (hr *HistoryReport) FindRelatedWork(targetID string, opts RelatedWorkOption
 	// 3. Dependency Cluster Detection
 	if opts.DependencyGraph != nil {
 		depClusterCandidates := hr.findDependencyCluster(targetID, opts, seen)
-		result.DependencyCluster = depClusterCandidates
+		result.DependencyCluster = append(result.DependencyCluster, depClusterCandidates...)
 		for _, rb := range depClusterCandidates {
 			seen[rb.BeadID] = true
 		}
@@ -127,7 +127,7 @@ func (hr *HistoryReport) FindRelatedWork(targetID string, opts RelatedWorkOption
 
 	// 4. Concurrent Detection (same time window)
 	concurrentCandidates := hr.findConcurrent(targetID, target, opts, seen)
-	result.Concurrent = concurrentCandidates
+	result.Concurrent = append(result.Concurrent, concurrentCandidates...)
 
 	// Calculate total
 	result.TotalRelated = len(result.FileOverlap) + len(result.CommitOverlap) +
@@ -35,6 +35,12 @@ var MethodRanges = map[CorrelationMethod]ConfidenceRange{
 		Max:    0.85,
 		Desc:   "By same author during bead's active window (temporal correlation)",
 	},
+	MethodHunkOwnership: {
+		Method: MethodHunkOwnership,
+		Min:    0.01,
+		Max:    0.99,
+		Desc:   "Share of a commit updating several beads, split by file ownership and hunk symbols",
+	},
 }
 
 // Scorer provides methods for calculating and combining confidence scores.
@@ -344,6 +350,9 @@ func FormatConfidence(confidence float64) string {
 
 // BuildExplanation generates a detailed CorrelationExplanation for a commit-bead correlation
 func (s *Scorer) BuildExplanation(commit CorrelatedCommit, beadID string) CorrelationExplanation {
+	if commit.BeadID == "" {
+		commit.BeadID = beadID // Not serialized, so missing on cached reports
+	}
 	signals := s.ExtractSignals(commit)
 	totalWeight := 0
 	for _, sig := range signals {
@@ -365,7 +374,16 @@ func (s *Scorer) BuildExplanation(commit CorrelatedCommit, beadID string) Correl
 		TotalWeight:    totalWeight,
 		Summary:        summary,
 		Recommendation: recommendation,
+		Shares:         sharesOf(commit),
+	}
+}
+
+// sharesOf returns the per-bead split of a shared commit, or nil
+func sharesOf(commit CorrelatedCommit) []AttributionShare {
+	if commit.Attribution == nil {
+		return nil
 	}
+	return commit.Attribution.Shares
 }
 
 // ExtractSignals derives individual signals from a CorrelatedCommit
@@ -397,6 +415,11 @@ func (s *Scorer) ExtractSignals(commit CorrelatedCommit) []CorrelationSignal {
 			Weight: 15,
 			Detail: fmt.Sprintf("By assignee: %s", commit.Author),
 		})
+	case MethodHunkOwnership:
+		// The share's own evidence already covers file overlap
+		if share, ok := commit.Attribution.ShareFor(commit.BeadID); ok {
+			return share.Evidence
+		}
 	}
 
 	// File-based signals
@@ -433,6 +456,11 @@ func (s *Scorer) buildSummary(commit CorrelatedCommit, signals []CorrelationSign
 		methodDesc = "Explicitly references bead ID"
 	case MethodTemporalAuthor:
 		methodDesc = "Temporal+author correlation"
+	case MethodHunkOwnership:
+		methodDesc = "Split from a commit shared by several beads"
+		if commit.Attribution != nil {
+			methodDesc = fmt.Sprintf("Split from a commit shared by %d beads", len(commit.Attribution.Shares))
+		}
 	}
 
 	return fmt.Sprintf("%s (%.0f%% confidence, %d signals)",
@@ -309,6 +309,7 @@ func (s *StreamExtractor) parseBufferedDiff(lines []string, info commitInfo, fil
 			CommitMsg:   info.Message,
 			Author:      info.Author,
 			AuthorEmail: info.AuthorEmail,
+			Status:      newSnap.Status,
 		}
 
 		if !hadOld && hasNew {
@@ -45,6 +45,7 @@ type BeadEvent struct {
 	CommitMsg   string    `json:"commit_message"`
 	Author      string    `json:"author"`
 	AuthorEmail string    `json:"author_email"`
+	Status      string    `json:"status,omitempty"` // Bead status after the event
 }
 
 // CorrelationMethod describes how a commit was linked to a bead
@@ -57,6 +58,9 @@ const (
 	MethodExplicitID CorrelationMethod = "explicit_id"
 	// MethodTemporalAuthor means the commit is temporally close and by the assignee
 	MethodTemporalAuthor CorrelationMethod = "temporal_author"
+	// MethodHunkOwnership means the commit was shared by several beads and this bead's
+	// share was assigned from file ownership and hunk symbol evidence
+	MethodHunkOwnership CorrelationMethod = "hunk_ownership"
 )
 
 // String returns the string representation of CorrelationMethod
@@ -67,7 +71,7 @@ func (c CorrelationMethod) String() string {
 // IsValid returns true if the correlation method is a recognized value
 func (c CorrelationMethod) IsValid() bool {
 	switch c {
-	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor:
+	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor, MethodHunkOwnership:
 		return true
 	}
 	return false
@@ -92,8 +96,36 @@ type CorrelatedCommit struct {
 	Timestamp   time.Time         `json:"timestamp"`
 	Files       []FileChange      `json:"files"`
 	Method      CorrelationMethod `json:"method"`
-	Confidence  float64           `json:"confidence"` // 0.0 to 1.0
-	Reason      string            `json:"reason"`     // Human-readable explanation
+	Confidence  float64           `json:"confidence"`            // 0.0 to 1.0
+	Reason      string            `json:"reason"`                // Human-readable explanation
+	Attribution *HunkAttribution  `json:"attribution,omitempty"` // Set when the commit was split across beads
+}
+
+// HunkAttribution records how a commit shared by several beads was split between them
+type HunkAttribution struct {
+	BaseConfidence float64            `json:"base_confidence"` // Confidence before the split
+	Shares         []AttributionShare `json:"shares"`          // One per bead, largest share first
+}
+
+// AttributionShare is one bead's portion of a shared commit and the evidence for it
+type AttributionShare struct {
+	BeadID     string              `json:"bead_id"`
+	Share      float64             `json:"share"`      // Fraction of the commit, shares sum to 1.0
+	Confidence float64             `json:"confidence"` // Confidence of this bead's link to the commit
+	Evidence   []CorrelationSignal `json:"evidence"`   // Signals that produced the share
+}
+
+// ShareFor returns the share attributed to beadID, if any
+func (a *HunkAttribution) ShareFor(beadID string) (AttributionShare, bool) {
+	if a == nil {
+		return AttributionShare{}, false
+	}
+	for _, s := range a.Shares {
+		if s.BeadID == beadID {
+			return s, true
+		}
+	}
+	return AttributionShare{}, false
 }
 
 // BeadMilestones contains key lifecycle timestamps for quick access
@@ -174,6 +206,8 @@ const (
 	SignalProximity SignalType = "proximity"
 	// SignalCoCommit indicates the commit modified beads file and code together
 	SignalCoCommit SignalType = "co_commit"
+	// SignalSymbolMatch indicates bead text shares terms with changed paths and hunk symbols
+	SignalSymbolMatch SignalType = "symbol_match"
 )
 
 // CorrelationSignal represents a single factor contributing to correlation confidence
@@ -187,14 +221,15 @@ type CorrelationSignal struct {
 type CorrelationExplanation struct {
 	CommitSHA      string              `json:"commit_sha"`
 	BeadID         string              `json:"bead_id"`
-	Confidence     float64             `json:"confidence"`     // 0.0 to 1.0
-	ConfidencePct  int                 `json:"confidence_pct"` // 0 to 100 for display
-	Level          string              `json:"level"`          // "very high", "high", "moderate", "low", "very low"
-	Method         CorrelationMethod   `json:"method"`         // Primary correlation method
-	Signals        []CorrelationSignal `json:"signals"`        // All contributing signals
-	TotalWeight    int                 `json:"total_weight"`   // Sum of signal weights
-	Summary        string              `json:"summary"`        // One-line summary
-	Recommendation string              `json:"recommendation"` // Suggested action
+	Confidence     float64             `json:"confidence"`       // 0.0 to 1.0
+	ConfidencePct  int                 `json:"confidence_pct"`   // 0 to 100 for display
+	Level          string              `json:"level"`            // "very high", "high", "moderate", "low", "very low"
+	Method         CorrelationMethod   `json:"method"`           // Primary correlation method
+	Signals        []CorrelationSignal `json:"signals"`          // All contributing signals
+	TotalWeight    int                 `json:"total_weight"`     // Sum of signal weights
+	Summary        string              `json:"summary"`          // One-line summary
+	Recommendation string              `json:"recommendation"`   // Suggested action
+	Shares         []AttributionShare  `json:"shares,omitempty"` // Per-bead split when the commit is shared
 }
 
 // FeedbackType categorizes user/agent feedback on correlations
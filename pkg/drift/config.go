@@ -52,6 +52,9 @@ type Config struct {
 	// Per-label staleness overrides (bv-167)
 	// Labels can have tighter or looser thresholds than the default
 	LabelOverrides map[string]*LabelConfig `yaml:"label_overrides,omitempty" json:"label_overrides,omitempty"`
+
+	// Rules are user-defined policy checks; see PolicyRule
+	Rules []PolicyRule `yaml:"rules,omitempty" json:"rules,omitempty"`
 }
 
 // LabelConfig allows per-label threshold customization (bv-167)
@@ -216,6 +219,21 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("label %q: in_progress_stale_multiplier must be between 0 and 5", label)
 		}
 	}
+	// Validate policy rules
+	names := make(map[string]bool, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			if rule.Name != "" {
+				return fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %q: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+	}
 	return nil
 }
 
@@ -331,5 +349,29 @@ blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 #   low-priority:
 #     stale_warning_days: 30
 #     stale_critical_days: 60
+
+# Policy rules: expressions in the --where query language over issue
+# fields and graph metrics. A rule applies to issues matching "where" that
+# carry one of "labels" (omit both for every issue). An issue violates it
+# when it fails "require", or when an unresolved blocker matches
+# "blocked_by". Violations raise policy_violation alerts at the rule's
+# severity, so critical and warning rules gate --check-drift exit codes.
+# rules:
+#   - name: p0-bugs-unblocked-by-p3
+#     description: No P0 bug may be blocked by a P3
+#     severity: critical
+#     where: "type:bug priority:0 is:open"
+#     blocked_by: "priority:3"
+#   - name: epics-have-children
+#     description: Epics must have at least one child
+#     severity: warning
+#     where: "type:epic is:open"
+#     require: "children>=1"
+#   - name: in-progress-assigned
+#     description: No in_progress bead without an assignee
+#     severity: warning
+#     labels: [backend, api]
+#     where: "status:in_progress"
+#     require: "has:assignee"
 `
 }
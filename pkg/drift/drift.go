@@ -38,6 +38,7 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertPolicyViolation    AlertType = "policy_violation"
 )
 
 // Alert represents a single drift detection alert
@@ -56,6 +57,9 @@ type Alert struct {
 	// Blocking cascade specific fields (bv-165)
 	UnblocksCount         int `json:"unblocks_count,omitempty"`
 	DownstreamPrioritySum int `json:"downstream_priority_sum,omitempty"`
+
+	// Rule names the policy rule a policy_violation alert comes from
+	Rule string `json:"rule,omitempty"`
 }
 
 // Result contains the complete drift analysis
@@ -70,6 +74,9 @@ type Result struct {
 	CriticalCount int `json:"critical_count"`
 	WarningCount  int `json:"warning_count"`
 	InfoCount     int `json:"info_count"`
+
+	// Policies reports each configured policy rule, passing or not
+	Policies []PolicyResult `json:"policies,omitempty"`
 }
 
 // Calculator performs drift detection
@@ -78,6 +85,9 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+
+	policyIssues []model.Issue
+	policyStats  *analysis.GraphStats
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -98,6 +108,16 @@ func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
 }
 
+// SetPolicyInput attaches the issues policy rules are checked against, and
+// their graph stats for rules on graph metrics. Unlike SetIssues it leaves
+// the staleness and cascade checks off. Without it the rules use the issues
+// from SetIssues; stats may be nil and are then computed only when a rule
+// needs them.
+func (c *Calculator) SetPolicyInput(issues []model.Issue, stats *analysis.GraphStats) {
+	c.policyIssues = issues
+	c.policyStats = stats
+}
+
 // Calculate performs drift detection and returns results
 func (c *Calculator) Calculate() *Result {
 	result := &Result{
@@ -128,6 +148,9 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check user-defined policy rules from drift.yaml
+	c.checkPolicies(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -553,7 +576,11 @@ func cycleKey(cycle []string) string {
 // Summary returns a human-readable summary of drift results
 func (r *Result) Summary() string {
 	if !r.HasDrift {
-		return "No drift detected. Project metrics are within baseline thresholds.\n"
+		summary := "No drift detected. Project metrics are within baseline thresholds.\n"
+		if len(r.Policies) > 0 {
+			summary += fmt.Sprintf("All %d policy rule(s) passed.\n", len(r.Policies))
+		}
+		return summary
 	}
 
 	var sb strings.Builder
@@ -584,6 +611,17 @@ func (r *Result) Summary() string {
 			sb.WriteString(fmt.Sprintf("      - %s\n", detail))
 		}
 	}
+
+	if len(r.Policies) > 0 {
+		sb.WriteString("\nPolicies:\n")
+		for _, p := range r.Policies {
+			status := "✓ pass"
+			if !p.Passed {
+				status = fmt.Sprintf("✗ %d violation(s)", p.Violations)
+			}
+			sb.WriteString(fmt.Sprintf("  %s [%s] %s (%d checked)\n", status, p.Severity, p.Rule, p.Checked))
+		}
+	}
 	sb.WriteString("\n")
 
 	return sb.String()
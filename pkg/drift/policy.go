@@ -0,0 +1,214 @@
+package drift
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/query"
+)
+
+// PolicyRule is a user-defined check from the rules section of drift.yaml.
+// Its expressions use the --where query language, so they can reference
+// issue fields and graph metrics alike.
+//
+// The rule applies to the issues matching Where that carry one of Labels
+// (all issues when both are empty). Such an issue violates the rule when it
+// fails Require, or when one of its unresolved blockers matches BlockedBy.
+type PolicyRule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Severity    Severity `yaml:"severity" json:"severity"`
+	Labels      []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Where       string   `yaml:"where,omitempty" json:"where,omitempty"`
+	Require     string   `yaml:"require,omitempty" json:"require,omitempty"`
+	BlockedBy   string   `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+}
+
+// PolicyResult reports how one rule fared, so CI output shows passing
+// rules as well as the violations
+type PolicyResult struct {
+	Rule       string   `json:"rule"`
+	Severity   Severity `json:"severity"`
+	Checked    int      `json:"checked"`
+	Violations int      `json:"violations"`
+	Passed     bool     `json:"passed"`
+}
+
+// compiledRule holds a rule's parsed expressions; nil means not set
+type compiledRule struct {
+	*PolicyRule
+	where, require, blockedBy *query.Query
+}
+
+func (r *PolicyRule) compile() (*compiledRule, error) {
+	c := &compiledRule{PolicyRule: r}
+	for _, expr := range []struct {
+		key string
+		src string
+		dst **query.Query
+	}{
+		{"where", r.Where, &c.where},
+		{"require", r.Require, &c.require},
+		{"blocked_by", r.BlockedBy, &c.blockedBy},
+	} {
+		if strings.TrimSpace(expr.src) == "" {
+			continue
+		}
+		q, err := query.Parse(expr.src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", expr.key, err)
+		}
+		*expr.dst = q
+	}
+	return c, nil
+}
+
+func (c *compiledRule) usesGraphMetrics() bool {
+	for _, q := range []*query.Query{c.where, c.require, c.blockedBy} {
+		if q != nil && q.UsesGraphMetrics() {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks a rule's fields and that its expressions parse
+func (r *PolicyRule) validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch r.Severity {
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	case "":
+		return fmt.Errorf("severity is required (critical, warning or info)")
+	default:
+		return fmt.Errorf("unknown severity %q (want critical, warning or info)", r.Severity)
+	}
+	if strings.TrimSpace(r.Require) == "" && strings.TrimSpace(r.BlockedBy) == "" {
+		return fmt.Errorf("needs require or blocked_by")
+	}
+	_, err := r.compile()
+	return err
+}
+
+// scopeLabel returns the first of the issue's labels the rule is scoped to,
+// and whether the issue is in scope at all
+func (r *PolicyRule) scopeLabel(issue *model.Issue) (string, bool) {
+	if len(r.Labels) == 0 {
+		return "", true
+	}
+	for _, label := range issue.Labels {
+		if slices.Contains(r.Labels, label) {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// checkPolicies evaluates the configured policy rules against the attached
+// issues, raising one alert per violating issue
+func (c *Calculator) checkPolicies(result *Result) {
+	// Check if alert type is disabled (bv-167)
+	if c.config.IsAlertDisabled(string(AlertPolicyViolation)) || len(c.config.Rules) == 0 {
+		return
+	}
+
+	issues := c.policyIssues
+	if issues == nil {
+		issues = c.issues
+	}
+	if len(issues) == 0 {
+		return
+	}
+
+	rules := make([]*compiledRule, 0, len(c.config.Rules))
+	needStats := false
+	for i := range c.config.Rules {
+		rule, err := c.config.Rules[i].compile()
+		if err != nil {
+			// LoadConfig rejects these; a config built in code may not have been validated
+			continue
+		}
+		rules = append(rules, rule)
+		needStats = needStats || rule.usesGraphMetrics()
+	}
+
+	stats := c.policyStats
+	if stats == nil && needStats {
+		s := analysis.NewAnalyzer(issues).Analyze()
+		stats = &s
+	}
+	now := time.Now().UTC()
+	env := query.NewEnv(issues, stats, now)
+
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	for _, rule := range rules {
+		res := PolicyResult{Rule: rule.Name, Severity: rule.Severity}
+		for i := range issues {
+			issue := &issues[i]
+			label, ok := rule.scopeLabel(issue)
+			if !ok || (rule.where != nil && !rule.where.Match(issue, env)) {
+				continue
+			}
+			res.Checked++
+
+			var details []string
+			if rule.require != nil && !rule.require.Match(issue, env) {
+				details = append(details, "fails require: "+rule.Require)
+			}
+			if rule.blockedBy != nil {
+				for _, blocker := range openBlockers(issue, byID) {
+					if rule.blockedBy.Match(blocker, env) {
+						details = append(details, fmt.Sprintf("blocked by %s (P%d %s)", blocker.ID, blocker.Priority, blocker.Status))
+					}
+				}
+			}
+			if len(details) == 0 {
+				continue
+			}
+
+			res.Violations++
+			message := fmt.Sprintf("%s violates policy %s", issue.ID, rule.Name)
+			if rule.Description != "" {
+				message += ": " + rule.Description
+			}
+			result.Alerts = append(result.Alerts, Alert{
+				Type:       AlertPolicyViolation,
+				Severity:   rule.Severity,
+				Message:    message,
+				Details:    details,
+				IssueID:    issue.ID,
+				Label:      label,
+				Rule:       rule.Name,
+				DetectedAt: now,
+			})
+		}
+		res.Passed = res.Violations == 0
+		result.Policies = append(result.Policies, res)
+	}
+}
+
+// openBlockers returns the unresolved issues blocking issue, in ID order.
+// Dependencies on issues outside the set are ignored.
+func openBlockers(issue *model.Issue, byID map[string]*model.Issue) []*model.Issue {
+	var blockers []*model.Issue
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.Class().IsResolved() {
+			blockers = append(blockers, blocker)
+		}
+	}
+	sort.Slice(blockers, func(i, j int) bool { return blockers[i].ID < blockers[j].ID })
+	return blockers
+}
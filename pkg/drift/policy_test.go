@@ -0,0 +1,188 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func policyIssues() []model.Issue {
+	old := time.Now().AddDate(0, 0, -90)
+	dep := func(on string, typ model.DependencyType) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: typ}}
+	}
+	return []model.Issue{
+		{ID: "bug-1", IssueType: model.TypeBug, Priority: 0, Status: model.StatusOpen, UpdatedAt: old, Dependencies: dep("chore-1", model.DepBlocks)},
+		{ID: "bug-2", IssueType: model.TypeBug, Priority: 0, Status: model.StatusOpen, UpdatedAt: old, Dependencies: dep("chore-2", model.DepBlocks)},
+		{ID: "chore-1", IssueType: model.TypeChore, Priority: 3, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "chore-2", IssueType: model.TypeChore, Priority: 3, Status: model.StatusClosed, UpdatedAt: old},
+		{ID: "epic-1", IssueType: model.TypeEpic, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "epic-2", IssueType: model.TypeEpic, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "task-1", IssueType: model.TypeTask, Status: model.StatusInProgress, Labels: []string{"backend"}, UpdatedAt: old, Dependencies: dep("epic-2", model.DepParentChild)},
+		{ID: "task-2", IssueType: model.TypeTask, Status: model.StatusInProgress, Labels: []string{"frontend"}, UpdatedAt: old},
+	}
+}
+
+func policyConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Rules = []PolicyRule{
+		{Name: "p0-bugs-unblocked-by-p3", Description: "No P0 bug may be blocked by a P3", Severity: SeverityCritical,
+			Where: "type:bug priority:0", BlockedBy: "priority:3"},
+		{Name: "epics-have-children", Severity: SeverityWarning, Where: "type:epic is:open", Require: "children>=1"},
+		{Name: "in-progress-assigned", Severity: SeverityInfo, Labels: []string{"backend", "api"},
+			Where: "status:in_progress", Require: "has:assignee"},
+	}
+	return cfg
+}
+
+func TestPolicyRules(t *testing.T) {
+	bl := &baseline.Baseline{}
+	calc := NewCalculator(bl, bl, policyConfig())
+	calc.SetPolicyInput(policyIssues(), nil)
+	result := calc.Calculate()
+
+	var got []string
+	for _, a := range result.Alerts {
+		if a.Type != AlertPolicyViolation {
+			t.Errorf("unexpected %s alert: policy input must not enable staleness checks", a.Type)
+			continue
+		}
+		got = append(got, a.Rule+":"+a.IssueID+":"+string(a.Severity)+":"+a.Label)
+	}
+	want := []string{
+		"p0-bugs-unblocked-by-p3:bug-1:critical:", // chore-2 is closed, so bug-2 is fine
+		"epics-have-children:epic-1:warning:",
+		"in-progress-assigned:task-1:info:backend", // task-2 is outside the label scope
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	if a := result.Alerts[0]; !strings.Contains(a.Message, "No P0 bug may be blocked by a P3") || !slices.Equal(a.Details, []string{"blocked by chore-1 (P3 open)"}) {
+		t.Errorf("alert = %+v", a)
+	}
+
+	if result.CriticalCount != 1 || result.WarningCount != 1 || result.InfoCount != 1 || result.ExitCode() != 1 {
+		t.Errorf("counts = %d/%d/%d exit %d", result.CriticalCount, result.WarningCount, result.InfoCount, result.ExitCode())
+	}
+	wantPolicies := []PolicyResult{
+		{Rule: "p0-bugs-unblocked-by-p3", Severity: SeverityCritical, Checked: 2, Violations: 1},
+		{Rule: "epics-have-children", Severity: SeverityWarning, Checked: 2, Violations: 1},
+		{Rule: "in-progress-assigned", Severity: SeverityInfo, Checked: 1, Violations: 1},
+	}
+	if !slices.Equal(result.Policies, wantPolicies) {
+		t.Errorf("policies = %+v", result.Policies)
+	}
+	if summary := result.Summary(); !strings.Contains(summary, "✗ 1 violation(s) [critical] p0-bugs-unblocked-by-p3 (2 checked)") {
+		t.Errorf("summary missing policy line:\n%s", summary)
+	}
+}
+
+func TestPolicyRulesPassing(t *testing.T) {
+	cfg := policyConfig()
+	cfg.Rules = cfg.Rules[2:]
+	cfg.Rules[0].Labels = []string{"frontend"}
+	cfg.Rules[0].Require = "is:active"
+
+	bl := &baseline.Baseline{}
+	calc := NewCalculator(bl, bl, cfg)
+	calc.SetPolicyInput(policyIssues(), nil)
+	result := calc.Calculate()
+	if result.HasDrift || result.ExitCode() != 0 || len(result.Policies) != 1 || !result.Policies[0].Passed {
+		t.Fatalf("result = %+v", result)
+	}
+	if summary := result.Summary(); !strings.Contains(summary, "All 1 policy rule(s) passed") {
+		t.Errorf("summary = %q", summary)
+	}
+
+	// Disabling the alert type turns all rules off
+	cfg.DisabledAlerts = []string{string(AlertPolicyViolation)}
+	cfg.Rules[0].Require = "has:assignee"
+	if result := calc.Calculate(); len(result.Policies) != 0 || result.HasDrift {
+		t.Errorf("disabled policies still ran: %+v", result)
+	}
+}
+
+func TestPolicyRulesUseSetIssues(t *testing.T) {
+	cfg := policyConfig()
+	cfg.DisabledAlerts = []string{string(AlertStaleIssue), string(AlertBlockingCascade)}
+	bl := &baseline.Baseline{}
+	calc := NewCalculator(bl, bl, cfg)
+	calc.SetIssues(policyIssues())
+	if result := calc.Calculate(); len(result.Alerts) != 3 {
+		t.Errorf("expected policy alerts from SetIssues, got %+v", result.Alerts)
+	}
+}
+
+func TestPolicyRuleValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		rule PolicyRule
+		want string
+	}{
+		{"missing name", PolicyRule{Severity: SeverityWarning, Require: "has:assignee"}, "rule 1: name is required"},
+		{"missing severity", PolicyRule{Name: "r", Require: "has:assignee"}, "severity is required"},
+		{"bad severity", PolicyRule{Name: "r", Severity: "fatal", Require: "has:assignee"}, `unknown severity "fatal"`},
+		{"no check", PolicyRule{Name: "r", Severity: SeverityInfo, Where: "type:epic"}, "needs require or blocked_by"},
+		{"bad expression", PolicyRule{Name: "r", Severity: SeverityInfo, Require: "childs>=1"}, `rule "r": require: invalid query`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Rules = []PolicyRule{tt.rule}
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.Rules = []PolicyRule{
+		{Name: "r", Severity: SeverityInfo, Require: "has:assignee"},
+		{Name: "r", Severity: SeverityInfo, Require: "has:labels"},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate name") {
+		t.Errorf("Validate() = %v, want duplicate name error", err)
+	}
+}
+
+func TestLoadConfigRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `rules:
+  - name: epics-have-children
+    description: Epics must have at least one child
+    severity: warning
+    labels: [platform]
+    where: "type:epic is:open"
+    require: "children>=1"
+`
+	if err := os.WriteFile(ConfigPath(tmpDir), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	want := PolicyRule{Name: "epics-have-children", Description: "Epics must have at least one child", Severity: SeverityWarning,
+		Labels: []string{"platform"}, Where: "type:epic is:open", Require: "children>=1"}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Name != want.Name || cfg.Rules[0].Require != want.Require || !slices.Equal(cfg.Rules[0].Labels, want.Labels) {
+		t.Errorf("rules = %+v", cfg.Rules)
+	}
+
+	if err := os.WriteFile(ConfigPath(tmpDir), []byte(strings.Replace(content, "children>=1", "children>=", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(tmpDir); err == nil || !strings.Contains(err.Error(), `rule "epics-have-children"`) {
+		t.Errorf("LoadConfig() error = %v, want rule error", err)
+	}
+}
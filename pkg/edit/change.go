@@ -0,0 +1,254 @@
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ErrNoChange is returned by the change constructors when the requested value
+// already matches the issue, so there is nothing to write.
+var ErrNoChange = errors.New("no change")
+
+// Kind identifies which field a Change touches.
+type Kind string
+
+const (
+	KindStatus           Kind = "status"
+	KindPriority         Kind = "priority"
+	KindAssignee         Kind = "assignee"
+	KindAddLabel         Kind = "add_label"
+	KindRemoveLabel      Kind = "remove_label"
+	KindAddDependency    Kind = "add_dependency"
+	KindRemoveDependency Kind = "remove_dependency"
+)
+
+// Change is a single field-level edit to one issue.
+//
+// For scalar kinds (status, priority, assignee) Old and New hold the previous
+// and desired values. For label kinds Value is the label. For dependency kinds
+// Value is the depends-on issue ID and DepType the dependency type.
+type Change struct {
+	IssueID string               `json:"issue_id"`
+	Kind    Kind                 `json:"kind"`
+	Old     string               `json:"old,omitempty"`
+	New     string               `json:"new,omitempty"`
+	Value   string               `json:"value,omitempty"`
+	DepType model.DependencyType `json:"dep_type,omitempty"`
+}
+
+// SetStatus builds a status change for issue.
+func SetStatus(issue model.Issue, status model.Status) (Change, error) {
+	if !status.IsValid() {
+		return Change{}, fmt.Errorf("invalid status: %q", status)
+	}
+	if issue.Status == status {
+		return Change{}, ErrNoChange
+	}
+	if !model.CanTransition(issue.Status, status) {
+		return Change{}, fmt.Errorf("workflow does not allow %s -> %s", issue.Status, status)
+	}
+	return Change{IssueID: issue.ID, Kind: KindStatus, Old: string(issue.Status), New: string(status)}, nil
+}
+
+// SetPriority builds a priority change for issue. Priorities range 0 (P0) to 4.
+func SetPriority(issue model.Issue, priority int) (Change, error) {
+	if priority < 0 || priority > 4 {
+		return Change{}, fmt.Errorf("priority must be between 0 and 4, got %d", priority)
+	}
+	if issue.Priority == priority {
+		return Change{}, ErrNoChange
+	}
+	return Change{IssueID: issue.ID, Kind: KindPriority, Old: strconv.Itoa(issue.Priority), New: strconv.Itoa(priority)}, nil
+}
+
+// SetAssignee builds an assignee change for issue. An empty assignee unassigns.
+func SetAssignee(issue model.Issue, assignee string) (Change, error) {
+	assignee = strings.TrimSpace(assignee)
+	if issue.Assignee == assignee {
+		return Change{}, ErrNoChange
+	}
+	return Change{IssueID: issue.ID, Kind: KindAssignee, Old: issue.Assignee, New: assignee}, nil
+}
+
+// AddLabel builds a change adding label to issue.
+func AddLabel(issue model.Issue, label string) (Change, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return Change{}, errors.New("label cannot be empty")
+	}
+	if strings.ContainsAny(label, " \t,") {
+		return Change{}, fmt.Errorf("label %q must not contain whitespace or commas", label)
+	}
+	if hasLabel(issue, label) {
+		return Change{}, ErrNoChange
+	}
+	return Change{IssueID: issue.ID, Kind: KindAddLabel, Value: label}, nil
+}
+
+// RemoveLabel builds a change removing label from issue.
+func RemoveLabel(issue model.Issue, label string) (Change, error) {
+	if !hasLabel(issue, label) {
+		return Change{}, fmt.Errorf("%s has no label %q", issue.ID, label)
+	}
+	return Change{IssueID: issue.ID, Kind: KindRemoveLabel, Value: label}, nil
+}
+
+// AddDependency builds a change making issue depend on dependsOn. known is
+// used to reject references to issues that do not exist; pass nil to skip
+// that check. An empty depType defaults to blocks.
+func AddDependency(issue model.Issue, dependsOn string, depType model.DependencyType, known map[string]bool) (Change, error) {
+	dependsOn = strings.TrimSpace(dependsOn)
+	if dependsOn == "" {
+		return Change{}, errors.New("dependency ID cannot be empty")
+	}
+	if dependsOn == issue.ID {
+		return Change{}, errors.New("an issue cannot depend on itself")
+	}
+	if known != nil && !known[dependsOn] {
+		return Change{}, fmt.Errorf("unknown issue %q", dependsOn)
+	}
+	if depType == "" {
+		depType = model.DepBlocks
+	}
+	if !depType.IsValid() {
+		return Change{}, fmt.Errorf("invalid dependency type: %q", depType)
+	}
+	if findDependency(issue, dependsOn) != nil {
+		return Change{}, ErrNoChange
+	}
+	return Change{IssueID: issue.ID, Kind: KindAddDependency, Value: dependsOn, DepType: depType}, nil
+}
+
+// RemoveDependency builds a change removing the dependency of issue on dependsOn.
+func RemoveDependency(issue model.Issue, dependsOn string) (Change, error) {
+	dep := findDependency(issue, dependsOn)
+	if dep == nil {
+		return Change{}, fmt.Errorf("%s does not depend on %s", issue.ID, dependsOn)
+	}
+	depType := dep.Type
+	if depType == "" {
+		depType = model.DepBlocks
+	}
+	return Change{IssueID: issue.ID, Kind: KindRemoveDependency, Value: dependsOn, DepType: depType}, nil
+}
+
+// Inverse returns the change that undoes c. Undoing a status change is a
+// transition of its own, so it fails when the workflow does not allow it.
+func (c Change) Inverse() (Change, error) {
+	inv := c
+	switch c.Kind {
+	case KindStatus:
+		if !model.CanTransition(model.Status(c.New), model.Status(c.Old)) {
+			return Change{}, fmt.Errorf("workflow does not allow %s -> %s", c.New, c.Old)
+		}
+		inv.Old, inv.New = c.New, c.Old
+	case KindPriority, KindAssignee:
+		inv.Old, inv.New = c.New, c.Old
+	case KindAddLabel:
+		inv.Kind = KindRemoveLabel
+	case KindRemoveLabel:
+		inv.Kind = KindAddLabel
+	case KindAddDependency:
+		inv.Kind = KindRemoveDependency
+	case KindRemoveDependency:
+		inv.Kind = KindAddDependency
+	}
+	return inv, nil
+}
+
+// ApplyTo applies c to an in-memory issue. It is used for previews and
+// tests; writers persist changes through their own backends.
+func (c Change) ApplyTo(issue *model.Issue) error {
+	if issue.ID != c.IssueID {
+		return fmt.Errorf("change targets %s, not %s", c.IssueID, issue.ID)
+	}
+	switch c.Kind {
+	case KindStatus:
+		issue.Status = model.Status(c.New)
+	case KindPriority:
+		p, err := strconv.Atoi(c.New)
+		if err != nil {
+			return fmt.Errorf("invalid priority %q: %w", c.New, err)
+		}
+		issue.Priority = p
+	case KindAssignee:
+		issue.Assignee = c.New
+	case KindAddLabel:
+		if !hasLabel(*issue, c.Value) {
+			issue.Labels = append(append([]string(nil), issue.Labels...), c.Value)
+		}
+	case KindRemoveLabel:
+		labels := make([]string, 0, len(issue.Labels))
+		for _, l := range issue.Labels {
+			if l != c.Value {
+				labels = append(labels, l)
+			}
+		}
+		issue.Labels = labels
+	case KindAddDependency:
+		if findDependency(*issue, c.Value) == nil {
+			deps := append([]*model.Dependency(nil), issue.Dependencies...)
+			issue.Dependencies = append(deps, &model.Dependency{IssueID: issue.ID, DependsOnID: c.Value, Type: c.DepType})
+		}
+	case KindRemoveDependency:
+		deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+		for _, d := range issue.Dependencies {
+			if d != nil && d.DependsOnID != c.Value {
+				deps = append(deps, d)
+			}
+		}
+		issue.Dependencies = deps
+	default:
+		return fmt.Errorf("unknown change kind %q", c.Kind)
+	}
+	return nil
+}
+
+// String returns a one-line, human-readable summary of the change.
+func (c Change) String() string {
+	switch c.Kind {
+	case KindStatus, KindPriority, KindAssignee:
+		return fmt.Sprintf("%s: %s %s → %s", c.IssueID, c.Kind, displayValue(c.Kind, c.Old), displayValue(c.Kind, c.New))
+	case KindAddLabel:
+		return fmt.Sprintf("%s: add label %s", c.IssueID, c.Value)
+	case KindRemoveLabel:
+		return fmt.Sprintf("%s: remove label %s", c.IssueID, c.Value)
+	case KindAddDependency:
+		return fmt.Sprintf("%s: depend on %s (%s)", c.IssueID, c.Value, c.DepType)
+	case KindRemoveDependency:
+		return fmt.Sprintf("%s: drop dependency on %s", c.IssueID, c.Value)
+	}
+	return fmt.Sprintf("%s: %s", c.IssueID, c.Kind)
+}
+
+func displayValue(kind Kind, v string) string {
+	switch {
+	case kind == KindPriority:
+		return "P" + v
+	case v == "":
+		return "(none)"
+	}
+	return v
+}
+
+func hasLabel(issue model.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func findDependency(issue model.Issue, dependsOn string) *model.Dependency {
+	for _, d := range issue.Dependencies {
+		if d != nil && d.DependsOnID == dependsOn {
+			return d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package edit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func sampleIssue() model.Issue {
+	return model.Issue{
+		ID:        "bv-1",
+		Title:     "Sample",
+		Status:    model.StatusOpen,
+		Priority:  2,
+		IssueType: model.TypeTask,
+		Assignee:  "alice",
+		Labels:    []string{"api", "backend"},
+		Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepBlocks},
+		},
+	}
+}
+
+func TestConstructorsValidate(t *testing.T) {
+	issue := sampleIssue()
+	known := map[string]bool{"bv-1": true, "bv-2": true, "bv-3": true}
+
+	if _, err := SetStatus(issue, model.StatusOpen); !errors.Is(err, ErrNoChange) {
+		t.Errorf("SetStatus to same value: got %v, want ErrNoChange", err)
+	}
+	if _, err := SetStatus(issue, "bogus"); err == nil {
+		t.Error("expected error for invalid status")
+	}
+	if _, err := SetPriority(issue, 7); err == nil {
+		t.Error("expected error for out-of-range priority")
+	}
+	if _, err := SetAssignee(issue, " alice "); !errors.Is(err, ErrNoChange) {
+		t.Errorf("SetAssignee to same value: got %v, want ErrNoChange", err)
+	}
+	if _, err := AddLabel(issue, "two words"); err == nil {
+		t.Error("expected error for label with whitespace")
+	}
+	if _, err := AddLabel(issue, "api"); !errors.Is(err, ErrNoChange) {
+		t.Errorf("AddLabel existing: got %v, want ErrNoChange", err)
+	}
+	if _, err := RemoveLabel(issue, "ui"); err == nil {
+		t.Error("expected error removing absent label")
+	}
+	if _, err := AddDependency(issue, "bv-1", "", known); err == nil {
+		t.Error("expected error for self-dependency")
+	}
+	if _, err := AddDependency(issue, "bv-9", "", known); err == nil {
+		t.Error("expected error for unknown dependency target")
+	}
+	if _, err := AddDependency(issue, "bv-2", "", known); !errors.Is(err, ErrNoChange) {
+		t.Errorf("AddDependency existing: got %v, want ErrNoChange", err)
+	}
+	if _, err := RemoveDependency(issue, "bv-3"); err == nil {
+		t.Error("expected error removing absent dependency")
+	}
+
+	c, err := AddDependency(issue, "bv-3", "", known)
+	if err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if c.DepType != model.DepBlocks {
+		t.Errorf("default dep type = %q, want blocks", c.DepType)
+	}
+}
+
+func TestSetStatus_WorkflowTransitions(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{
+		Classes:     map[model.Status]model.StatusClass{"qa": model.ClassActive},
+		Transitions: map[model.Status][]model.Status{model.StatusOpen: {model.StatusInProgress, "qa"}},
+	})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issue := sampleIssue()
+	c, err := SetStatus(issue, "qa")
+	if err != nil || c.New != "qa" {
+		t.Fatalf("SetStatus(qa) = %+v, %v", c, err)
+	}
+	if _, err := SetStatus(issue, model.StatusClosed); err == nil || !strings.Contains(err.Error(), "open -> closed") {
+		t.Errorf("SetStatus(closed) error = %v, want transition error", err)
+	}
+}
+
+func TestInverse_RejectsForbiddenReverseTransition(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{
+		Transitions: map[model.Status][]model.Status{
+			model.StatusOpen:   {model.StatusClosed},
+			model.StatusClosed: {model.StatusInProgress},
+		},
+	})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	c, err := SetStatus(sampleIssue(), model.StatusClosed)
+	if err != nil {
+		t.Fatalf("SetStatus(closed): %v", err)
+	}
+	if _, err := c.Inverse(); err == nil || !strings.Contains(err.Error(), "closed -> open") {
+		t.Errorf("Inverse error = %v, want the reverse transition rejected", err)
+	}
+}
+
+func TestInverseRoundTrip(t *testing.T) {
+	issue := sampleIssue()
+	build := []func() (Change, error){
+		func() (Change, error) { return SetStatus(issue, model.StatusInProgress) },
+		func() (Change, error) { return SetPriority(issue, 0) },
+		func() (Change, error) { return SetAssignee(issue, "") },
+		func() (Change, error) { return AddLabel(issue, "urgent") },
+		func() (Change, error) { return RemoveLabel(issue, "api") },
+		func() (Change, error) { return AddDependency(issue, "bv-3", model.DepRelated, nil) },
+		func() (Change, error) { return RemoveDependency(issue, "bv-2") },
+	}
+
+	for _, b := range build {
+		c, err := b()
+		if err != nil {
+			t.Fatalf("building change: %v", err)
+		}
+		edited := issue.Clone()
+		if err := c.ApplyTo(&edited); err != nil {
+			t.Fatalf("%s: ApplyTo failed: %v", c, err)
+		}
+		inv, err := c.Inverse()
+		if err != nil {
+			t.Fatalf("%s: Inverse failed: %v", c, err)
+		}
+		if err := inv.ApplyTo(&edited); err != nil {
+			t.Fatalf("%s: inverse ApplyTo failed: %v", c, err)
+		}
+		if edited.Status != issue.Status || edited.Priority != issue.Priority || edited.Assignee != issue.Assignee {
+			t.Errorf("%s: scalar fields not restored: %+v", c, edited)
+		}
+		if len(edited.Labels) != len(issue.Labels) {
+			t.Errorf("%s: labels not restored: %v", c, edited.Labels)
+		}
+		if len(edited.Dependencies) != len(issue.Dependencies) {
+			t.Errorf("%s: dependencies not restored: %d", c, len(edited.Dependencies))
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	issue := sampleIssue()
+	c, err := SetStatus(issue, model.StatusClosed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Preview(issue, c, NewCLIWriter(""))
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	for _, want := range []string{
+		"--- bv-1 (current)",
+		"+++ bv-1 (edited)",
+		"- status: open",
+		"+ status: closed",
+		"via bd: bd update bv-1 --status closed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q:\n%s", want, out)
+		}
+	}
+
+	c, _ = AddLabel(issue, "urgent")
+	lines, err := Diff(issue, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[1].Text != "labels: api, backend, urgent" {
+		t.Errorf("unexpected label diff: %+v", lines)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(2)
+	if _, ok := h.Pop(); ok {
+		t.Fatal("expected empty history")
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		h.Push(Change{IssueID: id, Kind: KindStatus})
+	}
+	if h.Len() != 2 {
+		t.Fatalf("Len = %d, want 2 (bounded)", h.Len())
+	}
+	if c, _ := h.Pop(); c.IssueID != "c" {
+		t.Errorf("Pop = %s, want c", c.IssueID)
+	}
+	if c, _ := h.Pop(); c.IssueID != "b" {
+		t.Errorf("Pop = %s, want b", c.IssueID)
+	}
+	if h.Len() != 0 {
+		t.Errorf("expected oldest entry to have been evicted")
+	}
+}
@@ -0,0 +1,28 @@
+// Package edit implements write-back support for beads: small, reversible
+// changes (status, priority, assignee, labels, dependencies) that the TUI can
+// apply to the underlying issue store.
+//
+// # Changes
+//
+// A Change is built against the current state of an issue with one of the
+// constructors (SetStatus, SetPriority, SetAssignee, AddLabel, RemoveLabel,
+// AddDependency, RemoveDependency). Constructors validate the edit and record
+// the previous value so every change has an exact Inverse, which powers undo.
+//
+// # Writers
+//
+// Changes are persisted through the Writer interface:
+//
+//	CLIWriter   - shells out to `bd update` / `bd dep add` / `bd dep remove`
+//	JSONLWriter - rewrites the matching line of the beads JSONL file in place
+//
+// DetectWriter picks the CLI writer when `bd` is on PATH and falls back to the
+// JSONL writer otherwise. BV_EDIT_WRITER=bd|jsonl overrides the detection.
+//
+// # Preview and Undo
+//
+// Preview renders a unified-diff style summary of a change (plus the command
+// the writer will run) so the user can confirm before anything is written.
+// History is a bounded stack of applied changes; popping it yields the
+// inverse change to apply.
+package edit
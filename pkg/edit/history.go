@@ -0,0 +1,50 @@
+package edit
+
+// DefaultHistoryLimit bounds the undo stack when no explicit limit is given.
+const DefaultHistoryLimit = 50
+
+// History is a bounded LIFO stack of applied changes used for undo.
+// It is not safe for concurrent use; the TUI owns it from its update loop.
+type History struct {
+	limit   int
+	changes []Change
+}
+
+// NewHistory creates an undo stack holding at most limit changes.
+// A non-positive limit uses DefaultHistoryLimit.
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{limit: limit}
+}
+
+// Push records an applied change, evicting the oldest entry when full.
+func (h *History) Push(c Change) {
+	h.changes = append(h.changes, c)
+	if len(h.changes) > h.limit {
+		h.changes = append([]Change(nil), h.changes[len(h.changes)-h.limit:]...)
+	}
+}
+
+// Peek returns the most recent change without removing it.
+func (h *History) Peek() (Change, bool) {
+	if len(h.changes) == 0 {
+		return Change{}, false
+	}
+	return h.changes[len(h.changes)-1], true
+}
+
+// Pop removes and returns the most recent change.
+func (h *History) Pop() (Change, bool) {
+	c, ok := h.Peek()
+	if ok {
+		h.changes = h.changes[:len(h.changes)-1]
+	}
+	return c, ok
+}
+
+// Len returns the number of undoable changes.
+func (h *History) Len() int {
+	return len(h.changes)
+}
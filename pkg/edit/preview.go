@@ -0,0 +1,77 @@
+package edit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DiffLine is one line of a change preview. Op is '-' for removed state,
+// '+' for added state and ' ' for unchanged context.
+type DiffLine struct {
+	Op   byte
+	Text string
+}
+
+// Diff computes the before/after lines for applying c to issue.
+func Diff(issue model.Issue, c Change) ([]DiffLine, error) {
+	after := issue.Clone()
+	if err := c.ApplyTo(&after); err != nil {
+		return nil, err
+	}
+
+	var lines []DiffLine
+	field := func(name, before, afterVal string) {
+		if before == afterVal {
+			return
+		}
+		lines = append(lines, DiffLine{Op: '-', Text: name + ": " + before})
+		lines = append(lines, DiffLine{Op: '+', Text: name + ": " + afterVal})
+	}
+
+	switch c.Kind {
+	case KindStatus:
+		field("status", string(issue.Status), string(after.Status))
+	case KindPriority:
+		field("priority", fmt.Sprintf("P%d", issue.Priority), fmt.Sprintf("P%d", after.Priority))
+	case KindAssignee:
+		field("assignee", displayValue(KindAssignee, issue.Assignee), displayValue(KindAssignee, after.Assignee))
+	case KindAddLabel, KindRemoveLabel:
+		field("labels", formatLabels(issue.Labels), formatLabels(after.Labels))
+	case KindAddDependency:
+		lines = append(lines, DiffLine{Op: '+', Text: fmt.Sprintf("depends on: %s (%s)", c.Value, c.DepType)})
+	case KindRemoveDependency:
+		lines = append(lines, DiffLine{Op: '-', Text: fmt.Sprintf("depends on: %s (%s)", c.Value, c.DepType)})
+	}
+	return lines, nil
+}
+
+// Preview renders a plain-text diff of c against issue, followed by the
+// action the writer will perform.
+func Preview(issue model.Issue, c Change, w Writer) (string, error) {
+	lines, err := Diff(issue, c)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s (current)\n", issue.ID)
+	fmt.Fprintf(&sb, "+++ %s (edited)\n", issue.ID)
+	for _, l := range lines {
+		sb.WriteByte(l.Op)
+		sb.WriteByte(' ')
+		sb.WriteString(l.Text)
+		sb.WriteByte('\n')
+	}
+	if w != nil {
+		fmt.Fprintf(&sb, "via %s: %s\n", w.Name(), w.Describe(c))
+	}
+	return sb.String(), nil
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return "(none)"
+	}
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,408 @@
+package edit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Writer persists changes to an issue store.
+type Writer interface {
+	// Name identifies the backend (e.g. "bd", "jsonl").
+	Name() string
+	// Describe returns a human-readable description of what Apply will do.
+	Describe(c Change) string
+	// Apply persists the change.
+	Apply(ctx context.Context, c Change) error
+}
+
+// DefaultCLITimeout bounds a single bd invocation.
+const DefaultCLITimeout = 10 * time.Second
+
+// CLIWriter applies changes by shelling out to the bd CLI.
+type CLIWriter struct {
+	Binary  string        // Executable to run (default "bd")
+	Dir     string        // Working directory (the project root)
+	Timeout time.Duration // Per-command timeout (default DefaultCLITimeout)
+}
+
+// NewCLIWriter creates a bd-backed writer running in dir.
+func NewCLIWriter(dir string) *CLIWriter {
+	return &CLIWriter{Binary: "bd", Dir: dir, Timeout: DefaultCLITimeout}
+}
+
+// Name implements Writer.
+func (w *CLIWriter) Name() string {
+	return filepath.Base(w.binary())
+}
+
+// Args returns the bd arguments that apply c.
+func (w *CLIWriter) Args(c Change) ([]string, error) {
+	switch c.Kind {
+	case KindStatus:
+		return []string{"update", c.IssueID, "--status", c.New}, nil
+	case KindPriority:
+		return []string{"update", c.IssueID, "--priority", c.New}, nil
+	case KindAssignee:
+		return []string{"update", c.IssueID, "--assignee", c.New}, nil
+	case KindAddLabel:
+		return []string{"update", c.IssueID, "--add-label", c.Value}, nil
+	case KindRemoveLabel:
+		return []string{"update", c.IssueID, "--remove-label", c.Value}, nil
+	case KindAddDependency:
+		depType := c.DepType
+		if depType == "" {
+			depType = model.DepBlocks
+		}
+		return []string{"dep", "add", c.IssueID, c.Value, "--type", string(depType)}, nil
+	case KindRemoveDependency:
+		return []string{"dep", "remove", c.IssueID, c.Value}, nil
+	}
+	return nil, fmt.Errorf("unknown change kind %q", c.Kind)
+}
+
+// Describe implements Writer.
+func (w *CLIWriter) Describe(c Change) string {
+	args, err := w.Args(c)
+	if err != nil {
+		return err.Error()
+	}
+	parts := []string{w.Name()}
+	for _, a := range args {
+		parts = append(parts, shellQuote(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Apply implements Writer.
+func (w *CLIWriter) Apply(ctx context.Context, c Change) error {
+	args, err := w.Args(c)
+	if err != nil {
+		return err
+	}
+	timeout := w.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCLITimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, w.binary(), args...)
+	cmd.Dir = w.Dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s timed out after %v", w.Describe(c), timeout)
+		}
+		msg := strings.TrimSpace(out.String())
+		if msg == "" {
+			return fmt.Errorf("%s: %w", w.Describe(c), err)
+		}
+		return fmt.Errorf("%s: %w: %s", w.Describe(c), err, msg)
+	}
+	return nil
+}
+
+func (w *CLIWriter) binary() string {
+	if w.Binary == "" {
+		return "bd"
+	}
+	return w.Binary
+}
+
+func shellQuote(s string) string {
+	if s == "" {
+		return `""`
+	}
+	if strings.ContainsAny(s, " \t\"'$\\") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// JSONLWriter applies changes by rewriting the matching issue line in a beads
+// JSONL file. Other lines are left byte-for-byte intact, and unknown fields on
+// the edited issue are preserved. The file is replaced atomically.
+type JSONLWriter struct {
+	Path  string           // Beads JSONL file to edit
+	Actor string           // Recorded as created_by on new dependencies (default "bv")
+	Now   func() time.Time // Clock for updated_at stamps (default time.Now)
+
+	mu sync.Mutex
+}
+
+// NewJSONLWriter creates a writer that edits path directly.
+func NewJSONLWriter(path string) *JSONLWriter {
+	return &JSONLWriter{Path: path}
+}
+
+// Name implements Writer.
+func (w *JSONLWriter) Name() string {
+	return "jsonl"
+}
+
+// Describe implements Writer.
+func (w *JSONLWriter) Describe(c Change) string {
+	return fmt.Sprintf("rewrite %s in %s", c.IssueID, filepath.Base(w.Path))
+}
+
+// Apply implements Writer.
+func (w *JSONLWriter) Apply(ctx context.Context, c Change) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", w.Path, err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	found := false
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(trimmed, &header); err != nil || header.ID != c.IssueID {
+			continue
+		}
+		updated, err := w.applyToLine(trimmed, c)
+		if err != nil {
+			return fmt.Errorf("editing %s: %w", c.IssueID, err)
+		}
+		lines[i] = updated
+		found = true
+		break
+	}
+	if !found {
+		return fmt.Errorf("issue %s not found in %s", c.IssueID, w.Path)
+	}
+
+	return writeFileAtomic(w.Path, bytes.Join(lines, []byte("\n")))
+}
+
+func (w *JSONLWriter) applyToLine(line []byte, c Change) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(line, &obj); err != nil {
+		return nil, err
+	}
+	now := w.now()
+
+	set := func(key string, v any) error {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		obj[key] = raw
+		return nil
+	}
+
+	switch c.Kind {
+	case KindStatus:
+		if err := set("status", c.New); err != nil {
+			return nil, err
+		}
+		if model.Status(c.New).IsClosed() {
+			if _, ok := obj["closed_at"]; !ok {
+				if err := set("closed_at", now); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			delete(obj, "closed_at")
+		}
+	case KindPriority:
+		p, err := strconv.Atoi(c.New)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %q: %w", c.New, err)
+		}
+		if err := set("priority", p); err != nil {
+			return nil, err
+		}
+	case KindAssignee:
+		if c.New == "" {
+			delete(obj, "assignee")
+		} else if err := set("assignee", c.New); err != nil {
+			return nil, err
+		}
+	case KindAddLabel, KindRemoveLabel:
+		var labels []string
+		if raw, ok := obj["labels"]; ok {
+			if err := json.Unmarshal(raw, &labels); err != nil {
+				return nil, fmt.Errorf("labels: %w", err)
+			}
+		}
+		next := make([]string, 0, len(labels)+1)
+		present := false
+		for _, l := range labels {
+			if l == c.Value {
+				present = true
+				if c.Kind == KindRemoveLabel {
+					continue
+				}
+			}
+			next = append(next, l)
+		}
+		if c.Kind == KindAddLabel && !present {
+			next = append(next, c.Value)
+		}
+		if len(next) == 0 {
+			delete(obj, "labels")
+		} else if err := set("labels", next); err != nil {
+			return nil, err
+		}
+	case KindAddDependency, KindRemoveDependency:
+		var deps []map[string]json.RawMessage
+		if raw, ok := obj["dependencies"]; ok {
+			if err := json.Unmarshal(raw, &deps); err != nil {
+				return nil, fmt.Errorf("dependencies: %w", err)
+			}
+		}
+		next := make([]map[string]json.RawMessage, 0, len(deps)+1)
+		present := false
+		for _, d := range deps {
+			var target string
+			_ = json.Unmarshal(d["depends_on_id"], &target)
+			if target == c.Value {
+				present = true
+				if c.Kind == KindRemoveDependency {
+					continue
+				}
+			}
+			next = append(next, d)
+		}
+		if c.Kind == KindAddDependency && !present {
+			depType := c.DepType
+			if depType == "" {
+				depType = model.DepBlocks
+			}
+			dep := model.Dependency{IssueID: c.IssueID, DependsOnID: c.Value, Type: depType, CreatedAt: now, CreatedBy: w.actor()}
+			raw, err := json.Marshal(dep)
+			if err != nil {
+				return nil, err
+			}
+			var entry map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return nil, err
+			}
+			next = append(next, entry)
+		}
+		if len(next) == 0 {
+			delete(obj, "dependencies")
+		} else if err := set("dependencies", next); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown change kind %q", c.Kind)
+	}
+
+	if err := set("updated_at", now); err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+func (w *JSONLWriter) now() time.Time {
+	if w.Now != nil {
+		return w.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (w *JSONLWriter) actor() string {
+	if w.Actor == "" {
+		return "bv"
+	}
+	return w.Actor
+}
+
+// writeFileAtomic replaces path with data via a temp file and rename,
+// keeping the original file mode.
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	cleanup := func() { _ = os.Remove(tmpName) }
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, mode); err != nil {
+		cleanup()
+		return fmt.Errorf("setting file mode: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		cleanup()
+		return fmt.Errorf("replacing %s: %w", path, err)
+	}
+	return nil
+}
+
+// DetectWriter chooses a writer for the beads file at beadsPath. The bd CLI
+// is preferred when it is on PATH so bd's database stays authoritative; the
+// JSONL writer is used otherwise. BV_EDIT_WRITER=bd|jsonl forces a backend.
+// Returns nil when no backend is usable (e.g. no local beads file).
+func DetectWriter(beadsPath string) Writer {
+	projectDir := ""
+	if beadsPath != "" {
+		dir := filepath.Dir(beadsPath)
+		if filepath.Base(dir) == ".beads" {
+			projectDir = filepath.Dir(dir)
+		} else {
+			projectDir = dir
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("BV_EDIT_WRITER"))) {
+	case "bd":
+		return NewCLIWriter(projectDir)
+	case "jsonl":
+		if beadsPath == "" {
+			return nil
+		}
+		return NewJSONLWriter(beadsPath)
+	}
+
+	if beadsPath == "" {
+		return nil
+	}
+	if _, err := exec.LookPath("bd"); err == nil {
+		return NewCLIWriter(projectDir)
+	}
+	return NewJSONLWriter(beadsPath)
+}
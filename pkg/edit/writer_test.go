@@ -0,0 +1,184 @@
+package edit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const writerFixture = `{"id":"bv-1","title":"First","status":"open","priority":2,"issue_type":"task","labels":["api"],"custom_field":{"keep":true},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bv-2","title":"Second","status":"open","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+`
+
+func writeFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "beads.jsonl")
+	if err := os.WriteFile(path, []byte(writerFixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func loadByID(t *testing.T, path string) map[string]model.Issue {
+	t.Helper()
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatalf("reloading: %v", err)
+	}
+	byID := make(map[string]model.Issue, len(issues))
+	for _, i := range issues {
+		byID[i.ID] = i
+	}
+	return byID
+}
+
+func TestJSONLWriter_AppliesAndUndoes(t *testing.T) {
+	path := writeFixture(t)
+	now := time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)
+	w := NewJSONLWriter(path)
+	w.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	issues := loadByID(t, path)
+	var applied []Change
+	apply := func(c Change, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("building change: %v", err)
+		}
+		if err := w.Apply(ctx, c); err != nil {
+			t.Fatalf("Apply(%s) failed: %v", c, err)
+		}
+		applied = append(applied, c)
+	}
+
+	apply(SetStatus(issues["bv-1"], model.StatusClosed))
+	apply(SetPriority(issues["bv-1"], 0))
+	apply(SetAssignee(issues["bv-1"], "bob"))
+	apply(AddLabel(issues["bv-1"], "urgent"))
+	apply(RemoveLabel(issues["bv-1"], "api"))
+	apply(AddDependency(issues["bv-1"], "bv-2", "", nil))
+
+	got := loadByID(t, path)["bv-1"]
+	if got.Status != model.StatusClosed || got.ClosedAt == nil {
+		t.Errorf("status not closed with closed_at: %+v", got)
+	}
+	if got.Priority != 0 || got.Assignee != "bob" {
+		t.Errorf("priority/assignee not applied: P%d %q", got.Priority, got.Assignee)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "urgent" {
+		t.Errorf("labels = %v, want [urgent]", got.Labels)
+	}
+	if len(got.Dependencies) != 1 || got.Dependencies[0].DependsOnID != "bv-2" || got.Dependencies[0].CreatedBy != "bv" {
+		t.Errorf("dependency not added: %+v", got.Dependencies)
+	}
+	if !got.UpdatedAt.Equal(now) {
+		t.Errorf("updated_at = %v, want %v", got.UpdatedAt, now)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"custom_field":{"keep":true}`) {
+		t.Errorf("unknown fields were not preserved:\n%s", data)
+	}
+	if lines := strings.Split(writerFixture, "\n"); !strings.Contains(string(data), lines[1]) {
+		t.Errorf("untouched line was modified:\n%s", data)
+	}
+
+	// Undo everything in reverse order.
+	for i := len(applied) - 1; i >= 0; i-- {
+		inv, err := applied[i].Inverse()
+		if err != nil {
+			t.Fatalf("inverse of %s: %v", applied[i], err)
+		}
+		if err := w.Apply(ctx, inv); err != nil {
+			t.Fatalf("undo %s failed: %v", applied[i], err)
+		}
+	}
+	got = loadByID(t, path)["bv-1"]
+	if got.Status != model.StatusOpen || got.ClosedAt != nil || got.Priority != 2 || got.Assignee != "" {
+		t.Errorf("undo did not restore scalar fields: %+v", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "api" || len(got.Dependencies) != 0 {
+		t.Errorf("undo did not restore labels/deps: %v %v", got.Labels, got.Dependencies)
+	}
+}
+
+func TestJSONLWriter_UnknownIssue(t *testing.T) {
+	w := NewJSONLWriter(writeFixture(t))
+	err := w.Apply(context.Background(), Change{IssueID: "bv-404", Kind: KindStatus, New: "closed"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestCLIWriter_Args(t *testing.T) {
+	w := NewCLIWriter("")
+	cases := []struct {
+		change Change
+		want   string
+	}{
+		{Change{IssueID: "bv-1", Kind: KindStatus, New: "in_progress"}, "bd update bv-1 --status in_progress"},
+		{Change{IssueID: "bv-1", Kind: KindPriority, New: "0"}, "bd update bv-1 --priority 0"},
+		{Change{IssueID: "bv-1", Kind: KindAssignee, New: ""}, `bd update bv-1 --assignee ""`},
+		{Change{IssueID: "bv-1", Kind: KindAddLabel, Value: "ui"}, "bd update bv-1 --add-label ui"},
+		{Change{IssueID: "bv-1", Kind: KindRemoveLabel, Value: "ui"}, "bd update bv-1 --remove-label ui"},
+		{Change{IssueID: "bv-1", Kind: KindAddDependency, Value: "bv-2"}, "bd dep add bv-1 bv-2 --type blocks"},
+		{Change{IssueID: "bv-1", Kind: KindRemoveDependency, Value: "bv-2"}, "bd dep remove bv-1 bv-2"},
+	}
+	for _, tc := range cases {
+		if got := w.Describe(tc.change); got != tc.want {
+			t.Errorf("Describe(%s) = %q, want %q", tc.change.Kind, got, tc.want)
+		}
+	}
+}
+
+func TestCLIWriter_RunsBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bd script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "calls.log")
+	script := filepath.Join(dir, "bd")
+	body := "#!/bin/sh\necho \"$@\" >> " + logPath + "\nif [ \"$3\" = \"bv-fail\" ]; then echo 'boom' >&2; exit 1; fi\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	w := &CLIWriter{Binary: script, Dir: dir}
+	if err := w.Apply(context.Background(), Change{IssueID: "bv-1", Kind: KindAddDependency, Value: "bv-2", DepType: model.DepRelated}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	logged, _ := os.ReadFile(logPath)
+	if strings.TrimSpace(string(logged)) != "dep add bv-1 bv-2 --type related" {
+		t.Errorf("unexpected invocation: %q", logged)
+	}
+
+	err := w.Apply(context.Background(), Change{IssueID: "bv-fail", Kind: KindRemoveDependency, Value: "bv-2"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected stderr in error, got %v", err)
+	}
+}
+
+func TestDetectWriter_Override(t *testing.T) {
+	t.Setenv("BV_EDIT_WRITER", "jsonl")
+	if w := DetectWriter("/tmp/project/.beads/beads.jsonl"); w == nil || w.Name() != "jsonl" {
+		t.Errorf("expected jsonl writer, got %v", w)
+	}
+	t.Setenv("BV_EDIT_WRITER", "bd")
+	w := DetectWriter("/tmp/project/.beads/beads.jsonl")
+	cli, ok := w.(*CLIWriter)
+	if !ok || cli.Dir != "/tmp/project" {
+		t.Errorf("expected bd writer rooted at project dir, got %#v", w)
+	}
+	t.Setenv("BV_EDIT_WRITER", "")
+	if w := DetectWriter(""); w != nil {
+		t.Errorf("expected no writer without a beads path, got %v", w)
+	}
+}
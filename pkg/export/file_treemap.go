@@ -0,0 +1,268 @@
+package export
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+
+	"github.com/ajstarks/svgo"
+)
+
+// FileTreemapOptions controls file risk treemap export.
+type FileTreemapOptions struct {
+	Path     string                    // Output path (.svg appended when missing)
+	Title    string                    // Optional title; defaults to "File Risk Treemap"
+	Tree     *correlation.FileRiskNode // From correlation.BuildFileRiskTree
+	DataHash string                    // Hash of input issues for provenance
+	Width    int                       // Canvas width in px (default 1600)
+	Height   int                       // Canvas height in px (default 1000)
+}
+
+const (
+	treemapHeader    = 84 // Title block above the map
+	treemapMargin    = 16
+	treemapDirHeader = 16 // Label strip at the top of a directory
+	treemapPad       = 2
+)
+
+var (
+	colorRiskLow  = color.RGBA{0x4c, 0xaf, 0x50, 0xff}
+	colorRiskMid  = color.RGBA{0xff, 0xc1, 0x07, 0xff}
+	colorRiskHigh = color.RGBA{0xe5, 0x39, 0x35, 0xff}
+	colorDirFill  = color.RGBA{0xe5, 0xe7, 0xeb, 0xff}
+)
+
+// SaveFileRiskTreemap renders a treemap of file risk as SVG. Rectangle area
+// is churn (lines inserted + deleted) and colour runs from green (low risk)
+// through amber to red (critical); hovering a file shows its breakdown.
+func SaveFileRiskTreemap(opts FileTreemapOptions) error {
+	if opts.Tree == nil || opts.Tree.Files == 0 {
+		return fmt.Errorf("no files to export")
+	}
+	if opts.Path == "" {
+		return fmt.Errorf("output path is required")
+	}
+	if filepath.Ext(opts.Path) == "" {
+		opts.Path += ".svg"
+	}
+	if ext := strings.ToLower(filepath.Ext(opts.Path)); ext != ".svg" {
+		return fmt.Errorf("unsupported format %q (want svg)", strings.TrimPrefix(ext, "."))
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0o755); err != nil {
+		return fmt.Errorf("create parent dir: %w", err)
+	}
+
+	file, err := os.Create(opts.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return renderFileTreemapSVG(file, opts)
+}
+
+func renderFileTreemapSVG(w io.Writer, opts FileTreemapOptions) error {
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = 1600
+	}
+	if height <= 0 {
+		height = 1000
+	}
+	title := opts.Title
+	if title == "" {
+		title = "File Risk Treemap"
+	}
+
+	canvas := svg.New(w)
+	canvas.Start(width, height)
+	canvas.Rect(0, 0, width, height, fmt.Sprintf("fill:%s", css(colorBackdrop)))
+	canvas.Roundrect(16, 16, width-32, treemapHeader-24, 10, 10, fmt.Sprintf("fill:%s", css(colorHeaderBG)))
+	canvas.Text(32, 40, title, fmt.Sprintf("fill:%s;font-size:16px;font-family:monospace;font-weight:bold", css(colorText)))
+	canvas.Text(32, 62, fmt.Sprintf("data_hash: %s  files: %d  churn: %d lines  area = churn, colour = risk",
+		opts.DataHash, opts.Tree.Files, opts.Tree.Churn),
+		fmt.Sprintf("fill:%s;font-size:13px;font-family:monospace", css(colorSubtle)))
+	drawRiskLegendSVG(canvas, width-260, 30)
+
+	weights := make(map[*correlation.FileRiskNode]float64)
+	treemapWeight(opts.Tree, weights)
+	area := tmRect{
+		x: treemapMargin,
+		y: treemapHeader,
+		w: float64(width - 2*treemapMargin),
+		h: float64(height - treemapHeader - treemapMargin),
+	}
+	drawTreemapChildren(canvas, opts.Tree, area, weights)
+
+	canvas.End()
+	return nil
+}
+
+// treemapWeight sizes files by churn, with a floor of one line so files
+// that were only renamed still show up
+func treemapWeight(n *correlation.FileRiskNode, weights map[*correlation.FileRiskNode]float64) float64 {
+	if !n.IsDir() {
+		weights[n] = math.Max(float64(n.Churn), 1)
+		return weights[n]
+	}
+	var total float64
+	for _, c := range n.Children {
+		total += treemapWeight(c, weights)
+	}
+	weights[n] = total
+	return total
+}
+
+func drawTreemapChildren(canvas *svg.SVG, n *correlation.FileRiskNode, r tmRect, weights map[*correlation.FileRiskNode]float64) {
+	children := make([]*correlation.FileRiskNode, len(n.Children))
+	copy(children, n.Children)
+	sort.SliceStable(children, func(i, j int) bool {
+		return weights[children[i]] > weights[children[j]]
+	})
+	sizes := make([]float64, len(children))
+	for i, c := range children {
+		sizes[i] = weights[c]
+	}
+	for i, cr := range squarify(sizes, r) {
+		drawTreemapNode(canvas, children[i], cr, weights)
+	}
+}
+
+func drawTreemapNode(canvas *svg.SVG, n *correlation.FileRiskNode, r tmRect, weights map[*correlation.FileRiskNode]float64) {
+	x, y := int(math.Round(r.x)), int(math.Round(r.y))
+	w, h := int(math.Round(r.x+r.w))-x, int(math.Round(r.y+r.h))-y
+	if w < 1 || h < 1 {
+		return
+	}
+
+	if !n.IsDir() {
+		f := n.File
+		canvas.Group()
+		canvas.Title(fmt.Sprintf("%s\nrisk %.2f (%s)\n%d beads, %d bugs, %d reopens\nchurn %d (+%d -%d) in %d commits\ncoupled: %d files",
+			f.FilePath, f.RiskScore, f.RiskLevel, f.TotalBeads, f.BugBeads, f.Reopens,
+			f.Churn(), f.Insertions, f.Deletions, f.Commits, len(f.CoupledFiles)))
+		canvas.Rect(x, y, w, h, fmt.Sprintf("fill:%s;stroke:%s;stroke-width:1", css(riskColor(n.RiskScore)), css(colorBackdrop)))
+		if w >= 48 && h >= 18 {
+			canvas.Text(x+4, y+13, truncate(n.Name, (w-8)/7), fmt.Sprintf("fill:%s;font-size:11px;font-family:monospace", css(colorText)))
+		}
+		canvas.Gend()
+		return
+	}
+
+	canvas.Group()
+	canvas.Title(fmt.Sprintf("%s/\nmax risk %.2f\n%d files, churn %d", n.Path, n.RiskScore, n.Files, n.Churn))
+	canvas.Rect(x, y, w, h, fmt.Sprintf("fill:%s;stroke:%s;stroke-width:1", css(colorDirFill), css(colorStroke)))
+	inner := r
+	if w >= 40 && h >= treemapDirHeader*2 {
+		canvas.Text(x+4, y+12, truncate(n.Name+"/", (w-8)/7), fmt.Sprintf("fill:%s;font-size:11px;font-family:monospace;font-weight:bold", css(colorText)))
+		inner = tmRect{x: r.x + treemapPad, y: r.y + treemapDirHeader, w: r.w - 2*treemapPad, h: r.h - treemapDirHeader - treemapPad}
+	}
+	canvas.Gend()
+	drawTreemapChildren(canvas, n, inner, weights)
+}
+
+func drawRiskLegendSVG(canvas *svg.SVG, x, y int) {
+	canvas.Text(x, y+8, "risk", fmt.Sprintf("fill:%s;font-size:12px;font-family:monospace", css(colorSubtle)))
+	const steps = 10
+	for i := 0; i < steps; i++ {
+		canvas.Rect(x+40+i*16, y, 16, 12, fmt.Sprintf("fill:%s", css(riskColor(float64(i)/(steps-1)))))
+	}
+	canvas.Text(x+40, y+28, "0", fmt.Sprintf("fill:%s;font-size:11px;font-family:monospace", css(colorSubtle)))
+	canvas.Text(x+40+steps*16, y+28, "1", fmt.Sprintf("fill:%s;font-size:11px;font-family:monospace;text-anchor:end", css(colorSubtle)))
+}
+
+// riskColor blends green -> amber -> red across a 0-1 risk score
+func riskColor(score float64) color.RGBA {
+	score = math.Max(0, math.Min(1, score))
+	if score < 0.5 {
+		return lerpColor(colorRiskLow, colorRiskMid, score*2)
+	}
+	return lerpColor(colorRiskMid, colorRiskHigh, (score-0.5)*2)
+}
+
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 0xff}
+}
+
+type tmRect struct {
+	x, y, w, h float64
+}
+
+// squarify lays sizes (sorted largest first) out in r using the squarified
+// treemap algorithm (Bruls, Huizing, van Wijk): rows are filled along the
+// shorter side while that keeps the worst aspect ratio from getting worse.
+func squarify(sizes []float64, r tmRect) []tmRect {
+	out := make([]tmRect, len(sizes))
+	var total float64
+	for _, s := range sizes {
+		total += s
+	}
+	if total <= 0 || r.w <= 0 || r.h <= 0 {
+		return out
+	}
+	scale := r.w * r.h / total
+	areas := make([]float64, len(sizes))
+	for i, s := range sizes {
+		areas[i] = s * scale
+	}
+
+	for i := 0; i < len(areas); {
+		side := math.Min(r.w, r.h)
+		j := i + 1
+		for j < len(areas) && worstAspect(areas[i:j+1], side) <= worstAspect(areas[i:j], side) {
+			j++
+		}
+		var rowSum float64
+		for _, a := range areas[i:j] {
+			rowSum += a
+		}
+		if r.w >= r.h {
+			// Column along the left edge
+			colW := rowSum / r.h
+			y := r.y
+			for k, a := range areas[i:j] {
+				out[i+k] = tmRect{x: r.x, y: y, w: colW, h: a / colW}
+				y += a / colW
+			}
+			r.x += colW
+			r.w -= colW
+		} else {
+			// Row along the top edge
+			rowH := rowSum / r.w
+			x := r.x
+			for k, a := range areas[i:j] {
+				out[i+k] = tmRect{x: x, y: r.y, w: a / rowH, h: rowH}
+				x += a / rowH
+			}
+			r.y += rowH
+			r.h -= rowH
+		}
+		i = j
+	}
+	return out
+}
+
+// worstAspect is the largest aspect ratio in a row of areas laid along side
+func worstAspect(row []float64, side float64) float64 {
+	var sum, maxA float64
+	minA := math.Inf(1)
+	for _, a := range row {
+		sum += a
+		maxA = math.Max(maxA, a)
+		minA = math.Min(minA, a)
+	}
+	if sum == 0 || minA == 0 {
+		return math.Inf(1)
+	}
+	s2, side2 := sum*sum, side*side
+	return math.Max(side2*maxA/s2, s2/(side2*minA))
+}
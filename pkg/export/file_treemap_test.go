@@ -0,0 +1,92 @@
+package export
+
+import (
+	"encoding/xml"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+func TestSquarify_FillsRectProportionally(t *testing.T) {
+	r := tmRect{x: 10, y: 20, w: 600, h: 400}
+	sizes := []float64{6, 6, 4, 3, 2, 2, 1}
+	rects := squarify(sizes, r)
+
+	var total float64
+	for _, s := range sizes {
+		total += s
+	}
+	for i, got := range rects {
+		want := sizes[i] / total * r.w * r.h
+		if math.Abs(got.w*got.h-want) > 1e-6 {
+			t.Errorf("rect %d area %.2f, want %.2f", i, got.w*got.h, want)
+		}
+		if got.x < r.x-1e-9 || got.y < r.y-1e-9 || got.x+got.w > r.x+r.w+1e-6 || got.y+got.h > r.y+r.h+1e-6 {
+			t.Errorf("rect %d %+v outside %+v", i, got, r)
+		}
+		if aspect := math.Max(got.w/got.h, got.h/got.w); aspect > 4 {
+			t.Errorf("rect %d aspect ratio %.1f, squarify should keep it low", i, aspect)
+		}
+	}
+
+	if rects := squarify([]float64{0, 0}, r); rects[0] != (tmRect{}) {
+		t.Errorf("zero sizes should yield empty rects, got %+v", rects)
+	}
+}
+
+func TestRiskColor(t *testing.T) {
+	if riskColor(0) != colorRiskLow || riskColor(0.5) != colorRiskMid || riskColor(1) != colorRiskHigh || riskColor(7) != colorRiskHigh {
+		t.Errorf("risk colour endpoints wrong: %v %v %v", riskColor(0), riskColor(0.5), riskColor(1))
+	}
+}
+
+func TestSaveFileRiskTreemap(t *testing.T) {
+	tree := correlation.BuildFileRiskTree([]correlation.FileRisk{
+		{FilePath: "pkg/auth/token.go", RiskScore: 0.9, RiskLevel: "critical", Insertions: 400, Deletions: 100, BugBeads: 2},
+		{FilePath: "pkg/auth/session.go", RiskScore: 0.5, RiskLevel: "high", Insertions: 100},
+		{FilePath: "pkg/ui/view.go", RiskScore: 0.1, RiskLevel: "low", Insertions: 300},
+		{FilePath: "README.md", RiskScore: 0, RiskLevel: "low"},
+	})
+	out := filepath.Join(t.TempDir(), "risk")
+	if err := SaveFileRiskTreemap(FileTreemapOptions{Path: out, Tree: tree, DataHash: "abc123"}); err != nil {
+		t.Fatalf("SaveFileRiskTreemap: %v", err)
+	}
+	data, err := os.ReadFile(out + ".svg")
+	if err != nil {
+		t.Fatalf("expected .svg to be appended: %v", err)
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err.Error() != "EOF" {
+				t.Fatalf("invalid XML: %v", err)
+			}
+			break
+		}
+	}
+
+	svg := string(data)
+	for _, want := range []string{
+		"data_hash: abc123",
+		"<title>pkg/auth/token.go&#xA;risk 0.90 (critical)",
+		"<title>README.md&#xA;",
+		"<title>pkg/auth/&#xA;",
+		"fill:" + css(riskColor(0.9)),
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+
+	if err := SaveFileRiskTreemap(FileTreemapOptions{Path: out + ".png", Tree: tree}); err == nil {
+		t.Error("expected an error for a non-SVG path")
+	}
+	if err := SaveFileRiskTreemap(FileTreemapOptions{Path: out, Tree: &correlation.FileRiskNode{}}); err == nil {
+		t.Error("expected an error for an empty tree")
+	}
+}
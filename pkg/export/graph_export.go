@@ -309,9 +309,13 @@ func generateDOT(issues []model.Issue, issueIDs map[string]bool, stats *analysis
 
 			style := "dashed"
 			color := "#999999"
-			if dep.Type == model.DepBlocks {
+			switch {
+			case dep.Type.IsBlocking():
 				style = "bold"
 				color = "#E53935" // Red for blocking
+			case dep.Type.IsHierarchy():
+				style = "solid"
+				color = "#7E57C2" // Purple for parent-child
 			}
 
 			sb.WriteString(fmt.Sprintf("    \"%s\" -> \"%s\" [style=%s, color=\"%s\"];\n",
@@ -469,8 +473,11 @@ func generateMermaid(issues []model.Issue, issueIDs map[string]bool) string {
 			safeToID := getSafeID(dep.DependsOnID)
 
 			linkStyle := "-.->" // Dashed for related
-			if dep.Type == model.DepBlocks {
+			switch {
+			case dep.Type.IsBlocking():
 				linkStyle = "==>" // Bold for blockers
+			case dep.Type.IsHierarchy():
+				linkStyle = "-->" // Solid for parent-child
 			}
 
 			sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeFromID, linkStyle, safeToID))
@@ -535,7 +542,7 @@ func generateAdjacency(issues []model.Issue, issueIDs map[string]bool, stats *an
 			}
 
 			edgeType := "related"
-			if dep.Type == model.DepBlocks {
+			if dep.Type.IsBlocking() {
 				edgeType = "blocks"
 			}
 
@@ -248,6 +248,41 @@ func TestExportGraph_Mermaid(t *testing.T) {
 	}
 }
 
+func TestExportGraph_CustomDependencyTypes(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{DependencyTypes: map[model.DependencyType]model.DependencyTypeDef{
+		"waits-for": {Blocking: true},
+		"tracks":    {Hierarchy: true},
+	}})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issues := []model.Issue{
+		{ID: "a", Title: "A", Status: model.StatusOpen},
+		{ID: "b", Title: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "b", DependsOnID: "a", Type: "waits-for"},
+		}},
+		{ID: "c", Title: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "c", DependsOnID: "a", Type: "tracks"},
+		}},
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	dot, err := ExportGraph(issues, &stats, GraphExportConfig{Format: GraphFormatDOT})
+	if err != nil {
+		t.Fatalf("ExportGraph DOT: %v", err)
+	}
+	if !strings.Contains(dot.Graph, `"b" -> "a" [style=bold`) || !strings.Contains(dot.Graph, `"c" -> "a" [style=solid`) {
+		t.Errorf("DOT edge styles wrong:\n%s", dot.Graph)
+	}
+
+	mmd, err := ExportGraph(issues, &stats, GraphExportConfig{Format: GraphFormatMermaid})
+	if err != nil {
+		t.Fatalf("ExportGraph Mermaid: %v", err)
+	}
+	if !strings.Contains(mmd.Graph, " ==> ") || !strings.Contains(mmd.Graph, " --> ") {
+		t.Errorf("Mermaid arrows wrong:\n%s", mmd.Graph)
+	}
+}
+
 func TestExportGraph_DOT_TombstoneUsesClosedColor(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "tombstone-1", Title: "Removed Issue", Status: model.StatusTombstone, Priority: 3},
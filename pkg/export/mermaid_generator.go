@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -116,8 +117,11 @@ func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config
 			safeToID := getSafeID(dep.DependsOnID)
 
 			linkStyle := "-.->" // Dashed for related
-			if dep.Type == model.DepBlocks {
+			switch {
+			case dep.Type.IsBlocking():
 				linkStyle = "==>" // Bold for blockers
+			case dep.Type.IsHierarchy():
+				linkStyle = "-->" // Solid for parent-child
 			}
 
 			sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeFromID, linkStyle, safeToID))
@@ -133,3 +137,76 @@ func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config
 }
 
 // Note: sanitizeMermaidID and sanitizeMermaidText are defined in markdown.go
+
+// GanttConfig configures Mermaid gantt chart generation.
+type GanttConfig struct {
+	Title string // Chart title (default: "Execution Schedule")
+}
+
+// GenerateMermaidGantt renders a schedule as a Mermaid gantt chart with one
+// section per agent. Beads on the critical path are tagged "crit" and beads
+// already in progress are tagged "active".
+func GenerateMermaidGantt(schedule analysis.Schedule, config GanttConfig) string {
+	var sb strings.Builder
+
+	title := config.Title
+	if title == "" {
+		title = "Execution Schedule"
+	}
+
+	sb.WriteString("gantt\n")
+	sb.WriteString(fmt.Sprintf("    title %s\n", sanitizeGanttText(title)))
+	sb.WriteString("    dateFormat YYYY-MM-DD HH:mm\n")
+	sb.WriteString("    axisFormat %m-%d\n")
+
+	byAgent := make(map[string][]analysis.ScheduledItem)
+	for _, item := range schedule.Items {
+		byAgent[item.Agent] = append(byAgent[item.Agent], item)
+	}
+
+	usedIDs := make(map[string]bool)
+	for _, agent := range schedule.Agents {
+		items := byAgent[agent.Name]
+		if len(items) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("    section %s\n", sanitizeGanttText(agent.Name)))
+		for _, item := range items {
+			taskID := sanitizeMermaidID(item.ID)
+			if usedIDs[taskID] {
+				h := fnv.New32a()
+				_, _ = h.Write([]byte(item.ID))
+				taskID = fmt.Sprintf("%s_%x", taskID, h.Sum32())
+			}
+			usedIDs[taskID] = true
+
+			var tags []string
+			if item.Critical {
+				tags = append(tags, "crit")
+			}
+			if item.Status == string(model.StatusInProgress) {
+				tags = append(tags, "active")
+			}
+			tags = append(tags, taskID)
+
+			name := sanitizeGanttText(fmt.Sprintf("%s %s", item.ID, item.Title))
+			sb.WriteString(fmt.Sprintf("    %s :%s, %s, %s\n",
+				name,
+				strings.Join(tags, ", "),
+				item.Start.Format("2006-01-02 15:04"),
+				item.End.Format("2006-01-02 15:04"),
+			))
+		}
+	}
+
+	return sb.String()
+}
+
+// sanitizeGanttText strips characters that terminate or confuse Mermaid
+// gantt task names (":" starts the task metadata, "#" and ";" are treated as
+// comment/statement separators). Angle brackets are dropped up front so
+// sanitizeMermaidText does not reintroduce ";" via HTML entities.
+func sanitizeGanttText(text string) string {
+	replacer := strings.NewReplacer(":", " -", "#", "", ";", ",", "<", "", ">", "")
+	return sanitizeMermaidText(replacer.Replace(text))
+}
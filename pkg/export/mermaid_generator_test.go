@@ -0,0 +1,53 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGenerateMermaidGantt(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	est := func(m int) *int { return &m }
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Design: API", Status: model.StatusInProgress, IssueType: model.TypeTask, EstimatedMinutes: est(60)},
+		{ID: "bv-2", Title: "Implement #2 <fast>", Status: model.StatusOpen, IssueType: model.TypeTask, EstimatedMinutes: est(30),
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+	sched := analysis.NewAnalyzer(issues).GetSchedule(analysis.ScheduleOptions{Agents: 1, Start: start})
+
+	out := GenerateMermaidGantt(sched, GanttConfig{Title: "Sprint: Q1"})
+
+	for _, want := range []string{
+		"gantt\n",
+		"title Sprint - Q1",
+		"dateFormat YYYY-MM-DD HH:mm",
+		"section agent-1",
+		"bv-1 Design - API :crit, active, bv-1, 2025-01-06 09:00, 2025-01-06 10:00",
+		"bv-2 Implement 2 fast :crit, bv-2, 2025-01-06 10:00, 2025-01-06 10:30",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("gantt output missing %q\n%s", want, out)
+		}
+	}
+
+	// Task names must not contain extra colons (they start task metadata).
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "    bv-") && strings.Count(line, ":") != 3 {
+			t.Errorf("unexpected colon count in task line %q", line)
+		}
+	}
+}
+
+func TestGenerateMermaidGantt_Empty(t *testing.T) {
+	out := GenerateMermaidGantt(analysis.Schedule{}, GanttConfig{})
+	if !strings.Contains(out, "title Execution Schedule") {
+		t.Errorf("expected default title, got %q", out)
+	}
+	if strings.Contains(out, "section") {
+		t.Errorf("expected no sections for empty schedule, got %q", out)
+	}
+}
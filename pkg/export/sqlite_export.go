@@ -238,8 +238,8 @@ func (e *SQLiteExporter) insertDependencies(db *sql.DB) error {
 	defer tx.Rollback()
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO dependencies (issue_id, depends_on_id, type)
-		VALUES (?, ?, ?)
+		INSERT INTO dependencies (issue_id, depends_on_id, type, blocking, hierarchy)
+		VALUES (?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -250,7 +250,7 @@ func (e *SQLiteExporter) insertDependencies(db *sql.DB) error {
 		if dep == nil {
 			continue
 		}
-		_, err := stmt.Exec(dep.IssueID, dep.DependsOnID, string(dep.Type))
+		_, err := stmt.Exec(dep.IssueID, dep.DependsOnID, string(dep.Type), dep.Type.IsBlocking(), dep.Type.IsHierarchy())
 		if err != nil {
 			return fmt.Errorf("insert dependency %s->%s: %w", dep.IssueID, dep.DependsOnID, err)
 		}
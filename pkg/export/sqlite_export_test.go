@@ -3,8 +3,10 @@ package export
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -127,6 +129,60 @@ func TestExport_CreatesDatabase(t *testing.T) {
 	}
 }
 
+func TestExport_DependencySemantics(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{DependencyTypes: map[model.DependencyType]model.DependencyTypeDef{
+		"waits-for": {Blocking: true},
+		"tracks":    {Hierarchy: true},
+	}})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	tmpDir := t.TempDir()
+	issues := []*model.Issue{
+		makeTestIssue("d-1", "One", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("d-2", "Two", model.StatusOpen, 1, model.TypeTask),
+		makeTestIssue("d-3", "Three", model.StatusOpen, 1, model.TypeTask),
+	}
+	deps := []*model.Dependency{
+		{IssueID: "d-2", DependsOnID: "d-1", Type: "waits-for"},
+		{IssueID: "d-3", DependsOnID: "d-1", Type: "tracks"},
+	}
+	if err := NewSQLiteExporter(issues, deps, nil, nil).Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT type, blocking, hierarchy FROM dependencies ORDER BY issue_id`)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var typ string
+		var blocking, hierarchy int
+		if err := rows.Scan(&typ, &blocking, &hierarchy); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, fmt.Sprintf("%s:%d:%d", typ, blocking, hierarchy))
+	}
+	if strings.Join(got, ",") != "waits-for:1:0,tracks:0:1" {
+		t.Errorf("dependencies = %v", got)
+	}
+
+	var blockedBy string
+	if err := db.QueryRow(`SELECT COALESCE(blocked_by_ids, '') FROM issue_overview_mv WHERE id = 'd-2'`).Scan(&blockedBy); err != nil {
+		t.Fatalf("overview: %v", err)
+	}
+	if blockedBy != "d-1" {
+		t.Errorf("blocked_by_ids = %q, want d-1", blockedBy)
+	}
+}
+
 func TestExport_CreatesDataDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 
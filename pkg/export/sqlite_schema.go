@@ -56,13 +56,16 @@ func createCoreTables(db *sql.DB) error {
 		return fmt.Errorf("create issues table: %w", err)
 	}
 
-	// Dependencies table - blocking relationships
+	// Dependencies table - all relationships; blocking/hierarchy record how
+	// the type behaved in the graph (custom types come from .bv/workflow.yaml)
 	depsSQL := `
 		CREATE TABLE IF NOT EXISTS dependencies (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			issue_id TEXT NOT NULL,
 			depends_on_id TEXT NOT NULL,
 			type TEXT NOT NULL DEFAULT 'blocks',
+			blocking INTEGER NOT NULL DEFAULT 1,
+			hierarchy INTEGER NOT NULL DEFAULT 0,
 			FOREIGN KEY (issue_id) REFERENCES issues(id),
 			FOREIGN KEY (depends_on_id) REFERENCES issues(id)
 		)
@@ -242,13 +245,13 @@ func CreateMaterializedViews(db *sql.DB) error {
 				(SELECT GROUP_CONCAT(issue_id) FROM (
 					SELECT issue_id
 					FROM dependencies
-					WHERE depends_on_id = i.id AND (type = 'blocks' OR type = '')
+					WHERE depends_on_id = i.id AND blocking = 1
 					ORDER BY issue_id
 				)) as blocks_ids,
 				(SELECT GROUP_CONCAT(depends_on_id) FROM (
 					SELECT depends_on_id
 					FROM dependencies
-					WHERE issue_id = i.id AND (type = 'blocks' OR type = '')
+					WHERE issue_id = i.id AND blocking = 1
 					ORDER BY depends_on_id
 				)) as blocked_by_ids
 			FROM issues i
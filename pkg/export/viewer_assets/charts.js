@@ -6,6 +6,7 @@
  * - Label dependency heatmap
  * - Priority distribution pie chart
  * - Type breakdown bar chart
+ * - Cumulative flow diagram (from data/cfd.json when exported with history)
  *
  * Uses Chart.js for standard charts, custom canvas for heatmap.
  *
@@ -32,6 +33,9 @@ const CHART_THEME = {
         closed: '#6272A4'
     },
 
+    // Fallback colors for custom workflow statuses
+    extraStatus: ['#BD93F9', '#8BE9FD', '#FF79C6', '#F1FA8C'],
+
     // Priority colors (0 = critical, 4 = backlog)
     priority: [
         '#FF0000',  // P0 Critical
@@ -71,6 +75,7 @@ const chartsState = {
     burndownChart: null,
     priorityChart: null,
     typeChart: null,
+    cfdChart: null,
     heatmapCanvas: null,
     issues: [],
     dependencies: [],
@@ -101,6 +106,7 @@ function initCharts(issues, dependencies) {
     initPriorityChart();
     initTypeChart();
     initHeatmap();
+    initCFDChart();
 
     console.log('[bv-charts] Dashboard initialized with', issues.length, 'issues');
 }
@@ -136,6 +142,10 @@ function destroyCharts() {
         chartsState.typeChart.destroy();
         chartsState.typeChart = null;
     }
+    if (chartsState.cfdChart) {
+        chartsState.cfdChart.destroy();
+        chartsState.cfdChart = null;
+    }
     chartsState.initialized = false;
 }
 
@@ -527,6 +537,115 @@ function updateTypeChart() {
     chartsState.typeChart.update();
 }
 
+// ============================================================================
+// CUMULATIVE FLOW DIAGRAM
+// ============================================================================
+
+/**
+ * Initialize the cumulative flow diagram. The series are reconstructed from
+ * git history at export time, so they do not change with the issue filter.
+ */
+async function initCFDChart() {
+    const canvas = document.getElementById('cfd-chart');
+    if (!canvas) return;
+
+    let flow;
+    try {
+        const response = await fetch('./data/cfd.json');
+        if (!response.ok) throw new Error(`HTTP ${response.status}`);
+        flow = await response.json();
+    } catch (err) {
+        showCFDEmpty(canvas, 'No cumulative flow data (export with history enabled)');
+        return;
+    }
+    if (!flow.days || !flow.days.length || !flow.statuses || !flow.statuses.length) {
+        showCFDEmpty(canvas, 'No status history in this window');
+        return;
+    }
+    // A re-init may have run while the fetch was pending
+    if (chartsState.cfdChart || !chartsState.initialized) return;
+
+    const labels = flow.days.map(d => formatDateLabel(d.date + 'T00:00:00Z'));
+    let extra = 0;
+    // Bands stack bottom (done) to top (open); each fills down to the one below
+    const datasets = flow.statuses.map((status, i) => {
+        const color = CHART_THEME.status[status] ||
+            CHART_THEME.extraStatus[extra++ % CHART_THEME.extraStatus.length];
+        return {
+            label: capitalize(status.replace(/_/g, ' ')),
+            data: flow.days.map(d => (d.counts && d.counts[status]) || 0),
+            borderColor: color,
+            backgroundColor: color + '99',
+            fill: i === 0 ? 'origin' : '-1',
+            tension: 0.2,
+            pointRadius: 0,
+            pointHoverRadius: 4,
+            borderWidth: 1
+        };
+    });
+
+    chartsState.cfdChart = new Chart(canvas.getContext('2d'), {
+        type: 'line',
+        data: { labels, datasets },
+        options: {
+            responsive: true,
+            maintainAspectRatio: false,
+            interaction: {
+                intersect: false,
+                mode: 'index'
+            },
+            plugins: {
+                legend: {
+                    position: 'top',
+                    labels: {
+                        usePointStyle: true,
+                        padding: 15
+                    }
+                },
+                tooltip: {
+                    backgroundColor: CHART_THEME.tooltipBg,
+                    titleColor: CHART_THEME.fg,
+                    bodyColor: CHART_THEME.fg,
+                    borderColor: CHART_THEME.borderColor,
+                    borderWidth: 1,
+                    padding: 12,
+                    displayColors: true
+                }
+            },
+            scales: {
+                x: {
+                    grid: {
+                        color: CHART_THEME.gridColor
+                    },
+                    ticks: {
+                        maxTicksLimit: 10
+                    }
+                },
+                y: {
+                    stacked: true,
+                    beginAtZero: true,
+                    grid: {
+                        color: CHART_THEME.gridColor
+                    },
+                    ticks: {
+                        precision: 0
+                    }
+                }
+            }
+        }
+    });
+}
+
+function showCFDEmpty(canvas, message) {
+    const container = canvas.parentElement;
+    if (!container || container.querySelector('.cfd-empty')) return;
+    canvas.style.display = 'none';
+    const note = document.createElement('p');
+    note.className = 'cfd-empty text-gray-500 dark:text-gray-400 text-sm text-center pt-24';
+    note.textContent = message;
+    container.appendChild(note);
+}
+
 // ============================================================================
 // LABEL DEPENDENCY HEATMAP
 // ============================================================================
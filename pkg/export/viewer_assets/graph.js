@@ -652,7 +652,7 @@ function buildWasmGraph() {
 
         // Add blocking edges
         store.dependencies
-            .filter(d => d.type === 'blocks' || !d.type)
+            .filter(d => d.blocking === 1 || d.type === 'blocks' || !d.type)
             .forEach(d => {
                 const fromIdx = store.wasmGraph.nodeIdx(d.issue_id);
                 const toIdx = store.wasmGraph.nodeIdx(d.depends_on_id);
@@ -994,7 +994,7 @@ function prepareGraphData(layout = null) {
 
     // Filter links
     let links = dependencies
-        .filter(d => (d.type === 'blocks' || !d.type))
+        .filter(d => (d.blocking === 1 || d.type === 'blocks' || !d.type))
         .filter(d => nodeIds.has(d.issue_id) && nodeIds.has(d.depends_on_id))
         .map(d => ({
             source: d.issue_id,
@@ -708,7 +708,7 @@ async function initGraphEngine() {
     const deps = execQuery(`
       SELECT issue_id, depends_on_id
       FROM dependencies
-      WHERE type = 'blocks'
+      WHERE blocking = 1
     `);
 
     for (const row of deps) {
@@ -1084,9 +1084,9 @@ function getGraphViewData() {
   }));
 
   const dependencies = execQuery(`
-    SELECT issue_id, depends_on_id, type
+    SELECT issue_id, depends_on_id, type, blocking
     FROM dependencies
-    WHERE type = 'blocks'
+    WHERE blocking = 1
   `);
 
   return { issues, dependencies };
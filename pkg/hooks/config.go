@@ -1,6 +1,8 @@
-// Package hooks provides a hook system for bv export automation.
+// Package hooks provides a hook system for bv automation.
 // Hooks are configured via .bv/hooks.yaml and run at specific points
-// in the export pipeline (pre-export, post-export).
+// in the export pipeline (pre-export, post-export) or when a reload of
+// the beads file reveals a lifecycle event (bead-created, status-changed,
+// became-actionable, cycle-introduced, drift-alert).
 package hooks
 
 import (
@@ -21,15 +23,29 @@ const (
 	PreExport HookPhase = "pre-export"
 	// PostExport runs after export is written. Failure is logged but doesn't break export.
 	PostExport HookPhase = "post-export"
+
+	// BeadCreated runs for each bead that appears in the beads file
+	BeadCreated HookPhase = "bead-created"
+	// StatusChanged runs for each bead whose status changes
+	StatusChanged HookPhase = "status-changed"
+	// BecameActionable runs when the last open blocker of a bead is closed
+	BecameActionable HookPhase = "became-actionable"
+	// CycleIntroduced runs for each dependency cycle that wasn't there before
+	CycleIntroduced HookPhase = "cycle-introduced"
+	// DriftAlert runs for each drift alert that wasn't raised before
+	DriftAlert HookPhase = "drift-alert"
 )
 
+// EventPhases lists the lifecycle event phases in the order a reload reports them
+var EventPhases = []HookPhase{BeadCreated, StatusChanged, BecameActionable, CycleIntroduced, DriftAlert}
+
 // Hook defines a single hook configuration
 type Hook struct {
 	Name    string            `yaml:"name" json:"name"`                             // Human-readable name
 	Command string            `yaml:"command" json:"command"`                       // Shell command to run
 	Timeout time.Duration     `yaml:"timeout,omitempty" json:"timeout,omitempty"`   // Execution timeout (default: 30s)
 	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`           // Additional environment variables
-	OnError string            `yaml:"on_error,omitempty" json:"on_error,omitempty"` // "fail" (default for pre) or "continue" (default for post)
+	OnError string            `yaml:"on_error,omitempty" json:"on_error,omitempty"` // "fail" (default for pre) or "continue" (default for post and events)
 }
 
 // Config holds all hook configurations
@@ -41,6 +57,34 @@ type Config struct {
 type HooksByPhase struct {
 	PreExport  []Hook `yaml:"pre-export,omitempty" json:"pre-export,omitempty"`
 	PostExport []Hook `yaml:"post-export,omitempty" json:"post-export,omitempty"`
+
+	BeadCreated      []Hook `yaml:"bead-created,omitempty" json:"bead-created,omitempty"`
+	StatusChanged    []Hook `yaml:"status-changed,omitempty" json:"status-changed,omitempty"`
+	BecameActionable []Hook `yaml:"became-actionable,omitempty" json:"became-actionable,omitempty"`
+	CycleIntroduced  []Hook `yaml:"cycle-introduced,omitempty" json:"cycle-introduced,omitempty"`
+	DriftAlert       []Hook `yaml:"drift-alert,omitempty" json:"drift-alert,omitempty"`
+}
+
+// forPhase returns a pointer to the hook list of a phase, or nil for an unknown phase
+func (h *HooksByPhase) forPhase(phase HookPhase) *[]Hook {
+	switch phase {
+	case PreExport:
+		return &h.PreExport
+	case PostExport:
+		return &h.PostExport
+	case BeadCreated:
+		return &h.BeadCreated
+	case StatusChanged:
+		return &h.StatusChanged
+	case BecameActionable:
+		return &h.BecameActionable
+	case CycleIntroduced:
+		return &h.CycleIntroduced
+	case DriftAlert:
+		return &h.DriftAlert
+	default:
+		return nil
+	}
 }
 
 // ExportContext contains information passed to hooks via environment variables
@@ -124,8 +168,10 @@ func (l *Loader) Load() error {
 
 // normalizeConfig applies defaults and validates hooks
 func (l *Loader) normalizeConfig(config *Config) {
-	config.Hooks.PreExport, l.warnings = normalizeHooks(config.Hooks.PreExport, PreExport, l.warnings)
-	config.Hooks.PostExport, l.warnings = normalizeHooks(config.Hooks.PostExport, PostExport, l.warnings)
+	for _, phase := range append([]HookPhase{PreExport, PostExport}, EventPhases...) {
+		hooks := config.Hooks.forPhase(phase)
+		*hooks, l.warnings = normalizeHooks(*hooks, phase, l.warnings)
+	}
 }
 
 // normalizeHooks applies defaults, drops empty commands, and accumulates warnings.
@@ -144,7 +190,7 @@ func normalizeHooks(hooks []Hook, phase HookPhase, warnings []string) ([]Hook, [
 			if phase == PreExport {
 				hook.OnError = "fail" // pre-export failures cancel export by default
 			} else {
-				hook.OnError = "continue" // post-export failures don't break export by default
+				hook.OnError = "continue" // post-export and event hook failures are only logged by default
 			}
 		}
 		if hook.Name == "" {
@@ -163,7 +209,7 @@ func (l *Loader) Config() *Config {
 	return l.config
 }
 
-// HasHooks returns true if any hooks are configured
+// HasHooks returns true if any export hooks are configured
 func (l *Loader) HasHooks() bool {
 	if l.config == nil {
 		return false
@@ -171,20 +217,29 @@ func (l *Loader) HasHooks() bool {
 	return len(l.config.Hooks.PreExport) > 0 || len(l.config.Hooks.PostExport) > 0
 }
 
+// HasEventHooks returns true if any lifecycle event hooks are configured
+func (l *Loader) HasEventHooks() bool {
+	if l.config == nil {
+		return false
+	}
+	for _, phase := range EventPhases {
+		if len(l.GetHooks(phase)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHooks returns hooks for a specific phase
 func (l *Loader) GetHooks(phase HookPhase) []Hook {
 	if l.config == nil {
 		return nil
 	}
 
-	switch phase {
-	case PreExport:
-		return l.config.Hooks.PreExport
-	case PostExport:
-		return l.config.Hooks.PostExport
-	default:
-		return nil
+	if hooks := l.config.Hooks.forPhase(phase); hooks != nil {
+		return *hooks
 	}
+	return nil
 }
 
 // Warnings returns any warnings from loading
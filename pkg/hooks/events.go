@@ -0,0 +1,321 @@
+package hooks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Event is a lifecycle change seen between two loads of the beads file.
+// Hooks receive it as JSON on stdin and as BV_* environment variables.
+type Event struct {
+	Phase     HookPhase `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	DataHash  string    `json:"data_hash"`
+
+	// Bead events (bead-created, status-changed, became-actionable)
+	IssueID        string       `json:"issue_id,omitempty"`
+	Title          string       `json:"title,omitempty"`
+	Status         model.Status `json:"status,omitempty"`
+	PreviousStatus model.Status `json:"previous_status,omitempty"`
+	Assignee       string       `json:"assignee,omitempty"`
+	Labels         []string     `json:"labels,omitempty"`
+
+	// ClearedBlockers are the blockers closed since the last load (became-actionable)
+	ClearedBlockers []string `json:"cleared_blockers,omitempty"`
+	// Cycle lists the issues of a new dependency cycle (cycle-introduced)
+	Cycle []string `json:"cycle,omitempty"`
+	// Alert is the newly raised drift alert (drift-alert)
+	Alert *drift.Alert `json:"alert,omitempty"`
+}
+
+// ToEnv converts the event to environment variables. Every variable is set,
+// empty when it doesn't apply, so hook commands can reference any of them.
+func (e Event) ToEnv() []string {
+	var alertType, alertSeverity, alertMessage string
+	if e.Alert != nil {
+		alertType = string(e.Alert.Type)
+		alertSeverity = string(e.Alert.Severity)
+		alertMessage = e.Alert.Message
+	}
+	return []string{
+		fmt.Sprintf("BV_EVENT=%s", e.Phase),
+		fmt.Sprintf("BV_TIMESTAMP=%s", e.Timestamp.Format(time.RFC3339)),
+		fmt.Sprintf("BV_DATA_HASH=%s", e.DataHash),
+		fmt.Sprintf("BV_ISSUE_ID=%s", e.IssueID),
+		fmt.Sprintf("BV_ISSUE_TITLE=%s", e.Title),
+		fmt.Sprintf("BV_STATUS=%s", e.Status),
+		fmt.Sprintf("BV_PREVIOUS_STATUS=%s", e.PreviousStatus),
+		fmt.Sprintf("BV_ASSIGNEE=%s", e.Assignee),
+		fmt.Sprintf("BV_CLEARED_BLOCKERS=%s", strings.Join(e.ClearedBlockers, ",")),
+		fmt.Sprintf("BV_CYCLE=%s", strings.Join(e.Cycle, ",")),
+		fmt.Sprintf("BV_ALERT_TYPE=%s", alertType),
+		fmt.Sprintf("BV_ALERT_SEVERITY=%s", alertSeverity),
+		fmt.Sprintf("BV_ALERT_MESSAGE=%s", alertMessage),
+	}
+}
+
+// DetectEvents compares two loads of the issues and returns the bead and
+// cycle events of the requested phases (all of them when phases is empty),
+// grouped by phase in EventPhases order. Drift alerts come from
+// DetectDriftEvents since they need a baseline.
+func DetectEvents(prev, curr []model.Issue, now time.Time, phases ...HookPhase) []Event {
+	want := func(phase HookPhase) bool {
+		return len(phases) == 0 || slices.Contains(phases, phase)
+	}
+	dataHash := analysis.ComputeDataHash(curr)
+	before := make(map[string]*model.Issue, len(prev))
+	for i := range prev {
+		before[prev[i].ID] = &prev[i]
+	}
+	beadEvent := func(phase HookPhase, issue *model.Issue) Event {
+		return Event{
+			Phase:     phase,
+			Timestamp: now,
+			DataHash:  dataHash,
+			IssueID:   issue.ID,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			Assignee:  issue.Assignee,
+			Labels:    issue.Labels,
+		}
+	}
+
+	var events []Event
+	if want(BeadCreated) {
+		for i := range curr {
+			if before[curr[i].ID] == nil {
+				events = append(events, beadEvent(BeadCreated, &curr[i]))
+			}
+		}
+	}
+	if want(StatusChanged) {
+		for i := range curr {
+			if old := before[curr[i].ID]; old != nil && old.Status != curr[i].Status {
+				ev := beadEvent(StatusChanged, &curr[i])
+				ev.PreviousStatus = old.Status
+				events = append(events, ev)
+			}
+		}
+	}
+	if want(BecameActionable) {
+		events = append(events, becameActionable(prev, curr, before, beadEvent)...)
+	}
+	if want(CycleIntroduced) {
+		known := make(map[string]bool)
+		for _, cycle := range findCycles(prev) {
+			known[cycleKey(cycle)] = true
+		}
+		for _, cycle := range findCycles(curr) {
+			if !known[cycleKey(cycle)] {
+				events = append(events, Event{Phase: CycleIntroduced, Timestamp: now, DataHash: dataHash, Cycle: cycle})
+			}
+		}
+	}
+	return events
+}
+
+// becameActionable reports beads that were open but blocked in prev and are
+// actionable in curr. New beads and reopened beads are not reported.
+func becameActionable(prev, curr []model.Issue, before map[string]*model.Issue, beadEvent func(HookPhase, *model.Issue) Event) []Event {
+	wasActionable := make(map[string]bool)
+	for _, issue := range analysis.NewAnalyzer(prev).GetActionableIssues() {
+		wasActionable[issue.ID] = true
+	}
+	after := make(map[string]*model.Issue, len(curr))
+	for i := range curr {
+		after[curr[i].ID] = &curr[i]
+	}
+
+	var events []Event
+	for _, issue := range analysis.NewAnalyzer(curr).GetActionableIssues() {
+		old := before[issue.ID]
+		if old == nil || wasActionable[issue.ID] || old.Status.Class().IsResolved() {
+			continue
+		}
+		current := after[issue.ID]
+		ev := beadEvent(BecameActionable, current)
+		for _, dep := range current.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			oldBlocker, newBlocker := before[dep.DependsOnID], after[dep.DependsOnID]
+			if oldBlocker != nil && !oldBlocker.Status.Class().IsResolved() && (newBlocker == nil || newBlocker.Status.Class().IsResolved()) {
+				ev.ClearedBlockers = append(ev.ClearedBlockers, dep.DependsOnID)
+			}
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// findCycles returns the dependency cycles of issues without the closing node
+func findCycles(issues []model.Issue) [][]string {
+	if len(issues) == 0 {
+		return nil
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(analysis.AnalysisConfig{
+		ComputeCycles:    true,
+		CyclesTimeout:    500 * time.Millisecond,
+		MaxCyclesToStore: 100,
+	})
+	cycles := stats.Cycles()
+	for i, cycle := range cycles {
+		if len(cycle) > 1 && cycle[0] == cycle[len(cycle)-1] {
+			cycles[i] = cycle[:len(cycle)-1]
+		}
+	}
+	return cycles
+}
+
+// cycleKey identifies a cycle regardless of where its listing starts
+func cycleKey(cycle []string) string {
+	if len(cycle) == 0 {
+		return ""
+	}
+	start := 0
+	for i, id := range cycle {
+		if id < cycle[start] {
+			start = i
+		}
+	}
+	return strings.Join(append(slices.Clone(cycle[start:]), cycle[:start]...), "→")
+}
+
+// DetectDriftEvents returns a drift-alert event for each alert in curr that
+// was not already raised in prev. Alerts are matched on type, rule, issue and
+// label since their messages carry changing numbers.
+func DetectDriftEvents(prev, curr []drift.Alert, dataHash string, now time.Time) []Event {
+	key := func(a drift.Alert) string {
+		return string(a.Type) + "\x00" + a.Rule + "\x00" + a.IssueID + "\x00" + a.Label
+	}
+	raised := make(map[string]bool, len(prev))
+	for _, a := range prev {
+		raised[key(a)] = true
+	}
+	var events []Event
+	for i := range curr {
+		if raised[key(curr[i])] {
+			continue
+		}
+		alert := curr[i]
+		events = append(events, Event{
+			Phase:     DriftAlert,
+			Timestamp: now,
+			DataHash:  dataHash,
+			IssueID:   alert.IssueID,
+			Alert:     &alert,
+		})
+	}
+	return events
+}
+
+// Monitor turns successive loads of the issues into lifecycle events and runs
+// the configured hooks for them. The first load only sets the starting point.
+type Monitor struct {
+	config *Config
+	alerts func([]model.Issue) []drift.Alert
+	logger func(string)
+	now    func() time.Time
+
+	mu         sync.Mutex
+	primed     bool
+	prev       []model.Issue
+	prevAlerts []drift.Alert
+}
+
+// MonitorOption configures a Monitor
+type MonitorOption func(*Monitor)
+
+// WithDriftAlerts sets how drift alerts are computed for a load. Without it
+// drift-alert hooks never fire.
+func WithDriftAlerts(alerts func([]model.Issue) []drift.Alert) MonitorOption {
+	return func(m *Monitor) {
+		m.alerts = alerts
+	}
+}
+
+// WithMonitorLogger receives hook progress and failures
+func WithMonitorLogger(logger func(string)) MonitorOption {
+	return func(m *Monitor) {
+		m.logger = logger
+	}
+}
+
+// NewMonitor creates a monitor for the event hooks in config
+func NewMonitor(config *Config, opts ...MonitorOption) *Monitor {
+	m := &Monitor{
+		config: config,
+		logger: func(string) {},
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// phases returns the event phases that have hooks
+func (m *Monitor) phases() []HookPhase {
+	var phases []HookPhase
+	if m.config == nil {
+		return nil
+	}
+	for _, phase := range EventPhases {
+		if hooks := m.config.Hooks.forPhase(phase); len(*hooks) > 0 {
+			phases = append(phases, phase)
+		}
+	}
+	return phases
+}
+
+// Observe records a load of the issues, runs the hooks of every event since
+// the previous load and returns those events. Hooks run synchronously, in
+// event order; the caller decides whether to call Observe in the background.
+func (m *Monitor) Observe(issues []model.Issue) []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	phases := m.phases()
+	if len(phases) == 0 {
+		return nil
+	}
+	now := m.now()
+	var alerts []drift.Alert
+	if m.alerts != nil && slices.Contains(phases, DriftAlert) {
+		alerts = m.alerts(issues)
+	}
+	if !m.primed {
+		m.primed, m.prev, m.prevAlerts = true, issues, alerts
+		return nil
+	}
+
+	var events []Event
+	if beadPhases := slices.DeleteFunc(slices.Clone(phases), func(p HookPhase) bool { return p == DriftAlert }); len(beadPhases) > 0 {
+		events = DetectEvents(m.prev, issues, now, beadPhases...)
+	}
+	if slices.Contains(phases, DriftAlert) {
+		events = append(events, DetectDriftEvents(m.prevAlerts, alerts, analysis.ComputeDataHash(issues), now)...)
+	}
+	m.prev, m.prevAlerts = issues, alerts
+
+	executor := NewExecutor(m.config, ExportContext{})
+	executor.SetLogger(m.logger)
+	for _, ev := range events {
+		if err := executor.RunEvent(ev); err != nil {
+			m.logger(err.Error())
+		}
+	}
+	for _, r := range executor.Results() {
+		if !r.Success && r.Hook.OnError != "fail" {
+			m.logger(fmt.Sprintf("%s hook %q failed: %v", r.Phase, r.Hook.Name, r.Error))
+		}
+	}
+	return events
+}
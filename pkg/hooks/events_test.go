@@ -0,0 +1,169 @@
+package hooks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func issue(id string, status model.Status, blockers ...string) model.Issue {
+	is := model.Issue{ID: id, Title: "Issue " + id, Status: status, IssueType: model.TypeTask}
+	for _, b := range blockers {
+		is.Dependencies = append(is.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return is
+}
+
+func summarize(events []Event) []string {
+	var out []string
+	for _, ev := range events {
+		switch ev.Phase {
+		case CycleIntroduced:
+			out = append(out, fmt.Sprintf("%s %s", ev.Phase, strings.Join(ev.Cycle, ",")))
+		case DriftAlert:
+			out = append(out, fmt.Sprintf("%s %s", ev.Phase, ev.Alert.Type))
+		default:
+			out = append(out, fmt.Sprintf("%s %s", ev.Phase, ev.IssueID))
+		}
+	}
+	return out
+}
+
+func TestDetectEvents(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := []model.Issue{
+		issue("A", model.StatusOpen),
+		issue("B", model.StatusOpen, "A"),
+		issue("C", model.StatusOpen, "A", "D"),
+		issue("D", model.StatusOpen),
+		issue("E", model.StatusOpen),
+	}
+	curr := []model.Issue{
+		issue("A", model.StatusClosed),
+		issue("B", model.StatusOpen, "A"),
+		issue("C", model.StatusOpen, "A", "D"), // still blocked by D
+		issue("D", model.StatusInProgress),
+		issue("E", model.StatusOpen, "F"),
+		issue("F", model.StatusOpen, "E"),
+	}
+
+	events := DetectEvents(prev, curr, now)
+	got := summarize(events)
+	want := []string{
+		"bead-created F",
+		"status-changed A",
+		"status-changed D",
+		"became-actionable B",
+		"cycle-introduced E,F",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("events = %v\nwant %v", got, want)
+	}
+
+	changed := events[1]
+	if changed.PreviousStatus != model.StatusOpen || changed.Status != model.StatusClosed || changed.Timestamp != now || changed.DataHash == "" {
+		t.Errorf("status-changed event = %+v", changed)
+	}
+	if cleared := events[3].ClearedBlockers; !slices.Equal(cleared, []string{"A"}) {
+		t.Errorf("cleared blockers = %v, want [A]", cleared)
+	}
+
+	// Nothing changes, nothing fires; a phase filter limits detection
+	if again := DetectEvents(curr, curr, now); len(again) != 0 {
+		t.Errorf("unchanged data produced %v", summarize(again))
+	}
+	if only := summarize(DetectEvents(prev, curr, now, BecameActionable)); !slices.Equal(only, []string{"became-actionable B"}) {
+		t.Errorf("filtered events = %v", only)
+	}
+}
+
+func TestDetectDriftEvents(t *testing.T) {
+	prev := []drift.Alert{{Type: drift.AlertStaleIssue, IssueID: "A", Message: "stale for 30 days"}}
+	curr := []drift.Alert{
+		{Type: drift.AlertStaleIssue, IssueID: "A", Message: "stale for 31 days"},
+		{Type: drift.AlertNewCycle, Message: "new cycle"},
+	}
+	events := DetectDriftEvents(prev, curr, "hash", time.Now())
+	if got := summarize(events); !slices.Equal(got, []string{"drift-alert new_cycle"}) {
+		t.Fatalf("events = %v", got)
+	}
+	env := strings.Join(events[0].ToEnv(), "\n")
+	if !strings.Contains(env, "BV_ALERT_TYPE=new_cycle") || !strings.Contains(env, "BV_EVENT=drift-alert") {
+		t.Errorf("env = %s", env)
+	}
+}
+
+func TestDetectDriftEvents_DistinguishesPolicyRules(t *testing.T) {
+	violation := func(rule string) drift.Alert {
+		return drift.Alert{Type: drift.AlertPolicyViolation, Rule: rule, IssueID: "A", Label: "api", Message: rule + " violated"}
+	}
+	prev := []drift.Alert{violation("needs-owner")}
+	curr := []drift.Alert{violation("needs-owner"), violation("needs-estimate")}
+
+	events := DetectDriftEvents(prev, curr, "hash", time.Now())
+	if len(events) != 1 || events[0].Alert.Rule != "needs-estimate" {
+		t.Fatalf("events = %v, want one alert for the new rule", summarize(events))
+	}
+}
+
+func TestMonitorPrimesThenRunsHooks(t *testing.T) {
+	var logs []string
+	config := &Config{Hooks: HooksByPhase{
+		BecameActionable: []Hook{{Name: "notify", Command: "exit 3", Timeout: 5 * time.Second, OnError: "continue"}},
+	}}
+	m := NewMonitor(config, WithMonitorLogger(func(msg string) { logs = append(logs, msg) }))
+
+	prev := []model.Issue{issue("A", model.StatusOpen), issue("B", model.StatusOpen, "A")}
+	if events := m.Observe(prev); events != nil {
+		t.Fatalf("first observation should only prime, got %v", summarize(events))
+	}
+
+	// Status and creation changes have no hooks, so only became-actionable is detected
+	curr := []model.Issue{issue("A", model.StatusClosed), issue("B", model.StatusOpen, "A"), issue("C", model.StatusOpen)}
+	events := m.Observe(curr)
+	if got := summarize(events); !slices.Equal(got, []string{"became-actionable B"}) {
+		t.Fatalf("events = %v", got)
+	}
+	if len(logs) == 0 || !strings.Contains(logs[len(logs)-1], `became-actionable hook "notify" failed`) {
+		t.Errorf("continue-on-error failure should be logged, got %v", logs)
+	}
+
+	if events := NewMonitor(&Config{}).Observe(curr); events != nil {
+		t.Errorf("monitor without event hooks returned %v", events)
+	}
+}
+
+func TestLoaderEventHooks(t *testing.T) {
+	tmp := t.TempDir()
+	writeHooksFile(t, tmp, `
+hooks:
+  became-actionable:
+    - command: ./notify.sh
+  drift-alert:
+    - name: page
+      command: ./page.sh
+      on_error: fail
+`)
+	loader := NewLoader(WithProjectDir(tmp))
+	if err := loader.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if loader.HasHooks() {
+		t.Error("HasHooks should only report export hooks")
+	}
+	if !loader.HasEventHooks() {
+		t.Error("HasEventHooks = false")
+	}
+	actionable := loader.GetHooks(BecameActionable)
+	if len(actionable) != 1 || actionable[0].Name != "became-actionable-1" || actionable[0].OnError != "continue" || actionable[0].Timeout != DefaultTimeout {
+		t.Errorf("became-actionable hooks = %+v", actionable)
+	}
+	if page := loader.GetHooks(DriftAlert); len(page) != 1 || page[0].OnError != "fail" {
+		t.Errorf("drift-alert hooks = %+v", page)
+	}
+}
@@ -3,6 +3,7 @@ package hooks
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -59,7 +60,7 @@ func (e *Executor) RunPreExport() error {
 
 	for _, hook := range e.config.Hooks.PreExport {
 		e.logger(fmt.Sprintf("Running pre-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PreExport)
+		result := e.runHook(hook, PreExport, e.context.ToEnv(), nil)
 		e.results = append(e.results, result)
 
 		if !result.Success && hook.OnError == "fail" {
@@ -80,7 +81,7 @@ func (e *Executor) RunPostExport() error {
 	var firstError error
 	for _, hook := range e.config.Hooks.PostExport {
 		e.logger(fmt.Sprintf("Running post-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PostExport)
+		result := e.runHook(hook, PostExport, e.context.ToEnv(), nil)
 		e.results = append(e.results, result)
 
 		if !result.Success && hook.OnError == "fail" && firstError == nil {
@@ -91,6 +92,36 @@ func (e *Executor) RunPostExport() error {
 	return firstError
 }
 
+// RunEvent executes the hooks configured for ev.Phase, passing ev as JSON on
+// stdin and as BV_* environment variables. Like post-export hooks, failures
+// are recorded but only returned for on_error="fail", which also skips the
+// remaining hooks for the event.
+func (e *Executor) RunEvent(ev Event) error {
+	if e.config == nil {
+		return nil
+	}
+	hooks := e.config.Hooks.forPhase(ev.Phase)
+	if hooks == nil || len(*hooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", ev.Phase, err)
+	}
+	env := ev.ToEnv()
+	for _, hook := range *hooks {
+		e.logger(fmt.Sprintf("Running %s hook %q: %s", ev.Phase, hook.Name, hook.Command))
+		result := e.runHook(hook, ev.Phase, env, payload)
+		e.results = append(e.results, result)
+
+		if !result.Success && hook.OnError == "fail" {
+			return fmt.Errorf("%s hook %q failed: %w", ev.Phase, hook.Name, result.Error)
+		}
+	}
+	return nil
+}
+
 // getShellCommand returns the shell and flag to use for executing commands
 func getShellCommand() (string, string) {
 	if runtime.GOOS == "windows" {
@@ -99,8 +130,9 @@ func getShellCommand() (string, string) {
 	return "sh", "-c"
 }
 
-// runHook executes a single hook with timeout and environment
-func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
+// runHook executes a single hook with timeout and environment. contextEnv
+// carries the BV_* variables of the phase; stdin, if set, is piped in.
+func (e *Executor) runHook(hook Hook, phase HookPhase, contextEnv []string, stdin []byte) HookResult {
 	result := HookResult{
 		Hook:  hook,
 		Phase: phase,
@@ -123,8 +155,8 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 	// Build environment
 	cmd.Env = os.Environ()
 
-	// Add export context variables
-	cmd.Env = append(cmd.Env, e.context.ToEnv()...)
+	// Add export or event context variables
+	cmd.Env = append(cmd.Env, contextEnv...)
 
 	// Add hook-specific env vars (with ${VAR} expansion from current env)
 	// Sort keys for deterministic environment order
@@ -141,6 +173,10 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, expandedValue))
 	}
 
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
 	// Capture output
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
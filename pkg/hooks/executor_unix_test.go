@@ -2,7 +2,16 @@
 
 package hooks
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
 
 func TestGetShellCommand_Unix(t *testing.T) {
 	shell, flag := getShellCommand()
@@ -10,3 +19,44 @@ func TestGetShellCommand_Unix(t *testing.T) {
 		t.Fatalf("getShellCommand() = (%q, %q); want (\"sh\", \"-c\")", shell, flag)
 	}
 }
+
+func TestRunEventPassesPayloadOnStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	config := &Config{Hooks: HooksByPhase{
+		StatusChanged: []Hook{
+			{Name: "record", Command: `cat > "$OUT"; echo "$BV_EVENT $BV_ISSUE_ID $BV_PREVIOUS_STATUS->$BV_STATUS"`, Timeout: 5 * time.Second, Env: map[string]string{"OUT": out}},
+			{Name: "stop", Command: "exit 1", Timeout: 5 * time.Second, OnError: "fail"},
+			{Name: "skipped", Command: "echo never", Timeout: 5 * time.Second},
+		},
+	}}
+	executor := NewExecutor(config, ExportContext{})
+	ev := Event{Phase: StatusChanged, Timestamp: time.Now(), IssueID: "A-1", Status: model.StatusClosed, PreviousStatus: model.StatusOpen}
+
+	if err := executor.RunEvent(ev); err == nil || !strings.Contains(err.Error(), `status-changed hook "stop" failed`) {
+		t.Fatalf("RunEvent error = %v", err)
+	}
+	results := executor.Results()
+	if len(results) != 2 {
+		t.Fatalf("on_error=fail should skip later hooks, ran %d", len(results))
+	}
+	if results[0].Stdout != "status-changed A-1 open->closed" {
+		t.Errorf("env stdout = %q", results[0].Stdout)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload Event
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("stdin was not the JSON event: %v\n%s", err, data)
+	}
+	if payload.Phase != StatusChanged || payload.IssueID != "A-1" || payload.PreviousStatus != model.StatusOpen {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	// Phases without hooks are a no-op
+	if err := executor.RunEvent(Event{Phase: BeadCreated}); err != nil {
+		t.Errorf("RunEvent without hooks: %v", err)
+	}
+}
@@ -19,8 +19,10 @@ import (
 // Set higher due to load balancer warmup issues.
 const DefaultHTTPTimeout = 90 * time.Second
 
-// defaultServicePath is the ConnectRPC service path for the beads daemon.
-const defaultServicePath = "/bd.v1.BeadsService/List"
+// ConnectRPC method paths on the beads daemon.
+const (
+	defaultServicePath = "/bd.v1.BeadsService/List"
+)
 
 // apiKeyEnvVar is the environment variable for the daemon API key/token.
 const apiKeyEnvVar = "BD_API_KEY"
@@ -47,6 +49,17 @@ type protoIssue struct {
 	BlockedBy   []string `json:"blocked_by"`
 	HookBead    string   `json:"hook_bead"`
 	AgentState  string   `json:"agent_state"`
+
+	Comments []protoComment `json:"comments"`
+}
+
+// protoComment mirrors a comment in daemon responses.
+type protoComment struct {
+	ID        int64  `json:"id"`
+	IssueID   string `json:"issue_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
 }
 
 type listIssuesResponse struct {
@@ -54,6 +67,17 @@ type listIssuesResponse struct {
 	Total  int          `json:"total"`
 }
 
+// listIssuesRequest is the List RPC body. Filter fields are omitted when
+// unset so older daemons that only understand status/limit keep working.
+type listIssuesRequest struct {
+	Status          string   `json:"status"`
+	Statuses        []string `json:"statuses,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	UpdatedSince    string   `json:"updated_since,omitempty"`
+	IncludeComments bool     `json:"include_comments,omitempty"`
+	Limit           int      `json:"limit"`
+}
+
 // protoStatusMap maps ConnectRPC enum strings to model.Status values.
 var protoStatusMap = map[string]model.Status{
 	"ISSUE_STATUS_OPEN":        model.StatusOpen,
@@ -185,37 +209,214 @@ func toModelIssue(p *protoIssue) (model.Issue, error) {
 	// blocks and children are intentionally skipped — they are inverse
 	// relationships that will be captured when processing the other issue.
 
+	for i := range p.Comments {
+		c, err := toModelComment(p.ID, &p.Comments[i])
+		if err != nil {
+			return model.Issue{}, err
+		}
+		issue.Comments = append(issue.Comments, c)
+	}
+
 	return issue, nil
 }
 
-// LoadIssuesFromURL loads issues from a Gas Town daemon via ConnectRPC over HTTP.
-// The baseURL should be the daemon address (e.g., "http://localhost:8443").
-// The apiKey is sent as X-GT-API-Key header; if empty, falls back to BD_API_KEY env var.
-func LoadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOptions) ([]model.Issue, error) {
-	return loadIssuesFromURL(ctx, baseURL, apiKey, opts, http.DefaultClient)
+// toModelComment converts a daemon comment, defaulting IssueID to issueID.
+func toModelComment(issueID string, p *protoComment) (*model.Comment, error) {
+	createdAt, err := parseProtoTime(p.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("issue %s: comment %d: invalid created_at %q: %w", issueID, p.ID, p.CreatedAt, err)
+	}
+	c := &model.Comment{
+		ID:        p.ID,
+		IssueID:   p.IssueID,
+		Author:    p.Author,
+		Text:      p.Text,
+		CreatedAt: createdAt,
+	}
+	if c.IssueID == "" {
+		c.IssueID = issueID
+	}
+	return c, nil
 }
 
-// loadIssuesFromURL is the internal implementation that accepts an *http.Client for testability.
-func loadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOptions, client *http.Client) ([]model.Issue, error) {
-	// Resolve API key: explicit > BD_API_KEY > BD_DAEMON_TOKEN env var
+// DaemonListOptions narrows a List RPC. Filters are sent to the daemon and
+// re-applied locally, so daemons that ignore a filter still yield the
+// expected subset.
+type DaemonListOptions struct {
+	// Statuses restricts results to these statuses (any match).
+	Statuses []model.Status
+	// Labels restricts results to issues carrying at least one of these labels.
+	Labels []string
+	// UpdatedSince returns only issues with updated_at >= this instant.
+	// Used as an incremental sync cursor; zero means a full listing.
+	UpdatedSince time.Time
+	// IncludeComments asks the daemon to inline comments on each issue.
+	IncludeComments bool
+	// Limit caps the number of issues returned (0 = no limit).
+	Limit int
+}
+
+// DaemonClient talks to a beads daemon's ConnectRPC JSON API.
+type DaemonClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewDaemonClient creates a client for the daemon at baseURL.
+// An empty apiKey falls back to BD_API_KEY, then BD_DAEMON_TOKEN.
+// A nil client uses http.DefaultClient.
+func NewDaemonClient(baseURL, apiKey string, client *http.Client) *DaemonClient {
 	if apiKey == "" {
 		apiKey = os.Getenv(apiKeyEnvVar)
 	}
 	if apiKey == "" {
 		apiKey = os.Getenv("BD_DAEMON_TOKEN")
 	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &DaemonClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  client,
+	}
+}
 
-	baseURL = strings.TrimRight(baseURL, "/")
-	endpoint := baseURL + defaultServicePath
+// ListIssues calls the List RPC and converts the results to model issues.
+func (c *DaemonClient) ListIssues(ctx context.Context, listOpts DaemonListOptions, opts ParseOptions) ([]model.Issue, error) {
+	req := listIssuesRequest{Limit: listOpts.Limit, Labels: listOpts.Labels, IncludeComments: listOpts.IncludeComments}
+	for _, st := range listOpts.Statuses {
+		req.Statuses = append(req.Statuses, string(st))
+	}
+	if len(req.Statuses) == 1 {
+		req.Status = req.Statuses[0]
+	}
+	if !listOpts.UpdatedSince.IsZero() {
+		req.UpdatedSince = listOpts.UpdatedSince.UTC().Format(time.RFC3339)
+	}
 
-	body := []byte(`{"status":"","limit":0}`)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	respBody, err := c.call(ctx, defaultServicePath, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
+	}
+
+	// The daemon may return either:
+	// 1. A flat array: [{...}, {...}]
+	// 2. A wrapped response: {issues: [...], total: N}
+	// Try flat array first (current daemon behavior), fall back to wrapped.
+	var protoIssues []protoIssue
+	if err := json.Unmarshal(respBody, &protoIssues); err != nil {
+		// Try wrapped format
+		var listResp listIssuesResponse
+		if err2 := json.Unmarshal(respBody, &listResp); err2 != nil {
+			return nil, fmt.Errorf("failed to parse response JSON: %w (also tried wrapped: %v)", err, err2)
+		}
+		protoIssues = listResp.Issues
+	}
+
+	warn := opts.WarningHandler
+	if warn == nil {
+		warn = func(msg string) {
+			fmt.Fprintf(io.Discard, "%s", msg)
+		}
+	}
+
+	issues := make([]model.Issue, 0, len(protoIssues))
+	for i := range protoIssues {
+		issue, err := c.convert(&protoIssues[i])
+		if err != nil {
+			warn(fmt.Sprintf("skipping issue: %v", err))
+			continue
+		}
+		if !matchesDaemonListOptions(&issue, listOpts) {
+			continue
+		}
+		if opts.IssueFilter != nil && !opts.IssueFilter(&issue) {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// convert maps and validates a daemon issue.
+func (c *DaemonClient) convert(p *protoIssue) (model.Issue, error) {
+	issue, err := toModelIssue(p)
+	if err != nil {
+		return model.Issue{}, err
+	}
+	issue.Status = model.NormalizeStatus(issue.Status)
+	if err := issue.Validate(); err != nil {
+		return model.Issue{}, fmt.Errorf("invalid issue %s: %w", issue.ID, err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
+	return issue, nil
+}
+
+// Matches reports whether issue passes the status, label and updated_since
+// filters in opts.
+func (opts DaemonListOptions) Matches(issue *model.Issue) bool {
+	return matchesDaemonListOptions(issue, opts)
+}
+
+// matchesDaemonListOptions re-applies server-side filters locally.
+func matchesDaemonListOptions(issue *model.Issue, opts DaemonListOptions) bool {
+	if len(opts.Statuses) > 0 {
+		ok := false
+		for _, st := range opts.Statuses {
+			if issue.Status == model.NormalizeStatus(st) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(opts.Labels) > 0 {
+		ok := false
+		for _, want := range opts.Labels {
+			for _, have := range issue.Labels {
+				if have == want {
+					ok = true
+					break
+				}
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if !opts.UpdatedSince.IsZero() && issue.UpdatedAt.Before(opts.UpdatedSince.Truncate(time.Second)) {
+		return false
+	}
+	return true
+}
+
+// call POSTs a JSON body to a daemon RPC and returns the response body.
+func (c *DaemonClient) call(ctx context.Context, path string, payload any) ([]byte, error) {
+	endpoint := c.baseURL + path
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Retry logic: the daemon's load balancer can be flaky on first request
@@ -230,14 +431,10 @@ func loadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOp
 			case <-time.After(time.Duration(attempt) * time.Second):
 			}
 			// Recreate request for retry (body reader is consumed)
-			req, _ = http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
-			req.Header.Set("Content-Type", "application/json")
-			if apiKey != "" {
-				req.Header.Set("Authorization", "Bearer "+apiKey)
-			}
+			req, _ = newRequest()
 		}
 
-		resp, lastErr = client.Do(req)
+		resp, lastErr = c.client.Do(req)
 		if lastErr == nil && resp.StatusCode == http.StatusOK {
 			break
 		}
@@ -266,49 +463,17 @@ func loadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOp
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	return respBody, nil
+}
 
-	// The daemon may return either:
-	// 1. A flat array: [{...}, {...}]
-	// 2. A wrapped response: {issues: [...], total: N}
-	// Try flat array first (current daemon behavior), fall back to wrapped.
-	var protoIssues []protoIssue
-	if err := json.Unmarshal(respBody, &protoIssues); err != nil {
-		// Try wrapped format
-		var listResp listIssuesResponse
-		if err2 := json.Unmarshal(respBody, &listResp); err2 != nil {
-			return nil, fmt.Errorf("failed to parse response JSON: %w (also tried wrapped: %v)", err, err2)
-		}
-		protoIssues = listResp.Issues
-	}
-
-	warn := opts.WarningHandler
-	if warn == nil {
-		warn = func(msg string) {
-			fmt.Fprintf(io.Discard, "%s", msg)
-		}
-	}
-
-	issues := make([]model.Issue, 0, len(protoIssues))
-	for i := range protoIssues {
-		issue, err := toModelIssue(&protoIssues[i])
-		if err != nil {
-			warn(fmt.Sprintf("skipping issue: %v", err))
-			continue
-		}
-
-		issue.Status = normalizeIssueStatus(issue.Status)
-
-		if err := issue.Validate(); err != nil {
-			warn(fmt.Sprintf("skipping invalid issue %s: %v", issue.ID, err))
-			continue
-		}
-
-		if opts.IssueFilter != nil && !opts.IssueFilter(&issue) {
-			continue
-		}
-
-		issues = append(issues, issue)
-	}
+// LoadIssuesFromURL loads issues from a Gas Town daemon via ConnectRPC over HTTP.
+// The baseURL should be the daemon address (e.g., "http://localhost:8443").
+// The apiKey is sent as a Bearer token; if empty, falls back to BD_API_KEY env var.
+func LoadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOptions) ([]model.Issue, error) {
+	return loadIssuesFromURL(ctx, baseURL, apiKey, opts, http.DefaultClient)
+}
 
-	return issues, nil
+// loadIssuesFromURL is the internal implementation that accepts an *http.Client for testability.
+func loadIssuesFromURL(ctx context.Context, baseURL, apiKey string, opts ParseOptions, client *http.Client) ([]model.Issue, error) {
+	return NewDaemonClient(baseURL, apiKey, client).ListIssues(ctx, DaemonListOptions{}, opts)
 }
@@ -0,0 +1,121 @@
+package loader
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+func daemonFixture() []model.Issue {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{
+			ID: "bd-1", Title: "Open API", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Labels: []string{"api"}, CreatedAt: base, UpdatedAt: base,
+			Comments: []*model.Comment{{ID: 7, IssueID: "bd-1", Author: "alice", Text: "first!", CreatedAt: base}},
+		},
+		{
+			ID: "bd-2", Title: "Blocked UI", Status: model.StatusBlocked, IssueType: model.TypeBug,
+			Labels: []string{"ui"}, CreatedAt: base, UpdatedAt: base.Add(time.Hour),
+			Dependencies: []*model.Dependency{{IssueID: "bd-2", DependsOnID: "bd-1", Type: model.DepBlocks}},
+		},
+		{
+			ID: "bd-3", Title: "Closed API", Status: model.StatusClosed, IssueType: model.TypeTask,
+			Labels: []string{"api", "ui"}, CreatedAt: base, UpdatedAt: base.Add(2 * time.Hour),
+			ClosedAt: ptrTime(base.Add(2 * time.Hour)),
+		},
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
+
+func issueIDs(issues []model.Issue) string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestDaemonClientListFilters(t *testing.T) {
+	d := testutil.NewFakeDaemon(daemonFixture())
+	defer d.Close()
+	c := NewDaemonClient(d.URL(), "", nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts DaemonListOptions
+		want string
+	}{
+		{"all", DaemonListOptions{}, "bd-1,bd-2,bd-3"},
+		{"status", DaemonListOptions{Statuses: []model.Status{model.StatusOpen, model.StatusBlocked}}, "bd-1,bd-2"},
+		{"label", DaemonListOptions{Labels: []string{"ui"}}, "bd-2,bd-3"},
+		{"status+label", DaemonListOptions{Statuses: []model.Status{model.StatusClosed}, Labels: []string{"api"}}, "bd-3"},
+		{"updated_since", DaemonListOptions{UpdatedSince: time.Date(2025, 3, 1, 13, 0, 0, 0, time.UTC)}, "bd-2,bd-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := c.ListIssues(ctx, tt.opts, ParseOptions{})
+			if err != nil {
+				t.Fatalf("ListIssues: %v", err)
+			}
+			if got := issueIDs(issues); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Filters must reach the daemon, not just be applied locally.
+	reqs := d.Requests()
+	last := reqs[len(reqs)-1].Body
+	if last["updated_since"] != "2025-03-01T13:00:00Z" {
+		t.Errorf("updated_since not sent: %v", last)
+	}
+	if labels, _ := reqs[2].Body["labels"].([]any); len(labels) != 1 || labels[0] != "ui" {
+		t.Errorf("labels not sent: %v", reqs[2].Body)
+	}
+}
+
+func TestDaemonClientListFiltersLocallyWhenDaemonIgnoresThem(t *testing.T) {
+	d := testutil.NewFakeDaemon(daemonFixture())
+	d.IgnoreFilters = true
+	defer d.Close()
+
+	issues, err := NewDaemonClient(d.URL(), "", nil).ListIssues(context.Background(),
+		DaemonListOptions{Statuses: []model.Status{model.StatusBlocked}}, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if got := issueIDs(issues); got != "bd-2" {
+		t.Errorf("got %s, want bd-2", got)
+	}
+}
+
+func TestDaemonClientListMapsCommentsAndDependencies(t *testing.T) {
+	d := testutil.NewFakeDaemon(daemonFixture())
+	defer d.Close()
+
+	issues, err := NewDaemonClient(d.URL(), "", nil).ListIssues(context.Background(),
+		DaemonListOptions{IncludeComments: true}, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	byID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	comments := byID["bd-1"].Comments
+	if len(comments) != 1 || comments[0].ID != 7 || comments[0].Text != "first!" || comments[0].IssueID != "bd-1" || comments[0].Author != "alice" {
+		t.Errorf("unexpected comments: %+v", comments)
+	}
+	deps := byID["bd-2"].Dependencies
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-1" {
+		t.Errorf("dependencies not mapped: %+v", deps)
+	}
+}
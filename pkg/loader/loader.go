@@ -396,7 +396,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 				continue
 			}
 
-			issue.Status = normalizeIssueStatus(issue.Status)
+			issue.Status = model.NormalizeStatus(issue.Status)
 
 			// Validate issue
 			if err := issue.Validate(); err != nil {
@@ -421,7 +421,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 				continue
 			}
 
-			issue.Status = normalizeIssueStatus(issue.Status)
+			issue.Status = model.NormalizeStatus(issue.Status)
 
 			// Validate issue
 			if err := issue.Validate(); err != nil {
@@ -448,11 +448,3 @@ func stripBOM(b []byte) []byte {
 	}
 	return b
 }
-
-func normalizeIssueStatus(status model.Status) model.Status {
-	trimmed := strings.TrimSpace(string(status))
-	if trimmed == "" {
-		return status
-	}
-	return model.Status(strings.ToLower(trimmed))
-}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClientClosed is returned for calls on a closed client
+var ErrClientClosed = errors.New("mcp client closed")
+
+// Notification is a server-to-client notification seen by a Client
+type Notification struct {
+	Method string
+	Params json.RawMessage
+}
+
+// Client speaks JSON-RPC to a Server running in the same process. It is
+// the harness for tests and for embedding bv's tools in another Go program.
+type Client struct {
+	w      *io.PipeWriter
+	nextID atomic.Int64
+
+	mu      sync.Mutex
+	pending map[int64]chan incoming
+	closed  bool
+
+	notifications chan Notification
+	done          chan struct{}
+	serveErr      error
+}
+
+// incoming is any message the server writes
+type incoming struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Connect starts s on a pair of pipes and returns a client wired to it.
+// Close the client to stop the server.
+func Connect(ctx context.Context, s *Server) *Client {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &Client{
+		w:             clientOut,
+		pending:       make(map[int64]chan incoming),
+		notifications: make(chan Notification, 64),
+		done:          make(chan struct{}),
+	}
+	go func() {
+		// serveErr is set before serverOut closes, which is what ends readLoop
+		c.serveErr = s.Serve(ctx, serverIn, serverOut)
+		serverIn.Close()
+		serverOut.Close()
+	}()
+	go c.readLoop(clientIn)
+	return c
+}
+
+// Notifications delivers server notifications. Notifications arriving while
+// the buffer is full are dropped.
+func (c *Client) Notifications() <-chan Notification {
+	return c.notifications
+}
+
+// Call sends a request and decodes its result into result (which may be nil).
+// A JSON-RPC error response is returned as *Error.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	id := c.nextID.Add(1)
+	ch := make(chan incoming, 1)
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params}); err != nil {
+		return err
+	}
+	select {
+	case msg := <-ch:
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	case <-c.done:
+		return ErrClientClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Notify sends a notification, which gets no response
+func (c *Client) Notify(method string, params any) error {
+	msg := map[string]any{"jsonrpc": "2.0", "method": method}
+	if params != nil {
+		msg["params"] = params
+	}
+	return c.send(msg)
+}
+
+// Initialize performs the handshake: initialize, then notifications/initialized
+func (c *Client) Initialize(ctx context.Context, info Implementation) (*InitializeResult, error) {
+	var result InitializeResult
+	params := InitializeParams{ProtocolVersion: ProtocolVersion, Capabilities: map[string]any{}, ClientInfo: info}
+	if err := c.Call(ctx, "initialize", params, &result); err != nil {
+		return nil, err
+	}
+	if err := c.Notify("notifications/initialized", nil); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CallTool invokes a tool by name
+func (c *Client) CallTool(ctx context.Context, name string, args any) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.Call(ctx, "tools/call", map[string]any{"name": name, "arguments": args}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReadResource reads a resource by URI
+func (c *Client) ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error) {
+	var result ReadResourceResult
+	if err := c.Call(ctx, "resources/read", ReadResourceParams{URI: uri}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Close ends the session and waits for the server to return
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+	c.w.Close()
+	<-c.done
+	if errors.Is(c.serveErr, context.Canceled) {
+		return nil
+	}
+	return c.serveErr
+}
+
+func (c *Client) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := c.w.Write(append(data, '\n')); err != nil {
+		return ErrClientClosed
+	}
+	return nil
+}
+
+func (c *Client) readLoop(r io.Reader) {
+	defer close(c.done)
+	defer close(c.notifications)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var msg incoming
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		if msg.Method != "" {
+			select {
+			case c.notifications <- Notification{Method: msg.Method, Params: msg.Params}:
+			default:
+			}
+			continue
+		}
+		id, err := strconv.ParseInt(string(msg.ID), 10, 64)
+		if err != nil {
+			continue
+		}
+		c.mu.Lock()
+		ch := c.pending[id]
+		c.mu.Unlock()
+		if ch != nil {
+			ch <- msg
+		}
+	}
+}
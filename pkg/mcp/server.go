@@ -0,0 +1,321 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// CodeResourceNotFound is the MCP error code for an unknown resource URI
+const CodeResourceNotFound = -32002
+
+// DefaultPageSize is how many tools or resources a list call returns per page
+const DefaultPageSize = 100
+
+// maxMessageSize bounds a single JSON-RPC line
+const maxMessageSize = 16 * 1024 * 1024
+
+// ErrResourceNotFound is returned by a ResourceProvider for an unknown URI
+var ErrResourceNotFound = errors.New("resource not found")
+
+// ResourceProvider supplies the resources a server exposes
+type ResourceProvider interface {
+	ListResources() []Resource
+	ResourceTemplates() []ResourceTemplate
+	ReadResource(uri string) (*ResourceContents, error)
+}
+
+// Server answers MCP requests from one client. Requests are handled in
+// order; Notify may be called from other goroutines while Serve runs.
+type Server struct {
+	info         Implementation
+	instructions string
+	tools        []Tool
+	resources    ResourceProvider
+	pageSize     int
+
+	mu          sync.Mutex // Guards out and initialized; serializes writes
+	out         io.Writer
+	initialized bool
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithInstructions sets the usage hint returned from initialize
+func WithInstructions(text string) Option {
+	return func(s *Server) {
+		s.instructions = text
+	}
+}
+
+// WithTools registers tools, listed in the order given
+func WithTools(tools ...Tool) Option {
+	return func(s *Server) {
+		s.tools = append(s.tools, tools...)
+	}
+}
+
+// WithResources exposes resources from p
+func WithResources(p ResourceProvider) Option {
+	return func(s *Server) {
+		s.resources = p
+	}
+}
+
+// WithPageSize sets the list page size (default DefaultPageSize)
+func WithPageSize(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.pageSize = n
+		}
+	}
+}
+
+// NewServer creates a server identifying itself as info
+func NewServer(info Implementation, opts ...Option) *Server {
+	s := &Server{info: info, pageSize: DefaultPageSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Serve reads newline-delimited requests from r and writes responses to w
+// until r is exhausted or ctx is cancelled
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.mu.Lock()
+	s.out = w
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.out = nil
+		s.initialized = false
+		s.mu.Unlock()
+	}()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.write(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: err.Error()}})
+			continue
+		}
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			if !req.isNotification() {
+				s.write(response{JSONRPC: "2.0", ID: req.ID, Error: &Error{Code: CodeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}})
+			}
+			continue
+		}
+
+		result, rpcErr := s.handle(&req)
+		if req.isNotification() {
+			continue
+		}
+		resp := response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}
+		if rpcErr == nil && result == nil {
+			resp.Result = struct{}{}
+		}
+		if err := s.write(resp); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Notify sends a notification to the client once the session is
+// initialized. It is a no-op before then or outside Serve.
+func (s *Server) Notify(method string, params any) error {
+	s.mu.Lock()
+	ready := s.out != nil && s.initialized
+	s.mu.Unlock()
+	if !ready {
+		return nil
+	}
+	return s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// NotifyResourcesChanged tells the client to re-list resources
+func (s *Server) NotifyResourcesChanged() error {
+	return s.Notify("notifications/resources/list_changed", nil)
+}
+
+func (s *Server) write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.out == nil {
+		return io.ErrClosedPipe
+	}
+	_, err = s.out.Write(append(data, '\n'))
+	return err
+}
+
+func (s *Server) handle(req *request) (any, *Error) {
+	switch req.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+	case "notifications/initialized":
+		s.mu.Lock()
+		s.initialized = true
+		s.mu.Unlock()
+		return nil, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		start, end, next, err := s.page(req.Params, len(s.tools))
+		if err != nil {
+			return nil, err
+		}
+		return ListToolsResult{Tools: s.tools[start:end], NextCursor: next}, nil
+	case "tools/call":
+		var params CallToolParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(params)
+	case "resources/list":
+		if s.resources == nil {
+			break
+		}
+		all := s.resources.ListResources()
+		start, end, next, err := s.page(req.Params, len(all))
+		if err != nil {
+			return nil, err
+		}
+		return ListResourcesResult{Resources: all[start:end], NextCursor: next}, nil
+	case "resources/templates/list":
+		if s.resources == nil {
+			break
+		}
+		templates := s.resources.ResourceTemplates()
+		if templates == nil {
+			templates = []ResourceTemplate{}
+		}
+		return ListResourceTemplatesResult{ResourceTemplates: templates}, nil
+	case "resources/read":
+		if s.resources == nil {
+			break
+		}
+		var params ReadResourceParams
+		if err := decodeParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		contents, err := s.resources.ReadResource(params.URI)
+		if errors.Is(err, ErrResourceNotFound) {
+			return nil, &Error{Code: CodeResourceNotFound, Message: "resource not found", Data: map[string]string{"uri": params.URI}}
+		}
+		if err != nil {
+			return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		return ReadResourceResult{Contents: []ResourceContents{*contents}}, nil
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+func (s *Server) initialize(params InitializeParams) InitializeResult {
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+	capabilities := map[string]any{
+		"tools": map[string]any{"listChanged": false},
+	}
+	if s.resources != nil {
+		capabilities["resources"] = map[string]any{"listChanged": true}
+	}
+	return InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    capabilities,
+		ServerInfo:      s.info,
+		Instructions:    s.instructions,
+	}
+}
+
+func (s *Server) callTool(params CallToolParams) (*CallToolResult, *Error) {
+	var tool *Tool
+	for i := range s.tools {
+		if s.tools[i].Name == params.Name {
+			tool = &s.tools[i]
+			break
+		}
+	}
+	if tool == nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+	}
+
+	args := params.Arguments
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+	v, err := tool.Handler(args)
+	if err != nil {
+		return &CallToolResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, &Error{Code: CodeInternalError, Message: err.Error()}
+	}
+	result := &CallToolResult{Content: []Content{{Type: "text", Text: string(data)}}}
+	if len(data) > 0 && data[0] == '{' {
+		result.StructuredContent = json.RawMessage(data)
+	}
+	return result, nil
+}
+
+// page resolves the cursor of a list request into a slice range. Cursors
+// are opaque to clients; here they are the offset of the next page.
+func (s *Server) page(raw json.RawMessage, total int) (start, end int, next string, rpcErr *Error) {
+	var params struct {
+		Cursor string `json:"cursor"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return 0, 0, "", err
+	}
+	if params.Cursor != "" {
+		n, err := strconv.Atoi(params.Cursor)
+		if err != nil || n < 0 || n > total {
+			return 0, 0, "", &Error{Code: CodeInvalidParams, Message: "invalid cursor"}
+		}
+		start = n
+	}
+	end = min(start+s.pageSize, total)
+	if end < total {
+		next = strconv.Itoa(end)
+	}
+	return start, end, next, nil
+}
+
+func decodeParams(raw json.RawMessage, v any) *Error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
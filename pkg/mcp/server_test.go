@@ -0,0 +1,245 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryResources map[string]string
+
+func (m memoryResources) ListResources() []Resource {
+	var out []Resource
+	for _, uri := range []string{"mem://a", "mem://b", "mem://c"} {
+		if _, ok := m[uri]; ok {
+			out = append(out, Resource{URI: uri, Name: strings.TrimPrefix(uri, "mem://")})
+		}
+	}
+	return out
+}
+
+func (m memoryResources) ResourceTemplates() []ResourceTemplate {
+	return []ResourceTemplate{{URITemplate: "mem://{name}", Name: "memory"}}
+}
+
+func (m memoryResources) ReadResource(uri string) (*ResourceContents, error) {
+	text, ok := m[uri]
+	if !ok {
+		return nil, ErrResourceNotFound
+	}
+	return &ResourceContents{URI: uri, MimeType: "text/plain", Text: text}, nil
+}
+
+func newTestServer() *Server {
+	echo := Tool{
+		Name:        "echo",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{"msg": map[string]any{"type": "string"}}},
+		Handler: func(args json.RawMessage) (any, error) {
+			var in struct {
+				Msg string `json:"msg"`
+			}
+			if err := json.Unmarshal(args, &in); err != nil {
+				return nil, err
+			}
+			if in.Msg == "" {
+				return nil, errors.New("msg is required")
+			}
+			return map[string]string{"echo": in.Msg}, nil
+		},
+	}
+	return NewServer(Implementation{Name: "test", Version: "0"},
+		WithInstructions("say hi"),
+		WithTools(echo),
+		WithResources(memoryResources{"mem://a": "alpha", "mem://b": "beta", "mem://c": "gamma"}),
+		WithPageSize(2),
+	)
+}
+
+func connect(t *testing.T, s *Server) (*Client, context.Context) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	c := Connect(ctx, s)
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("close: %v", err)
+		}
+	})
+	return c, ctx
+}
+
+func TestHandshake(t *testing.T) {
+	c, ctx := connect(t, newTestServer())
+
+	init, err := c.Initialize(ctx, Implementation{Name: "client", Version: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if init.ProtocolVersion != ProtocolVersion || init.ServerInfo.Name != "test" || init.Instructions != "say hi" {
+		t.Errorf("initialize result = %+v", init)
+	}
+	if _, ok := init.Capabilities["resources"]; !ok {
+		t.Error("resources capability missing")
+	}
+	if err := c.Call(ctx, "ping", nil, nil); err != nil {
+		t.Errorf("ping: %v", err)
+	}
+
+	// Older revisions are honoured; unknown ones fall back to the newest
+	var old InitializeResult
+	if err := c.Call(ctx, "initialize", InitializeParams{ProtocolVersion: "2024-11-05"}, &old); err != nil {
+		t.Fatal(err)
+	}
+	if old.ProtocolVersion != "2024-11-05" {
+		t.Errorf("negotiated %q, want 2024-11-05", old.ProtocolVersion)
+	}
+	var future InitializeResult
+	if err := c.Call(ctx, "initialize", InitializeParams{ProtocolVersion: "2099-01-01"}, &future); err != nil {
+		t.Fatal(err)
+	}
+	if future.ProtocolVersion != ProtocolVersion {
+		t.Errorf("negotiated %q, want %q", future.ProtocolVersion, ProtocolVersion)
+	}
+}
+
+func TestTools(t *testing.T) {
+	c, ctx := connect(t, newTestServer())
+
+	var list ListToolsResult
+	if err := c.Call(ctx, "tools/list", nil, &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Tools) != 1 || list.Tools[0].Name != "echo" || list.Tools[0].InputSchema["type"] != "object" {
+		t.Fatalf("tools/list = %+v", list)
+	}
+
+	res, err := c.CallTool(ctx, "echo", map[string]string{"msg": "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || len(res.Content) != 1 || res.Content[0].Text != `{"echo":"hi"}` {
+		t.Errorf("echo result = %+v", res)
+	}
+	if got, _ := res.StructuredContent.(map[string]any); got["echo"] != "hi" {
+		t.Errorf("structured content = %v", res.StructuredContent)
+	}
+
+	// Handler errors are results the model can see, not protocol errors
+	res, err = c.CallTool(ctx, "echo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.IsError || res.Content[0].Text != "msg is required" {
+		t.Errorf("error result = %+v", res)
+	}
+
+	_, err = c.CallTool(ctx, "nope", nil)
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("unknown tool error = %v", err)
+	}
+}
+
+func TestResources(t *testing.T) {
+	c, ctx := connect(t, newTestServer())
+
+	var uris []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		var page ListResourcesResult
+		if err := c.Call(ctx, "resources/list", map[string]string{"cursor": cursor}, &page); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range page.Resources {
+			uris = append(uris, r.URI)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if fmt.Sprint(uris) != "[mem://a mem://b mem://c]" {
+		t.Errorf("listed %v", uris)
+	}
+
+	var templates ListResourceTemplatesResult
+	if err := c.Call(ctx, "resources/templates/list", nil, &templates); err != nil {
+		t.Fatal(err)
+	}
+	if len(templates.ResourceTemplates) != 1 {
+		t.Errorf("templates = %+v", templates)
+	}
+
+	read, err := c.ReadResource(ctx, "mem://b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Contents) != 1 || read.Contents[0].Text != "beta" {
+		t.Errorf("read = %+v", read)
+	}
+
+	_, err = c.ReadResource(ctx, "mem://zzz")
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeResourceNotFound {
+		t.Errorf("missing resource error = %v", err)
+	}
+
+	err = c.Call(ctx, "resources/list", map[string]string{"cursor": "bogus"}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("bad cursor error = %v", err)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	c, ctx := connect(t, newTestServer())
+
+	err := c.Call(ctx, "does/not/exist", nil, nil)
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeMethodNotFound {
+		t.Errorf("unknown method error = %v", err)
+	}
+	err = c.Call(ctx, "tools/call", []int{1}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("malformed params error = %v", err)
+	}
+
+	// A server without resources doesn't answer resource methods
+	bare := NewServer(Implementation{Name: "bare", Version: "0"})
+	c2, _ := connect(t, bare)
+	err = c2.Call(ctx, "resources/list", nil, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeMethodNotFound {
+		t.Errorf("resources/list without provider = %v", err)
+	}
+}
+
+func TestNotifyAfterInitialized(t *testing.T) {
+	s := newTestServer()
+	c, ctx := connect(t, s)
+
+	if _, err := c.Initialize(ctx, Implementation{Name: "client", Version: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	// The initialized notification has no response, so wait on a ping to
+	// know the server has processed it
+	if err := c.Call(ctx, "ping", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.NotifyResourcesChanged(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case n := <-c.Notifications():
+		if n.Method != "notifications/resources/list_changed" {
+			t.Errorf("notification = %q", n.Method)
+		}
+	case <-ctx.Done():
+		t.Fatal("no notification received")
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -126,24 +127,31 @@ const (
 	StatusTombstone  Status = "tombstone" // Soft-deleted issue
 )
 
-// IsValid returns true if the status is a recognized value
+// IsValid returns true if the status is a recognized value: a built-in
+// status or one declared by the active workflow.
 func (s Status) IsValid() bool {
 	switch s {
 	case StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred,
 		StatusPinned, StatusHooked, StatusReview, StatusClosed, StatusTombstone:
 		return true
 	}
-	return false
+	_, ok := customStatusClass(s)
+	return ok
 }
 
-// IsClosed returns true if the status represents a closed state
+// IsClosed returns true if the status represents a closed (done) state
 func (s Status) IsClosed() bool {
-	return s == StatusClosed
+	return s.Class() == ClassDone
 }
 
-// IsOpen returns true if the status represents an active (open or in_progress) state
+// IsOpen returns true if the status represents an active (open or in_progress) state.
+// Custom workflow statuses count when classified as open or active.
 func (s Status) IsOpen() bool {
-	return s == StatusOpen || s == StatusInProgress
+	if s == StatusOpen || s == StatusInProgress {
+		return true
+	}
+	c, ok := customStatusClass(s)
+	return ok && (c == ClassOpen || c == ClassActive)
 }
 
 // IsTombstone returns true if the status represents a permanently deleted/archived state
@@ -151,6 +159,16 @@ func (s Status) IsTombstone() bool {
 	return s == StatusTombstone
 }
 
+// NormalizeStatus trims and lowercases a status as read from a beads file,
+// so "Open" and "open" are one status. Blank statuses are returned as is.
+func NormalizeStatus(s Status) Status {
+	trimmed := strings.TrimSpace(string(s))
+	if trimmed == "" {
+		return s
+	}
+	return Status(strings.ToLower(trimmed))
+}
+
 // IssueType categorizes the kind of work
 type IssueType string
 
@@ -209,23 +227,38 @@ const (
 	DepDiscoveredFrom DependencyType = "discovered-from"
 )
 
-// IsValid returns true if the dependency type is a recognized value
+// IsValid returns true if the dependency type is a recognized value: a
+// built-in type or one declared by the active workflow.
 func (d DependencyType) IsValid() bool {
 	switch d {
 	case DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom:
 		return true
 	}
-	return false
+	_, ok := customDependencyType(d)
+	return ok
 }
 
 // IsBlocking returns true if this dependency type represents a blocking relationship.
 // Note: An empty string ("") is treated as blocking for backward compatibility with
 // legacy beads data that predates the typed dependency system. This means dependencies
 // created without an explicit type will block by default.
+// Custom workflow types block when declared with blocking: true.
 func (d DependencyType) IsBlocking() bool {
+	if def, ok := customDependencyType(d); ok {
+		return def.Blocking
+	}
 	return d == "" || d == DepBlocks
 }
 
+// IsHierarchy returns true if this dependency type links a child to its parent
+// (parent-child, or a custom workflow type declared with hierarchy: true).
+func (d DependencyType) IsHierarchy() bool {
+	if def, ok := customDependencyType(d); ok {
+		return def.Hierarchy
+	}
+	return d == DepParentChild
+}
+
 // Comment represents a comment on an issue
 type Comment struct {
 	ID        int64     `json:"id"`
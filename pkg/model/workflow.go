@@ -0,0 +1,185 @@
+package model
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// StatusClass groups statuses by what they mean for scheduling. Analysis,
+// the board and history correlation reason about classes rather than
+// individual status names so that custom workflow statuses behave like the
+// built-in ones they resemble.
+type StatusClass string
+
+const (
+	ClassOpen     StatusClass = "open"     // Waiting to be picked up
+	ClassActive   StatusClass = "active"   // Being worked on
+	ClassBlocked  StatusClass = "blocked"  // Waiting on something outside the graph
+	ClassDone     StatusClass = "done"     // Completed
+	ClassTerminal StatusClass = "terminal" // Ended without completion (deleted, won't fix)
+)
+
+// StatusClasses lists the classes in workflow order.
+var StatusClasses = []StatusClass{ClassOpen, ClassActive, ClassBlocked, ClassDone, ClassTerminal}
+
+// IsValid returns true if the class is one of the known classes
+func (c StatusClass) IsValid() bool {
+	switch c {
+	case ClassOpen, ClassActive, ClassBlocked, ClassDone, ClassTerminal:
+		return true
+	}
+	return false
+}
+
+// IsResolved returns true for classes that take an issue out of the open set
+func (c StatusClass) IsResolved() bool {
+	return c == ClassDone || c == ClassTerminal
+}
+
+// builtinStatusClasses classifies the built-in statuses. Deferred, pinned,
+// hooked and review stay in the open class, matching how analysis treated
+// them before workflows were configurable.
+var builtinStatusClasses = map[Status]StatusClass{
+	StatusOpen:       ClassOpen,
+	StatusInProgress: ClassActive,
+	StatusBlocked:    ClassBlocked,
+	StatusDeferred:   ClassOpen,
+	StatusPinned:     ClassOpen,
+	StatusHooked:     ClassOpen,
+	StatusReview:     ClassOpen,
+	StatusClosed:     ClassDone,
+	StatusTombstone:  ClassTerminal,
+}
+
+// Workflow declares custom statuses, the transitions allowed between
+// statuses, and custom dependency types. It is usually loaded from
+// .bv/workflow.yaml by pkg/workflow.
+type Workflow struct {
+	// Classes maps custom statuses to their class. Entries for built-in
+	// statuses override the built-in classification.
+	Classes map[Status]StatusClass
+
+	// Transitions lists the statuses reachable from each status. Statuses
+	// without an entry may move to any status.
+	Transitions map[Status][]Status
+
+	// DependencyTypes declares custom dependency types. Entries for
+	// built-in types override their built-in semantics.
+	DependencyTypes map[DependencyType]DependencyTypeDef
+}
+
+// DependencyTypeDef describes how the graph treats a dependency type.
+type DependencyTypeDef struct {
+	// Blocking dependencies become graph edges and keep the dependent out of
+	// the actionable set until the target is resolved.
+	Blocking bool `json:"blocking"`
+
+	// Hierarchy dependencies link a child to its parent epic; a blocked
+	// parent blocks its children.
+	Hierarchy bool `json:"hierarchy"`
+
+	Description string `json:"description,omitempty"`
+}
+
+var activeWorkflow atomic.Pointer[Workflow]
+
+// SetWorkflow installs the process-wide workflow. Passing nil restores the
+// built-in statuses. Call it before loading issues so custom statuses pass
+// validation.
+func SetWorkflow(w *Workflow) {
+	activeWorkflow.Store(w)
+}
+
+// ActiveWorkflow returns the installed workflow, or nil if only the
+// built-in statuses are in use.
+func ActiveWorkflow() *Workflow {
+	return activeWorkflow.Load()
+}
+
+// Class returns the status's class. Unknown statuses are treated as open.
+func (s Status) Class() StatusClass {
+	if c, ok := customStatusClass(s); ok {
+		return c
+	}
+	if c, ok := builtinStatusClasses[s]; ok {
+		return c
+	}
+	return ClassOpen
+}
+
+// IsBuiltin returns true for the statuses bv knows without a workflow.
+func (s Status) IsBuiltin() bool {
+	_, ok := builtinStatusClasses[s]
+	return ok
+}
+
+func customStatusClass(s Status) (StatusClass, bool) {
+	w := activeWorkflow.Load()
+	if w == nil {
+		return "", false
+	}
+	c, ok := w.Classes[s]
+	return c, ok
+}
+
+func customDependencyType(d DependencyType) (DependencyTypeDef, bool) {
+	w := activeWorkflow.Load()
+	if w == nil {
+		return DependencyTypeDef{}, false
+	}
+	def, ok := w.DependencyTypes[d]
+	return def, ok
+}
+
+// IsBuiltin returns true for the dependency types bv knows without a workflow.
+func (d DependencyType) IsBuiltin() bool {
+	switch d {
+	case DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom:
+		return true
+	}
+	return false
+}
+
+// CanTransition reports whether the active workflow allows moving from one
+// status to another. Without a workflow, or when from has no declared
+// transitions, every move is allowed.
+func CanTransition(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	w := activeWorkflow.Load()
+	if w == nil {
+		return true
+	}
+	allowed, ok := w.Transitions[from]
+	if !ok {
+		return true
+	}
+	for _, s := range allowed {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownStatuses returns the built-in statuses followed by the active
+// workflow's custom statuses, sorted.
+func KnownStatuses() []Status {
+	statuses := []Status{
+		StatusOpen, StatusInProgress, StatusBlocked, StatusDeferred,
+		StatusPinned, StatusHooked, StatusReview, StatusClosed, StatusTombstone,
+	}
+	w := activeWorkflow.Load()
+	if w == nil {
+		return statuses
+	}
+	var custom []Status
+	for s := range w.Classes {
+		if !s.IsBuiltin() {
+			custom = append(custom, s)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i] < custom[j] })
+	return append(statuses, custom...)
+}
@@ -0,0 +1,303 @@
+package query
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindNumber
+	kindTime
+	kindFlag
+)
+
+func (k fieldKind) String() string {
+	switch k {
+	case kindNumber:
+		return "number"
+	case kindTime:
+		return "date"
+	case kindFlag:
+		return "flag"
+	default:
+		return "text"
+	}
+}
+
+// ops lists the operators each kind accepts.
+func (k fieldKind) ops() []string {
+	switch k {
+	case kindNumber, kindTime:
+		return []string{":", "=", "!=", "<", "<=", ">", ">="}
+	case kindFlag:
+		return []string{":"}
+	default:
+		return []string{":", "=", "!=", "~"}
+	}
+}
+
+type field struct {
+	name    string
+	aliases []string
+	kind    fieldKind
+	graph   bool
+	doc     string
+
+	text   func(*model.Issue) []string
+	number func(*model.Issue, *Env) (float64, bool)
+	when   func(*model.Issue) *time.Time
+	flags  map[string]func(*model.Issue, *Env) bool
+}
+
+// FieldInfo describes a query field for help text and robot docs.
+type FieldInfo struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Kind        string   `json:"kind"`
+	Graph       bool     `json:"graph_metric,omitempty"`
+	Description string   `json:"description"`
+	Values      []string `json:"values,omitempty"`
+}
+
+// Fields lists the fields a query can reference.
+func Fields() []FieldInfo {
+	out := make([]FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		info := FieldInfo{
+			Name:        f.name,
+			Aliases:     f.aliases,
+			Kind:        f.kind.String(),
+			Graph:       f.graph,
+			Description: f.doc,
+		}
+		if f.flags != nil {
+			info.Values = sortedFlags(f)
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+func sortedFlags(f *field) []string {
+	names := make([]string, 0, len(f.flags))
+	for name := range f.flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func one(s string) []string { return []string{s} }
+
+func count(n int) (float64, bool) { return float64(n), true }
+
+// metric adapts a GraphStats accessor. Metrics are unavailable (and terms
+// using them never match) when the environment has no stats or Phase 2 has
+// not produced a value for the issue.
+func metric(get func(*analysis.GraphStats, string) (float64, bool)) func(*model.Issue, *Env) (float64, bool) {
+	return func(issue *model.Issue, env *Env) (float64, bool) {
+		if env.Stats == nil {
+			return 0, false
+		}
+		return get(env.Stats, issue.ID)
+	}
+}
+
+func degree(pick func(*analysis.GraphStats) map[string]int) func(*model.Issue, *Env) (float64, bool) {
+	return func(issue *model.Issue, env *Env) (float64, bool) {
+		if env.Stats == nil {
+			return 0, false
+		}
+		return count(pick(env.Stats)[issue.ID])
+	}
+}
+
+func isReady(issue *model.Issue, env *Env) bool {
+	class := issue.Status.Class()
+	return !class.IsResolved() && class != model.ClassBlocked && env.openBlockers(issue) == 0
+}
+
+var fields = []*field{
+	{name: "id", kind: kindText, doc: "Issue ID",
+		text: func(i *model.Issue) []string { return one(i.ID) }},
+	{name: "title", kind: kindText, doc: "Issue title",
+		text: func(i *model.Issue) []string { return one(i.Title) }},
+	{name: "description", aliases: []string{"desc"}, kind: kindText, doc: "Issue description",
+		text: func(i *model.Issue) []string { return one(i.Description) }},
+	{name: "status", kind: kindText, doc: "Status name, including custom workflow statuses",
+		text: func(i *model.Issue) []string { return one(string(i.Status)) }},
+	{name: "class", kind: kindText, doc: "Status class: open, active, blocked, done or terminal",
+		text: func(i *model.Issue) []string { return one(string(i.Status.Class())) }},
+	{name: "type", aliases: []string{"kind"}, kind: kindText, doc: "Issue type (bug, feature, task, epic, chore)",
+		text: func(i *model.Issue) []string { return one(string(i.IssueType)) }},
+	{name: "assignee", aliases: []string{"owner"}, kind: kindText, doc: "Assignee; assignee:\"\" matches unassigned issues",
+		text: func(i *model.Issue) []string { return one(i.Assignee) }},
+	{name: "label", aliases: []string{"labels", "tag"}, kind: kindText, doc: "Any of the issue's labels",
+		text: func(i *model.Issue) []string { return i.Labels }},
+	{name: "repo", kind: kindText, doc: "Source repository in workspace mode",
+		text: func(i *model.Issue) []string { return one(i.SourceRepo) }},
+
+	{name: "priority", aliases: []string{"p", "prio"}, kind: kindNumber, doc: "Priority 0-4 (p0-p4 also accepted)",
+		number: func(i *model.Issue, _ *Env) (float64, bool) { return count(i.Priority) }},
+	{name: "blocks", kind: kindNumber, doc: "Number of open issues directly blocked by this one",
+		number: func(i *model.Issue, env *Env) (float64, bool) { return count(env.dependents[i.ID]) }},
+	{name: "blockers", kind: kindNumber, doc: "Number of unresolved blocking dependencies",
+		number: func(i *model.Issue, env *Env) (float64, bool) { return count(env.openBlockers(i)) }},
+	{name: "deps", aliases: []string{"dependencies"}, kind: kindNumber, doc: "Number of dependencies of any type",
+		number: func(i *model.Issue, _ *Env) (float64, bool) { return count(len(i.Dependencies)) }},
+	{name: "children", kind: kindNumber, doc: "Number of child issues (parent-child dependencies on this one)",
+		number: func(i *model.Issue, env *Env) (float64, bool) { return count(env.children[i.ID]) }},
+	{name: "comments", kind: kindNumber, doc: "Number of comments",
+		number: func(i *model.Issue, _ *Env) (float64, bool) { return count(len(i.Comments)) }},
+	{name: "estimate", kind: kindNumber, doc: "Estimated minutes",
+		number: func(i *model.Issue, _ *Env) (float64, bool) {
+			if i.EstimatedMinutes == nil {
+				return 0, false
+			}
+			return count(*i.EstimatedMinutes)
+		}},
+
+	{name: "pagerank", aliases: []string{"pr"}, kind: kindNumber, graph: true, doc: "PageRank centrality",
+		number: metric((*analysis.GraphStats).PageRankValue)},
+	{name: "betweenness", aliases: []string{"bw"}, kind: kindNumber, graph: true, doc: "Betweenness centrality",
+		number: metric((*analysis.GraphStats).BetweennessValue)},
+	{name: "eigenvector", aliases: []string{"eigen"}, kind: kindNumber, graph: true, doc: "Eigenvector centrality",
+		number: metric((*analysis.GraphStats).EigenvectorValue)},
+	{name: "hubs", aliases: []string{"hub"}, kind: kindNumber, graph: true, doc: "HITS hub score",
+		number: metric((*analysis.GraphStats).HubValue)},
+	{name: "authorities", aliases: []string{"authority"}, kind: kindNumber, graph: true, doc: "HITS authority score",
+		number: metric((*analysis.GraphStats).AuthorityValue)},
+	{name: "critical", aliases: []string{"critical_path"}, kind: kindNumber, graph: true, doc: "Critical path depth",
+		number: metric((*analysis.GraphStats).CriticalPathValue)},
+	{name: "slack", kind: kindNumber, graph: true, doc: "Longest-path slack (0 = on the critical path)",
+		number: metric((*analysis.GraphStats).SlackValue)},
+	{name: "core", aliases: []string{"kcore"}, kind: kindNumber, graph: true, doc: "k-core number",
+		number: metric(func(s *analysis.GraphStats, id string) (float64, bool) {
+			v, ok := s.CoreNumberValue(id)
+			return float64(v), ok
+		})},
+	{name: "indegree", kind: kindNumber, graph: true, doc: "Issues that depend on this one (graph in-degree)",
+		number: degree(func(s *analysis.GraphStats) map[string]int { return s.InDegree })},
+	{name: "outdegree", kind: kindNumber, graph: true, doc: "Issues this one depends on (graph out-degree)",
+		number: degree(func(s *analysis.GraphStats) map[string]int { return s.OutDegree })},
+
+	{name: "created", kind: kindTime, doc: "Creation time",
+		when: func(i *model.Issue) *time.Time { return &i.CreatedAt }},
+	{name: "updated", kind: kindTime, doc: "Last update time",
+		when: func(i *model.Issue) *time.Time { return &i.UpdatedAt }},
+	{name: "closed", kind: kindTime, doc: "Close time",
+		when: func(i *model.Issue) *time.Time { return i.ClosedAt }},
+	{name: "due", kind: kindTime, doc: "Due date",
+		when: func(i *model.Issue) *time.Time { return i.DueDate }},
+
+	{name: "is", kind: kindFlag, doc: "Issue state",
+		flags: map[string]func(*model.Issue, *Env) bool{
+			"open":     func(i *model.Issue, _ *Env) bool { return !i.Status.Class().IsResolved() },
+			"closed":   func(i *model.Issue, _ *Env) bool { return i.Status.Class().IsResolved() },
+			"active":   func(i *model.Issue, _ *Env) bool { return i.Status.Class() == model.ClassActive },
+			"done":     func(i *model.Issue, _ *Env) bool { return i.Status.Class() == model.ClassDone },
+			"terminal": func(i *model.Issue, _ *Env) bool { return i.Status.Class() == model.ClassTerminal },
+			"ready":    isReady,
+			"blocked": func(i *model.Issue, env *Env) bool {
+				return i.Status.Class() == model.ClassBlocked ||
+					(!i.Status.Class().IsResolved() && env.openBlockers(i) > 0)
+			},
+			"overdue": func(i *model.Issue, env *Env) bool {
+				return i.DueDate != nil && !i.Status.Class().IsResolved() && i.DueDate.Before(env.Now)
+			},
+			"articulation": func(i *model.Issue, env *Env) bool {
+				if env.Stats == nil {
+					return false
+				}
+				cut, _ := env.Stats.IsArticulationPoint(i.ID)
+				return cut
+			},
+		}},
+	{name: "has", kind: kindFlag, doc: "Presence of an optional attribute",
+		flags: map[string]func(*model.Issue, *Env) bool{
+			"assignee":    func(i *model.Issue, _ *Env) bool { return i.Assignee != "" },
+			"labels":      func(i *model.Issue, _ *Env) bool { return len(i.Labels) > 0 },
+			"deps":        func(i *model.Issue, _ *Env) bool { return len(i.Dependencies) > 0 },
+			"blockers":    func(i *model.Issue, env *Env) bool { return env.openBlockers(i) > 0 },
+			"comments":    func(i *model.Issue, _ *Env) bool { return len(i.Comments) > 0 },
+			"description": func(i *model.Issue, _ *Env) bool { return strings.TrimSpace(i.Description) != "" },
+			"estimate":    func(i *model.Issue, _ *Env) bool { return i.EstimatedMinutes != nil },
+			"due":         func(i *model.Issue, _ *Env) bool { return i.DueDate != nil },
+			"children":    func(i *model.Issue, env *Env) bool { return env.children[i.ID] > 0 },
+			"parent": func(i *model.Issue, _ *Env) bool {
+				for _, dep := range i.Dependencies {
+					if dep != nil && dep.Type.IsHierarchy() {
+						return true
+					}
+				}
+				return false
+			},
+		}},
+}
+
+// fieldIndex maps field names and aliases to their definitions.
+var fieldIndex = func() map[string]*field {
+	idx := make(map[string]*field)
+	for _, f := range fields {
+		idx[f.name] = f
+		for _, a := range f.aliases {
+			idx[a] = f
+		}
+	}
+	return idx
+}()
+
+// graphFlags are is: values that need graph metrics.
+var graphFlags = map[string]bool{"articulation": true}
+
+// suggest returns the candidate closest to word, or "" if none is close
+// enough to be a plausible typo.
+func suggest(word string, candidates []string) string {
+	best, bestDist := "", 3
+	if len(word) <= 3 {
+		bestDist = 2
+	}
+	for _, c := range candidates {
+		if d := levenshtein(word, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(fieldIndex))
+	for name := range fieldIndex {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tWord
+	tString
+	tOp
+	tLParen
+	tRParen
+	tComma
+	tAnd
+	tOr
+	tNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset into the source
+}
+
+func (t token) describe() string {
+	if t.kind == tEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// wordBreaks are the characters that end a bare word.
+const wordBreaks = `()"',<>=!:~&|`
+
+// lex splits src into tokens. Keywords AND, OR and NOT are recognized
+// case-insensitively, as are &&, || and a leading !.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+
+		start := i
+		switch {
+		case r == '(':
+			tokens = append(tokens, token{tLParen, "(", start})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tRParen, ")", start})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tComma, ",", start})
+			i++
+		case r == '"' || r == '\'':
+			text, next, err := lexString(src, i, byte(r))
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tString, text, start})
+			i = next
+		case strings.HasPrefix(src[i:], "&&"):
+			tokens = append(tokens, token{tAnd, "&&", start})
+			i += 2
+		case strings.HasPrefix(src[i:], "||"):
+			tokens = append(tokens, token{tOr, "||", start})
+			i += 2
+		case strings.HasPrefix(src[i:], "<="), strings.HasPrefix(src[i:], ">="), strings.HasPrefix(src[i:], "!="):
+			tokens = append(tokens, token{tOp, src[i : i+2], start})
+			i += 2
+		case r == '!':
+			tokens = append(tokens, token{tNot, "!", start})
+			i++
+		case r == '<' || r == '>' || r == '=' || r == ':' || r == '~':
+			tokens = append(tokens, token{tOp, string(r), start})
+			i++
+		case r == '&' || r == '|':
+			return nil, &Error{Input: src, Pos: start, Msg: fmt.Sprintf("unexpected %q (use %c%c, AND or OR)", r, r, r)}
+		default:
+			for i < len(src) {
+				r, size = utf8.DecodeRuneInString(src[i:])
+				if unicode.IsSpace(r) || strings.ContainsRune(wordBreaks, r) {
+					break
+				}
+				i += size
+			}
+			word := src[start:i]
+			kind := tWord
+			switch strings.ToUpper(word) {
+			case "AND":
+				kind = tAnd
+			case "OR":
+				kind = tOr
+			case "NOT":
+				kind = tNot
+			}
+			tokens = append(tokens, token{kind, word, start})
+		}
+	}
+	tokens = append(tokens, token{tEOF, "", len(src)})
+	return tokens, nil
+}
+
+// lexString reads a quoted string starting at src[start] and returns its
+// unescaped text and the offset just past the closing quote.
+func lexString(src string, start int, quote byte) (string, int, error) {
+	var sb strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src):
+			i++
+			sb.WriteByte(src[i])
+		case c == quote:
+			return sb.String(), i + 1, nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, &Error{Input: src, Pos: start, Msg: "unterminated string"}
+}
@@ -0,0 +1,456 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// parser is a recursive-descent parser over the token stream:
+//
+//	or      = and { OR and }
+//	and     = unary { [AND] unary }
+//	unary   = NOT unary | primary
+//	primary = "(" or ")" | field op value { "," value } | word
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+
+	graph      bool // references a graph metric
+	structured bool // has field terms or explicit operators
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &Error{Input: p.src, Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tOr {
+		p.next()
+		p.structured = true
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tAnd:
+			p.next()
+			p.structured = true
+		case tWord, tString, tNot, tLParen:
+			// Implicit AND between adjacent terms.
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().kind == tNot {
+		p.next()
+		p.structured = true
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tLParen:
+		if p.peek().kind == tRParen {
+			return nil, p.errorf(p.peek(), "empty parentheses")
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tRParen {
+			return nil, p.errorf(tok, "missing closing parenthesis")
+		}
+		p.next()
+		return inner, nil
+	case tWord:
+		if p.peek().kind == tOp {
+			return p.parseComparison(tok)
+		}
+		return textNode{strings.ToLower(tok.text)}, nil
+	case tString:
+		return textNode{strings.ToLower(tok.text)}, nil
+	case tEOF:
+		return nil, p.errorf(tok, "expected a filter term, got end of query")
+	case tOp:
+		return nil, p.errorf(tok, "operator %q needs a field name before it", tok.text)
+	default:
+		return nil, p.errorf(tok, "expected a filter term, got %s", tok.describe())
+	}
+}
+
+func (p *parser) parseComparison(name token) (node, error) {
+	f, ok := fieldIndex[strings.ToLower(name.text)]
+	if !ok {
+		msg := fmt.Sprintf("unknown field %q", name.text)
+		if s := suggest(strings.ToLower(name.text), fieldNames()); s != "" {
+			msg += fmt.Sprintf(" (did you mean %q?)", s)
+		}
+		return nil, p.errorf(name, "%s", msg)
+	}
+	op := p.next()
+	if !slices.Contains(f.kind.ops(), op.text) {
+		return nil, p.errorf(op, "%s is a %s field; use one of %s", f.name, f.kind, strings.Join(f.kind.ops(), " "))
+	}
+
+	var values []token
+	for {
+		v := p.next()
+		if v.kind != tWord && v.kind != tString {
+			return nil, p.errorf(v, "missing value after %s%s", name.text, op.text)
+		}
+		values = append(values, v)
+		if p.peek().kind != tComma {
+			break
+		}
+		p.next()
+	}
+	if len(values) > 1 && strings.ContainsAny(op.text, "<>~") {
+		return nil, p.errorf(values[1], "%s takes a single value", op.text)
+	}
+	p.structured = true
+	if f.graph {
+		p.graph = true
+	}
+
+	switch f.kind {
+	case kindNumber:
+		return p.numberComparison(f, op.text, values)
+	case kindTime:
+		return p.timeComparison(f, op.text, values)
+	case kindFlag:
+		return p.flagComparison(f, values)
+	default:
+		n := textCmp{f: f, op: op.text}
+		for _, v := range values {
+			n.values = append(n.values, strings.ToLower(v.text))
+		}
+		return n, nil
+	}
+}
+
+func (p *parser) numberComparison(f *field, op string, values []token) (node, error) {
+	n := numberCmp{f: f, op: op}
+	for _, v := range values {
+		text := v.text
+		if f.name == "priority" {
+			text = strings.TrimPrefix(strings.ToLower(text), "p")
+		}
+		num, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, p.errorf(v, "%s expects a number, got %q", f.name, v.text)
+		}
+		n.values = append(n.values, num)
+	}
+	return n, nil
+}
+
+var agePattern = regexp.MustCompile(`^(\d+)([hdwmy])$`)
+
+func (p *parser) timeComparison(f *field, op string, values []token) (node, error) {
+	n := timeCmp{f: f, op: op}
+	for _, v := range values {
+		if m := agePattern.FindStringSubmatch(strings.ToLower(v.text)); m != nil {
+			if op == ":" || op == "=" || op == "!=" {
+				return nil, p.errorf(v, "relative age %q needs < or > (e.g. %s<%s means within the last %s)", v.text, f.name, v.text, v.text)
+			}
+			amount, _ := strconv.Atoi(m[1])
+			n.values = append(n.values, timeValue{age: amount, unit: m[2][0]})
+			continue
+		}
+		tv, ok := parseDate(v.text)
+		if !ok {
+			return nil, p.errorf(v, "%s expects a date (2025-01-31) or an age (14d, 2w, 3m, 1y), got %q", f.name, v.text)
+		}
+		n.values = append(n.values, tv)
+	}
+	return n, nil
+}
+
+func (p *parser) flagComparison(f *field, values []token) (node, error) {
+	n := flagCmp{}
+	for _, v := range values {
+		name := strings.ToLower(v.text)
+		check, ok := f.flags[name]
+		if !ok {
+			msg := fmt.Sprintf("unknown %s: value %q", f.name, v.text)
+			if s := suggest(name, sortedFlags(f)); s != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", s)
+			} else {
+				msg += " (expected one of " + strings.Join(sortedFlags(f), ", ") + ")"
+			}
+			return nil, p.errorf(v, "%s", msg)
+		}
+		if f.name == "is" && graphFlags[name] {
+			p.graph = true
+		}
+		n.checks = append(n.checks, check)
+	}
+	return n, nil
+}
+
+// textCmp compares a text field. : and = match whole values (with * as a
+// wildcard), ~ matches substrings; all comparisons ignore case.
+type textCmp struct {
+	f      *field
+	op     string
+	values []string
+}
+
+func (n textCmp) match(issue *model.Issue, _ *Env) bool {
+	got := n.f.text(issue)
+	if len(got) == 0 {
+		got = []string{""}
+	}
+	for _, g := range got {
+		g = strings.ToLower(g)
+		for _, v := range n.values {
+			var hit bool
+			if n.op == "~" {
+				hit = strings.Contains(g, v)
+			} else {
+				hit = globMatch(v, g)
+			}
+			if hit {
+				return n.op != "!="
+			}
+		}
+	}
+	return n.op == "!="
+}
+
+// globMatch reports whether s matches pattern, where * matches any run of
+// characters.
+func globMatch(pattern, s string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == s
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
+type numberCmp struct {
+	f      *field
+	op     string
+	values []float64
+}
+
+func (n numberCmp) match(issue *model.Issue, env *Env) bool {
+	got, ok := n.f.number(issue, env)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return got < n.values[0]
+	case "<=":
+		return got <= n.values[0]
+	case ">":
+		return got > n.values[0]
+	case ">=":
+		return got >= n.values[0]
+	}
+	equal := slices.Contains(n.values, got)
+	if n.op == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+// timeValue is either an age relative to Env.Now or an absolute time. Dates
+// without a zone are resolved in Env.Now's location; date-only values cover
+// the whole day.
+type timeValue struct {
+	age  int
+	unit byte
+
+	wall  time.Time
+	zoned bool
+	day   bool
+}
+
+var dateLayouts = []struct {
+	layout string
+	zoned  bool
+	day    bool
+}{
+	{time.RFC3339, true, false},
+	{"2006-01-02T15:04:05", false, false},
+	{"2006-01-02", false, true},
+}
+
+func parseDate(s string) (timeValue, bool) {
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return timeValue{wall: t, zoned: l.zoned, day: l.day}, true
+		}
+	}
+	return timeValue{}, false
+}
+
+// bounds returns the half-open interval [lo, hi) the value denotes. Ages
+// and timestamps are instants, so lo == hi.
+func (v timeValue) bounds(now time.Time) (lo, hi time.Time) {
+	if v.unit != 0 {
+		var t time.Time
+		switch v.unit {
+		case 'h':
+			t = now.Add(-time.Duration(v.age) * time.Hour)
+		case 'd':
+			t = now.AddDate(0, 0, -v.age)
+		case 'w':
+			t = now.AddDate(0, 0, -7*v.age)
+		case 'm':
+			t = now.AddDate(0, -v.age, 0)
+		default:
+			t = now.AddDate(-v.age, 0, 0)
+		}
+		return t, t
+	}
+	t := v.wall
+	if !v.zoned {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+	}
+	if v.day {
+		return t, t.AddDate(0, 0, 1)
+	}
+	return t, t
+}
+
+type timeCmp struct {
+	f      *field
+	op     string
+	values []timeValue
+}
+
+func (n timeCmp) match(issue *model.Issue, env *Env) bool {
+	tp := n.f.when(issue)
+	if tp == nil || tp.IsZero() {
+		return false
+	}
+	t := *tp
+	now := env.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	v := n.values[0]
+	lo, hi := v.bounds(now)
+	if v.unit != 0 {
+		// Ages run backwards: updated<14d means the timestamp is newer than
+		// 14 days ago.
+		switch n.op {
+		case "<":
+			return t.After(lo)
+		case "<=":
+			return !t.Before(lo)
+		case ">":
+			return t.Before(lo)
+		default:
+			return !t.After(lo)
+		}
+	}
+
+	switch n.op {
+	case "<":
+		return t.Before(lo)
+	case "<=":
+		if v.day {
+			return t.Before(hi)
+		}
+		return !t.After(lo)
+	case ">":
+		if v.day {
+			return !t.Before(hi)
+		}
+		return t.After(lo)
+	case ">=":
+		return !t.Before(lo)
+	}
+
+	within := false
+	for _, v := range n.values {
+		lo, hi := v.bounds(now)
+		if (v.day && !t.Before(lo) && t.Before(hi)) || (!v.day && t.Equal(lo)) {
+			within = true
+			break
+		}
+	}
+	if n.op == "!=" {
+		return !within
+	}
+	return within
+}
+
+type flagCmp struct {
+	checks []func(*model.Issue, *Env) bool
+}
+
+func (n flagCmp) match(issue *model.Issue, env *Env) bool {
+	for _, check := range n.checks {
+		if check(issue, env) {
+			return true
+		}
+	}
+	return false
+}
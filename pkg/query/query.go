@@ -0,0 +1,219 @@
+// Package query implements the filter expression language shared by the TUI
+// filter bar, the --where robot flag and recipe filters.
+//
+// A query is a boolean combination of terms:
+//
+//	status:open AND label:api AND (priority<=1 OR blocks>3) AND updated<14d AND pagerank>0.01
+//
+// Terms are joined with AND, OR and NOT (or &&, || and !); adjacent terms
+// without an operator are ANDed. A term is either `field op value` or a bare
+// word, which matches the ID, title and description. Operators are
+// : = != < <= > >= and ~ (substring). A comma-separated value list matches any
+// of its values (label:api,ui). Time fields compare against an absolute date
+// (created>=2025-01-01) or an age (updated<14d means updated within the last
+// 14 days). Graph metrics from analysis.GraphStats are fields too; see Fields.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Error describes a query that failed to parse. Pos is a byte offset into
+// Input.
+type Error struct {
+	Input string
+	Pos   int
+	Msg   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query at column %d: %s", e.column(), e.Msg)
+}
+
+// Context renders the query with a caret under the offending position, for
+// display beneath the error message.
+func (e *Error) Context() string {
+	return e.Input + "\n" + strings.Repeat(" ", e.column()-1) + "^"
+}
+
+func (e *Error) column() int {
+	pos := e.Pos
+	if pos > len(e.Input) {
+		pos = len(e.Input)
+	}
+	return utf8.RuneCountInString(e.Input[:pos]) + 1
+}
+
+// Query is a parsed filter expression. It is immutable and safe for
+// concurrent use.
+type Query struct {
+	src        string
+	root       node
+	graph      bool
+	structured bool
+}
+
+// Parse compiles a query. An empty or all-whitespace query matches every
+// issue. Errors are of type *Error.
+func Parse(src string) (*Query, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	q := &Query{src: src}
+	if p.peek().kind == tEOF {
+		q.root = matchAll{}
+		return q, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tEOF {
+		if tok.kind == tRParen {
+			return nil, p.errorf(tok, "unmatched closing parenthesis")
+		}
+		return nil, p.errorf(tok, "unexpected %s", tok.describe())
+	}
+	q.root = root
+	q.graph = p.graph
+	q.structured = p.structured
+	return q, nil
+}
+
+// String returns the source text of the query.
+func (q *Query) String() string {
+	return q.src
+}
+
+// UsesGraphMetrics reports whether the query references a graph metric, so
+// callers can skip graph analysis when it does not.
+func (q *Query) UsesGraphMetrics() bool {
+	return q.graph
+}
+
+// Structured reports whether the query has field terms or boolean
+// operators. A query of bare words only is plain text search, which
+// interactive callers may prefer to hand to fuzzy matching instead.
+func (q *Query) Structured() bool {
+	return q.structured
+}
+
+// Match reports whether issue satisfies the query.
+func (q *Query) Match(issue *model.Issue, env *Env) bool {
+	if env == nil {
+		env = &Env{Now: time.Now()}
+	}
+	return q.root.match(issue, env)
+}
+
+// Filter returns the issues that satisfy the query, preserving order.
+func (q *Query) Filter(issues []model.Issue, env *Env) []model.Issue {
+	out := make([]model.Issue, 0, len(issues))
+	for i := range issues {
+		if q.Match(&issues[i], env) {
+			out = append(out, issues[i])
+		}
+	}
+	return out
+}
+
+// Env supplies the context a query is evaluated against: the rest of the
+// issue set (for blocker and dependent counts), graph metrics, and the
+// reference time for relative dates.
+type Env struct {
+	Now   time.Time
+	Stats *analysis.GraphStats
+
+	issues     map[string]*model.Issue
+	dependents map[string]int
+	children   map[string]int
+}
+
+// NewEnv builds an evaluation environment over issues. stats may be nil, in
+// which case graph metric terms never match.
+func NewEnv(issues []model.Issue, stats *analysis.GraphStats, now time.Time) *Env {
+	env := &Env{
+		Now:        now,
+		Stats:      stats,
+		issues:     make(map[string]*model.Issue, len(issues)),
+		dependents: make(map[string]int),
+		children:   make(map[string]int),
+	}
+	for i := range issues {
+		env.issues[issues[i].ID] = &issues[i]
+	}
+	for i := range issues {
+		for _, dep := range issues[i].Dependencies {
+			if dep != nil && dep.Type.IsHierarchy() {
+				env.children[dep.DependsOnID]++
+			}
+		}
+		if issues[i].Status.Class().IsResolved() {
+			continue
+		}
+		for _, dep := range issues[i].Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				env.dependents[dep.DependsOnID]++
+			}
+		}
+	}
+	return env
+}
+
+// openBlockers counts the issue's blocking dependencies that are still
+// unresolved. Dependencies on issues outside the environment are ignored.
+func (e *Env) openBlockers(issue *model.Issue) int {
+	n := 0
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := e.issues[dep.DependsOnID]; ok && !blocker.Status.Class().IsResolved() {
+			n++
+		}
+	}
+	return n
+}
+
+type node interface {
+	match(issue *model.Issue, env *Env) bool
+}
+
+type matchAll struct{}
+
+func (matchAll) match(*model.Issue, *Env) bool { return true }
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(issue *model.Issue, env *Env) bool {
+	return n.left.match(issue, env) && n.right.match(issue, env)
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(issue *model.Issue, env *Env) bool {
+	return n.left.match(issue, env) || n.right.match(issue, env)
+}
+
+type notNode struct{ inner node }
+
+func (n notNode) match(issue *model.Issue, env *Env) bool {
+	return !n.inner.match(issue, env)
+}
+
+// textNode matches a bare word against the ID, title and description.
+type textNode struct{ needle string }
+
+func (n textNode) match(issue *model.Issue, _ *Env) bool {
+	return strings.Contains(strings.ToLower(issue.ID), n.needle) ||
+		strings.Contains(strings.ToLower(issue.Title), n.needle) ||
+		strings.Contains(strings.ToLower(issue.Description), n.needle)
+}
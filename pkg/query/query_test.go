@@ -0,0 +1,225 @@
+package query
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+var now = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func fixture() []model.Issue {
+	est := 90
+	blocks := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "api-1", Title: "Auth gateway", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeFeature,
+			Labels: []string{"api", "security"}, CreatedAt: now.AddDate(0, -2, 0), UpdatedAt: now.AddDate(0, 0, -3), EstimatedMinutes: &est},
+		{ID: "api-2", Title: "Rate limiter", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask,
+			Labels: []string{"api"}, Assignee: "ana", CreatedAt: now.AddDate(0, -1, 0), UpdatedAt: now.AddDate(0, 0, -30), Dependencies: blocks("api-1")},
+		{ID: "api-3", Title: "Quota dashboard", Status: model.StatusInProgress, Priority: 2, IssueType: model.TypeTask,
+			Labels: []string{"api", "ui"}, CreatedAt: now.AddDate(0, 0, -10), UpdatedAt: now.AddDate(0, 0, -1), Dependencies: blocks("api-1")},
+		{ID: "ui-1", Title: "Dark mode", Description: "Theme toggle for the gateway UI", Status: model.StatusClosed, Priority: 3,
+			IssueType: model.TypeFeature, Labels: []string{"ui"}, CreatedAt: now.AddDate(0, -3, 0), UpdatedAt: now.AddDate(0, 0, -20)},
+		{ID: "ui-2", Title: "Settings page", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeBug,
+			CreatedAt: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), UpdatedAt: now.AddDate(0, 0, -2), Dependencies: blocks("api-1")},
+	}
+}
+
+func run(t *testing.T, src string) []string {
+	t.Helper()
+	q, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", src, err)
+	}
+	issues := fixture()
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	var ids []string
+	for _, issue := range q.Filter(issues, NewEnv(issues, &stats, now)) {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{"", "api-1,api-2,api-3,ui-1,ui-2"},
+		{"status:open", "api-1,api-2,ui-2"},
+		{"status:open,in_progress label:api", "api-1,api-2,api-3"},
+		{"label:api AND NOT label:ui", "api-1,api-2"},
+		{"label!=api", "ui-1,ui-2"},
+		{"label:sec*", "api-1"},
+		{"title~dash", "api-3"},
+		{"gateway", "api-1,ui-1"},
+		{`"rate limiter"`, "api-2"},
+		{"priority<=1 OR type:bug", "api-1,api-2,ui-2"},
+		{"priority:p2", "api-3,ui-2"},
+		{"blocks>2", "api-1"},
+		{"blockers>0", "api-2,api-3,ui-2"},
+		{"is:ready", "api-1"},
+		{"is:blocked,closed", "api-2,api-3,ui-1,ui-2"},
+		{"!is:open", "ui-1"},
+		{"class:active", "api-3"},
+		{"has:assignee || has:description", "api-2,ui-1"},
+		{"assignee:\"\" has:labels", "api-1,api-3,ui-1"},
+		{"estimate>=60", "api-1"},
+		{"updated<14d", "api-1,api-3,ui-2"},
+		{"updated>=14d", "api-2,ui-1"},
+		{"created:2025-06-01", "ui-2"},
+		{"created>2025-06-01", "api-3"},
+		{"created<=2025-06-01 created>=2025-05-01", "api-2,ui-2"},
+		{"pagerank>0 AND indegree>=3", "api-1"},
+		{"status:open AND label:api AND (priority<=1 OR blocks>3) AND updated<14d", "api-1"},
+	}
+	for _, tc := range cases {
+		if got := strings.Join(run(t, tc.query), ","); got != tc.want {
+			t.Errorf("%q matched %s, want %s", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		query string
+		pos   int
+		want  string
+	}{
+		{"statsu:open", 0, `did you mean "status"`},
+		{"priority:high", 9, "expects a number"},
+		{"label<api", 5, "text field"},
+		{"status:open AND", 15, "end of query"},
+		{"(label:api", 0, "missing closing parenthesis"},
+		{"label:api)", 9, "unmatched closing parenthesis"},
+		{"is:redy", 3, `did you mean "ready"`},
+		{"updated:14d", 8, "needs < or >"},
+		{"created>yesterday", 8, "expects a date"},
+		{`title:"open`, 6, "unterminated string"},
+		{"priority<1,2", 11, "single value"},
+		{"label:", 6, "missing value"},
+		{"a & b", 2, "unexpected"},
+	}
+	for _, tc := range cases {
+		_, err := Parse(tc.query)
+		var qe *Error
+		if !errors.As(err, &qe) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tc.query, err)
+			continue
+		}
+		if qe.Pos != tc.pos || !strings.Contains(qe.Msg, tc.want) {
+			t.Errorf("Parse(%q) = pos %d %q, want pos %d containing %q", tc.query, qe.Pos, qe.Msg, tc.pos, tc.want)
+		}
+	}
+}
+
+func TestErrorContext(t *testing.T) {
+	_, err := Parse("status:open AND prio:hi")
+	var qe *Error
+	if !errors.As(err, &qe) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	want := "status:open AND prio:hi\n                     ^"
+	if got := qe.Context(); got != want {
+		t.Errorf("Context() =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.HasPrefix(qe.Error(), "invalid query at column 22") {
+		t.Errorf("Error() = %q", qe.Error())
+	}
+}
+
+func TestUsesGraphMetrics(t *testing.T) {
+	for src, want := range map[string]bool{
+		"status:open":                false,
+		"blocks>2":                   false,
+		"pagerank>0.01":              true,
+		"label:api OR betweenness>0": true,
+		"is:articulation":            true,
+	} {
+		q, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", src, err)
+		}
+		if q.UsesGraphMetrics() != want {
+			t.Errorf("%q UsesGraphMetrics = %v, want %v", src, !want, want)
+		}
+	}
+}
+
+func TestStructured(t *testing.T) {
+	for src, want := range map[string]bool{
+		"auth login":      false,
+		`"rate limiter"`:  false,
+		"auth OR login":   true,
+		"!wip":            true,
+		"label:api":       true,
+		"auth priority<2": true,
+	} {
+		q, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", src, err)
+		}
+		if q.Structured() != want {
+			t.Errorf("%q Structured = %v, want %v", src, !want, want)
+		}
+	}
+}
+
+func TestGraphMetricsWithoutStats(t *testing.T) {
+	q, err := Parse("pagerank>=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := fixture()
+	if got := q.Filter(issues, NewEnv(issues, nil, now)); len(got) != 0 {
+		t.Errorf("graph terms should not match without stats, got %d issues", len(got))
+	}
+}
+
+func TestFields(t *testing.T) {
+	var names []string
+	for _, f := range Fields() {
+		names = append(names, f.Name)
+	}
+	for _, want := range []string{"status", "label", "priority", "pagerank", "updated", "is"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("Fields() missing %q", want)
+		}
+	}
+}
+
+func TestChildren(t *testing.T) {
+	child := func(id, parent string, status model.Status) model.Issue {
+		return model.Issue{ID: id, Status: status, Dependencies: []*model.Dependency{{DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	issues := []model.Issue{
+		{ID: "epic-1", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "epic-2", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		child("task-1", "epic-1", model.StatusOpen),
+		child("task-2", "epic-1", model.StatusClosed),
+	}
+	env := NewEnv(issues, nil, now)
+	for src, want := range map[string]string{
+		"type:epic children>=2":       "epic-1",
+		"type:epic AND !has:children": "epic-2",
+	} {
+		q, err := Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, issue := range q.Filter(issues, env) {
+			ids = append(ids, issue.ID)
+		}
+		if got := strings.Join(ids, ","); got != want {
+			t.Errorf("%s = %q, want %q", src, got, want)
+		}
+	}
+}
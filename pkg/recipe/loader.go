@@ -151,6 +151,10 @@ func (l *Loader) loadFromFile(path, source string) error {
 			continue
 		}
 		recipe.Name = name
+		if _, err := recipe.Filters.WhereQuery(); err != nil {
+			l.warnings = append(l.warnings, fmt.Sprintf("recipe %q in %s: %v", name, path, err))
+			continue
+		}
 		l.recipes[name] = *recipe
 		l.sources[name] = source
 	}
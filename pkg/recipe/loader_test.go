@@ -3,6 +3,7 @@ package recipe_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
@@ -284,6 +285,49 @@ func TestLoaderInvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoaderWhereQuery(t *testing.T) {
+	tmpDir := t.TempDir()
+	userPath := filepath.Join(tmpDir, "recipes.yaml")
+
+	config := `
+recipes:
+  hot-api:
+    filters:
+      where: "label:api AND (priority<=1 OR blocks>3)"
+  broken:
+    filters:
+      where: "lable:api"
+`
+	if err := os.WriteFile(userPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := recipe.NewLoader(
+		recipe.WithUserPath(userPath),
+		recipe.WithProjectDir(""),
+	)
+	if err := loader.Load(); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+
+	r := loader.Get("hot-api")
+	if r == nil {
+		t.Fatal("Expected hot-api recipe")
+	}
+	if q, err := r.Filters.WhereQuery(); err != nil || q == nil {
+		t.Errorf("WhereQuery() = %v, %v", q, err)
+	}
+
+	// Recipes with an invalid where expression are skipped with a warning
+	if loader.Get("broken") != nil {
+		t.Error("Expected broken recipe to be skipped")
+	}
+	warnings := loader.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `did you mean "label"`) {
+		t.Errorf("Expected a did-you-mean warning, got %v", warnings)
+	}
+}
+
 func TestLoadDefault(t *testing.T) {
 	loader, err := recipe.LoadDefault()
 	if err != nil {
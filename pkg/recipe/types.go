@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/query"
 )
 
 // Recipe defines a reusable view configuration for beads
@@ -32,6 +34,15 @@ type FilterConfig struct {
 	Actionable    *bool    `yaml:"actionable,omitempty" json:"actionable,omitempty"`         // true = no open blockers
 	TitleContains string   `yaml:"title_contains,omitempty" json:"title_contains,omitempty"` // Substring match
 	IDPrefix      string   `yaml:"id_prefix,omitempty" json:"id_prefix,omitempty"`           // e.g., "bv-" for project filtering
+	Where         string   `yaml:"where,omitempty" json:"where,omitempty"`                   // Query expression, e.g. "label:api AND pagerank>0.01"
+}
+
+// WhereQuery parses the Where expression. It returns nil when Where is empty.
+func (f FilterConfig) WhereQuery() (*query.Query, error) {
+	if strings.TrimSpace(f.Where) == "" {
+		return nil, nil
+	}
+	return query.Parse(f.Where)
 }
 
 // SortConfig defines how to order issues
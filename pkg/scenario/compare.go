@@ -0,0 +1,284 @@
+package scenario
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Options tunes Compare.
+type Options struct {
+	Agents int       // Parallel workers for the forecast (default 1)
+	Trials int       // Monte Carlo trials; 0 or less skips the forecast
+	Seed   int64     // Forecast RNG seed, shared by both sides
+	Now    time.Time // Reference time; zero means time.Now()
+}
+
+// Result compares a scenario against the baseline it was applied to.
+type Result struct {
+	Scenario    string                 `json:"scenario"`
+	Description string                 `json:"description,omitempty"`
+	Mutations   []Applied              `json:"mutations"`
+	Diff        *analysis.SnapshotDiff `json:"diff"`
+	Triage      TriageComparison       `json:"triage"`
+	Plan        PlanComparison         `json:"plan"`
+	Forecast    *ForecastComparison    `json:"forecast,omitempty"`
+}
+
+// TriageSummary is the part of a triage result that scenarios move.
+type TriageSummary struct {
+	OpenCount         int      `json:"open_count"`
+	ActionableCount   int      `json:"actionable_count"`
+	BlockedCount      int      `json:"blocked_count"`
+	InProgressCount   int      `json:"in_progress_count"`
+	TopPicks          []string `json:"top_picks"`
+	Recommendations   []string `json:"recommendations"`
+	HighestImpact     string   `json:"highest_impact,omitempty"`
+	HighestImpactSize int      `json:"highest_impact_unblocks,omitempty"`
+}
+
+// TriageComparison is the triage delta between baseline and scenario.
+type TriageComparison struct {
+	Baseline               TriageSummary `json:"baseline"`
+	Scenario               TriageSummary `json:"scenario"`
+	OpenDelta              int           `json:"open_delta"`
+	ActionableDelta        int           `json:"actionable_delta"`
+	BlockedDelta           int           `json:"blocked_delta"`
+	TopPicksAdded          []string      `json:"top_picks_added,omitempty"`
+	TopPicksDropped        []string      `json:"top_picks_dropped,omitempty"`
+	RecommendationsAdded   []string      `json:"recommendations_added,omitempty"`
+	RecommendationsDropped []string      `json:"recommendations_dropped,omitempty"`
+}
+
+// PlanSummary is the shape of an execution plan.
+type PlanSummary struct {
+	Actionable    int    `json:"actionable"`
+	Blocked       int    `json:"blocked"`
+	Tracks        int    `json:"tracks"`
+	HighestImpact string `json:"highest_impact,omitempty"`
+}
+
+// PlanComparison is the execution-plan delta between baseline and scenario.
+type PlanComparison struct {
+	Baseline           PlanSummary `json:"baseline"`
+	Scenario           PlanSummary `json:"scenario"`
+	NewlyActionable    []string    `json:"newly_actionable,omitempty"`
+	NoLongerActionable []string    `json:"no_longer_actionable,omitempty"`
+}
+
+// ForecastSummary holds the headline Monte Carlo percentiles, in days.
+type ForecastSummary struct {
+	Remaining int       `json:"remaining"` // Open target beads still to finish
+	MeanDays  float64   `json:"mean_days"`
+	P50Days   float64   `json:"p50_days"`
+	P85Days   float64   `json:"p85_days"`
+	P95Days   float64   `json:"p95_days"`
+	P85Date   time.Time `json:"p85_date"`
+}
+
+// ForecastComparison is the completion-forecast delta for all open work.
+// Both sides sample throughput from the baseline history and share a seed,
+// so the deltas reflect the mutations rather than simulation noise.
+type ForecastComparison struct {
+	Trials       int             `json:"trials"`
+	Seed         int64           `json:"seed"`
+	Agents       int             `json:"agents"`
+	Baseline     ForecastSummary `json:"baseline"`
+	Scenario     ForecastSummary `json:"scenario"`
+	P50DeltaDays float64         `json:"p50_delta_days"`
+	P85DeltaDays float64         `json:"p85_delta_days"`
+	P95DeltaDays float64         `json:"p95_delta_days"`
+	Warnings     []string        `json:"warnings,omitempty"`
+}
+
+// Compare applies the scenario to a copy of baseline and diffs the issue
+// set, triage, execution plan and (when opts.Trials > 0) the forecast.
+func Compare(s *Scenario, baseline []model.Issue, opts Options) (*Result, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Agents <= 0 {
+		opts.Agents = 1
+	}
+
+	mutated, applied, err := s.Apply(baseline, opts.Now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Scenario:    s.Name,
+		Description: s.Description,
+		Mutations:   applied,
+		Diff: analysis.CompareSnapshots(
+			analysis.NewSnapshotAt(baseline, opts.Now, "baseline"),
+			analysis.NewSnapshotAt(mutated, opts.Now, "scenario"),
+		),
+		Triage: compareTriage(baseline, mutated, opts.Now),
+		Plan:   comparePlans(baseline, mutated),
+	}
+
+	if opts.Trials > 0 {
+		forecast, err := compareForecasts(baseline, mutated, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Forecast = forecast
+	}
+	return result, nil
+}
+
+func compareTriage(baseline, mutated []model.Issue, now time.Time) TriageComparison {
+	before := summarizeTriage(baseline, now)
+	after := summarizeTriage(mutated, now)
+	cmp := TriageComparison{
+		Baseline:        before,
+		Scenario:        after,
+		OpenDelta:       after.OpenCount - before.OpenCount,
+		ActionableDelta: after.ActionableCount - before.ActionableCount,
+		BlockedDelta:    after.BlockedCount - before.BlockedCount,
+	}
+	cmp.TopPicksAdded, cmp.TopPicksDropped = setDelta(before.TopPicks, after.TopPicks)
+	cmp.RecommendationsAdded, cmp.RecommendationsDropped = setDelta(before.Recommendations, after.Recommendations)
+	return cmp
+}
+
+func summarizeTriage(issues []model.Issue, now time.Time) TriageSummary {
+	triage := analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{
+		WaitForPhase2: true,
+		UseFastConfig: true,
+	}, now)
+	s := TriageSummary{
+		OpenCount:       triage.QuickRef.OpenCount,
+		ActionableCount: triage.QuickRef.ActionableCount,
+		BlockedCount:    triage.QuickRef.BlockedCount,
+		InProgressCount: triage.QuickRef.InProgressCount,
+		TopPicks:        make([]string, 0, len(triage.QuickRef.TopPicks)),
+		Recommendations: make([]string, 0, len(triage.Recommendations)),
+	}
+	for _, p := range triage.QuickRef.TopPicks {
+		s.TopPicks = append(s.TopPicks, p.ID)
+	}
+	for _, r := range triage.Recommendations {
+		s.Recommendations = append(s.Recommendations, r.ID)
+	}
+	if len(triage.BlockersToClear) > 0 {
+		s.HighestImpact = triage.BlockersToClear[0].ID
+		s.HighestImpactSize = triage.BlockersToClear[0].UnblocksCount
+	}
+	return s
+}
+
+func comparePlans(baseline, mutated []model.Issue) PlanComparison {
+	before, beforeIDs := summarizePlan(baseline)
+	after, afterIDs := summarizePlan(mutated)
+	cmp := PlanComparison{Baseline: before, Scenario: after}
+	cmp.NewlyActionable, cmp.NoLongerActionable = setDelta(beforeIDs, afterIDs)
+	return cmp
+}
+
+func summarizePlan(issues []model.Issue) (PlanSummary, []string) {
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlan()
+	var ids []string
+	for _, track := range plan.Tracks {
+		for _, item := range track.Items {
+			ids = append(ids, item.ID)
+		}
+	}
+	return PlanSummary{
+		Actionable:    plan.TotalActionable,
+		Blocked:       plan.TotalBlocked,
+		Tracks:        len(plan.Tracks),
+		HighestImpact: plan.Summary.HighestImpact,
+	}, ids
+}
+
+func compareForecasts(baseline, mutated []model.Issue, opts Options) (*ForecastComparison, error) {
+	// Forecast the same target set on both sides: every bead open in either.
+	// Beads the scenario closes simply drop out of its simulation.
+	targets := make(map[string]bool)
+	for _, set := range [][]model.Issue{baseline, mutated} {
+		for _, issue := range set {
+			if !issue.Status.IsClosed() && !issue.Status.IsTombstone() {
+				targets[issue.ID] = true
+			}
+		}
+	}
+	targetIDs := make([]string, 0, len(targets))
+	for id := range targets {
+		targetIDs = append(targetIDs, id)
+	}
+	sort.Strings(targetIDs)
+
+	mcOpts := analysis.MonteCarloOptions{
+		Trials:  opts.Trials,
+		Seed:    opts.Seed,
+		Agents:  opts.Agents,
+		Now:     opts.Now,
+		History: baseline,
+	}
+	before, err := analysis.ForecastMonteCarlo(baseline, "all", targetIDs, mcOpts)
+	if err != nil {
+		return nil, err
+	}
+	after, err := analysis.ForecastMonteCarlo(mutated, "all", targetIDs, mcOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &ForecastComparison{
+		Trials:       before.Trials,
+		Seed:         before.Seed,
+		Agents:       before.Agents,
+		Baseline:     summarizeForecast(before),
+		Scenario:     summarizeForecast(after),
+		P50DeltaDays: after.P50.Days - before.P50.Days,
+		P85DeltaDays: after.P85.Days - before.P85.Days,
+		P95DeltaDays: after.P95.Days - before.P95.Days,
+	}
+	seen := make(map[string]bool)
+	for _, w := range append(append([]string{}, before.Warnings...), after.Warnings...) {
+		if !seen[w] {
+			seen[w] = true
+			cmp.Warnings = append(cmp.Warnings, w)
+		}
+	}
+	return cmp, nil
+}
+
+func summarizeForecast(f *analysis.MonteCarloForecast) ForecastSummary {
+	return ForecastSummary{
+		Remaining: f.SimulatedIssues,
+		MeanDays:  f.MeanDays,
+		P50Days:   f.P50.Days,
+		P85Days:   f.P85.Days,
+		P95Days:   f.P95.Days,
+		P85Date:   f.P85.Date,
+	}
+}
+
+// setDelta returns the IDs in after but not before, and in before but not
+// after, each sorted.
+func setDelta(before, after []string) (added, dropped []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, id := range before {
+		inBefore[id] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, id := range after {
+		inAfter[id] = true
+		if !inBefore[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if !inAfter[id] {
+			dropped = append(dropped, id)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(dropped)
+	return added, dropped
+}
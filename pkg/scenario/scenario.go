@@ -0,0 +1,379 @@
+// Package scenario implements compound "what-if" planning: a scenario is a
+// list of hypothetical mutations (close, reopen, add/remove dependency,
+// change estimate) that is applied to a cloned issue set and compared
+// against the baseline.
+//
+// Scenarios are written in YAML or JSON:
+//
+//	name: auth ships, bd-12 slips
+//	mutations:
+//	  - op: close
+//	    label: auth
+//	  - op: close
+//	    assignee: alice
+//	    status: in_progress
+//	  - op: estimate
+//	    id: bd-12
+//	    add: 2w
+//
+// Each mutation selects issues by id, ids, label, assignee and/or status
+// (all given selectors must match) and applies its op to every selected
+// issue. A bare list of mutations is accepted as well.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Op names a mutation.
+type Op string
+
+const (
+	OpClose     Op = "close"
+	OpReopen    Op = "reopen"
+	OpAddDep    Op = "add_dep"
+	OpRemoveDep Op = "remove_dep"
+	OpEstimate  Op = "estimate"
+)
+
+// Working time used to convert estimate durations ("2w", "3d") to minutes.
+const (
+	workMinutesPerDay = 8 * 60
+	workDaysPerWeek   = 5
+)
+
+// Scenario is a named list of hypothetical mutations.
+type Scenario struct {
+	Name        string     `yaml:"name" json:"name"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Mutations   []Mutation `yaml:"mutations" json:"mutations"`
+}
+
+// Mutation is one hypothetical change applied to every selected issue.
+type Mutation struct {
+	Op Op `yaml:"op" json:"op"`
+
+	// Selectors; every non-empty selector must match.
+	ID       string   `yaml:"id,omitempty" json:"id,omitempty"`
+	IDs      []string `yaml:"ids,omitempty" json:"ids,omitempty"`
+	Label    string   `yaml:"label,omitempty" json:"label,omitempty"`
+	Assignee string   `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Status   string   `yaml:"status,omitempty" json:"status,omitempty"`
+
+	// add_dep / remove_dep
+	DependsOn string `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Type      string `yaml:"type,omitempty" json:"type,omitempty"` // add_dep only; default "blocks"
+
+	// estimate: Minutes sets the estimate, Add shifts it by a working-time
+	// duration such as "2w", "3d", "4h", "90m" (negative values allowed).
+	Minutes *int   `yaml:"minutes,omitempty" json:"minutes,omitempty"`
+	Add     string `yaml:"add,omitempty" json:"add,omitempty"`
+}
+
+// Applied records what one mutation did.
+type Applied struct {
+	Index    int      `json:"index"` // 0-based position in the scenario
+	Op       Op       `json:"op"`
+	Selector string   `json:"selector"`
+	IssueIDs []string `json:"issue_ids"` // Issues actually changed
+	Skipped  []string `json:"skipped,omitempty"`
+}
+
+// Load reads a scenario from a YAML or JSON file.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario: %w", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse decodes a scenario from YAML or JSON (JSON is valid YAML). Both a
+// scenario object and a bare list of mutations are accepted.
+func Parse(data []byte) (*Scenario, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("parsing scenario: %w", err)
+	}
+	s := &Scenario{}
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		if err := node.Content[0].Decode(&s.Mutations); err != nil {
+			return nil, fmt.Errorf("parsing scenario: %w", err)
+		}
+	} else if err := node.Decode(s); err != nil {
+		return nil, fmt.Errorf("parsing scenario: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks that every mutation is well formed.
+func (s *Scenario) Validate() error {
+	if len(s.Mutations) == 0 {
+		return fmt.Errorf("scenario has no mutations")
+	}
+	for i, m := range s.Mutations {
+		if err := m.validate(); err != nil {
+			return fmt.Errorf("mutation %d (%s): %w", i+1, m.Op, err)
+		}
+	}
+	return nil
+}
+
+func (m Mutation) validate() error {
+	if m.ID == "" && len(m.IDs) == 0 && m.Label == "" && m.Assignee == "" && m.Status == "" {
+		return fmt.Errorf("needs at least one selector (id, ids, label, assignee, status)")
+	}
+	if m.Status != "" && !model.Status(m.Status).IsValid() {
+		return fmt.Errorf("invalid status selector %q", m.Status)
+	}
+	switch m.Op {
+	case OpClose, OpReopen:
+	case OpAddDep, OpRemoveDep:
+		if m.DependsOn == "" {
+			return fmt.Errorf("depends_on is required")
+		}
+		if m.Type != "" && !model.DependencyType(m.Type).IsValid() {
+			return fmt.Errorf("invalid dependency type %q", m.Type)
+		}
+	case OpEstimate:
+		if (m.Minutes == nil) == (m.Add == "") {
+			return fmt.Errorf("exactly one of minutes or add is required")
+		}
+		if m.Minutes != nil && *m.Minutes < 0 {
+			return fmt.Errorf("minutes must be non-negative")
+		}
+		if m.Add != "" {
+			if _, err := ParseWorkDuration(m.Add); err != nil {
+				return err
+			}
+		}
+	case "":
+		return fmt.Errorf("op is required")
+	default:
+		return fmt.Errorf("unknown op (want close, reopen, add_dep, remove_dep, estimate)")
+	}
+	return nil
+}
+
+// selector renders the mutation's selectors for reports.
+func (m Mutation) selector() string {
+	var parts []string
+	if m.ID != "" {
+		parts = append(parts, "id="+m.ID)
+	}
+	if len(m.IDs) > 0 {
+		parts = append(parts, "ids="+strings.Join(m.IDs, ","))
+	}
+	if m.Label != "" {
+		parts = append(parts, "label="+m.Label)
+	}
+	if m.Assignee != "" {
+		parts = append(parts, "assignee="+m.Assignee)
+	}
+	if m.Status != "" {
+		parts = append(parts, "status="+m.Status)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m Mutation) matches(issue model.Issue) bool {
+	if m.ID != "" && issue.ID != m.ID {
+		return false
+	}
+	if len(m.IDs) > 0 {
+		found := false
+		for _, id := range m.IDs {
+			if id == issue.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if m.Label != "" {
+		found := false
+		for _, l := range issue.Labels {
+			if l == m.Label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if m.Assignee != "" && !strings.EqualFold(issue.Assignee, m.Assignee) {
+		return false
+	}
+	if m.Status != "" && string(issue.Status) != m.Status {
+		return false
+	}
+	return true
+}
+
+// Apply runs the scenario's mutations in order against a deep copy of
+// issues; the input slice is never modified. Mutations see the effect of the
+// ones before them, so "reopen" after "close" is well defined.
+func (s *Scenario) Apply(issues []model.Issue, now time.Time) ([]model.Issue, []Applied, error) {
+	out := make([]model.Issue, len(issues))
+	index := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.Clone()
+		index[issue.ID] = i
+	}
+
+	applied := make([]Applied, 0, len(s.Mutations))
+	for mi, m := range s.Mutations {
+		if (m.Op == OpAddDep || m.Op == OpRemoveDep) && m.DependsOn != "" {
+			if _, ok := index[m.DependsOn]; !ok {
+				return nil, nil, fmt.Errorf("mutation %d (%s): unknown issue %q", mi+1, m.Op, m.DependsOn)
+			}
+		}
+		rec := Applied{Index: mi, Op: m.Op, Selector: m.selector(), IssueIDs: []string{}}
+		selected := 0
+		for i := range out {
+			if !m.matches(out[i]) {
+				continue
+			}
+			selected++
+			if applyMutation(&out[i], m, now) {
+				rec.IssueIDs = append(rec.IssueIDs, out[i].ID)
+			} else {
+				rec.Skipped = append(rec.Skipped, out[i].ID)
+			}
+		}
+		if selected == 0 {
+			return nil, nil, fmt.Errorf("mutation %d (%s %s): selects no issues", mi+1, m.Op, rec.Selector)
+		}
+		sort.Strings(rec.IssueIDs)
+		sort.Strings(rec.Skipped)
+		applied = append(applied, rec)
+	}
+	return out, applied, nil
+}
+
+// applyMutation changes issue in place and reports whether anything changed.
+func applyMutation(issue *model.Issue, m Mutation, now time.Time) bool {
+	switch m.Op {
+	case OpClose:
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			return false
+		}
+		issue.Status = model.StatusClosed
+		closedAt := now
+		issue.ClosedAt = &closedAt
+		issue.UpdatedAt = now
+
+	case OpReopen:
+		if !issue.Status.IsClosed() {
+			return false
+		}
+		issue.Status = model.StatusOpen
+		issue.ClosedAt = nil
+		issue.UpdatedAt = now
+
+	case OpAddDep:
+		if m.DependsOn == issue.ID {
+			return false
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == m.DependsOn {
+				return false
+			}
+		}
+		depType := model.DepBlocks
+		if m.Type != "" {
+			depType = model.DependencyType(m.Type)
+		}
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+			IssueID:     issue.ID,
+			DependsOnID: m.DependsOn,
+			Type:        depType,
+			CreatedAt:   now,
+			CreatedBy:   "scenario",
+		})
+		issue.UpdatedAt = now
+
+	case OpRemoveDep:
+		kept := issue.Dependencies[:0:0]
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == m.DependsOn {
+				continue
+			}
+			kept = append(kept, dep)
+		}
+		if len(kept) == len(issue.Dependencies) {
+			return false
+		}
+		issue.Dependencies = kept
+		issue.UpdatedAt = now
+
+	case OpEstimate:
+		var minutes int
+		if m.Minutes != nil {
+			minutes = *m.Minutes
+		} else {
+			delta, _ := ParseWorkDuration(m.Add) // validated
+			if issue.EstimatedMinutes != nil {
+				minutes = *issue.EstimatedMinutes
+			}
+			minutes = max(0, minutes+delta)
+		}
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes == minutes {
+			return false
+		}
+		issue.EstimatedMinutes = &minutes
+		issue.UpdatedAt = now
+
+	default:
+		return false
+	}
+	return true
+}
+
+// ParseWorkDuration converts a working-time duration ("2w", "3d", "4h",
+// "90m", optionally signed) to minutes, assuming 8-hour days and 5-day weeks.
+// A bare number is taken as minutes.
+func ParseWorkDuration(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	unit := 1
+	switch s[len(s)-1] {
+	case 'w':
+		unit = workDaysPerWeek * workMinutesPerDay
+	case 'd':
+		unit = workMinutesPerDay
+	case 'h':
+		unit = 60
+	case 'm':
+	default:
+		if s[len(s)-1] < '0' || s[len(s)-1] > '9' {
+			return 0, fmt.Errorf("invalid duration %q (use w, d, h or m)", s)
+		}
+		s += "m"
+	}
+	n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return int(n * float64(unit)), nil
+}
@@ -0,0 +1,182 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+var testNow = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func issue(id string, status model.Status, deps ...string) model.Issue {
+	iss := model.Issue{
+		ID:        id,
+		Title:     id,
+		Status:    status,
+		IssueType: model.TypeTask,
+		CreatedAt: testNow.Add(-30 * 24 * time.Hour),
+		UpdatedAt: testNow.Add(-24 * time.Hour),
+	}
+	for _, d := range deps {
+		iss.Dependencies = append(iss.Dependencies, &model.Dependency{IssueID: id, DependsOnID: d, Type: model.DepBlocks})
+	}
+	return iss
+}
+
+func fixture() []model.Issue {
+	auth1 := issue("auth-1", model.StatusOpen)
+	auth1.Labels = []string{"auth"}
+	auth2 := issue("auth-2", model.StatusInProgress, "auth-1")
+	auth2.Labels = []string{"auth"}
+	auth2.Assignee = "alice"
+	ui := issue("ui-1", model.StatusOpen, "auth-2")
+	est := 120
+	ui.EstimatedMinutes = &est
+	docs := issue("docs-1", model.StatusOpen)
+	done := issue("done-1", model.StatusClosed)
+	closedAt := testNow.Add(-3 * 24 * time.Hour)
+	done.ClosedAt = &closedAt
+	return []model.Issue{auth1, auth2, ui, docs, done}
+}
+
+func TestParse_YAMLAndBareJSONList(t *testing.T) {
+	s, err := Parse([]byte(`
+name: auth ships
+mutations:
+  - op: close
+    label: auth
+  - op: estimate
+    id: ui-1
+    add: 2w
+`))
+	if err != nil {
+		t.Fatalf("Parse YAML: %v", err)
+	}
+	if s.Name != "auth ships" || len(s.Mutations) != 2 || s.Mutations[1].Add != "2w" {
+		t.Errorf("unexpected scenario: %+v", s)
+	}
+
+	s, err = Parse([]byte(`[{"op":"add_dep","id":"docs-1","depends_on":"ui-1"},{"op":"estimate","id":"ui-1","minutes":60}]`))
+	if err != nil {
+		t.Fatalf("Parse JSON list: %v", err)
+	}
+	if len(s.Mutations) != 2 || s.Mutations[0].DependsOn != "ui-1" || *s.Mutations[1].Minutes != 60 {
+		t.Errorf("unexpected scenario: %+v", s)
+	}
+}
+
+func TestParse_Validation(t *testing.T) {
+	cases := map[string]string{
+		"[]":                          "no mutations",
+		`[{"op":"close"}]`:            "selector",
+		`[{"op":"explode","id":"a"}]`: "unknown op",
+		`[{"op":"add_dep","id":"a"}]`: "depends_on",
+		`[{"op":"add_dep","id":"a","depends_on":"b","type":"nope"}]`: "dependency type",
+		`[{"op":"estimate","id":"a"}]`:                               "exactly one",
+		`[{"op":"estimate","id":"a","add":"3x"}]`:                    "invalid duration",
+		`[{"op":"close","status":"someday"}]`:                        "invalid status",
+	}
+	for input, want := range cases {
+		if _, err := Parse([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%s) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestParseWorkDuration(t *testing.T) {
+	cases := map[string]int{"2w": 4800, "3d": 1440, "4h": 240, "90m": 90, "45": 45, "-1d": -480, "1.5h": 90}
+	for in, want := range cases {
+		got, err := ParseWorkDuration(in)
+		if err != nil || got != want {
+			t.Errorf("ParseWorkDuration(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+}
+
+func TestApply_SelectorsAndOps(t *testing.T) {
+	baseline := fixture()
+	s := &Scenario{Mutations: []Mutation{
+		{Op: OpClose, Assignee: "alice", Status: "in_progress"},
+		{Op: OpEstimate, ID: "ui-1", Add: "2w"},
+		{Op: OpRemoveDep, ID: "ui-1", DependsOn: "auth-2"},
+		{Op: OpAddDep, ID: "docs-1", DependsOn: "ui-1"},
+		{Op: OpReopen, IDs: []string{"done-1", "docs-1"}},
+	}}
+	got, applied, err := s.Apply(baseline, testNow)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	byID := make(map[string]model.Issue)
+	for _, iss := range got {
+		byID[iss.ID] = iss
+	}
+	if a2 := byID["auth-2"]; a2.Status != model.StatusClosed || a2.ClosedAt == nil || !a2.ClosedAt.Equal(testNow) {
+		t.Errorf("auth-2 not closed: %+v", a2)
+	}
+	if ui := byID["ui-1"]; *ui.EstimatedMinutes != 120+4800 || len(ui.Dependencies) != 0 {
+		t.Errorf("ui-1 = estimate %d deps %d", *ui.EstimatedMinutes, len(ui.Dependencies))
+	}
+	if docs := byID["docs-1"]; len(docs.Dependencies) != 1 || docs.Dependencies[0].Type != model.DepBlocks {
+		t.Errorf("docs-1 deps = %v", docs.Dependencies)
+	}
+	if done := byID["done-1"]; done.Status != model.StatusOpen || done.ClosedAt != nil {
+		t.Errorf("done-1 not reopened: %+v", done)
+	}
+	if fmt.Sprint(applied[4].IssueIDs, applied[4].Skipped) != "[done-1] [docs-1]" {
+		t.Errorf("reopen applied = %+v", applied[4])
+	}
+
+	// The baseline is untouched.
+	if baseline[1].Status != model.StatusInProgress || *baseline[2].EstimatedMinutes != 120 || len(baseline[2].Dependencies) != 1 {
+		t.Error("Apply mutated the baseline")
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	for _, m := range []Mutation{
+		{Op: OpClose, Label: "nope"},
+		{Op: OpAddDep, ID: "ui-1", DependsOn: "ghost"},
+	} {
+		s := &Scenario{Mutations: []Mutation{m}}
+		if _, _, err := s.Apply(fixture(), testNow); err == nil {
+			t.Errorf("Apply(%+v) succeeded, want error", m)
+		}
+	}
+}
+
+func TestCompare_ClosingBlockersUnblocksWork(t *testing.T) {
+	s := &Scenario{Name: "auth ships", Mutations: []Mutation{{Op: OpClose, Label: "auth"}}}
+	res, err := Compare(s, fixture(), Options{Trials: 200, Seed: 7, Now: testNow})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+
+	if res.Diff.Summary.IssuesClosed != 2 {
+		t.Errorf("diff closed = %d, want 2", res.Diff.Summary.IssuesClosed)
+	}
+	if res.Triage.OpenDelta != -2 || res.Triage.Baseline.BlockedCount != 2 || res.Triage.Scenario.BlockedCount != 0 {
+		t.Errorf("triage = %+v", res.Triage)
+	}
+	if fmt.Sprint(res.Plan.NewlyActionable) != "[ui-1]" || fmt.Sprint(res.Plan.NoLongerActionable) != "[auth-1]" {
+		t.Errorf("plan = %+v", res.Plan)
+	}
+	f := res.Forecast
+	if f == nil {
+		t.Fatal("forecast missing")
+	}
+	if f.Baseline.Remaining != 4 || f.Scenario.Remaining != 2 {
+		t.Errorf("remaining = %d -> %d", f.Baseline.Remaining, f.Scenario.Remaining)
+	}
+	if f.P85DeltaDays >= 0 {
+		t.Errorf("closing work should pull the forecast in, got %+.1f days", f.P85DeltaDays)
+	}
+
+	res, err = Compare(s, fixture(), Options{Now: testNow})
+	if err != nil || res.Forecast != nil {
+		t.Errorf("Trials=0 should skip the forecast: %v %+v", err, res.Forecast)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // EmbeddingConfigFromEnv reads semantic embedding configuration from environment variables.
@@ -14,17 +15,45 @@ import (
 //   - BV_SEMANTIC_EMBEDDER: embedding provider (default: "hash")
 //   - BV_SEMANTIC_MODEL: model identifier (provider-specific, optional)
 //   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim)
+//   - BV_SEMANTIC_URL: OpenAI-compatible base URL (default: DefaultOpenAIBaseURL)
+//   - BV_SEMANTIC_API_KEY: bearer token (falls back to OPENAI_API_KEY)
+//   - BV_SEMANTIC_COMMAND: command line for the subprocess embedder
+//   - BV_SEMANTIC_BATCH: texts per request (default: DefaultEmbeddingBatchSize)
+//   - BV_SEMANTIC_TIMEOUT: per-request timeout as a Go duration (default: 30s)
+//   - BV_SEMANTIC_RETRIES: retries on transient failures (default: DefaultEmbeddingMaxRetries)
 func EmbeddingConfigFromEnv() EmbeddingConfig {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvSemanticEmbedder)))
 	cfg := EmbeddingConfig{
-		Provider: Provider(provider),
-		Model:    strings.TrimSpace(os.Getenv(EnvSemanticModel)),
+		Provider:   Provider(provider),
+		Model:      strings.TrimSpace(os.Getenv(EnvSemanticModel)),
+		BaseURL:    strings.TrimSpace(os.Getenv(EnvSemanticURL)),
+		APIKey:     strings.TrimSpace(os.Getenv(EnvSemanticAPIKey)),
+		Command:    strings.TrimSpace(os.Getenv(EnvSemanticCommand)),
+		MaxRetries: DefaultEmbeddingMaxRetries,
 	}
 	if dimStr := os.Getenv(EnvSemanticDim); dimStr != "" {
 		if dim, err := strconv.Atoi(dimStr); err == nil {
 			cfg.Dim = dim
 		}
 	}
+	if batchStr := os.Getenv(EnvSemanticBatch); batchStr != "" {
+		if batch, err := strconv.Atoi(batchStr); err == nil {
+			cfg.BatchSize = batch
+		}
+	}
+	if timeoutStr := os.Getenv(EnvSemanticTimeout); timeoutStr != "" {
+		if timeout, err := time.ParseDuration(timeoutStr); err == nil {
+			cfg.Timeout = timeout
+		}
+	}
+	if retriesStr := os.Getenv(EnvSemanticRetries); retriesStr != "" {
+		if retries, err := strconv.Atoi(retriesStr); err == nil {
+			cfg.MaxRetries = retries
+		}
+	}
+	if cfg.APIKey == "" {
+		cfg.APIKey = strings.TrimSpace(os.Getenv("OPENAI_API_KEY"))
+	}
 	if cfg.Provider == "" {
 		cfg.Provider = ProviderHash
 	}
@@ -37,12 +66,18 @@ func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 	switch cfg.Provider {
 	case "", ProviderHash:
 		return NewHashEmbedder(cfg.Dim), nil
-	case ProviderPythonSentenceTransformers:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (mvp placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+	case ProviderPythonSentenceTransformers, ProviderSubprocess:
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("semantic embedder %q requires %s (a command speaking the bv embedding protocol); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticCommand, EnvSemanticEmbedder, ProviderHash)
+		}
+		return NewSubprocessEmbedder(cfg), nil
 	case ProviderOpenAI:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		if cfg.Model == "" {
+			return nil, fmt.Errorf("semantic embedder %q requires %s (e.g. text-embedding-3-small or nomic-embed-text); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticModel, EnvSemanticEmbedder, ProviderHash)
+		}
+		return NewOpenAIEmbedder(cfg), nil
 	default:
-		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q", cfg.Provider, ProviderHash)
+		return nil, fmt.Errorf("unknown semantic embedder %q; expected one of %q, %q, %q", cfg.Provider, ProviderHash, ProviderOpenAI, ProviderSubprocess)
 	}
 }
 
@@ -58,22 +93,33 @@ const (
 	EnvSearchMode    = "BV_SEARCH_MODE"
 	EnvSearchPreset  = "BV_SEARCH_PRESET"
 	EnvSearchWeights = "BV_SEARCH_WEIGHTS"
+
+	EnvSearchLexicalWeight = "BV_SEARCH_LEXICAL_WEIGHT"
 )
 
+// DefaultLexicalWeight is the share of text relevance taken from BM25 when
+// blending it with vector similarity. Blending is opt-in, so vector
+// similarity alone ranks results unless a weight is configured.
+const DefaultLexicalWeight = 0.0
+
 // SearchConfig captures hybrid search configuration from env or flags.
 type SearchConfig struct {
 	Mode       SearchMode
 	Preset     PresetName
 	Weights    Weights
 	HasWeights bool
+
+	// LexicalWeight blends BM25 into text relevance (0 = vectors only).
+	LexicalWeight float64
 }
 
 // SearchConfigFromEnv reads hybrid search configuration from environment variables.
-// Defaults: mode=text, preset=default.
+// Defaults: mode=text, preset=default, lexical weight=DefaultLexicalWeight.
 func SearchConfigFromEnv() (SearchConfig, error) {
 	cfg := SearchConfig{
-		Mode:   SearchModeText,
-		Preset: PresetDefault,
+		Mode:          SearchModeText,
+		Preset:        PresetDefault,
+		LexicalWeight: DefaultLexicalWeight,
 	}
 
 	if mode := strings.TrimSpace(os.Getenv(EnvSearchMode)); mode != "" {
@@ -102,9 +148,26 @@ func SearchConfigFromEnv() (SearchConfig, error) {
 		cfg.HasWeights = true
 	}
 
+	if raw := strings.TrimSpace(os.Getenv(EnvSearchLexicalWeight)); raw != "" {
+		weight, err := ParseLexicalWeight(raw)
+		if err != nil {
+			return SearchConfig{}, fmt.Errorf("invalid %s: %w", EnvSearchLexicalWeight, err)
+		}
+		cfg.LexicalWeight = weight
+	}
+
 	return cfg, nil
 }
 
+// ParseLexicalWeight parses a BM25 blend weight between 0 and 1.
+func ParseLexicalWeight(raw string) (float64, error) {
+	weight, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil || weight < 0 || weight > 1 {
+		return 0, fmt.Errorf("%q (expected a number from 0 to 1)", raw)
+	}
+	return weight, nil
+}
+
 // ParseWeightsJSON parses a JSON string into Weights, enforcing required keys.
 func ParseWeightsJSON(raw string) (Weights, error) {
 	var payload map[string]float64
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -250,6 +251,24 @@ func TestSearchConfigFromEnv_InvalidPreset(t *testing.T) {
 	}
 }
 
+func TestSearchConfigFromEnv_LexicalWeight(t *testing.T) {
+	t.Setenv(EnvSearchLexicalWeight, "")
+	cfg, err := SearchConfigFromEnv()
+	if err != nil || cfg.LexicalWeight != DefaultLexicalWeight {
+		t.Fatalf("default lexical weight = %v (err %v)", cfg.LexicalWeight, err)
+	}
+
+	t.Setenv(EnvSearchLexicalWeight, "0")
+	if cfg, err = SearchConfigFromEnv(); err != nil || cfg.LexicalWeight != 0 {
+		t.Fatalf("lexical weight = %v (err %v), want 0", cfg.LexicalWeight, err)
+	}
+
+	t.Setenv(EnvSearchLexicalWeight, "2")
+	if _, err := SearchConfigFromEnv(); err == nil {
+		t.Fatal("expected error for out-of-range lexical weight")
+	}
+}
+
 func TestParseWeightsJSON(t *testing.T) {
 	valid := `{"text":0.4,"pagerank":0.2,"status":0.15,"impact":0.1,"priority":0.1,"recency":0.05}`
 	weights, err := ParseWeightsJSON(valid)
@@ -287,6 +306,32 @@ func saveSearchEnv() func() {
 	}
 }
 
+func TestEmbeddingConfigFromEnv_BackendSettings(t *testing.T) {
+	t.Setenv(EnvSemanticEmbedder, "openai")
+	t.Setenv(EnvSemanticURL, "http://localhost:11434/v1")
+	t.Setenv(EnvSemanticAPIKey, "")
+	t.Setenv("OPENAI_API_KEY", "sk-fallback")
+	t.Setenv(EnvSemanticCommand, "  python embed.py  ")
+	t.Setenv(EnvSemanticBatch, "8")
+	t.Setenv(EnvSemanticTimeout, "90s")
+	t.Setenv(EnvSemanticRetries, "")
+
+	cfg := EmbeddingConfigFromEnv()
+	if cfg.BaseURL != "http://localhost:11434/v1" || cfg.APIKey != "sk-fallback" || cfg.Command != "python embed.py" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.BatchSize != 8 || cfg.Timeout != 90*time.Second || cfg.MaxRetries != DefaultEmbeddingMaxRetries {
+		t.Errorf("batch %d timeout %s retries %d", cfg.BatchSize, cfg.Timeout, cfg.MaxRetries)
+	}
+
+	t.Setenv(EnvSemanticTimeout, "soon")
+	t.Setenv(EnvSemanticRetries, "0")
+	cfg = EmbeddingConfigFromEnv()
+	if cfg.Timeout != DefaultEmbeddingTimeout || cfg.MaxRetries != 0 {
+		t.Errorf("invalid timeout should fall back: timeout %s retries %d", cfg.Timeout, cfg.MaxRetries)
+	}
+}
+
 // =============================================================================
 // NewEmbedderFromConfig Tests
 // =============================================================================
@@ -336,16 +381,34 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 			},
 		},
 		{
-			name:        "python-sentence-transformers not implemented",
+			name:        "python-sentence-transformers requires command",
 			cfg:         EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Dim: 384},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: EnvSemanticCommand,
 		},
 		{
-			name:        "openai not implemented",
+			name:        "openai requires model",
 			cfg:         EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: EnvSemanticModel,
+		},
+		{
+			name: "openai with model",
+			cfg:  EmbeddingConfig{Provider: ProviderOpenAI, Model: "nomic-embed-text", Dim: 768},
+			checkEmbed: func(t *testing.T, e Embedder) {
+				if e.Provider() != ProviderOpenAI || e.Dim() != 768 {
+					t.Errorf("got provider %q dim %d", e.Provider(), e.Dim())
+				}
+			},
+		},
+		{
+			name: "subprocess with command",
+			cfg:  EmbeddingConfig{Provider: ProviderSubprocess, Command: "my-embedder --serve"},
+			checkEmbed: func(t *testing.T, e Embedder) {
+				if e.Provider() != ProviderSubprocess || e.Dim() != DefaultEmbeddingDim {
+					t.Errorf("got provider %q dim %d", e.Provider(), e.Dim())
+				}
+			},
 		},
 		{
 			name:        "unknown provider error",
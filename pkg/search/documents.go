@@ -36,12 +36,19 @@ func IssueDocument(issue model.Issue) string {
 
 // DocumentsFromIssues builds an ID->document map suitable for indexing.
 func DocumentsFromIssues(issues []model.Issue) map[string]string {
-	docs := make(map[string]string, len(issues))
+	return documentsFromIssues(issues, IssueDocument)
+}
+
+// documentsFromIssues builds an ID->document map with build, skipping issues
+// without an ID. The vector and lexical indexes share it so they always
+// cover the same issues.
+func documentsFromIssues[T any](issues []model.Issue, build func(model.Issue) T) map[string]T {
+	docs := make(map[string]T, len(issues))
 	for _, issue := range issues {
 		if issue.ID == "" {
 			continue
 		}
-		docs[issue.ID] = IssueDocument(issue)
+		docs[issue.ID] = build(issue)
 	}
 	return docs
 }
@@ -0,0 +1,282 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DuplicateMethodSemantic tags duplicate pairs found by embedding similarity,
+// as opposed to analysis.DetectDuplicates' "jaccard" keyword overlap.
+const DuplicateMethodSemantic = "semantic"
+
+// SemanticDuplicateConfig configures embedding-based duplicate detection.
+type SemanticDuplicateConfig struct {
+	// Threshold is the minimum cosine similarity for two issues to be
+	// considered duplicates. Default: 0.9
+	Threshold float64
+
+	// Neighbors is how many nearest neighbours are checked per issue.
+	// Default: 10
+	Neighbors int
+
+	// IgnoreClosedVsOpen skips pairs where one is closed and one is open.
+	// Default: true
+	IgnoreClosedVsOpen bool
+
+	// MaxSuggestions limits the number of suggestions. Default: 20
+	MaxSuggestions int
+}
+
+// DefaultSemanticDuplicateConfig returns sensible defaults.
+func DefaultSemanticDuplicateConfig() SemanticDuplicateConfig {
+	return SemanticDuplicateConfig{
+		Threshold:          0.9,
+		Neighbors:          10,
+		IgnoreClosedVsOpen: true,
+		MaxSuggestions:     20,
+	}
+}
+
+// DuplicateThreshold returns the default duplicate threshold for an
+// embedding provider. Hashed-token vectors only measure word overlap, so a
+// reworded duplicate scores lower than it would with a real model.
+func DuplicateThreshold(provider Provider) float64 {
+	if provider == "" || provider == ProviderHash {
+		return 0.75
+	}
+	return DefaultSemanticDuplicateConfig().Threshold
+}
+
+// DuplicateCluster is a group of issues joined by duplicate pairs, including
+// transitive ones (A~B and B~C put A, B and C together).
+type DuplicateCluster struct {
+	// Canonical is the oldest issue, the one the others duplicate.
+	Canonical string                   `json:"canonical"`
+	IssueIDs  []string                 `json:"issue_ids"`
+	Pairs     []analysis.DuplicatePair `json:"pairs"`
+}
+
+// FindSemanticDuplicatePairs returns issue pairs whose vectors in idx are at
+// least cfg.Threshold similar, highest first. Issues missing from idx are
+// skipped.
+func FindSemanticDuplicatePairs(idx *VectorIndex, issues []model.Issue, cfg SemanticDuplicateConfig) []analysis.DuplicatePair {
+	if idx == nil || len(issues) < 2 {
+		return nil
+	}
+	if cfg.Neighbors <= 0 {
+		cfg.Neighbors = DefaultSemanticDuplicateConfig().Neighbors
+	}
+
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		if issues[i].Status != model.StatusTombstone {
+			byID[issues[i].ID] = &issues[i]
+		}
+	}
+
+	seen := make(map[[2]string]bool)
+	var pairs []analysis.DuplicatePair
+	for _, id := range idx.sortedIDs() {
+		issue := byID[id]
+		if issue == nil {
+			continue
+		}
+		entry, ok := idx.Get(id)
+		if !ok {
+			continue
+		}
+		neighbors, err := idx.SearchTopK(entry.Vector, cfg.Neighbors+1)
+		if err != nil {
+			continue
+		}
+		for _, n := range neighbors {
+			if n.Score < cfg.Threshold {
+				break
+			}
+			other := byID[n.IssueID]
+			if other == nil || n.IssueID == id {
+				continue
+			}
+			key := [2]string{min(id, n.IssueID), max(id, n.IssueID)}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if cfg.IgnoreClosedVsOpen && issue.Status.Class().IsResolved() != other.Status.Class().IsResolved() {
+				continue
+			}
+			pairs = append(pairs, analysis.DuplicatePair{
+				Issue1:     key[0],
+				Issue2:     key[1],
+				Similarity: min(n.Score, 1),
+				Method:     DuplicateMethodSemantic,
+			})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Similarity != pairs[j].Similarity {
+			return pairs[i].Similarity > pairs[j].Similarity
+		}
+		if pairs[i].Issue1 != pairs[j].Issue1 {
+			return pairs[i].Issue1 < pairs[j].Issue1
+		}
+		return pairs[i].Issue2 < pairs[j].Issue2
+	})
+	return pairs
+}
+
+// ClusterDuplicates merges pairs into clusters with union-find. The
+// canonical issue of each cluster is the oldest by creation time (ties by
+// ID); clusters are ordered by their best pair.
+func ClusterDuplicates(pairs []analysis.DuplicatePair, issues []model.Issue) []DuplicateCluster {
+	parent := make(map[string]string)
+	var find func(x string) string
+	find = func(x string) string {
+		if parent[x] == "" {
+			parent[x] = x
+		}
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	for _, p := range pairs {
+		a, b := find(p.Issue1), find(p.Issue2)
+		if a != b {
+			// Smaller ID wins so roots don't depend on pair order
+			if a < b {
+				parent[b] = a
+			} else {
+				parent[a] = b
+			}
+		}
+	}
+
+	created := make(map[string]int64, len(issues))
+	for _, issue := range issues {
+		created[issue.ID] = issue.CreatedAt.UnixNano()
+	}
+
+	byRoot := make(map[string]*DuplicateCluster)
+	var order []string
+	for _, p := range pairs { // Highest similarity first
+		root := find(p.Issue1)
+		c := byRoot[root]
+		if c == nil {
+			c = &DuplicateCluster{}
+			byRoot[root] = c
+			order = append(order, root)
+		}
+		c.Pairs = append(c.Pairs, p)
+	}
+
+	clusters := make([]DuplicateCluster, 0, len(order))
+	for _, root := range order {
+		c := byRoot[root]
+		members := make(map[string]bool)
+		for _, p := range c.Pairs {
+			members[p.Issue1] = true
+			members[p.Issue2] = true
+		}
+		for id := range members {
+			c.IssueIDs = append(c.IssueIDs, id)
+		}
+		sort.Slice(c.IssueIDs, func(i, j int) bool {
+			a, b := c.IssueIDs[i], c.IssueIDs[j]
+			if created[a] != created[b] {
+				return created[a] < created[b]
+			}
+			return a < b
+		})
+		c.Canonical = c.IssueIDs[0]
+		clusters = append(clusters, *c)
+	}
+	return clusters
+}
+
+// DetectSemanticDuplicates finds duplicate clusters in idx and suggests
+// marking each non-canonical member as a duplicate of its cluster's
+// canonical issue.
+func DetectSemanticDuplicates(idx *VectorIndex, issues []model.Issue, cfg SemanticDuplicateConfig) []analysis.Suggestion {
+	pairs := FindSemanticDuplicatePairs(idx, issues, cfg)
+	if len(pairs) == 0 {
+		return nil
+	}
+	statusByID := make(map[string]model.Status, len(issues))
+	for _, issue := range issues {
+		statusByID[issue.ID] = issue.Status
+	}
+
+	var suggestions []analysis.Suggestion
+	for i, c := range ClusterDuplicates(pairs, issues) {
+		canonical, ok := idx.Get(c.Canonical)
+		if !ok {
+			continue
+		}
+		for _, id := range c.IssueIDs[1:] {
+			similarity, ok := idx.Score(canonical.Vector, id)
+			if !ok {
+				continue
+			}
+			similarity = min(max(similarity, 0), 1)
+			reason := fmt.Sprintf("%.0f%% embedding similarity", similarity*100)
+			if len(c.IssueIDs) > 2 {
+				reason += fmt.Sprintf("; cluster of %d: %s", len(c.IssueIDs), strings.Join(c.IssueIDs, ", "))
+			}
+			sug := analysis.NewSuggestion(
+				analysis.SuggestionPotentialDuplicate,
+				id,
+				fmt.Sprintf("Potential duplicate of %s", c.Canonical),
+				reason,
+				similarity,
+			).WithRelatedBead(c.Canonical).
+				WithMetadata("method", DuplicateMethodSemantic).
+				WithMetadata("cluster_id", i+1).
+				WithMetadata("cluster", c.IssueIDs)
+			if !statusByID[id].Class().IsResolved() && !statusByID[c.Canonical].Class().IsResolved() {
+				sug = sug.WithAction(fmt.Sprintf("br dep add %s %s --type=related", id, c.Canonical))
+			}
+			suggestions = append(suggestions, sug)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Confidence > suggestions[j].Confidence
+	})
+	if cfg.MaxSuggestions > 0 && len(suggestions) > cfg.MaxSuggestions {
+		suggestions = suggestions[:cfg.MaxSuggestions]
+	}
+	return suggestions
+}
+
+// SimilarIssues returns up to k issues most similar to issueID with a score
+// of at least minScore, excluding issueID itself.
+func SimilarIssues(idx *VectorIndex, issueID string, k int, minScore float64) []SearchResult {
+	if idx == nil || k <= 0 {
+		return nil
+	}
+	entry, ok := idx.Get(issueID)
+	if !ok {
+		return nil
+	}
+	hits, err := idx.SearchTopK(entry.Vector, k+1)
+	if err != nil {
+		return nil
+	}
+	out := make([]SearchResult, 0, k)
+	for _, h := range hits {
+		if h.IssueID == issueID {
+			continue
+		}
+		if h.Score < minScore || len(out) == k {
+			break
+		}
+		out = append(out, h)
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// duplicateFixture: A~B and B~C pass 0.9 but A~C (0.84) doesn't, so only
+// union-find puts all three together. E is a closed copy of A.
+func duplicateFixture(t *testing.T) (*VectorIndex, []model.Issue) {
+	t.Helper()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	vectors := map[string][]float32{
+		"A": {1, 0, 0, 0},
+		"B": {1, 0.3, 0, 0},
+		"C": {1, 0.65, 0, 0},
+		"D": {0, 0, 1, 0},
+		"E": {1, 0, 0, 0.1},
+	}
+	created := map[string]int{"A": 2, "B": 1, "C": 3, "D": 0, "E": 4}
+
+	idx := NewVectorIndex(4)
+	var issues []model.Issue
+	for _, id := range []string{"A", "B", "C", "D", "E"} {
+		vec := vectors[id]
+		normalizeL2(vec)
+		if err := idx.Upsert(id, ComputeContentHash(id), vec); err != nil {
+			t.Fatal(err)
+		}
+		status := model.StatusOpen
+		if id == "E" {
+			status = model.StatusClosed
+		}
+		issues = append(issues, model.Issue{
+			ID:        id,
+			Title:     "Issue " + id,
+			Status:    status,
+			CreatedAt: base.Add(time.Duration(created[id]) * time.Hour),
+		})
+	}
+	return idx, issues
+}
+
+func TestFindSemanticDuplicatePairs(t *testing.T) {
+	idx, issues := duplicateFixture(t)
+	pairs := FindSemanticDuplicatePairs(idx, issues, DefaultSemanticDuplicateConfig())
+
+	var got []string
+	for _, p := range pairs {
+		got = append(got, fmt.Sprintf("%s-%s", p.Issue1, p.Issue2))
+		if p.Method != DuplicateMethodSemantic {
+			t.Errorf("method = %q", p.Method)
+		}
+	}
+	// B-C (0.960) just edges out A-B (0.958); closed E is excluded
+	if strings.Join(got, ",") != "B-C,A-B" {
+		t.Errorf("pairs = %v, want [B-C A-B]", got)
+	}
+
+	cfg := DefaultSemanticDuplicateConfig()
+	cfg.IgnoreClosedVsOpen = false
+	if pairs := FindSemanticDuplicatePairs(idx, issues, cfg); len(pairs) != 4 {
+		t.Errorf("with closed issues: %d pairs, want 4 (A-E, B-E added)", len(pairs))
+	}
+}
+
+func TestClusterDuplicates_MergesTransitivePairs(t *testing.T) {
+	idx, issues := duplicateFixture(t)
+	clusters := ClusterDuplicates(FindSemanticDuplicatePairs(idx, issues, DefaultSemanticDuplicateConfig()), issues)
+	if len(clusters) != 1 {
+		t.Fatalf("clusters = %+v, want 1", clusters)
+	}
+	c := clusters[0]
+	if c.Canonical != "B" || strings.Join(c.IssueIDs, ",") != "B,A,C" || len(c.Pairs) != 2 {
+		t.Errorf("cluster = %+v, want B (oldest) first of B,A,C", c)
+	}
+}
+
+func TestDetectSemanticDuplicates(t *testing.T) {
+	idx, issues := duplicateFixture(t)
+	sugs := DetectSemanticDuplicates(idx, issues, DefaultSemanticDuplicateConfig())
+	if len(sugs) != 2 {
+		t.Fatalf("suggestions = %+v, want 2", sugs)
+	}
+	for _, s := range sugs {
+		if s.RelatedBead != "B" || s.Metadata["method"] != DuplicateMethodSemantic || s.ActionCommand == "" {
+			t.Errorf("suggestion = %+v", s)
+		}
+		if !strings.Contains(s.Reason, "cluster of 3") {
+			t.Errorf("reason %q should name the cluster", s.Reason)
+		}
+	}
+	if sugs[0].TargetBead != "C" || sugs[1].TargetBead != "A" {
+		t.Errorf("order = %s, %s; want C (0.960) before A (0.958)", sugs[0].TargetBead, sugs[1].TargetBead)
+	}
+}
+
+func TestSimilarIssues(t *testing.T) {
+	idx, _ := duplicateFixture(t)
+	got := SimilarIssues(idx, "A", 3, 0.5)
+	if len(got) != 3 || got[0].IssueID != "E" {
+		t.Errorf("similar to A = %v, want E first of 3", got)
+	}
+	for _, r := range got {
+		if r.IssueID == "A" || r.IssueID == "D" {
+			t.Errorf("unexpected %s in %v", r.IssueID, got)
+		}
+	}
+	if got := SimilarIssues(idx, "missing", 3, 0); got != nil {
+		t.Errorf("missing issue = %v", got)
+	}
+}
+
+func TestDetectSemanticDuplicates_HashEmbedderParaphrase(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Login page crashes on Safari", Description: "The login page crashes when opened in Safari 17", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Safari: login page crashes", Description: "Opening the login page in Safari 17 crashes it", Status: model.StatusOpen},
+		{ID: "bv-3", Title: "Add CSV export", Description: "Export the board as CSV", Status: model.StatusOpen},
+	}
+	idx := NewVectorIndex(DefaultEmbeddingDim)
+	if _, err := SyncVectorIndex(t.Context(), idx, NewHashEmbedder(DefaultEmbeddingDim), DocumentsFromIssues(issues), 8); err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultSemanticDuplicateConfig()
+	cfg.Threshold = DuplicateThreshold(ProviderHash)
+	sugs := DetectSemanticDuplicates(idx, issues, cfg)
+	if len(sugs) != 1 || sugs[0].TargetBead != "bv-2" || sugs[0].RelatedBead != "bv-1" {
+		t.Errorf("suggestions = %+v, want bv-2 as duplicate of bv-1", sugs)
+	}
+}
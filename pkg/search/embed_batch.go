@@ -0,0 +1,78 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errPermanent marks failures that retrying cannot fix (bad request, bad model,
+// dimension mismatch).
+type errPermanent struct{ err error }
+
+func (e errPermanent) Error() string { return e.err.Error() }
+func (e errPermanent) Unwrap() error { return e.err }
+
+func permanent(err error) error { return errPermanent{err: err} }
+
+// embedBatches splits texts into batches of at most batchSize, calls embed on
+// each and checks every returned vector against dim. Vectors are L2-normalized
+// so VectorIndex dot products stay cosine similarities.
+func embedBatches(ctx context.Context, texts []string, batchSize, dim int, embed func(context.Context, []string) ([][]float32, error)) ([][]float32, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultEmbeddingBatchSize
+	}
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+batchSize, len(texts))
+		vecs, err := embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(vecs) != end-start {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(vecs), end-start)
+		}
+		for _, vec := range vecs {
+			if len(vec) != dim {
+				return nil, fmt.Errorf("embedder returned %d-dim vectors, expected %d; set %s=%d to match the model", len(vec), dim, EnvSemanticDim, len(vec))
+			}
+			normalizeL2(vec)
+			out = append(out, vec)
+		}
+	}
+	return out, nil
+}
+
+// withRetries runs attempt up to 1+maxRetries times with exponential backoff,
+// stopping early on permanent errors or context cancellation.
+func withRetries(ctx context.Context, maxRetries int, backoff time.Duration, attempt func(context.Context) ([][]float32, error)) ([][]float32, error) {
+	var lastErr error
+	tries := 0
+	for tries <= maxRetries {
+		if tries > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w (after %d attempts: %v)", ctx.Err(), tries, lastErr)
+			case <-time.After(backoff << (tries - 1)):
+			}
+		}
+		tries++
+		vecs, err := attempt(ctx)
+		if err == nil {
+			return vecs, nil
+		}
+		lastErr = err
+		var perm errPermanent
+		if errors.As(err, &perm) || ctx.Err() != nil {
+			break
+		}
+	}
+	if tries > 1 {
+		return nil, fmt.Errorf("after %d attempts: %w", tries, lastErr)
+	}
+	return nil, lastErr
+}
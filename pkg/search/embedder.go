@@ -1,6 +1,10 @@
 package search
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 // Provider identifies an embedding backend.
 type Provider string
@@ -12,18 +16,37 @@ const (
 
 	// ProviderPythonSentenceTransformers uses a Python subprocess running
 	// sentence-transformers to generate high-quality embeddings (MVP choice for bv-9gf).
+	// It speaks the same protocol as ProviderSubprocess; the script is user-supplied.
 	ProviderPythonSentenceTransformers Provider = "python-sentence-transformers"
 
-	// ProviderOpenAI uses a hosted embedding API.
+	// ProviderOpenAI uses an OpenAI-compatible /embeddings endpoint: the hosted
+	// API or a local server such as Ollama or llama.cpp.
 	ProviderOpenAI Provider = "openai"
+
+	// ProviderSubprocess runs a user-supplied command and exchanges
+	// length-prefixed JSON frames with it over stdin/stdout.
+	ProviderSubprocess Provider = "subprocess"
 )
 
 const DefaultEmbeddingDim = 384
 
+const (
+	DefaultEmbeddingBatchSize  = 32
+	DefaultEmbeddingTimeout    = 30 * time.Second
+	DefaultEmbeddingMaxRetries = 2
+	DefaultOpenAIBaseURL       = "https://api.openai.com/v1"
+)
+
 const (
 	EnvSemanticEmbedder = "BV_SEMANTIC_EMBEDDER"
 	EnvSemanticModel    = "BV_SEMANTIC_MODEL"
 	EnvSemanticDim      = "BV_SEMANTIC_DIM"
+	EnvSemanticURL      = "BV_SEMANTIC_URL"
+	EnvSemanticAPIKey   = "BV_SEMANTIC_API_KEY"
+	EnvSemanticCommand  = "BV_SEMANTIC_COMMAND"
+	EnvSemanticBatch    = "BV_SEMANTIC_BATCH"
+	EnvSemanticTimeout  = "BV_SEMANTIC_TIMEOUT"
+	EnvSemanticRetries  = "BV_SEMANTIC_RETRIES"
 )
 
 // EmbeddingConfig captures embedder selection/configuration.
@@ -32,18 +55,54 @@ type EmbeddingConfig struct {
 	Provider Provider
 	Model    string
 	Dim      int
+
+	BaseURL    string        // OpenAI-compatible endpoint root (e.g. http://localhost:11434/v1)
+	APIKey     string        // Bearer token; optional for local servers
+	Command    string        // Subprocess command line, run via the shell
+	BatchSize  int           // Texts per request
+	Timeout    time.Duration // Per-request timeout
+	MaxRetries int           // Retries after the first attempt on transient failures
 }
 
 func (c EmbeddingConfig) Normalized() EmbeddingConfig {
 	if c.Dim <= 0 {
 		c.Dim = DefaultEmbeddingDim
 	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultEmbeddingBatchSize
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultEmbeddingTimeout
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	}
 	return c
 }
 
+// IndexBuildTimeout bounds a full index sync. Local hashing is fast; model
+// backends get room for many batches on a cold index.
+func (c EmbeddingConfig) IndexBuildTimeout() time.Duration {
+	switch c.Provider {
+	case "", ProviderHash:
+		return 30 * time.Second
+	default:
+		return 10 * time.Minute
+	}
+}
+
 // Embedder produces fixed-size dense vectors for text inputs.
 type Embedder interface {
 	Provider() Provider
 	Dim() int
 	Embed(ctx context.Context, texts []string) ([][]float32, error)
 }
+
+// CloseEmbedder releases whatever e holds open, such as a subprocess. It is a
+// no-op for embedders that implement no io.Closer.
+func CloseEmbedder(e Embedder) error {
+	if c, ok := e.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
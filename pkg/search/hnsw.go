@@ -0,0 +1,511 @@
+package search
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+)
+
+// HNSW (hierarchical navigable small world) graph used by VectorIndex once it
+// grows past the ANN threshold. Scores are dot products, so vectors are
+// expected to be L2-normalized (all embedders normalize).
+
+const (
+	// DefaultANNThreshold is the index size from which SearchTopK uses the
+	// HNSW graph instead of a brute-force scan.
+	DefaultANNThreshold = 5000
+
+	hnswM              = 16  // Links per node on upper layers
+	hnswM0             = 32  // Links per node on layer 0
+	hnswEfConstruction = 128 // Candidate list size while inserting
+	hnswEfSearch       = 96  // Minimum candidate list size while searching
+	hnswMaxLevel       = 16
+
+	hnswMagic   = "BVHN"
+	hnswVersion = uint16(1)
+)
+
+type hnswNode struct {
+	id      string
+	vec     []float32 // Shared with the VectorIndex entry
+	links   [][]int32 // Neighbors per layer, 0..level
+	deleted bool
+}
+
+type hnswGraph struct {
+	nodes    []hnswNode
+	byID     map[string]int32
+	entry    int32 // -1 when empty
+	maxLevel int
+	deleted  int
+
+	visited sync.Pool // *visitedSet, so concurrent searches don't share marks
+}
+
+type scoredNode struct {
+	node  int32
+	score float64
+}
+
+func newHNSWGraph() *hnswGraph {
+	return &hnswGraph{byID: make(map[string]int32), entry: -1}
+}
+
+// hnswLevel draws a node's top layer from a hash of its ID, so the graph
+// shape doesn't depend on insertion order or a shared RNG.
+func hnswLevel(id string) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("hnsw-level:"))
+	_, _ = h.Write([]byte(id))
+	u := (float64(h.Sum64()>>11) + 1) / float64(uint64(1)<<53) // (0, 1]
+	level := int(-math.Log(u) / math.Log(hnswM))
+	return min(level, hnswMaxLevel)
+}
+
+func (g *hnswGraph) live() int {
+	return len(g.nodes) - g.deleted
+}
+
+// insert adds a node and links it into every layer up to its level.
+func (g *hnswGraph) insert(id string, vec []float32) {
+	level := hnswLevel(id)
+	n := int32(len(g.nodes))
+	g.nodes = append(g.nodes, hnswNode{id: id, vec: vec, links: make([][]int32, level+1)})
+	g.byID[id] = n
+	if g.entry < 0 {
+		g.entry = n
+		g.maxLevel = level
+		return
+	}
+
+	ep := g.greedyDescend(vec, g.maxLevel, level)
+	eps := []scoredNode{ep}
+	for l := min(level, g.maxLevel); l >= 0; l-- {
+		candidates := g.searchLayer(vec, eps, hnswEfConstruction, l)
+		neighbors := g.selectNeighbors(candidates, hnswM)
+		g.nodes[n].links[l] = neighbors
+		maxLinks := hnswM
+		if l == 0 {
+			maxLinks = hnswM0
+		}
+		for _, nb := range neighbors {
+			g.nodes[nb].links[l] = append(g.nodes[nb].links[l], n)
+			if len(g.nodes[nb].links[l]) > maxLinks {
+				g.shrink(nb, l, maxLinks)
+			}
+		}
+		eps = candidates
+	}
+	if level > g.maxLevel {
+		g.entry = n
+		g.maxLevel = level
+	}
+}
+
+// remove tombstones a node: it stays traversable but never appears in results.
+func (g *hnswGraph) remove(id string) {
+	n, ok := g.byID[id]
+	if !ok {
+		return
+	}
+	delete(g.byID, id)
+	g.nodes[n].deleted = true
+	g.deleted++
+	if g.entry != n {
+		return
+	}
+	// Promote the highest live node
+	g.entry = -1
+	g.maxLevel = 0
+	for i := range g.nodes {
+		if !g.nodes[i].deleted && (g.entry < 0 || len(g.nodes[i].links)-1 > g.maxLevel) {
+			g.entry = int32(i)
+			g.maxLevel = len(g.nodes[i].links) - 1
+		}
+	}
+}
+
+// search returns up to k live nodes by descending score.
+func (g *hnswGraph) search(query []float32, k, ef int) []scoredNode {
+	if g.entry < 0 || k <= 0 {
+		return nil
+	}
+	ep := g.greedyDescend(query, g.maxLevel, 0)
+	candidates := g.searchLayer(query, []scoredNode{ep}, max(ef, k), 0)
+	out := make([]scoredNode, 0, k)
+	for _, c := range candidates {
+		if g.nodes[c.node].deleted {
+			continue
+		}
+		out = append(out, c)
+		if len(out) == k {
+			break
+		}
+	}
+	return out
+}
+
+// greedyDescend walks from the entry point down to layer target+1, moving to
+// the best neighbor on each layer.
+func (g *hnswGraph) greedyDescend(query []float32, from, target int) scoredNode {
+	cur := scoredNode{node: g.entry, score: dotFloat32(query, g.nodes[g.entry].vec)}
+	for l := from; l > target; l-- {
+		for changed := true; changed; {
+			changed = false
+			for _, nb := range g.nodes[cur.node].links[l] {
+				if s := dotFloat32(query, g.nodes[nb].vec); s > cur.score {
+					cur = scoredNode{node: nb, score: s}
+					changed = true
+				}
+			}
+		}
+	}
+	return cur
+}
+
+// searchLayer is the HNSW best-first search on one layer, returning up to ef
+// nodes by descending score.
+func (g *hnswGraph) searchLayer(query []float32, eps []scoredNode, ef, layer int) []scoredNode {
+	visited := g.acquireVisited()
+	defer g.visited.Put(visited)
+
+	candidates := scoredHeap{max: true}
+	results := scoredHeap{}
+	for _, ep := range eps {
+		if visited.mark(ep.node) {
+			continue
+		}
+		candidates.push(ep)
+		results.push(ep)
+		if results.len() > ef {
+			results.pop()
+		}
+	}
+	for candidates.len() > 0 {
+		c := candidates.pop()
+		if results.len() >= ef && c.score < results.top().score {
+			break
+		}
+		for _, nb := range g.nodes[c.node].links[layer] {
+			if visited.mark(nb) {
+				continue
+			}
+			s := dotFloat32(query, g.nodes[nb].vec)
+			if results.len() < ef || s > results.top().score {
+				candidates.push(scoredNode{node: nb, score: s})
+				results.push(scoredNode{node: nb, score: s})
+				if results.len() > ef {
+					results.pop()
+				}
+			}
+		}
+	}
+
+	out := results.items
+	sort.Slice(out, func(i, j int) bool { return out[i].score > out[j].score })
+	return out
+}
+
+// selectNeighbors applies the HNSW heuristic: keep a candidate only if it is
+// closer to the base than to any already kept neighbor, which preserves links
+// between clusters. Pruned candidates fill any remaining slots.
+func (g *hnswGraph) selectNeighbors(candidates []scoredNode, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var pruned []int32
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		if g.nodes[c.node].deleted {
+			continue
+		}
+		keep := true
+		for _, s := range selected {
+			if dotFloat32(g.nodes[c.node].vec, g.nodes[s].vec) > c.score {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			selected = append(selected, c.node)
+		} else {
+			pruned = append(pruned, c.node)
+		}
+	}
+	for _, p := range pruned {
+		if len(selected) >= m {
+			break
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+// shrink re-selects a node's links on one layer after it overflowed.
+func (g *hnswGraph) shrink(n int32, layer, maxLinks int) {
+	base := g.nodes[n].vec
+	links := g.nodes[n].links[layer]
+	scored := make([]scoredNode, len(links))
+	for i, nb := range links {
+		scored[i] = scoredNode{node: nb, score: dotFloat32(base, g.nodes[nb].vec)}
+	}
+	sort.Slice(scored, func(i, j int) bool { return scored[i].score > scored[j].score })
+	g.nodes[n].links[layer] = g.selectNeighbors(scored, maxLinks)
+}
+
+// fingerprint ties a persisted graph to the exact entries it was built from.
+func hnswFingerprint(ids []string, entries map[string]VectorEntry) [32]byte {
+	h := sha256.New()
+	var lenBuf [2]byte
+	for _, id := range ids {
+		binary.LittleEndian.PutUint16(lenBuf[:], uint16(len(id)))
+		h.Write(lenBuf[:])
+		h.Write([]byte(id))
+		ch := entries[id].ContentHash
+		h.Write(ch[:])
+	}
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// writeHNSW persists the live part of the graph with nodes numbered in ids
+// order (the same order as the .bvvi entries), so vectors are not duplicated.
+// Links to tombstoned nodes are dropped.
+func writeHNSW(w io.Writer, g *hnswGraph, dim int, ids []string, fingerprint [32]byte) error {
+	bw := bufio.NewWriter(w)
+	order := make(map[int32]uint32, len(ids))
+	for i, id := range ids {
+		n, ok := g.byID[id]
+		if !ok {
+			return fmt.Errorf("graph missing %s", id)
+		}
+		order[n] = uint32(i)
+	}
+	entry, ok := order[g.entry]
+	if !ok {
+		return fmt.Errorf("graph entry point is not live")
+	}
+
+	header := []any{
+		hnswVersion, uint16(0), uint32(dim), uint16(hnswM), uint16(0),
+		uint32(len(ids)), fingerprint, entry, uint8(g.maxLevel),
+	}
+	if _, err := bw.WriteString(hnswMagic); err != nil {
+		return fmt.Errorf("write magic: %w", err)
+	}
+	for _, v := range header {
+		if err := binary.Write(bw, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+	}
+	for _, id := range ids {
+		node := g.nodes[g.byID[id]]
+		if err := bw.WriteByte(uint8(len(node.links) - 1)); err != nil {
+			return fmt.Errorf("write level: %w", err)
+		}
+		for _, layer := range node.links {
+			live := make([]uint32, 0, len(layer))
+			for _, nb := range layer {
+				if idx, ok := order[nb]; ok {
+					live = append(live, idx)
+				}
+			}
+			if err := binary.Write(bw, binary.LittleEndian, uint16(len(live))); err != nil {
+				return fmt.Errorf("write links: %w", err)
+			}
+			if err := binary.Write(bw, binary.LittleEndian, live); err != nil {
+				return fmt.Errorf("write links: %w", err)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// readHNSW loads a graph written by writeHNSW. Any mismatch with the current
+// entries is an error; callers then rebuild the graph on demand.
+func readHNSW(r io.Reader, dim int, ids []string, entries map[string]VectorEntry) (*hnswGraph, error) {
+	br := bufio.NewReader(r)
+	var magic [4]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if string(magic[:]) != hnswMagic {
+		return nil, fmt.Errorf("invalid magic %q", string(magic[:]))
+	}
+	var hdr struct {
+		Version     uint16
+		_           uint16
+		Dim         uint32
+		M           uint16
+		_           uint16
+		Count       uint32
+		Fingerprint [32]byte
+		Entry       uint32
+		MaxLevel    uint8
+	}
+	if err := binary.Read(br, binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	switch {
+	case hdr.Version != hnswVersion:
+		return nil, fmt.Errorf("unsupported version %d", hdr.Version)
+	case int(hdr.Dim) != dim || hdr.M != hnswM:
+		return nil, fmt.Errorf("graph built for dim %d M %d", hdr.Dim, hdr.M)
+	case int(hdr.Count) != len(ids) || hdr.Fingerprint != hnswFingerprint(ids, entries):
+		return nil, fmt.Errorf("graph is stale")
+	case len(ids) == 0 || int(hdr.Entry) >= len(ids):
+		return nil, fmt.Errorf("invalid entry point %d", hdr.Entry)
+	}
+
+	g := newHNSWGraph()
+	g.nodes = make([]hnswNode, len(ids))
+	for i, id := range ids {
+		level, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("read level: %w", err)
+		}
+		if int(level) > hnswMaxLevel {
+			return nil, fmt.Errorf("invalid level %d", level)
+		}
+		node := hnswNode{id: id, vec: entries[id].Vector, links: make([][]int32, int(level)+1)}
+		for l := range node.links {
+			var count uint16
+			if err := binary.Read(br, binary.LittleEndian, &count); err != nil {
+				return nil, fmt.Errorf("read links: %w", err)
+			}
+			raw := make([]uint32, count)
+			if err := binary.Read(br, binary.LittleEndian, raw); err != nil {
+				return nil, fmt.Errorf("read links: %w", err)
+			}
+			node.links[l] = make([]int32, count)
+			for j, nb := range raw {
+				if int(nb) >= len(ids) {
+					return nil, fmt.Errorf("link %d out of range", nb)
+				}
+				node.links[l][j] = int32(nb)
+			}
+		}
+		g.nodes[i] = node
+		g.byID[id] = int32(i)
+	}
+	// Links must point at nodes that exist on that layer
+	for _, node := range g.nodes {
+		for l, layer := range node.links {
+			for _, nb := range layer {
+				if len(g.nodes[nb].links) <= l {
+					return nil, fmt.Errorf("link to %s above its level", g.nodes[nb].id)
+				}
+			}
+		}
+	}
+	g.entry = int32(hdr.Entry)
+	g.maxLevel = int(hdr.MaxLevel)
+	if len(g.nodes[g.entry].links)-1 != g.maxLevel {
+		return nil, fmt.Errorf("entry point level mismatch")
+	}
+	return g, nil
+}
+
+func hnswSidecarPath(indexPath string) string {
+	return indexPath + ".hnsw"
+}
+
+// loadHNSWSidecar reads the graph next to an index file, or returns nil when
+// it is missing or stale.
+func loadHNSWSidecar(indexPath string, dim int, ids []string, entries map[string]VectorEntry) *hnswGraph {
+	f, err := os.Open(hnswSidecarPath(indexPath))
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+	g, err := readHNSW(f, dim, ids, entries)
+	if err != nil {
+		return nil
+	}
+	return g
+}
+
+// visitedSet marks nodes with a generation counter so it can be reused
+// without clearing.
+type visitedSet struct {
+	marks []uint32
+	gen   uint32
+}
+
+func (g *hnswGraph) acquireVisited() *visitedSet {
+	v, _ := g.visited.Get().(*visitedSet)
+	if v == nil {
+		v = &visitedSet{}
+	}
+	if len(v.marks) < len(g.nodes) {
+		v.marks = append(v.marks, make([]uint32, len(g.nodes)-len(v.marks))...)
+	}
+	v.gen++
+	if v.gen == 0 {
+		clear(v.marks)
+		v.gen = 1
+	}
+	return v
+}
+
+// mark records n as visited and reports whether it already was.
+func (v *visitedSet) mark(n int32) bool {
+	if v.marks[n] == v.gen {
+		return true
+	}
+	v.marks[n] = v.gen
+	return false
+}
+
+// scoredHeap is a binary heap of scored nodes: a max-heap when max is set,
+// otherwise a min-heap.
+type scoredHeap struct {
+	items []scoredNode
+	max   bool
+}
+
+func (h *scoredHeap) len() int           { return len(h.items) }
+func (h *scoredHeap) top() scoredNode    { return h.items[0] }
+func (h *scoredHeap) less(i, j int) bool { return (h.items[i].score > h.items[j].score) == h.max }
+
+func (h *scoredHeap) push(s scoredNode) {
+	h.items = append(h.items, s)
+	for i := len(h.items) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if !h.less(i, parent) {
+			break
+		}
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i = parent
+	}
+}
+
+func (h *scoredHeap) pop() scoredNode {
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.items[0] = h.items[last]
+	h.items = h.items[:last]
+	for i := 0; ; {
+		best, l, r := i, 2*i+1, 2*i+2
+		if l < last && h.less(l, best) {
+			best = l
+		}
+		if r < last && h.less(r, best) {
+			best = r
+		}
+		if best == i {
+			break
+		}
+		h.items[i], h.items[best] = h.items[best], h.items[i]
+		i = best
+	}
+	return top
+}
@@ -0,0 +1,291 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// clusteredVectors returns n normalized vectors drawn around a handful of
+// centers in a low-dimensional latent space and projected up to dim, which
+// resembles real embedding distributions better than uniform noise.
+func clusteredVectors(rng *rand.Rand, n, dim, clusters int) [][]float32 {
+	const latent = 16
+	proj := make([][]float64, dim)
+	for j := range proj {
+		proj[j] = make([]float64, latent)
+		for l := range proj[j] {
+			proj[j][l] = rng.NormFloat64()
+		}
+	}
+	centers := make([][]float64, clusters)
+	for c := range centers {
+		centers[c] = make([]float64, latent)
+		for l := range centers[c] {
+			centers[c][l] = rng.NormFloat64()
+		}
+	}
+	out := make([][]float32, n)
+	z := make([]float64, latent)
+	for i := range out {
+		center := centers[rng.Intn(clusters)]
+		for l := range z {
+			z[l] = center[l] + rng.NormFloat64()*0.5
+		}
+		vec := make([]float32, dim)
+		for j := range vec {
+			var v float64
+			for l, zl := range z {
+				v += proj[j][l] * zl
+			}
+			vec[j] = float32(v + rng.NormFloat64()*0.1)
+		}
+		normalizeL2(vec)
+		out[i] = vec
+	}
+	return out
+}
+
+func buildANNTestIndex(tb testing.TB, n, dim int) (*VectorIndex, [][]float32) {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(42))
+	vecs := clusteredVectors(rng, n+100, dim, 24)
+	idx := NewVectorIndex(dim)
+	for i, vec := range vecs[:n] {
+		id := fmt.Sprintf("bv-%05d", i)
+		if err := idx.Upsert(id, ComputeContentHash(id), vec); err != nil {
+			tb.Fatalf("Upsert: %v", err)
+		}
+	}
+	return idx, vecs[n:] // Held-out queries from the same distribution
+}
+
+// recallAtK is the fraction of exact top-k IDs the index returns.
+func recallAtK(tb testing.TB, idx *VectorIndex, queries [][]float32, k int) float64 {
+	tb.Helper()
+	hits, total := 0, 0
+	for _, q := range queries {
+		exact, err := idx.SearchTopKExact(q, k)
+		if err != nil {
+			tb.Fatalf("SearchTopKExact: %v", err)
+		}
+		approx, err := idx.SearchTopK(q, k)
+		if err != nil {
+			tb.Fatalf("SearchTopK: %v", err)
+		}
+		want := make(map[string]bool, k)
+		for _, r := range exact {
+			want[r.IssueID] = true
+		}
+		for _, r := range approx {
+			if want[r.IssueID] {
+				hits++
+			}
+		}
+		total += len(exact)
+	}
+	return float64(hits) / float64(total)
+}
+
+func TestVectorIndex_ANNRecall(t *testing.T) {
+	idx, queries := buildANNTestIndex(t, 3000, 32)
+	idx.SetANNThreshold(1000)
+
+	if recall := recallAtK(t, idx, queries, 10); recall < 0.95 {
+		t.Errorf("recall@10 = %.3f, want >= 0.95", recall)
+	}
+	if idx.ann == nil || idx.ann.live() != 3000 {
+		t.Fatal("graph should be built on the first search past the threshold")
+	}
+
+	idx.SetANNThreshold(-1)
+	if _, err := idx.SearchTopK(queries[0], 10); err != nil {
+		t.Fatal(err)
+	}
+	if recall := recallAtK(t, idx, queries[:5], 10); recall != 1 {
+		t.Errorf("exact fallback recall = %.3f", recall)
+	}
+}
+
+func TestVectorIndex_ANNIncrementalUpdates(t *testing.T) {
+	idx, queries := buildANNTestIndex(t, 2000, 16)
+	idx.SetANNThreshold(500)
+	q := queries[0]
+
+	before, _ := idx.SearchTopK(q, 5)
+	for _, r := range before[:3] {
+		idx.Remove(r.IssueID)
+	}
+	after, _ := idx.SearchTopK(q, 5)
+	for _, r := range after {
+		if _, ok := idx.Get(r.IssueID); !ok {
+			t.Errorf("removed %s still returned", r.IssueID)
+		}
+	}
+
+	// Move an existing entry onto the query
+	moved := before[4].IssueID
+	if err := idx.Upsert(moved, ComputeContentHash("moved"), q); err != nil {
+		t.Fatal(err)
+	}
+	if top, _ := idx.SearchTopK(q, 1); len(top) != 1 || top[0].IssueID != moved {
+		t.Errorf("top = %v, want the upserted %s", top, moved)
+	}
+	if idx.ann.live() != idx.Size() {
+		t.Errorf("graph has %d live nodes for %d entries", idx.ann.live(), idx.Size())
+	}
+
+	// Heavy churn triggers a compacting rebuild
+	for i := 0; i < 800; i++ {
+		idx.Remove(fmt.Sprintf("bv-%05d", i))
+	}
+	if _, err := idx.SearchTopK(q, 5); err != nil {
+		t.Fatal(err)
+	}
+	if idx.ann.deleted != 0 || len(idx.ann.nodes) != idx.Size() {
+		t.Errorf("graph not compacted: %d nodes, %d deleted", len(idx.ann.nodes), idx.ann.deleted)
+	}
+}
+
+func TestVectorIndex_ANNPersistsAlongsideIndex(t *testing.T) {
+	idx, queries := buildANNTestIndex(t, 1500, 16)
+	idx.SetANNThreshold(500)
+	want, _ := idx.SearchTopK(queries[1], 10)
+
+	path := filepath.Join(t.TempDir(), "semantic", "index.bvvi")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(hnswSidecarPath(path)); err != nil {
+		t.Fatalf("graph sidecar not written: %v", err)
+	}
+
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVectorIndex: %v", err)
+	}
+	if loaded.ann == nil || loaded.ann.live() != 1500 {
+		t.Fatal("graph should load from the sidecar")
+	}
+	loaded.SetANNThreshold(500)
+	got, _ := loaded.SearchTopK(queries[1], 10)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("results after reload differ:\n got %v\nwant %v", got, want)
+	}
+
+	// Dropping below the threshold removes the sidecar
+	loaded.Remove("bv-00001")
+	loaded.SetANNThreshold(2000)
+	if err := loaded.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(hnswSidecarPath(path)); !os.IsNotExist(err) {
+		t.Error("sidecar should be removed once the index is below the threshold")
+	}
+
+	// A sidecar that no longer matches the entries is ignored
+	if err := idx.saveANNLocked(path, idx.sortedIDs()); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.ann != nil {
+		t.Error("graph with a mismatched fingerprint should not load")
+	}
+}
+
+func TestVectorIndex_ANNBuiltBySyncAndLoadedFromSidecar(t *testing.T) {
+	embedder := NewHashEmbedder(16)
+	docs := make(map[string]string, 600)
+	for i := 0; i < 600; i++ {
+		docs[fmt.Sprintf("bv-%03d", i)] = fmt.Sprintf("Issue %d\nComponent %d handles case %d", i, i%17, i%29)
+	}
+	ctx := context.Background()
+
+	idx := NewVectorIndex(embedder.Dim())
+	idx.SetANNThreshold(500)
+	stats, err := SyncVectorIndex(ctx, idx, embedder, docs, 64)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if idx.ann == nil || idx.ann.live() != 600 {
+		t.Fatal("sync past the threshold should build the graph")
+	}
+	if !stats.NeedsSave() {
+		t.Error("a fresh sync should need saving")
+	}
+
+	path := filepath.Join(t.TempDir(), "index.bvvi")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(hnswSidecarPath(path)); err != nil {
+		t.Fatalf("graph sidecar not written before any search: %v", err)
+	}
+
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVectorIndex: %v", err)
+	}
+	loaded.SetANNThreshold(500)
+	graph := loaded.ann
+	if graph == nil {
+		t.Fatal("graph should load from the sidecar")
+	}
+	stats, err = SyncVectorIndex(ctx, loaded, embedder, docs, 64)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex after reload: %v", err)
+	}
+	if stats.NeedsSave() {
+		t.Errorf("unchanged reload should not need saving: %+v", stats)
+	}
+	q, err := embedder.Embed(ctx, []string{"Component 3 handles case 7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loaded.SearchTopK(q[0], 10); err != nil {
+		t.Fatalf("SearchTopK: %v", err)
+	}
+	if loaded.ann != graph {
+		t.Error("search rebuilt the graph instead of using the loaded sidecar")
+	}
+}
+
+func BenchmarkVectorIndexSearch(b *testing.B) {
+	for _, n := range []int{10000, 50000} {
+		idx, queries := buildANNTestIndex(b, n, 128)
+		idx.SetANNThreshold(1)
+		if _, err := idx.SearchTopK(queries[0], 10); err != nil { // build outside the timer
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("exact/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = idx.SearchTopKExact(queries[i%len(queries)], 10)
+			}
+			b.ReportMetric(1, "recall@10")
+		})
+		b.Run(fmt.Sprintf("hnsw/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = idx.SearchTopK(queries[i%len(queries)], 10)
+			}
+			b.StopTimer()
+			b.ReportMetric(recallAtK(b, idx, queries, 10), "recall@10")
+		})
+	}
+}
+
+func BenchmarkVectorIndexBuildANN(b *testing.B) {
+	idx, queries := buildANNTestIndex(b, 10000, 128)
+	idx.SetANNThreshold(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.ann = nil
+		_, _ = idx.SearchTopK(queries[0], 10)
+	}
+}
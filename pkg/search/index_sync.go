@@ -8,18 +8,69 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // DefaultIndexPath returns the default semantic index path under the given project directory.
-// The filename is keyed by provider+dim to avoid mixing incompatible embeddings.
+// The filename is keyed by provider+dim (and model for model backends) to avoid mixing
+// incompatible embeddings.
 func DefaultIndexPath(projectDir string, cfg EmbeddingConfig) string {
 	cfg = cfg.Normalized()
 	provider := cfg.Provider
 	if provider == "" {
 		provider = ProviderHash
 	}
-	safeProvider := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(string(provider))
-	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", safeProvider, cfg.Dim))
+	sanitize := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_").Replace
+	name := sanitize(string(provider))
+	if provider != ProviderHash && cfg.Model != "" {
+		name += "-" + sanitize(cfg.Model)
+	}
+	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", name, cfg.Dim))
+}
+
+// SyncedIndex is the default vector index for an embedding config, brought
+// up to date with a set of issues.
+type SyncedIndex struct {
+	Embedder Embedder
+	Index    *VectorIndex
+	Path     string
+	Loaded   bool
+	Stats    IndexSyncStats
+}
+
+// OpenSyncedIndex loads the index at DefaultIndexPath(projectDir, cfg),
+// embeds new and changed issues, and saves it when anything changed.
+//
+// A non-nil embedder (built from the same cfg) is reused, which keeps one
+// subprocess alive across rebuilds; otherwise a new one is created. The
+// caller owns the returned Embedder and releases it with CloseEmbedder.
+func OpenSyncedIndex(ctx context.Context, projectDir string, cfg EmbeddingConfig, embedder Embedder, issues []model.Issue) (_ *SyncedIndex, err error) {
+	if embedder == nil {
+		if embedder, err = NewEmbedderFromConfig(cfg); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				_ = CloseEmbedder(embedder)
+			}
+		}()
+	}
+	path := DefaultIndexPath(projectDir, cfg)
+	idx, loaded, err := LoadOrNewVectorIndex(path, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := SyncVectorIndex(ctx, idx, embedder, DocumentsFromIssues(issues), 64)
+	if err != nil {
+		return nil, err
+	}
+	if !loaded || stats.NeedsSave() {
+		if err := idx.Save(path); err != nil {
+			return nil, fmt.Errorf("save semantic index: %w", err)
+		}
+	}
+	return &SyncedIndex{Embedder: embedder, Index: idx, Path: path, Loaded: loaded, Stats: stats}, nil
 }
 
 type IndexSyncStats struct {
@@ -29,12 +80,20 @@ type IndexSyncStats struct {
 	Removed  int `json:"removed"`
 	Skipped  int `json:"skipped"`
 	Embedded int `json:"embedded"`
+
+	graphBuilt bool // SyncVectorIndex built a new ANN graph
 }
 
 func (s IndexSyncStats) Changed() bool {
 	return s.Added+s.Updated+s.Removed > 0
 }
 
+// NeedsSave reports whether the synced index differs from what was loaded,
+// counting a freshly built ANN graph that has no sidecar yet.
+func (s IndexSyncStats) NeedsSave() bool {
+	return s.Changed() || s.graphBuilt
+}
+
 // LoadOrNewVectorIndex loads an existing vector index if present, otherwise creates a new one.
 // If loading fails due to corruption, it backs up the corrupt file and returns a new empty index.
 func LoadOrNewVectorIndex(path string, dim int) (*VectorIndex, bool, error) {
@@ -152,5 +211,12 @@ func SyncVectorIndex(ctx context.Context, idx *VectorIndex, embedder Embedder, d
 		}
 	}
 
+	// Build the ANN graph here, under ctx, rather than on the first query
+	built, err := idx.ensureANN(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.graphBuilt = built
+
 	return stats, nil
 }
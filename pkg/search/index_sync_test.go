@@ -4,6 +4,8 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 func TestSyncVectorIndex_IncrementalUpdates(t *testing.T) {
@@ -94,3 +96,42 @@ func TestLoadOrNewVectorIndex(t *testing.T) {
 		t.Fatalf("expected 1 entry, got %d", loadedIdx.Size())
 	}
 }
+
+// closeCountingEmbedder records Close calls so tests can check ownership.
+type closeCountingEmbedder struct {
+	*HashEmbedder
+	closed int
+}
+
+func (e *closeCountingEmbedder) Close() error {
+	e.closed++
+	return nil
+}
+
+func TestOpenSyncedIndex_ReusesCallerEmbedder(t *testing.T) {
+	dir := t.TempDir()
+	cfg := EmbeddingConfig{Provider: ProviderHash, Dim: 16}
+	embedder := &closeCountingEmbedder{HashEmbedder: NewHashEmbedder(16)}
+	issues := []model.Issue{{ID: "A", Title: "Fix login"}, {ID: "B", Title: "Update docs"}}
+
+	synced, err := OpenSyncedIndex(context.Background(), dir, cfg, embedder, issues)
+	if err != nil {
+		t.Fatalf("OpenSyncedIndex: %v", err)
+	}
+	if synced.Embedder != embedder {
+		t.Error("a caller-supplied embedder should be reused, not replaced")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	issues = append(issues, model.Issue{ID: "C", Title: "New work"})
+	if _, err := OpenSyncedIndex(ctx, dir, cfg, embedder, issues); err == nil {
+		t.Fatal("expected an error from a cancelled sync")
+	}
+	if embedder.closed != 0 {
+		t.Errorf("caller-owned embedder closed %d times on error", embedder.closed)
+	}
+	if err := CloseEmbedder(embedder); err != nil || embedder.closed != 1 {
+		t.Errorf("CloseEmbedder: err=%v closed=%d", err, embedder.closed)
+	}
+}
@@ -0,0 +1,496 @@
+package search
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const (
+	lexicalIndexMagic   = "BVLI"
+	lexicalIndexVersion = uint16(1)
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// LexicalField identifies one weighted field of a LexicalDocument.
+type LexicalField uint8
+
+const (
+	LexicalFieldID LexicalField = iota
+	LexicalFieldTitle
+	LexicalFieldLabels
+	LexicalFieldDescription
+	LexicalFieldComments
+	numLexicalFields
+)
+
+// lexicalFieldBoosts weights term frequency per field (BM25F). The ID is
+// weighted highest, mirroring its repetition in IssueDocument.
+var lexicalFieldBoosts = [numLexicalFields]float64{
+	LexicalFieldID:          3.0,
+	LexicalFieldTitle:       2.5,
+	LexicalFieldLabels:      1.8,
+	LexicalFieldDescription: 1.0,
+	LexicalFieldComments:    0.6,
+}
+
+// LexicalDocument is the fielded text of an issue for BM25 indexing.
+type LexicalDocument struct {
+	ID          string
+	Title       string
+	Labels      string
+	Description string
+	Comments    string
+}
+
+func (d LexicalDocument) fields() [numLexicalFields]string {
+	return [numLexicalFields]string{d.ID, d.Title, d.Labels, d.Description, d.Comments}
+}
+
+// ContentHash changes whenever any field changes.
+func (d LexicalDocument) ContentHash() ContentHash {
+	f := d.fields()
+	return ComputeContentHash(strings.Join(f[:], "\x1f"))
+}
+
+// LexicalDocumentFromIssue splits an issue into the fields scored by the
+// lexical index.
+func LexicalDocumentFromIssue(issue model.Issue) LexicalDocument {
+	var comments []string
+	for _, c := range issue.Comments {
+		if c != nil && strings.TrimSpace(c.Text) != "" {
+			comments = append(comments, c.Text)
+		}
+	}
+	return LexicalDocument{
+		ID:          strings.TrimSpace(issue.ID),
+		Title:       strings.TrimSpace(issue.Title),
+		Labels:      strings.Join(issue.Labels, " "),
+		Description: strings.TrimSpace(issue.Description),
+		Comments:    strings.Join(comments, "\n"),
+	}
+}
+
+// DefaultLexicalIndexPath returns the lexical index path under the given
+// project directory, next to the semantic vector indexes. It does not depend
+// on the embedding provider.
+func DefaultLexicalIndexPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "semantic", "lexical.bvli")
+}
+
+// tokenizeLexical lowercases text and splits it on anything that isn't a
+// letter or digit.
+func tokenizeLexical(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+type lexicalDoc struct {
+	hash   ContentHash
+	tokens [numLexicalFields][]string // Token streams, for phrase positions
+	tf     map[string]*[numLexicalFields]int32
+}
+
+func newLexicalDoc(hash ContentHash, tokens [numLexicalFields][]string) *lexicalDoc {
+	d := &lexicalDoc{hash: hash, tokens: tokens, tf: make(map[string]*[numLexicalFields]int32)}
+	for f, stream := range tokens {
+		for _, tok := range stream {
+			counts := d.tf[tok]
+			if counts == nil {
+				counts = new([numLexicalFields]int32)
+				d.tf[tok] = counts
+			}
+			counts[f]++
+		}
+	}
+	return d
+}
+
+// LexicalIndex is an in-memory inverted index scored with BM25F.
+type LexicalIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]*lexicalDoc
+	postings map[string]map[string]struct{} // term -> doc IDs
+	fieldLen [numLexicalFields]int          // Total tokens per field
+
+	// vocab is the sorted term list, built once per change to the term set
+	// so that concurrent searches can share it under the read lock.
+	vocab     []string
+	vocabOnce *sync.Once
+}
+
+func NewLexicalIndex() *LexicalIndex {
+	return &LexicalIndex{
+		docs:      make(map[string]*lexicalDoc),
+		postings:  make(map[string]map[string]struct{}),
+		vocabOnce: new(sync.Once),
+	}
+}
+
+// Upsert indexes doc under issueID, replacing any previous version.
+func (idx *LexicalIndex) Upsert(issueID string, doc LexicalDocument) error {
+	if issueID == "" {
+		return fmt.Errorf("issue id cannot be empty")
+	}
+	var tokens [numLexicalFields][]string
+	for f, text := range doc.fields() {
+		tokens[f] = tokenizeLexical(text)
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.putLocked(issueID, newLexicalDoc(doc.ContentHash(), tokens))
+	return nil
+}
+
+func (idx *LexicalIndex) putLocked(issueID string, d *lexicalDoc) {
+	idx.removeLocked(issueID)
+	idx.docs[issueID] = d
+	for f, stream := range d.tokens {
+		idx.fieldLen[f] += len(stream)
+	}
+	for term := range d.tf {
+		ids := idx.postings[term]
+		if ids == nil {
+			ids = make(map[string]struct{})
+			idx.postings[term] = ids
+			idx.invalidateVocabLocked()
+		}
+		ids[issueID] = struct{}{}
+	}
+}
+
+func (idx *LexicalIndex) Remove(issueID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(issueID)
+}
+
+func (idx *LexicalIndex) removeLocked(issueID string) {
+	d, ok := idx.docs[issueID]
+	if !ok {
+		return
+	}
+	delete(idx.docs, issueID)
+	for f, stream := range d.tokens {
+		idx.fieldLen[f] -= len(stream)
+	}
+	for term := range d.tf {
+		ids := idx.postings[term]
+		delete(ids, issueID)
+		if len(ids) == 0 {
+			delete(idx.postings, term)
+			idx.invalidateVocabLocked()
+		}
+	}
+}
+
+// ContentHash returns the hash of the indexed version of issueID.
+func (idx *LexicalIndex) ContentHash(issueID string) (ContentHash, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	d, ok := idx.docs[issueID]
+	if !ok {
+		return ContentHash{}, false
+	}
+	return d.hash, true
+}
+
+func (idx *LexicalIndex) Size() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+func (idx *LexicalIndex) sortedIDsLocked() []string {
+	ids := make([]string, 0, len(idx.docs))
+	for id := range idx.docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sortedVocabLocked returns the sorted term list, building it on first use
+// after a change; idx.mu must be held for reading or writing.
+func (idx *LexicalIndex) sortedVocabLocked() []string {
+	idx.vocabOnce.Do(func() {
+		vocab := make([]string, 0, len(idx.postings))
+		for term := range idx.postings {
+			vocab = append(vocab, term)
+		}
+		sort.Strings(vocab)
+		idx.vocab = vocab
+	})
+	return idx.vocab
+}
+
+// invalidateVocabLocked drops the term list; idx.mu must be held for writing.
+func (idx *LexicalIndex) invalidateVocabLocked() {
+	idx.vocab = nil
+	idx.vocabOnce = new(sync.Once)
+}
+
+// SyncLexicalIndex updates idx to match issues, re-tokenizing only changed
+// documents. Embedded is always 0 in the returned stats.
+func SyncLexicalIndex(idx *LexicalIndex, issues []model.Issue) (IndexSyncStats, error) {
+	var stats IndexSyncStats
+	if idx == nil {
+		return stats, fmt.Errorf("index cannot be nil")
+	}
+	docs := documentsFromIssues(issues, LexicalDocumentFromIssue)
+	stats.Total = len(docs)
+
+	idx.mu.RLock()
+	existing := idx.sortedIDsLocked()
+	idx.mu.RUnlock()
+	for _, id := range existing {
+		if _, ok := docs[id]; !ok {
+			idx.Remove(id)
+			stats.Removed++
+		}
+	}
+
+	ids := make([]string, 0, len(docs))
+	for id := range docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		doc := docs[id]
+		hash, ok := idx.ContentHash(id)
+		if ok && hash == doc.ContentHash() {
+			stats.Skipped++
+			continue
+		}
+		if ok {
+			stats.Updated++
+		} else {
+			stats.Added++
+		}
+		if err := idx.Upsert(id, doc); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// LoadOrNewLexicalIndex loads the index at path, starting fresh when it is
+// missing or unreadable (it is rebuilt cheaply from the issues).
+func LoadOrNewLexicalIndex(path string) (*LexicalIndex, bool, error) {
+	idx, err := LoadLexicalIndex(path)
+	if err == nil {
+		return idx, true, nil
+	}
+	if os.IsNotExist(err) {
+		return NewLexicalIndex(), false, nil
+	}
+	if rmErr := os.Remove(path); rmErr != nil {
+		return nil, false, fmt.Errorf("load lexical index (and removal failed): %w", err)
+	}
+	return NewLexicalIndex(), false, nil
+}
+
+// Lexical index file format (little-endian):
+//
+//	magic "BVLI", version u16, reserved u16
+//	term count u32, then terms in sorted order (u16 length + bytes)
+//	doc count u32, then per doc in ID order:
+//	  id (u16 length + bytes), 32-byte content hash,
+//	  per field: token count u32 followed by that many u32 term numbers
+func LoadLexicalIndex(path string) (*LexicalIndex, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	lr := &io.LimitedReader{R: f, N: info.Size()}
+	r := bufio.NewReader(lr)
+	// Counts are checked against the unread bytes before allocating, so a
+	// corrupt file cannot ask for more memory than it could hold
+	remaining := func() int64 { return lr.N + int64(r.Buffered()) }
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if string(magic[:]) != lexicalIndexMagic {
+		return nil, fmt.Errorf("invalid magic %q", string(magic[:]))
+	}
+	var header struct {
+		Version  uint16
+		Reserved uint16
+		Terms    uint32
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if header.Version != lexicalIndexVersion {
+		return nil, fmt.Errorf("unsupported version %d", header.Version)
+	}
+
+	if int64(header.Terms)*2 > remaining() {
+		return nil, fmt.Errorf("term count %d exceeds file size", header.Terms)
+	}
+	vocab := make([]string, header.Terms)
+	for i := range vocab {
+		if vocab[i], err = readLexicalString(r); err != nil {
+			return nil, fmt.Errorf("read term: %w", err)
+		}
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, fmt.Errorf("read count: %w", err)
+	}
+	idx := NewLexicalIndex()
+	for i := uint32(0); i < count; i++ {
+		issueID, err := readLexicalString(r)
+		if err != nil {
+			return nil, fmt.Errorf("read id: %w", err)
+		}
+		if issueID == "" {
+			return nil, fmt.Errorf("empty issue id")
+		}
+		var hash ContentHash
+		if _, err := io.ReadFull(r, hash[:]); err != nil {
+			return nil, fmt.Errorf("read content hash: %w", err)
+		}
+		var tokens [numLexicalFields][]string
+		for f := range tokens {
+			var n uint32
+			if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+				return nil, fmt.Errorf("read token count: %w", err)
+			}
+			if int64(n)*4 > remaining() {
+				return nil, fmt.Errorf("token count %d for %s exceeds file size", n, issueID)
+			}
+			refs := make([]uint32, n)
+			if err := binary.Read(r, binary.LittleEndian, refs); err != nil {
+				return nil, fmt.Errorf("read tokens: %w", err)
+			}
+			tokens[f] = make([]string, n)
+			for j, ref := range refs {
+				if int(ref) >= len(vocab) {
+					return nil, fmt.Errorf("term %d out of range for %s", ref, issueID)
+				}
+				tokens[f][j] = vocab[ref]
+			}
+		}
+		idx.putLocked(issueID, newLexicalDoc(hash, tokens))
+	}
+	return idx, nil
+}
+
+func (idx *LexicalIndex) Save(path string) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "bvli-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	vocab := idx.sortedVocabLocked()
+	termNum := make(map[string]uint32, len(vocab))
+	for i, term := range vocab {
+		termNum[term] = uint32(i)
+	}
+
+	w := bufio.NewWriter(tmp)
+	if _, err := w.WriteString(lexicalIndexMagic); err != nil {
+		return fmt.Errorf("write magic: %w", err)
+	}
+	for _, v := range []any{lexicalIndexVersion, uint16(0), uint32(len(vocab))} {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+	}
+	for _, term := range vocab {
+		if err := writeLexicalString(w, term); err != nil {
+			return fmt.Errorf("write term: %w", err)
+		}
+	}
+
+	ids := idx.sortedIDsLocked()
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(ids))); err != nil {
+		return fmt.Errorf("write count: %w", err)
+	}
+	for _, issueID := range ids {
+		d := idx.docs[issueID]
+		if err := writeLexicalString(w, issueID); err != nil {
+			return fmt.Errorf("write id: %w", err)
+		}
+		if _, err := w.Write(d.hash[:]); err != nil {
+			return fmt.Errorf("write content hash: %w", err)
+		}
+		for _, stream := range d.tokens {
+			refs := make([]uint32, len(stream))
+			for j, tok := range stream {
+				refs[j] = termNum[tok]
+			}
+			if err := binary.Write(w, binary.LittleEndian, uint32(len(refs))); err != nil {
+				return fmt.Errorf("write token count: %w", err)
+			}
+			if err := binary.Write(w, binary.LittleEndian, refs); err != nil {
+				return fmt.Errorf("write tokens: %w", err)
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp: %w", err)
+	}
+	return replaceFile(tmpPath, path)
+}
+
+func readLexicalString(r io.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return "", err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func writeLexicalString(w io.Writer, s string) error {
+	if len(s) > math.MaxUint16 {
+		return fmt.Errorf("string too long: %d", len(s))
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
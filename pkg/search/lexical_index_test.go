@@ -0,0 +1,212 @@
+package search
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func lexicalTestIndex(t *testing.T) *LexicalIndex {
+	t.Helper()
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Login flow broken", Description: "OAuth callback fails after redirect", Labels: []string{"auth"}},
+		{ID: "bv-2", Title: "Update docs", Description: "Explain the login flow and token refresh", Labels: []string{"docs"}},
+		{ID: "bv-3", Title: "Flow metrics", Description: "Lead time for login broken builds", Labels: []string{"metrics"}},
+		{ID: "bv-4", Title: "Crash on startup", Labels: []string{"bug"},
+			Comments: []*model.Comment{{Text: "stack trace mentions authentication middleware"}}},
+		{ID: "bv-5", Title: "Authentication tokens expire early", Labels: []string{"auth", "security"}},
+	}
+	idx := NewLexicalIndex()
+	if _, err := SyncLexicalIndex(idx, issues); err != nil {
+		t.Fatalf("SyncLexicalIndex: %v", err)
+	}
+	return idx
+}
+
+func resultIDs(results []SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.IssueID
+	}
+	return ids
+}
+
+func TestLexicalIndex_FieldBoosts(t *testing.T) {
+	idx := lexicalTestIndex(t)
+
+	// "login" is in bv-1's title but only bv-2's description
+	got := resultIDs(idx.Search("login", 10))
+	if len(got) != 3 || got[0] != "bv-1" {
+		t.Errorf("login = %v, want bv-1 first of 3", got)
+	}
+
+	// Label match beats a comment match
+	got = resultIDs(idx.Search("authentication", 10))
+	if len(got) != 2 || got[0] != "bv-5" || got[1] != "bv-4" {
+		t.Errorf("authentication = %v, want [bv-5 bv-4]", got)
+	}
+	// Exact terms don't pick up typo neighbours like "auth" -> "oauth"
+	if got := resultIDs(idx.Search("auth", 10)); len(got) != 2 {
+		t.Errorf("auth label = %v, want bv-1 and bv-5", got)
+	}
+}
+
+func TestLexicalIndex_PhrasePrefixAndTypos(t *testing.T) {
+	idx := lexicalTestIndex(t)
+
+	// bv-3 has both words but not adjacent
+	if got := resultIDs(idx.Search(`"login flow"`, 10)); len(got) != 2 || got[0] != "bv-1" || got[1] != "bv-2" {
+		t.Errorf("phrase = %v, want [bv-1 bv-2]", got)
+	}
+	if got := idx.Search(`"flow login"`, 10); len(got) != 0 {
+		t.Errorf("reversed phrase matched %v", resultIDs(got))
+	}
+
+	if got := resultIDs(idx.Search("authent*", 10)); len(got) != 2 || got[0] != "bv-5" {
+		t.Errorf("prefix = %v, want bv-5 first of 2", got)
+	}
+
+	if got := resultIDs(idx.Search("authenticaton", 10)); len(got) != 2 || got[0] != "bv-5" {
+		t.Errorf("typo = %v, want the authentication matches", got)
+	}
+	if got := resultIDs(idx.Search("lgoin", 10)); len(got) != 3 {
+		t.Errorf("transposition = %v, want the login matches", got)
+	}
+	if got := idx.Search("doc", 10); len(got) != 0 {
+		t.Errorf("short terms should match exactly, got %v", resultIDs(got))
+	}
+}
+
+func TestLexicalIndex_IncrementalSyncAndPersistence(t *testing.T) {
+	idx := lexicalTestIndex(t)
+	want := idx.Search("login broken", 10)
+
+	path := filepath.Join(t.TempDir(), ".bv", "semantic", "lexical.bvli")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, ok, err := LoadOrNewLexicalIndex(path)
+	if err != nil || !ok {
+		t.Fatalf("LoadOrNewLexicalIndex: loaded=%v err=%v", ok, err)
+	}
+	if got := loaded.Search("login broken", 10); len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("after reload %v, want %v", got, want)
+	}
+
+	stats, err := SyncLexicalIndex(loaded, []model.Issue{
+		{ID: "bv-1", Title: "Login flow broken", Description: "OAuth callback fails after redirect", Labels: []string{"auth"}},
+		{ID: "bv-2", Title: "Update docs", Description: "Explain the signup flow"},
+		{ID: "bv-6", Title: "Login rate limiting"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Added != 1 || stats.Updated != 1 || stats.Removed != 3 || stats.Skipped != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if got := resultIDs(loaded.Search("login", 10)); len(got) != 2 || got[0] == "bv-2" || got[1] == "bv-2" {
+		t.Errorf("login after sync = %v", got)
+	}
+	if got := loaded.Search("metrics", 10); len(got) != 0 {
+		t.Errorf("removed doc still indexed: %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fresh, ok, err := LoadOrNewLexicalIndex(path)
+	if err != nil || ok || fresh.Size() != 0 {
+		t.Errorf("corrupt index: loaded=%v err=%v size=%d, want a fresh index", ok, err, fresh.Size())
+	}
+}
+
+func TestLoadLexicalIndex_RejectsCountsPastEndOfFile(t *testing.T) {
+	header := func(terms uint32) []byte {
+		b := []byte(lexicalIndexMagic)
+		b = binary.LittleEndian.AppendUint16(b, lexicalIndexVersion)
+		b = binary.LittleEndian.AppendUint16(b, 0)
+		return binary.LittleEndian.AppendUint32(b, terms)
+	}
+	hugeTerms := header(math.MaxUint32)
+
+	hugeTokens := header(1)
+	hugeTokens = binary.LittleEndian.AppendUint16(hugeTokens, 1)
+	hugeTokens = append(hugeTokens, 'a')
+	hugeTokens = binary.LittleEndian.AppendUint32(hugeTokens, 1) // Docs
+	hugeTokens = binary.LittleEndian.AppendUint16(hugeTokens, 1)
+	hugeTokens = append(hugeTokens, 'x')
+	hugeTokens = append(hugeTokens, make([]byte, 32)...) // Content hash
+	hugeTokens = binary.LittleEndian.AppendUint32(hugeTokens, math.MaxUint32-1)
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{"terms": hugeTerms, "tokens": hugeTokens} {
+		path := filepath.Join(dir, name+".bvli")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadLexicalIndex(path); err == nil || !strings.Contains(err.Error(), "exceeds file size") {
+			t.Errorf("%s: err = %v, want a size check before allocating", name, err)
+		}
+	}
+}
+
+func TestLexicalIndex_ConcurrentSearches(t *testing.T) {
+	idx := lexicalTestIndex(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if got := idx.Search("auth*", 10); len(got) == 0 {
+					t.Error("prefix search found nothing")
+					return
+				}
+				if i == 0 && j%10 == 0 {
+					// Writers invalidate the vocabulary the readers share
+					_ = idx.Upsert(fmt.Sprintf("bv-new-%d", j), LexicalDocument{ID: "x", Title: fmt.Sprintf("authz term%d", j)})
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestBlendTextScores(t *testing.T) {
+	vector := []SearchResult{{IssueID: "a", Score: 0.9}, {IssueID: "b", Score: 0.5}}
+	lexical := []SearchResult{{IssueID: "b", Score: 8}, {IssueID: "c", Score: 4}}
+	lookup := func(id string) (float64, bool) { return 0.2, id == "c" }
+
+	got := BlendTextScores(vector, lexical, 0.5, lookup)
+	want := map[string]float64{"a": 0.45, "b": 0.75, "c": 0.35}
+	if len(got) != 3 || got[0].IssueID != "b" {
+		t.Fatalf("blend = %v, want b first", got)
+	}
+	for _, r := range got {
+		if d := r.Score - want[r.IssueID]; d > 1e-9 || d < -1e-9 {
+			t.Errorf("%s = %f, want %f", r.IssueID, r.Score, want[r.IssueID])
+		}
+	}
+
+	if got := BlendTextScores(vector, lexical, 0, lookup); len(got) != 2 {
+		t.Errorf("weight 0 should return vector results unchanged, got %v", got)
+	}
+}
+
+func TestParseLexicalWeight(t *testing.T) {
+	if w, err := ParseLexicalWeight(" 0.25 "); err != nil || w != 0.25 {
+		t.Errorf("got %v, %v", w, err)
+	}
+	for _, raw := range []string{"-0.1", "1.5", "lots"} {
+		if _, err := ParseLexicalWeight(raw); err == nil {
+			t.Errorf("%q should be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/topk"
+)
+
+const (
+	lexicalMaxExpansions = 32  // Vocabulary terms a prefix or typo may expand to
+	lexicalPrefixWeight  = 0.9 // Score factor for prefix completions
+	lexicalFuzzyWeight   = 0.7 // Score factor per edit for typo matches
+	lexicalFuzzyMinRunes = 4   // Shorter terms are matched exactly
+	lexicalFuzzyTwoEdits = 8   // From this length two edits are allowed
+)
+
+// lexicalClause is one part of a parsed query: a term, a prefix (`auth*`)
+// or a quoted phrase (`"login flow"`).
+type lexicalClause struct {
+	terms  []string
+	prefix bool
+	phrase bool
+}
+
+// parseLexicalQuery splits a query into clauses. Quoted text becomes a
+// phrase; a trailing * makes the last token a prefix.
+func parseLexicalQuery(query string) []lexicalClause {
+	var clauses []lexicalClause
+	parts := strings.Split(query, `"`)
+	for i, part := range parts {
+		if i%2 == 1 {
+			// Inside quotes; an unbalanced final quote is treated as a phrase too
+			if terms := tokenizeLexical(part); len(terms) > 1 {
+				clauses = append(clauses, lexicalClause{terms: terms, phrase: true})
+			} else if len(terms) == 1 {
+				clauses = append(clauses, lexicalClause{terms: terms})
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			terms := tokenizeLexical(word)
+			for j, term := range terms {
+				prefix := j == len(terms)-1 && strings.HasSuffix(word, "*")
+				clauses = append(clauses, lexicalClause{terms: []string{term}, prefix: prefix})
+			}
+		}
+	}
+	return clauses
+}
+
+type weightedTerm struct {
+	term   string
+	weight float64
+}
+
+// Search returns the k best documents for query by BM25F score. Clauses are
+// OR-ed; phrases only count for documents containing the exact sequence, and
+// a term missing from the index falls back to close spellings.
+func (idx *LexicalIndex) Search(query string, k int) []SearchResult {
+	if k <= 0 {
+		return nil
+	}
+	clauses := parseLexicalQuery(query)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	// Concurrent searches share the read lock; see sortedVocabLocked
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if len(idx.docs) == 0 {
+		return nil
+	}
+
+	scores := make(map[string]float64)
+	for _, c := range clauses {
+		var clauseScores map[string]float64
+		if c.phrase {
+			clauseScores = idx.scorePhraseLocked(c.terms)
+		} else {
+			clauseScores = idx.scoreExpansionsLocked(idx.expandLocked(c))
+		}
+		for id, s := range clauseScores {
+			scores[id] += s
+		}
+	}
+
+	collector := topk.New[SearchResult](k, func(a, b SearchResult) bool {
+		return a.IssueID < b.IssueID
+	})
+	for id, s := range scores {
+		collector.Add(SearchResult{IssueID: id, Score: s}, s)
+	}
+	return collector.Results()
+}
+
+// expandLocked returns the index terms a non-phrase clause matches.
+func (idx *LexicalIndex) expandLocked(c lexicalClause) []weightedTerm {
+	term := c.terms[0]
+	_, exact := idx.postings[term]
+	out := make([]weightedTerm, 0, 1)
+	if exact {
+		out = append(out, weightedTerm{term, 1})
+	}
+	if c.prefix {
+		vocab := idx.sortedVocabLocked()
+		for i := sort.SearchStrings(vocab, term); i < len(vocab) && len(out) < lexicalMaxExpansions; i++ {
+			if !strings.HasPrefix(vocab[i], term) {
+				break
+			}
+			if vocab[i] != term {
+				out = append(out, weightedTerm{vocab[i], lexicalPrefixWeight})
+			}
+		}
+		return out
+	}
+	if exact {
+		return out
+	}
+	return idx.fuzzyLocked(term)
+}
+
+// fuzzyLocked finds index terms within one edit (two for long terms) of a
+// term that isn't indexed, preferring fewer edits then more common terms.
+func (idx *LexicalIndex) fuzzyLocked(term string) []weightedTerm {
+	n := utf8.RuneCountInString(term)
+	if n < lexicalFuzzyMinRunes {
+		return nil
+	}
+	maxEdits := 1
+	if n >= lexicalFuzzyTwoEdits {
+		maxEdits = 2
+	}
+	type match struct {
+		term  string
+		edits int
+		df    int
+	}
+	var matches []match
+	target := []rune(term)
+	for _, cand := range idx.sortedVocabLocked() {
+		if d := utf8.RuneCountInString(cand) - n; d > maxEdits || -d > maxEdits {
+			continue
+		}
+		if edits := boundedEditDistance(target, []rune(cand), maxEdits); edits <= maxEdits {
+			matches = append(matches, match{cand, edits, len(idx.postings[cand])})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].edits != matches[j].edits {
+			return matches[i].edits < matches[j].edits
+		}
+		return matches[i].df > matches[j].df
+	})
+	out := make([]weightedTerm, 0, min(len(matches), lexicalMaxExpansions))
+	for _, m := range matches[:min(len(matches), lexicalMaxExpansions)] {
+		out = append(out, weightedTerm{m.term, math.Pow(lexicalFuzzyWeight, float64(m.edits))})
+	}
+	return out
+}
+
+// scoreExpansionsLocked scores each document by its best-matching expansion,
+// so a prefix with many completions doesn't outweigh a single exact term.
+func (idx *LexicalIndex) scoreExpansionsLocked(terms []weightedTerm) map[string]float64 {
+	out := make(map[string]float64)
+	for _, wt := range terms {
+		idf := idx.idfLocked(wt.term)
+		for id := range idx.postings[wt.term] {
+			if s := wt.weight * idx.termScoreLocked(idx.docs[id], wt.term, idf); s > out[id] {
+				out[id] = s
+			}
+		}
+	}
+	return out
+}
+
+// scorePhraseLocked sums the term scores of documents where the terms appear
+// consecutively within one field.
+func (idx *LexicalIndex) scorePhraseLocked(terms []string) map[string]float64 {
+	out := make(map[string]float64)
+	// Walk the rarest term's postings
+	rarest := terms[0]
+	for _, t := range terms[1:] {
+		if len(idx.postings[t]) < len(idx.postings[rarest]) {
+			rarest = t
+		}
+	}
+	for id := range idx.postings[rarest] {
+		d := idx.docs[id]
+		if !containsPhrase(d, terms) {
+			continue
+		}
+		var s float64
+		for _, t := range terms {
+			s += idx.termScoreLocked(d, t, idx.idfLocked(t))
+		}
+		out[id] = s
+	}
+	return out
+}
+
+func containsPhrase(d *lexicalDoc, terms []string) bool {
+	for _, t := range terms {
+		if _, ok := d.tf[t]; !ok {
+			return false
+		}
+	}
+	for _, stream := range d.tokens {
+	next:
+		for i := 0; i+len(terms) <= len(stream); i++ {
+			for j, t := range terms {
+				if stream[i+j] != t {
+					continue next
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (idx *LexicalIndex) idfLocked(term string) float64 {
+	n := float64(len(idx.docs))
+	df := float64(len(idx.postings[term]))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// termScoreLocked is the BM25F contribution of one term: field frequencies
+// are length-normalized and boosted, then saturated once.
+func (idx *LexicalIndex) termScoreLocked(d *lexicalDoc, term string, idf float64) float64 {
+	counts := d.tf[term]
+	if counts == nil {
+		return 0
+	}
+	n := float64(len(idx.docs))
+	var tf float64
+	for f, c := range counts {
+		if c == 0 || idx.fieldLen[f] == 0 {
+			continue
+		}
+		avg := float64(idx.fieldLen[f]) / n
+		norm := 1 - bm25B + bm25B*float64(len(d.tokens[f]))/avg
+		tf += lexicalFieldBoosts[f] * float64(c) / norm
+	}
+	return idf * tf * (bm25K1 + 1) / (tf + bm25K1)
+}
+
+// boundedEditDistance is the optimal-string-alignment distance between a and
+// b (adjacent transpositions count as one edit), or max+1 once it is certain
+// to exceed max.
+func boundedEditDistance(a, b []rune, max int) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	prevMin := 0
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		// A transposition can still reach back one row, so both must exceed
+		if rowMin > max && prevMin > max {
+			return max + 1
+		}
+		prevMin = rowMin
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// BlendTextScores merges vector and BM25 results into one text-relevance
+// ranking: (1-weight)*vector + weight*bm25/maxBM25. An ID found by only one
+// side gets its vector score from vectorScore when provided, else 0.
+func BlendTextScores(vector, lexical []SearchResult, weight float64, vectorScore func(issueID string) (float64, bool)) []SearchResult {
+	weight = math.Max(0, math.Min(1, weight))
+	if weight == 0 || len(lexical) == 0 {
+		return vector
+	}
+
+	var maxLex float64
+	for _, r := range lexical {
+		maxLex = math.Max(maxLex, r.Score)
+	}
+	vec := make(map[string]float64, len(vector)+len(lexical))
+	for _, r := range vector {
+		vec[r.IssueID] = r.Score
+	}
+	lex := make(map[string]float64, len(lexical))
+	for _, r := range lexical {
+		if maxLex > 0 {
+			lex[r.IssueID] = r.Score / maxLex
+		}
+		if _, ok := vec[r.IssueID]; !ok && vectorScore != nil {
+			if s, ok := vectorScore(r.IssueID); ok {
+				vec[r.IssueID] = s
+			}
+		}
+	}
+
+	out := make([]SearchResult, 0, len(vec)+len(lex))
+	for id, v := range vec {
+		out = append(out, SearchResult{IssueID: id, Score: (1-weight)*v + weight*lex[id]})
+	}
+	for id, l := range lex {
+		if _, ok := vec[id]; !ok {
+			out = append(out, SearchResult{IssueID: id, Score: weight * l})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score == out[j].Score {
+			return out[i].IssueID < out[j].IssueID
+		}
+		return out[i].Score > out[j].Score
+	})
+	return out
+}
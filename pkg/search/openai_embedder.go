@@ -0,0 +1,123 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpenAIEmbedder calls an OpenAI-compatible POST {base}/embeddings endpoint.
+// Besides the hosted API this covers local servers such as Ollama
+// (http://localhost:11434/v1) and llama.cpp's server (http://localhost:8080/v1).
+type OpenAIEmbedder struct {
+	cfg     EmbeddingConfig
+	client  *http.Client
+	backoff time.Duration // Base delay between retries
+}
+
+// NewOpenAIEmbedder creates an embedder for cfg.BaseURL (DefaultOpenAIBaseURL
+// when empty) using cfg.Model.
+func NewOpenAIEmbedder(cfg EmbeddingConfig) *OpenAIEmbedder {
+	cfg = cfg.Normalized()
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultOpenAIBaseURL
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &OpenAIEmbedder{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		backoff: 500 * time.Millisecond,
+	}
+}
+
+func (*OpenAIEmbedder) Provider() Provider { return ProviderOpenAI }
+func (e *OpenAIEmbedder) Dim() int         { return e.cfg.Dim }
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedBatches(ctx, texts, e.cfg.BatchSize, e.cfg.Dim, func(ctx context.Context, batch []string) ([][]float32, error) {
+		body, err := json.Marshal(openAIEmbeddingRequest{Model: e.cfg.Model, Input: batch})
+		if err != nil {
+			return nil, fmt.Errorf("encode embedding request: %w", err)
+		}
+		return withRetries(ctx, e.cfg.MaxRetries, e.backoff, func(ctx context.Context) ([][]float32, error) {
+			return e.post(ctx, body, len(batch))
+		})
+	})
+}
+
+// post sends one batch. 429 and 5xx responses and transport errors are
+// retryable; other HTTP errors are permanent.
+func (e *OpenAIEmbedder) post(ctx context.Context, body []byte, n int) ([][]float32, error) {
+	endpoint := e.cfg.BaseURL + "/embeddings"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, permanent(fmt.Errorf("create embedding request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.cfg.APIKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 256<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read embedding response: %w", err)
+	}
+
+	var parsed openAIEmbeddingResponse
+	decodeErr := json.Unmarshal(respBody, &parsed)
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(respBody))
+		if decodeErr == nil && parsed.Error != nil && parsed.Error.Message != "" {
+			msg = parsed.Error.Message
+		}
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		err := fmt.Errorf("embedding endpoint returned HTTP %d: %s", resp.StatusCode, msg)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return nil, err
+		}
+		return nil, permanent(err)
+	}
+	if decodeErr != nil {
+		return nil, permanent(fmt.Errorf("decode embedding response: %w", decodeErr))
+	}
+	if len(parsed.Data) != n {
+		return nil, permanent(fmt.Errorf("embedding endpoint returned %d vectors for %d texts", len(parsed.Data), n))
+	}
+
+	// The API documents data as ordered by index, but not every server complies
+	sort.SliceStable(parsed.Data, func(i, j int) bool { return parsed.Data[i].Index < parsed.Data[j].Index })
+	out := make([][]float32, n)
+	for i, d := range parsed.Data {
+		out[i] = d.Embedding
+	}
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeEmbeddingServer serves /v1/embeddings with vectors [len(text), 1, 0...],
+// returning data in reverse order to exercise index sorting. The first
+// failFirst requests get HTTP 503.
+func fakeEmbeddingServer(t *testing.T, dim int, failFirst int32) (*httptest.Server, *atomic.Int32, *[]int) {
+	t.Helper()
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.URL.Path != "/v1/embeddings" || r.Method != http.MethodPost {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"bad key"}}`))
+			return
+		}
+		if n <= failFirst {
+			http.Error(w, "warming up", http.StatusServiceUnavailable)
+			return
+		}
+		var req openAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "nomic-embed-text" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, len(req.Input))
+		mu.Unlock()
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		data := make([]item, 0, len(req.Input))
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vec := make([]float32, dim)
+			vec[0] = float32(len(req.Input[i]))
+			vec[1] = 1
+			data = append(data, item{Index: i, Embedding: vec})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls, &batches
+}
+
+func testOpenAIEmbedder(baseURL string, dim int) *OpenAIEmbedder {
+	e := NewOpenAIEmbedder(EmbeddingConfig{
+		Provider:   ProviderOpenAI,
+		Model:      "nomic-embed-text",
+		Dim:        dim,
+		BaseURL:    baseURL + "/v1/",
+		APIKey:     "sk-test",
+		BatchSize:  2,
+		Timeout:    2 * time.Second,
+		MaxRetries: 2,
+	})
+	e.backoff = time.Millisecond
+	return e
+}
+
+func TestOpenAIEmbedder_BatchesAndOrders(t *testing.T) {
+	srv, calls, batches := fakeEmbeddingServer(t, 4, 0)
+	e := testOpenAIEmbedder(srv.URL, 4)
+
+	texts := []string{"a", "bbb", "cc", "dddd", "e"}
+	vecs, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if calls.Load() != 3 || len(*batches) != 3 || (*batches)[2] != 1 {
+		t.Errorf("calls=%d batches=%v, want 3 requests of 2,2,1", calls.Load(), *batches)
+	}
+	for i, vec := range vecs {
+		// [len, 1] normalized: first/second == len
+		if got := vec[0] / vec[1]; math.Abs(float64(got)-float64(len(texts[i]))) > 1e-5 {
+			t.Errorf("vec %d = %v, want ratio %d (order lost?)", i, vec, len(texts[i]))
+		}
+		var norm float64
+		for _, v := range vec {
+			norm += float64(v) * float64(v)
+		}
+		if math.Abs(norm-1) > 1e-5 {
+			t.Errorf("vec %d not normalized: |v|²=%f", i, norm)
+		}
+	}
+}
+
+func TestOpenAIEmbedder_RetriesTransientErrors(t *testing.T) {
+	srv, calls, _ := fakeEmbeddingServer(t, 4, 2)
+	e := testOpenAIEmbedder(srv.URL, 4)
+
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("Embed should succeed on the third attempt: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+
+	srv2, calls2, _ := fakeEmbeddingServer(t, 4, 5)
+	e = testOpenAIEmbedder(srv2.URL, 4)
+	e.cfg.MaxRetries = 1
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("err = %v, want 503 after 2 attempts", err)
+	}
+	if calls2.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls2.Load())
+	}
+}
+
+func TestOpenAIEmbedder_PermanentErrors(t *testing.T) {
+	srv, calls, _ := fakeEmbeddingServer(t, 4, 0)
+
+	e := testOpenAIEmbedder(srv.URL, 4)
+	e.cfg.APIKey = "wrong"
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "401: bad key") || calls.Load() != 1 {
+		t.Errorf("err = %v after %d calls, want a single 401 attempt", err, calls.Load())
+	}
+
+	e = testOpenAIEmbedder(srv.URL, 8)
+	_, err = e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), EnvSemanticDim+"=4") {
+		t.Errorf("err = %v, want dim mismatch hint", err)
+	}
+}
+
+func TestOpenAIEmbedder_SyncVectorIndex(t *testing.T) {
+	srv, _, _ := fakeEmbeddingServer(t, 4, 0)
+	e := testOpenAIEmbedder(srv.URL, 4)
+
+	idx := NewVectorIndex(e.Dim())
+	docs := map[string]string{"a": "one", "b": "three", "c": "seven"}
+	stats, err := SyncVectorIndex(context.Background(), idx, e, docs, 2)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if stats.Embedded != 3 || idx.Size() != 3 {
+		t.Errorf("stats = %+v size %d", stats, idx.Size())
+	}
+
+	if _, err := SyncVectorIndex(context.Background(), NewVectorIndex(8), e, docs, 2); err == nil {
+		t.Error("expected dim mismatch between index and embedder")
+	}
+}
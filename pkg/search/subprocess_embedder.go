@@ -0,0 +1,276 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSubprocessFrame caps a single response frame to guard against a
+// misbehaving command writing garbage lengths.
+const maxSubprocessFrame = 256 << 20
+
+// SubprocessEmbedder runs a long-lived user command and exchanges
+// length-prefixed JSON frames with it. Each frame is a 4-byte big-endian
+// length followed by that many bytes of JSON.
+//
+// Request:  {"model": "...", "dim": 384, "texts": ["...", ...]}
+// Response: {"embeddings": [[0.1, ...], ...]} or {"error": "message"}
+//
+// One request is in flight at a time. The command is started on first use,
+// restarted after a crash or timeout, and should exit when stdin closes.
+type SubprocessEmbedder struct {
+	cfg     EmbeddingConfig
+	backoff time.Duration // Base delay between retries
+
+	mu   sync.Mutex
+	proc *embedProcess
+}
+
+type embedProcess struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdoutR *os.File // Owned here rather than by cmd so Wait never races reads
+	stdout  *bufio.Reader
+	stderr  *tailBuffer
+	exited  chan struct{}
+}
+
+type subprocessRequest struct {
+	Model string   `json:"model,omitempty"`
+	Dim   int      `json:"dim"`
+	Texts []string `json:"texts"`
+}
+
+type subprocessResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// NewSubprocessEmbedder creates an embedder for cfg.Command. The command is
+// run through the shell so it may include arguments and pipes.
+func NewSubprocessEmbedder(cfg EmbeddingConfig) *SubprocessEmbedder {
+	cfg = cfg.Normalized()
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderSubprocess
+	}
+	return &SubprocessEmbedder{cfg: cfg, backoff: 200 * time.Millisecond}
+}
+
+func (e *SubprocessEmbedder) Provider() Provider { return e.cfg.Provider }
+func (e *SubprocessEmbedder) Dim() int           { return e.cfg.Dim }
+
+func (e *SubprocessEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedBatches(ctx, texts, e.cfg.BatchSize, e.cfg.Dim, func(ctx context.Context, batch []string) ([][]float32, error) {
+		req := subprocessRequest{Model: e.cfg.Model, Dim: e.cfg.Dim, Texts: batch}
+		return withRetries(ctx, e.cfg.MaxRetries, e.backoff, func(ctx context.Context) ([][]float32, error) {
+			return e.roundTrip(ctx, req)
+		})
+	})
+}
+
+// Close stops the command, giving it a moment to exit after stdin closes.
+func (e *SubprocessEmbedder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.proc == nil {
+		return nil
+	}
+	_ = e.proc.stdin.Close()
+	select {
+	case <-e.proc.exited:
+	case <-time.After(2 * time.Second):
+		_ = e.proc.cmd.Process.Kill()
+		<-e.proc.exited
+	}
+	_ = e.proc.stdoutR.Close()
+	e.proc = nil
+	return nil
+}
+
+// roundTrip sends one request frame and reads one response frame. Transport
+// failures and timeouts kill the command so the next attempt starts fresh.
+func (e *SubprocessEmbedder) roundTrip(ctx context.Context, req subprocessRequest) ([][]float32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, permanent(fmt.Errorf("encode embedding request: %w", err))
+	}
+	if e.proc == nil {
+		proc, err := startEmbedProcess(e.cfg.Command)
+		if err != nil {
+			return nil, permanent(err)
+		}
+		e.proc = proc
+	}
+	proc := e.proc
+
+	type result struct {
+		resp subprocessResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var r result
+		if r.err = writeFrame(proc.stdin, payload); r.err == nil {
+			var frame []byte
+			if frame, r.err = readFrame(proc.stdout); r.err == nil {
+				if err := json.Unmarshal(frame, &r.resp); err != nil {
+					r.err = fmt.Errorf("decode response frame: %w", err)
+				}
+			}
+		}
+		done <- r
+	}()
+
+	timer := time.NewTimer(e.cfg.Timeout)
+	defer timer.Stop()
+	var r result
+	select {
+	case r = <-done:
+	case <-timer.C:
+		e.killLocked()
+		<-done
+		return nil, fmt.Errorf("embedding command timed out after %s", e.cfg.Timeout)
+	case <-ctx.Done():
+		e.killLocked()
+		<-done
+		return nil, ctx.Err()
+	}
+
+	if r.err != nil {
+		// Let Wait drain stderr so a crash message makes it into the error
+		e.killLocked()
+		select {
+		case <-proc.exited:
+		case <-time.After(2 * time.Second):
+		}
+		if stderr := proc.stderr.String(); stderr != "" {
+			return nil, fmt.Errorf("embedding command failed: %w (stderr: %s)", r.err, stderr)
+		}
+		return nil, fmt.Errorf("embedding command failed: %w", r.err)
+	}
+	if r.resp.Error != "" {
+		return nil, permanent(fmt.Errorf("embedding command error: %s", r.resp.Error))
+	}
+	if len(r.resp.Embeddings) != len(req.Texts) {
+		return nil, permanent(fmt.Errorf("embedding command returned %d vectors for %d texts", len(r.resp.Embeddings), len(req.Texts)))
+	}
+	return r.resp.Embeddings, nil
+}
+
+// killLocked terminates the running command and closes its pipes, which
+// unblocks an in-flight read even if a grandchild still holds stdout open;
+// e.mu must be held.
+func (e *SubprocessEmbedder) killLocked() {
+	if e.proc == nil {
+		return
+	}
+	_ = e.proc.cmd.Process.Kill()
+	_ = e.proc.stdin.Close()
+	_ = e.proc.stdoutR.Close()
+	e.proc = nil
+}
+
+func startEmbedProcess(command string) (*embedProcess, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, command)
+	cmd.Env = os.Environ()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("embedding command stdin: %w", err)
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("embedding command stdout: %w", err)
+	}
+	cmd.Stdout = stdoutW
+	stderr := &tailBuffer{max: 2048}
+	cmd.Stderr = stderr
+	// Don't let a lingering grandchild holding stderr block Wait forever
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		_ = stdoutR.Close()
+		_ = stdoutW.Close()
+		return nil, fmt.Errorf("start embedding command %q: %w", command, err)
+	}
+	_ = stdoutW.Close()
+	proc := &embedProcess{
+		cmd:     cmd,
+		stdin:   stdin,
+		stdoutR: stdoutR,
+		stdout:  bufio.NewReaderSize(stdoutR, 64*1024),
+		stderr:  stderr,
+		exited:  make(chan struct{}),
+	}
+	go func() {
+		_ = cmd.Wait()
+		close(proc.exited)
+	}()
+	return proc, nil
+}
+
+func writeFrame(w io.Writer, payload []byte) error {
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(payload)))
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("write frame header: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("write frame: %w", err)
+	}
+	return nil
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("read frame header: %w", err)
+	}
+	n := binary.BigEndian.Uint32(header[:])
+	if n > maxSubprocessFrame {
+		return nil, fmt.Errorf("frame of %d bytes exceeds limit", n)
+	}
+	frame := make([]byte, n)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, fmt.Errorf("read frame: %w", err)
+	}
+	return frame, nil
+}
+
+// tailBuffer keeps the last max bytes written, for error messages.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.TrimSpace(string(b.buf))
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const envEmbedHelper = "BV_TEST_EMBED_HELPER"
+
+// TestSubprocessEmbedderHelper is the fake embedding command. It only runs
+// when re-executed by the tests below with BV_TEST_EMBED_HELPER set.
+func TestSubprocessEmbedderHelper(t *testing.T) {
+	mode := os.Getenv(envEmbedHelper)
+	if mode == "" {
+		return
+	}
+	if mode == "crash-once" {
+		marker := os.Getenv("BV_TEST_EMBED_MARKER")
+		if _, err := os.Stat(marker); os.IsNotExist(err) {
+			_ = os.WriteFile(marker, nil, 0o644)
+			fmt.Fprintln(os.Stderr, "model failed to load")
+			os.Exit(3)
+		}
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	for {
+		frame, err := readFrame(in)
+		if err != nil {
+			os.Exit(0) // stdin closed
+		}
+		var req subprocessRequest
+		if err := json.Unmarshal(frame, &req); err != nil {
+			os.Exit(2)
+		}
+		var resp subprocessResponse
+		switch mode {
+		case "hang":
+			time.Sleep(time.Hour)
+		case "error":
+			resp.Error = "model not loaded"
+		default:
+			for _, text := range req.Texts {
+				vec := make([]float32, req.Dim)
+				vec[0] = float32(len(text))
+				vec[1] = 1
+				resp.Embeddings = append(resp.Embeddings, vec)
+			}
+			if log := os.Getenv("BV_TEST_EMBED_LOG"); log != "" {
+				f, _ := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+				fmt.Fprintf(f, "%d\n", len(req.Texts))
+				f.Close()
+			}
+		}
+		payload, _ := json.Marshal(resp)
+		if err := writeFrame(os.Stdout, payload); err != nil {
+			os.Exit(2)
+		}
+	}
+}
+
+func helperEmbedder(t *testing.T, mode string, cfg EmbeddingConfig) *SubprocessEmbedder {
+	t.Helper()
+	t.Setenv(envEmbedHelper, mode)
+	cfg.Provider = ProviderSubprocess
+	cfg.Command = fmt.Sprintf("%q -test.run=^TestSubprocessEmbedderHelper$", os.Args[0])
+	e := NewSubprocessEmbedder(cfg)
+	e.backoff = time.Millisecond
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestSubprocessEmbedder_BatchesOverOneProcess(t *testing.T) {
+	log := filepath.Join(t.TempDir(), "batches.log")
+	t.Setenv("BV_TEST_EMBED_LOG", log)
+	e := helperEmbedder(t, "ok", EmbeddingConfig{Dim: 4, BatchSize: 2, Timeout: 10 * time.Second})
+
+	texts := []string{"a", "bbb", "cc"}
+	vecs, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	for i, vec := range vecs {
+		if len(vec) != 4 || vec[0]/vec[1] != float32(len(texts[i])) {
+			t.Errorf("vec %d = %v", i, vec)
+		}
+	}
+	pid := e.proc.cmd.Process.Pid
+
+	idx := NewVectorIndex(e.Dim())
+	if _, err := SyncVectorIndex(context.Background(), idx, e, map[string]string{"x": "one", "y": "two"}, 8); err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if idx.Size() != 2 || e.proc.cmd.Process.Pid != pid {
+		t.Errorf("index size %d; process should be reused", idx.Size())
+	}
+
+	data, _ := os.ReadFile(log)
+	if got := strings.Fields(string(data)); strings.Join(got, ",") != "2,1,2" {
+		t.Errorf("batches = %v, want 2,1,2", got)
+	}
+}
+
+func TestSubprocessEmbedder_RestartsAfterCrash(t *testing.T) {
+	t.Setenv("BV_TEST_EMBED_MARKER", filepath.Join(t.TempDir(), "crashed"))
+	e := helperEmbedder(t, "crash-once", EmbeddingConfig{Dim: 4, Timeout: 10 * time.Second, MaxRetries: 1})
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("Embed should succeed after a restart: %v", err)
+	}
+
+	t.Setenv("BV_TEST_EMBED_MARKER", filepath.Join(t.TempDir(), "crashed"))
+	e = helperEmbedder(t, "crash-once", EmbeddingConfig{Dim: 4, Timeout: 10 * time.Second})
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "model failed to load") {
+		t.Errorf("err = %v, want stderr in the error without retries", err)
+	}
+}
+
+func TestSubprocessEmbedder_ErrorsAndTimeout(t *testing.T) {
+	e := helperEmbedder(t, "error", EmbeddingConfig{Dim: 4, Timeout: 10 * time.Second, MaxRetries: 3})
+	_, err := e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "model not loaded") || strings.Contains(err.Error(), "attempts") {
+		t.Errorf("err = %v, want a permanent command error", err)
+	}
+
+	e = helperEmbedder(t, "hang", EmbeddingConfig{Dim: 4, Timeout: 300 * time.Millisecond})
+	start := time.Now()
+	_, err = e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("err = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeout took %s", elapsed)
+	}
+	if e.proc != nil {
+		t.Error("timed-out process should be discarded")
+	}
+}
@@ -2,6 +2,7 @@ package search
 
 import (
 	"bufio"
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
@@ -57,6 +58,13 @@ type VectorIndex struct {
 	entries  map[string]VectorEntry
 	idsCache []string
 	idsDirty bool
+
+	// ann is the HNSW graph, built once the index reaches annThreshold (by
+	// SyncVectorIndex, Save or the first large search) and kept current by
+	// Upsert/Remove from then on.
+	ann          *hnswGraph
+	annThreshold int    // 0 = DefaultANNThreshold, <0 = always exact
+	gen          uint64 // Bumped by every Upsert/Remove
 }
 
 func NewVectorIndex(dim int) *VectorIndex {
@@ -148,6 +156,12 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 		}
 	}
 
+	// A missing or stale graph is rebuilt by the next sync, save or large search
+	ids := idx.sortedIDs()
+	idx.mu.Lock()
+	idx.ann = loadHNSWSidecar(path, idx.Dim, ids, idx.entries)
+	idx.mu.Unlock()
+
 	return idx, nil
 }
 
@@ -155,6 +169,11 @@ func (idx *VectorIndex) Save(path string) error {
 	// Acquire sorted IDs before locking to avoid deadlock (sortedIDs needs Write lock if dirty)
 	ids := idx.sortedIDs()
 
+	// Build the graph now so the sidecar is written and later loads skip it
+	if _, err := idx.ensureANN(context.Background()); err != nil {
+		return err
+	}
+
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
@@ -227,6 +246,46 @@ func (idx *VectorIndex) Save(path string) error {
 		return fmt.Errorf("close temp: %w", err)
 	}
 
+	if err := replaceFile(tmpPath, path); err != nil {
+		return err
+	}
+	return idx.saveANNLocked(path, ids)
+}
+
+// saveANNLocked writes the HNSW graph next to the index file, or removes the
+// sidecar once the index is below the ANN threshold; idx.mu must be held.
+func (idx *VectorIndex) saveANNLocked(path string, ids []string) error {
+	sidecar := hnswSidecarPath(path)
+	if !idx.annWantedLocked() || len(ids) == 0 {
+		if err := os.Remove(sidecar); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove stale ann graph: %w", err)
+		}
+		return nil
+	}
+	if idx.ann == nil || idx.ann.live() != len(ids) {
+		// Changed mid-save; an older sidecar fails its fingerprint check on load
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "bvhn-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+	if err := writeHNSW(tmp, idx.ann, idx.Dim, ids, hnswFingerprint(ids, idx.entries)); err != nil {
+		return fmt.Errorf("write ann graph: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp: %w", err)
+	}
+	return replaceFile(tmpPath, sidecar)
+}
+
+func replaceFile(tmpPath, path string) error {
 	if err := os.Rename(tmpPath, path); err != nil {
 		// os.Rename doesn't replace existing files on Windows. Since the index is deterministic
 		// and can be rebuilt, fall back to removing the destination and retrying.
@@ -268,6 +327,11 @@ func (idx *VectorIndex) Upsert(issueID string, hash ContentHash, vec []float32)
 	if !exists {
 		idx.idsDirty = true
 	}
+	idx.gen++
+	if idx.ann != nil {
+		idx.ann.remove(issueID)
+		idx.ann.insert(issueID, cp)
+	}
 	return nil
 }
 
@@ -280,6 +344,10 @@ func (idx *VectorIndex) Remove(issueID string) {
 	}
 	delete(idx.entries, issueID)
 	idx.idsDirty = true
+	idx.gen++
+	if idx.ann != nil {
+		idx.ann.remove(issueID)
+	}
 }
 
 func (idx *VectorIndex) Get(issueID string) (VectorEntry, bool) {
@@ -289,6 +357,15 @@ func (idx *VectorIndex) Get(issueID string) (VectorEntry, bool) {
 	return e, ok
 }
 
+// Score returns the dot product of query with issueID's vector.
+func (idx *VectorIndex) Score(query []float32, issueID string) (float64, bool) {
+	e, ok := idx.Get(issueID)
+	if !ok || len(query) != idx.Dim {
+		return 0, false
+	}
+	return dotFloat32(query, e.Vector), true
+}
+
 func (idx *VectorIndex) Size() int {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
@@ -326,6 +403,17 @@ type SearchResult struct {
 	Score   float64 `json:"score"`
 }
 
+// SetANNThreshold sets the size from which SearchTopK uses the approximate
+// HNSW graph (0 restores DefaultANNThreshold, negative disables it).
+func (idx *VectorIndex) SetANNThreshold(n int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.annThreshold = n
+}
+
+// SearchTopK returns the k entries with the highest dot product. Below the
+// ANN threshold this is an exact scan; above it the HNSW graph answers
+// approximately (see SearchTopKExact for the brute-force baseline).
 func (idx *VectorIndex) SearchTopK(query []float32, k int) ([]SearchResult, error) {
 	if k <= 0 {
 		return nil, nil
@@ -333,6 +421,112 @@ func (idx *VectorIndex) SearchTopK(query []float32, k int) ([]SearchResult, erro
 	if len(query) != idx.Dim {
 		return nil, fmt.Errorf("query dim mismatch: %d != %d", len(query), idx.Dim)
 	}
+	if !idx.useANN(k) {
+		return idx.SearchTopKExact(query, k)
+	}
+
+	// Normally built already by SyncVectorIndex or loaded from the sidecar
+	_, _ = idx.ensureANN(context.Background())
+
+	idx.mu.RLock()
+	if idx.ann == nil {
+		// The build was discarded because the index changed under it
+		idx.mu.RUnlock()
+		return idx.SearchTopKExact(query, k)
+	}
+	defer idx.mu.RUnlock()
+	hits := idx.ann.search(query, k, max(hnswEfSearch, 2*k))
+	results := make([]SearchResult, len(hits))
+	for i, h := range hits {
+		results[i] = SearchResult{IssueID: idx.ann.nodes[h.node].id, Score: h.score}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].IssueID < results[j].IssueID // Same tie-break as the exact scan
+	})
+	return results, nil
+}
+
+// useANN reports whether the graph should answer a top-k query. Large k
+// relative to the index gains nothing over a scan.
+func (idx *VectorIndex) useANN(k int) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.annWantedLocked() && k*4 < len(idx.entries)
+}
+
+// annWantedLocked reports whether the index is large enough to keep a graph;
+// idx.mu must be held.
+func (idx *VectorIndex) annWantedLocked() bool {
+	threshold := idx.annThreshold
+	if threshold == 0 {
+		threshold = DefaultANNThreshold
+	}
+	return threshold > 0 && len(idx.entries) >= threshold
+}
+
+// ensureANN builds the graph when the index is past the ANN threshold and
+// has no graph yet, or compacts it after heavy churn. It reports whether a
+// new graph was installed.
+func (idx *VectorIndex) ensureANN(ctx context.Context) (bool, error) {
+	idx.mu.RLock()
+	stale := idx.ann == nil || idx.ann.deleted*4 > len(idx.ann.nodes)
+	need := stale && idx.annWantedLocked()
+	idx.mu.RUnlock()
+	if !need {
+		return false, nil
+	}
+	return idx.buildANN(ctx)
+}
+
+// buildANN builds the graph from scratch in ID order, dropping tombstones.
+// Readers are not blocked while it runs; the result is discarded if the
+// index changed in the meantime.
+func (idx *VectorIndex) buildANN(ctx context.Context) (bool, error) {
+	ids := idx.sortedIDs()
+
+	idx.mu.RLock()
+	gen := idx.gen
+	vecs := make([][]float32, 0, len(ids))
+	live := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if e, ok := idx.entries[id]; ok {
+			live = append(live, id)
+			vecs = append(vecs, e.Vector) // Upsert replaces vectors, never mutates them
+		}
+	}
+	idx.mu.RUnlock()
+
+	g := newHNSWGraph()
+	for i, id := range live {
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, fmt.Errorf("build ann graph: %w", err)
+			}
+		}
+		g.insert(id, vecs[i])
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.gen != gen {
+		return false, nil
+	}
+	idx.ann = g
+	return true, nil
+}
+
+// SearchTopKExact scores every entry; it is the recall baseline for the ANN
+// graph and the path used for small indexes.
+func (idx *VectorIndex) SearchTopKExact(query []float32, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	if len(query) != idx.Dim {
+		return nil, fmt.Errorf("query dim mismatch: %d != %d", len(query), idx.Dim)
+	}
 
 	// sortedIDs now handles its own locking safely
 	ids := idx.sortedIDs()
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// ChangeEvent is pushed to /events subscribers when a reload changes the data
+type ChangeEvent struct {
+	DataHash     string    `json:"data_hash"`
+	PreviousHash string    `json:"previous_hash,omitempty"`
+	IssueCount   int       `json:"issue_count"`
+	Added        []string  `json:"added,omitempty"`
+	Removed      []string  `json:"removed,omitempty"`
+	Modified     []string  `json:"modified,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// eventBufferSize is how many events a slow subscriber may fall behind
+// before further events are dropped for it
+const eventBufferSize = 16
+
+// broker fans change events out to Server-Sent Events subscribers
+type broker struct {
+	mu   sync.Mutex
+	subs map[chan ChangeEvent]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[chan ChangeEvent]struct{})}
+}
+
+func (b *broker) subscribe() chan ChangeEvent {
+	ch := make(chan ChangeEvent, eventBufferSize)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan ChangeEvent) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+}
+
+// publish delivers an event without blocking on slow subscribers
+func (b *broker) publish(ev ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// subscribers returns the number of connected /events clients
+func (b *broker) subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
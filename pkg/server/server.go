@@ -0,0 +1,448 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	toon "github.com/Dicklesworthstone/toon-go"
+)
+
+// DefaultHeartbeat is how often idle /events streams send a keep-alive comment
+const DefaultHeartbeat = 15 * time.Second
+
+// DefaultCacheEntries caps the encoded bodies kept between reloads
+const DefaultCacheEntries = 256
+
+// Config tunes the HTTP layer
+type Config struct {
+	DefaultFormat string              // "json" (default) or "toon" when the request does not choose
+	ToonOptions   *toon.EncodeOptions // nil uses toon.DefaultEncodeOptions
+	Heartbeat     time.Duration       // /events keep-alive interval (default DefaultHeartbeat)
+	CacheEntries  int                 // Encoded bodies kept, least recently used evicted first (default DefaultCacheEntries)
+	ListenAddr    string              // TCP listen address, accepted in Host headers alongside localhost, 127.0.0.1 and [::1]
+}
+
+// cachedResponse is an encoded payload and the ETag it was rendered for
+type cachedResponse struct {
+	key  string
+	etag string
+	body []byte
+}
+
+// Server answers the robot endpoints over HTTP from a warm Service. Every
+// payload carries a weak ETag derived from the data hash (and git HEAD for
+// history and diff) so unchanged data costs a 304, and encoded bodies are
+// reused until the next reload. The body cache is an LRU keyed by path and
+// query, so distinct queries (e.g. /search?q=) cannot grow it without bound.
+type Server struct {
+	svc    *Service
+	cfg    Config
+	mux    *http.ServeMux
+	events *broker
+
+	cacheMu    sync.Mutex
+	cache      map[string]*list.Element // key -> element holding a cachedResponse
+	cacheOrder *list.List               // LRU order (front = most recently used)
+	generation uint64                   // Bumped on reload so in-flight renders are not cached
+}
+
+// endpointDoc describes a route for the index at /
+type endpointDoc struct {
+	Path        string `json:"path"`
+	Params      string `json:"params,omitempty"`
+	Description string `json:"description"`
+}
+
+var endpointDocs = []endpointDoc{
+	{Path: "/health", Description: "Data hash, issue count and load time"},
+	{Path: "/triage", Description: "Ranked recommendations, quick wins and blockers (--robot-triage)"},
+	{Path: "/plan", Description: "Dependency-respecting execution plan (--robot-plan)"},
+	{Path: "/insights", Description: "Graph analysis summary (--robot-insights)"},
+	{Path: "/search", Params: "q, limit", Description: "BM25 lexical search over titles, descriptions and comments"},
+	{Path: "/graph", Params: "format=json|dot|mermaid, root, depth", Description: "Dependency graph export (--robot-graph)"},
+	{Path: "/history", Params: "bead", Description: "Bead-to-commit correlations (--robot-history)"},
+	{Path: "/forecast", Params: "id (default all), agents, trials", Description: "ETA forecast (--robot-forecast)"},
+	{Path: "/diff", Params: "since", Description: "Changes since a git revision or date (--robot-diff)"},
+	{Path: "/events", Description: "Server-Sent Events stream of data changes"},
+}
+
+// New wraps a Service in an HTTP handler
+func New(svc *Service, cfg Config) *Server {
+	if cfg.DefaultFormat == "" {
+		cfg.DefaultFormat = "json"
+	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = DefaultHeartbeat
+	}
+	if cfg.CacheEntries <= 0 {
+		cfg.CacheEntries = DefaultCacheEntries
+	}
+	s := &Server{
+		svc:        svc,
+		cfg:        cfg,
+		mux:        http.NewServeMux(),
+		events:     newBroker(),
+		cache:      make(map[string]*list.Element),
+		cacheOrder: list.New(),
+	}
+
+	s.mux.HandleFunc("/", s.handleIndex)
+	s.mux.HandleFunc("/health", s.endpoint(false, func(r *http.Request) (any, error) {
+		return s.svc.Health(), nil
+	}))
+	s.mux.HandleFunc("/triage", s.endpoint(true, func(r *http.Request) (any, error) {
+		return s.svc.Triage()
+	}))
+	s.mux.HandleFunc("/plan", s.endpoint(false, func(r *http.Request) (any, error) {
+		return s.svc.Plan()
+	}))
+	s.mux.HandleFunc("/insights", s.endpoint(false, func(r *http.Request) (any, error) {
+		return s.svc.Insights()
+	}))
+	s.mux.HandleFunc("/search", s.endpoint(false, func(r *http.Request) (any, error) {
+		limit, err := intParam(r, "limit")
+		if err != nil {
+			return nil, err
+		}
+		return s.svc.Search(r.URL.Query().Get("q"), limit)
+	}))
+	s.mux.HandleFunc("/graph", s.endpoint(false, func(r *http.Request) (any, error) {
+		depth, err := intParam(r, "depth")
+		if err != nil {
+			return nil, err
+		}
+		q := r.URL.Query()
+		return s.svc.Graph(q.Get("format"), q.Get("root"), depth)
+	}))
+	s.mux.HandleFunc("/history", s.endpoint(true, func(r *http.Request) (any, error) {
+		return s.svc.History(r.URL.Query().Get("bead"))
+	}))
+	s.mux.HandleFunc("/forecast", s.endpoint(false, func(r *http.Request) (any, error) {
+		agents, err := intParam(r, "agents")
+		if err != nil {
+			return nil, err
+		}
+		trials, err := intParam(r, "trials")
+		if err != nil {
+			return nil, err
+		}
+		return s.svc.Forecast(r.URL.Query().Get("id"), agents, trials)
+	}))
+	s.mux.HandleFunc("/diff", s.endpoint(true, func(r *http.Request) (any, error) {
+		return s.svc.Diff(r.URL.Query().Get("since"))
+	}))
+	s.mux.HandleFunc("/events", s.handleEvents)
+	return s
+}
+
+// Service returns the service the server answers from
+func (s *Server) Service() *Service {
+	return s.svc
+}
+
+// ServeHTTP implements http.Handler. TCP requests whose Host header does
+// not name this server are refused, so a web page that rebinds its DNS name
+// to the loopback address cannot read the API through the visitor's
+// browser. Browsers cannot reach Unix sockets, which accept any Host.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !viaUnixSocket(r) && !s.allowedHost(r.Host) {
+		writeError(w, &RequestError{Status: http.StatusForbidden, Message: fmt.Sprintf("unexpected Host header %q", r.Host)})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func viaUnixSocket(r *http.Request) bool {
+	addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && addr.Network() == "unix"
+}
+
+// allowedHost reports whether a Host header is localhost, 127.0.0.1, [::1]
+// or the configured listen address, with or without a port.
+func (s *Server) allowedHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	case "":
+		return false
+	}
+	if s.cfg.ListenAddr == "" {
+		return false
+	}
+	if strings.EqualFold(hostport, s.cfg.ListenAddr) {
+		return true
+	}
+	listenHost, _, err := net.SplitHostPort(s.cfg.ListenAddr)
+	return err == nil && strings.EqualFold(host, listenHost)
+}
+
+// Reload re-reads the issues and, when they changed, drops cached bodies
+// and notifies /events subscribers
+func (s *Server) Reload() (*ChangeEvent, error) {
+	ev, err := s.svc.Reload()
+	if err != nil || ev == nil {
+		return nil, err
+	}
+	s.cacheMu.Lock()
+	s.cache = make(map[string]*list.Element)
+	s.cacheOrder.Init()
+	s.generation++
+	s.cacheMu.Unlock()
+	s.events.publish(*ev)
+	return ev, nil
+}
+
+// cached returns the body cached under key, marking it recently used, and
+// the current cache generation.
+func (s *Server) cached(key string) (cachedResponse, uint64, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	el, ok := s.cache[key]
+	if !ok {
+		return cachedResponse{}, s.generation, false
+	}
+	s.cacheOrder.MoveToFront(el)
+	return el.Value.(cachedResponse), s.generation, true
+}
+
+// store caches resp unless a reload happened since gen, evicting the least
+// recently used body when the cache is full.
+func (s *Server) store(resp cachedResponse, gen uint64) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.generation != gen {
+		return
+	}
+	if el, ok := s.cache[resp.key]; ok {
+		el.Value = resp
+		s.cacheOrder.MoveToFront(el)
+		return
+	}
+	s.cache[resp.key] = s.cacheOrder.PushFront(resp)
+	for s.cacheOrder.Len() > s.cfg.CacheEntries {
+		oldest := s.cacheOrder.Back()
+		s.cacheOrder.Remove(oldest)
+		delete(s.cache, oldest.Value.(cachedResponse).key)
+	}
+}
+
+// endpoint adapts a payload function into a GET handler with format
+// negotiation, ETag validation and body caching. gitDependent payloads
+// also change when HEAD moves, so HEAD is part of their ETag.
+func (s *Server) endpoint(gitDependent bool, payload func(*http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, &RequestError{Status: http.StatusMethodNotAllowed, Message: "method not allowed"})
+			return
+		}
+		format := s.negotiateFormat(r)
+
+		dataHash := s.svc.DataHash()
+		tag := dataHash
+		if gitDependent {
+			if head := s.svc.GitHead(); len(head) >= 12 {
+				tag += "-" + head[:12]
+			}
+		}
+		etag := fmt.Sprintf(`W/"%s-%s"`, tag, format)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Vary", "Accept")
+		w.Header().Set("X-Bv-Data-Hash", dataHash)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		key := format + " " + r.URL.Path + "?" + r.URL.RawQuery
+		cached, gen, ok := s.cached(key)
+
+		body := cached.body
+		if !ok || cached.etag != etag {
+			v, err := payload(r)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			body, err = s.encode(v, format)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			s.store(cachedResponse{key: key, etag: etag, body: body}, gen)
+		}
+
+		w.Header().Set("Content-Type", contentType(format))
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write(body)
+	}
+}
+
+// negotiateFormat picks json or toon from ?format=, then Accept, then the
+// configured default. TOON needs the tru binary; without it JSON is served.
+func (s *Server) negotiateFormat(r *http.Request) string {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	// /graph's format names the graph syntax, so only Accept picks its encoding
+	if r.URL.Path == "/graph" || (format != "json" && format != "toon") {
+		format = ""
+	}
+	if format == "" {
+		accept := r.Header.Get("Accept")
+		switch {
+		case strings.Contains(accept, "application/toon"), strings.Contains(accept, "text/toon"):
+			format = "toon"
+		case strings.Contains(accept, "application/json"):
+			format = "json"
+		default:
+			format = s.cfg.DefaultFormat
+		}
+	}
+	if format == "toon" && !toon.Available() {
+		format = "json"
+	}
+	return format
+}
+
+func (s *Server) encode(v any, format string) ([]byte, error) {
+	if format == "toon" {
+		opts := toon.DefaultEncodeOptions()
+		if s.cfg.ToonOptions != nil {
+			opts = *s.cfg.ToonOptions
+		}
+		out, err := toon.EncodeWithOptions(v, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strings.TrimRight(out, "\n") + "\n"), nil
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		writeError(w, notFound("no endpoint %s", r.URL.Path))
+		return
+	}
+	w.Header().Set("Content-Type", contentType("json"))
+	_ = json.NewEncoder(w).Encode(struct {
+		DataHash  string        `json:"data_hash"`
+		Endpoints []endpointDoc `json:"endpoints"`
+	}{
+		DataHash:  s.svc.DataHash(),
+		Endpoints: endpointDocs,
+	})
+}
+
+// handleEvents streams change notifications as Server-Sent Events. A
+// "hello" event with the current state opens the stream; each reload that
+// changes the data sends a "change" event whose id is the new data hash.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("streaming unsupported"))
+		return
+	}
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	health := s.svc.Health()
+	writeSSE(w, "hello", health.DataHash, health)
+	flusher.Flush()
+
+	ticker := time.NewTicker(s.cfg.Heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			writeSSE(w, "change", ev.DataHash, ev)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, event, id string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", event, id, data)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		status = reqErr.Status
+	}
+	w.Header().Del("ETag")
+	w.Header().Set("Content-Type", contentType("json"))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error  string `json:"error"`
+		Status int    `json:"status"`
+	}{Error: err.Error(), Status: status})
+}
+
+func contentType(format string) string {
+	if format == "toon" {
+		return "application/toon; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// etagMatches reports whether an If-None-Match header names etag
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag || "W/"+candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func intParam(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, badRequest("invalid %s %q (expected a non-negative integer)", name, raw)
+	}
+	return n, nil
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// memoryIssues is a swappable in-memory issue source
+type memoryIssues struct {
+	mu     sync.Mutex
+	issues []model.Issue
+}
+
+func (m *memoryIssues) load() ([]model.Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]model.Issue(nil), m.issues...), nil
+}
+
+func (m *memoryIssues) set(issues []model.Issue) {
+	m.mu.Lock()
+	m.issues = issues
+	m.mu.Unlock()
+}
+
+func testIssues() []model.Issue {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{ID: "bv-1", Title: "Login page", Description: "OAuth login flow", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature, CreatedAt: now},
+		{ID: "bv-2", Title: "Session storage", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Fix logout crash", Status: model.StatusClosed, Priority: 0, IssueType: model.TypeBug, CreatedAt: now},
+	}
+}
+
+func newTestServer(t *testing.T) (*Server, *memoryIssues) {
+	t.Helper()
+	src := &memoryIssues{issues: testIssues()}
+	svc, err := NewService(Options{Load: src.load})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return New(svc, Config{Heartbeat: 50 * time.Millisecond}), src
+}
+
+func get(t *testing.T, h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "http://localhost"+target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServerEndpoints(t *testing.T) {
+	srv, _ := newTestServer(t)
+	hash := srv.Service().DataHash()
+
+	for _, path := range []string{"/", "/health", "/triage", "/plan", "/insights", "/search?q=login", "/graph?format=mermaid", "/forecast?agents=2"} {
+		rec := get(t, srv, path, nil)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d: %s", path, rec.Code, rec.Body)
+			continue
+		}
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Errorf("GET %s: invalid JSON: %v", path, err)
+			continue
+		}
+		if body["data_hash"] != hash {
+			t.Errorf("GET %s data_hash = %v, want %s", path, body["data_hash"], hash)
+		}
+	}
+
+	var search SearchPayload
+	rec := get(t, srv, "/search?q=logn&limit=1", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &search); err != nil {
+		t.Fatal(err)
+	}
+	if len(search.Results) != 1 || search.Results[0].IssueID != "bv-1" || search.Results[0].Title != "Login page" {
+		t.Errorf("typo search results = %+v", search.Results)
+	}
+
+	for path, want := range map[string]int{
+		"/search":             http.StatusBadRequest,
+		"/search?q=x&limit=z": http.StatusBadRequest,
+		"/graph?format=png":   http.StatusBadRequest,
+		"/forecast?id=nope":   http.StatusNotFound,
+		"/diff?since=HEAD~1":  http.StatusServiceUnavailable,
+		"/history":            http.StatusServiceUnavailable,
+		"/missing":            http.StatusNotFound,
+	} {
+		if rec := get(t, srv, path, nil); rec.Code != want {
+			t.Errorf("GET %s = %d, want %d: %s", path, rec.Code, want, rec.Body)
+		}
+	}
+}
+
+func TestServerETag(t *testing.T) {
+	srv, src := newTestServer(t)
+
+	first := get(t, srv, "/plan", nil)
+	etag := first.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`+srv.Service().DataHash()) {
+		t.Fatalf("ETag = %q, want weak tag on the data hash", etag)
+	}
+
+	again := get(t, srv, "/plan", http.Header{"If-None-Match": {etag}})
+	if again.Code != http.StatusNotModified || again.Body.Len() != 0 {
+		t.Errorf("matching If-None-Match = %d with %d bytes, want empty 304", again.Code, again.Body.Len())
+	}
+	if cached := get(t, srv, "/plan", nil); cached.Body.String() != first.Body.String() {
+		t.Error("unchanged data should reuse the cached body")
+	}
+
+	issues := testIssues()
+	issues[1].Status = model.StatusInProgress
+	src.set(issues)
+	ev, err := srv.Reload()
+	if err != nil || ev == nil {
+		t.Fatalf("Reload = %v, %v; want a change event", ev, err)
+	}
+	if len(ev.Modified) != 1 || ev.Modified[0] != "bv-2" {
+		t.Errorf("modified = %v, want [bv-2]", ev.Modified)
+	}
+
+	stale := get(t, srv, "/plan", http.Header{"If-None-Match": {etag}})
+	if stale.Code != http.StatusOK || stale.Header().Get("ETag") == etag {
+		t.Errorf("after reload = %d with ETag %q, want fresh 200", stale.Code, stale.Header().Get("ETag"))
+	}
+
+	if ev, _ := srv.Reload(); ev != nil {
+		t.Errorf("reload without changes = %+v, want nil", ev)
+	}
+}
+
+func TestServerCacheIsBounded(t *testing.T) {
+	src := &memoryIssues{issues: testIssues()}
+	svc, err := NewService(Options{Load: src.load})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	srv := New(svc, Config{CacheEntries: 2})
+
+	for _, q := range []string{"login", "session", "logout", "login"} {
+		if rec := get(t, srv, "/search?q="+q, nil); rec.Code != http.StatusOK {
+			t.Fatalf("GET /search?q=%s = %d", q, rec.Code)
+		}
+	}
+	srv.cacheMu.Lock()
+	defer srv.cacheMu.Unlock()
+	if len(srv.cache) != 2 || srv.cacheOrder.Len() != 2 {
+		t.Fatalf("cache holds %d entries (%d ordered), want 2", len(srv.cache), srv.cacheOrder.Len())
+	}
+	if _, ok := srv.cache["json /search?q=session"]; ok {
+		t.Error("least recently used query should have been evicted")
+	}
+	if front := srv.cacheOrder.Front().Value.(cachedResponse); front.key != "json /search?q=login" {
+		t.Errorf("most recent entry = %q, want the repeated login query", front.key)
+	}
+}
+
+func TestServerRejectsForeignHost(t *testing.T) {
+	src := &memoryIssues{issues: testIssues()}
+	svc, err := NewService(Options{Load: src.load})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	srv := New(svc, Config{ListenAddr: "127.0.0.2:9210"})
+
+	for host, want := range map[string]int{
+		"localhost":        http.StatusOK,
+		"LOCALHOST:9210":   http.StatusOK,
+		"127.0.0.1:9210":   http.StatusOK,
+		"[::1]:9210":       http.StatusOK,
+		"127.0.0.2:9210":   http.StatusOK,
+		"127.0.0.2":        http.StatusOK,
+		"attacker.example": http.StatusForbidden,
+		"rebind.test:9210": http.StatusForbidden,
+		"127.0.0.1.nip.io": http.StatusForbidden,
+		"":                 http.StatusForbidden,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Host %q = %d, want %d", host, rec.Code, want)
+		}
+	}
+
+	// Browsers cannot reach a Unix socket, so its clients may send any Host
+	sock := filepath.Join(t.TempDir(), "bv.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	hs := &http.Server{Handler: srv}
+	go func() { _ = hs.Serve(l) }()
+	defer hs.Close()
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	resp, err := client.Get("http://bv/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unix socket with Host bv = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestServerEvents(t *testing.T) {
+	srv, src := newTestServer(t)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		defer close(lines)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	next := func(prefix string) string {
+		t.Helper()
+		for line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimPrefix(line, prefix)
+			}
+		}
+		t.Fatalf("stream ended before %q", prefix)
+		return ""
+	}
+
+	if ev := next("event: "); ev != "hello" {
+		t.Fatalf("first event = %q, want hello", ev)
+	}
+	next(": ping")
+
+	issues := append(testIssues(), model.Issue{ID: "bv-4", Title: "New", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: time.Now()})
+	src.set(issues)
+	for srv.events.subscribers() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := srv.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next("event: "); ev != "change" {
+		t.Fatalf("event = %q, want change", ev)
+	}
+	var change ChangeEvent
+	if err := json.Unmarshal([]byte(next("data: ")), &change); err != nil {
+		t.Fatal(err)
+	}
+	if change.DataHash != srv.Service().DataHash() || change.IssueCount != 4 || len(change.Added) != 1 || change.Added[0] != "bv-4" {
+		t.Errorf("change = %+v", change)
+	}
+}
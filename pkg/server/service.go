@@ -0,0 +1,734 @@
+// Package server keeps issues and their analysis warm in a long-running
+// process and answers the robot commands over a local HTTP API.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+// DefaultAddr is where bv serve listens when neither --addr nor --socket is given
+const DefaultAddr = "127.0.0.1:9210"
+
+// DefaultHistoryLimit caps the commits correlated for the history endpoint
+const DefaultHistoryLimit = 500
+
+// DefaultSearchLimit is the number of search results when no limit is given
+const DefaultSearchLimit = 10
+
+// Options configures a Service
+type Options struct {
+	Load         func() ([]model.Issue, error) // Reads the current issues
+	RepoPath     string                        // Git work tree for history and diff ("" disables both)
+	BeadsPath    string                        // Beads JSONL path passed to the correlator
+	HistoryLimit int                           // Commits to correlate (default DefaultHistoryLimit)
+}
+
+// RequestError is a failure caused by the caller's parameters rather than
+// the data; Status is the HTTP status to answer with
+type RequestError struct {
+	Status  int
+	Message string
+}
+
+func (e *RequestError) Error() string {
+	return e.Message
+}
+
+func badRequest(format string, args ...any) error {
+	return &RequestError{Status: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &RequestError{Status: http.StatusNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrNoRepository is returned by history and diff when no git work tree is configured
+var ErrNoRepository = &RequestError{Status: http.StatusServiceUnavailable, Message: "not inside a git repository"}
+
+// snapshot is one loaded generation of issues. Its analysis is computed on
+// first use and shared by every request until the next reload.
+type snapshot struct {
+	issues   []model.Issue
+	dataHash string
+	loadedAt time.Time
+
+	mu       sync.Mutex // Guards the lazily computed fields; Analyzer is not safe for concurrent use
+	analyzer *analysis.Analyzer
+	stats    *analysis.GraphStats
+
+	triage        *analysis.TriageResult
+	triageHistory *correlation.HistoryReport // History the cached triage was scored with
+}
+
+// graphLocked returns the snapshot's analyzer and graph stats, computing them once.
+// Callers must hold s.mu.
+func (s *snapshot) graphLocked() (*analysis.Analyzer, *analysis.GraphStats) {
+	if s.analyzer == nil {
+		s.analyzer = analysis.NewAnalyzer(s.issues)
+		stats := s.analyzer.Analyze()
+		s.stats = &stats
+	}
+	return s.analyzer, s.stats
+}
+
+// Service holds the warm issue snapshot and renders the robot payloads
+// from it. It is safe for concurrent use.
+type Service struct {
+	opts Options
+
+	mu      sync.RWMutex
+	current *snapshot
+	lexical *search.LexicalIndex
+
+	historyMu  sync.Mutex
+	history    *correlation.HistoryReport
+	historyKey string // dataHash + HEAD the cached report was built for
+}
+
+// NewService loads the issues once and returns a service ready to answer
+func NewService(opts Options) (*Service, error) {
+	if opts.Load == nil {
+		return nil, errors.New("server: Options.Load is required")
+	}
+	if opts.HistoryLimit <= 0 {
+		opts.HistoryLimit = DefaultHistoryLimit
+	}
+	s := &Service{opts: opts, lexical: search.NewLexicalIndex()}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads the issues. It returns nil when the data hash is
+// unchanged, otherwise a ChangeEvent describing what moved.
+func (s *Service) Reload() (*ChangeEvent, error) {
+	issues, err := s.opts.Load()
+	if err != nil {
+		return nil, err
+	}
+	next := &snapshot{
+		issues:   issues,
+		dataHash: analysis.ComputeDataHash(issues),
+		loadedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.current
+	if prev != nil && prev.dataHash == next.dataHash {
+		return nil, nil
+	}
+	if _, err := search.SyncLexicalIndex(s.lexical, issues); err != nil {
+		return nil, fmt.Errorf("indexing issues for search: %w", err)
+	}
+	s.current = next
+
+	event := &ChangeEvent{
+		DataHash:   next.dataHash,
+		IssueCount: len(issues),
+		Time:       next.loadedAt,
+	}
+	if prev != nil {
+		diff := analysis.ComputeIssueDiff(prev.issues, issues)
+		event.PreviousHash = prev.dataHash
+		event.Added = diff.Added
+		event.Removed = diff.Removed
+		event.Modified = diff.Modified
+	}
+	return event, nil
+}
+
+func (s *Service) snapshot() *snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// DataHash returns the fingerprint of the loaded issues
+func (s *Service) DataHash() string {
+	return s.snapshot().dataHash
+}
+
+// Issues returns the loaded issues. The slice is shared and must not be modified.
+func (s *Service) Issues() []model.Issue {
+	return s.snapshot().issues
+}
+
+// GitHead returns the repository HEAD, or "" without a repository. History
+// and diff payloads depend on it as well as on the data hash.
+func (s *Service) GitHead() string {
+	if s.opts.RepoPath == "" {
+		return ""
+	}
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = s.opts.RepoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Health reports what the service has loaded
+func (s *Service) Health() HealthPayload {
+	snap := s.snapshot()
+	return HealthPayload{
+		Status:     "ok",
+		DataHash:   snap.dataHash,
+		IssueCount: len(snap.issues),
+		LoadedAt:   snap.loadedAt.Format(time.RFC3339),
+	}
+}
+
+// HealthPayload is the response of /health
+type HealthPayload struct {
+	Status     string `json:"status"`
+	DataHash   string `json:"data_hash"`
+	IssueCount int    `json:"issue_count"`
+	LoadedAt   string `json:"loaded_at"`
+}
+
+// TriagePayload mirrors --robot-triage
+type TriagePayload struct {
+	GeneratedAt string                `json:"generated_at"`
+	DataHash    string                `json:"data_hash"`
+	Triage      analysis.TriageResult `json:"triage"`
+}
+
+// Triage ranks the open work. Git history feeds staleness when available.
+func (s *Service) Triage() (*TriagePayload, error) {
+	snap := s.snapshot()
+	return &TriagePayload{
+		GeneratedAt: now(),
+		DataHash:    snap.dataHash,
+		Triage:      *s.triage(snap),
+	}, nil
+}
+
+// triage returns the snapshot's triage, recomputing it only when the
+// history it was scored with has been regenerated
+func (s *Service) triage(snap *snapshot) *analysis.TriageResult {
+	history, _ := s.historyReport(snap)
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	if snap.triage == nil || snap.triageHistory != history {
+		triage := analysis.ComputeTriageWithOptions(snap.issues, analysis.TriageOptions{
+			WaitForPhase2: true,
+			UseFastConfig: true,
+			History:       history,
+		})
+		snap.triage = &triage
+		snap.triageHistory = history
+	}
+	return snap.triage
+}
+
+// NextPayload mirrors --robot-next
+type NextPayload struct {
+	GeneratedAt    string   `json:"generated_at"`
+	DataHash       string   `json:"data_hash"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Score          float64  `json:"score"`
+	Reasons        []string `json:"reasons"`
+	Unblocks       int      `json:"unblocks"`
+	ClaimCommand   string   `json:"claim_command"`
+	ShowCommand    string   `json:"show_command"`
+	ScoringProfile string   `json:"scoring_profile"`
+}
+
+// ErrNothingActionable is returned by Next when no open work is ready
+var ErrNothingActionable = notFound("No actionable items available")
+
+// Next returns the single top triage pick
+func (s *Service) Next() (*NextPayload, error) {
+	snap := s.snapshot()
+	triage := s.triage(snap)
+	if len(triage.QuickRef.TopPicks) == 0 {
+		return nil, ErrNothingActionable
+	}
+	top := triage.QuickRef.TopPicks[0]
+	return &NextPayload{
+		GeneratedAt:    now(),
+		DataHash:       snap.dataHash,
+		ID:             top.ID,
+		Title:          top.Title,
+		Score:          top.Score,
+		Reasons:        top.Reasons,
+		Unblocks:       top.Unblocks,
+		ClaimCommand:   fmt.Sprintf("br update %s --status=in_progress", top.ID),
+		ShowCommand:    fmt.Sprintf("br show %s", top.ID),
+		ScoringProfile: triage.Meta.ScoringProfile,
+	}, nil
+}
+
+// PlanPayload mirrors --robot-plan
+type PlanPayload struct {
+	GeneratedAt string                 `json:"generated_at"`
+	DataHash    string                 `json:"data_hash"`
+	Status      analysis.MetricStatus  `json:"status"`
+	Plan        analysis.ExecutionPlan `json:"plan"`
+}
+
+// Plan returns the dependency-respecting execution plan
+func (s *Service) Plan() (*PlanPayload, error) {
+	snap := s.snapshot()
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	analyzer, stats := snap.graphLocked()
+	return &PlanPayload{
+		GeneratedAt: now(),
+		DataHash:    snap.dataHash,
+		Status:      stats.Status(),
+		Plan:        analyzer.GetExecutionPlan(),
+	}, nil
+}
+
+// BlockerChainPayload mirrors --robot-blocker-chain
+type BlockerChainPayload struct {
+	GeneratedAt string                       `json:"generated_at"`
+	DataHash    string                       `json:"data_hash"`
+	Result      *analysis.BlockerChainResult `json:"result"`
+}
+
+// BlockerChain walks the chain of open blockers in front of an issue
+func (s *Service) BlockerChain(id string) (*BlockerChainPayload, error) {
+	if id == "" {
+		return nil, badRequest("issue id is required")
+	}
+	snap := s.snapshot()
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	analyzer, _ := snap.graphLocked()
+	result := analyzer.GetBlockerChain(id)
+	if result == nil {
+		return nil, notFound("issue %q not found", id)
+	}
+	return &BlockerChainPayload{
+		GeneratedAt: now(),
+		DataHash:    snap.dataHash,
+		Result:      result,
+	}, nil
+}
+
+// InsightsPayload mirrors --robot-insights without the full metric maps
+type InsightsPayload struct {
+	GeneratedAt    string                  `json:"generated_at"`
+	DataHash       string                  `json:"data_hash"`
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	analysis.Insights
+	TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`
+	AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"`
+}
+
+// Insights returns the graph analysis summary
+func (s *Service) Insights() (*InsightsPayload, error) {
+	snap := s.snapshot()
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	analyzer, stats := snap.graphLocked()
+	return &InsightsPayload{
+		GeneratedAt:      now(),
+		DataHash:         snap.dataHash,
+		AnalysisConfig:   stats.Config,
+		Status:           stats.Status(),
+		Insights:         stats.GenerateInsights(50),
+		TopWhatIfs:       analyzer.TopWhatIfDeltas(10),
+		AdvancedInsights: analyzer.GenerateAdvancedInsights(analysis.DefaultAdvancedInsightsConfig()),
+	}, nil
+}
+
+// SearchResult is one hit of the search endpoint
+type SearchResult struct {
+	IssueID string       `json:"issue_id"`
+	Score   float64      `json:"score"`
+	Title   string       `json:"title,omitempty"`
+	Status  model.Status `json:"status,omitempty"`
+}
+
+// SearchPayload is the response of /search
+type SearchPayload struct {
+	GeneratedAt string         `json:"generated_at"`
+	DataHash    string         `json:"data_hash"`
+	Query       string         `json:"query"`
+	Mode        string         `json:"mode"`
+	Limit       int            `json:"limit"`
+	Results     []SearchResult `json:"results"`
+}
+
+// Search runs a BM25 query (phrases, prefixes and typo tolerance as in
+// --robot-search) against the in-memory lexical index
+func (s *Service) Search(query string, limit int) (*SearchPayload, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, badRequest("query parameter q is required")
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	s.mu.RLock()
+	snap := s.current
+	hits := s.lexical.Search(query, limit)
+	s.mu.RUnlock()
+
+	byID := make(map[string]*model.Issue, len(snap.issues))
+	for i := range snap.issues {
+		byID[snap.issues[i].ID] = &snap.issues[i]
+	}
+	results := make([]SearchResult, 0, len(hits))
+	for _, h := range hits {
+		r := SearchResult{IssueID: h.IssueID, Score: h.Score}
+		if issue, ok := byID[h.IssueID]; ok {
+			r.Title = issue.Title
+			r.Status = issue.Status
+		}
+		results = append(results, r)
+	}
+	return &SearchPayload{
+		GeneratedAt: now(),
+		DataHash:    snap.dataHash,
+		Query:       query,
+		Mode:        "lexical",
+		Limit:       limit,
+		Results:     results,
+	}, nil
+}
+
+// Graph exports the dependency graph as --robot-graph does. format is
+// json, dot or mermaid; root and depth select a subgraph.
+func (s *Service) Graph(format, root string, depth int) (*export.GraphExportResult, error) {
+	var f export.GraphExportFormat
+	switch strings.ToLower(format) {
+	case "", "json":
+		f = export.GraphFormatJSON
+	case "dot":
+		f = export.GraphFormatDOT
+	case "mermaid":
+		f = export.GraphFormatMermaid
+	default:
+		return nil, badRequest("invalid graph format %q (expected json|dot|mermaid)", format)
+	}
+
+	snap := s.snapshot()
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	_, stats := snap.graphLocked()
+	result, err := export.ExportGraph(snap.issues, stats, export.GraphExportConfig{
+		Format:   f,
+		Root:     root,
+		Depth:    depth,
+		DataHash: snap.dataHash,
+	})
+	if err != nil {
+		return nil, badRequest("%v", err)
+	}
+	return result, nil
+}
+
+// History correlates beads with git commits. With a bead ID only that
+// bead's history and commits are returned.
+func (s *Service) History(beadID string) (*correlation.HistoryReport, error) {
+	snap := s.snapshot()
+	report, err := s.historyReport(snap)
+	if err != nil {
+		return nil, err
+	}
+	if beadID == "" {
+		return report, nil
+	}
+
+	hist, ok := report.Histories[beadID]
+	if !ok {
+		return nil, notFound("bead %q not found", beadID)
+	}
+	filtered := *report
+	filtered.Histories = map[string]correlation.BeadHistory{beadID: hist}
+	filtered.CommitIndex = make(correlation.CommitIndex)
+	for _, c := range hist.Commits {
+		filtered.CommitIndex[c.SHA] = []string{beadID}
+	}
+	return &filtered, nil
+}
+
+// ImpactPayload mirrors --robot-impact
+type ImpactPayload struct {
+	GeneratedAt   string                     `json:"generated_at"`
+	DataHash      string                     `json:"data_hash"`
+	Files         []string                   `json:"files"`
+	RiskLevel     string                     `json:"risk_level"`
+	RiskScore     float64                    `json:"risk_score"`
+	Summary       string                     `json:"summary"`
+	Warnings      []string                   `json:"warnings"`
+	AffectedBeads []correlation.AffectedBead `json:"affected_beads"`
+}
+
+// Impact lists the beads that touched the given files and how risky
+// changing them is
+func (s *Service) Impact(files []string) (*ImpactPayload, error) {
+	var paths []string
+	for _, f := range files {
+		if f = strings.TrimSpace(f); f != "" {
+			paths = append(paths, f)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, badRequest("at least one file path is required")
+	}
+	snap := s.snapshot()
+	report, err := s.historyReport(snap)
+	if err != nil {
+		return nil, err
+	}
+	result := correlation.NewFileLookup(report).ImpactAnalysis(paths)
+	return &ImpactPayload{
+		GeneratedAt:   now(),
+		DataHash:      report.DataHash,
+		Files:         result.Files,
+		RiskLevel:     result.RiskLevel,
+		RiskScore:     result.RiskScore,
+		Summary:       result.Summary,
+		Warnings:      result.Warnings,
+		AffectedBeads: result.AffectedBeads,
+	}, nil
+}
+
+// RelatedPayload mirrors --robot-related
+type RelatedPayload struct {
+	*correlation.RelatedWorkResult
+	DataHash string `json:"data_hash"`
+}
+
+// Related finds beads that share files, commits or dependencies with a
+// bead. Zero MinRelevance and MaxResults use the --robot-related defaults.
+func (s *Service) Related(id string, opts correlation.RelatedWorkOptions) (*RelatedPayload, error) {
+	if id == "" {
+		return nil, badRequest("bead id is required")
+	}
+	defaults := correlation.DefaultRelatedWorkOptions()
+	if opts.MinRelevance <= 0 {
+		opts.MinRelevance = defaults.MinRelevance
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = defaults.MaxResults
+	}
+	if opts.ConcurrencyWindow <= 0 {
+		opts.ConcurrencyWindow = defaults.ConcurrencyWindow
+	}
+
+	snap := s.snapshot()
+	report, err := s.historyReport(snap)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DependencyGraph == nil {
+		opts.DependencyGraph = make(map[string][]string)
+		for _, issue := range snap.issues {
+			for _, dep := range issue.Dependencies {
+				opts.DependencyGraph[issue.ID] = append(opts.DependencyGraph[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+	result := report.FindRelatedWork(id, opts)
+	if result == nil {
+		return nil, notFound("bead %q not found in history", id)
+	}
+	return &RelatedPayload{RelatedWorkResult: result, DataHash: report.DataHash}, nil
+}
+
+// historyReport returns the correlation report for a snapshot, reusing the
+// last one while neither the issues nor HEAD have moved
+func (s *Service) historyReport(snap *snapshot) (*correlation.HistoryReport, error) {
+	if s.opts.RepoPath == "" {
+		return nil, ErrNoRepository
+	}
+	key := snap.dataHash + ":" + s.GitHead()
+
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	if s.history != nil && s.historyKey == key {
+		return s.history, nil
+	}
+	if err := correlation.ValidateRepository(s.opts.RepoPath); err != nil {
+		return nil, &RequestError{Status: http.StatusServiceUnavailable, Message: err.Error()}
+	}
+
+	beadInfos := make([]correlation.BeadInfo, len(snap.issues))
+	for i, issue := range snap.issues {
+		beadInfos[i] = correlation.BeadInfo{
+			ID:                 issue.ID,
+			Title:              issue.Title,
+			Status:             string(issue.Status),
+			Description:        issue.Description,
+			AcceptanceCriteria: issue.AcceptanceCriteria,
+		}
+	}
+	correlator := correlation.NewCorrelator(s.opts.RepoPath, s.opts.BeadsPath)
+	report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{Limit: s.opts.HistoryLimit})
+	if err != nil {
+		return nil, fmt.Errorf("generating history report: %w", err)
+	}
+	s.history = report
+	s.historyKey = key
+	return report, nil
+}
+
+// ForecastSummary aggregates a multi-issue forecast
+type ForecastSummary struct {
+	TotalMinutes  int       `json:"total_minutes"`
+	TotalDays     float64   `json:"total_days"`
+	AvgConfidence float64   `json:"avg_confidence"`
+	EarliestETA   time.Time `json:"earliest_eta"`
+	LatestETA     time.Time `json:"latest_eta"`
+}
+
+// ForecastPayload mirrors --robot-forecast
+type ForecastPayload struct {
+	GeneratedAt   string                       `json:"generated_at"`
+	DataHash      string                       `json:"data_hash"`
+	Agents        int                          `json:"agents"`
+	ForecastCount int                          `json:"forecast_count"`
+	Forecasts     []analysis.ETAEstimate       `json:"forecasts"`
+	Summary       *ForecastSummary             `json:"summary,omitempty"`
+	MonteCarlo    *analysis.MonteCarloForecast `json:"monte_carlo,omitempty"`
+}
+
+// Forecast estimates completion for one issue or, with "all" or "", every
+// open issue. trials > 0 adds a Monte Carlo completion forecast.
+func (s *Service) Forecast(id string, agents, trials int) (*ForecastPayload, error) {
+	if id == "" {
+		id = "all"
+	}
+	if agents <= 0 {
+		agents = 1
+	}
+
+	snap := s.snapshot()
+	snap.mu.Lock()
+	_, stats := snap.graphLocked()
+	snap.mu.Unlock()
+
+	at := time.Now()
+	forecasts := []analysis.ETAEstimate{}
+	var targetIDs []string
+	if id == "all" {
+		for _, iss := range snap.issues {
+			if iss.Status == model.StatusClosed {
+				continue
+			}
+			eta, err := analysis.EstimateETAForIssue(snap.issues, stats, iss.ID, agents, at)
+			if err != nil {
+				continue
+			}
+			forecasts = append(forecasts, eta)
+			targetIDs = append(targetIDs, iss.ID)
+		}
+	} else {
+		eta, err := analysis.EstimateETAForIssue(snap.issues, stats, id, agents, at)
+		if err != nil {
+			return nil, notFound("%v", err)
+		}
+		forecasts = append(forecasts, eta)
+		targetIDs = []string{id}
+	}
+
+	payload := &ForecastPayload{
+		GeneratedAt:   now(),
+		DataHash:      snap.dataHash,
+		Agents:        agents,
+		ForecastCount: len(forecasts),
+		Forecasts:     forecasts,
+	}
+	if len(forecasts) > 1 {
+		sum := &ForecastSummary{EarliestETA: forecasts[0].ETADate, LatestETA: forecasts[0].ETADate}
+		var totalConf float64
+		for _, f := range forecasts {
+			sum.TotalMinutes += f.EstimatedMinutes
+			totalConf += f.Confidence
+			if f.ETADate.Before(sum.EarliestETA) {
+				sum.EarliestETA = f.ETADate
+			}
+			if f.ETADate.After(sum.LatestETA) {
+				sum.LatestETA = f.ETADate
+			}
+		}
+		sum.TotalDays = float64(sum.TotalMinutes) / (60.0 * 8.0) // 8hr workday
+		sum.AvgConfidence = totalConf / float64(len(forecasts))
+		payload.Summary = sum
+	}
+	if trials > 0 {
+		target := "all"
+		if id != "all" {
+			target = "bead:" + id
+		}
+		mc, err := analysis.ForecastMonteCarlo(snap.issues, target, targetIDs, analysis.MonteCarloOptions{
+			Trials: trials,
+			Agents: agents,
+			Now:    at,
+		})
+		if err != nil {
+			return nil, badRequest("%v", err)
+		}
+		payload.MonteCarlo = mc
+	}
+	return payload, nil
+}
+
+// DiffPayload mirrors --robot-diff
+type DiffPayload struct {
+	GeneratedAt      string                 `json:"generated_at"`
+	ResolvedRevision string                 `json:"resolved_revision"`
+	FromDataHash     string                 `json:"from_data_hash"`
+	ToDataHash       string                 `json:"to_data_hash"`
+	Diff             *analysis.SnapshotDiff `json:"diff"`
+}
+
+// Diff compares the loaded issues with those at a git revision or date
+func (s *Service) Diff(since string) (*DiffPayload, error) {
+	if since == "" {
+		return nil, badRequest("query parameter since is required")
+	}
+	if s.opts.RepoPath == "" {
+		return nil, ErrNoRepository
+	}
+	snap := s.snapshot()
+
+	gitLoader := loader.NewGitLoader(s.opts.RepoPath)
+	historical, err := gitLoader.LoadAt(since)
+	if err != nil {
+		return nil, badRequest("loading issues at %s: %v", since, err)
+	}
+	revision, err := gitLoader.ResolveRevision(since)
+	if err != nil {
+		revision = since
+	}
+
+	from := analysis.NewSnapshotAt(historical, time.Time{}, revision)
+	to := analysis.NewSnapshot(snap.issues)
+	return &DiffPayload{
+		GeneratedAt:      now(),
+		ResolvedRevision: revision,
+		FromDataHash:     analysis.ComputeDataHash(historical),
+		ToDataHash:       snap.dataHash,
+		Diff:             analysis.CompareSnapshots(from, to),
+	}, nil
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,219 @@
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FakeDaemon is an in-process beads daemon speaking the ConnectRPC JSON
+// method bv uses (List). It honours the status, label
+// and updated_since filters so tests can verify server-side filtering and
+// incremental sync.
+type FakeDaemon struct {
+	Server *httptest.Server
+
+	mu       sync.Mutex
+	issues   map[string]model.Issue
+	requests []FakeDaemonRequest
+	// IgnoreFilters makes List return everything, emulating an old daemon.
+	IgnoreFilters bool
+}
+
+// FakeDaemonRequest records one RPC received by the fake daemon.
+type FakeDaemonRequest struct {
+	Path string
+	Body map[string]any
+}
+
+// NewFakeDaemon starts a fake daemon seeded with issues. Call Close when done.
+func NewFakeDaemon(issues []model.Issue) *FakeDaemon {
+	d := &FakeDaemon{issues: make(map[string]model.Issue)}
+	for _, issue := range issues {
+		d.issues[issue.ID] = issue.Clone()
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bd.v1.BeadsService/List", d.handleList)
+	d.Server = httptest.NewServer(mux)
+	return d
+}
+
+// URL returns the daemon base URL.
+func (d *FakeDaemon) URL() string {
+	return d.Server.URL
+}
+
+// Close shuts the daemon down.
+func (d *FakeDaemon) Close() {
+	d.Server.Close()
+}
+
+// Upsert adds or replaces an issue.
+func (d *FakeDaemon) Upsert(issue model.Issue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.issues[issue.ID] = issue.Clone()
+}
+
+// Delete removes an issue outright (a hard delete, invisible to cursors).
+func (d *FakeDaemon) Delete(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.issues, id)
+}
+
+// Requests returns the RPCs received so far.
+func (d *FakeDaemon) Requests() []FakeDaemonRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]FakeDaemonRequest(nil), d.requests...)
+}
+
+func (d *FakeDaemon) decode(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+	body := map[string]any{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	d.mu.Lock()
+	d.requests = append(d.requests, FakeDaemonRequest{Path: r.URL.Path, Body: body})
+	d.mu.Unlock()
+	return body, true
+}
+
+func (d *FakeDaemon) handleList(w http.ResponseWriter, r *http.Request) {
+	body, ok := d.decode(w, r)
+	if !ok {
+		return
+	}
+	statuses := stringSet(body["statuses"])
+	if s, _ := body["status"].(string); s != "" {
+		statuses[s] = true
+	}
+	labels := stringSet(body["labels"])
+	var since time.Time
+	if s, _ := body["updated_since"].(string); s != "" {
+		since, _ = time.Parse(time.RFC3339, s)
+	}
+	includeComments, _ := body["include_comments"].(bool)
+
+	d.mu.Lock()
+	ids := make([]string, 0, len(d.issues))
+	for id := range d.issues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		issue := d.issues[id]
+		if !d.IgnoreFilters {
+			if len(statuses) > 0 && !statuses[string(issue.Status)] {
+				continue
+			}
+			if len(labels) > 0 && !hasAnyLabel(issue, labels) {
+				continue
+			}
+			if !since.IsZero() && issue.UpdatedAt.Before(since) {
+				continue
+			}
+		}
+		out = append(out, daemonIssue(issue, includeComments))
+	}
+	d.mu.Unlock()
+
+	writeJSON(w, out)
+}
+
+// daemonIssue renders an issue in the daemon's wire shape.
+func daemonIssue(issue model.Issue, includeComments bool) map[string]any {
+	out := map[string]any{
+		"id":          issue.ID,
+		"title":       issue.Title,
+		"description": issue.Description,
+		"status":      string(issue.Status),
+		"issue_type":  string(issue.IssueType),
+		"priority":    issue.Priority,
+		"assignee":    issue.Assignee,
+		"labels":      issue.Labels,
+		"created_at":  formatDaemonTime(issue.CreatedAt),
+		"updated_at":  formatDaemonTime(issue.UpdatedAt),
+	}
+	if issue.ClosedAt != nil {
+		out["closed_at"] = formatDaemonTime(*issue.ClosedAt)
+	}
+	var dependsOn []string
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if dep.Type == model.DepParentChild {
+			out["parent"] = dep.DependsOnID
+			continue
+		}
+		dependsOn = append(dependsOn, dep.DependsOnID)
+	}
+	out["depends_on"] = dependsOn
+	if includeComments {
+		out["comments"] = daemonComments(issue)
+	}
+	return out
+}
+
+func daemonComments(issue model.Issue) []map[string]any {
+	out := make([]map[string]any, 0, len(issue.Comments))
+	for _, c := range issue.Comments {
+		if c == nil {
+			continue
+		}
+		out = append(out, map[string]any{
+			"id":         c.ID,
+			"issue_id":   c.IssueID,
+			"author":     c.Author,
+			"text":       c.Text,
+			"created_at": formatDaemonTime(c.CreatedAt),
+		})
+	}
+	return out
+}
+
+func formatDaemonTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func stringSet(v any) map[string]bool {
+	set := map[string]bool{}
+	if list, ok := v.([]any); ok {
+		for _, item := range list {
+			if s, ok := item.(string); ok && s != "" {
+				set[s] = true
+			}
+		}
+	}
+	return set
+}
+
+func hasAnyLabel(issue model.Issue, labels map[string]bool) bool {
+	for _, l := range issue.Labels {
+		if labels[l] {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -139,6 +139,9 @@ type WorkerMetrics struct {
 	IncrementalListCount uint64
 	FullListCount        uint64
 	IncrementalListRatio float64
+	// Graph analysis reuse: incremental updates vs from-scratch recomputes.
+	IncrementalAnalysisCount uint64
+	FullAnalysisCount        uint64
 }
 
 type workerMetrics struct {
@@ -159,6 +162,8 @@ type workerMetrics struct {
 	snapshotVersion        atomic.Uint64
 	incrementalListCount   atomic.Uint64
 	fullListCount          atomic.Uint64
+	incrementalAnalysis    atomic.Uint64
+	fullAnalysis           atomic.Uint64
 }
 
 // BackgroundWorker manages background processing of beads data.
@@ -192,6 +197,8 @@ type BackgroundWorker struct {
 	currentRecipe     *recipe.Recipe
 	currentRecipeID   string // Recipe identifier for snapshot rebuild keys
 	currentRecipeHash string // Recipe fingerprint for rebuild keys (bv-4ilb)
+	scoringProfile    *analysis.ScoringProfile
+	incremental       *analysis.IncrementalAnalyzer
 	logLevel          WorkerLogLevel
 	logJSON           bool
 	metricsEnabled    bool
@@ -339,6 +346,7 @@ func NewBackgroundWorker(cfg WorkerConfig) (*BackgroundWorker, error) {
 		logJSON:           logJSON,
 		metricsEnabled:    metricsEnabled,
 		tracePath:         tracePath,
+		incremental:       analysis.NewIncrementalAnalyzer(),
 
 		idleGCEnabled:     idleGCConfig.Enabled,
 		idleGCThreshold:   idleGCConfig.Threshold,
@@ -422,6 +430,9 @@ func (w *BackgroundWorker) Metrics() WorkerMetrics {
 		IncrementalListCount: incremental,
 		FullListCount:        full,
 		IncrementalListRatio: ratio,
+
+		IncrementalAnalysisCount: w.metrics.incrementalAnalysis.Load(),
+		FullAnalysisCount:        w.metrics.fullAnalysis.Load(),
 	}
 }
 
@@ -868,6 +879,10 @@ func (w *BackgroundWorker) ForceRefresh() {
 
 	w.lastHash = ""
 	w.forceNext = true
+	// A forced refresh recomputes graph metrics from scratch as well.
+	if w.incremental != nil {
+		w.incremental.Reset()
+	}
 
 	if w.state == WorkerProcessing {
 		w.dirty = true
@@ -898,6 +913,15 @@ func recipeFingerprint(r *recipe.Recipe) string {
 	return fmt.Sprintf("%x", sum[:])
 }
 
+// SetScoringProfile sets the triage scoring profile used for subsequent
+// snapshots. The caller re-ranks the current snapshot itself, so no refresh
+// is triggered.
+func (w *BackgroundWorker) SetScoringProfile(p *analysis.ScoringProfile) {
+	w.mu.Lock()
+	w.scoringProfile = p
+	w.mu.Unlock()
+}
+
 // SetRecipe updates the worker's current recipe and triggers a refresh (bv-2h40).
 // This allows Phase 3 view builders to incorporate recipe/filter state off-thread.
 func (w *BackgroundWorker) SetRecipe(r *recipe.Recipe) {
@@ -1055,6 +1079,11 @@ func (w *BackgroundWorker) process() {
 		} else {
 			w.metrics.fullListCount.Add(1)
 		}
+		if mc := snapshot.MetricChanges; mc != nil && !mc.Full {
+			w.metrics.incrementalAnalysis.Add(1)
+		} else {
+			w.metrics.fullAnalysis.Add(1)
+		}
 	}
 	wasDirty := w.dirty
 	coalesced := w.coalesceCount.Load()
@@ -1309,6 +1338,7 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	currentRecipe := w.currentRecipe
 	recipeID := w.currentRecipeID
 	recipeHash := w.currentRecipeHash
+	scoringProfile := w.scoringProfile
 	w.mu.RUnlock()
 
 	// Determine dataset tier using a fast line count (bv-9thm).
@@ -1441,7 +1471,9 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	analyzeErr := w.safeCompute("analyze_phase1", func() error {
 		builder := NewSnapshotBuilder(issues).
 			WithRecipe(currentRecipe).
-			WithBuildConfig(snapshotBuildConfigForTier(tier))
+			WithScoringProfile(scoringProfile).
+			WithBuildConfig(snapshotBuildConfigForTier(tier)).
+			WithIncrementalAnalyzer(w.incremental)
 		if prevSnapshot != nil {
 			builder.WithPreviousSnapshot(prevSnapshot, diff)
 		}
@@ -1539,7 +1571,22 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 		)
 	}
 
-	// Spawn Phase 2 completion watcher if Phase 2 isn't ready yet
+	if snapshot != nil && snapshot.MetricChanges != nil {
+		mc := snapshot.MetricChanges
+		w.logEvent(LogLevelDebug, "analysis_delta", map[string]any{
+			"full":           mc.Full,
+			"added_nodes":    len(mc.Delta.AddedNodes),
+			"removed_nodes":  len(mc.Delta.RemovedNodes),
+			"added_edges":    len(mc.Delta.AddedEdges),
+			"removed_edges":  len(mc.Delta.RemovedEdges),
+			"phase1_changed": mc.Phase1Changed(),
+			"phase2_changed": mc.Phase2Changed(),
+		})
+	}
+
+	// Spawn Phase 2 completion watcher if Phase 2 isn't ready yet. When the
+	// incremental analyzer reused the previous metrics, Phase 2 is already
+	// ready and there is nothing to wait for.
 	if snapshot != nil && !snapshot.Phase2Ready {
 		go w.runPhase2Analysis(snapshot.Analysis, hash)
 	}
@@ -274,16 +274,14 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 		var colIdx int
 		switch mode {
 		case SwimByStatus:
-			// Default: Open | In Progress | Blocked | Closed
-			switch {
-			case isClosedLikeStatus(issue.Status):
-				colIdx = 3
-			case issue.Status == model.StatusOpen:
-				colIdx = 0
-			case issue.Status == model.StatusInProgress:
+			// Default: Open | In Progress | Blocked | Closed, by workflow class
+			switch issue.Status.Class() {
+			case model.ClassActive:
 				colIdx = 1
-			case issue.Status == model.StatusBlocked:
+			case model.ClassBlocked:
 				colIdx = 2
+			case model.ClassDone, model.ClassTerminal:
+				colIdx = 3
 			default:
 				colIdx = 0
 			}
@@ -289,6 +289,31 @@ func TestColumnCounts(t *testing.T) {
 	}
 }
 
+// TestColumnCounts_WorkflowClasses verifies custom statuses land in the
+// column for their workflow class
+func TestColumnCounts_WorkflowClasses(t *testing.T) {
+	model.SetWorkflow(&model.Workflow{Classes: map[model.Status]model.StatusClass{
+		"qa":         model.ClassActive,
+		"needs-info": model.ClassBlocked,
+		"staged":     model.ClassDone,
+	}})
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issues := []model.Issue{
+		{ID: "1", Status: "qa"},
+		{ID: "2", Status: "needs-info"},
+		{ID: "3", Status: "staged"},
+		{ID: "4", Status: model.StatusReview},
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+
+	for col, want := range []int{1, 1, 1, 1} {
+		if got := b.ColumnCount(col); got != want {
+			t.Errorf("column %d count = %d, want %d", col, got, want)
+		}
+	}
+}
+
 // TestSetIssuesSanitizesSelection verifies selection is sanitized after SetIssues
 func TestSetIssuesSanitizesSelection(t *testing.T) {
 	theme := createTheme()
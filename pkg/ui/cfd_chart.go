@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cfdChartHeight is the number of rows in the cumulative flow chart
+const cfdChartHeight = 14
+
+// renderCFDBands lays the daily counts out as a stacked area chart. Each cell
+// holds the index into statuses of the band covering it, or -1 when empty.
+// Bands stack in statuses order from the bottom row up; columns resample the
+// days so the whole window fits the width.
+func renderCFDBands(days []correlation.CFDDay, statuses []string, width, height int) [][]int {
+	if width < 1 || height < 1 {
+		return nil
+	}
+	cells := make([][]int, height)
+	for r := range cells {
+		cells[r] = make([]int, width)
+		for c := range cells[r] {
+			cells[r][c] = -1
+		}
+	}
+	maxTotal := 0
+	for _, d := range days {
+		maxTotal = max(maxTotal, d.Total)
+	}
+	if len(days) == 0 || maxTotal == 0 {
+		return cells
+	}
+
+	for c := 0; c < width; c++ {
+		day := days[0]
+		if width > 1 {
+			day = days[(c*(len(days)-1)+(width-1)/2)/(width-1)]
+		}
+		for r := 0; r < height; r++ {
+			// Sample the middle of the cell's value range
+			level := (float64(height-r) - 0.5) / float64(height) * float64(maxTotal)
+			cumulative := 0
+			for i, status := range statuses {
+				cumulative += day.Counts[status]
+				if float64(cumulative) > level {
+					cells[r][c] = i
+					break
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// cfdBandColor uses the theme's status color, falling back to the class
+// color for custom workflow statuses
+func cfdBandColor(t Theme, status string) lipgloss.AdaptiveColor {
+	switch status {
+	case "open", "in_progress", "blocked", "closed":
+		return t.GetStatusColor(status)
+	}
+	switch model.Status(status).Class() {
+	case model.ClassDone, model.ClassTerminal:
+		return t.Closed
+	case model.ClassActive:
+		return t.InProgress
+	case model.ClassBlocked:
+		return t.Blocked
+	case model.ClassOpen:
+		return t.Open
+	}
+	return t.Subtext
+}
+
+// SetCumulativeFlow stores the reconstructed daily status counts
+func (m *FlowMetricsModel) SetCumulativeFlow(flow *correlation.CumulativeFlow, err error) {
+	m.cfd = flow
+	m.cfdErr = err
+}
+
+// ToggleCFD switches between the flow metrics table and the cumulative flow chart
+func (m *FlowMetricsModel) ToggleCFD() {
+	m.showCFD = !m.showCFD
+}
+
+// ShowingCFD reports whether the cumulative flow chart is displayed
+func (m *FlowMetricsModel) ShowingCFD() bool {
+	return m.showCFD
+}
+
+// renderCFD renders the cumulative flow chart with legend and date axis
+func (m *FlowMetricsModel) renderCFD() string {
+	t := m.theme
+	var sb strings.Builder
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	sb.WriteString(titleStyle.Render("Cumulative Flow"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.cfdErr != nil:
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  Cumulative flow unavailable: %v", m.cfdErr)))
+		sb.WriteString("\n")
+	case m.cfd == nil:
+		sb.WriteString(dimStyle.Render("  Replaying beads history…"))
+		sb.WriteString("\n")
+	case len(m.cfd.Statuses) == 0:
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  No beads between %s and %s", m.cfd.Since, m.cfd.Until)))
+		sb.WriteString("\n")
+	default:
+		chartWidth := min(max(m.width-12, 10), max(len(m.cfd.Days), 10))
+		chartWidth = min(chartWidth, 90)
+		maxTotal := 0
+		for _, d := range m.cfd.Days {
+			maxTotal = max(maxTotal, d.Total)
+		}
+		styles := make([]lipgloss.Style, len(m.cfd.Statuses))
+		for i, status := range m.cfd.Statuses {
+			styles[i] = t.Renderer.NewStyle().Foreground(cfdBandColor(t, status))
+		}
+
+		cells := renderCFDBands(m.cfd.Days, m.cfd.Statuses, chartWidth, cfdChartHeight)
+		for r, row := range cells {
+			axis := "       "
+			switch r {
+			case 0:
+				axis = fmt.Sprintf("%6d ", maxTotal)
+			case len(cells) - 1:
+				axis = fmt.Sprintf("%6d ", 0)
+			}
+			sb.WriteString(dimStyle.Render(axis) + "│")
+			for _, band := range row {
+				if band < 0 {
+					sb.WriteString(" ")
+				} else {
+					sb.WriteString(styles[band].Render("█"))
+				}
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.Repeat(" ", 7) + "└" + strings.Repeat("─", chartWidth))
+		sb.WriteString("\n")
+		gap := max(chartWidth-len(m.cfd.Since)-len(m.cfd.Until), 1)
+		sb.WriteString(dimStyle.Render(strings.Repeat(" ", 8) + m.cfd.Since + strings.Repeat(" ", gap) + m.cfd.Until))
+		sb.WriteString("\n\n")
+
+		// Legend top band first, matching the chart, with the latest count
+		last := m.cfd.Days[len(m.cfd.Days)-1]
+		for i := len(m.cfd.Statuses) - 1; i >= 0; i-- {
+			status := m.cfd.Statuses[i]
+			sb.WriteString(fmt.Sprintf("  %s %-14s %5d\n", styles[i].Render("█"), status, last.Counts[status]))
+		}
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  %d commits replayed", m.cfd.CommitsReplayed)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("c: flow metrics | esc: back"))
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func testCumulativeFlow() *correlation.CumulativeFlow {
+	return &correlation.CumulativeFlow{
+		Since:    "2025-03-01",
+		Until:    "2025-03-03",
+		Statuses: []string{"closed", "open"},
+		Days: []correlation.CFDDay{
+			{Date: "2025-03-01", Counts: map[string]int{"open": 2}, Total: 2},
+			{Date: "2025-03-02", Counts: map[string]int{"closed": 1, "open": 1}, Total: 2},
+			{Date: "2025-03-03", Counts: map[string]int{"closed": 2}, Total: 2},
+		},
+		CommitsReplayed: 3,
+	}
+}
+
+func TestRenderCFDBands(t *testing.T) {
+	flow := testCumulativeFlow()
+	got := renderCFDBands(flow.Days, flow.Statuses, 3, 2)
+	want := [][]int{
+		{1, 1, 0},
+		{1, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bands = %v, want %v", got, want)
+	}
+
+	empty := renderCFDBands(nil, flow.Statuses, 2, 1)
+	if !reflect.DeepEqual(empty, [][]int{{-1, -1}}) {
+		t.Errorf("empty bands = %v", empty)
+	}
+}
+
+func TestFlowMetricsModel_CFDToggle(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	data, issues := testFlowMetrics()
+	m.SetData(data, issues)
+	m.SetSize(100, 40)
+
+	m.ToggleCFD()
+	if !m.ShowingCFD() || !strings.Contains(m.View(), "Replaying beads history") {
+		t.Fatalf("CFD view before load:\n%s", m.View())
+	}
+
+	m.SetCumulativeFlow(testCumulativeFlow(), nil)
+	out := m.View()
+	for _, want := range []string{"Cumulative Flow", "2025-03-01", "2025-03-03", "closed", "3 commits replayed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+
+	m.ToggleCFD()
+	if m.ShowingCFD() || !strings.Contains(m.View(), "Flow Metrics") {
+		t.Error("second toggle should return to the metrics table")
+	}
+}
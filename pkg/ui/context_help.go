@@ -99,7 +99,7 @@ const contextHelpList = `## List View
   o         Open issues only
   c         Closed issues only
   r         Ready (no blockers)
-  /         Fuzzy search
+  /         Fuzzy search or query (label:api p<2)
   Ctrl+S    Semantic search (AI)
   H         Hybrid ranking
   Alt+H     Hybrid preset
@@ -112,6 +112,10 @@ const contextHelpList = `## List View
   h         History view
 
 **Actions**
+  M         Edit status/priority/labels/deps
+  Ctrl+Z    Undo last edit
+  B         Break dependency cycles
+  %         Cycle scoring profile
   U         Self-update bv
   V         Preview cass sessions`
 
@@ -122,6 +126,7 @@ const contextHelpGraph = `## Graph View
   h/l       Navigate siblings
   Enter     View selected issue
   f         Focus on subgraph
+  W         Repo lanes (workspace mode)
   Esc       Exit to list
 
 **Understanding the Graph**
@@ -129,7 +134,11 @@ const contextHelpGraph = `## Graph View
   (A → B means A blocks B)
 • Node size = priority
 • Color = status
-  Green=closed, Blue=in_progress`
+  Green=closed, Blue=in_progress
+• Repo lanes group nodes by repo;
+  ⇠/⇢ count cross-repo blockers/dependents,
+  ⚠ marks references to repos outside
+  workspace.yaml`
 
 const contextHelpBoard = `## Board View
 
@@ -201,6 +210,8 @@ const contextHelpHistory = `## History View
   f         Toggle file tree panel
   /         Search commits/beads
   c         Cycle confidence filter
+  A         Blame: beads behind each line
+            of the commit's files (J/K: file)
 
 **Causality Markers**
   🎯 Direct   Commit mentions bead ID
@@ -222,6 +233,8 @@ const contextHelpDetail = `## Detail View
 **Actions (from list view)**
   O         Open in editor
   C         Copy issue ID
+  M         Edit bead (write-back)
+  Ctrl+Z    Undo last edit
 
 **Info Shown**
 • Full description (markdown)
@@ -256,7 +269,7 @@ const contextHelpFilter = `## Filter Mode
   a         All (clear filter)
 
 **Search**
-  /         Start fuzzy search
+  /         Start fuzzy search or query
   Ctrl+S    Semantic search (AI)
   H         Hybrid ranking
   Alt+H     Hybrid preset
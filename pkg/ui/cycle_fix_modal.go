@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CycleFixModal walks through the cycle-breaking plan one strongly connected
+// component at a time, showing what each candidate cut does to triage.
+type CycleFixModal struct {
+	plan      *analysis.CycleFixPlan
+	component int
+	cursor    int
+	close     bool
+
+	theme  Theme
+	width  int
+	height int
+}
+
+// NewCycleFixModal creates a modal that shows a loading state until SetPlan.
+func NewCycleFixModal(theme Theme) CycleFixModal {
+	return CycleFixModal{theme: theme, width: 80, height: 24}
+}
+
+// SetPlan installs the computed plan and resets the selection.
+func (m *CycleFixModal) SetPlan(plan *analysis.CycleFixPlan) {
+	m.plan = plan
+	m.component = 0
+	m.cursor = 0
+}
+
+// SetSize updates the modal dimensions.
+func (m *CycleFixModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Loading reports whether the plan is still being computed.
+func (m CycleFixModal) Loading() bool {
+	return m.plan == nil
+}
+
+// ShouldClose reports whether the user dismissed the modal.
+func (m CycleFixModal) ShouldClose() bool {
+	return m.close
+}
+
+// Commands returns the `bd dep remove` commands for the whole plan.
+func (m CycleFixModal) Commands() []string {
+	if m.plan == nil {
+		return nil
+	}
+	return m.plan.Commands
+}
+
+func (m CycleFixModal) current() *analysis.CycleFixComponent {
+	if m.plan == nil || m.component >= len(m.plan.Components) {
+		return nil
+	}
+	return &m.plan.Components[m.component]
+}
+
+// Update handles navigation keys.
+func (m CycleFixModal) Update(msg tea.Msg) (CycleFixModal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "esc", "q", "B":
+		m.close = true
+	case "tab", "l", "right":
+		if m.plan != nil && m.component < len(m.plan.Components)-1 {
+			m.component++
+			m.cursor = 0
+		}
+	case "shift+tab", "h", "left":
+		if m.component > 0 {
+			m.component--
+			m.cursor = 0
+		}
+	case "j", "down":
+		if comp := m.current(); comp != nil && m.cursor < len(comp.Candidates)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	}
+	return m, nil
+}
+
+// View renders the modal.
+func (m CycleFixModal) View() string {
+	r := m.theme.Renderer
+	t := m.theme
+
+	boxWidth := 76
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	if boxWidth < 40 {
+		boxWidth = 40
+	}
+	inner := boxWidth - 6
+
+	modalStyle := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+	titleStyle := r.NewStyle().Bold(true).Foreground(t.Primary)
+	subtleStyle := r.NewStyle().Foreground(t.Subtext)
+	selectedStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := r.NewStyle().Foreground(t.Secondary).Bold(true)
+	goodStyle := r.NewStyle().Foreground(t.Open)
+	badStyle := r.NewStyle().Foreground(t.Blocked)
+
+	// signed renders a delta right-aligned in width cells, coloured by direction.
+	signed := func(d int, higherIsBetter bool, width int) string {
+		s := fmt.Sprintf("%*s", width, fmt.Sprintf("%+d", d))
+		switch {
+		case d == 0:
+			return subtleStyle.Render(s)
+		case (d > 0) == higherIsBetter:
+			return goodStyle.Render(s)
+		default:
+			return badStyle.Render(s)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⟲ Break dependency cycles"))
+	b.WriteString("\n\n")
+
+	if m.plan == nil {
+		b.WriteString(subtleStyle.Render("Simulating cuts…"))
+		b.WriteString("\n\n")
+		b.WriteString(subtleStyle.Italic(true).Render("esc close"))
+		return modalStyle.Render(b.String())
+	}
+	if len(m.plan.Components) == 0 {
+		b.WriteString(goodStyle.Render("✓ No cycles: the dependency graph is a DAG."))
+		b.WriteString("\n\n")
+		b.WriteString(subtleStyle.Italic(true).Render("esc close"))
+		return modalStyle.Render(b.String())
+	}
+
+	comp := m.current()
+	header := fmt.Sprintf("Component %d/%d • %d beads • %d edges", m.component+1, len(m.plan.Components), len(comp.Members), comp.EdgeCount)
+	if m.plan.Status.Capped {
+		header += fmt.Sprintf(" (of %d)", m.plan.ComponentCount)
+	}
+	b.WriteString(labelStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(truncateRunesHelper(strings.Join(comp.Members, " "), inner, "…")))
+	b.WriteString("\n\n")
+
+	kind := "minimal"
+	if !comp.Exact {
+		kind = "greedy"
+	}
+	cuts := make([]string, 0, len(comp.FeedbackArcSet))
+	for _, c := range comp.FeedbackArcSet {
+		cuts = append(cuts, c.From+"→"+c.To)
+	}
+	b.WriteString(fmt.Sprintf("Cut (%s, %d): %s\n", kind, len(cuts), truncateRunesHelper(strings.Join(cuts, ", "), inner-18, "…")))
+	b.WriteString(fmt.Sprintf("  actionable %s • longest path %s • cycles %s\n\n",
+		signed(comp.Delta.Actionable, true, 0), signed(comp.Delta.LongestPath, false, 0), signed(comp.Delta.CyclicComponents, false, 0)))
+
+	b.WriteString(subtleStyle.Render(fmt.Sprintf("   %-*s %5s %6s %6s", inner-23, "Candidate edge", "left", "ready", "path")))
+	b.WriteString("\n")
+	for i, c := range comp.Candidates {
+		marker := "  "
+		if c.InFeedbackArcSet {
+			marker = "✂ "
+		}
+		pointer := " "
+		if i == m.cursor {
+			pointer = selectedStyle.Render("▸")
+		}
+		edge := padRight(truncateRunesHelper(c.From+" → "+c.To, inner-23, "…"), inner-23)
+		b.WriteString(fmt.Sprintf("%s%s%s %5d %s %s", pointer, marker, edge, c.RemainingCuts,
+			signed(c.Delta.Actionable, true, 6), signed(c.Delta.LongestPath, false, 6)))
+		b.WriteString("\n")
+	}
+	if comp.CandidatesCapped {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("  … %d more edges not simulated", comp.EdgeCount-len(comp.Candidates))))
+		b.WriteString("\n")
+	}
+
+	if m.cursor < len(comp.Candidates) {
+		c := comp.Candidates[m.cursor]
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("If %s stops waiting on %s:\n", c.From, c.To))
+		b.WriteString(fmt.Sprintf("  actionable %d, longest path %d, cycles left %d\n",
+			c.Impact.ActionableCount, c.Impact.LongestPath, c.Impact.CyclicComponents))
+		picks := strings.Join(c.Impact.TopPicks, ", ")
+		if picks == "" {
+			picks = "none"
+		}
+		b.WriteString("  top picks: " + truncateRunesHelper(picks, inner-13, "…"))
+		b.WriteString("\n")
+		if len(c.Delta.TopPicksAdded) > 0 {
+			b.WriteString(goodStyle.Render("  + " + strings.Join(c.Delta.TopPicksAdded, ", ")))
+			b.WriteString("\n")
+		}
+		if len(c.Delta.TopPicksDropped) > 0 {
+			b.WriteString(badStyle.Render("  - " + strings.Join(c.Delta.TopPicksDropped, ", ")))
+			b.WriteString("\n")
+		}
+		b.WriteString(subtleStyle.Render("  " + c.Command))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("All cuts: %d commands • actionable %d→%d • longest path %d→%d\n",
+		len(m.plan.Commands), m.plan.Before.ActionableCount, m.plan.After.ActionableCount,
+		m.plan.Before.LongestPath, m.plan.After.LongestPath))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Italic(true).Render("tab/h/l component • j/k candidate • y copy commands • esc close"))
+
+	return modalStyle.Render(b.String())
+}
+
+// CenterModal returns the modal view centered in the given dimensions.
+func (m CycleFixModal) CenterModal(termWidth, termHeight int) string {
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, m.View())
+}
+
+// CycleFixReadyMsg carries a computed cycle-fix plan.
+type CycleFixReadyMsg struct {
+	Plan *analysis.CycleFixPlan
+}
+
+// ComputeCycleFixCmd builds the cycle-fix plan off the UI goroutine; each
+// candidate re-runs triage, which is too slow for the update loop.
+func ComputeCycleFixCmd(issues []model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		return CycleFixReadyMsg{Plan: analysis.GenerateCycleFix(issues, analysis.DefaultCycleFixOptions())}
+	}
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_CycleFixModal(t *testing.T) {
+	dep := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, Dependencies: dep("A", "B")},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen, Dependencies: dep("B", "A")},
+		{ID: "C", Title: "Gamma", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(keyRunes("B"))
+	m = updated.(Model)
+	if !m.showCycleFixModal || m.FocusState() != "cycle_fix_modal" {
+		t.Fatalf("expected cycle fix modal, focus %s", m.FocusState())
+	}
+	if !m.cycleFixModal.Loading() || !strings.Contains(m.View(), "Simulating cuts") {
+		t.Fatal("expected loading state before the plan arrives")
+	}
+	if cmd == nil {
+		t.Fatal("expected a compute command")
+	}
+	ready, ok := cmd().(CycleFixReadyMsg)
+	if !ok {
+		t.Fatal("expected CycleFixReadyMsg")
+	}
+	updated, _ = m.Update(ready)
+	m = updated.(Model)
+
+	view := m.View()
+	for _, want := range []string{"Component 1/1", "A → B", "B → A", "bd dep remove"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+	if got := m.cycleFixModal.Commands(); len(got) != 1 || !strings.HasPrefix(got[0], "bd dep remove ") {
+		t.Errorf("commands = %v", got)
+	}
+
+	updated, _ = m.Update(keyRunes("j"))
+	m = updated.(Model)
+	if m.cycleFixModal.cursor != 1 {
+		t.Errorf("cursor = %d, want 1", m.cycleFixModal.cursor)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showCycleFixModal || m.FocusState() != "list" {
+		t.Fatalf("expected modal closed, focus %s", m.FocusState())
+	}
+}
@@ -0,0 +1,513 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/edit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editApplyTimeout bounds a single write-back (bd invocation or JSONL rewrite).
+const editApplyTimeout = 15 * time.Second
+
+// EditModalResult represents the outcome of the edit modal.
+type EditModalResult int
+
+const (
+	EditPending EditModalResult = iota
+	EditConfirmed
+	EditCancelled
+)
+
+type editStage int
+
+const (
+	editStageField   editStage = iota // Choosing which field to edit
+	editStageChoice                   // Picking a value from a fixed list
+	editStageInput                    // Typing a free-form value
+	editStagePreview                  // Reviewing the diff before writing
+)
+
+type editField struct {
+	key   string
+	label string
+	kind  edit.Kind
+}
+
+var editFields = []editField{
+	{"s", "Status", edit.KindStatus},
+	{"p", "Priority", edit.KindPriority},
+	{"a", "Assignee", edit.KindAssignee},
+	{"l", "Add label", edit.KindAddLabel},
+	{"L", "Remove label", edit.KindRemoveLabel},
+	{"d", "Add dependency", edit.KindAddDependency},
+	{"D", "Remove dependency", edit.KindRemoveDependency},
+}
+
+// editStatusChoices lists the statuses offered in the picker (tombstone is
+// deliberately excluded; deleting beads is out of scope for write-back).
+var editStatusChoices = []model.Status{
+	model.StatusOpen,
+	model.StatusInProgress,
+	model.StatusBlocked,
+	model.StatusReview,
+	model.StatusDeferred,
+	model.StatusPinned,
+	model.StatusHooked,
+	model.StatusClosed,
+}
+
+// statusChoicesFor returns the picker statuses reachable from current: the
+// built-in choices plus any custom workflow statuses, minus moves the
+// workflow's transitions forbid. The current status stays listed so the
+// cursor has somewhere to start.
+func statusChoicesFor(current model.Status) []model.Status {
+	candidates := append([]model.Status(nil), editStatusChoices...)
+	for _, s := range model.KnownStatuses() {
+		if !s.IsBuiltin() {
+			candidates = append(candidates, s)
+		}
+	}
+	choices := candidates[:0]
+	for _, s := range candidates {
+		if s == current || model.CanTransition(current, s) {
+			choices = append(choices, s)
+		}
+	}
+	return choices
+}
+
+// EditModal walks the user through a single write-back edit:
+// pick a field, choose or type a value, review the diff, confirm.
+type EditModal struct {
+	issue  model.Issue
+	known  map[string]bool
+	writer edit.Writer
+	undo   bool
+
+	stage        editStage
+	fieldCursor  int
+	field        editField
+	choices      []string
+	choiceLabels []string
+	choiceCursor int
+	input        textinput.Model
+
+	change  edit.Change
+	preview []edit.DiffLine
+	err     string
+	result  EditModalResult
+
+	theme  Theme
+	width  int
+	height int
+}
+
+// NewEditModal creates an edit modal for issue. known holds the IDs of all
+// loaded issues and is used to validate new dependencies.
+func NewEditModal(issue model.Issue, known map[string]bool, writer edit.Writer, theme Theme) EditModal {
+	ti := textinput.New()
+	ti.CharLimit = 80
+	ti.Width = 30
+	return EditModal{
+		issue:  issue.Clone(),
+		known:  known,
+		writer: writer,
+		input:  ti,
+		theme:  theme,
+		width:  60,
+		height: 20,
+	}
+}
+
+// NewUndoModal creates a modal that previews and confirms an undo change.
+func NewUndoModal(issue model.Issue, change edit.Change, writer edit.Writer, theme Theme) EditModal {
+	m := NewEditModal(issue, nil, writer, theme)
+	m.undo = true
+	m.setPreview(change)
+	return m
+}
+
+// Update handles input for the modal.
+func (m EditModal) Update(msg tea.Msg) (EditModal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		if m.stage == editStageInput {
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+	key := keyMsg.String()
+
+	switch m.stage {
+	case editStageField:
+		switch key {
+		case "j", "down":
+			if m.fieldCursor < len(editFields)-1 {
+				m.fieldCursor++
+			}
+		case "k", "up":
+			if m.fieldCursor > 0 {
+				m.fieldCursor--
+			}
+		case "enter":
+			return m.selectField(editFields[m.fieldCursor])
+		case "esc", "q", "M":
+			m.result = EditCancelled
+		default:
+			for i, f := range editFields {
+				if key == f.key {
+					m.fieldCursor = i
+					return m.selectField(f)
+				}
+			}
+		}
+
+	case editStageChoice:
+		switch key {
+		case "j", "down":
+			if m.choiceCursor < len(m.choices)-1 {
+				m.choiceCursor++
+			}
+		case "k", "up":
+			if m.choiceCursor > 0 {
+				m.choiceCursor--
+			}
+		case "enter":
+			if len(m.choices) > 0 {
+				m.buildChange(m.choices[m.choiceCursor])
+			}
+		case "esc", "q":
+			m.backToFields()
+		default:
+			// Priority shortcut: 0-4 picks directly
+			if m.field.kind == edit.KindPriority && len(key) == 1 && key[0] >= '0' && key[0] <= '4' {
+				m.buildChange(key)
+			}
+		}
+
+	case editStageInput:
+		switch key {
+		case "enter":
+			m.buildChange(m.input.Value())
+		case "esc":
+			m.backToFields()
+		default:
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+	case editStagePreview:
+		switch key {
+		case "enter", "y", "Y":
+			m.result = EditConfirmed
+		case "n", "N", "q":
+			m.result = EditCancelled
+		case "esc":
+			if m.undo {
+				m.result = EditCancelled
+			} else {
+				m.backToFields()
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m EditModal) selectField(f editField) (EditModal, tea.Cmd) {
+	m.field = f
+	m.err = ""
+	m.choices = nil
+	m.choiceLabels = nil
+	m.choiceCursor = 0
+
+	switch f.kind {
+	case edit.KindStatus:
+		for i, s := range statusChoicesFor(m.issue.Status) {
+			m.choices = append(m.choices, string(s))
+			m.choiceLabels = append(m.choiceLabels, string(s))
+			if s == m.issue.Status {
+				m.choiceCursor = i
+			}
+		}
+		m.stage = editStageChoice
+	case edit.KindPriority:
+		for p := 0; p <= 4; p++ {
+			m.choices = append(m.choices, fmt.Sprintf("%d", p))
+			m.choiceLabels = append(m.choiceLabels, fmt.Sprintf("P%d %s", p, GetPriorityIcon(p)))
+		}
+		if m.issue.Priority >= 0 && m.issue.Priority <= 4 {
+			m.choiceCursor = m.issue.Priority
+		}
+		m.stage = editStageChoice
+	case edit.KindRemoveLabel:
+		if len(m.issue.Labels) == 0 {
+			m.err = "No labels to remove"
+			return m, nil
+		}
+		labels := append([]string(nil), m.issue.Labels...)
+		sort.Strings(labels)
+		m.choices = labels
+		m.choiceLabels = labels
+		m.stage = editStageChoice
+	case edit.KindRemoveDependency:
+		for _, d := range m.issue.Dependencies {
+			if d == nil {
+				continue
+			}
+			m.choices = append(m.choices, d.DependsOnID)
+			m.choiceLabels = append(m.choiceLabels, fmt.Sprintf("%s (%s)", d.DependsOnID, d.Type))
+		}
+		if len(m.choices) == 0 {
+			m.err = "No dependencies to remove"
+			return m, nil
+		}
+		m.stage = editStageChoice
+	case edit.KindAssignee:
+		m.input.SetValue(m.issue.Assignee)
+		m.input.Placeholder = "assignee (empty to unassign)"
+		m.stage = editStageInput
+	case edit.KindAddLabel:
+		m.input.SetValue("")
+		m.input.Placeholder = "label"
+		m.stage = editStageInput
+	case edit.KindAddDependency:
+		m.input.SetValue("")
+		m.input.Placeholder = "issue ID [type]"
+		m.stage = editStageInput
+	}
+
+	if m.stage == editStageInput {
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	}
+	return m, nil
+}
+
+// buildChange validates value for the selected field and moves to preview.
+func (m *EditModal) buildChange(value string) {
+	var (
+		c   edit.Change
+		err error
+	)
+	switch m.field.kind {
+	case edit.KindStatus:
+		c, err = edit.SetStatus(m.issue, model.Status(value))
+	case edit.KindPriority:
+		var p int
+		if _, scanErr := fmt.Sscanf(value, "%d", &p); scanErr != nil {
+			err = fmt.Errorf("invalid priority %q", value)
+		} else {
+			c, err = edit.SetPriority(m.issue, p)
+		}
+	case edit.KindAssignee:
+		c, err = edit.SetAssignee(m.issue, value)
+	case edit.KindAddLabel:
+		c, err = edit.AddLabel(m.issue, value)
+	case edit.KindRemoveLabel:
+		c, err = edit.RemoveLabel(m.issue, value)
+	case edit.KindAddDependency:
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			err = errors.New("dependency ID cannot be empty")
+			break
+		}
+		var depType model.DependencyType
+		if len(fields) > 1 {
+			depType = model.DependencyType(fields[1])
+		}
+		c, err = edit.AddDependency(m.issue, fields[0], depType, m.known)
+	case edit.KindRemoveDependency:
+		c, err = edit.RemoveDependency(m.issue, value)
+	}
+
+	if errors.Is(err, edit.ErrNoChange) {
+		m.err = "No change: value already set"
+		return
+	}
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+	m.input.Blur()
+	m.setPreview(c)
+}
+
+func (m *EditModal) setPreview(c edit.Change) {
+	lines, err := edit.Diff(m.issue, c)
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+	m.change = c
+	m.preview = lines
+	m.err = ""
+	m.stage = editStagePreview
+}
+
+func (m *EditModal) backToFields() {
+	m.input.Blur()
+	m.err = ""
+	m.stage = editStageField
+}
+
+// Result returns the user's decision, or EditPending while still editing.
+func (m EditModal) Result() EditModalResult {
+	return m.result
+}
+
+// Change returns the confirmed change.
+func (m EditModal) Change() edit.Change {
+	return m.change
+}
+
+// IsUndo reports whether the modal is confirming an undo.
+func (m EditModal) IsUndo() bool {
+	return m.undo
+}
+
+// SetSize sets the modal dimensions.
+func (m *EditModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// View renders the modal.
+func (m EditModal) View() string {
+	r := m.theme.Renderer
+	t := m.theme
+
+	boxWidth := 60
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+
+	modalStyle := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+	titleStyle := r.NewStyle().Bold(true).Foreground(t.Primary)
+	subtleStyle := r.NewStyle().Foreground(t.Subtext)
+	selectedStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	keyStyle := r.NewStyle().Foreground(t.Secondary).Bold(true)
+	errStyle := r.NewStyle().Foreground(t.Blocked).Bold(true)
+	addStyle := r.NewStyle().Foreground(t.Open)
+	delStyle := r.NewStyle().Foreground(t.Blocked)
+
+	var b strings.Builder
+	title := "✏️  Edit " + m.issue.ID
+	if m.undo {
+		title = "↩ Undo edit on " + m.issue.ID
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(truncateRunesHelper(m.issue.Title, boxWidth-6, "…")))
+	b.WriteString("\n\n")
+
+	var hint string
+	switch m.stage {
+	case editStageField:
+		for i, f := range editFields {
+			line := fmt.Sprintf("%s  %s", keyStyle.Render(fmt.Sprintf("%-1s", f.key)), f.label)
+			if i == m.fieldCursor {
+				line = selectedStyle.Render("▸ ") + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+		hint = "j/k move • enter/key select • esc close"
+
+	case editStageChoice:
+		b.WriteString(m.field.label + ":\n")
+		for i, label := range m.choiceLabels {
+			if i == m.choiceCursor {
+				b.WriteString(selectedStyle.Render("▸ " + label))
+			} else {
+				b.WriteString("  " + label)
+			}
+			b.WriteString("\n")
+		}
+		hint = "j/k move • enter choose • esc back"
+
+	case editStageInput:
+		b.WriteString(m.field.label + ":\n")
+		b.WriteString(m.input.View())
+		b.WriteString("\n")
+		hint = "enter preview • esc back"
+
+	case editStagePreview:
+		b.WriteString(subtleStyle.Render("--- current"))
+		b.WriteString("\n")
+		b.WriteString(subtleStyle.Render("+++ edited"))
+		b.WriteString("\n")
+		for _, l := range m.preview {
+			text := string(l.Op) + " " + l.Text
+			switch l.Op {
+			case '+':
+				b.WriteString(addStyle.Render(text))
+			case '-':
+				b.WriteString(delStyle.Render(text))
+			default:
+				b.WriteString(text)
+			}
+			b.WriteString("\n")
+		}
+		if m.writer != nil {
+			b.WriteString("\n")
+			b.WriteString(subtleStyle.Render("via " + m.writer.Name() + ": " + m.writer.Describe(m.change)))
+			b.WriteString("\n")
+		}
+		hint = "enter/y write • n cancel • esc back"
+		if m.undo {
+			hint = "enter/y undo • n/esc cancel"
+		}
+	}
+
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(errStyle.Render("⚠ " + m.err))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Italic(true).Render(hint))
+
+	return modalStyle.Render(b.String())
+}
+
+// CenterModal returns the modal view centered in the given dimensions.
+func (m EditModal) CenterModal(termWidth, termHeight int) string {
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, m.View())
+}
+
+// EditAppliedMsg reports the outcome of a write-back.
+type EditAppliedMsg struct {
+	Change edit.Change
+	Undo   bool
+	Err    error
+}
+
+// ApplyEditCmd persists change through writer off the UI goroutine.
+func ApplyEditCmd(writer edit.Writer, change edit.Change, undo bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), editApplyTimeout)
+		defer cancel()
+		return EditAppliedMsg{Change: change, Undo: undo, Err: writer.Apply(ctx, change)}
+	}
+}
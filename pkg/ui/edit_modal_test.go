@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/edit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type recordingWriter struct {
+	applied []edit.Change
+}
+
+func (w *recordingWriter) Name() string                  { return "fake" }
+func (w *recordingWriter) Describe(c edit.Change) string { return "fake " + string(c.Kind) }
+func (w *recordingWriter) Apply(_ context.Context, c edit.Change) error {
+	w.applied = append(w.applied, c)
+	return nil
+}
+
+func keyRunes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// findEditApplied runs cmd (expanding batches) and returns the first EditAppliedMsg.
+func findEditApplied(cmd tea.Cmd) (EditAppliedMsg, bool) {
+	if cmd == nil {
+		return EditAppliedMsg{}, false
+	}
+	switch msg := cmd().(type) {
+	case EditAppliedMsg:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if found, ok := findEditApplied(c); ok {
+				return found, true
+			}
+		}
+	}
+	return EditAppliedMsg{}, false
+}
+
+func TestEditModal_StatusFlowShowsPreview(t *testing.T) {
+	issue := model.Issue{ID: "bv-1", Title: "One", Status: model.StatusOpen, Priority: 2}
+	w := &recordingWriter{}
+	m := NewEditModal(issue, map[string]bool{"bv-1": true}, w, DefaultTheme(lipgloss.NewRenderer(nil)))
+
+	m, _ = m.Update(keyRunes("s"))
+	if m.stage != editStageChoice {
+		t.Fatalf("expected status choices, got stage %d", m.stage)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.stage != editStagePreview {
+		t.Fatalf("expected preview stage, got %d (err %q)", m.stage, m.err)
+	}
+	view := m.View()
+	for _, want := range []string{"- status: open", "+ status: in_progress", "via fake"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("preview missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = m.Update(keyRunes("y"))
+	if m.Result() != EditConfirmed {
+		t.Fatalf("expected confirmed result")
+	}
+	if c := m.Change(); c.Kind != edit.KindStatus || c.New != "in_progress" {
+		t.Errorf("unexpected change %+v", c)
+	}
+	if len(w.applied) != 0 {
+		t.Error("modal must not write; the model applies confirmed changes")
+	}
+}
+
+func TestEditModal_InputValidation(t *testing.T) {
+	issue := model.Issue{ID: "bv-1", Title: "One", Status: model.StatusOpen}
+	m := NewEditModal(issue, map[string]bool{"bv-1": true, "bv-2": true}, nil, DefaultTheme(lipgloss.NewRenderer(nil)))
+
+	m, _ = m.Update(keyRunes("d"))
+	if m.stage != editStageInput {
+		t.Fatalf("expected input stage for add dependency")
+	}
+	for _, r := range "bv-9" {
+		m, _ = m.Update(keyRunes(string(r)))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.stage != editStageInput || !strings.Contains(m.err, "unknown issue") {
+		t.Fatalf("expected unknown issue error, got stage %d err %q", m.stage, m.err)
+	}
+
+	// Removing labels on an unlabeled issue is refused up front.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.Update(keyRunes("L"))
+	if m.stage != editStageField || m.err == "" {
+		t.Errorf("expected error staying on field list, got stage %d err %q", m.stage, m.err)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Result() != EditCancelled {
+		t.Errorf("expected esc on field list to cancel")
+	}
+}
+
+func TestModel_EditAndUndo(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen, Priority: 2},
+		{ID: "bv-2", Title: "Two", Status: model.StatusOpen, Priority: 1},
+	}
+	m := NewModel(issues, nil, "")
+	w := &recordingWriter{}
+	m.SetEditWriter(w)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(keyRunes("M"))
+	m = updated.(Model)
+	if !m.showEditModal || m.FocusState() != "edit_modal" {
+		t.Fatalf("expected edit modal, focus %s", m.FocusState())
+	}
+	selected := m.editModal.issue.ID
+
+	updated, _ = m.Update(keyRunes("p"))
+	m = updated.(Model)
+	updated, _ = m.Update(keyRunes("0"))
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showEditModal || m.FocusState() != "list" {
+		t.Fatalf("expected modal closed and focus restored, got %s", m.FocusState())
+	}
+
+	applied, ok := findEditApplied(cmd)
+	if !ok {
+		t.Fatal("expected an apply command")
+	}
+	if len(w.applied) != 1 || w.applied[0].IssueID != selected || w.applied[0].New != "0" {
+		t.Fatalf("unexpected writes: %+v", w.applied)
+	}
+	updated, _ = m.Update(applied)
+	m = updated.(Model)
+	if m.editHistory.Len() != 1 {
+		t.Fatalf("expected one undoable edit, got %d", m.editHistory.Len())
+	}
+
+	// Undo previews the inverse and applies it on confirm.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(Model)
+	if !m.showEditModal || !m.editModal.IsUndo() {
+		t.Fatal("expected undo preview modal")
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	applied, ok = findEditApplied(cmd)
+	if !ok || !applied.Undo {
+		t.Fatal("expected an undo apply command")
+	}
+	updated, _ = m.Update(applied)
+	m = updated.(Model)
+	if m.editHistory.Len() != 0 {
+		t.Errorf("expected undo stack drained, got %d", m.editHistory.Len())
+	}
+	if last := w.applied[len(w.applied)-1]; last.Kind != edit.KindPriority || last.Old != "0" {
+		t.Errorf("expected inverse priority change, got %+v", last)
+	}
+}
+
+func TestModel_EditUnavailableWithoutWriter(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(keyRunes("M"))
+	m = updated.(Model)
+	if m.showEditModal {
+		t.Fatal("edit modal should not open without a writer")
+	}
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "Editing unavailable") {
+		t.Errorf("expected unavailable status, got %q", m.statusMsg)
+	}
+}
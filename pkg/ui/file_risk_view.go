@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// fileRiskRow is one visible line of the risk tree: a directory, a file, or
+// a bead under an expanded file
+type fileRiskRow struct {
+	node   *correlation.FileRiskNode
+	beadID string // Set for bead rows
+	depth  int
+}
+
+// FileRiskModel is a directory tree of per-file risk that drills from
+// package to file to the beads that touched it
+type FileRiskModel struct {
+	report       *correlation.FileRiskReport
+	root         *correlation.FileRiskNode
+	issueMap     map[string]*model.Issue
+	expanded     map[string]bool // Expanded directory and file paths
+	rows         []fileRiskRow
+	cursor       int
+	width        int
+	height       int
+	scrollOffset int
+	theme        Theme
+}
+
+// NewFileRiskModel creates a new file risk view
+func NewFileRiskModel(theme Theme) FileRiskModel {
+	return FileRiskModel{
+		theme:    theme,
+		expanded: make(map[string]bool),
+	}
+}
+
+// SetData builds the tree from a risk report. The riskiest path is
+// expanded down to its file so the worst hotspot is visible on open.
+func (m *FileRiskModel) SetData(report *correlation.FileRiskReport, issues []model.Issue) {
+	m.report = report
+	m.root = nil
+	if report != nil {
+		m.root = correlation.BuildFileRiskTree(report.Files)
+	}
+	m.issueMap = make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		m.issueMap[issues[i].ID] = &issues[i]
+	}
+	m.expanded = make(map[string]bool)
+	if m.root != nil && len(m.root.Children) > 0 {
+		for n := m.root.Children[0]; n.IsDir() && len(n.Children) > 0; n = n.Children[0] {
+			m.expanded[n.Path] = true
+		}
+	}
+	m.cursor = 0
+	m.scrollOffset = 0
+	m.rebuildRows()
+}
+
+// SetSize updates the view dimensions
+func (m *FileRiskModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *FileRiskModel) rebuildRows() {
+	m.rows = m.rows[:0]
+	if m.root == nil {
+		return
+	}
+	var walk func(n *correlation.FileRiskNode, depth int)
+	walk = func(n *correlation.FileRiskNode, depth int) {
+		m.rows = append(m.rows, fileRiskRow{node: n, depth: depth})
+		if !m.expanded[n.Path] {
+			return
+		}
+		if !n.IsDir() {
+			for _, id := range n.File.BeadIDs {
+				m.rows = append(m.rows, fileRiskRow{node: n, beadID: id, depth: depth + 1})
+			}
+			return
+		}
+		for _, c := range n.Children {
+			walk(c, depth+1)
+		}
+	}
+	for _, c := range m.root.Children {
+		walk(c, 0)
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(len(m.rows)-1, 0)
+	}
+}
+
+// MoveUp moves cursor up
+func (m *FileRiskModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+		m.ensureVisible()
+	}
+}
+
+// MoveDown moves cursor down
+func (m *FileRiskModel) MoveDown() {
+	if m.cursor < len(m.rows)-1 {
+		m.cursor++
+		m.ensureVisible()
+	}
+}
+
+// GoToStart moves cursor to the first row
+func (m *FileRiskModel) GoToStart() {
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// GoToEnd moves cursor to the last row
+func (m *FileRiskModel) GoToEnd() {
+	if len(m.rows) > 0 {
+		m.cursor = len(m.rows) - 1
+		m.ensureVisible()
+	}
+}
+
+// Expand opens the selected directory or file, or moves into it when
+// already open
+func (m *FileRiskModel) Expand() {
+	row, ok := m.selectedRow()
+	if !ok || row.beadID != "" {
+		return
+	}
+	if m.expanded[row.node.Path] {
+		m.MoveDown()
+		return
+	}
+	m.expanded[row.node.Path] = true
+	m.rebuildRows()
+}
+
+// Collapse closes the selected node, or jumps to its parent when it is
+// already closed or is a bead
+func (m *FileRiskModel) Collapse() {
+	row, ok := m.selectedRow()
+	if !ok {
+		return
+	}
+	if row.beadID == "" && m.expanded[row.node.Path] {
+		m.expanded[row.node.Path] = false
+		m.rebuildRows()
+		return
+	}
+	for i := m.cursor - 1; i >= 0; i-- {
+		if m.rows[i].depth < row.depth && m.rows[i].beadID == "" {
+			m.cursor = i
+			m.ensureVisible()
+			return
+		}
+	}
+}
+
+// Toggle expands or collapses the selected directory or file
+func (m *FileRiskModel) Toggle() {
+	row, ok := m.selectedRow()
+	if !ok || row.beadID != "" {
+		return
+	}
+	m.expanded[row.node.Path] = !m.expanded[row.node.Path]
+	m.rebuildRows()
+}
+
+// SelectedBeadID returns the bead under the cursor, or "" when the cursor
+// is on a directory or file
+func (m *FileRiskModel) SelectedBeadID() string {
+	if row, ok := m.selectedRow(); ok {
+		return row.beadID
+	}
+	return ""
+}
+
+// SelectedPath returns the file or directory path under the cursor
+func (m *FileRiskModel) SelectedPath() string {
+	if row, ok := m.selectedRow(); ok {
+		return row.node.Path
+	}
+	return ""
+}
+
+func (m *FileRiskModel) selectedRow() (fileRiskRow, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return fileRiskRow{}, false
+	}
+	return m.rows[m.cursor], true
+}
+
+// ensureVisible adjusts scroll offset to keep cursor visible
+func (m *FileRiskModel) ensureVisible() {
+	visibleRows := m.visibleRowCount()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	} else if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+}
+
+// visibleRowCount returns how many tree rows fit between the header and
+// the detail footer
+func (m *FileRiskModel) visibleRowCount() int {
+	available := m.height - 12
+	if available < 3 {
+		return 3
+	}
+	return available
+}
+
+// riskHeatColor picks the heat colour for a risk score
+func riskHeatColor(score float64) string {
+	switch correlation.RiskLevel(score) {
+	case "critical":
+		return "#e53935"
+	case "high":
+		return "#ff8f00"
+	case "medium":
+		return "#ffc107"
+	default:
+		return "#4caf50"
+	}
+}
+
+// riskBar renders a score as a bar of width cells
+func riskBar(score float64, width int) string {
+	filled := int(score*float64(width) + 0.5)
+	filled = min(max(filled, 0), width)
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// View renders the file risk view
+func (m *FileRiskModel) View() string {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 30
+	}
+
+	t := m.theme
+	var sb strings.Builder
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	headerStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	sepStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	if m.report == nil || len(m.rows) == 0 {
+		sb.WriteString(titleStyle.Render("File Risk Heatmap"))
+		sb.WriteString("\n\n")
+		sb.WriteString(dimStyle.Render("  No files linked to beads (git history not loaded or no correlated commits)"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	s := m.report.Stats
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("File Risk Heatmap — %d files • %d critical • %d high+", s.TotalFiles, s.CriticalFiles, s.HighRiskFiles)))
+	sb.WriteString("\n\n")
+
+	nameWidth := max(m.width-52, 20)
+	header := fmt.Sprintf("  %-*s %-10s %5s %4s %4s %7s %4s", nameWidth, "Path", "Risk", "", "Bugs", "Reop", "Churn", "Cpl")
+	sb.WriteString(headerStyle.Render(header))
+	sb.WriteString("\n")
+	sb.WriteString(sepStyle.Render(strings.Repeat("─", min(len(header)+2, m.width-2))))
+	sb.WriteString("\n")
+
+	visibleRows := m.visibleRowCount()
+	endIdx := min(m.scrollOffset+visibleRows, len(m.rows))
+	for i := m.scrollOffset; i < endIdx; i++ {
+		row := m.rows[i]
+		isSelected := i == m.cursor
+		rowStyle := t.Renderer.NewStyle()
+		if isSelected {
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true).Background(ThemeBg("#333"))
+		}
+		prefix := "  "
+		if isSelected {
+			prefix = "> "
+		}
+		indent := strings.Repeat("  ", row.depth)
+
+		if row.beadID != "" {
+			label := row.beadID
+			if issue, ok := m.issueMap[row.beadID]; ok {
+				label = fmt.Sprintf("%s %s %s", getStatusIcon(issue.Status), row.beadID, issue.Title)
+			}
+			sb.WriteString(rowStyle.Render(prefix + truncateRunesHelper(indent+label, m.width-4, "…")))
+			sb.WriteString("\n")
+			continue
+		}
+
+		n := row.node
+		marker := "  "
+		if n.IsDir() || len(n.File.BeadIDs) > 0 {
+			marker = "▸ "
+			if m.expanded[n.Path] {
+				marker = "▾ "
+			}
+		}
+		name := n.Name
+		if n.IsDir() {
+			name += "/"
+		}
+		name = truncateRunesHelper(indent+marker+name, nameWidth, "…")
+
+		heat := t.Renderer.NewStyle().Foreground(ThemeFg(riskHeatColor(n.RiskScore)))
+		var stats string
+		if n.IsDir() {
+			stats = fmt.Sprintf(" %5.2f %4s %4s %7d %4s", n.RiskScore, "", "", n.Churn, "")
+		} else {
+			f := n.File
+			stats = fmt.Sprintf(" %5.2f %4d %4d %7d %4d", f.RiskScore, f.BugBeads, f.Reopens, f.Churn(), len(f.CoupledFiles))
+		}
+		sb.WriteString(rowStyle.Render(prefix + padRight(name, nameWidth) + " "))
+		sb.WriteString(heat.Render(riskBar(n.RiskScore, 10)))
+		sb.WriteString(rowStyle.Render(stats))
+		sb.WriteString("\n")
+	}
+	if len(m.rows) > visibleRows {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  [%d-%d of %d]", m.scrollOffset+1, endIdx, len(m.rows))))
+		sb.WriteString("\n")
+	}
+
+	// Breakdown of the selected file
+	sb.WriteString("\n")
+	if row, ok := m.selectedRow(); ok {
+		n := row.node
+		if n.IsDir() {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("  %s/ • %d files • max risk %.2f (%s) • churn %d",
+				n.Path, n.Files, n.RiskScore, correlation.RiskLevel(n.RiskScore), n.Churn)))
+		} else {
+			f := n.File
+			c := f.Components
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("  %s • %s • bugs %.2f reopens %.2f churn %.2f coupling %.2f",
+				f.FilePath, f.RiskLevel, c.BugDensity, c.Reopens, c.Churn, c.Coupling)))
+			if len(f.CoupledFiles) > 0 {
+				sb.WriteString("\n")
+				sb.WriteString(dimStyle.Render(truncateRunesHelper("  changes with: "+strings.Join(f.CoupledFiles, ", "), m.width-2, "…")))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: navigate | ⏎: expand/collapse, open bead | ←/→: parent/child | esc: back"))
+	return sb.String()
+}
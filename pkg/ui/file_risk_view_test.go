@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func testFileRisk() (*correlation.FileRiskReport, []model.Issue) {
+	report := &correlation.FileRiskReport{
+		Stats: correlation.FileRiskStats{TotalFiles: 3, CriticalFiles: 1, HighRiskFiles: 1},
+		Files: []correlation.FileRisk{
+			{FilePath: "pkg/auth/token.go", RiskScore: 0.9, RiskLevel: "critical", BugBeads: 2, Reopens: 1,
+				Insertions: 120, Deletions: 30, CoupledFiles: []string{"pkg/auth/session.go"}, BeadIDs: []string{"bv-1", "bv-2"}},
+			{FilePath: "pkg/auth/session.go", RiskScore: 0.3, RiskLevel: "medium", BeadIDs: []string{"bv-1"}},
+			{FilePath: "docs/guide.md", RiskScore: 0.05, RiskLevel: "low", BeadIDs: []string{"bv-3"}},
+		},
+	}
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Token refresh race", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Expired sessions", Status: model.StatusClosed},
+		{ID: "bv-3", Title: "Document login", Status: model.StatusClosed},
+	}
+	return report, issues
+}
+
+func TestFileRiskModel_DrillDown(t *testing.T) {
+	m := NewFileRiskModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	report, issues := testFileRisk()
+	m.SetData(report, issues)
+
+	// The riskiest directory opens on its hotspot: pkg/auth, token.go, session.go, docs
+	if len(m.rows) != 4 || m.SelectedPath() != "pkg/auth" {
+		t.Fatalf("rows = %d, selected %q; want pkg/auth expanded", len(m.rows), m.SelectedPath())
+	}
+
+	m.MoveDown()
+	if m.SelectedPath() != "pkg/auth/token.go" {
+		t.Fatalf("selected %q, want token.go", m.SelectedPath())
+	}
+	m.Expand()
+	m.Expand() // Already open: moves onto the first bead
+	if m.SelectedBeadID() != "bv-1" || len(m.rows) != 6 {
+		t.Fatalf("selected bead %q with %d rows, want bv-1 of 6", m.SelectedBeadID(), len(m.rows))
+	}
+
+	m.Collapse() // From a bead: jump to its file
+	if m.SelectedPath() != "pkg/auth/token.go" || m.SelectedBeadID() != "" {
+		t.Errorf("collapse from bead selected %q", m.SelectedPath())
+	}
+	m.Collapse()
+	m.Collapse()
+	m.Collapse()
+	if m.SelectedPath() != "pkg/auth" || len(m.rows) != 2 {
+		t.Errorf("selected %q with %d rows, want pkg/auth collapsed", m.SelectedPath(), len(m.rows))
+	}
+
+	m.GoToEnd()
+	m.Toggle()
+	m.MoveDown()
+	if m.SelectedPath() != "docs/guide.md" {
+		t.Errorf("selected %q after opening docs", m.SelectedPath())
+	}
+}
+
+func TestFileRiskModel_View(t *testing.T) {
+	m := NewFileRiskModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	if !strings.Contains(m.View(), "No files linked") {
+		t.Error("empty view should explain missing data")
+	}
+
+	report, issues := testFileRisk()
+	m.SetData(report, issues)
+	m.SetSize(110, 30)
+	m.MoveDown()
+	m.Expand()
+	out := m.View()
+	for _, want := range []string{"File Risk Heatmap — 3 files • 1 critical", "▾ pkg/auth/", "token.go", "█████████░", "150", "Token refresh race", "changes with: pkg/auth/session.go", "docs/"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestModel_FileRiskKeyNeedsHistory(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	newM, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = newM.(Model)
+	if m.FocusState() == "file_risk" || !strings.Contains(m.statusMsg, "File risk") {
+		t.Errorf("focus %s status %q; the view needs git history first", m.FocusState(), m.statusMsg)
+	}
+}
@@ -0,0 +1,410 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// flowDimensions are the groupings the flow metrics view cycles through
+var flowDimensions = []string{
+	correlation.FlowDimensionLabel,
+	correlation.FlowDimensionType,
+	correlation.FlowDimensionAssignee,
+}
+
+// FlowMetricsModel shows lead/cycle time, throughput and aging WIP with a
+// cycle-time scatter chart for the selected group
+type FlowMetricsModel struct {
+	data         *correlation.FlowMetrics
+	issueMap     map[string]*model.Issue
+	dimension    int // Index into flowDimensions
+	cursor       int // 0 = overall, then groups of the current dimension
+	width        int
+	height       int
+	scrollOffset int
+	theme        Theme
+
+	cfd     *correlation.CumulativeFlow // Loaded in the background; nil until ready
+	cfdErr  error
+	showCFD bool
+}
+
+// NewFlowMetricsModel creates a new flow metrics view
+func NewFlowMetricsModel(theme Theme) FlowMetricsModel {
+	return FlowMetricsModel{
+		theme: theme,
+	}
+}
+
+// SetData updates the view with computed flow metrics
+func (m *FlowMetricsModel) SetData(data *correlation.FlowMetrics, issues []model.Issue) {
+	m.data = data
+	m.issueMap = make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		m.issueMap[issues[i].ID] = &issues[i]
+	}
+	if m.cursor >= len(m.rows()) {
+		m.cursor = 0
+		m.scrollOffset = 0
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *FlowMetricsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// rows returns the overall row followed by the current dimension's groups
+func (m *FlowMetricsModel) rows() []correlation.FlowGroupMetrics {
+	if m.data == nil {
+		return nil
+	}
+	rows := []correlation.FlowGroupMetrics{m.data.Overall}
+	switch flowDimensions[m.dimension] {
+	case correlation.FlowDimensionLabel:
+		rows = append(rows, m.data.ByLabel...)
+	case correlation.FlowDimensionType:
+		rows = append(rows, m.data.ByType...)
+	case correlation.FlowDimensionAssignee:
+		rows = append(rows, m.data.ByAssignee...)
+	}
+	return rows
+}
+
+// NextDimension cycles the grouping between label, type and assignee
+func (m *FlowMetricsModel) NextDimension() {
+	m.dimension = (m.dimension + 1) % len(flowDimensions)
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// Dimension returns the current grouping
+func (m *FlowMetricsModel) Dimension() string {
+	return flowDimensions[m.dimension]
+}
+
+// MoveUp moves cursor up
+func (m *FlowMetricsModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+		m.ensureVisible()
+	}
+}
+
+// MoveDown moves cursor down
+func (m *FlowMetricsModel) MoveDown() {
+	if m.cursor < len(m.rows())-1 {
+		m.cursor++
+		m.ensureVisible()
+	}
+}
+
+// GoToStart moves cursor to the overall row
+func (m *FlowMetricsModel) GoToStart() {
+	m.cursor = 0
+	m.scrollOffset = 0
+}
+
+// GoToEnd moves cursor to the last group
+func (m *FlowMetricsModel) GoToEnd() {
+	if n := len(m.rows()); n > 0 {
+		m.cursor = n - 1
+		m.ensureVisible()
+	}
+}
+
+// Selected returns the currently selected group
+func (m *FlowMetricsModel) Selected() *correlation.FlowGroupMetrics {
+	rows := m.rows()
+	if m.cursor >= len(rows) {
+		return nil
+	}
+	return &rows[m.cursor]
+}
+
+// ensureVisible adjusts scroll offset to keep cursor visible
+func (m *FlowMetricsModel) ensureVisible() {
+	visibleRows := m.visibleRowCount()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	} else if m.cursor >= m.scrollOffset+visibleRows {
+		m.scrollOffset = m.cursor - visibleRows + 1
+	}
+}
+
+// visibleRowCount returns how many group rows fit below the chart and
+// above the aging list
+func (m *FlowMetricsModel) visibleRowCount() int {
+	// Title, chart, table header and aging section
+	available := m.height - flowScatterHeight - 14
+	if available < 3 {
+		return 3
+	}
+	return available
+}
+
+// inGroup reports whether a bead belongs to a group of the current dimension
+func (m *FlowMetricsModel) inGroup(beadID string, g correlation.FlowGroupMetrics) bool {
+	if g.Dimension == correlation.FlowDimensionOverall {
+		return true
+	}
+	issue, ok := m.issueMap[beadID]
+	if !ok {
+		return false
+	}
+	switch g.Dimension {
+	case correlation.FlowDimensionLabel:
+		for _, l := range issue.Labels {
+			if l == g.Key {
+				return true
+			}
+		}
+	case correlation.FlowDimensionType:
+		return string(issue.IssueType) == g.Key
+	case correlation.FlowDimensionAssignee:
+		if issue.Assignee == "" {
+			return g.Key == correlation.FlowUnassigned
+		}
+		return issue.Assignee == g.Key
+	}
+	return false
+}
+
+// flowScatterHeight is the number of rows in the cycle-time scatter chart
+const flowScatterHeight = 6
+
+// renderFlowScatter plots samples by close date (x) and cycle time (y, lead
+// time when never claimed). Highlighted samples render as '●', the rest as
+// '·', and the threshold row is marked with a dotted rule.
+func renderFlowScatter(samples []correlation.FlowSample, highlight func(string) bool, threshold float64, width, height int) []string {
+	if width < 1 || height < 2 {
+		return nil
+	}
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", width))
+	}
+	if len(samples) == 0 {
+		return gridLines(grid)
+	}
+
+	days := func(s correlation.FlowSample) float64 {
+		if s.CycleDays != nil {
+			return *s.CycleDays
+		}
+		return s.LeadDays
+	}
+	first, last := samples[0].ClosedAt, samples[0].ClosedAt
+	maxDays := threshold
+	for _, s := range samples {
+		if s.ClosedAt.Before(first) {
+			first = s.ClosedAt
+		}
+		if s.ClosedAt.After(last) {
+			last = s.ClosedAt
+		}
+		maxDays = math.Max(maxDays, days(s))
+	}
+	if maxDays <= 0 {
+		maxDays = 1
+	}
+	rowOf := func(v float64) int {
+		return height - 1 - int(math.Round(v/maxDays*float64(height-1)))
+	}
+
+	if threshold > 0 {
+		r := rowOf(threshold)
+		for c := range grid[r] {
+			grid[r][c] = '┈'
+		}
+	}
+
+	span := last.Sub(first)
+	for _, s := range samples {
+		col := 0
+		if span > 0 {
+			col = int(math.Round(float64(s.ClosedAt.Sub(first)) / float64(span) * float64(width-1)))
+		}
+		r := rowOf(days(s))
+		switch {
+		case highlight(s.BeadID):
+			grid[r][col] = '●'
+		case grid[r][col] != '●':
+			grid[r][col] = '·'
+		}
+	}
+	return gridLines(grid)
+}
+
+func gridLines(grid [][]rune) []string {
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+	return lines
+}
+
+// throughputSparkline renders weekly closes, oldest first
+func throughputSparkline(weeks []correlation.WeeklyThroughput) string {
+	values := make([]int, len(weeks))
+	maxVal := 0
+	for i, w := range weeks {
+		values[i] = w.Closed
+		if w.Closed > maxVal {
+			maxVal = w.Closed
+		}
+	}
+	if maxVal == 0 {
+		return strings.Repeat(" ", len(values))
+	}
+	return buildSparkline(values, maxVal)
+}
+
+// View renders the flow metrics view
+func (m *FlowMetricsModel) View() string {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 30
+	}
+	if m.showCFD {
+		return m.renderCFD()
+	}
+
+	t := m.theme
+	var sb strings.Builder
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	headerStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	sepStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Flow Metrics — by %s", m.Dimension())))
+	sb.WriteString("\n\n")
+
+	if m.data == nil {
+		sb.WriteString(dimStyle.Render("  No flow data available (git history not loaded)"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	// Cycle-time scatter for the selected group
+	selected := m.Selected()
+	chartWidth := min(max(m.width-12, 10), 72)
+	highlight := func(id string) bool { return selected != nil && m.inGroup(id, *selected) }
+	chart := renderFlowScatter(m.data.Samples, highlight, m.data.AgingThresholdDays, chartWidth, flowScatterHeight)
+	maxDays := m.data.AgingThresholdDays
+	for _, s := range m.data.Samples {
+		if s.CycleDays != nil {
+			maxDays = math.Max(maxDays, *s.CycleDays)
+		} else {
+			maxDays = math.Max(maxDays, s.LeadDays)
+		}
+	}
+	pointStyle := t.Renderer.NewStyle().Foreground(ThemeFg("#88aaff"))
+	for i, line := range chart {
+		axis := "        "
+		switch i {
+		case 0:
+			axis = fmt.Sprintf("%6.1fd ", maxDays)
+		case len(chart) - 1:
+			axis = fmt.Sprintf("%6s ", "0d")
+		}
+		sb.WriteString(dimStyle.Render(axis) + "│" + pointStyle.Render(line))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(strings.Repeat(" ", 7) + "└" + strings.Repeat("─", chartWidth))
+	sb.WriteString("\n")
+	if selected != nil {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("        cycle days by close date • ● %s • ┈ P85 %.1fd", selected.Key, m.data.AgingThresholdDays)))
+	}
+	sb.WriteString("\n\n")
+
+	// Group table
+	keyWidth := 18
+	header := fmt.Sprintf("  %-*s %5s %4s %11s %11s %6s %5s %s",
+		keyWidth, strings.ToUpper(m.Dimension()[:1])+m.Dimension()[1:],
+		"Done", "WIP", "Lead 50/85", "Cycle 50/85", "Thr/wk", "Eff", "Throughput")
+	sb.WriteString(headerStyle.Render(header))
+	sb.WriteString("\n")
+	sb.WriteString(sepStyle.Render(strings.Repeat("─", min(len(header)+2, m.width-2))))
+	sb.WriteString("\n")
+
+	rows := m.rows()
+	visibleRows := m.visibleRowCount()
+	endIdx := min(m.scrollOffset+visibleRows, len(rows))
+	sparkStyle := t.Renderer.NewStyle().Foreground(ThemeFg("#88aaff"))
+	for i := m.scrollOffset; i < endIdx; i++ {
+		row := rows[i]
+		isSelected := i == m.cursor
+
+		rowStyle := t.Renderer.NewStyle()
+		if isSelected {
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true).Background(ThemeBg("#333"))
+		}
+		key := row.Key
+		if row.Dimension == correlation.FlowDimensionOverall {
+			key = "All beads"
+		}
+		if len(key) > keyWidth {
+			key = key[:keyWidth-1] + "…"
+		}
+		eff := "  -"
+		if row.FlowEfficiency != nil {
+			eff = fmt.Sprintf("%3.0f%%", *row.FlowEfficiency*100)
+		}
+		prefix := "  "
+		if isSelected {
+			prefix = "> "
+		}
+		rowText := fmt.Sprintf("%s%-*s %5d %4d %11s %11s %6.1f %5s ",
+			prefix, keyWidth, key, row.Completed, row.InProgress,
+			formatPercentilePair(row.LeadTime), formatPercentilePair(row.CycleTime),
+			row.ThroughputPerWeek, eff)
+		sb.WriteString(rowStyle.Render(rowText))
+		sb.WriteString(sparkStyle.Render(throughputSparkline(row.Throughput)))
+		sb.WriteString("\n")
+	}
+	if len(rows) > visibleRows {
+		sb.WriteString(dimStyle.Render(fmt.Sprintf("  [%d-%d of %d]", m.scrollOffset+1, endIdx, len(rows))))
+		sb.WriteString("\n")
+	}
+
+	// Aging WIP
+	sb.WriteString("\n")
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("Aging WIP (older than P85 cycle time %.1fd)", m.data.AgingThresholdDays)))
+	sb.WriteString("\n")
+	if len(m.data.AgingWIP) == 0 {
+		sb.WriteString(dimStyle.Render("  None"))
+		sb.WriteString("\n")
+	}
+	warnStyle := t.Renderer.NewStyle().Foreground(ThemeFg("#ffaa00"))
+	for i, item := range m.data.AgingWIP {
+		if i == 5 {
+			sb.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more", len(m.data.AgingWIP)-i)))
+			sb.WriteString("\n")
+			break
+		}
+		title := truncateRunesHelper(item.Title, max(m.width-40, 10), "…")
+		sb.WriteString(fmt.Sprintf("  %s %-12s %s", warnStyle.Render(fmt.Sprintf("%6.1fd", item.AgeDays)), item.BeadID, title))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimStyle.Render("j/k: navigate | tab: label/type/assignee | c: cumulative flow | esc: back"))
+	return sb.String()
+}
+
+// formatPercentilePair renders "p50/p85" days, or "-" without samples
+func formatPercentilePair(p correlation.FlowPercentiles) string {
+	if p.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f/%.1f", p.P50Days, p.P85Days)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func flowDays(v float64) *float64 { return &v }
+
+func testFlowMetrics() (*correlation.FlowMetrics, []model.Issue) {
+	now := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	eff := 0.8
+	group := func(dim, key string, done int) correlation.FlowGroupMetrics {
+		return correlation.FlowGroupMetrics{
+			Dimension: dim, Key: key, Completed: done,
+			CycleTime:      correlation.FlowPercentiles{Count: done, P50Days: 2, P85Days: 8},
+			Throughput:     []correlation.WeeklyThroughput{{Closed: 0}, {Closed: 2}, {Closed: 1}},
+			FlowEfficiency: &eff,
+		}
+	}
+	data := &correlation.FlowMetrics{
+		Overall:    group(correlation.FlowDimensionOverall, "all", 3),
+		ByLabel:    []correlation.FlowGroupMetrics{group(correlation.FlowDimensionLabel, "api", 2), group(correlation.FlowDimensionLabel, "ui", 1)},
+		ByType:     []correlation.FlowGroupMetrics{group(correlation.FlowDimensionType, "task", 3)},
+		ByAssignee: []correlation.FlowGroupMetrics{group(correlation.FlowDimensionAssignee, correlation.FlowUnassigned, 3)},
+		Samples: []correlation.FlowSample{
+			{BeadID: "a", ClosedAt: now.AddDate(0, 0, -20), CycleDays: flowDays(8)},
+			{BeadID: "b", ClosedAt: now.AddDate(0, 0, -10), CycleDays: flowDays(2)},
+			{BeadID: "c", ClosedAt: now, LeadDays: 1},
+		},
+		AgingThresholdDays: 8,
+		AgingWIP:           []correlation.AgingWIPItem{{BeadID: "w", Title: "Stuck migration", AgeDays: 12.5}},
+	}
+	issues := []model.Issue{
+		{ID: "a", Labels: []string{"api"}, IssueType: model.TypeTask},
+		{ID: "b", Labels: []string{"api"}, IssueType: model.TypeTask},
+		{ID: "c", Labels: []string{"ui"}, IssueType: model.TypeTask},
+	}
+	return data, issues
+}
+
+func TestRenderFlowScatter(t *testing.T) {
+	data, _ := testFlowMetrics()
+	lines := renderFlowScatter(data.Samples, func(id string) bool { return id == "a" }, 4, 5, 5)
+	want := []string{
+		"●    ",
+		"     ",
+		"┈┈┈┈┈",
+		"  · ·",
+		"     ",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("scatter =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestFlowMetricsModel_NavigationAndDimensions(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	data, issues := testFlowMetrics()
+	m.SetData(data, issues)
+
+	if m.Dimension() != correlation.FlowDimensionLabel || len(m.rows()) != 3 {
+		t.Fatalf("dimension %s with %d rows, want label with overall + 2", m.Dimension(), len(m.rows()))
+	}
+	m.MoveDown()
+	if sel := m.Selected(); sel.Key != "api" || !m.inGroup("a", *sel) || m.inGroup("c", *sel) {
+		t.Errorf("selected %+v should cover a but not c", sel)
+	}
+	m.GoToEnd()
+	m.MoveDown()
+	if m.Selected().Key != "ui" {
+		t.Errorf("selected = %s, want ui", m.Selected().Key)
+	}
+
+	m.NextDimension()
+	m.NextDimension()
+	if m.Dimension() != correlation.FlowDimensionAssignee || m.cursor != 0 {
+		t.Errorf("dimension = %s cursor %d, want assignee at 0", m.Dimension(), m.cursor)
+	}
+	m.MoveDown()
+	if !m.inGroup("a", *m.Selected()) {
+		t.Error("unassigned bead should belong to the unassigned group")
+	}
+}
+
+func TestFlowMetricsModel_View(t *testing.T) {
+	m := NewFlowMetricsModel(Theme{Renderer: lipgloss.DefaultRenderer()})
+	if !strings.Contains(m.View(), "No flow data") {
+		t.Error("empty view should explain missing data")
+	}
+
+	data, issues := testFlowMetrics()
+	m.SetData(data, issues)
+	m.SetSize(100, 40)
+	out := m.View()
+	for _, want := range []string{"Flow Metrics — by label", "All beads", "api", "2.0/8.0", "80%", "█▄", "Aging WIP", "12.5d", "Stuck migration"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+}
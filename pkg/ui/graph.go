@@ -7,6 +7,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,6 +17,7 @@ type GraphModel struct {
 	issues       []model.Issue
 	issueMap     map[string]*model.Issue
 	insights     *analysis.Insights
+	stats        *analysis.GraphStats // Metrics behind insights (the snapshot's analysis when set from one)
 	selectedIdx  int
 	scrollOffset int
 	width        int
@@ -28,6 +30,13 @@ type GraphModel struct {
 
 	// Flat list for navigation
 	sortedIDs []string
+	plainIDs  []string // sortedIDs before grouping into repo lanes
+
+	// Repo lanes (workspace mode): nodes grouped by repo with cross-repo links
+	workspace *workspace.Config
+	laneMode  bool
+	lanes     *workspace.Analysis
+	laneOf    map[string]string // issue ID -> lane (repo name)
 
 	// Precomputed rankings for all metrics (id -> rank, 1-indexed)
 	rankPageRank     map[string]int
@@ -45,12 +54,20 @@ func NewGraphModel(issues []model.Issue, insights *analysis.Insights, theme Them
 	g := GraphModel{
 		issues:   issues,
 		insights: insights,
+		stats:    insightsStats(insights),
 		theme:    theme,
 	}
 	g.rebuildGraph()
 	return g
 }
 
+func insightsStats(insights *analysis.Insights) *analysis.GraphStats {
+	if insights == nil {
+		return nil
+	}
+	return insights.Stats
+}
+
 // SetSnapshot updates the graph data from a pre-built DataSnapshot (bv-za8z).
 // This avoids rebuilding blockers/dependents and metric ranks on the UI thread.
 func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
@@ -67,6 +84,7 @@ func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
 	g.issues = snapshot.Issues
 	g.issueMap = snapshot.IssueMap
 	g.insights = &snapshot.Insights
+	g.stats = snapshot.Analysis
 
 	if g.issueMap == nil {
 		g.issueMap = make(map[string]*model.Issue, len(g.issues))
@@ -88,6 +106,8 @@ func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
 		g.rankCriticalPath = snapshot.GraphLayout.RankCriticalPath
 		g.rankInDegree = snapshot.GraphLayout.RankInDegree
 		g.rankOutDegree = snapshot.GraphLayout.RankOutDegree
+		g.plainIDs = g.sortedIDs
+		g.applyLanes()
 	} else {
 		g.rebuildGraph()
 	}
@@ -121,6 +141,7 @@ func (g *GraphModel) SetIssues(issues []model.Issue, insights *analysis.Insights
 
 	g.issues = issues
 	g.insights = insights
+	g.stats = insightsStats(insights)
 	g.rebuildGraph()
 
 	// Restore selection
@@ -184,6 +205,8 @@ func (g *GraphModel) rebuildGraph() {
 	} else {
 		sort.Strings(g.sortedIDs)
 	}
+	g.plainIDs = g.sortedIDs
+	g.applyLanes()
 
 	if g.selectedIdx >= len(g.sortedIDs) {
 		g.selectedIdx = 0
@@ -310,6 +333,12 @@ func (g *GraphModel) View(width, height int) string {
 	if width < 120 {
 		listWidth = 24
 	}
+	if g.RepoLanesActive() {
+		listWidth = 44
+		if width < 120 {
+			listWidth = 36
+		}
+	}
 	if width < 80 {
 		// Narrow: just show visual graph
 		return g.renderVisualGraph(selectedID, selectedIssue, width, height, t)
@@ -318,7 +347,12 @@ func (g *GraphModel) View(width, height int) string {
 	detailWidth := width - listWidth - 3
 
 	// Left: scrollable list of all nodes
-	listView := g.renderNodeList(listWidth, height-2, t)
+	var listView string
+	if g.RepoLanesActive() {
+		listView = g.renderRepoLanes(listWidth, height-2, t)
+	} else {
+		listView = g.renderNodeList(listWidth, height-2, t)
+	}
 
 	// Right: visual graph + metrics
 	graphView := g.renderVisualGraph(selectedID, selectedIssue, detailWidth, height-2, t)
@@ -447,7 +481,11 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 		Foreground(t.Secondary).
 		Italic(true)
 	sections = append(sections, "")
-	sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • g: back to list"))
+	hint := "j/k: navigate • enter: view details • g: back to list"
+	if g.HasRepoLanes() {
+		hint += " • W: repo lanes"
+	}
+	sections = append(sections, navStyle.Render(hint))
 
 	return strings.Join(sections, "\n")
 }
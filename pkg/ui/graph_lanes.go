@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// otherLane holds issues that match no enabled repo of the workspace
+const otherLane = "(other)"
+
+// SetWorkspace makes repo lanes available for a multi-repo workspace
+func (g *GraphModel) SetWorkspace(config *workspace.Config) {
+	g.workspace = config
+	if config == nil {
+		g.laneMode = false
+	}
+	g.applyLanes()
+}
+
+// HasRepoLanes returns whether the graph can group nodes into repo lanes
+func (g *GraphModel) HasRepoLanes() bool {
+	return g.workspace != nil
+}
+
+// RepoLanesActive returns whether nodes are currently grouped by repo
+func (g *GraphModel) RepoLanesActive() bool {
+	return g.laneMode && g.lanes != nil
+}
+
+// RepoLanes returns the workspace analysis behind the lanes, or nil when
+// lanes are off
+func (g *GraphModel) RepoLanes() *workspace.Analysis {
+	return g.lanes
+}
+
+// ToggleRepoLanes switches between the node list and repo lanes, keeping
+// the selected issue. It returns whether lanes are now on.
+func (g *GraphModel) ToggleRepoLanes() bool {
+	if g.workspace == nil {
+		return false
+	}
+	var selectedID string
+	if issue := g.SelectedIssue(); issue != nil {
+		selectedID = issue.ID
+	}
+	g.laneMode = !g.laneMode
+	g.scrollOffset = 0
+	g.applyLanes()
+	if selectedID != "" {
+		g.SelectByID(selectedID)
+	}
+	return g.laneMode
+}
+
+// applyLanes derives sortedIDs from plainIDs: unchanged without lanes, else
+// grouped by repo in workspace.yaml order, keeping the order within a repo
+func (g *GraphModel) applyLanes() {
+	if !g.laneMode || g.workspace == nil {
+		g.lanes, g.laneOf = nil, nil
+		if g.plainIDs != nil {
+			g.sortedIDs = g.plainIDs
+		}
+		return
+	}
+
+	// Lane health uses whatever metrics the view already has; a full graph
+	// analysis here would block the UI thread
+	opts := workspace.DefaultAnalysisOptions()
+	opts.Stats = g.stats
+	opts.SkipGraphMetrics = true
+	lanes := workspace.Analyze(g.issues, g.workspace, nil, opts)
+	g.lanes = &lanes
+
+	order := make(map[string]int, len(lanes.Flow.Repos))
+	for i, repo := range lanes.Flow.Repos {
+		order[repo] = i
+	}
+	g.laneOf = make(map[string]string, len(g.plainIDs))
+	for _, id := range g.plainIDs {
+		g.laneOf[id] = otherLane
+		if repo := g.workspace.RepoForID(id); repo != nil && repo.IsEnabled() {
+			g.laneOf[id] = repo.GetName()
+		}
+	}
+	laneIndex := func(id string) int {
+		if i, ok := order[g.laneOf[id]]; ok {
+			return i
+		}
+		return len(order)
+	}
+	ids := slices.Clone(g.plainIDs)
+	sort.SliceStable(ids, func(i, j int) bool {
+		return laneIndex(ids[i]) < laneIndex(ids[j])
+	})
+	g.sortedIDs = ids
+	if g.selectedIdx >= len(g.sortedIDs) {
+		g.selectedIdx = 0
+	}
+}
+
+// crossRepoLinks counts the blockers and dependents of id that sit in
+// another lane
+func (g *GraphModel) crossRepoLinks(id string) (in, out int) {
+	lane := g.laneOf[id]
+	for _, b := range g.blockers[id] {
+		if other, ok := g.laneOf[b]; ok && other != lane {
+			in++
+		}
+	}
+	for _, d := range g.dependents[id] {
+		if other, ok := g.laneOf[d]; ok && other != lane {
+			out++
+		}
+	}
+	return in, out
+}
+
+// laneRow is one line of the lanes panel; issue rows point into sortedIDs
+type laneRow struct {
+	text  string
+	style lipgloss.Style
+	index int // -1 for lane headers
+}
+
+// renderRepoLanes renders the left panel in repo-lane mode: a header per
+// repo with its health and neighbours, then its issues with cross-repo
+// blocker (⇠) and dependent (⇢) counts
+func (g *GraphModel) renderRepoLanes(width, height int, t Theme) string {
+	lanes := g.lanes
+	var lines []string
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Primary).
+		Width(width)
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🛣  Repo lanes (%d repos · %d cross)", lanes.Summary.RepoCount, lanes.Summary.CrossRepoDeps)))
+	lines = append(lines, strings.Repeat("─", width))
+
+	health := make(map[string]workspace.RepoHealth, len(lanes.Repos))
+	for _, repo := range lanes.Repos {
+		health[repo.Repo] = repo
+	}
+	dangling := make(map[string]bool, len(lanes.DanglingRefs))
+	for _, ref := range lanes.DanglingRefs {
+		dangling[ref.IssueID] = true
+	}
+
+	var rows []laneRow
+	selectedRow := 0
+	current := ""
+	for i, id := range g.sortedIDs {
+		issue := g.issueMap[id]
+		if issue == nil {
+			continue
+		}
+		if lane := g.laneOf[id]; i == 0 || lane != current {
+			current = lane
+			rows = append(rows, g.laneHeaderRows(lane, health, width, t)...)
+		}
+
+		in, out := g.crossRepoLinks(id)
+		var marks []string
+		if in > 0 {
+			marks = append(marks, fmt.Sprintf("⇠%d", in))
+		}
+		if out > 0 {
+			marks = append(marks, fmt.Sprintf("⇢%d", out))
+		}
+		if dangling[id] {
+			marks = append(marks, "⚠")
+		}
+		suffix := ""
+		if len(marks) > 0 {
+			suffix = " " + strings.Join(marks, " ")
+		}
+		maxIDLen := width - 5 - lipgloss.Width(suffix)
+		line := fmt.Sprintf(" %s %s%s", getStatusIcon(issue.Status), smartTruncateID(id, maxIDLen), suffix)
+
+		style := t.Renderer.NewStyle().Foreground(getStatusColor(issue.Status, t)).Width(width)
+		if i == g.selectedIdx {
+			style = t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Background(t.Highlight).Width(width)
+			selectedRow = len(rows)
+		}
+		rows = append(rows, laneRow{text: line, style: style, index: i})
+	}
+
+	var footer []string
+	if n := len(lanes.DanglingRefs); n > 0 {
+		refs := make([]string, 0, n)
+		for _, ref := range lanes.DanglingRefs {
+			refs = append(refs, ref.Reference)
+		}
+		footerStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Width(width)
+		text := truncateRunesHelper(fmt.Sprintf("⚠ %d dangling: %s", n, strings.Join(refs, ", ")), width, "…")
+		footer = append(footer, footerStyle.Render(text))
+	}
+
+	visibleRows := height - 4 - len(footer)
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	start := g.scrollOffset
+	if selectedRow < start {
+		start = selectedRow
+	} else if selectedRow >= start+visibleRows {
+		start = selectedRow - visibleRows + 1
+	}
+	// Keep the lane header in view when its first issue is selected
+	if start > 0 && start == selectedRow && rows[start-1].index < 0 {
+		start--
+	}
+	if start > len(rows)-visibleRows && len(rows) >= visibleRows {
+		start = len(rows) - visibleRows
+	}
+	if start < 0 {
+		start = 0
+	}
+	g.scrollOffset = start
+	end := start + visibleRows
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	for _, row := range rows[start:end] {
+		lines = append(lines, row.style.Render(row.text))
+	}
+	if len(rows) > visibleRows {
+		scrollStyle := t.Renderer.NewStyle().
+			Foreground(t.Secondary).
+			Italic(true).
+			Width(width).
+			Align(lipgloss.Center)
+		lines = append(lines, scrollStyle.Render(fmt.Sprintf("(%d-%d of %d rows)", start+1, end, len(rows))))
+	}
+	lines = append(lines, footer...)
+
+	return strings.Join(lines, "\n")
+}
+
+// laneHeaderRows renders a lane's title with its health, and the repos it
+// waits on (⇠) and holds up (⇢) when there are any
+func (g *GraphModel) laneHeaderRows(lane string, health map[string]workspace.RepoHealth, width int, t Theme) []laneRow {
+	h, ok := health[lane]
+	if !ok {
+		style := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary).Width(width)
+		return []laneRow{{text: "━ " + lane, style: style, index: -1}}
+	}
+
+	color := t.Blocked
+	switch h.HealthLevel {
+	case analysis.HealthLevelHealthy:
+		color = t.Open
+	case analysis.HealthLevelWarning:
+		color = t.Feature
+	}
+	title := fmt.Sprintf("━ %s  %d%% · %d open · %d blocked", lane, h.Health, h.OpenCount, h.BlockedCount)
+	rows := []laneRow{{
+		text:  truncateRunesHelper(title, width, "…"),
+		style: t.Renderer.NewStyle().Bold(true).Foreground(color).Width(width),
+		index: -1,
+	}}
+
+	var links []string
+	if len(h.UpstreamRepos) > 0 {
+		links = append(links, "⇠ "+strings.Join(h.UpstreamRepos, ","))
+	}
+	if len(h.DownstreamRepos) > 0 {
+		links = append(links, "⇢ "+strings.Join(h.DownstreamRepos, ","))
+	}
+	if len(links) > 0 {
+		rows = append(rows, laneRow{
+			text:  truncateRunesHelper("  "+strings.Join(links, "  "), width, "…"),
+			style: t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Width(width),
+			index: -1,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func laneTestData() ([]model.Issue, *workspace.Config) {
+	dep := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "web-UI-1", Title: "Login", Status: model.StatusOpen, Dependencies: dep("web-UI-1", "api-AUTH-1")},
+		{ID: "api-AUTH-2", Title: "Billing hook", Status: model.StatusOpen, Dependencies: dep("api-AUTH-2", "api-billing-7")},
+		{ID: "api-AUTH-1", Title: "Tokens", Status: model.StatusInProgress},
+		{ID: "lib-CORE-1", Title: "Shared", Status: model.StatusOpen},
+	}
+	config := &workspace.Config{Repos: []workspace.RepoConfig{
+		{Name: "api", Path: "api", Prefix: "api-"},
+		{Name: "web", Path: "web", Prefix: "web-"},
+		{Name: "lib", Path: "lib", Prefix: "lib-"},
+	}}
+	return issues, config
+}
+
+func TestGraphRepoLanes(t *testing.T) {
+	issues, config := laneTestData()
+	g := NewGraphModel(issues, nil, DefaultTheme(nil))
+	plain := slices.Clone(g.sortedIDs)
+
+	if g.ToggleRepoLanes() {
+		t.Fatal("lanes should need a workspace")
+	}
+	g.SetWorkspace(config)
+	if !g.SelectByID("web-UI-1") {
+		t.Fatal("select failed")
+	}
+	if !g.ToggleRepoLanes() || !g.RepoLanesActive() {
+		t.Fatal("lanes did not turn on")
+	}
+
+	want := []string{"api-AUTH-1", "api-AUTH-2", "web-UI-1", "lib-CORE-1"}
+	if !slices.Equal(g.sortedIDs, want) {
+		t.Errorf("lane order = %v, want %v", g.sortedIDs, want)
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "web-UI-1" {
+		t.Errorf("selection not kept: %v", sel)
+	}
+	if in, out := g.crossRepoLinks("api-AUTH-1"); in != 0 || out != 1 {
+		t.Errorf("cross-repo links of api-AUTH-1 = %d/%d", in, out)
+	}
+
+	view := g.View(140, 30)
+	for _, want := range []string{"Repo lanes (3 repos · 1 cross)", "━ api", "━ web", "⇢ web", "⇢1", "⇠1", "⚠ 1 dangling: billing-7", "W: repo lanes"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("lane view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Reloads keep the lanes; turning them off restores the plain order
+	g.SetIssues(issues, nil)
+	if !slices.Equal(g.sortedIDs, want) {
+		t.Errorf("lane order after reload = %v", g.sortedIDs)
+	}
+	if g.ToggleRepoLanes() || !slices.Equal(g.sortedIDs, plain) {
+		t.Errorf("plain order = %v, want %v", g.sortedIDs, plain)
+	}
+}
+
+func TestGraphRepoLanesUseViewStats(t *testing.T) {
+	issues, config := laneTestData()
+	g := NewGraphModel(issues, nil, DefaultTheme(nil))
+	g.SetWorkspace(config)
+	g.ToggleRepoLanes()
+	// No metrics yet: lanes render without running an analysis
+	for _, repo := range g.RepoLanes().Repos {
+		if repo.CriticalityScore != 0 {
+			t.Errorf("%s criticality = %d before metrics, want 0", repo.Repo, repo.CriticalityScore)
+		}
+	}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	ins := stats.GenerateInsights(len(issues))
+	g.SetIssues(issues, &ins)
+	if g.stats != ins.Stats || g.RepoLanes().Repos[0].CriticalityScore == 0 {
+		t.Errorf("lanes should score health from the view's metrics: %+v", g.RepoLanes().Repos[0])
+	}
+}
+
+func TestGraphRepoLanesKey(t *testing.T) {
+	issues, config := laneTestData()
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+
+	press("g")
+	press("W")
+	if m.graphView.RepoLanesActive() || !strings.Contains(m.statusMsg, "need a workspace") {
+		t.Fatalf("W outside workspace mode: lanes=%v status=%q", m.graphView.RepoLanesActive(), m.statusMsg)
+	}
+
+	m.EnableWorkspaceMode(WorkspaceInfo{Enabled: true, RepoCount: 3, RepoPrefixes: []string{"api-", "web-", "lib-"}, Config: config})
+	press("W")
+	if !m.graphView.RepoLanesActive() {
+		t.Fatal("W did not enable repo lanes")
+	}
+	if want := "Repo lanes: 3 repos • 1 cross-repo deps • 1 dangling refs"; m.statusMsg != want {
+		t.Errorf("status = %q, want %q", m.statusMsg, want)
+	}
+}
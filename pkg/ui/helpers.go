@@ -214,6 +214,13 @@ func getDepTypeIcon(depType string) string {
 		return "📦"
 	case "discovered-from":
 		return "🔍"
+	}
+	// Custom workflow types borrow the icon of the built-in type they act like
+	switch t := model.DependencyType(depType); {
+	case t.IsBlocking():
+		return "⛔"
+	case t.IsHierarchy():
+		return "📦"
 	default:
 		return "•"
 	}
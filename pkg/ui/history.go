@@ -158,6 +158,16 @@ type HistoryModel struct {
 	fileFilter      string          // Current file filter (empty = no filter)
 	fileTreeFocus   bool            // True when file tree has focus
 
+	// Blame pane state: annotates the selected commit's files line by line
+	showBlame    bool
+	blame        *correlation.BlameReport
+	blameErr     string
+	blameFiles   []string // Files changed by the commit the pane was opened on
+	blameFileIdx int
+	blameScroll  int
+	blameLoading bool // git blame is running for the current file
+	repoPath     string
+
 	// Cass session integration state (bv-pr1l)
 	sessionCache map[string][]cass.ScoredResult // Cached sessions per bead ID
 
@@ -1231,6 +1241,11 @@ func (h *HistoryModel) HasReport() bool {
 	return h.report != nil
 }
 
+// Report returns the loaded history report, or nil
+func (h *HistoryModel) Report() *correlation.HistoryReport {
+	return h.report
+}
+
 // determineLayout returns the appropriate layout based on terminal width (bv-xrfh)
 func (h *HistoryModel) determineLayout() historyLayout {
 	if h.width < layoutBreakpointStandard {
@@ -1298,6 +1313,9 @@ func (h *HistoryModel) renderTwoPaneView() string {
 		listPanel = h.renderListPanel(listWidth, h.height-2)
 		detailPanel = h.renderDetailPanel(detailWidth, h.height-2)
 	}
+	if h.showBlame {
+		detailPanel = h.renderBlamePanel(detailWidth, h.height-2)
+	}
 
 	// Combine panels
 	panels := lipgloss.JoinHorizontal(lipgloss.Top, listPanel, detailPanel)
@@ -1326,6 +1344,9 @@ func (h *HistoryModel) renderThreePaneView() string {
 		timelinePanel := h.renderTimelinePanel(timelineWidth, panelHeight)
 		middlePanel := h.renderCommitMiddlePanel(middleWidth, panelHeight)
 		detailPanel := h.renderDetailPanel(detailWidth, panelHeight)
+		if h.showBlame {
+			detailPanel = h.renderBlamePanel(detailWidth, panelHeight)
+		}
 
 		panels := lipgloss.JoinHorizontal(lipgloss.Top, listPanel, timelinePanel, middlePanel, detailPanel)
 		return lipgloss.JoinVertical(lipgloss.Left, header, panels)
@@ -1359,6 +1380,9 @@ func (h *HistoryModel) renderThreePaneView() string {
 		middlePanel = h.renderCommitMiddlePanel(middleWidth, panelHeight)
 		detailPanel = h.renderDetailPanel(detailWidth, panelHeight)
 	}
+	if h.showBlame {
+		detailPanel = h.renderBlamePanel(detailWidth, panelHeight)
+	}
 
 	// Combine panels
 	panels := lipgloss.JoinHorizontal(lipgloss.Top, listPanel, middlePanel, detailPanel)
@@ -2618,6 +2642,8 @@ func methodLabel(method correlation.CorrelationMethod) string {
 		return "(explicit ID)"
 	case correlation.MethodTemporalAuthor:
 		return "(temporal)"
+	case correlation.MethodHunkOwnership:
+		return "(shared)"
 	default:
 		return ""
 	}
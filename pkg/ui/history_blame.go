@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// blameGutterWidth is the width of the bead column left of each annotated line
+const blameGutterWidth = 12
+
+// BlameLoadedMsg is sent when git blame for a file in the blame pane completes
+type BlameLoadedMsg struct {
+	Path  string
+	Blame *correlation.BlameReport
+	Error error
+}
+
+// ToggleBlame opens the blame pane on the files changed by the selected
+// commit, or closes it when already open. repoPath is the git work tree the
+// files are blamed in. The returned command runs git blame in the background.
+func (h *HistoryModel) ToggleBlame(repoPath string) tea.Cmd {
+	if h.showBlame {
+		h.CloseBlame()
+		return nil
+	}
+	h.repoPath = repoPath
+	h.blameFiles = h.selectedCommitFiles()
+	h.blameFileIdx = 0
+	h.showBlame = true
+	return h.loadBlame()
+}
+
+// CloseBlame hides the blame pane
+func (h *HistoryModel) CloseBlame() {
+	h.showBlame = false
+	h.blame = nil
+	h.blameErr = ""
+	h.blameFiles = nil
+	h.blameLoading = false
+}
+
+// SetBlame installs a finished annotation. Results for a file the pane has
+// moved off since, or for a closed pane, are dropped and false is returned.
+func (h *HistoryModel) SetBlame(msg BlameLoadedMsg) bool {
+	if !h.showBlame || !h.blameLoading || msg.Path != h.BlameFile() {
+		return false
+	}
+	h.blameLoading = false
+	if msg.Error != nil {
+		h.blameErr = msg.Error.Error()
+		return true
+	}
+	h.blame = msg.Blame
+	return true
+}
+
+// IsBlameLoading reports whether git blame is still running for the pane
+func (h *HistoryModel) IsBlameLoading() bool {
+	return h.blameLoading
+}
+
+// IsBlameOpen returns whether the blame pane replaces the detail pane
+func (h *HistoryModel) IsBlameOpen() bool {
+	return h.showBlame
+}
+
+// BlameFile returns the file shown in the blame pane
+func (h *HistoryModel) BlameFile() string {
+	if h.blameFileIdx < len(h.blameFiles) {
+		return h.blameFiles[h.blameFileIdx]
+	}
+	return ""
+}
+
+// BlameReport returns the annotation shown in the blame pane, or nil
+func (h *HistoryModel) BlameReport() *correlation.BlameReport {
+	return h.blame
+}
+
+// NextBlameFile annotates the next file changed by the commit
+func (h *HistoryModel) NextBlameFile() tea.Cmd {
+	if h.blameFileIdx < len(h.blameFiles)-1 {
+		h.blameFileIdx++
+		return h.loadBlame()
+	}
+	return nil
+}
+
+// PrevBlameFile annotates the previous file changed by the commit
+func (h *HistoryModel) PrevBlameFile() tea.Cmd {
+	if h.blameFileIdx > 0 {
+		h.blameFileIdx--
+		return h.loadBlame()
+	}
+	return nil
+}
+
+// ScrollBlame moves the blame pane by delta lines
+func (h *HistoryModel) ScrollBlame(delta int) {
+	h.blameScroll = max(h.blameScroll+delta, 0)
+	if h.blame != nil {
+		h.blameScroll = min(h.blameScroll, max(h.blame.Stats.TotalLines-1, 0))
+	}
+}
+
+// selectedCommitFiles lists the files the selected commit changed that
+// still exist to be blamed, skipping deletions and the beads data itself
+func (h *HistoryModel) selectedCommitFiles() []string {
+	var changes []correlation.FileChange
+	if h.viewMode == historyModeGit {
+		if entry := h.SelectedGitCommit(); entry != nil && h.report != nil {
+			for _, id := range entry.BeadIDs {
+				for _, c := range h.report.Histories[id].Commits {
+					if c.SHA == entry.SHA {
+						changes = c.Files
+						break
+					}
+				}
+				if changes != nil {
+					break
+				}
+			}
+		}
+	} else if commit := h.SelectedCommit(); commit != nil {
+		changes = commit.Files
+	}
+
+	var files []string
+	for _, f := range changes {
+		if f.Action == "D" || strings.HasPrefix(f.Path, ".beads/") {
+			continue
+		}
+		files = append(files, f.Path)
+	}
+	return files
+}
+
+// loadBlame clears the pane and returns a command that annotates the current
+// file off the UI goroutine, or nil when there is nothing to annotate.
+func (h *HistoryModel) loadBlame() tea.Cmd {
+	h.blame = nil
+	h.blameErr = ""
+	h.blameScroll = 0
+	h.blameLoading = false
+	path := h.BlameFile()
+	if path == "" {
+		h.blameErr = "Selected commit changed no files that can be annotated"
+		return nil
+	}
+	if h.report == nil {
+		h.blameErr = "No history data loaded"
+		return nil
+	}
+	h.blameLoading = true
+	report, repoPath := h.report, h.repoPath
+	return func() tea.Msg {
+		blame, err := report.Blame(repoPath, correlation.BlameOptions{Path: path, IncludeText: true})
+		return BlameLoadedMsg{Path: path, Blame: blame, Error: err}
+	}
+}
+
+// renderBlamePanel renders the current file with the bead behind each line
+// in a gutter. Lines owned by the selected bead are highlighted.
+func (h *HistoryModel) renderBlamePanel(width, height int) string {
+	t := h.theme
+
+	panelStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Width(width - 2).
+		Height(height - 2)
+
+	innerWidth := max(width-4, 1)
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	hintStyle := mutedStyle.Italic(true)
+
+	var lines []string
+	header := "BLAME"
+	if file := h.BlameFile(); file != "" {
+		header = fmt.Sprintf("BLAME %s", file)
+		if len(h.blameFiles) > 1 {
+			header += fmt.Sprintf(" (%d/%d)", h.blameFileIdx+1, len(h.blameFiles))
+		}
+	}
+	lines = append(lines, headerStyle.Render(truncateRunesHelper(header, innerWidth, "…")))
+
+	if h.blame == nil {
+		msg := h.blameErr
+		switch {
+		case h.blameLoading:
+			msg = "Running git blame…"
+		case msg == "":
+			msg = "No annotation loaded"
+		}
+		lines = append(lines, strings.Repeat("─", innerWidth), mutedStyle.Width(innerWidth).Render(msg))
+		lines = append(lines, "", hintStyle.Render("A/esc: close"))
+		return panelStyle.Render(strings.Join(lines, "\n"))
+	}
+
+	s := h.blame.Stats
+	lines = append(lines, mutedStyle.Render(truncateRunesHelper(
+		fmt.Sprintf("%d lines • %.0f%% attributed • %d beads • %d commits", s.TotalLines, s.Coverage*100, s.Beads, s.Commits),
+		innerWidth, "…")))
+	lines = append(lines, strings.Repeat("─", innerWidth))
+
+	selectedID := h.SelectedBeadID()
+	if h.viewMode == historyModeGit {
+		selectedID = h.SelectedRelatedBeadID()
+	}
+
+	// Flatten ranges so scrolling is by source line
+	type annotated struct {
+		r     *correlation.BlameRange
+		line  int
+		text  string
+		first bool
+	}
+	var rows []annotated
+	for i := range h.blame.Ranges {
+		r := &h.blame.Ranges[i]
+		for j, text := range r.Text {
+			rows = append(rows, annotated{r: r, line: r.StartLine + j, text: text, first: j == 0})
+		}
+	}
+
+	visible := max(height-2-3-2, 1) // border, header lines, footer
+	start := min(h.blameScroll, max(len(rows)-1, 0))
+	numWidth := len(fmt.Sprintf("%d", h.blame.EndLine))
+	textWidth := max(innerWidth-blameGutterWidth-numWidth-2, 1)
+	for i := start; i < len(rows) && i < start+visible; i++ {
+		row := rows[i]
+		gutter := "│"
+		gutterStyle := mutedStyle
+		if row.first {
+			switch {
+			case row.r.Uncommitted:
+				gutter = "uncommitted"
+			case len(row.r.Beads) > 0:
+				b := row.r.Beads[0]
+				gutter = b.BeadID
+				if len(row.r.Beads) > 1 {
+					gutter += fmt.Sprintf("+%d", len(row.r.Beads)-1)
+				}
+				gutterStyle = t.Renderer.NewStyle().Foreground(t.GetStatusColor(b.Status))
+			default:
+				gutter = row.r.ShortSHA
+			}
+		}
+		gutter = padRight(truncateRunesHelper(gutter, blameGutterWidth-1, "…"), blameGutterWidth)
+
+		textStyle := t.Renderer.NewStyle()
+		if selectedID != "" && blameRangeHasBead(row.r, selectedID) {
+			textStyle = textStyle.Foreground(t.Primary).Bold(true)
+		}
+		code := strings.ReplaceAll(row.text, "\t", "    ")
+		lines = append(lines, gutterStyle.Render(gutter)+
+			mutedStyle.Render(fmt.Sprintf("%*d ", numWidth, row.line))+" "+
+			textStyle.Render(truncateRunesHelper(code, textWidth, "…")))
+	}
+
+	for len(lines) < height-2-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, hintStyle.Render(truncateRunesHelper("j/k:scroll  J/K:file  A/esc:close", innerWidth, "…")))
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+func blameRangeHasBead(r *correlation.BlameRange, beadID string) bool {
+	for _, b := range r.Beads {
+		if b.BeadID == beadID {
+			return true
+		}
+	}
+	return false
+}
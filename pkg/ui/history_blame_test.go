@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// finishBlame runs a blame command the way the program would and delivers
+// its result to h
+func finishBlame(t *testing.T, h *HistoryModel, cmd tea.Cmd) {
+	t.Helper()
+	if cmd == nil || !h.IsBlameLoading() {
+		t.Fatalf("expected a pending blame command (loading=%v)", h.IsBlameLoading())
+	}
+	if !h.SetBlame(cmd().(BlameLoadedMsg)) {
+		t.Fatal("blame result for the current file was dropped")
+	}
+}
+
+// blameRepoFixture commits two files in one commit linked to bv-1 and
+// returns the repo and a report correlating that commit
+func blameRepoFixture(t *testing.T) (string, *correlation.HistoryReport) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	for name, content := range map[string]string{
+		"auth.go":       "package auth\n\nfunc Login() {}\n",
+		"auth_test.go":  "package auth\n",
+		".beads/x.json": "{}\n",
+	} {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init")
+	git("add", ".")
+	git("commit", "-m", "bv-1: login")
+	sha := git("rev-parse", "HEAD")
+
+	report := &correlation.HistoryReport{
+		Histories: map[string]correlation.BeadHistory{
+			"bv-1": {BeadID: "bv-1", Title: "Login", Status: "open", Commits: []correlation.CorrelatedCommit{{
+				SHA: sha, ShortSHA: sha[:7], Message: "bv-1: login", Author: "Test", Timestamp: time.Now(),
+				Method: correlation.MethodExplicitID, Confidence: 0.95,
+				Files: []correlation.FileChange{
+					{Path: "auth.go", Action: "A"},
+					{Path: "auth_test.go", Action: "A"},
+					{Path: ".beads/x.json", Action: "A"},
+					{Path: "gone.go", Action: "D"},
+				},
+			}}},
+		},
+		CommitIndex: correlation.CommitIndex{sha: {"bv-1"}},
+	}
+	return repo, report
+}
+
+func TestHistoryModel_BlamePane(t *testing.T) {
+	repo, report := blameRepoFixture(t)
+	h := NewHistoryModel(report, testTheme())
+	h.SetSize(120, 30)
+
+	cmd := h.ToggleBlame(repo)
+	if !h.IsBlameOpen() || h.BlameFile() != "auth.go" {
+		t.Fatalf("blame open=%v file=%q, want auth.go", h.IsBlameOpen(), h.BlameFile())
+	}
+	if len(h.blameFiles) != 2 {
+		t.Errorf("files = %v, want deletions and .beads skipped", h.blameFiles)
+	}
+	if h.BlameReport() != nil || !strings.Contains(h.View(), "Running git blame") {
+		t.Errorf("pane should show a loading state until git blame finishes:\n%s", h.View())
+	}
+	finishBlame(t, &h, cmd)
+	blame := h.BlameReport()
+	if blame == nil || blame.Stats.TotalLines != 3 || blame.Stats.AttributedLines != 3 {
+		t.Fatalf("blame = %+v, err %q", blame, h.blameErr)
+	}
+
+	view := h.View()
+	for _, want := range []string{"BLAME auth.go (1/2)", "bv-1", "func Login() {}", "100% attributed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	stale := h.PrevBlameFile() // Already on the first file
+	if stale != nil {
+		t.Error("no command expected when there is no previous file")
+	}
+	first := h.loadBlame()
+	next := h.NextBlameFile()
+	if h.SetBlame(first().(BlameLoadedMsg)) {
+		t.Error("result for a file the pane moved off should be dropped")
+	}
+	finishBlame(t, &h, next)
+	if h.BlameFile() != "auth_test.go" || h.BlameReport().Stats.TotalLines != 1 {
+		t.Errorf("next file = %q", h.BlameFile())
+	}
+	if h.NextBlameFile() != nil || h.BlameFile() != "auth_test.go" { // Already on the last file
+		t.Errorf("moved past last file: %q", h.BlameFile())
+	}
+
+	if h.ToggleBlame(repo) != nil {
+		t.Error("closing the pane should not start git blame")
+	}
+	if h.IsBlameOpen() || h.BlameReport() != nil {
+		t.Error("second toggle should close the pane")
+	}
+	if strings.Contains(h.View(), "BLAME") {
+		t.Error("closed pane still rendered")
+	}
+}
+
+func TestHistoryModel_BlamePaneWithoutFiles(t *testing.T) {
+	h := NewHistoryModel(createTestHistoryReport(), testTheme())
+	h.SetSize(200, 30)
+	if h.ToggleBlame(t.TempDir()) != nil {
+		t.Error("nothing to annotate, so no command expected")
+	}
+	if !h.IsBlameOpen() || h.BlameReport() != nil {
+		t.Fatal("pane should open with an explanation when the commit has no files")
+	}
+	if !strings.Contains(h.View(), "changed no files") {
+		t.Errorf("view should explain the empty pane:\n%s", h.View())
+	}
+}
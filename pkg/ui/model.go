@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/edit"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
@@ -26,6 +28,7 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
@@ -68,6 +71,10 @@ const (
 	focusTutorial    // Interactive tutorial (bv-8y31)
 	focusCassModal   // Cass session preview modal (bv-5bqh)
 	focusUpdateModal // Self-update modal (bv-182)
+	focusEditModal   // Write-back edit modal
+	focusCycleFix    // Cycle-breaking assistant modal
+	focusFlowMetrics // Lead/cycle time and aging WIP view
+	focusFileRisk    // Per-file risk heatmap tree
 )
 
 // SortMode represents the current list sorting mode (bv-3ita)
@@ -133,6 +140,11 @@ func WaitForPhase2Cmd(stats *analysis.GraphStats) tea.Cmd {
 // FileChangedMsg is sent when the beads file changes on disk
 type FileChangedMsg struct{}
 
+// RemoteIssuesMsg carries a refreshed issue set from a daemon poller (--beads-url)
+type RemoteIssuesMsg struct {
+	Issues []model.Issue
+}
+
 // semanticDebounceTickMsg is sent after debounce delay to trigger semantic computation
 type semanticDebounceTickMsg struct{}
 
@@ -180,6 +192,18 @@ func WatchFileCmd(w *watcher.Watcher) tea.Cmd {
 	}
 }
 
+// WatchRemoteCmd returns a command that waits for the next daemon update and
+// sends RemoteIssuesMsg
+func WatchRemoteCmd(updates <-chan []model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		issues, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return RemoteIssuesMsg{Issues: issues}
+	}
+}
+
 // StartBackgroundWorkerCmd starts the background worker and triggers an initial refresh.
 func StartBackgroundWorkerCmd(w *BackgroundWorker) tea.Cmd {
 	return func() tea.Msg {
@@ -261,42 +285,44 @@ func CheckAgentFileCmd(workDir string) tea.Cmd {
 	}
 }
 
-// LoadHistoryCmd returns a command that loads history data in the background
-func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
-	return func() tea.Msg {
-		var repoPath string
-		var err error
-
-		if beadsPath != "" {
-			// If beadsPath is provided (single-repo mode), derive repo root from it.
-			// Try to resolve absolute path first.
-			if absPath, e := filepath.Abs(beadsPath); e == nil {
-				dir := filepath.Dir(absPath)
-				// Standard layout: <repo_root>/.beads/<file.jsonl>
-				if filepath.Base(dir) == ".beads" {
-					repoPath = filepath.Dir(dir)
-				} else {
-					// Legacy/Flat layout: <repo_root>/<file.jsonl>
-					repoPath = dir
-				}
+// historyRepoPath derives the git repo root from the beads file path,
+// falling back to the working directory (workspace mode)
+func historyRepoPath(beadsPath string) (string, error) {
+	if beadsPath != "" {
+		// If beadsPath is provided (single-repo mode), derive repo root from it.
+		// Try to resolve absolute path first.
+		if absPath, e := filepath.Abs(beadsPath); e == nil {
+			dir := filepath.Dir(absPath)
+			// Standard layout: <repo_root>/.beads/<file.jsonl>
+			if filepath.Base(dir) == ".beads" {
+				return filepath.Dir(dir), nil
 			}
+			// Legacy/Flat layout: <repo_root>/<file.jsonl>
+			return dir, nil
 		}
+	}
 
-		// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
-		if repoPath == "" {
-			repoPath, err = os.Getwd()
-			if err != nil {
-				return HistoryLoadedMsg{Error: err}
-			}
+	// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
+	return os.Getwd()
+}
+
+// LoadHistoryCmd returns a command that loads history data in the background
+func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return HistoryLoadedMsg{Error: err}
 		}
 
 		// Convert model.Issue to correlation.BeadInfo
 		beads := make([]correlation.BeadInfo, len(issues))
 		for i, issue := range issues {
 			beads[i] = correlation.BeadInfo{
-				ID:     issue.ID,
-				Title:  issue.Title,
-				Status: string(issue.Status),
+				ID:                 issue.ID,
+				Title:              issue.Title,
+				Status:             string(issue.Status),
+				Description:        issue.Description,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
 			}
 		}
 
@@ -310,6 +336,31 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 	}
 }
 
+// CumulativeFlowLoadedMsg is sent when the cumulative flow replay completes
+type CumulativeFlowLoadedMsg struct {
+	Flow  *correlation.CumulativeFlow
+	Error error
+}
+
+// LoadCumulativeFlowCmd replays the beads file history over the default
+// window in the background
+func LoadCumulativeFlowCmd(beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return CumulativeFlowLoadedMsg{Error: err}
+		}
+		var extractor *correlation.Extractor
+		if beadsPath != "" {
+			extractor = correlation.NewExtractor(repoPath, beadsPath)
+		} else {
+			extractor = correlation.NewExtractor(repoPath)
+		}
+		flow, err := extractor.CumulativeFlow(correlation.CFDOptions{})
+		return CumulativeFlowLoadedMsg{Flow: flow, Error: err}
+	}
+}
+
 func cloneIssuesForAsync(issues []model.Issue) []model.Issue {
 	if len(issues) == 0 {
 		return nil
@@ -329,9 +380,11 @@ type Model struct {
 	issueMap     map[string]*model.Issue
 	analyzer     *analysis.Analyzer
 	analysis     *analysis.GraphStats
-	beadsPath    string           // Path to beads.jsonl for reloading
-	watcher      *watcher.Watcher // File watcher for live reload
-	instanceLock *instance.Lock   // Multi-instance coordination lock
+	beadsPath    string               // Path to beads.jsonl for reloading
+	watcher      *watcher.Watcher     // File watcher for live reload
+	remote       <-chan []model.Issue // Daemon poller updates (--beads-url live reload)
+	onReload     func([]model.Issue)  // Called with each reloaded issue set (lifecycle hooks)
+	instanceLock *instance.Lock       // Multi-instance coordination lock
 
 	// Background Worker (Phase 2 architecture - bv-m7v8)
 	// snapshot is the current immutable data snapshot from BackgroundWorker.
@@ -357,7 +410,9 @@ type Model struct {
 	graphView          GraphModel
 	tree               TreeModel // Hierarchical tree view (bv-gllx)
 	insightsPanel      InsightsModel
-	flowMatrix         FlowMatrixModel // Cross-label flow matrix
+	flowMatrix         FlowMatrixModel  // Cross-label flow matrix
+	flowMetrics        FlowMetricsModel // Lead/cycle time, throughput and aging WIP
+	fileRisk           FileRiskModel    // Per-file risk heatmap
 	theme              Theme
 
 	// Update State
@@ -407,9 +462,12 @@ type Model struct {
 
 	// Filter and sort state
 	currentFilter          string
-	sortMode               SortMode // bv-3ita: current sort mode
+	queryFilter            *QueryFilter // query expressions in the / filter bar and currentFilter
+	sortMode               SortMode     // bv-3ita: current sort mode
 	semanticSearchEnabled  bool
 	semanticIndexBuilding  bool
+	semanticIndexFailed    bool // last build failed; only ctrl+s retries
+	semanticAutoIndex      bool // local hash embedder, so Similar Beads may build the index unprompted
 	semanticSearch         *SemanticSearch
 	semanticHybridEnabled  bool
 	semanticHybridPreset   search.PresetName
@@ -434,6 +492,10 @@ type Model struct {
 	quickWinSet   map[string]bool                   // issueID -> true if quick win
 	blockerSet    map[string]bool                   // issueID -> true if significant blocker
 
+	// Triage scoring profiles (.bv/scoring.yaml); nil profile = built-in weights
+	scoringConfig  *analysis.ScoringConfig
+	scoringProfile *analysis.ScoringProfile
+
 	// Recipe picker
 	showRecipePicker bool
 	recipePicker     RecipePickerModel
@@ -461,8 +523,9 @@ type Model struct {
 	showTimeTravelPrompt bool
 
 	// Status message (for temporary feedback)
-	statusMsg     string
-	statusIsError bool
+	statusMsg          string
+	statusIsError      bool
+	statusIsQueryError bool // statusMsg is a filter bar query error
 
 	// Workspace mode state
 	workspaceMode    bool            // True when viewing multiple repos
@@ -485,6 +548,12 @@ type Model struct {
 	isSprintView   bool
 	sprintViewText string
 
+	// Monte Carlo forecast of the selected sprint, cached by sprint ID and
+	// cleared when the issues change
+	sprintForecast    *analysis.MonteCarloForecast
+	sprintForecastErr error
+	sprintForecastFor string
+
 	// AGENTS.md integration (bv-i8dk)
 	showAgentPrompt  bool
 	agentPromptModal AgentPromptModal
@@ -502,6 +571,18 @@ type Model struct {
 	// Self-update modal (bv-182)
 	showUpdateModal bool
 	updateModal     UpdateModal
+
+	// Write-back edit mode: modal, persistence backend and undo stack
+	showEditModal   bool
+	editModal       EditModal
+	editWriter      edit.Writer // nil when the data source is read-only
+	editHistory     *edit.History
+	focusBeforeEdit focus
+
+	// Cycle-breaking assistant modal
+	showCycleFixModal   bool
+	cycleFixModal       CycleFixModal
+	focusBeforeCycleFix focus
 }
 
 // labelCount is a simple label->count pair for display
@@ -597,6 +678,7 @@ type WorkspaceInfo struct {
 	FailedCount  int
 	TotalIssues  int
 	RepoPrefixes []string
+	Config       *workspace.Config // Enables repo lanes in the graph view
 }
 
 func (m *Model) updateSemanticIDs(items []list.Item) {
@@ -616,6 +698,47 @@ func (m *Model) updateSemanticIDs(items []list.Item) {
 	m.semanticSearch.SetDocs(docs)
 }
 
+// updateQueryFilterItems keeps the / filter bar's query evaluation aligned
+// with the list items.
+func (m *Model) updateQueryFilterItems(items []list.Item) {
+	if m.queryFilter == nil {
+		return
+	}
+	m.queryFilter.SetItems(items, m.issues, m.analysis)
+}
+
+// setListFilter installs the list's filter function, layering query
+// expressions over the fuzzy or semantic matcher.
+func (m *Model) setListFilter(fn list.FilterFunc) {
+	if m.queryFilter != nil {
+		fn = m.queryFilter.Wrap(fn)
+	}
+	m.list.Filter = fn
+}
+
+// updateQueryTermStatus reports query syntax errors while the user types in
+// the filter bar, and clears the message once the term parses again.
+func (m *Model) updateQueryTermStatus(term string) {
+	if err := queryTermError(term); err != nil {
+		m.statusMsg = err.Error()
+		m.statusIsError = true
+		m.statusIsQueryError = true
+	} else if m.statusIsQueryError {
+		m.statusMsg = ""
+		m.statusIsError = false
+		m.statusIsQueryError = false
+	}
+}
+
+// matchesFilterQuery evaluates a currentFilter that is not one of the
+// built-in names as a query expression.
+func (m *Model) matchesFilterQuery(issue model.Issue) bool {
+	if m.queryFilter == nil {
+		return false
+	}
+	return m.queryFilter.MatchExpr(m.currentFilter, &issue, m.issues, m.analysis)
+}
+
 func (m *Model) shouldShowSearchScores() bool {
 	if !m.semanticSearchEnabled || !m.semanticHybridEnabled || m.semanticSearch == nil {
 		return false
@@ -821,6 +944,9 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	l.SetShowPagination(false)
 	l.SetFilteringEnabled(true)
 	l.DisableQuitKeybindings()
+	queryFilter := NewQueryFilter()
+	queryFilter.SetItems(items, issues, graphStats)
+	l.Filter = queryFilter.Wrap(list.DefaultFilter)
 	// Clear all default styles that might add extra lines
 	l.Styles.Title = lipgloss.NewStyle()
 	l.Styles.TitleBar = lipgloss.NewStyle()
@@ -1015,6 +1141,8 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		analysis:               graphStats,
 		beadsPath:              beadsPath,
 		watcher:                fileWatcher,
+		editWriter:             edit.DetectWriter(beadsPath),
+		editHistory:            edit.NewHistory(0),
 		snapshotInitPending:    backgroundWorker != nil,
 		backgroundWorker:       backgroundWorker,
 		instanceLock:           instLock,
@@ -1030,7 +1158,9 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		insightsPanel:          insightsPanel,
 		theme:                  theme,
 		currentFilter:          "all",
+		queryFilter:            queryFilter,
 		semanticSearch:         semanticSearch,
+		semanticAutoIndex:      search.EmbeddingConfigFromEnv().Provider == search.ProviderHash,
 		semanticHybridEnabled:  false,
 		semanticHybridPreset:   search.PresetDefault,
 		semanticHybridBuilding: false,
@@ -1099,6 +1229,9 @@ func (m Model) Init() tea.Cmd {
 	} else if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
 	}
+	if m.remote != nil {
+		cmds = append(cmds, WatchRemoteCmd(m.remote))
+	}
 	// Start loading history in background
 	if len(m.issues) > 0 {
 		cmds = append(cmds, LoadHistoryCmd(m.issuesForAsync(), m.beadsPath))
@@ -1110,7 +1243,20 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// Update handles msg, then builds the semantic index if the detail pane is
+// showing without it, so Similar Beads appears however the pane was opened
+// (hash embedder only; other backends wait for ctrl+s).
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		if build := nm.similarBeadsIndexCmd(); build != nil {
+			return nm, tea.Batch(cmd, build)
+		}
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -1157,10 +1303,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case SemanticIndexReadyMsg:
 		m.semanticIndexBuilding = false
+		m.semanticIndexFailed = msg.Error != nil
 		if msg.Error != nil {
 			// If indexing fails, revert to fuzzy mode for predictable behavior.
 			m.semanticSearchEnabled = false
-			m.list.Filter = list.DefaultFilter
+			m.setListFilter(list.DefaultFilter)
 			m.statusMsg = fmt.Sprintf("Semantic search unavailable: %v", msg.Error)
 			m.statusIsError = true
 			break
@@ -1177,6 +1324,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.statusIsError = false
 
+		// Refresh detail pane so it picks up similar beads
+		if m.isSplitView || m.showDetails {
+			m.updateViewportContent()
+		}
+
 		// Refresh current filter view if the user is actively searching.
 		if m.semanticSearchEnabled && m.list.FilterState() != list.Unfiltered {
 			prevState := m.list.FilterState()
@@ -1268,7 +1420,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Phase 2 analysis complete - update insights with full data (computed off-thread).
 		ins := msg.Insights
-		if m.snapshot != nil {
+		if m.snapshot != nil && !m.snapshot.insightsComplete {
 			m.snapshot.Insights = ins
 		}
 		m.insightsPanel.SetInsights(ins)
@@ -1287,44 +1439,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.graphView.SetIssues(m.issues, &ins)
 		}
 
-		// Generate triage for priority panel (bv-91) - reuse existing analyzer/stats (bv-runn.12)
-		triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
-		triageScores := make(map[string]float64, len(triage.Recommendations))
-		triageReasons := make(map[string]analysis.TriageReasons, len(triage.Recommendations))
-		quickWinSet := make(map[string]bool, len(triage.QuickWins))
-		blockerSet := make(map[string]bool, len(triage.BlockersToClear))
-		unblocksMap := make(map[string][]string, len(triage.Recommendations))
-
-		for _, rec := range triage.Recommendations {
-			triageScores[rec.ID] = rec.Score
-			if len(rec.Reasons) > 0 {
-				triageReasons[rec.ID] = analysis.TriageReasons{
-					Primary:    rec.Reasons[0],
-					All:        rec.Reasons,
-					ActionHint: rec.Action,
-				}
-			}
-			unblocksMap[rec.ID] = rec.UnblocksIDs
-		}
-		for _, qw := range triage.QuickWins {
-			quickWinSet[qw.ID] = true
-		}
-		for _, bl := range triage.BlockersToClear {
-			blockerSet[bl.ID] = true
+		// Generate triage for priority panel (bv-91) - reuse existing analyzer/stats (bv-runn.12),
+		// or the snapshot's own triage when it was built from these complete metrics
+		if m.snapshot != nil && m.snapshot.Analysis == msg.Stats && m.snapshot.phase2Triage != nil {
+			m.applyTriage(*m.snapshot.phase2Triage)
+		} else {
+			m.applyTriage(analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now()))
 		}
 
-		m.triageScores = triageScores
-		m.triageReasons = triageReasons
-		m.quickWinSet = quickWinSet
-		m.blockerSet = blockerSet
-		m.unblocksMap = unblocksMap
-
-		m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
-
-		// Set full recommendations with breakdown for priority radar (bv-93)
-		dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
-		m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
-
 		// Generate priority recommendations now that Phase 2 is ready
 		recommendations := m.analyzer.GenerateRecommendations()
 		m.priorityHints = make(map[string]*analysis.PriorityRecommendation, len(recommendations))
@@ -1388,7 +1510,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Re-apply recipe filter if active (to update scores while preserving filter)
+		// Re-apply recipe filter if active (to update scores while preserving filter,
+		// and to evaluate where terms that reference Phase 2 metrics).
 		// Otherwise, update list respecting current filter (open/ready/etc.)
 		if m.activeRecipe != nil {
 			m.applyRecipe(m.activeRecipe)
@@ -1438,6 +1561,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case CumulativeFlowLoadedMsg:
+		m.flowMetrics.SetCumulativeFlow(msg.Flow, msg.Error)
+
+	case BlameLoadedMsg:
+		if m.historyView.SetBlame(msg) {
+			if blame := m.historyView.BlameReport(); blame != nil {
+				m.statusMsg = fmt.Sprintf("Blame: %s (%d lines, %d beads)", blame.FilePath, blame.Stats.TotalLines, blame.Stats.Beads)
+				m.statusIsError = false
+			}
+		}
+
 	case AgentFileCheckMsg:
 		// AGENTS.md integration check (bv-i8dk)
 		if msg.ShouldPrompt && msg.FilePath != "" {
@@ -1509,6 +1643,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Eventually these will be removed when all code reads from snapshot
 		m.issues = msg.Snapshot.Issues
 		m.issueMap = msg.Snapshot.IssueMap
+		m.sprintForecastFor = ""
 		m.analyzer = msg.Snapshot.Analyzer
 		m.analysis = msg.Snapshot.Analysis
 		m.countOpen = msg.Snapshot.CountOpen
@@ -1563,8 +1698,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update list/board/graph views while preserving the current recipe/filter state.
 		if m.activeRecipe != nil {
-			// If the snapshot already includes recipe filtering/sorting, use it directly (bv-cwwd).
-			if msg.Snapshot.RecipeName == m.activeRecipe.Name && msg.Snapshot.RecipeHash == recipeFingerprint(m.activeRecipe) {
+			switch {
+			case msg.Snapshot.recipePending && !firstSnapshot && m.currentFilter == "recipe:"+m.activeRecipe.Name:
+				// The recipe's where expression needs Phase 2 metrics, which would
+				// match nothing yet. Keep the current result on screen until
+				// Phase2ReadyMsg re-applies the recipe.
+			case msg.Snapshot.RecipeName == m.activeRecipe.Name && msg.Snapshot.RecipeHash == recipeFingerprint(m.activeRecipe):
+				// The snapshot already includes recipe filtering/sorting; use it directly (bv-cwwd).
 				filteredItems := make([]list.Item, 0, len(msg.Snapshot.ListItems))
 				filteredIssues := make([]model.Issue, 0, len(msg.Snapshot.ListItems))
 
@@ -1585,6 +1725,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				m.list.SetItems(filteredItems)
 				m.updateSemanticIDs(filteredItems)
+				m.updateQueryFilterItems(filteredItems)
 				m.board.SetIssues(filteredIssues)
 
 				recipeIns := analysis.Insights{}
@@ -1599,7 +1740,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(filteredItems) > 0 && m.list.Index() >= len(filteredItems) {
 					m.list.Select(0)
 				}
-			} else {
+			default:
 				m.applyRecipe(m.activeRecipe)
 			}
 		} else {
@@ -1652,6 +1793,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								break
 							}
 						}
+					} else {
+						include = m.matchesFilterQuery(issue)
 					}
 				}
 
@@ -1664,6 +1807,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sortFilteredItems(filteredItems, filteredIssues)
 			m.list.SetItems(filteredItems)
 			m.updateSemanticIDs(filteredItems)
+			m.updateQueryFilterItems(filteredItems)
 			if m.snapshot != nil && m.snapshot.BoardState != nil && (!m.workspaceMode || m.activeRepos == nil) && len(filteredIssues) == len(m.snapshot.Issues) {
 				m.board.SetSnapshot(m.snapshot)
 			} else {
@@ -1719,10 +1863,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateViewportContent()
 		}
 
-		// Keep semantic index current when enabled.
-		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
+		// Keep semantic index current once enabled or built for Similar Beads.
+		if m.semanticIndexInUse() && !m.semanticIndexBuilding {
 			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+			cmds = append(cmds, m.semanticIndexCmd())
 		}
 
 		// Reload sprints (bv-161)
@@ -1736,6 +1880,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					for i := range m.sprints {
 						if m.sprints[i].ID == m.selectedSprint.ID {
 							m.selectedSprint = &m.sprints[i]
+							m.refreshSprintForecast()
 							m.sprintViewText = m.renderSprintDashboard()
 							found = true
 							break
@@ -1763,9 +1908,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.statusIsError = false
 
-		// Wait for Phase 2 if not ready
-		if msg.Snapshot.Analysis != nil {
-			cmds = append(cmds, WaitForPhase2Cmd(msg.Snapshot.Analysis))
+		m.notifyReload()
+
+		// Wait for Phase 2 if not ready. Insights built from complete metrics
+		// (e.g. no metric moved since the last snapshot) skip regeneration.
+		if s := msg.Snapshot; s.Analysis != nil && s.insightsComplete {
+			stats, ins := s.Analysis, s.Insights
+			cmds = append(cmds, func() tea.Msg { return Phase2ReadyMsg{Stats: stats, Insights: ins} })
+		} else if s.Analysis != nil {
+			cmds = append(cmds, WaitForPhase2Cmd(s.Analysis))
 		}
 
 		if m.backgroundWorker != nil {
@@ -1793,16 +1944,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
-	case FileChangedMsg:
-		// File changed on disk - reload issues and recompute analysis
+	case CycleFixReadyMsg:
+		if m.showCycleFixModal {
+			m.cycleFixModal.SetPlan(msg.Plan)
+		}
+		return m, nil
+
+	case EditAppliedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Edit failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		if msg.Undo {
+			undone, _ := m.editHistory.Pop()
+			m.statusMsg = "↩ Undid " + undone.String()
+		} else {
+			m.editHistory.Push(msg.Change)
+			m.statusMsg = "✓ " + msg.Change.String() + " • ctrl+z to undo"
+		}
+		m.statusIsError = false
+		// The watcher (or background worker) picks up the rewritten file;
+		// reload directly only when nothing is watching it.
+		if m.backgroundWorker == nil && m.watcher == nil && m.beadsPath != "" {
+			cmds = append(cmds, func() tea.Msg { return FileChangedMsg{} })
+		}
+		return m, tea.Batch(cmds...)
+
+	case FileChangedMsg, RemoteIssuesMsg:
+		// File changed on disk (or the daemon poller saw changes) - reload
+		// issues and recompute analysis
+		remote, isRemote := msg.(RemoteIssuesMsg)
+		if isRemote {
+			// Wait for the next poll; remote mode has no watcher or background worker
+			cmds = append(cmds, WatchRemoteCmd(m.remote))
+		}
 		// In background mode the BackgroundWorker owns file watching and snapshot building.
-		if m.backgroundWorker != nil {
+		if !isRemote && m.backgroundWorker != nil {
 			if m.watcher != nil {
 				cmds = append(cmds, WatchFileCmd(m.watcher))
 			}
 			return m, tea.Batch(cmds...)
 		}
-		if m.beadsPath == "" {
+		if !isRemote && m.beadsPath == "" {
 			// Re-start watch for next change
 			if m.watcher != nil {
 				cmds = append(cmds, WatchFileCmd(m.watcher))
@@ -1822,7 +2006,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			refreshTimings[name] = d
 			debug.LogTiming("refresh."+name, d)
 		}
-		if profileRefresh {
+		if profileRefresh && isRemote {
+			debug.Log("refresh: daemon update issues=%d", len(remote.Issues))
+		} else if profileRefresh {
 			debug.Log("refresh: file change detected path=%s", m.beadsPath)
 		}
 
@@ -1842,33 +2028,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload issues from disk
 		// Use custom warning handler to prevent stderr pollution during TUI render (bv-fix)
 		var reloadWarnings []string
-		var loadStart time.Time
-		if profileRefresh {
-			loadStart = time.Now()
-		}
-		loadedIssues, err := loader.LoadIssuesFromFileWithOptionsPooled(m.beadsPath, loader.ParseOptions{
-			WarningHandler: func(msg string) {
-				reloadWarnings = append(reloadWarnings, msg)
-			},
-			BufferSize: envMaxLineSizeBytes(),
-		})
-		if profileRefresh {
-			recordTiming("load_issues", time.Since(loadStart))
-		}
-		if err != nil {
-			m.statusMsg = fmt.Sprintf("Reload error: %v", err)
-			m.statusIsError = true
-			// Re-start watch for next change
-			if m.watcher != nil {
-				cmds = append(cmds, WatchFileCmd(m.watcher))
+		var newIssues []model.Issue
+		if isRemote {
+			newIssues = remote.Issues
+		} else {
+			var loadStart time.Time
+			if profileRefresh {
+				loadStart = time.Now()
+			}
+			loadedIssues, err := loader.LoadIssuesFromFileWithOptionsPooled(m.beadsPath, loader.ParseOptions{
+				WarningHandler: func(msg string) {
+					reloadWarnings = append(reloadWarnings, msg)
+				},
+				BufferSize: envMaxLineSizeBytes(),
+			})
+			if profileRefresh {
+				recordTiming("load_issues", time.Since(loadStart))
 			}
-			return m, tea.Batch(cmds...)
-		}
-		if len(m.pooledIssues) > 0 {
-			loader.ReturnIssuePtrsToPool(m.pooledIssues)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("Reload error: %v", err)
+				m.statusIsError = true
+				// Re-start watch for next change
+				if m.watcher != nil {
+					cmds = append(cmds, WatchFileCmd(m.watcher))
+				}
+				return m, tea.Batch(cmds...)
+			}
+			if len(m.pooledIssues) > 0 {
+				loader.ReturnIssuePtrsToPool(m.pooledIssues)
+			}
+			m.pooledIssues = loadedIssues.PoolRefs
+			newIssues = loadedIssues.Issues
 		}
-		m.pooledIssues = loadedIssues.PoolRefs
-		newIssues := loadedIssues.Issues
 
 		// Store selected issue ID to restore position after reload
 		var selectedID string
@@ -1900,12 +2091,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Recompute analysis (async Phase 1/Phase 2) with caching
 		m.issues = newIssues
+		m.sprintForecastFor = ""
 		var analysisStart time.Time
 		if profileRefresh {
 			analysisStart = time.Now()
 		}
 		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
 		m.analyzer = cachedAnalyzer.Analyzer
+		m.analyzer.SetScoringProfile(m.scoringProfile)
 		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
 		cacheHit := cachedAnalyzer.WasCacheHit()
 		if profileRefresh {
@@ -2005,6 +2198,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			recordTiming("list_items", time.Since(listStart))
 		}
 		m.updateSemanticIDs(items)
+		m.updateQueryFilterItems(items)
 		m.clearSemanticScores()
 		if m.semanticSearch != nil {
 			m.semanticSearch.ResetCache()
@@ -2108,6 +2302,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					for i := range m.sprints {
 						if m.sprints[i].ID == m.selectedSprint.ID {
 							m.selectedSprint = &m.sprints[i]
+							m.refreshSprintForecast()
 							m.sprintViewText = m.renderSprintDashboard()
 							found = true
 							break
@@ -2121,10 +2316,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Keep semantic index current when enabled.
-		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
+		// Keep semantic index current once enabled or built for Similar Beads.
+		if m.semanticIndexInUse() && !m.semanticIndexBuilding {
 			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+			cmds = append(cmds, m.semanticIndexCmd())
 		}
 
 		if cacheHit {
@@ -2201,9 +2396,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.labelHealthCached = false
 		m.labelDrilldownCache = make(map[string][]model.Issue)
 		m.updateViewportContent()
+		m.notifyReload()
 
 		// Re-start watching for next change + wait for Phase 2
-		if m.watcher != nil && !autoEnabled {
+		if !isRemote && m.watcher != nil && !autoEnabled {
 			cmds = append(cmds, WatchFileCmd(m.watcher))
 		}
 		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
@@ -2300,6 +2496,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Handle write-back edit modal
+		if m.showEditModal {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.editModal, cmd = m.editModal.Update(msg)
+			cmds = append(cmds, cmd)
+
+			switch m.editModal.Result() {
+			case EditConfirmed:
+				m.showEditModal = false
+				m.focused = m.focusBeforeEdit
+				if m.editWriter != nil {
+					change := m.editModal.Change()
+					m.statusMsg = "Saving " + change.String() + "…"
+					cmds = append(cmds, ApplyEditCmd(m.editWriter, change, m.editModal.IsUndo()))
+				}
+			case EditCancelled:
+				m.showEditModal = false
+				m.focused = m.focusBeforeEdit
+			}
+			return m, tea.Batch(cmds...)
+		}
+
+		// Handle cycle-breaking assistant modal
+		if m.showCycleFixModal {
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+			case "y":
+				if commands := m.cycleFixModal.Commands(); len(commands) > 0 {
+					if err := clipboard.WriteAll(strings.Join(commands, "\n") + "\n"); err != nil {
+						m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+						m.statusIsError = true
+					} else {
+						m.statusMsg = fmt.Sprintf("📋 Copied %d bd dep remove commands", len(commands))
+						m.statusIsError = false
+					}
+				}
+				return m, nil
+			}
+			m.cycleFixModal, cmd = m.cycleFixModal.Update(msg)
+			if m.cycleFixModal.ShouldClose() {
+				m.showCycleFixModal = false
+				m.focused = m.focusBeforeCycleFix
+			}
+			return m, cmd
+		}
+
 		// Close label health detail modal if open
 		if m.showLabelHealthDetail {
 			s := msg.String()
@@ -2629,11 +2874,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.semanticSearchEnabled = !m.semanticSearchEnabled
 			if m.semanticSearchEnabled {
 				if m.semanticSearch != nil {
-					m.list.Filter = m.semanticSearch.Filter
+					m.setListFilter(m.semanticSearch.Filter)
 					if !m.semanticSearch.Snapshot().Ready && !m.semanticIndexBuilding {
 						m.semanticIndexBuilding = true
 						m.statusMsg = "Semantic search: building index…"
-						cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+						cmds = append(cmds, m.semanticIndexCmd())
 					} else if !m.semanticSearch.Snapshot().Ready && m.semanticIndexBuilding {
 						m.statusMsg = "Semantic search: indexing…"
 					} else {
@@ -2641,7 +2886,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				} else {
 					m.semanticSearchEnabled = false
-					m.list.Filter = list.DefaultFilter
+					m.setListFilter(list.DefaultFilter)
 					m.statusMsg = "Semantic search unavailable"
 					m.statusIsError = true
 				}
@@ -2650,7 +2895,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, BuildHybridMetricsCmd(m.issuesForAsync()))
 				}
 			} else {
-				m.list.Filter = list.DefaultFilter
+				m.setListFilter(list.DefaultFilter)
 				m.statusMsg = "Fuzzy search enabled"
 				m.clearSemanticScores()
 			}
@@ -2723,6 +2968,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusFlowMetrics || m.focused == focusFileRisk {
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2754,6 +3003,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusFlowMetrics || m.focused == focusFileRisk {
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2769,6 +3022,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.isHistoryView && m.historyView.IsBlameOpen() {
+					m.historyView.CloseBlame()
+					return m, nil
+				}
 				if m.isHistoryView {
 					m.isHistoryView = false
 					m.focused = focusList
@@ -3031,6 +3288,76 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.flowMatrix.SetSize(m.width, panelHeight)
 				return m, nil
 
+			case "D":
+				// Flow metrics view (lead/cycle time, throughput, aging WIP)
+				if !m.historyView.HasReport() {
+					if m.historyLoading {
+						m.statusMsg = "Flow metrics need git history: still loading"
+					} else {
+						m.statusMsg = "Flow metrics unavailable: no git history loaded"
+					}
+					m.statusIsError = !m.historyLoading
+					return m, nil
+				}
+				m.clearAttentionOverlay()
+				m.isGraphView = false
+				m.isBoardView = false
+				m.isActionableView = false
+				m.isHistoryView = false
+				m.focused = focusFlowMetrics
+				metrics := m.historyView.Report().BuildFlowMetrics(m.issues, correlation.DefaultFlowOptions())
+				m.flowMetrics = NewFlowMetricsModel(m.theme)
+				m.flowMetrics.SetData(metrics, m.issues)
+				m.flowMetrics.SetSize(m.width, m.height-1)
+				m.statusMsg = fmt.Sprintf("Flow: %d completed • P85 cycle %.1fd • %d aging WIP",
+					metrics.Overall.Completed, metrics.Overall.CycleTime.P85Days, len(metrics.AgingWIP))
+				m.statusIsError = false
+				return m, LoadCumulativeFlowCmd(m.beadsPath)
+
+			case "R":
+				// File risk heatmap (bug density, reopens, churn, coupling)
+				if m.focused == focusFileRisk {
+					m.focused = focusList
+					return m, nil
+				}
+				if !m.historyView.HasReport() {
+					if m.historyLoading {
+						m.statusMsg = "File risk needs git history: still loading"
+					} else {
+						m.statusMsg = "File risk unavailable: no git history loaded"
+					}
+					m.statusIsError = !m.historyLoading
+					return m, nil
+				}
+				m.clearAttentionOverlay()
+				m.isGraphView = false
+				m.isBoardView = false
+				m.isActionableView = false
+				m.isHistoryView = false
+				m.focused = focusFileRisk
+				risk := m.historyView.Report().BuildFileRisk(m.issues, correlation.DefaultFileRiskOptions())
+				m.fileRisk = NewFileRiskModel(m.theme)
+				m.fileRisk.SetData(risk, m.issues)
+				m.fileRisk.SetSize(m.width, m.height-1)
+				m.statusMsg = fmt.Sprintf("File risk: %d files • %d critical • %d high+",
+					risk.Stats.TotalFiles, risk.Stats.CriticalFiles, risk.Stats.HighRiskFiles)
+				m.statusIsError = false
+				return m, nil
+
+			case "B":
+				// Cycle-breaking assistant: plan is computed off the UI goroutine
+				m.cycleFixModal = NewCycleFixModal(m.theme)
+				m.cycleFixModal.SetSize(m.width, m.height)
+				m.focusBeforeCycleFix = m.focused
+				m.showCycleFixModal = true
+				m.focused = focusCycleFix
+				return m, ComputeCycleFixCmd(m.issuesForAsync())
+
+			case "%":
+				// Cycle triage scoring profiles from .bv/scoring.yaml
+				m.cycleScoringProfile()
+				return m, nil
+
 			case "!":
 				// Toggle alerts panel (bv-168)
 				// Only show if there are active alerts
@@ -3100,6 +3427,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			}
 
+			// Write-back edits from the list, board and detail views
+			if m.focused == focusList || m.focused == focusDetail ||
+				(m.focused == focusBoard && !m.board.IsSearchMode()) {
+				switch msg.String() {
+				case "M":
+					m.openEditModal()
+					return m, nil
+				case "ctrl+z":
+					m.openUndoModal()
+					return m, nil
+				}
+			}
+
 			// Focus-specific key handling
 			switch m.focused {
 			case focusRecipePicker:
@@ -3159,7 +3499,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleActionableKeys(msg)
 
 			case focusHistory:
-				m = m.handleHistoryKeys(msg)
+				m, cmd = m.handleHistoryKeys(msg)
+				cmds = append(cmds, cmd)
 
 			case focusSprint:
 				m = m.handleSprintKeys(msg)
@@ -3167,6 +3508,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case focusFlowMatrix:
 				m = m.handleFlowMatrixKeys(msg)
 
+			case focusFlowMetrics:
+				m = m.handleFlowMetricsKeys(msg)
+
+			case focusFileRisk:
+				m = m.handleFileRiskKeys(msg)
+
 			case focusList:
 				m = m.handleListKeys(msg)
 
@@ -3206,6 +3553,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historyView.MoveUp()
 			case focusFlowMatrix:
 				m.flowMatrix.MoveUp()
+			case focusFlowMetrics:
+				m.flowMetrics.MoveUp()
+			case focusFileRisk:
+				m.fileRisk.MoveUp()
 			}
 			return m, nil
 		case tea.MouseButtonWheelDown:
@@ -3235,6 +3586,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historyView.MoveDown()
 			case focusFlowMatrix:
 				m.flowMatrix.MoveDown()
+			case focusFlowMetrics:
+				m.flowMetrics.MoveDown()
+			case focusFileRisk:
+				m.fileRisk.MoveDown()
 			}
 			return m, nil
 		}
@@ -3307,6 +3662,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.semanticSearchEnabled {
 				m.clearSemanticScores()
 			}
+			m.updateQueryTermStatus(currentTerm)
 		}
 		if m.semanticSearchEnabled && m.semanticHybridEnabled && m.list.FilterState() != list.Unfiltered {
 			if strings.TrimSpace(currentTerm) != "" {
@@ -3552,6 +3908,19 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "W":
+		if !m.graphView.HasRepoLanes() {
+			m.statusMsg = "Repo lanes need a workspace (--workspace)"
+			m.statusIsError = false
+			break
+		}
+		if m.graphView.ToggleRepoLanes() {
+			s := m.graphView.RepoLanes().Summary
+			m.statusMsg = fmt.Sprintf("Repo lanes: %d repos • %d cross-repo deps • %d dangling refs", s.RepoCount, s.CrossRepoDeps, s.DanglingRefs)
+		} else {
+			m.statusMsg = "Repo lanes off"
+		}
+		m.statusIsError = false
 	case "enter":
 		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -3657,7 +4026,7 @@ func (m Model) handleActionableKeys(msg tea.KeyMsg) Model {
 }
 
 // handleHistoryKeys handles keyboard input when history view is focused
-func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
+func (m Model) handleHistoryKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 	// Handle search input when active (bv-nkrj)
 	if m.historyView.IsSearchActive() {
 		switch msg.String() {
@@ -3665,11 +4034,11 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			m.historyView.CancelSearch()
 			m.statusMsg = "🔍 Search cancelled"
 			m.statusIsError = false
-			return m
+			return m, nil
 		case "enter":
 			// Confirm search (just blur input, keep filter active)
 			m.historyView.CancelSearch() // For now, just close search
-			return m
+			return m, nil
 		default:
 			// Forward to search input
 			m.historyView.UpdateSearchInput(msg)
@@ -3680,7 +4049,38 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 				m.statusMsg = "🔍 Type to search..."
 			}
 			m.statusIsError = false
-			return m
+			return m, nil
+		}
+	}
+
+	// Blame pane takes j/k for scrolling while open
+	if m.historyView.IsBlameOpen() {
+		switch msg.String() {
+		case "j", "down":
+			m.historyView.ScrollBlame(1)
+			return m, nil
+		case "k", "up":
+			m.historyView.ScrollBlame(-1)
+			return m, nil
+		case "ctrl+d", "pgdown":
+			m.historyView.ScrollBlame(10)
+			return m, nil
+		case "ctrl+u", "pgup":
+			m.historyView.ScrollBlame(-10)
+			return m, nil
+		case "J":
+			cmd := m.historyView.NextBlameFile()
+			m.statusMsg = fmt.Sprintf("Blame: %s", m.historyView.BlameFile())
+			m.statusIsError = false
+			return m, cmd
+		case "K":
+			cmd := m.historyView.PrevBlameFile()
+			m.statusMsg = fmt.Sprintf("Blame: %s", m.historyView.BlameFile())
+			m.statusIsError = false
+			return m, cmd
+		case "A", "esc":
+			m.historyView.CloseBlame()
+			return m, nil
 		}
 	}
 
@@ -3689,10 +4089,10 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 		switch msg.String() {
 		case "j", "down":
 			m.historyView.MoveDownFileTree()
-			return m
+			return m, nil
 		case "k", "up":
 			m.historyView.MoveUpFileTree()
-			return m
+			return m, nil
 		case "enter", "l":
 			// Expand directory or select file for filtering
 			node := m.historyView.SelectedFileNode()
@@ -3706,11 +4106,11 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 					m.statusIsError = false
 				}
 			}
-			return m
+			return m, nil
 		case "h":
 			// Collapse directory
 			m.historyView.CollapseFileNode()
-			return m
+			return m, nil
 		case "esc":
 			// If filter is active, clear it; otherwise close file tree
 			if m.historyView.GetFileFilter() != "" {
@@ -3721,11 +4121,11 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 				m.statusMsg = "📁 File tree: press Tab to return focus"
 			}
 			m.statusIsError = false
-			return m
+			return m, nil
 		case "tab":
 			// Switch focus away from file tree
 			m.historyView.SetFileTreeFocus(false)
-			return m
+			return m, nil
 		}
 	}
 
@@ -3859,6 +4259,20 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			m.statusMsg = "📁 File tree hidden"
 		}
 		m.statusIsError = false
+	case "A":
+		// Annotate the selected commit's files with the beads behind each line
+		cwd, err := os.Getwd()
+		if err != nil {
+			m.statusMsg = "Cannot get working directory for blame"
+			m.statusIsError = true
+			return m, nil
+		}
+		cmd := m.historyView.ToggleBlame(cwd)
+		if m.historyView.IsBlameLoading() {
+			m.statusMsg = fmt.Sprintf("Blame: %s…", m.historyView.BlameFile())
+			m.statusIsError = false
+		}
+		return m, cmd
 	case "o":
 		// Open commit in browser (bv-xf4p)
 		var sha string
@@ -3925,7 +4339,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 		m.isHistoryView = false
 		m.focused = focusList
 	}
-	return m
+	return m, nil
 }
 
 // getCommitURL returns the GitHub/GitLab commit URL for a SHA (bv-xf4p)
@@ -4045,6 +4459,66 @@ func (m Model) handleFlowMatrixKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// handleFlowMetricsKeys handles keyboard input when flow metrics view is focused
+func (m Model) handleFlowMetricsKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "D", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.flowMetrics.MoveDown()
+	case "k", "up":
+		m.flowMetrics.MoveUp()
+	case "tab":
+		m.flowMetrics.NextDimension()
+	case "c":
+		m.flowMetrics.ToggleCFD()
+	case "G", "end":
+		m.flowMetrics.GoToEnd()
+	case "g", "home":
+		m.flowMetrics.GoToStart()
+	}
+	return m
+}
+
+// handleFileRiskKeys handles keyboard input when the file risk view is focused
+func (m Model) handleFileRiskKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "R", "q", "esc":
+		m.focused = focusList
+	case "j", "down":
+		m.fileRisk.MoveDown()
+	case "k", "up":
+		m.fileRisk.MoveUp()
+	case "right":
+		m.fileRisk.Expand()
+	case "left", "backspace":
+		m.fileRisk.Collapse()
+	case "enter", " ":
+		beadID := m.fileRisk.SelectedBeadID()
+		if beadID == "" {
+			m.fileRisk.Toggle()
+			break
+		}
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == beadID {
+				m.list.Select(i)
+				break
+			}
+		}
+		m.focused = focusDetail
+		if !m.isSplitView {
+			m.showDetails = true
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	case "G", "end":
+		m.fileRisk.GoToEnd()
+	case "home":
+		m.fileRisk.GoToStart()
+	}
+	return m
+}
+
 // handleRecipePickerKeys handles keyboard input when recipe picker is focused
 func (m Model) handleRecipePickerKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -4357,6 +4831,12 @@ func (m Model) restoreFocusFromHelp() focus {
 	if m.focusBeforeHelp == focusFlowMatrix {
 		return focusFlowMatrix
 	}
+	if m.focusBeforeHelp == focusFlowMetrics {
+		return focusFlowMetrics
+	}
+	if m.focusBeforeHelp == focusFileRisk {
+		return focusFileRisk
+	}
 	if m.focusBeforeHelp == focusAttention {
 		return focusAttention
 	}
@@ -4453,6 +4933,12 @@ func (m Model) View() string {
 	} else if m.showUpdateModal {
 		// Self-update modal (bv-182)
 		body = m.updateModal.CenterModal(m.width, m.height-1)
+	} else if m.showEditModal {
+		// Write-back edit modal
+		body = m.editModal.CenterModal(m.width, m.height-1)
+	} else if m.showCycleFixModal {
+		// Cycle-breaking assistant modal
+		body = m.cycleFixModal.CenterModal(m.width, m.height-1)
 	} else if m.showLabelHealthDetail && m.labelHealthDetail != nil {
 		body = m.renderLabelHealthDetail(*m.labelHealthDetail)
 	} else if m.showLabelGraphAnalysis && m.labelGraphAnalysisResult != nil {
@@ -4482,6 +4968,12 @@ func (m Model) View() string {
 	} else if m.focused == focusFlowMatrix {
 		m.flowMatrix.SetSize(m.width, m.height-1)
 		body = m.flowMatrix.View()
+	} else if m.focused == focusFlowMetrics {
+		m.flowMetrics.SetSize(m.width, m.height-1)
+		body = m.flowMetrics.View()
+	} else if m.focused == focusFileRisk {
+		m.fileRisk.SetSize(m.width, m.height-1)
+		body = m.fileRisk.View()
 	} else if m.focused == focusTree {
 		// Hierarchical tree view (bv-gllx)
 		m.tree.SetSize(m.width, m.height-1)
@@ -4821,6 +5313,8 @@ func (m *Model) renderHelpOverlay() string {
 		{"h", "History view"},
 		{"a", "Actionable"},
 		{"f", "Flow matrix"},
+		{"D", "Flow metrics"},
+		{"R", "File risk heatmap"},
 		{"[", "Label dashboard"},
 		{"]", "Attention view"},
 	}
@@ -4829,6 +5323,8 @@ func (m *Model) renderHelpOverlay() string {
 		{"?", "This help"},
 		{";", "Shortcuts bar"},
 		{"!", "Alerts panel"},
+		{"B", "Break cycles"},
+		{"%", "Scoring profile"},
 		{"'", "Recipes"},
 		{"w", "Repo picker"},
 		{"q", "Back / Quit"},
@@ -4836,7 +5332,7 @@ func (m *Model) renderHelpOverlay() string {
 	}
 
 	filterSection := []struct{ key, desc string }{
-		{"/", "Fuzzy search"},
+		{"/", "Fuzzy search / query"},
 		{"Ctrl+S", "Semantic search"},
 		{"H", "Hybrid ranking"},
 		{"Alt+H", "Hybrid preset"},
@@ -4853,6 +5349,7 @@ func (m *Model) renderHelpOverlay() string {
 		{"H/L", "Scroll left/right"},
 		{"PgUp/Dn", "Scroll up/down"},
 		{"Enter", "Jump to issue"},
+		{"W", "Repo lanes"},
 	}
 
 	insightsSection := []struct{ key, desc string }{
@@ -4870,6 +5367,7 @@ func (m *Model) renderHelpOverlay() string {
 		{"Tab", "Toggle focus"},
 		{"y", "Copy SHA"},
 		{"c", "Confidence filter"},
+		{"A", "Blame commit files"},
 	}
 
 	actionsSection := []struct{ key, desc string }{
@@ -4881,6 +5379,8 @@ func (m *Model) renderHelpOverlay() string {
 		{"x", "Export markdown"},
 		{"C", "Copy to clipboard"},
 		{"O", "Open in editor"},
+		{"M", "Edit bead (write-back)"},
+		{"Ctrl+Z", "Undo last edit"},
 	}
 
 	statusSection := []struct{ key, desc string }{
@@ -5913,6 +6413,10 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, keyStyle.Render("A")+" attention", keyStyle.Render("F")+" flow")
 	} else if m.focused == focusFlowMatrix {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" panel", keyStyle.Render("⏎")+" drill", keyStyle.Render("esc")+" back", keyStyle.Render("f")+" close")
+	} else if m.focused == focusFlowMetrics {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" group by", keyStyle.Render("c")+" cfd", keyStyle.Render("esc")+" back", keyStyle.Render("D")+" close")
+	} else if m.focused == focusFileRisk {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" expand/open", keyStyle.Render("←/→")+" parent/child", keyStyle.Render("esc")+" back", keyStyle.Render("R")+" close")
 	} else if m.isGraphView {
 		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
 	} else if m.isBoardView {
@@ -5923,7 +6427,9 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" focus", keyStyle.Render("⏎")+" jump", keyStyle.Render("H")+" close")
 	} else if m.list.FilterState() == list.Filtering {
 		mode := "fuzzy"
-		if m.semanticSearchEnabled {
+		if isQueryTerm(m.list.FilterInput.Value()) {
+			mode = "query"
+		} else if m.semanticSearchEnabled {
 			mode = "semantic"
 			if m.semanticIndexBuilding {
 				mode = "semantic (indexing)"
@@ -6155,8 +6661,9 @@ func (m *Model) matchesCurrentFilter(issue model.Issue) bool {
 					return true
 				}
 			}
+			return false
 		}
-		return false
+		return m.matchesFilterQuery(issue)
 	}
 }
 
@@ -6164,6 +6671,7 @@ func (m *Model) filteredIssuesForActiveView() []model.Issue {
 	filtered := make([]model.Issue, 0, len(m.issues))
 	recipeFilterActive := m.activeRecipe != nil && strings.HasPrefix(m.currentFilter, "recipe:")
 	if recipeFilterActive {
+		where, whereEnv := recipeWhere(m.activeRecipe, m.issues, m.analysis)
 		for _, issue := range m.issues {
 			if m.workspaceMode && m.activeRepos != nil {
 				repoKey := strings.ToLower(ExtractRepoPrefix(issue.ID))
@@ -6171,7 +6679,7 @@ func (m *Model) filteredIssuesForActiveView() []model.Issue {
 					continue
 				}
 			}
-			if issueMatchesRecipe(issue, m.issueMap, m.activeRecipe) {
+			if issueMatchesRecipe(issue, m.issueMap, m.activeRecipe, where, whereEnv) {
 				filtered = append(filtered, issue)
 			}
 		}
@@ -6260,6 +6768,7 @@ func (m *Model) applyFilter() {
 
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
+	m.updateQueryFilterItems(filteredItems)
 	if m.snapshot != nil && m.snapshot.BoardState != nil && m.currentFilter == "all" && (!m.workspaceMode || m.activeRepos == nil) && len(filteredIssues) == len(m.snapshot.Issues) {
 		m.board.SetSnapshot(m.snapshot)
 	} else {
@@ -6407,6 +6916,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 
 	var filteredItems []list.Item
 	var filteredIssues []model.Issue
+	where, whereEnv := recipeWhere(r, m.issues, m.analysis)
 
 	for _, issue := range m.issues {
 		include := true
@@ -6473,6 +6983,11 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			include = !isBlocked
 		}
 
+		// Apply where expression
+		if include && where != nil {
+			include = where.Match(&issue, whereEnv)
+		}
+
 		if include {
 			item := IssueItem{
 				Issue:      issue,
@@ -6614,6 +7129,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
+	m.updateQueryFilterItems(filteredItems)
 	m.board.SetIssues(filteredIssues)
 	// Generate insights for graph view (for metric rankings and sorting)
 	recipeIns := m.analysis.GenerateInsights(len(filteredIssues))
@@ -6802,6 +7318,11 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString("```\n" + treeStr + "```\n\n")
 	}
 
+	// Similar Beads (once the semantic index is built; Update starts the build)
+	if m.semanticSearch != nil {
+		sb.WriteString(m.renderSimilarBeadsMD(item.ID))
+	}
+
 	// Comments
 	if len(item.Comments) > 0 {
 		sb.WriteString(fmt.Sprintf("### Comments (%d)\n", len(item.Comments)))
@@ -6829,6 +7350,73 @@ func (m *Model) updateViewportContent() {
 	}
 }
 
+const (
+	similarBeadsLimit    = 5
+	similarBeadsMinScore = 0.5
+)
+
+// semanticIndexCmd builds the semantic index, reusing the current embedder so
+// reloads do not start another embedding backend.
+func (m *Model) semanticIndexCmd() tea.Cmd {
+	var embedder search.Embedder
+	if m.semanticSearch != nil {
+		embedder = m.semanticSearch.Snapshot().Embedder
+	}
+	return BuildSemanticIndexCmd(m.issuesForAsync(), embedder)
+}
+
+// similarBeadsIndexCmd starts a semantic index build when the detail pane is
+// visible and the index is neither ready, building nor failed. Only the local
+// hash embedder is started this way: a subprocess or remote endpoint is not
+// launched, nor sent bead text, until the user enables semantic search.
+func (m *Model) similarBeadsIndexCmd() tea.Cmd {
+	if !m.semanticAutoIndex || m.semanticSearch == nil || m.semanticIndexBuilding || m.semanticIndexFailed || !(m.isSplitView || m.showDetails) {
+		return nil
+	}
+	if m.semanticSearch.Snapshot().Ready || len(m.issues) == 0 {
+		return nil
+	}
+	m.semanticIndexBuilding = true
+	return m.semanticIndexCmd()
+}
+
+// semanticIndexInUse reports whether reloads should keep the semantic index
+// current: semantic search is on, or the index was built for Similar Beads.
+func (m *Model) semanticIndexInUse() bool {
+	return m.semanticSearchEnabled || (m.semanticSearch != nil && m.semanticSearch.Snapshot().Ready)
+}
+
+// renderSimilarBeadsMD lists the issues nearest to issueID in the semantic
+// index as a markdown section, flagging likely duplicates. It returns "" until
+// the index is ready or when nothing is similar enough.
+func (m *Model) renderSimilarBeadsMD(issueID string) string {
+	snap := m.semanticSearch.Snapshot()
+	if !snap.Ready {
+		return ""
+	}
+	hits := search.SimilarIssues(snap.Index, issueID, similarBeadsLimit, similarBeadsMinScore)
+	if len(hits) == 0 {
+		return ""
+	}
+	dupThreshold := search.DuplicateThreshold(snap.Embedder.Provider())
+
+	var sb strings.Builder
+	sb.WriteString("### 🧬 Similar Beads\n")
+	for _, h := range hits {
+		title := h.IssueID
+		if issue, ok := m.issueMap[h.IssueID]; ok && issue != nil {
+			title = fmt.Sprintf("%s %s", h.IssueID, issue.Title)
+		}
+		line := fmt.Sprintf("- **%.0f%%** %s", h.Score*100, title)
+		if h.Score >= dupThreshold {
+			line += " ⚠️ possible duplicate"
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 // renderBeadHistoryMD generates markdown for a bead's history
 func (m *Model) renderBeadHistoryMD(beadID string) string {
 	hist := m.historyView.GetHistoryForBead(beadID)
@@ -6975,6 +7563,7 @@ func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 
 	// Update delegate to show repo badges
 	m.updateListDelegate()
+	m.graphView.SetWorkspace(info.Config)
 }
 
 // IsWorkspaceMode returns whether workspace mode is active
@@ -6995,9 +7584,11 @@ func (m *Model) enterHistoryView() {
 	beads := make([]correlation.BeadInfo, len(m.issues))
 	for i, issue := range m.issues {
 		beads[i] = correlation.BeadInfo{
-			ID:     issue.ID,
-			Title:  issue.Title,
-			Status: string(issue.Status),
+			ID:                 issue.ID,
+			Title:              issue.Title,
+			Status:             string(issue.Status),
+			Description:        issue.Description,
+			AcceptanceCriteria: issue.AcceptanceCriteria,
 		}
 	}
 
@@ -7170,12 +7761,20 @@ func (m Model) FocusState() string {
 		return "agent_prompt"
 	case focusFlowMatrix:
 		return "flow_matrix"
+	case focusFlowMetrics:
+		return "flow_metrics"
+	case focusFileRisk:
+		return "file_risk"
 	case focusTutorial:
 		return "tutorial"
 	case focusCassModal:
 		return "cass_modal"
 	case focusUpdateModal:
 		return "update_modal"
+	case focusEditModal:
+		return "edit_modal"
+	case focusCycleFix:
+		return "cycle_fix_modal"
 	default:
 		return "unknown"
 	}
@@ -7413,6 +8012,95 @@ func (m *Model) showSelfUpdateModal() {
 	m.focused = focusUpdateModal
 }
 
+// editTargetIssue returns the issue the edit keys act on: the board
+// selection in board view, otherwise the list selection.
+func (m *Model) editTargetIssue() *model.Issue {
+	id := ""
+	if m.focused == focusBoard {
+		if issue := m.board.SelectedIssue(); issue != nil {
+			id = issue.ID
+		}
+	} else if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		id = item.Issue.ID
+	}
+	if id == "" {
+		return nil
+	}
+	return m.issueMap[id]
+}
+
+// editUnavailableReason explains why write-back is disabled, or "" if allowed.
+func (m *Model) editUnavailableReason() string {
+	switch {
+	case m.editWriter == nil:
+		return "Editing unavailable: no writable beads file"
+	case m.timeTravelMode:
+		return "Editing unavailable in time-travel mode"
+	}
+	return ""
+}
+
+// openEditModal opens the write-back edit modal for the selected issue.
+func (m *Model) openEditModal() {
+	if reason := m.editUnavailableReason(); reason != "" {
+		m.statusMsg = reason
+		m.statusIsError = true
+		return
+	}
+	issue := m.editTargetIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		m.statusIsError = true
+		return
+	}
+	known := make(map[string]bool, len(m.issueMap))
+	for id := range m.issueMap {
+		known[id] = true
+	}
+	m.editModal = NewEditModal(*issue, known, m.editWriter, m.theme)
+	m.editModal.SetSize(m.width, m.height)
+	m.focusBeforeEdit = m.focused
+	m.showEditModal = true
+	m.focused = focusEditModal
+}
+
+// openUndoModal previews the inverse of the most recent edit for confirmation.
+func (m *Model) openUndoModal() {
+	if reason := m.editUnavailableReason(); reason != "" {
+		m.statusMsg = reason
+		m.statusIsError = true
+		return
+	}
+	last, ok := m.editHistory.Peek()
+	if !ok {
+		m.statusMsg = "Nothing to undo"
+		m.statusIsError = false
+		return
+	}
+	issue, ok := m.issueMap[last.IssueID]
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Cannot undo: %s is no longer loaded", last.IssueID)
+		m.statusIsError = true
+		return
+	}
+	inverse, err := last.Inverse()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Cannot undo %s: %v", last, err)
+		m.statusIsError = true
+		return
+	}
+	m.editModal = NewUndoModal(*issue, inverse, m.editWriter, m.theme)
+	m.editModal.SetSize(m.width, m.height)
+	m.focusBeforeEdit = m.focused
+	m.showEditModal = true
+	m.focused = focusEditModal
+}
+
+// SetEditWriter overrides the write-back backend (nil disables editing).
+func (m *Model) SetEditWriter(w edit.Writer) {
+	m.editWriter = w
+}
+
 // getCassSessionCount returns the cached session count for the selected bead (bv-y836)
 // Returns 0 if no sessions found, cass not available, or no bead selected.
 // This method only checks the cache - it never triggers new correlation requests.
@@ -7829,6 +8517,25 @@ func (m *Model) openInEditor() {
 	m.statusIsError = false
 }
 
+// EnableRemoteSync live-reloads from a daemon poller: each issue set received
+// on updates replaces the current one, as a file change would.
+func (m *Model) EnableRemoteSync(updates <-chan []model.Issue) {
+	m.remote = updates
+}
+
+// SetReloadObserver registers fn to receive the issue set after every live
+// reload (file change, background snapshot or daemon poll). fn runs on the UI
+// goroutine and gets its own copy, so it should hand the work off quickly.
+func (m *Model) SetReloadObserver(fn func([]model.Issue)) {
+	m.onReload = fn
+}
+
+func (m *Model) notifyReload() {
+	if m.onReload != nil {
+		m.onReload(slices.Clone(m.issues))
+	}
+}
+
 // Stop cleans up resources (file watcher, instance lock, background worker, etc.)
 // Should be called when the program exits
 func (m *Model) Stop() {
@@ -7845,6 +8552,9 @@ func (m *Model) Stop() {
 		loader.ReturnIssuePtrsToPool(m.pooledIssues)
 		m.pooledIssues = nil
 	}
+	if m.semanticSearch != nil {
+		m.semanticSearch.Close()
+	}
 }
 
 // clearAttentionOverlay hides the attention overlay and clears its rendered text.
@@ -7900,6 +8610,7 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 
 	calc := drift.NewCalculator(bl, cur, driftConfig)
 	calc.SetIssues(issues)
+	calc.SetPolicyInput(issues, stats)
 	result := calc.Calculate()
 
 	critical, warning, info := 0, 0, 0
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/query"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// QueryFilter lets the / filter bar and non-builtin currentFilter values
+// accept query expressions (status:open label:api pagerank>0.01). Terms
+// without field comparisons or operators keep the fuzzy or semantic
+// behavior of the wrapped filter.
+//
+// The list runs its FilterFunc from a tea.Cmd, so state is guarded by a
+// mutex rather than owned by the Model value.
+type QueryFilter struct {
+	mu sync.Mutex
+
+	items []model.Issue // aligned with the list's items
+	env   *query.Env
+
+	// envBase/envLen/envStats identify the issue set env was built over.
+	envBase  *model.Issue
+	envLen   int
+	envStats *analysis.GraphStats
+
+	lastSrc string
+	lastQ   *query.Query
+	lastErr error
+}
+
+// NewQueryFilter creates an empty query filter.
+func NewQueryFilter() *QueryFilter {
+	return &QueryFilter{}
+}
+
+// SetItems records the list's current items and rebuilds the evaluation
+// environment over all issues, so blocker counts and graph metrics reflect
+// the whole project rather than the visible subset.
+func (f *QueryFilter) SetItems(items []list.Item, all []model.Issue, stats *analysis.GraphStats) {
+	issues := make([]model.Issue, 0, len(items))
+	for _, it := range items {
+		if issueItem, ok := it.(IssueItem); ok {
+			issues = append(issues, issueItem.Issue)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.items = issues
+	f.rebuildEnvLocked(all, stats)
+}
+
+func (f *QueryFilter) rebuildEnvLocked(all []model.Issue, stats *analysis.GraphStats) {
+	f.env = query.NewEnv(all, stats, time.Now())
+	f.envBase, f.envLen, f.envStats = nil, len(all), stats
+	if len(all) > 0 {
+		f.envBase = &all[0]
+	}
+}
+
+// compileLocked parses src, reusing the previous result when unchanged.
+func (f *QueryFilter) compileLocked(src string) (*query.Query, error) {
+	if src != f.lastSrc || (f.lastQ == nil && f.lastErr == nil) {
+		f.lastSrc = src
+		f.lastQ, f.lastErr = query.Parse(src)
+	}
+	return f.lastQ, f.lastErr
+}
+
+// Wrap returns a list.FilterFunc that evaluates structured query terms and
+// delegates everything else to fallback.
+func (f *QueryFilter) Wrap(fallback list.FilterFunc) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		q, err := f.compileLocked(term)
+		if err != nil || !q.Structured() || len(f.items) != len(targets) {
+			// Unparseable or plain-text terms keep fuzzy/semantic matching;
+			// a stale item mapping does too.
+			return fallback(term, targets)
+		}
+		var ranks []list.Rank
+		for i := range f.items {
+			if q.Match(&f.items[i], f.env) {
+				ranks = append(ranks, list.Rank{Index: i})
+			}
+		}
+		return ranks
+	}
+}
+
+// MatchExpr evaluates expr against issue over the given issue set. It
+// returns false for expressions that do not parse.
+func (f *QueryFilter) MatchExpr(expr string, issue *model.Issue, all []model.Issue, stats *analysis.GraphStats) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	q, err := f.compileLocked(expr)
+	if err != nil {
+		return false
+	}
+	var base *model.Issue
+	if len(all) > 0 {
+		base = &all[0]
+	}
+	if f.env == nil || base != f.envBase || len(all) != f.envLen || stats != f.envStats {
+		f.rebuildEnvLocked(all, stats)
+	}
+	return q.Match(issue, f.env)
+}
+
+// isQueryTerm reports whether a filter bar term is a structured query.
+func isQueryTerm(term string) bool {
+	q, err := query.Parse(term)
+	return err == nil && q.Structured()
+}
+
+// queryTermError returns the parse error for a filter bar term that looks
+// like an attempted query (it contains a field operator or parenthesis),
+// or nil otherwise. Plain words never produce an error.
+func queryTermError(term string) error {
+	if !strings.ContainsAny(term, ":<>=~()") {
+		return nil
+	}
+	_, err := query.Parse(term)
+	return err
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func queryFilterIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "a", Title: "API auth", Status: model.StatusOpen, Priority: 0, Labels: []string{"api"}},
+		{ID: "b", Title: "API quota", Status: model.StatusOpen, Priority: 2, Labels: []string{"api"},
+			Dependencies: []*model.Dependency{{DependsOnID: "a", Type: model.DepBlocks}}},
+		{ID: "c", Title: "Docs", Status: model.StatusClosed, Priority: 1, Labels: []string{"docs"}},
+	}
+}
+
+func TestQueryFilter_WrapEvaluatesStructuredTerms(t *testing.T) {
+	issues := queryFilterIssues()
+	items := make([]list.Item, len(issues))
+	targets := make([]string, len(issues))
+	for i := range issues {
+		item := IssueItem{Issue: issues[i]}
+		items[i] = item
+		targets[i] = item.FilterValue()
+	}
+
+	qf := NewQueryFilter()
+	qf.SetItems(items, issues, nil)
+
+	fuzzyCalls := 0
+	filter := qf.Wrap(func(term string, targets []string) []list.Rank {
+		fuzzyCalls++
+		return list.DefaultFilter(term, targets)
+	})
+
+	ranks := filter("label:api AND blockers=0", targets)
+	if len(ranks) != 1 || ranks[0].Index != 0 {
+		t.Errorf("query ranks = %+v, want only index 0", ranks)
+	}
+	if fuzzyCalls != 0 {
+		t.Errorf("structured query should not use the fallback")
+	}
+
+	// Plain words and broken queries fall back to fuzzy matching.
+	filter("quota", targets)
+	filter("label:", targets)
+	if fuzzyCalls != 2 {
+		t.Errorf("fallback calls = %d, want 2", fuzzyCalls)
+	}
+}
+
+func TestModel_QueryCurrentFilter(t *testing.T) {
+	m := NewModel(queryFilterIssues(), nil, "")
+
+	m.SetFilter("is:ready OR status:closed")
+	var ids []string
+	for _, issue := range m.FilteredIssues() {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "a,c" {
+		t.Errorf("filtered = %s, want a,c", got)
+	}
+
+	m.updateQueryTermStatus("lable:api")
+	if !m.statusIsError || !strings.Contains(m.statusMsg, `did you mean "label"`) {
+		t.Errorf("status = %q (error=%v)", m.statusMsg, m.statusIsError)
+	}
+	m.updateQueryTermStatus("label:api")
+	if m.statusMsg != "" || m.statusIsError {
+		t.Errorf("query error should clear once the term parses, got %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// applyTriage installs a triage result into the list decorations and the
+// insights priority panel.
+func (m *Model) applyTriage(triage analysis.TriageResult) {
+	triageScores := make(map[string]float64, len(triage.Recommendations))
+	triageReasons := make(map[string]analysis.TriageReasons, len(triage.Recommendations))
+	quickWinSet := make(map[string]bool, len(triage.QuickWins))
+	blockerSet := make(map[string]bool, len(triage.BlockersToClear))
+	unblocksMap := make(map[string][]string, len(triage.Recommendations))
+
+	for _, rec := range triage.Recommendations {
+		triageScores[rec.ID] = rec.Score
+		if len(rec.Reasons) > 0 {
+			triageReasons[rec.ID] = analysis.TriageReasons{
+				Primary:    rec.Reasons[0],
+				All:        rec.Reasons,
+				ActionHint: rec.Action,
+			}
+		}
+		unblocksMap[rec.ID] = rec.UnblocksIDs
+	}
+	for _, qw := range triage.QuickWins {
+		quickWinSet[qw.ID] = true
+	}
+	for _, bl := range triage.BlockersToClear {
+		blockerSet[bl.ID] = true
+	}
+
+	m.triageScores = triageScores
+	m.triageReasons = triageReasons
+	m.quickWinSet = quickWinSet
+	m.blockerSet = blockerSet
+	m.unblocksMap = unblocksMap
+
+	m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
+
+	// Set full recommendations with breakdown for priority radar (bv-93)
+	dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
+	m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
+}
+
+// SetScoringProfiles installs the profiles from .bv/scoring.yaml and ranks
+// triage with the named one ("" selects the config's default).
+func (m *Model) SetScoringProfiles(cfg *analysis.ScoringConfig, name string) error {
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		return err
+	}
+	m.scoringConfig = cfg
+	m.applyScoringProfile(profile)
+	return nil
+}
+
+// ScoringProfileName returns the name of the active scoring profile.
+func (m Model) ScoringProfileName() string {
+	if m.scoringProfile == nil {
+		return analysis.DefaultScoringProfileName
+	}
+	return m.scoringProfile.Name
+}
+
+// applyScoringProfile switches the ranking weights and re-ranks the current
+// data. Later snapshots pick the profile up from the background worker.
+func (m *Model) applyScoringProfile(profile *analysis.ScoringProfile) {
+	m.scoringProfile = profile
+	if m.backgroundWorker != nil {
+		m.backgroundWorker.SetScoringProfile(profile)
+	}
+	if m.analyzer == nil || m.analysis == nil {
+		return
+	}
+	m.analyzer.SetScoringProfile(profile)
+	m.applyTriage(analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now()))
+
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	} else if m.currentFilter == "" || m.currentFilter == "all" {
+		m.refreshListItemsPhase2()
+	} else {
+		m.applyFilter()
+	}
+}
+
+// cycleScoringProfile switches to the next profile in .bv/scoring.yaml.
+func (m *Model) cycleScoringProfile() {
+	if m.scoringConfig == nil || len(m.scoringConfig.Profiles) < 2 {
+		m.statusMsg = "No scoring profiles defined (add .bv/scoring.yaml)"
+		m.statusIsError = false
+		return
+	}
+	names := m.scoringConfig.Names()
+	current := m.ScoringProfileName()
+	next := names[0]
+	for i, name := range names {
+		if name == current {
+			next = names[(i+1)%len(names)]
+			break
+		}
+	}
+	profile, err := m.scoringConfig.Profile(next)
+	if err != nil {
+		m.statusMsg = err.Error()
+		m.statusIsError = true
+		return
+	}
+	m.applyScoringProfile(profile)
+
+	m.statusMsg = fmt.Sprintf("Scoring profile: %s", next)
+	if profile.Description != "" {
+		m.statusMsg += " — " + profile.Description
+	}
+	m.statusIsError = false
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_CycleScoringProfile(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "hub", Title: "Hub", Status: model.StatusOpen, Priority: 4},
+		{ID: "d1", Title: "Dep", Status: model.StatusOpen, Priority: 4,
+			Dependencies: []*model.Dependency{{IssueID: "d1", DependsOnID: "hub", Type: model.DepBlocks}}},
+		{ID: "urgent", Title: "Urgent", Status: model.StatusOpen, Priority: 0},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	// Without a scoring.yaml there is nothing to switch to.
+	updated, _ = m.Update(keyRunes("%"))
+	m = updated.(Model)
+	if !strings.Contains(m.statusMsg, "No scoring profiles") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	cfg, err := analysis.ParseScoringConfig([]byte("profiles:\n  bugfix:\n    description: P0s first\n    weights: {pagerank: 0, betweenness: 0, blocker_ratio: 0, staleness: 0, priority_boost: 1, time_to_impact: 0, urgency: 0, risk: 0}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetScoringProfiles(cfg, ""); err != nil {
+		t.Fatal(err)
+	}
+	if m.ScoringProfileName() != "default" {
+		t.Fatalf("profile = %s", m.ScoringProfileName())
+	}
+	before := m.triageScores["urgent"]
+
+	updated, _ = m.Update(keyRunes("%"))
+	m = updated.(Model)
+	if m.ScoringProfileName() != "bugfix" || !strings.Contains(m.statusMsg, "bugfix — P0s first") {
+		t.Fatalf("profile = %s, status = %q", m.ScoringProfileName(), m.statusMsg)
+	}
+	if m.triageScores["urgent"] <= before {
+		t.Errorf("urgent score %v should rise above %v under bugfix", m.triageScores["urgent"], before)
+	}
+
+	// Wraps back to the default.
+	updated, _ = m.Update(keyRunes("%"))
+	m = updated.(Model)
+	if m.ScoringProfileName() != "default" {
+		t.Errorf("profile = %s, want default", m.ScoringProfileName())
+	}
+
+	if err := m.SetScoringProfiles(cfg, "nope"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
@@ -2,7 +2,6 @@ package ui
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"sort"
 	"sync/atomic"
@@ -221,12 +220,30 @@ func (s *SemanticSearch) Snapshot() semanticSearchSnapshot {
 	return v.(semanticSearchSnapshot)
 }
 
+// SetIndex installs a new index and embedder. A replaced embedder is closed in
+// the background so a subprocess backend does not outlive it.
 func (s *SemanticSearch) SetIndex(idx *search.VectorIndex, embedder search.Embedder) {
 	snap := s.Snapshot()
+	prev := snap.Embedder
 	snap.Index = idx
 	snap.Embedder = embedder
 	snap.Ready = idx != nil && embedder != nil
 	s.snapshot.Store(snap)
+	if prev != nil && prev != embedder {
+		go func() { _ = search.CloseEmbedder(prev) }()
+	}
+}
+
+// Close releases the current embedder; the search is no longer ready after.
+func (s *SemanticSearch) Close() {
+	snap := s.Snapshot()
+	prev := snap.Embedder
+	snap.Embedder = nil
+	snap.Ready = false
+	s.snapshot.Store(snap)
+	if prev != nil {
+		_ = search.CloseEmbedder(prev)
+	}
 }
 
 func (s *SemanticSearch) SetIDs(ids []string) {
@@ -297,7 +314,12 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	// Model backends need a round trip to embed the query; hashing is local
+	timeout := 500 * time.Millisecond
+	if snap.Embedder.Provider() != search.ProviderHash {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	vecs, err := snap.Embedder.Embed(ctx, []string{term})
@@ -476,46 +498,29 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 	}
 }
 
-// BuildSemanticIndexCmd builds or updates the semantic index for the given issues.
-func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
+// BuildSemanticIndexCmd builds or updates the semantic index for the given
+// issues, reusing embedder when it is non-nil.
+func BuildSemanticIndexCmd(issues []model.Issue, embedder search.Embedder) tea.Cmd {
 	return func() tea.Msg {
 		cfg := search.EmbeddingConfigFromEnv()
-		embedder, err := search.NewEmbedderFromConfig(cfg)
-		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
-		}
-
 		projectDir, err := os.Getwd()
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
 
-		indexPath := search.DefaultIndexPath(projectDir, cfg)
-		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
-		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.IndexBuildTimeout())
 		defer cancel()
 
-		docs := search.DocumentsFromIssues(issues)
-		stats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
+		synced, err := search.OpenSyncedIndex(ctx, projectDir, cfg, embedder, issues)
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
-		if !loaded || stats.Changed() {
-			if err := idx.Save(indexPath); err != nil {
-				return SemanticIndexReadyMsg{Error: fmt.Errorf("save semantic index: %w", err)}
-			}
-		}
-
 		return SemanticIndexReadyMsg{
-			Embedder:  embedder,
-			Index:     idx,
-			IndexPath: indexPath,
-			Loaded:    loaded,
-			Stats:     stats,
+			Embedder:  synced.Embedder,
+			Index:     synced.Index,
+			IndexPath: synced.Path,
+			Loaded:    synced.Loaded,
+			Stats:     synced.Stats,
 		}
 	}
 }
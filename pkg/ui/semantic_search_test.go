@@ -2,8 +2,12 @@ package ui
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -99,6 +103,49 @@ func TestSemanticSearchSetIndex(t *testing.T) {
 	}
 }
 
+// closingEmbedder reports Close calls on a channel.
+type closingEmbedder struct {
+	mockEmbedder
+	closed chan struct{}
+}
+
+func (e *closingEmbedder) Close() error {
+	close(e.closed)
+	return nil
+}
+
+func TestSemanticSearchSetIndexClosesReplacedEmbedder(t *testing.T) {
+	ss := NewSemanticSearch()
+	idx := search.NewVectorIndex(3)
+	first := &closingEmbedder{mockEmbedder: mockEmbedder{dim: 3}, closed: make(chan struct{})}
+
+	ss.SetIndex(idx, first)
+	ss.SetIndex(idx, first) // Reused across a rebuild
+	select {
+	case <-first.closed:
+		t.Fatal("reused embedder should stay open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	second := &closingEmbedder{mockEmbedder: mockEmbedder{dim: 3}, closed: make(chan struct{})}
+	ss.SetIndex(idx, second)
+	select {
+	case <-first.closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("replaced embedder was not closed")
+	}
+
+	ss.Close()
+	select {
+	case <-second.closed:
+	default:
+		t.Fatal("Close should release the current embedder")
+	}
+	if ss.Snapshot().Ready {
+		t.Error("search should not be ready after Close")
+	}
+}
+
 func TestSemanticSearchSetIndexNilIndex(t *testing.T) {
 	ss := NewSemanticSearch()
 	embedder := &mockEmbedder{dim: 384}
@@ -769,3 +816,92 @@ func BenchmarkDotFloat32(b *testing.B) {
 		_ = dotFloat32(a, bVec)
 	}
 }
+
+// =============================================================================
+// Similar Beads Detail Section Tests
+// =============================================================================
+
+func TestRenderSimilarBeadsMD(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Login crashes", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Login crash on Safari", Status: model.StatusOpen},
+		{ID: "bv-3", Title: "Login slow", Status: model.StatusOpen},
+		{ID: "bv-4", Title: "CSV export", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	if got := m.renderSimilarBeadsMD("bv-1"); got != "" {
+		t.Errorf("expected no section before the index is ready, got %q", got)
+	}
+
+	idx := search.NewVectorIndex(3)
+	idx.Upsert("bv-1", search.ContentHash{}, []float32{1, 0, 0})
+	idx.Upsert("bv-2", search.ContentHash{}, []float32{0.99, 0.141, 0})
+	idx.Upsert("bv-3", search.ContentHash{}, []float32{0.8, 0.6, 0})
+	idx.Upsert("bv-4", search.ContentHash{}, []float32{0, 0, 1})
+	m.semanticSearch.SetIndex(idx, &mockEmbedder{dim: 3})
+
+	got := m.renderSimilarBeadsMD("bv-1")
+	if !strings.Contains(got, "Similar Beads") {
+		t.Fatalf("missing section header:\n%s", got)
+	}
+	if !strings.Contains(got, "**99%** bv-2 Login crash on Safari ⚠️ possible duplicate") {
+		t.Errorf("expected bv-2 flagged as a duplicate:\n%s", got)
+	}
+	if !strings.Contains(got, "**80%** bv-3 Login slow\n") {
+		t.Errorf("expected bv-3 listed without a duplicate flag:\n%s", got)
+	}
+	if strings.Contains(got, "bv-4") || strings.Index(got, "bv-2") > strings.Index(got, "bv-3") {
+		t.Errorf("expected bv-2 then bv-3 only:\n%s", got)
+	}
+}
+
+func TestDetailPaneBuildsSimilarBeadsIndex(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "Login crashes", Status: model.StatusOpen}}, nil, "")
+	m.semanticAutoIndex = true
+	m.isSplitView, m.showDetails = false, false
+	if cmd := m.similarBeadsIndexCmd(); cmd != nil || m.semanticIndexBuilding {
+		t.Fatal("index build started without a detail pane")
+	}
+
+	// Subprocess and remote embedders wait for the user to enable semantic search
+	m.showDetails = true
+	m.semanticAutoIndex = false
+	if cmd := m.similarBeadsIndexCmd(); cmd != nil || m.semanticIndexBuilding {
+		t.Fatal("index build started for a non-local embedder")
+	}
+
+	m.semanticAutoIndex = true
+	if cmd := m.similarBeadsIndexCmd(); cmd == nil || !m.semanticIndexBuilding {
+		t.Fatal("expected an index build once the detail pane shows")
+	}
+	if cmd := m.similarBeadsIndexCmd(); cmd != nil {
+		t.Error("started a second build while one is running")
+	}
+
+	// A failed build is not retried implicitly
+	updated, _ := m.Update(SemanticIndexReadyMsg{Error: errors.New("no embedder")})
+	m = updated.(Model)
+	if m.semanticIndexBuilding || !m.semanticIndexFailed {
+		t.Fatalf("building=%v failed=%v after error", m.semanticIndexBuilding, m.semanticIndexFailed)
+	}
+	if cmd := m.similarBeadsIndexCmd(); cmd != nil {
+		t.Error("retried a failed build")
+	}
+}
+
+func TestSimilarBeadsIndexRefreshesOnReload(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1", Title: "Login crashes", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	if m.semanticIndexInUse() {
+		t.Fatal("index should not be in use before it is built or enabled")
+	}
+
+	// Built for Similar Beads while semantic search stays off
+	m.semanticSearch.SetIndex(search.NewVectorIndex(3), &mockEmbedder{dim: 3})
+	reloaded := append(copyIssues(issues), model.Issue{ID: "bv-2", Title: "Login crash on Safari", Status: model.StatusOpen})
+	updated, _ := m.Update(SnapshotReadyMsg{Snapshot: NewSnapshotBuilder(reloaded).Build()})
+	m = updated.(Model)
+	if m.semanticSearchEnabled || !m.semanticIndexBuilding {
+		t.Errorf("reload should rebuild a built index (enabled=%v building=%v)", m.semanticSearchEnabled, m.semanticIndexBuilding)
+	}
+}
@@ -121,6 +121,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"H/L", "Scroll ←/→"},
 				{"PgUp/Dn", "Scroll ↑/↓"},
 				{"Enter", "Jump to issue"},
+				{"W", "Repo lanes"},
 			},
 		},
 		{
@@ -159,6 +160,8 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"j/k", "Items ↓/↑"},
 				{"Tab", "Toggle detail"},
 				{"y", "Copy ID"},
+				{"M", "Edit bead"},
+				{"^z", "Undo edit"},
 				{"^j/^k", "Scroll detail"},
 				{"Enter", "Full view"},
 			},
@@ -183,6 +186,10 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"y", "Copy ID"},
 				{"C", "Copy"},
 				{"O", "Open in $EDITOR"},
+				{"M", "Edit bead"},
+				{"^z", "Undo edit"},
+				{"B", "Break cycles"},
+				{"%", "Scoring profile"},
 				{"'", "Recipe picker"},
 				{"U", "Self-update"},
 				{"V", "Cass sessions"},
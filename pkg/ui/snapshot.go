@@ -10,6 +10,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/query"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 )
 
@@ -54,7 +55,7 @@ func (t datasetTier) String() string {
 }
 
 func isClosedLikeStatus(status model.Status) bool {
-	return status == model.StatusClosed || status == model.StatusTombstone
+	return status.Class().IsResolved()
 }
 
 type snapshotBuildConfig struct {
@@ -133,11 +134,21 @@ type DataSnapshot struct {
 	// ViewIssues are the issues included in the current view context (e.g. recipe).
 	// When empty, callers should fall back to Issues.
 	ViewIssues []model.Issue
+	// recipePending is set when the recipe's where expression uses graph
+	// metrics that were not computed yet, so ViewIssues and ListItems are
+	// provisional until Phase 2 completes and the UI re-applies the recipe.
+	recipePending bool
 
 	// Graph analysis
 	Analyzer *analysis.Analyzer
 	Analysis *analysis.GraphStats
 	Insights analysis.Insights
+	// insightsComplete is set at build time when Insights came from Phase 2
+	// metrics. The UI then neither regenerates nor overwrites them.
+	insightsComplete bool
+	// phase2Triage is the build-time triage when it was computed from Phase 2
+	// metrics, so the UI can apply it instead of recomputing.
+	phase2Triage *analysis.TriageResult
 
 	// Computed statistics
 	CountOpen    int
@@ -197,6 +208,9 @@ type DataSnapshot struct {
 	IssueDiffStats IssueDiffStats
 	// IncrementalListUsed reports whether list items were rebuilt incrementally.
 	IncrementalListUsed bool
+	// MetricChanges reports which graph metrics moved since the previous
+	// snapshot. Nil when the snapshot was not built incrementally.
+	MetricChanges *analysis.MetricChanges
 
 	// Error state (for graceful degradation)
 	LoadError    error     // Non-nil if last load had recoverable errors
@@ -277,6 +291,7 @@ type SnapshotBuilder struct {
 	prevSnapshot *DataSnapshot
 	diff         *analysis.IssueDiff
 	diffStats    IssueDiffStats
+	incremental  *analysis.IncrementalAnalyzer
 }
 
 // NewSnapshotBuilder creates a builder for constructing a DataSnapshot.
@@ -299,6 +314,12 @@ func (b *SnapshotBuilder) WithRecipe(r *recipe.Recipe) *SnapshotBuilder {
 	return b
 }
 
+// WithScoringProfile ranks the snapshot's triage with the given profile.
+func (b *SnapshotBuilder) WithScoringProfile(p *analysis.ScoringProfile) *SnapshotBuilder {
+	b.analyzer.SetScoringProfile(p)
+	return b
+}
+
 func (b *SnapshotBuilder) WithBuildConfig(cfg snapshotBuildConfig) *SnapshotBuilder {
 	b.cfg = cfg
 	return b
@@ -312,6 +333,13 @@ func (b *SnapshotBuilder) WithPreviousSnapshot(prev *DataSnapshot, diff *analysi
 	return b
 }
 
+// WithIncrementalAnalyzer carries graph metrics over from the analyzer's
+// previous update instead of recomputing them from scratch.
+func (b *SnapshotBuilder) WithIncrementalAnalyzer(ia *analysis.IncrementalAnalyzer) *SnapshotBuilder {
+	b.incremental = ia
+	return b
+}
+
 // Build constructs the final immutable DataSnapshot.
 // This performs all necessary computations that should happen in the background.
 // Uses AnalyzeAsync() so Phase 2 metrics compute in background - check Phase2Ready
@@ -336,6 +364,7 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 	// Compute analysis if not provided
 	// Use AnalyzeAsync to allow Phase 2 to run in background
 	graphStats := b.analysis
+	var metricChanges *analysis.MetricChanges
 	if graphStats == nil {
 		if b.cfg.SkipPhase2 {
 			// Still compute Phase 1 metrics, but skip expensive Phase 2 work.
@@ -346,7 +375,17 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 			cfg.ComputeHITS = false
 			cfg.ComputeCriticalPath = false
 			cfg.ComputeCycles = false
-			graphStats = b.analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
+			if b.incremental != nil {
+				var changes analysis.MetricChanges
+				graphStats, changes = b.incremental.UpdateWithConfig(context.Background(), b.analyzer, cfg, b.diff)
+				metricChanges = &changes
+			} else {
+				graphStats = b.analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
+			}
+		} else if b.incremental != nil {
+			var changes analysis.MetricChanges
+			graphStats, changes = b.incremental.Update(context.Background(), b.analyzer, b.diff)
+			metricChanges = &changes
 		} else {
 			graphStats = b.analyzer.AnalyzeAsync(context.Background())
 		}
@@ -368,7 +407,7 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 		}
 
 		cOpen++
-		if issue.Status == model.StatusBlocked {
+		if issue.Status.Class() == model.ClassBlocked {
 			cBlocked++
 			continue
 		}
@@ -390,10 +429,12 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 	}
 
 	viewIssues := issues
+	recipePending := recipeWherePending(b.recipe, graphStats)
 	if b.recipe != nil {
 		viewIssues = make([]model.Issue, 0, len(issues))
+		where, whereEnv := recipeWhere(b.recipe, issues, graphStats)
 		for i := range issues {
-			if issueMatchesRecipe(issues[i], issueMap, b.recipe) {
+			if issueMatchesRecipe(issues[i], issueMap, b.recipe, where, whereEnv) {
 				viewIssues = append(viewIssues, issues[i])
 			}
 		}
@@ -423,9 +464,17 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 		unblocksMap   map[string][]string
 	)
 
+	// Checked up front: a snapshot claiming complete metrics must not have
+	// raced Phase 2 finishing mid-build.
+	phase2Ready := graphStats.IsPhase2Ready()
+	var phase2Triage *analysis.TriageResult
+
 	// Compute triage insights (may be skipped for large/huge datasets; bv-9thm).
 	if b.cfg.PrecomputeTriage {
 		triageResult := analysis.ComputeTriageFromAnalyzer(b.analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
+		if phase2Ready {
+			phase2Triage = &triageResult
+		}
 		triageScores = make(map[string]float64, len(triageResult.Recommendations))
 		triageReasons = make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
 		quickWinSet = make(map[string]bool, len(triageResult.QuickWins))
@@ -478,8 +527,16 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 	}
 
 	insights := analysis.Insights{Stats: graphStats, ClusterDensity: graphStats.Density}
+	insightsComplete := false
 	if b.cfg.PrecomputeInsights {
-		insights = graphStats.GenerateInsights(len(issues))
+		if prev := b.prevSnapshot; prev != nil && prev.insightsComplete && metricChanges != nil && !metricChanges.Any() {
+			// No metric moved since the previous snapshot, so its insights still hold
+			insights = prev.Insights
+			insights.Stats = graphStats
+		} else {
+			insights = graphStats.GenerateInsights(len(issues))
+		}
+		insightsComplete = phase2Ready
 	}
 
 	var graphLayout *GraphLayout
@@ -488,35 +545,39 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 	}
 
 	return &DataSnapshot{
-		Issues:        issues,
-		IssueMap:      issueMap,
-		ViewIssues:    viewIssues,
-		Analyzer:      b.analyzer,
-		Analysis:      graphStats,
-		Insights:      insights,
-		CountOpen:     cOpen,
-		CountReady:    cReady,
-		CountBlocked:  cBlocked,
-		CountClosed:   cClosed,
-		ListItems:     listItems,
-		TriageScores:  triageScores,
-		TriageReasons: triageReasons,
-		QuickWinSet:   quickWinSet,
-		BlockerSet:    blockerSet,
-		UnblocksMap:   unblocksMap,
-		TreeRoots:     treeRoots,
-		TreeNodeMap:   treeNodeMap,
-		BoardState:    boardState,
-		GraphLayout:   graphLayout,
-		CreatedAt:     time.Now(),
-		Phase2Ready:   graphStats.IsPhase2Ready(),
-		IssueDiff:     b.diff,
+		Issues:           issues,
+		IssueMap:         issueMap,
+		ViewIssues:       viewIssues,
+		recipePending:    recipePending,
+		Analyzer:         b.analyzer,
+		Analysis:         graphStats,
+		Insights:         insights,
+		insightsComplete: insightsComplete,
+		phase2Triage:     phase2Triage,
+		CountOpen:        cOpen,
+		CountReady:       cReady,
+		CountBlocked:     cBlocked,
+		CountClosed:      cClosed,
+		ListItems:        listItems,
+		TriageScores:     triageScores,
+		TriageReasons:    triageReasons,
+		QuickWinSet:      quickWinSet,
+		BlockerSet:       blockerSet,
+		UnblocksMap:      unblocksMap,
+		TreeRoots:        treeRoots,
+		TreeNodeMap:      treeNodeMap,
+		BoardState:       boardState,
+		GraphLayout:      graphLayout,
+		CreatedAt:        time.Now(),
+		Phase2Ready:      graphStats.IsPhase2Ready(),
+		IssueDiff:        b.diff,
 		IssueDiffStats: IssueDiffStats{
 			Changed: b.diffStats.Changed,
 			Total:   b.diffStats.Total,
 			Ratio:   b.diffStats.Ratio,
 		},
 		IncrementalListUsed: listItemsIncremental,
+		MetricChanges:       metricChanges,
 	}
 }
 
@@ -631,7 +692,34 @@ func isChangedID(changed map[string]struct{}, id string) bool {
 	return ok
 }
 
-func issueMatchesRecipe(issue model.Issue, issueMap map[string]*model.Issue, r *recipe.Recipe) bool {
+// recipeWhere compiles the recipe's where expression along with its
+// evaluation environment. It returns a nil query when there is none.
+func recipeWhere(r *recipe.Recipe, issues []model.Issue, stats *analysis.GraphStats) (*query.Query, *query.Env) {
+	if r == nil {
+		return nil, nil
+	}
+	q, err := r.Filters.WhereQuery()
+	if err != nil || q == nil {
+		return nil, nil
+	}
+	return q, query.NewEnv(issues, stats, time.Now())
+}
+
+// recipeWherePending reports whether the recipe's where expression uses
+// graph metrics that stats has not finished computing. Metric terms never
+// match before Phase 2, so such a filter has to be re-applied afterwards.
+func recipeWherePending(r *recipe.Recipe, stats *analysis.GraphStats) bool {
+	if r == nil {
+		return false
+	}
+	q, err := r.Filters.WhereQuery()
+	if err != nil || q == nil || !q.UsesGraphMetrics() {
+		return false
+	}
+	return stats == nil || !stats.IsPhase2Ready()
+}
+
+func issueMatchesRecipe(issue model.Issue, issueMap map[string]*model.Issue, r *recipe.Recipe, where *query.Query, whereEnv *query.Env) bool {
 	if r == nil {
 		return true
 	}
@@ -692,6 +780,11 @@ func issueMatchesRecipe(issue model.Issue, issueMap map[string]*model.Issue, r *
 		}
 	}
 
+	// Where expression
+	if where != nil && !where.Match(&issue, whereEnv) {
+		return false
+	}
+
 	return true
 }
 
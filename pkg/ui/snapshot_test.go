@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -182,6 +183,67 @@ func TestSnapshotBuilder_WithDependencies(t *testing.T) {
 	}
 }
 
+func TestSnapshotBuilder_WithIncrementalAnalyzer(t *testing.T) {
+	build := func(title string, deps ...string) []model.Issue {
+		issues := []model.Issue{
+			{ID: "test-1", Title: title, Status: model.StatusOpen},
+			{ID: "test-2", Title: "Blocked", Status: model.StatusOpen},
+			{ID: "test-3", Title: "Other", Status: model.StatusOpen},
+		}
+		for _, d := range deps {
+			issues[1].Dependencies = append(issues[1].Dependencies, &model.Dependency{DependsOnID: d, Type: model.DepBlocks})
+		}
+		return issues
+	}
+	ia := analysis.NewIncrementalAnalyzer()
+
+	first := NewSnapshotBuilder(build("Blocker", "test-1")).WithIncrementalAnalyzer(ia).Build()
+	if first.MetricChanges == nil || !first.MetricChanges.Full {
+		t.Fatalf("first build should be a full analysis, got %+v", first.MetricChanges)
+	}
+	first.Analysis.WaitForPhase2()
+
+	// Title-only edit: graph metrics are reused as-is.
+	prevIssues := first.Issues
+	next := build("Blocker (renamed)", "test-1")
+	diff := analysis.ComputeIssueDiff(prevIssues, next)
+	second := NewSnapshotBuilder(next).WithIncrementalAnalyzer(ia).WithPreviousSnapshot(first, &diff).Build()
+	if second.Analysis != first.Analysis || second.MetricChanges.Any() || !second.Phase2Ready {
+		t.Errorf("content-only change should reuse analysis: changes=%+v ready=%v", second.MetricChanges, second.Phase2Ready)
+	}
+	// Built from complete metrics, so the UI can skip the Phase 2 rebuild
+	if !second.insightsComplete || second.phase2Triage == nil {
+		t.Errorf("expected complete insights and triage, got complete=%v triage=%v", second.insightsComplete, second.phase2Triage != nil)
+	}
+
+	// Unchanged metrics again: insights carry over from the previous snapshot
+	third := NewSnapshotBuilder(build("Blocker (again)", "test-1")).WithIncrementalAnalyzer(ia).WithPreviousSnapshot(second, nil).Build()
+	if !third.insightsComplete || third.Insights.Stats != third.Analysis || len(third.Insights.Bottlenecks) != len(second.Insights.Bottlenecks) {
+		t.Errorf("expected reused insights, got %+v", third.Insights)
+	}
+
+	m := NewModel(nil, nil, "")
+	updated, cmd := m.Update(SnapshotReadyMsg{Snapshot: third})
+	if cmd == nil {
+		t.Fatal("expected follow-up commands")
+	}
+	m = updated.(Model)
+	next2, _ := m.Update(Phase2ReadyMsg{Stats: third.Analysis, Insights: third.Insights})
+	if got := next2.(Model).triageScores; len(got) == 0 || len(got) != len(third.phase2Triage.Recommendations) {
+		t.Errorf("triage scores = %v, want the snapshot's triage", got)
+	}
+
+	// New dependency: incremental update reporting degree changes.
+	fourth := NewSnapshotBuilder(build("Blocker (renamed)", "test-1", "test-3")).WithIncrementalAnalyzer(ia).Build()
+	mc := fourth.MetricChanges
+	if mc == nil || mc.Full || !mc.Degree || len(mc.Delta.AddedEdges) != 1 {
+		t.Fatalf("expected incremental degree change, got %+v", mc)
+	}
+	if fourth.Analysis.InDegree["test-3"] != 1 {
+		t.Errorf("in-degree(test-3) = %d, want 1", fourth.Analysis.InDegree["test-3"])
+	}
+}
+
 func TestSnapshotBuilder_TombstoneCounts(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "open-1", Title: "Open", Status: model.StatusOpen},
@@ -491,6 +553,63 @@ func TestSnapshotBuilder_WithRecipe_FiltersListItems(t *testing.T) {
 	}
 }
 
+func TestSnapshotBuilder_RecipeWhereWaitsForPhase2(t *testing.T) {
+	blocksOnA := func(id string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: "A", Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 1},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 2, Dependencies: blocksOnA("B")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 2, Dependencies: blocksOnA("C")},
+	}
+	// C loses its dependency in the reload, so only a re-applied filter drops it
+	reloaded := copyIssues(issues)
+	reloaded[2].Dependencies = nil
+	r := &recipe.Recipe{Name: "connected", Filters: recipe.FilterConfig{Where: "indegree>0 OR outdegree>0"}}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	ready := &stats
+	if snap := NewSnapshotBuilder(copyIssues(issues)).WithAnalysis(ready).WithRecipe(r).Build(); snap.recipePending {
+		t.Error("recipe should not be pending once Phase 2 is ready")
+	}
+
+	// Phase 2 still running: metric terms match nothing and the result is provisional
+	pending := NewSnapshotBuilder(copyIssues(reloaded)).WithAnalysis(&analysis.GraphStats{}).WithRecipe(r).Build()
+	if !pending.recipePending || len(pending.ListItems) != 0 {
+		t.Fatalf("expected a provisional empty recipe view, got pending=%v items=%d", pending.recipePending, len(pending.ListItems))
+	}
+	pending.RecipeName, pending.RecipeHash = r.Name, recipeFingerprint(r)
+
+	m := NewModel(copyIssues(issues), nil, "")
+	m.analysis = ready
+	m.activeRecipe = r
+	m.applyRecipe(r)
+	if got := len(m.list.Items()); got != 3 {
+		t.Fatalf("recipe matched %d issues, want 3", got)
+	}
+
+	// A reload keeps the current result instead of blanking the list
+	updated, _ := m.Update(SnapshotReadyMsg{Snapshot: pending})
+	m = updated.(Model)
+	if got := len(m.list.Items()); got != 3 {
+		t.Fatalf("list has %d items while Phase 2 runs, want 3", got)
+	}
+
+	// Phase 2 completing re-applies the where expression to the reloaded issues
+	reloadedStats := analysis.NewAnalyzer(reloaded).Analyze()
+	m.analysis = &reloadedStats
+	m.snapshot.Analysis = &reloadedStats
+	updated, _ = m.Update(Phase2ReadyMsg{Stats: &reloadedStats, Insights: reloadedStats.GenerateInsights(10)})
+	m = updated.(Model)
+	var ids []string
+	for _, item := range m.list.Items() {
+		ids = append(ids, item.(IssueItem).Issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "A,B" {
+		t.Errorf("list after Phase 2 = %s, want A,B (C no longer matches)", got)
+	}
+}
+
 func TestSnapshotBuilder_IncrementalListClearsEphemeralFields(t *testing.T) {
 	now := time.Now()
 	issues := []model.Issue{
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -171,6 +172,43 @@ func (m Model) renderSprintDashboard() string {
 		sb.WriteString("\n\n")
 	}
 
+	// Monte Carlo completion forecast for the sprint's open beads
+	sb.WriteString(labelStyle.Render("Forecast:"))
+	sb.WriteString("\n")
+	var openIDs []string
+	for _, iss := range sprintIssues {
+		if !isClosedLikeStatus(iss.Status) {
+			openIDs = append(openIDs, iss.ID)
+		}
+	}
+	if len(openIDs) == 0 {
+		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Open).Render("  ✓ All beads closed"))
+		sb.WriteString("\n\n")
+	} else if fc := m.sprintForecast; fc != nil && m.sprintForecastErr == nil && m.sprintForecastFor == sprint.ID {
+		sb.WriteString(valStyle.Render(fmt.Sprintf("  P50 %s  •  P85 %s  •  P95 %s",
+			fc.P50.Date.Format("Jan 2"), fc.P85.Date.Format("Jan 2"), fc.P95.Date.Format("Jan 2"))))
+		sb.WriteString("\n")
+		if !sprint.EndDate.IsZero() {
+			onTime := fc.ProbabilityBy(sprint.EndDate)
+			onTimeStyle := t.Renderer.NewStyle().Foreground(t.Open)
+			if onTime < 0.5 {
+				onTimeStyle = t.Renderer.NewStyle().Foreground(t.Blocked)
+			} else if onTime < 0.85 {
+				onTimeStyle = t.Renderer.NewStyle().Foreground(t.Feature)
+			}
+			sb.WriteString(onTimeStyle.Render(fmt.Sprintf("  %.0f%% chance to finish by %s", onTime*100, sprint.EndDate.Format("Jan 2"))))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("  ")
+		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Primary).Render(renderForecastHistogram(fc.Histogram)))
+		sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
+			fmt.Sprintf(" %.0f–%.0fd (%d trials)", fc.Histogram[0].StartDays, fc.Histogram[len(fc.Histogram)-1].EndDays, fc.Trials)))
+		sb.WriteString("\n\n")
+	} else {
+		sb.WriteString(valStyle.Render("  (forecast unavailable)"))
+		sb.WriteString("\n\n")
+	}
+
 	// At-risk items (in_progress for more than X days without update)
 	sb.WriteString(labelStyle.Render("At Risk:"))
 	sb.WriteString("\n")
@@ -251,6 +289,66 @@ func (m Model) renderSprintDashboard() string {
 	)
 }
 
+// refreshSprintForecast runs the Monte Carlo forecast for the selected
+// sprint's open beads unless it is already cached for that sprint; the
+// dashboard only renders the cached result
+func (m *Model) refreshSprintForecast() {
+	if m.selectedSprint == nil {
+		m.sprintForecast, m.sprintForecastErr, m.sprintForecastFor = nil, nil, ""
+		return
+	}
+	sprint := m.selectedSprint
+	if m.sprintForecastFor == sprint.ID {
+		return
+	}
+
+	inSprint := make(map[string]bool, len(sprint.BeadIDs))
+	for _, id := range sprint.BeadIDs {
+		inSprint[id] = true
+	}
+	var openIDs []string
+	for _, iss := range m.issues {
+		if inSprint[iss.ID] && !isClosedLikeStatus(iss.Status) {
+			openIDs = append(openIDs, iss.ID)
+		}
+	}
+
+	m.sprintForecastFor = sprint.ID
+	m.sprintForecast, m.sprintForecastErr = nil, nil
+	if len(openIDs) == 0 {
+		return
+	}
+	m.sprintForecast, m.sprintForecastErr = analysis.ForecastMonteCarlo(m.issues, "sprint:"+sprint.ID, openIDs, analysis.MonteCarloOptions{
+		Trials: sprintForecastTrials,
+		Now:    time.Now(),
+	})
+}
+
+// sprintForecastTrials keeps the sprint dashboard's Monte Carlo forecast cheap
+// enough to recompute on every render.
+const sprintForecastTrials = 300
+
+// renderForecastHistogram draws a Monte Carlo histogram as a one-line bar chart.
+func renderForecastHistogram(bins []analysis.ForecastBin) string {
+	levels := []rune(" ▁▂▃▄▅▆▇█")
+	peak := 0
+	for _, b := range bins {
+		peak = max(peak, b.Count)
+	}
+	if peak == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, b := range bins {
+		idx := b.Count * (len(levels) - 1) / peak
+		if b.Count > 0 && idx == 0 {
+			idx = 1
+		}
+		sb.WriteRune(levels[idx])
+	}
+	return sb.String()
+}
+
 // truncateStrSprint truncates a string to maxLen runes, adding ellipsis if needed.
 // Uses rune-based counting to safely handle UTF-8 multi-byte characters.
 func truncateStrSprint(s string, maxLen int) string {
@@ -277,6 +375,7 @@ func (m Model) handleSprintKeys(msg tea.KeyMsg) Model {
 			for i, s := range m.sprints {
 				if s.ID == m.selectedSprint.ID && i < len(m.sprints)-1 {
 					m.selectedSprint = &m.sprints[i+1]
+					m.refreshSprintForecast()
 					m.sprintViewText = m.renderSprintDashboard()
 					break
 				}
@@ -288,6 +387,7 @@ func (m Model) handleSprintKeys(msg tea.KeyMsg) Model {
 			for i, s := range m.sprints {
 				if s.ID == m.selectedSprint.ID && i > 0 {
 					m.selectedSprint = &m.sprints[i-1]
+					m.refreshSprintForecast()
 					m.sprintViewText = m.renderSprintDashboard()
 					break
 				}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -556,3 +557,59 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRenderSprintDashboard_MonteCarloForecast(t *testing.T) {
+	now := time.Now().UTC()
+	sprint := model.Sprint{
+		ID:        "s1",
+		Name:      "Forecast Sprint",
+		StartDate: now.AddDate(0, 0, -7),
+		EndDate:   now.AddDate(0, 0, 7),
+		BeadIDs:   []string{"A", "B"},
+	}
+	closedAt := now.AddDate(0, 0, -3)
+
+	m := Model{
+		theme:          DefaultTheme(lipgloss.NewRenderer(nil)),
+		width:          100,
+		height:         70,
+		selectedSprint: &sprint,
+		issues: []model.Issue{
+			{ID: "A", Title: "Issue A", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+			{ID: "B", Title: "Issue B", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+			{ID: "Z", Title: "Done", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeTask, ClosedAt: &closedAt},
+		},
+	}
+
+	if result := m.renderSprintDashboard(); containsStr(result, "P85") {
+		t.Error("Rendering should not run the forecast itself")
+	}
+	m.refreshSprintForecast()
+	cached := m.sprintForecast
+	m.refreshSprintForecast()
+	if cached == nil || m.sprintForecast != cached {
+		t.Error("Forecast should be computed once per sprint and cached")
+	}
+
+	result := m.renderSprintDashboard()
+	if !containsStr(result, "Forecast") {
+		t.Error("Should contain 'Forecast' label")
+	}
+	if !containsStr(result, "P85") {
+		t.Error("Should contain P85 completion date")
+	}
+	if !containsStr(result, "chance to finish") {
+		t.Error("Should contain on-time probability")
+	}
+}
+
+func TestRenderForecastHistogram(t *testing.T) {
+	bins := []analysis.ForecastBin{{Count: 0}, {Count: 1}, {Count: 8}, {Count: 4}}
+	got := renderForecastHistogram(bins)
+	if got != " ▁█▄" {
+		t.Errorf("renderForecastHistogram() = %q, want %q", got, " ▁█▄")
+	}
+	if renderForecastHistogram(nil) != "" {
+		t.Error("expected empty histogram for no bins")
+	}
+}
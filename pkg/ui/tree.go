@@ -229,7 +229,7 @@ func buildIssueTreeNodes(issues []model.Issue) ([]*IssueTreeNode, map[string]*Is
 		issueByID[issue.ID] = issue
 
 		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepParentChild {
+			if dep != nil && dep.Type.IsHierarchy() {
 				parentID := dep.DependsOnID
 				childrenOf[parentID] = append(childrenOf[parentID], issue)
 				hasParent[issue.ID] = true
@@ -249,7 +249,7 @@ func buildIssueTreeNodes(issues []model.Issue) ([]*IssueTreeNode, map[string]*Is
 		// Issue declares a parent - verify at least one referenced parent exists
 		hasValidParent := false
 		for _, dep := range issue.Dependencies {
-			if dep != nil && dep.Type == model.DepParentChild {
+			if dep != nil && dep.Type.IsHierarchy() {
 				if _, exists := issueByID[dep.DependsOnID]; exists {
 					hasValidParent = true
 					break
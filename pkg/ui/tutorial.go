@@ -2036,7 +2036,7 @@ Create a ` + "`.beads/workspace.json`" + `:
 | Key | Action |
 |-----|--------|
 | **w** | Toggle workspace picker |
-| **W** | Workspace-wide search |
+| **W** | Repo lanes (graph view) |
 
 ### Aggregated Views
 
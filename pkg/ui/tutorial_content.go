@@ -574,6 +574,10 @@ func structuredTutorialPages() []StructuredTutorialPage {
 				Section{Title: "How It Stays Fast"},
 				Paragraph{Text: "The index uses a weighted issue document (ID/title emphasized) so quick searches are precise. Short queries get a literal-match boost so you can type a single word and still land on the right issue."},
 				Spacer{Lines: 1},
+				Section{Title: "Query Expressions"},
+				Paragraph{Text: "Type field terms into / to filter precisely instead of fuzzily. Terms combine with AND, OR, NOT and parentheses; graph metrics like pagerank and betweenness work too. The same syntax drives --where and recipe filters."},
+				Code{Text: "status:open label:api (priority<=1 OR blocks>3) updated<14d"},
+				Spacer{Lines: 1},
 				Section{Title: "Tuning"},
 				Code{Text: "BV_SEARCH_MODE=hybrid\nBV_SEARCH_PRESET=impact-first\nBV_SEARCH_WEIGHTS='{\"text\":0.4,\"pagerank\":0.2,\"status\":0.15,\"impact\":0.1,\"priority\":0.1,\"recency\":0.05}'"},
 				Spacer{Lines: 1},
@@ -681,11 +685,11 @@ func structuredTutorialPages() []StructuredTutorialPage {
 				Section{Title: "Navigation"},
 				KeyTable{Bindings: []KeyBinding{
 					{Key: "w", Desc: "Toggle workspace picker"},
-					{Key: "W", Desc: "Workspace-wide search"},
+					{Key: "W", Desc: "Repo lanes (graph view)"},
 				}},
 				Spacer{Lines: 1},
 				Section{Title: "Cross-Repo Dependencies"},
-				Paragraph{Text: "Issues can depend on issues in other repos. The graph shows these relationships."},
+				Paragraph{Text: "Issues can depend on issues in other repos. Press W in the graph view to group nodes into repo lanes with cross-repo links and references to repos missing from the workspace."},
 			},
 		},
 		{
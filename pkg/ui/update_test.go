@@ -108,6 +108,35 @@ func TestUpdateFileChangedReloadsSelection(t *testing.T) {
 	}
 }
 
+func TestUpdateRemoteIssuesReloads(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "ONE", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updates := make(chan []model.Issue, 1)
+	m.EnableRemoteSync(updates)
+
+	updated, cmd := m.Update(RemoteIssuesMsg{Issues: []model.Issue{
+		{ID: "ONE", Title: "One (edited)", Status: model.StatusOpen},
+		{ID: "TWO", Title: "Two", Status: model.StatusOpen},
+	}})
+	m2 := updated.(Model)
+	if len(m2.issues) != 2 || m2.issueMap["ONE"].Title != "One (edited)" || m2.statusMsg != "Reloaded 2 issues" {
+		t.Fatalf("remote reload: %d issues, status %q", len(m2.issues), m2.statusMsg)
+	}
+	if cmd == nil {
+		t.Fatal("expected commands re-arming the remote watch")
+	}
+
+	// The re-armed watch delivers the next update
+	next := []model.Issue{{ID: "THREE", Title: "Three", Status: model.StatusOpen}}
+	updates <- next
+	if msg, ok := WatchRemoteCmd(updates)().(RemoteIssuesMsg); !ok || len(msg.Issues) != 1 || msg.Issues[0].ID != "THREE" {
+		t.Fatalf("WatchRemoteCmd = %+v", msg)
+	}
+	close(updates)
+	if msg := WatchRemoteCmd(updates)(); msg != nil {
+		t.Fatalf("closed channel produced %+v", msg)
+	}
+}
+
 func TestNewModel_SetsTreeBeadsDirFromBeadsPath(t *testing.T) {
 	tmp := t.TempDir()
 	beads := filepath.Join(tmp, "beads.jsonl")
@@ -124,3 +153,19 @@ func TestNewModel_SetsTreeBeadsDirFromBeadsPath(t *testing.T) {
 		t.Fatalf("expected tree beadsDir %q, got %q", want, got)
 	}
 }
+
+func TestReloadObserverSeesReloadedIssues(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "ONE", Title: "One", Status: model.StatusOpen}}, nil, "")
+	m.EnableRemoteSync(make(chan []model.Issue))
+	var observed [][]model.Issue
+	m.SetReloadObserver(func(issues []model.Issue) { observed = append(observed, issues) })
+
+	updated, _ := m.Update(RemoteIssuesMsg{Issues: []model.Issue{{ID: "ONE", Title: "One", Status: model.StatusClosed}}})
+	if len(observed) != 1 || len(observed[0]) != 1 || observed[0][0].Status != model.StatusClosed {
+		t.Fatalf("observed = %+v", observed)
+	}
+	// The observer gets a copy it can keep while the model re-sorts its issues
+	if &observed[0][0] == &updated.(Model).issues[0] {
+		t.Error("observer shares the model's issue slice")
+	}
+}
@@ -0,0 +1,231 @@
+// Package workflow loads custom issue statuses, their allowed transitions and
+// custom dependency types from .bv/workflow.yaml.
+//
+// Each custom status is classified as open, active, blocked, done or
+// terminal so that analysis, the board and history correlation can treat it
+// like the built-in status it resembles:
+//
+//	statuses:
+//	  qa:
+//	    class: active
+//	    description: Verifying on staging
+//	  staged: done
+//	  needs-info: blocked
+//	transitions:
+//	  in_progress: [qa, blocked, open]
+//	  qa: [staged, in_progress]
+//
+// Custom dependency types declare whether they block (become graph edges and
+// gate actionability) and whether they form a parent/child hierarchy:
+//
+//	dependency_types:
+//	  waits-for: {blocking: true}
+//	  tracks: {hierarchy: true}
+//	  duplicates: {}
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilename is the workflow config filename under .bv/
+const ConfigFilename = "workflow.yaml"
+
+// StatusDef declares one status. In YAML it may be written as a mapping or
+// as the bare class name.
+type StatusDef struct {
+	Class       model.StatusClass `yaml:"class" json:"class"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// UnmarshalYAML accepts either `qa: active` or `qa: {class: active}`.
+func (d *StatusDef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Class = model.StatusClass(node.Value)
+		return nil
+	}
+	type rawDef StatusDef
+	var raw rawDef
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	*d = StatusDef(raw)
+	return nil
+}
+
+// Config is the contents of .bv/workflow.yaml.
+type Config struct {
+	// Statuses declares custom statuses, or reclassifies built-in ones.
+	Statuses map[string]StatusDef `yaml:"statuses" json:"statuses"`
+
+	// Transitions lists the statuses reachable from each status. Statuses
+	// without an entry may move anywhere.
+	Transitions map[string][]string `yaml:"transitions,omitempty" json:"transitions,omitempty"`
+
+	// DependencyTypes declares custom dependency types, or overrides the
+	// semantics of built-in ones.
+	DependencyTypes map[string]model.DependencyTypeDef `yaml:"dependency_types,omitempty" json:"dependency_types,omitempty"`
+}
+
+// ConfigPath returns the workflow config path for a project
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig loads .bv/workflow.yaml from projectDir.
+// Returns nil (and no error) if the file doesn't exist.
+func LoadConfig(projectDir string) (*Config, error) {
+	data, err := os.ReadFile(ConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading workflow config: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates workflow YAML. Status names are lowercased to
+// match how the loader normalizes issue statuses.
+func Parse(data []byte) (*Config, error) {
+	var raw Config
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing workflow config: %w", err)
+	}
+
+	cfg := &Config{
+		Statuses:        make(map[string]StatusDef, len(raw.Statuses)),
+		Transitions:     make(map[string][]string, len(raw.Transitions)),
+		DependencyTypes: make(map[string]model.DependencyTypeDef, len(raw.DependencyTypes)),
+	}
+	for name, def := range raw.Statuses {
+		def.Class = model.StatusClass(strings.ToLower(strings.TrimSpace(string(def.Class))))
+		cfg.Statuses[normalizeName(name)] = def
+	}
+	for from, targets := range raw.Transitions {
+		normalized := make([]string, 0, len(targets))
+		for _, to := range targets {
+			normalized = append(normalized, normalizeName(to))
+		}
+		cfg.Transitions[normalizeName(from)] = normalized
+	}
+	for name, def := range raw.DependencyTypes {
+		cfg.DependencyTypes[normalizeName(name)] = def
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+	return cfg, nil
+}
+
+func normalizeName(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Validate checks classes and that transitions only reference known statuses
+func (c *Config) Validate() error {
+	for _, name := range sortedKeys(c.Statuses) {
+		if name == "" {
+			return fmt.Errorf("status names must be non-empty")
+		}
+		if strings.ContainsAny(name, " \t,") {
+			return fmt.Errorf("status %q must not contain spaces or commas", name)
+		}
+		if class := c.Statuses[name].Class; !class.IsValid() {
+			return fmt.Errorf("status %q has invalid class %q (want one of %s)", name, class, classList())
+		}
+	}
+	for _, from := range sortedKeys(c.Transitions) {
+		if !c.known(from) {
+			return fmt.Errorf("transitions: unknown status %q", from)
+		}
+		for _, to := range c.Transitions[from] {
+			if !c.known(to) {
+				return fmt.Errorf("transitions from %q: unknown status %q", from, to)
+			}
+		}
+	}
+	for _, name := range sortedKeys(c.DependencyTypes) {
+		if name == "" {
+			return fmt.Errorf("dependency type names must be non-empty")
+		}
+		if strings.ContainsAny(name, " \t,") {
+			return fmt.Errorf("dependency type %q must not contain spaces or commas", name)
+		}
+		if def := c.DependencyTypes[name]; def.Blocking && def.Hierarchy {
+			return fmt.Errorf("dependency type %q cannot be both blocking and hierarchy", name)
+		}
+	}
+	return nil
+}
+
+// known reports whether name is a built-in status or declared by the config.
+func (c *Config) known(name string) bool {
+	if _, ok := c.Statuses[name]; ok {
+		return true
+	}
+	return model.Status(name).IsBuiltin()
+}
+
+// Workflow converts the config into the form model.SetWorkflow installs.
+func (c *Config) Workflow() *model.Workflow {
+	w := &model.Workflow{
+		Classes:         make(map[model.Status]model.StatusClass, len(c.Statuses)),
+		Transitions:     make(map[model.Status][]model.Status, len(c.Transitions)),
+		DependencyTypes: make(map[model.DependencyType]model.DependencyTypeDef, len(c.DependencyTypes)),
+	}
+	for name, def := range c.Statuses {
+		w.Classes[model.Status(name)] = def.Class
+	}
+	for from, targets := range c.Transitions {
+		statuses := make([]model.Status, 0, len(targets))
+		for _, to := range targets {
+			statuses = append(statuses, model.Status(to))
+		}
+		w.Transitions[model.Status(from)] = statuses
+	}
+	for name, def := range c.DependencyTypes {
+		w.DependencyTypes[model.DependencyType(name)] = def
+	}
+	return w
+}
+
+// Install loads .bv/workflow.yaml from projectDir and makes it the active
+// workflow. Without a config file the built-in statuses stay in effect.
+func Install(projectDir string) error {
+	cfg, err := LoadConfig(projectDir)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		model.SetWorkflow(nil)
+		return nil
+	}
+	model.SetWorkflow(cfg.Workflow())
+	return nil
+}
+
+func classList() string {
+	names := make([]string, len(model.StatusClasses))
+	for i, c := range model.StatusClasses {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
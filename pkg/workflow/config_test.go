@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const gastown = `
+statuses:
+  QA:
+    class: active
+    description: Verifying on staging
+  staged: done
+  needs-info: blocked
+  wontfix: terminal
+transitions:
+  in_progress: [qa, blocked, open]
+  qa: [staged, in_progress]
+dependency_types:
+  Waits-For: {blocking: true}
+  tracks:
+    hierarchy: true
+    description: Epic membership
+  duplicates: {}
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(gastown))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if def := cfg.Statuses["qa"]; def.Class != model.ClassActive || def.Description != "Verifying on staging" {
+		t.Errorf("qa = %+v", def)
+	}
+	if cfg.Statuses["staged"].Class != model.ClassDone {
+		t.Errorf("shorthand class not decoded: %+v", cfg.Statuses["staged"])
+	}
+	if got := strings.Join(cfg.Transitions["qa"], ","); got != "staged,in_progress" {
+		t.Errorf("qa transitions = %s", got)
+	}
+	if !cfg.DependencyTypes["waits-for"].Blocking || !cfg.DependencyTypes["tracks"].Hierarchy {
+		t.Errorf("dependency types = %+v", cfg.DependencyTypes)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"statuses:\n  qa: reviewing\n":                                    "invalid class",
+		"statuses:\n  in qa: active\n":                                    "spaces",
+		"transitions:\n  qa: [open]\n":                                    "unknown status \"qa\"",
+		"statuses:\n  qa: active\ntransitions:\n  qa: [shipped]\n":        "unknown status \"shipped\"",
+		"dependency_types:\n  nests: {blocking: true, hierarchy: true}\n": "both blocking and hierarchy",
+	}
+	for input, want := range cases {
+		if _, err := Parse([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	dir := t.TempDir()
+	if err := Install(dir); err != nil {
+		t.Fatalf("Install without config: %v", err)
+	}
+	if model.ActiveWorkflow() != nil || model.Status("qa").IsValid() {
+		t.Fatal("missing config should leave only built-in statuses")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(gastown), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(dir); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	qa := model.Status("qa")
+	if !qa.IsValid() || qa.Class() != model.ClassActive || !qa.IsOpen() {
+		t.Errorf("qa: valid=%v class=%s open=%v", qa.IsValid(), qa.Class(), qa.IsOpen())
+	}
+	if !model.Status("staged").IsClosed() {
+		t.Error("staged should count as closed")
+	}
+	if c := model.Status("wontfix").Class(); !c.IsResolved() || model.Status("wontfix").IsClosed() {
+		t.Errorf("wontfix class = %s", c)
+	}
+	if model.StatusClosed.Class() != model.ClassDone || model.StatusInProgress.Class() != model.ClassActive {
+		t.Error("built-in classes changed")
+	}
+
+	if !model.CanTransition("qa", "staged") || model.CanTransition("qa", model.StatusClosed) {
+		t.Error("qa transitions not enforced")
+	}
+	if !model.CanTransition(model.StatusOpen, "staged") {
+		t.Error("statuses without transitions should move freely")
+	}
+
+	waits, tracks, dup := model.DependencyType("waits-for"), model.DependencyType("tracks"), model.DependencyType("duplicates")
+	if !waits.IsValid() || !waits.IsBlocking() || waits.IsHierarchy() {
+		t.Error("waits-for should be a valid blocking type")
+	}
+	if !tracks.IsHierarchy() || tracks.IsBlocking() || !dup.IsValid() || dup.IsBlocking() {
+		t.Error("tracks/duplicates semantics wrong")
+	}
+	if !model.DepBlocks.IsBlocking() || !model.DepParentChild.IsHierarchy() {
+		t.Error("built-in dependency semantics changed")
+	}
+
+	known := model.KnownStatuses()
+	if got := strings.Join([]string{string(known[len(known)-4]), string(known[len(known)-1])}, ","); got != "needs-info,wontfix" {
+		t.Errorf("custom statuses = %v", known)
+	}
+}
@@ -0,0 +1,647 @@
+package workspace
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultMaxCrossRepoPaths is how many cross-repo critical paths Analyze keeps
+const DefaultMaxCrossRepoPaths = 10
+
+// Reasons a cross-repo reference cannot be resolved
+const (
+	DanglingNotInWorkspace = "not_in_workspace" // prefix matches no repo in workspace.yaml
+	DanglingRepoDisabled   = "repo_disabled"    // repo is listed but enabled: false
+)
+
+// AnalysisOptions configures Analyze
+type AnalysisOptions struct {
+	Now      time.Time
+	MaxPaths int                        // Cross-repo critical paths to keep (0 = DefaultMaxCrossRepoPaths)
+	Health   analysis.LabelHealthConfig // Staleness threshold and health weights, shared with label health
+	Stats    *analysis.GraphStats       // Precomputed graph metrics of the merged issues (computed when nil)
+
+	// SkipGraphMetrics leaves a nil Stats uncomputed, so repo health goes
+	// without PageRank and betweenness. Interactive callers set it rather
+	// than run a full graph analysis on their own thread.
+	SkipGraphMetrics bool
+}
+
+// DefaultAnalysisOptions returns options using the label health defaults
+func DefaultAnalysisOptions() AnalysisOptions {
+	return AnalysisOptions{
+		Now:      time.Now(),
+		MaxPaths: DefaultMaxCrossRepoPaths,
+		Health:   analysis.DefaultLabelHealthConfig(),
+	}
+}
+
+// Analysis is the workspace-aware view of a merged issue set: each repo's
+// health, which repos block which, and the dependency chains that cross
+// repo boundaries.
+type Analysis struct {
+	GeneratedAt    time.Time       `json:"generated_at"`
+	Workspace      string          `json:"workspace,omitempty"`
+	Summary        AnalysisSummary `json:"summary"`
+	Repos          []RepoHealth    `json:"repos"`
+	Flow           RepoFlow        `json:"flow"`
+	CrossRepoPaths []CrossRepoPath `json:"cross_repo_paths"`
+	DanglingRefs   []DanglingRef   `json:"dangling_refs"`
+}
+
+// AnalysisSummary gives the headline numbers of an Analysis
+type AnalysisSummary struct {
+	RepoCount       int      `json:"repo_count"`
+	FailedRepos     int      `json:"failed_repos"`
+	IssueCount      int      `json:"issue_count"`
+	OpenCount       int      `json:"open_count"`
+	CrossRepoDeps   int      `json:"cross_repo_deps"`
+	DanglingRefs    int      `json:"dangling_refs"`
+	MissingRepos    []string `json:"missing_repos"`    // Prefixes referenced but not in workspace.yaml
+	AttentionNeeded []string `json:"attention_needed"` // Repos that failed to load or are below healthy
+}
+
+// RepoHealth summarizes one repository of the workspace
+type RepoHealth struct {
+	Repo             string                    `json:"repo"`
+	Prefix           string                    `json:"prefix"`
+	LoadError        string                    `json:"load_error,omitempty"`
+	IssueCount       int                       `json:"issue_count"`
+	OpenCount        int                       `json:"open_count"` // Not closed, including in progress and blocked
+	InProgressCount  int                       `json:"in_progress_count"`
+	BlockedCount     int                       `json:"blocked_count"` // Open but waiting on an open blocker
+	ClosedCount      int                       `json:"closed_count"`
+	ActionableCount  int                       `json:"actionable_count"`
+	Health           int                       `json:"health"` // Composite 0-100, weighted like label health
+	HealthLevel      string                    `json:"health_level"`
+	Velocity         analysis.VelocityMetrics  `json:"velocity"`
+	Freshness        analysis.FreshnessMetrics `json:"freshness"`
+	FlowScore        int                       `json:"flow_score"`        // 100 minus 5 per incoming cross-repo dependency
+	CriticalityScore int                       `json:"criticality_score"` // Graph importance of the repo's issues
+	// BlockedByOtherRepos counts open issues waiting on an open issue of another repo
+	BlockedByOtherRepos int `json:"blocked_by_other_repos"`
+	// BlockingOtherRepos counts open issues holding up open issues of another repo
+	BlockingOtherRepos int      `json:"blocking_other_repos"`
+	UpstreamRepos      []string `json:"upstream_repos"`   // Repos blocking this one
+	DownstreamRepos    []string `json:"downstream_repos"` // Repos this one blocks
+}
+
+// RepoFlow is the repo-to-repo counterpart of analysis.CrossLabelFlow.
+// Only open issues blocking open issues are counted.
+type RepoFlow struct {
+	Repos              []string         `json:"repos"`                 // Enabled repos in workspace.yaml order
+	BlockingMatrix     [][]int          `json:"blocking_matrix"`       // [from][to] blocking dependency counts
+	Dependencies       []RepoDependency `json:"dependencies"`          // Non-empty cells, largest first
+	BottleneckRepos    []string         `json:"bottleneck_repos"`      // Repos with the most outgoing blocks
+	TotalCrossRepoDeps int              `json:"total_cross_repo_deps"` // Sum of the matrix
+}
+
+// RepoDependency is one cell of the blocking matrix
+type RepoDependency struct {
+	FromRepo      string             `json:"from_repo"` // Repo of the blockers
+	ToRepo        string             `json:"to_repo"`   // Repo of the blocked issues
+	IssueCount    int                `json:"issue_count"`
+	BlockingPairs []RepoBlockingPair `json:"blocking_pairs"`
+}
+
+// RepoBlockingPair is a single cross-repo blocking dependency
+type RepoBlockingPair struct {
+	BlockerID string `json:"blocker_id"`
+	BlockedID string `json:"blocked_id"`
+}
+
+// CrossRepoPath is a longest chain of open blocking dependencies that
+// crosses at least one repo boundary. Work flows from the first issue to
+// the last, so IssueIDs[0] is the one to start with.
+type CrossRepoPath struct {
+	IssueIDs []string `json:"issue_ids"`
+	Repos    []string `json:"repos"` // Repos along the chain, consecutive repeats collapsed
+	Length   int      `json:"length"`
+	RepoHops int      `json:"repo_hops"`
+}
+
+// DanglingRef is a dependency on an issue of a repo the workspace does not load
+type DanglingRef struct {
+	IssueID        string               `json:"issue_id"`
+	Repo           string               `json:"repo"`      // Repo of the referencing issue
+	Reference      string               `json:"reference"` // The ID as written in the referencing repo
+	Prefix         string               `json:"prefix"`    // Prefix of the missing repo
+	DependencyType model.DependencyType `json:"dependency_type"`
+	Reason         string               `json:"reason"`
+}
+
+// Analyze computes the workspace analysis of issues loaded by an
+// AggregateLoader. results may be nil when load errors are not known.
+func Analyze(issues []model.Issue, config *Config, results []LoadResult, opts AnalysisOptions) Analysis {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.MaxPaths <= 0 {
+		opts.MaxPaths = DefaultMaxCrossRepoPaths
+	}
+	if opts.Health.StaleThresholdDays == 0 {
+		opts.Health = analysis.DefaultLabelHealthConfig()
+	}
+	if config == nil {
+		config = &Config{}
+	}
+
+	result := Analysis{
+		GeneratedAt:    opts.Now.UTC(),
+		Workspace:      config.Name,
+		Repos:          []RepoHealth{},
+		CrossRepoPaths: []CrossRepoPath{},
+		DanglingRefs:   []DanglingRef{},
+	}
+	result.Summary.MissingRepos = []string{}
+	result.Summary.AttentionNeeded = []string{}
+
+	loadErrors := make(map[string]string, len(results))
+	for _, r := range results {
+		if r.Error != nil {
+			loadErrors[r.RepoName] = r.Error.Error()
+		}
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	repoOf := make(map[string]string, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+		if repo := config.RepoForID(issues[i].ID); repo != nil {
+			repoOf[issues[i].ID] = repo.GetName()
+		}
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	actionable := make(map[string]bool)
+	for _, issue := range analyzer.GetActionableIssues() {
+		actionable[issue.ID] = true
+	}
+	stats := opts.Stats
+	if stats == nil && len(issues) > 0 && !opts.SkipGraphMetrics {
+		s := analyzer.Analyze()
+		stats = &s
+	}
+
+	result.Flow = computeRepoFlow(issues, enabledRepoNames(config), issueMap, repoOf)
+	result.Repos = computeRepoHealth(issues, config, repoOf, actionable, loadErrors, result.Flow, stats, opts)
+	result.CrossRepoPaths = crossRepoPaths(issues, issueMap, repoOf, opts.MaxPaths)
+	result.DanglingRefs = danglingRefs(issues, config, issueMap)
+
+	result.Summary.RepoCount = len(result.Repos)
+	result.Summary.IssueCount = len(issues)
+	result.Summary.CrossRepoDeps = result.Flow.TotalCrossRepoDeps
+	result.Summary.DanglingRefs = len(result.DanglingRefs)
+	for _, repo := range result.Repos {
+		result.Summary.OpenCount += repo.OpenCount
+		if repo.LoadError != "" {
+			result.Summary.FailedRepos++
+		}
+		if repo.LoadError != "" || repo.Health < analysis.HealthyThreshold {
+			result.Summary.AttentionNeeded = append(result.Summary.AttentionNeeded, repo.Repo)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, ref := range result.DanglingRefs {
+		if ref.Reason == DanglingNotInWorkspace && !seen[ref.Prefix] {
+			seen[ref.Prefix] = true
+			result.Summary.MissingRepos = append(result.Summary.MissingRepos, ref.Prefix)
+		}
+	}
+	sort.Strings(result.Summary.MissingRepos)
+
+	return result
+}
+
+// enabledRepoNames returns the enabled repos in config order
+func enabledRepoNames(config *Config) []string {
+	var names []string
+	for i := range config.Repos {
+		if config.Repos[i].IsEnabled() {
+			names = append(names, config.Repos[i].GetName())
+		}
+	}
+	return names
+}
+
+// openBlockers returns the open issues blocking an open issue
+func openBlockers(issue *model.Issue, issueMap map[string]*model.Issue) []*model.Issue {
+	if isResolved(issue.Status) {
+		return nil
+	}
+	var blockers []*model.Issue
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		blocker, ok := issueMap[dep.DependsOnID]
+		if !ok || blocker.ID == issue.ID || isResolved(blocker.Status) {
+			continue
+		}
+		blockers = append(blockers, blocker)
+	}
+	return blockers
+}
+
+func isResolved(status model.Status) bool {
+	return status.Class().IsResolved()
+}
+
+func computeRepoFlow(issues []model.Issue, repos []string, issueMap map[string]*model.Issue, repoOf map[string]string) RepoFlow {
+	n := len(repos)
+	index := make(map[string]int, n)
+	for i, r := range repos {
+		index[r] = i
+	}
+	matrix := make([][]int, n)
+	for i := range matrix {
+		matrix[i] = make([]int, n)
+	}
+
+	type pairKey struct{ from, to int }
+	cells := make(map[pairKey]*RepoDependency)
+	total := 0
+	for i := range issues {
+		blocked := &issues[i]
+		to, ok := index[repoOf[blocked.ID]]
+		if !ok {
+			continue
+		}
+		for _, blocker := range openBlockers(blocked, issueMap) {
+			from, ok := index[repoOf[blocker.ID]]
+			if !ok || from == to {
+				continue
+			}
+			matrix[from][to]++
+			total++
+			key := pairKey{from, to}
+			cell := cells[key]
+			if cell == nil {
+				cell = &RepoDependency{FromRepo: repos[from], ToRepo: repos[to]}
+				cells[key] = cell
+			}
+			cell.IssueCount++
+			cell.BlockingPairs = append(cell.BlockingPairs, RepoBlockingPair{BlockerID: blocker.ID, BlockedID: blocked.ID})
+		}
+	}
+
+	deps := make([]RepoDependency, 0, len(cells))
+	for _, cell := range cells {
+		deps = append(deps, *cell)
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].IssueCount != deps[j].IssueCount {
+			return deps[i].IssueCount > deps[j].IssueCount
+		}
+		if deps[i].FromRepo != deps[j].FromRepo {
+			return index[deps[i].FromRepo] < index[deps[j].FromRepo]
+		}
+		return index[deps[i].ToRepo] < index[deps[j].ToRepo]
+	})
+
+	bottlenecks := []string{}
+	maxOut := 0
+	for i, row := range matrix {
+		sum := 0
+		for _, v := range row {
+			sum += v
+		}
+		switch {
+		case sum == 0:
+		case sum > maxOut:
+			maxOut = sum
+			bottlenecks = []string{repos[i]}
+		case sum == maxOut:
+			bottlenecks = append(bottlenecks, repos[i])
+		}
+	}
+
+	if repos == nil {
+		repos = []string{}
+	}
+	return RepoFlow{
+		Repos:              repos,
+		BlockingMatrix:     matrix,
+		Dependencies:       deps,
+		BottleneckRepos:    bottlenecks,
+		TotalCrossRepoDeps: total,
+	}
+}
+
+func computeRepoHealth(issues []model.Issue, config *Config, repoOf map[string]string, actionable map[string]bool, loadErrors map[string]string, flow RepoFlow, stats *analysis.GraphStats, opts AnalysisOptions) []RepoHealth {
+	byRepo := make(map[string][]model.Issue)
+	for _, issue := range issues {
+		if repo, ok := repoOf[issue.ID]; ok {
+			byRepo[repo] = append(byRepo[repo], issue)
+		}
+	}
+
+	var pr, bw map[string]float64
+	var maxPR, maxBW float64
+	if stats != nil {
+		pr, bw = stats.PageRank(), stats.Betweenness()
+		maxPR, maxBW = maxValue(pr), maxValue(bw)
+	}
+
+	repos := make([]RepoHealth, 0, len(flow.Repos))
+	for i := range config.Repos {
+		cfg := &config.Repos[i]
+		if !cfg.IsEnabled() {
+			continue
+		}
+		name := cfg.GetName()
+		h := RepoHealth{
+			Repo:            name,
+			Prefix:          cfg.GetPrefix(),
+			LoadError:       loadErrors[name],
+			HealthLevel:     analysis.HealthLevelCritical,
+			UpstreamRepos:   []string{},
+			DownstreamRepos: []string{},
+		}
+		repoIssues := byRepo[name]
+		h.IssueCount = len(repoIssues)
+
+		row := -1
+		for j, r := range flow.Repos {
+			if r == name {
+				row = j
+			}
+		}
+		incoming := 0
+		if row >= 0 {
+			for j, r := range flow.Repos {
+				if flow.BlockingMatrix[j][row] > 0 {
+					incoming += flow.BlockingMatrix[j][row]
+					h.UpstreamRepos = append(h.UpstreamRepos, r)
+				}
+				if flow.BlockingMatrix[row][j] > 0 {
+					h.DownstreamRepos = append(h.DownstreamRepos, r)
+				}
+			}
+		}
+		blockedBy := make(map[string]bool)
+		blocking := make(map[string]bool)
+		for _, dep := range flow.Dependencies {
+			for _, pair := range dep.BlockingPairs {
+				if dep.ToRepo == name {
+					blockedBy[pair.BlockedID] = true
+				}
+				if dep.FromRepo == name {
+					blocking[pair.BlockerID] = true
+				}
+			}
+		}
+		h.BlockedByOtherRepos, h.BlockingOtherRepos = len(blockedBy), len(blocking)
+
+		if h.IssueCount == 0 {
+			repos = append(repos, h)
+			continue
+		}
+
+		var prSum, bwMax float64
+		for _, issue := range repoIssues {
+			switch {
+			case isResolved(issue.Status):
+				h.ClosedCount++
+				continue
+			case issue.Status.Class() == model.ClassActive:
+				h.InProgressCount++
+			}
+			h.OpenCount++
+			if actionable[issue.ID] {
+				h.ActionableCount++
+			} else {
+				h.BlockedCount++
+			}
+			prSum += pr[issue.ID]
+			if bw[issue.ID] > bwMax {
+				bwMax = bw[issue.ID]
+			}
+		}
+
+		h.Velocity = analysis.ComputeVelocityMetrics(repoIssues, opts.Now)
+		h.Freshness = analysis.ComputeFreshnessMetrics(repoIssues, opts.Now, opts.Health.StaleThresholdDays)
+		h.FlowScore = clampScore(100 - incoming*5)
+		if maxPR > 0 {
+			h.CriticalityScore += int(prSum / float64(h.IssueCount) / maxPR * 50)
+		}
+		if maxBW > 0 {
+			h.CriticalityScore += int(bwMax / maxBW * 50)
+		}
+		h.CriticalityScore = clampScore(h.CriticalityScore)
+		h.Health = analysis.ComputeCompositeHealth(h.Velocity.VelocityScore, h.Freshness.FreshnessScore, h.FlowScore, h.CriticalityScore, opts.Health)
+		h.HealthLevel = analysis.HealthLevelFromScore(h.Health)
+		repos = append(repos, h)
+	}
+	return repos
+}
+
+// crossRepoPaths finds the longest open blocking chain ending at each issue
+// and keeps the maximal ones that cross a repo boundary, longest first.
+// Ties between chains of equal length go to the one with more repo hops.
+func crossRepoPaths(issues []model.Issue, issueMap map[string]*model.Issue, repoOf map[string]string, limit int) []CrossRepoPath {
+	type chain struct {
+		length, hops int
+		prev         string
+	}
+	memo := make(map[string]chain, len(issues))
+	visiting := make(map[string]bool)
+	var walk func(issue *model.Issue) chain
+	walk = func(issue *model.Issue) chain {
+		if c, ok := memo[issue.ID]; ok {
+			return c
+		}
+		visiting[issue.ID] = true
+		best := chain{length: 1}
+		for _, blocker := range openBlockers(issue, issueMap) {
+			if visiting[blocker.ID] {
+				continue // cycle; --robot-insights reports those
+			}
+			c := walk(blocker)
+			hops := c.hops
+			if repoOf[blocker.ID] != repoOf[issue.ID] {
+				hops++
+			}
+			better := c.length+1 > best.length ||
+				(c.length+1 == best.length && hops > best.hops) ||
+				(c.length+1 == best.length && hops == best.hops && best.prev != "" && blocker.ID < best.prev)
+			if better {
+				best = chain{length: c.length + 1, hops: hops, prev: blocker.ID}
+			}
+		}
+		visiting[issue.ID] = false
+		memo[issue.ID] = best
+		return best
+	}
+
+	for i := range issues {
+		if !isResolved(issues[i].Status) {
+			walk(&issues[i])
+		}
+	}
+
+	inner := make(map[string]bool)
+	for _, c := range memo {
+		if c.prev != "" {
+			inner[c.prev] = true
+		}
+	}
+	var ends []string
+	for id, c := range memo {
+		if c.hops > 0 && !inner[id] {
+			ends = append(ends, id)
+		}
+	}
+	sort.Slice(ends, func(i, j int) bool {
+		a, b := memo[ends[i]], memo[ends[j]]
+		if a.length != b.length {
+			return a.length > b.length
+		}
+		if a.hops != b.hops {
+			return a.hops > b.hops
+		}
+		return ends[i] < ends[j]
+	})
+	if len(ends) > limit {
+		ends = ends[:limit]
+	}
+
+	paths := make([]CrossRepoPath, 0, len(ends))
+	for _, end := range ends {
+		var ids []string
+		for id := end; id != ""; id = memo[id].prev {
+			ids = append(ids, id)
+		}
+		path := CrossRepoPath{Length: len(ids), RepoHops: memo[end].hops}
+		for i := len(ids) - 1; i >= 0; i-- {
+			path.IssueIDs = append(path.IssueIDs, ids[i])
+			if repo := repoOf[ids[i]]; len(path.Repos) == 0 || path.Repos[len(path.Repos)-1] != repo {
+				path.Repos = append(path.Repos, repo)
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// danglingRefs lists dependencies on repos the workspace does not load.
+// The aggregate loader qualifies references it does not recognize with the
+// referencing repo's prefix, so a reference like "billing-7" from the "api-"
+// repo arrives as "api-billing-7". It is told apart from a missing local
+// issue, or an unqualified reference to another loaded repo, by its own
+// prefix, which no loaded issue uses.
+func danglingRefs(issues []model.Issue, config *Config, issueMap map[string]*model.Issue) []DanglingRef {
+	native := make(map[string]bool)
+	for _, issue := range issues {
+		id := issue.ID
+		if repo := config.RepoForID(id); repo != nil {
+			id = UnqualifyID(id, repo.GetPrefix())
+		}
+		if p := idPrefix(id); p != "" {
+			native[p] = true
+		}
+	}
+
+	refs := []DanglingRef{}
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		source := config.RepoForID(issue.ID)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.DependsOnID == "" {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; ok {
+				continue
+			}
+			ref := DanglingRef{IssueID: issue.ID, DependencyType: dep.Type}
+			if source != nil {
+				ref.Repo = source.GetName()
+			}
+
+			target := config.RepoForID(dep.DependsOnID)
+			switch {
+			case target != nil && target != source:
+				// A repo from workspace.yaml; only disabled ones are dangling,
+				// other misses are deleted issues or failed loads
+				if target.IsEnabled() {
+					continue
+				}
+				ref.Reference = dep.DependsOnID
+				ref.Prefix = target.GetPrefix()
+				ref.Reason = DanglingRepoDisabled
+			default:
+				raw := dep.DependsOnID
+				if target != nil {
+					raw = UnqualifyID(raw, target.GetPrefix())
+				}
+				p := idPrefix(raw)
+				if p == "" || native[p] {
+					continue
+				}
+				if other := config.RepoForID(raw); other != nil && !other.IsEnabled() {
+					ref.Prefix = other.GetPrefix()
+					ref.Reason = DanglingRepoDisabled
+				} else {
+					ref.Prefix = p
+					ref.Reason = DanglingNotInWorkspace
+				}
+				ref.Reference = raw
+			}
+
+			key := ref.IssueID + "\x00" + ref.Reference
+			if !seen[key] {
+				seen[key] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Prefix != refs[j].Prefix {
+			return refs[i].Prefix < refs[j].Prefix
+		}
+		if refs[i].IssueID != refs[j].IssueID {
+			return refs[i].IssueID < refs[j].IssueID
+		}
+		return refs[i].Reference < refs[j].Reference
+	})
+	return refs
+}
+
+// idPrefix returns the "name-" prefix of a beads ID such as "billing-7",
+// or "" when the ID has none
+func idPrefix(id string) string {
+	i := strings.IndexByte(id, '-')
+	if i <= 0 || i == len(id)-1 {
+		return ""
+	}
+	for _, r := range id[:i] {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return ""
+		}
+	}
+	return id[:i+1]
+}
+
+func maxValue(m map[string]float64) float64 {
+	var max float64
+	for _, v := range m {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+func clampScore(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
@@ -0,0 +1,158 @@
+package workspace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+func blocks(id string, blockers ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, b := range blockers {
+		deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return deps
+}
+
+func TestAnalyzeWorkspace(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	disabled := false
+	config := &workspace.Config{
+		Name: "shop",
+		Repos: []workspace.RepoConfig{
+			{Name: "api", Path: "services/api", Prefix: "api-"},
+			{Name: "web", Path: "apps/web", Prefix: "web-"},
+			{Name: "lib", Path: "packages/lib", Prefix: "lib-"},
+			{Name: "legacy", Path: "legacy", Prefix: "old-", Enabled: &disabled},
+		},
+	}
+	issue := func(id string, status model.Status, blockers ...string) model.Issue {
+		return model.Issue{ID: id, Title: id, Status: status, IssueType: model.TypeTask, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now, Dependencies: blocks(id, blockers...)}
+	}
+	issues := []model.Issue{
+		issue("lib-L-1", model.StatusOpen),
+		issue("api-A-1", model.StatusInProgress, "lib-L-1"),
+		issue("api-A-2", model.StatusOpen, "api-A-1", "api-billing-7"), // unknown repo, qualified by the loader
+		issue("web-W-1", model.StatusOpen, "api-A-2", "old-9"),
+		issue("web-W-2", model.StatusOpen, "api-A-1", "web-W-9"), // W-9 is a missing local issue
+		issue("web-W-3", model.StatusClosed, "lib-L-1"),
+	}
+
+	opts := workspace.DefaultAnalysisOptions()
+	opts.Now = now
+	results := []workspace.LoadResult{{RepoName: "api"}, {RepoName: "web"}, {RepoName: "lib", Error: os.ErrNotExist}}
+	got := workspace.Analyze(issues, config, results, opts)
+
+	if got.Workspace != "shop" || got.Summary.RepoCount != 3 || got.Summary.IssueCount != 6 || got.Summary.OpenCount != 5 || got.Summary.FailedRepos != 1 {
+		t.Errorf("summary = %+v", got.Summary)
+	}
+
+	// Repo-to-repo blocking matrix, rows block columns; closed issues don't count
+	if !slices.Equal(got.Flow.Repos, []string{"api", "web", "lib"}) {
+		t.Fatalf("flow repos = %v", got.Flow.Repos)
+	}
+	want := [][]int{{0, 2, 0}, {0, 0, 0}, {1, 0, 0}}
+	for i := range want {
+		if !slices.Equal(got.Flow.BlockingMatrix[i], want[i]) {
+			t.Fatalf("matrix = %v, want %v", got.Flow.BlockingMatrix, want)
+		}
+	}
+	if got.Flow.TotalCrossRepoDeps != 3 || !slices.Equal(got.Flow.BottleneckRepos, []string{"api"}) {
+		t.Errorf("flow = %+v", got.Flow)
+	}
+	if d := got.Flow.Dependencies[0]; d.FromRepo != "api" || d.ToRepo != "web" || d.IssueCount != 2 {
+		t.Errorf("top dependency = %+v", d)
+	}
+
+	api := got.Repos[0]
+	if api.Repo != "api" || api.OpenCount != 2 || api.InProgressCount != 1 || api.BlockedCount != 2 || api.ActionableCount != 0 {
+		t.Errorf("api counts = %+v", api)
+	}
+	if api.BlockedByOtherRepos != 1 || api.BlockingOtherRepos != 2 || !slices.Equal(api.UpstreamRepos, []string{"lib"}) || !slices.Equal(api.DownstreamRepos, []string{"web"}) {
+		t.Errorf("api cross-repo = %+v", api)
+	}
+	if api.FlowScore != 95 || api.HealthLevel == "" {
+		t.Errorf("api health = %d/%s flow %d", api.Health, api.HealthLevel, api.FlowScore)
+	}
+	if lib := got.Repos[2]; lib.LoadError == "" || lib.ActionableCount != 1 || !slices.Contains(got.Summary.AttentionNeeded, "lib") {
+		t.Errorf("lib = %+v, attention %v", lib, got.Summary.AttentionNeeded)
+	}
+
+	// Longest open chain crossing repos, blocker first
+	if len(got.CrossRepoPaths) == 0 {
+		t.Fatal("no cross-repo paths")
+	}
+	path := got.CrossRepoPaths[0]
+	if !slices.Equal(path.IssueIDs, []string{"lib-L-1", "api-A-1", "api-A-2", "web-W-1"}) || !slices.Equal(path.Repos, []string{"lib", "api", "web"}) || path.RepoHops != 2 {
+		t.Errorf("path = %+v", path)
+	}
+	for _, p := range got.CrossRepoPaths {
+		if p.IssueIDs[len(p.IssueIDs)-1] == "api-A-2" {
+			t.Errorf("sub-path of a longer chain reported: %v", p.IssueIDs)
+		}
+	}
+
+	// Missing local issues are not cross-repo references
+	var refs []string
+	for _, r := range got.DanglingRefs {
+		refs = append(refs, r.IssueID+"→"+r.Reference+" "+r.Prefix+" "+r.Reason)
+	}
+	wantRefs := []string{"api-A-2→billing-7 billing- not_in_workspace", "web-W-1→old-9 old- repo_disabled"}
+	if !slices.Equal(refs, wantRefs) {
+		t.Errorf("dangling refs = %v\nwant %v", refs, wantRefs)
+	}
+	if !slices.Equal(got.Summary.MissingRepos, []string{"billing-"}) {
+		t.Errorf("missing repos = %v", got.Summary.MissingRepos)
+	}
+
+	// Without graph metrics, criticality drops out of health instead of
+	// triggering an analysis
+	if api.CriticalityScore == 0 {
+		t.Fatal("expected computed metrics to give api a criticality score")
+	}
+	opts.SkipGraphMetrics = true
+	skipped := workspace.Analyze(issues, config, results, opts)
+	for _, repo := range skipped.Repos {
+		if repo.CriticalityScore != 0 {
+			t.Errorf("%s criticality = %d without metrics, want 0", repo.Repo, repo.CriticalityScore)
+		}
+	}
+	if skipped.Flow.TotalCrossRepoDeps != got.Flow.TotalCrossRepoDeps || len(skipped.CrossRepoPaths) != len(got.CrossRepoPaths) {
+		t.Errorf("skipping metrics changed the flow: %+v", skipped.Flow)
+	}
+}
+
+func TestAnalyzeLoadedWorkspace(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTestBeadsFile(t, filepath.Join(tmpDir, "api"), []model.Issue{
+		{ID: "AUTH-1", Title: "Auth", Dependencies: blocks("AUTH-1", "UI-1", "pay-3", "AUTH-404")},
+	})
+	createTestBeadsFile(t, filepath.Join(tmpDir, "web"), []model.Issue{
+		{ID: "UI-1", Title: "Login page", Dependencies: blocks("UI-1", "api-AUTH-2")},
+		{ID: "UI-2", Title: "Signup page", Dependencies: blocks("UI-2", "api-AUTH-1")},
+	})
+	config := &workspace.Config{Repos: []workspace.RepoConfig{
+		{Name: "api", Path: "api", Prefix: "api-"},
+		{Name: "web", Path: "web", Prefix: "web-"},
+	}}
+	issues, results, err := workspace.NewAggregateLoader(config, tmpDir).LoadAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := workspace.Analyze(issues, config, results, workspace.DefaultAnalysisOptions())
+	// The loader reads the api's unqualified "UI-1" as a local ID, so only
+	// web-UI-2 ← api-AUTH-1 crosses repos; it is still no dangling reference
+	if got.Flow.TotalCrossRepoDeps != 1 {
+		t.Errorf("cross-repo deps = %d, want 1 (web-UI-2 ← api-AUTH-1): %+v", got.Flow.TotalCrossRepoDeps, got.Flow.Dependencies)
+	}
+	if len(got.DanglingRefs) != 1 || got.DanglingRefs[0].Reference != "pay-3" || got.DanglingRefs[0].Repo != "api" {
+		t.Errorf("dangling refs = %+v", got.DanglingRefs)
+	}
+}
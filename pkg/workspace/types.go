@@ -148,6 +148,21 @@ func (r *RepoConfig) IsEnabled() bool {
 	return *r.Enabled
 }
 
+// RepoForID returns the repo whose prefix is the longest prefix of a
+// namespaced ID, or nil. Disabled repos are included so callers can tell
+// them apart from repos missing from the config.
+func (c *Config) RepoForID(id string) *RepoConfig {
+	var best *RepoConfig
+	bestLen := 0
+	for i := range c.Repos {
+		prefix := c.Repos[i].GetPrefix()
+		if len(prefix) > bestLen && len(id) > len(prefix) && strings.HasPrefix(id, prefix) {
+			best, bestLen = &c.Repos[i], len(prefix)
+		}
+	}
+	return best
+}
+
 // LoadConfig loads a workspace configuration from a file
 func LoadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -587,3 +587,34 @@ func TestIDResolverDisabledRepos(t *testing.T) {
 		t.Error("Disabled repo prefix should not be recognized")
 	}
 }
+
+func TestConfigRepoForID(t *testing.T) {
+	disabled := false
+	config := &workspace.Config{
+		Repos: []workspace.RepoConfig{
+			{Name: "api", Path: "services/api", Prefix: "api-"},
+			{Name: "api-v2", Path: "services/api-v2", Prefix: "api-v2-"},
+			{Name: "web", Path: "apps/web", Prefix: "web-", Enabled: &disabled},
+		},
+	}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"api-AUTH-1", "api"},
+		{"api-v2-AUTH-1", "api-v2"}, // longest prefix wins
+		{"web-UI-1", "web"},         // disabled repos still resolve
+		{"billing-7", ""},
+		{"api-", ""}, // a bare prefix is no ID
+	}
+	for _, tt := range tests {
+		got := ""
+		if repo := config.RepoForID(tt.id); repo != nil {
+			got = repo.GetName()
+		}
+		if got != tt.want {
+			t.Errorf("RepoForID(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
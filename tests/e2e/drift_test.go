@@ -332,7 +332,7 @@ node_growth_info_pct: 1000
 edge_growth_info_pct: 1000
 pagerank_change_warning_pct: 1000
 actionable_increase_info_pct: 1000
-actionable_decrease_warning_pct: 1000`
+actionable_decrease_warning_pct: 100`
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
 		t.Fatal(err)
 	}
@@ -453,13 +453,21 @@ func TestDriftErrorHandling(t *testing.T) {
 	cmdCheck.Dir = envDir
 	out, err = cmdCheck.CombinedOutput()
 
-	// Should still SUCCEED (fallback to defaults) but print warning
-	if err != nil {
-		t.Errorf("Expected success (fallback) with invalid config, got error: %v\nOutput: %s", err, out)
+	// Should FAIL rather than check against the default thresholds
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 with invalid config, got %v\nOutput: %s", err, out)
 	}
 
 	output := string(out)
-	if !strings.Contains(output, "Warning: Error loading drift config") {
-		t.Errorf("Expected warning about invalid config, got:\n%s", output)
+	if !strings.Contains(output, "Error loading drift config") {
+		t.Errorf("Expected error about invalid config, got:\n%s", output)
+	}
+
+	// Robot mode reports it the same way
+	cmdCheck = exec.Command(binPath, "--check-drift", "--robot-drift")
+	cmdCheck.Dir = envDir
+	out, err = cmdCheck.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 || !strings.Contains(string(out), "Error loading drift config") {
+		t.Errorf("Expected robot-mode config error, got %v\nOutput: %s", err, out)
 	}
 }
@@ -158,6 +158,73 @@ func TestRobotForecast_AllAndLabelFilter(t *testing.T) {
 	}
 }
 
+func TestRobotForecast_MonteCarloPercentiles(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, start := createForecastRepo(t)
+
+	type percentile struct {
+		Days float64 `json:"days"`
+		Date string  `json:"date"`
+	}
+	type mcPayload struct {
+		MonteCarlo *struct {
+			Target    string     `json:"target"`
+			Trials    int        `json:"trials"`
+			Seed      int64      `json:"seed"`
+			P50       percentile `json:"p50"`
+			P85       percentile `json:"p85"`
+			P95       percentile `json:"p95"`
+			Histogram []struct {
+				Count int `json:"count"`
+			} `json:"histogram"`
+		} `json:"monte_carlo"`
+	}
+
+	run := func(args ...string) mcPayload {
+		cmd := exec.Command(bv, append([]string{"--robot-forecast", "OPEN-1"}, args...)...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("--robot-forecast failed: %v\n%s", err, out)
+		}
+		var payload mcPayload
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		return payload
+	}
+
+	p1 := run("--forecast-trials", "200", "--forecast-seed", "9")
+	p2 := run("--forecast-trials", "200", "--forecast-seed", "9")
+	if p1.MonteCarlo == nil || p2.MonteCarlo == nil {
+		t.Fatalf("expected monte_carlo block in forecast output")
+	}
+	mc := p1.MonteCarlo
+	if mc.Target != "bead:OPEN-1" || mc.Trials != 200 || mc.Seed != 9 {
+		t.Fatalf("unexpected monte_carlo header: %+v", mc)
+	}
+	if mc.P50.Days > mc.P85.Days || mc.P85.Days > mc.P95.Days {
+		t.Fatalf("percentiles not ordered: %+v %+v %+v", mc.P50, mc.P85, mc.P95)
+	}
+	if mustParseRFC3339(t, mc.P95.Date).Before(start.Add(-time.Minute)) {
+		t.Fatalf("p95 date should not be in the past: %s", mc.P95.Date)
+	}
+	total := 0
+	for _, b := range mc.Histogram {
+		total += b.Count
+	}
+	if total != 200 {
+		t.Fatalf("histogram counts sum to %d, want 200", total)
+	}
+	if p1.MonteCarlo.P85.Days != p2.MonteCarlo.P85.Days || p1.MonteCarlo.P95.Days != p2.MonteCarlo.P95.Days {
+		t.Fatalf("same seed should reproduce percentiles: %+v vs %+v", p1.MonteCarlo.P85, p2.MonteCarlo.P85)
+	}
+
+	if off := run("--forecast-trials", "0"); off.MonteCarlo != nil {
+		t.Fatalf("expected --forecast-trials=0 to disable monte_carlo output")
+	}
+}
+
 func mustParseRFC3339(t *testing.T, s string) time.Time {
 	t.Helper()
 	parsed, err := time.Parse(time.RFC3339, s)
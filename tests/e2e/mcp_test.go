@@ -0,0 +1,120 @@
+package main_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestMCP_StdioSession drives `bv mcp` the way an agent host does: write
+// requests on stdin, read one JSON-RPC message per stdout line, close stdin.
+func TestMCP_StdioSession(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	beads := `{"id":"MC-1","title":"Parser","status":"open","priority":1,"issue_type":"task"}
+{"id":"MC-2","title":"Codegen","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"MC-2","depends_on_id":"MC-1","type":"blocks"}]}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "mcp", "--no-watch")
+	cmd.Dir = repoDir
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start bv mcp: %v", err)
+	}
+	defer func() { _ = cmd.Process.Kill() }()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	send := func(msg string) {
+		t.Helper()
+		if _, err := io.WriteString(stdin, msg+"\n"); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	recv := func(id int) map[string]any {
+		t.Helper()
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("bv mcp closed stdout")
+			}
+			var msg map[string]any
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				t.Fatalf("stdout line is not JSON-RPC: %q", line)
+			}
+			if msg["id"] != float64(id) {
+				t.Fatalf("got %v, want response to request %d", msg, id)
+			}
+			return msg
+		case <-time.After(30 * time.Second):
+			t.Fatalf("no response to request %d", id)
+		}
+		return nil
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"e2e","version":"0"}}}`)
+	init := recv(1)["result"].(map[string]any)
+	if init["serverInfo"].(map[string]any)["name"] != "bv" {
+		t.Errorf("serverInfo = %v", init["serverInfo"])
+	}
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"next"}}`)
+	next := recv(2)["result"].(map[string]any)
+	if next["structuredContent"].(map[string]any)["id"] != "MC-1" {
+		t.Errorf("next = %v, want MC-1", next)
+	}
+
+	send(`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"bv://issues/MC-2"}}`)
+	contents := recv(3)["result"].(map[string]any)["contents"].([]any)
+	var issue map[string]any
+	if err := json.Unmarshal([]byte(contents[0].(map[string]any)["text"].(string)), &issue); err != nil || issue["title"] != "Codegen" {
+		t.Errorf("bead resource = %v (%v)", contents, err)
+	}
+
+	send(`{"jsonrpc":"2.0","id":4,"method":"no/such/method"}`)
+	if code := recv(4)["error"].(map[string]any)["code"]; code != float64(-32601) {
+		t.Errorf("unknown method code = %v", code)
+	}
+
+	stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("bv mcp exited with %v after stdin closed", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("bv mcp did not exit when stdin closed")
+	}
+	for line := range lines {
+		t.Errorf("unexpected output after session: %s", line)
+	}
+}
@@ -0,0 +1,101 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRobotBlame_AttributesLinesToBeads commits a file in two steps, the
+// second for bead BL-1, and checks only the second step's lines name it.
+func TestRobotBlame_AttributesLinesToBeads(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+
+	write := func(rel, content string) {
+		path := filepath.Join(repoDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	git("init")
+	write(".beads/beads.jsonl", `{"id":"BL-1","title":"Retry uploads","status":"open","priority":1,"issue_type":"feature"}`+"\n")
+	write("src/upload.go", "package src\n\nfunc Upload() {\n}\n")
+	git("add", ".")
+	git("commit", "-m", "Initial scaffold")
+	write(".beads/beads.jsonl", `{"id":"BL-1","title":"Retry uploads","status":"in_progress","priority":1,"issue_type":"feature"}`+"\n")
+	write("src/upload.go", "package src\n\nfunc Upload() {\n\tretry()\n}\n")
+	git("add", ".")
+	git("commit", "-m", "BL-1: retry failed uploads")
+
+	cmd := exec.Command(bv, "--robot-blame", "src/upload.go:3-5")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-blame failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash  string `json:"data_hash"`
+		FilePath  string `json:"file_path"`
+		StartLine int    `json:"start_line"`
+		EndLine   int    `json:"end_line"`
+		Stats     struct {
+			TotalLines      int `json:"total_lines"`
+			AttributedLines int `json:"attributed_lines"`
+		} `json:"stats"`
+		Ranges []struct {
+			StartLine int    `json:"start_line"`
+			EndLine   int    `json:"end_line"`
+			Summary   string `json:"summary"`
+			Beads     []struct {
+				BeadID     string  `json:"bead_id"`
+				Title      string  `json:"title"`
+				Confidence float64 `json:"confidence"`
+			} `json:"beads"`
+		} `json:"ranges"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" || payload.FilePath != "src/upload.go" || payload.StartLine != 3 || payload.EndLine != 5 {
+		t.Fatalf("header = %s", out)
+	}
+	if payload.Stats.TotalLines != 3 || len(payload.Ranges) != 3 {
+		t.Fatalf("want 3 single-line ranges, got %s", out)
+	}
+	mid := payload.Ranges[1]
+	if mid.StartLine != 4 || !strings.HasPrefix(mid.Summary, "BL-1") || len(mid.Beads) != 1 ||
+		mid.Beads[0].BeadID != "BL-1" || mid.Beads[0].Title != "Retry uploads" || mid.Beads[0].Confidence <= 0 {
+		t.Errorf("line 4 range = %+v, want BL-1", mid)
+	}
+	if len(payload.Ranges[0].Beads) != 0 {
+		t.Errorf("scaffold line attributed to %+v", payload.Ranges[0].Beads)
+	}
+
+	cmd = exec.Command(bv, "--robot-blame", "src/upload.go:9-2")
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "invalid line range") {
+		t.Errorf("bad range should fail, got err=%v out=%s", err, out)
+	}
+}
@@ -0,0 +1,96 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestRobotCFD_FromGitHistory replays three dated beads commits: the first
+// predates the window and seeds the baseline, the other two are replayed.
+func TestRobotCFD_FromGitHistory(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	commit := func(daysAgo int, status1, status2 string) {
+		content := `{"id":"CF-1","title":"Parser","status":"` + status1 + `","priority":1,"issue_type":"task"}` + "\n" +
+			`{"id":"CF-2","title":"Exporter","status":"` + status2 + `","priority":2,"issue_type":"task"}` + "\n"
+		if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+		date := now.AddDate(0, 0, -daysAgo).Format(time.RFC3339)
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "day " + date}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoDir
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=Test",
+				"GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=Test",
+				"GIT_COMMITTER_EMAIL=test@example.com",
+				"GIT_AUTHOR_DATE="+date,
+				"GIT_COMMITTER_DATE="+date,
+			)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	if out, err := exec.Command("git", "-C", repoDir, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	commit(10, "open", "open")
+	commit(6, "in_progress", "open")
+	commit(3, "closed", "in_progress")
+
+	cmd := exec.Command(bv, "--robot-cfd", "--history-since=8d")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-cfd failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash string   `json:"data_hash"`
+		Since    string   `json:"since"`
+		Until    string   `json:"until"`
+		Statuses []string `json:"statuses"`
+		Days     []struct {
+			Date   string         `json:"date"`
+			Counts map[string]int `json:"counts"`
+			Total  int            `json:"total"`
+		} `json:"days"`
+		BaselineCommit  string `json:"baseline_commit"`
+		CommitsReplayed int    `json:"commits_replayed"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+
+	if len(payload.Days) != 9 || payload.Until != now.Format("2006-01-02") {
+		t.Fatalf("window %s..%s with %d days, want 9 days ending today", payload.Since, payload.Until, len(payload.Days))
+	}
+	if payload.BaselineCommit == "" || payload.CommitsReplayed != 2 {
+		t.Errorf("baseline %q replayed %d, want a baseline and 2 replayed commits", payload.BaselineCommit, payload.CommitsReplayed)
+	}
+	if !reflect.DeepEqual(payload.Statuses, []string{"closed", "in_progress", "open"}) {
+		t.Errorf("statuses = %v", payload.Statuses)
+	}
+	if first := payload.Days[0]; !reflect.DeepEqual(first.Counts, map[string]int{"open": 2}) || first.Total != 2 {
+		t.Errorf("first day = %+v, want both beads open", first)
+	}
+	if last := payload.Days[len(payload.Days)-1]; !reflect.DeepEqual(last.Counts, map[string]int{"closed": 1, "in_progress": 1}) {
+		t.Errorf("last day = %+v", last)
+	}
+	if payload.DataHash == "" {
+		t.Error("missing data_hash")
+	}
+}
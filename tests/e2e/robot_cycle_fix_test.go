@@ -0,0 +1,67 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"testing"
+)
+
+func TestRobotCycleFix_EmitsCommands(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	// A -> B -> C -> A plus an independent bead.
+	writeBeads(t, env, `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"B","type":"blocks"}]}
+{"id":"B","title":"Beta","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"C","type":"blocks"}]}
+{"id":"C","title":"Gamma","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"A","type":"blocks"}]}
+{"id":"D","title":"Delta","status":"open","priority":2,"issue_type":"task"}`)
+
+	cmd := exec.Command(bv, "--robot-cycle-fix")
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--robot-cycle-fix failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash   string `json:"data_hash"`
+		Components []struct {
+			Members    []string `json:"members"`
+			Candidates []struct {
+				Command string `json:"command"`
+				Delta   struct {
+					Actionable int `json:"actionable"`
+				} `json:"delta"`
+			} `json:"candidates"`
+		} `json:"components"`
+		Before struct {
+			CyclicComponents int `json:"cyclic_components"`
+		} `json:"before"`
+		After struct {
+			CyclicComponents int `json:"cyclic_components"`
+			ActionableCount  int `json:"actionable_count"`
+		} `json:"after"`
+		Commands []string `json:"commands"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" {
+		t.Fatalf("expected data_hash in envelope")
+	}
+	if len(payload.Components) != 1 || len(payload.Components[0].Members) != 3 {
+		t.Fatalf("components=%+v; want one 3-member component", payload.Components)
+	}
+	if len(payload.Components[0].Candidates) != 3 {
+		t.Fatalf("candidates=%d; want 3", len(payload.Components[0].Candidates))
+	}
+	if payload.Before.CyclicComponents != 1 || payload.After.CyclicComponents != 0 {
+		t.Fatalf("cyclic before=%d after=%d", payload.Before.CyclicComponents, payload.After.CyclicComponents)
+	}
+	if payload.After.ActionableCount != 2 {
+		t.Fatalf("actionable after=%d; want 2", payload.After.ActionableCount)
+	}
+	if len(payload.Commands) != 1 || payload.Commands[0] != "bd dep remove A B" {
+		t.Fatalf("commands=%v", payload.Commands)
+	}
+}
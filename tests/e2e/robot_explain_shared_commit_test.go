@@ -0,0 +1,119 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRobotExplainCorrelation_SplitsSharedCommit closes two beads in one
+// commit that mostly changes the file only the first bead worked on before.
+func TestRobotExplainCorrelation_SplitsSharedCommit(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(repoDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	beads := func(status1, status2 string) {
+		write(".beads/beads.jsonl",
+			`{"id":"SH-1","title":"Parser error recovery","status":"`+status1+`","priority":1,"issue_type":"feature"}`+"\n"+
+				`{"id":"SH-2","title":"Export cache eviction","status":"`+status2+`","priority":2,"issue_type":"task"}`+"\n")
+	}
+
+	git("init")
+	beads("open", "open")
+	write("pkg/parser/parser.go", "package parser\n\nfunc Parse() {}\n")
+	write("pkg/export/cache.go", "package export\n\nfunc Evict() {}\n")
+	git("add", ".")
+	git("commit", "-m", "seed")
+
+	beads("in_progress", "open")
+	write("pkg/parser/parser.go", "package parser\n\nfunc Parse() {}\n\nfunc Recover() {}\n")
+	git("add", ".")
+	git("commit", "-m", "start recovery")
+
+	beads("in_progress", "in_progress")
+	write("pkg/export/cache.go", "package export\n\nfunc Evict() {}\n\nfunc Size() int { return 0 }\n")
+	git("add", ".")
+	git("commit", "-m", "start eviction")
+
+	beads("closed", "closed")
+	write("pkg/parser/parser.go", "package parser\n\nfunc Parse() {}\n\nfunc Recover() {\n\t// skip to next statement\n\tparseNext()\n}\n\nfunc parseNext() {}\n")
+	write("pkg/export/cache.go", "package export\n\nfunc Evict() {}\n\nfunc Size() int { return 1 }\n")
+	git("add", ".")
+	git("commit", "-m", "wrap up sprint")
+	sha := git("rev-parse", "HEAD")
+
+	cmd := exec.Command(bv, "--robot-explain-correlation", sha[:7]+":SH-1")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-explain-correlation failed: %v\n%s", err, out)
+	}
+
+	var exp struct {
+		Method     string  `json:"method"`
+		Confidence float64 `json:"confidence"`
+		Signals    []struct {
+			Type string `json:"type"`
+		} `json:"signals"`
+		Shares []struct {
+			BeadID     string  `json:"bead_id"`
+			Share      float64 `json:"share"`
+			Confidence float64 `json:"confidence"`
+			Evidence   []struct {
+				Type   string `json:"type"`
+				Detail string `json:"detail"`
+			} `json:"evidence"`
+		} `json:"shares"`
+	}
+	if err := json.Unmarshal(out, &exp); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if exp.Method != "hunk_ownership" {
+		t.Fatalf("method = %q, want hunk_ownership\n%s", exp.Method, out)
+	}
+	if len(exp.Shares) != 2 || exp.Shares[0].BeadID != "SH-1" {
+		t.Fatalf("shares = %+v, want SH-1 first of 2", exp.Shares)
+	}
+	if exp.Shares[0].Share <= exp.Shares[1].Share {
+		t.Errorf("SH-1 share %.2f should exceed SH-2 share %.2f", exp.Shares[0].Share, exp.Shares[1].Share)
+	}
+	if exp.Confidence != exp.Shares[0].Confidence {
+		t.Errorf("confidence %.2f != SH-1 share confidence %.2f", exp.Confidence, exp.Shares[0].Confidence)
+	}
+	found := false
+	for _, e := range exp.Shares[0].Evidence {
+		if e.Type == "file_overlap" && strings.Contains(e.Detail, "pkg/parser/parser.go") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("SH-1 evidence lacks file overlap on parser.go: %+v", exp.Shares[0].Evidence)
+	}
+}
@@ -0,0 +1,121 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRobotFileRisk_FromGitHistory links a bug and a feature to code files
+// through commit messages; the file the bug keeps touching ranks first.
+func TestRobotFileRisk_FromGitHistory(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	base := time.Now().Add(-10 * 24 * time.Hour).UTC().Truncate(time.Hour)
+
+	write := func(rel, content string) {
+		path := filepath.Join(repoDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	beads := func(status1, status2 string) {
+		write(".beads/beads.jsonl",
+			`{"id":"FR-1","title":"Parser crash","status":"`+status1+`","priority":1,"issue_type":"bug"}`+"\n"+
+				`{"id":"FR-2","title":"CSV export","status":"`+status2+`","priority":2,"issue_type":"feature"}`+"\n")
+	}
+	commit := func(day int, msg string) {
+		date := base.Add(time.Duration(day) * 24 * time.Hour).Format(time.RFC3339)
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", msg}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoDir
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=Test",
+				"GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=Test",
+				"GIT_COMMITTER_EMAIL=test@example.com",
+				"GIT_AUTHOR_DATE="+date,
+				"GIT_COMMITTER_DATE="+date,
+			)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	if out, err := exec.Command("git", "-C", repoDir, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	beads("open", "open")
+	commit(0, "Add beads")
+	beads("in_progress", "open")
+	write("pkg/parser/parse.go", strings.Repeat("// parse\n", 40))
+	commit(1, "FR-1: fix parser crash")
+	beads("in_progress", "in_progress")
+	write("pkg/export/csv.go", strings.Repeat("// csv\n", 5))
+	commit(2, "FR-2: add CSV export")
+	beads("closed", "in_progress")
+	write("pkg/parser/parse.go", strings.Repeat("// parse again\n", 30))
+	commit(3, "FR-1: handle empty input")
+
+	cmd := exec.Command(bv, "--robot-file-risk")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-file-risk failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash string `json:"data_hash"`
+		Stats    struct {
+			TotalFiles int `json:"total_files"`
+		} `json:"stats"`
+		Files []struct {
+			FilePath  string   `json:"file_path"`
+			RiskScore float64  `json:"risk_score"`
+			BugBeads  int      `json:"bug_beads"`
+			BeadIDs   []string `json:"bead_ids"`
+		} `json:"files"`
+		Directories []struct {
+			Path    string `json:"path"`
+			Hotspot string `json:"hotspot"`
+		} `json:"directories"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" || payload.Stats.TotalFiles != 2 || len(payload.Files) != 2 {
+		t.Fatalf("want 2 files with data_hash, got %s", out)
+	}
+	top := payload.Files[0]
+	if top.FilePath != "pkg/parser/parse.go" || top.BugBeads != 1 || strings.Join(top.BeadIDs, ",") != "FR-1" {
+		t.Errorf("top file = %+v, want pkg/parser/parse.go linked to bug FR-1", top)
+	}
+	if top.RiskScore <= payload.Files[1].RiskScore {
+		t.Errorf("parser risk %.3f should exceed csv risk %.3f", top.RiskScore, payload.Files[1].RiskScore)
+	}
+	if len(payload.Directories) == 0 || payload.Directories[0].Hotspot != "pkg/parser/parse.go" {
+		t.Errorf("directories = %+v, want parser hotspot first", payload.Directories)
+	}
+
+	svgPath := filepath.Join(repoDir, "risk.svg")
+	cmd = exec.Command(bv, "--export-file-treemap", svgPath)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("--export-file-treemap failed: %v\n%s", err, out)
+	}
+	svg, err := os.ReadFile(svgPath)
+	if err != nil {
+		t.Fatalf("read treemap: %v", err)
+	}
+	if !strings.Contains(string(svg), "<svg") || !strings.Contains(string(svg), "pkg/parser/parse.go") {
+		t.Errorf("treemap missing svg root or file tooltip")
+	}
+}
@@ -0,0 +1,113 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRobotFlowMetrics_FromGitHistory walks two beads through their
+// lifecycle in dated commits; FL-2 spends two of its four claimed days blocked.
+func TestRobotFlowMetrics_FromGitHistory(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	base := time.Now().Add(-30 * 24 * time.Hour).UTC().Truncate(time.Hour)
+
+	commit := func(day int, status1, status2 string) {
+		content := `{"id":"FL-1","title":"Parser","status":"` + status1 + `","priority":1,"issue_type":"feature","labels":["api"]}` + "\n" +
+			`{"id":"FL-2","title":"Exporter","status":"` + status2 + `","priority":2,"issue_type":"task","labels":["api"],"assignee":"ann"}` + "\n"
+		if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+		date := base.Add(time.Duration(day) * 24 * time.Hour).Format(time.RFC3339)
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "day " + date}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoDir
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=Test",
+				"GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=Test",
+				"GIT_COMMITTER_EMAIL=test@example.com",
+				"GIT_AUTHOR_DATE="+date,
+				"GIT_COMMITTER_DATE="+date,
+			)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	if out, err := exec.Command("git", "-C", repoDir, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	commit(0, "open", "open")
+	commit(1, "in_progress", "in_progress")
+	commit(2, "in_progress", "blocked")
+	commit(3, "closed", "blocked")
+	commit(4, "closed", "in_progress")
+	commit(5, "closed", "closed")
+
+	cmd := exec.Command(bv, "--robot-flow-metrics")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-flow-metrics failed: %v\n%s", err, out)
+	}
+
+	type group struct {
+		Key       string `json:"key"`
+		Completed int    `json:"completed"`
+		CycleTime struct {
+			Count   int     `json:"count"`
+			P50Days float64 `json:"p50_days"`
+			P85Days float64 `json:"p85_days"`
+		} `json:"cycle_time"`
+		ActiveDays     float64  `json:"active_days"`
+		BlockedDays    float64  `json:"blocked_days"`
+		FlowEfficiency *float64 `json:"flow_efficiency"`
+	}
+	var payload struct {
+		DataHash   string  `json:"data_hash"`
+		Overall    group   `json:"overall"`
+		ByLabel    []group `json:"by_label"`
+		ByAssignee []group `json:"by_assignee"`
+		Samples    []struct {
+			BeadID string `json:"bead_id"`
+		} `json:"samples"`
+		AgingWIP []any `json:"aging_wip"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+
+	o := payload.Overall
+	if o.Completed != 2 || o.CycleTime.Count != 2 || o.CycleTime.P50Days != 2 || o.CycleTime.P85Days != 4 {
+		t.Errorf("overall = %+v, want 2 completed with cycle times 2d and 4d", o)
+	}
+	if o.BlockedDays != 2 || o.ActiveDays != 4 || o.FlowEfficiency == nil {
+		t.Errorf("active/blocked = %v/%v efficiency %v, want 4/2", o.ActiveDays, o.BlockedDays, o.FlowEfficiency)
+	}
+	if len(payload.ByLabel) != 1 || payload.ByLabel[0].Key != "api" || payload.ByLabel[0].Completed != 2 {
+		t.Errorf("by_label = %+v", payload.ByLabel)
+	}
+	var keys []string
+	for _, g := range payload.ByAssignee {
+		keys = append(keys, g.Key)
+	}
+	if strings.Join(keys, ",") != "(unassigned),ann" {
+		t.Errorf("by_assignee keys = %v", keys)
+	}
+	if len(payload.Samples) != 2 || payload.Samples[0].BeadID != "FL-1" {
+		t.Errorf("samples = %+v, want FL-1 first", payload.Samples)
+	}
+	if payload.DataHash == "" || payload.AgingWIP == nil {
+		t.Errorf("missing data_hash or aging_wip: %s", out)
+	}
+}
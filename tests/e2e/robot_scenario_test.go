@@ -0,0 +1,100 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotScenario_DiffsAgainstBaseline(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	// B waits on A; C waits on B.
+	writeBeads(t, env, `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task","labels":["auth"]}
+{"id":"B","title":"Beta","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}
+{"id":"C","title":"Gamma","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"B","type":"blocks"}]}`)
+
+	scenarioPath := filepath.Join(env, "what-if.yaml")
+	if err := os.WriteFile(scenarioPath, []byte(`name: auth ships
+mutations:
+  - op: close
+    label: auth
+  - op: estimate
+    id: C
+    add: 2d
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--robot-scenario", scenarioPath, "--forecast-trials", "100")
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--robot-scenario failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash  string `json:"data_hash"`
+		Scenario  string `json:"scenario"`
+		Mutations []struct {
+			IssueIDs []string `json:"issue_ids"`
+		} `json:"mutations"`
+		Diff struct {
+			Summary struct {
+				IssuesClosed int `json:"issues_closed"`
+			} `json:"summary"`
+		} `json:"diff"`
+		Triage struct {
+			OpenDelta int `json:"open_delta"`
+		} `json:"triage"`
+		Plan struct {
+			NewlyActionable []string `json:"newly_actionable"`
+		} `json:"plan"`
+		Forecast *struct {
+			Trials int `json:"trials"`
+		} `json:"forecast"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.DataHash == "" || payload.Scenario != "auth ships" {
+		t.Fatalf("envelope/scenario missing: %s", out)
+	}
+	if len(payload.Mutations) != 2 || len(payload.Mutations[0].IssueIDs) != 1 {
+		t.Fatalf("mutations=%+v", payload.Mutations)
+	}
+	if payload.Diff.Summary.IssuesClosed != 1 || payload.Triage.OpenDelta != -1 {
+		t.Fatalf("closed=%d open_delta=%d", payload.Diff.Summary.IssuesClosed, payload.Triage.OpenDelta)
+	}
+	if len(payload.Plan.NewlyActionable) != 1 || payload.Plan.NewlyActionable[0] != "B" {
+		t.Fatalf("newly_actionable=%v", payload.Plan.NewlyActionable)
+	}
+	if payload.Forecast == nil || payload.Forecast.Trials != 100 {
+		t.Fatalf("forecast=%+v", payload.Forecast)
+	}
+}
+
+func TestRobotScenario_RejectsUnknownIssue(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task"}`)
+
+	scenarioPath := filepath.Join(env, "bad.json")
+	if err := os.WriteFile(scenarioPath, []byte(`[{"op":"close","id":"ZZZ"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--robot-scenario", scenarioPath)
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected failure, got: %s", out)
+	}
+	if !strings.Contains(string(out), "selects no issues") {
+		t.Fatalf("unexpected error output: %s", out)
+	}
+}
@@ -0,0 +1,77 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRobotSchedule_MakespanAndGantt(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	// A blocks B and C; D is independent. Estimates drive the makespan deterministically.
+	writeBeads(t, env, `{"id":"A","title":"Foundation","status":"open","priority":1,"issue_type":"task","estimated_minutes":120}
+{"id":"B","title":"Build","status":"open","priority":1,"issue_type":"task","estimated_minutes":60,"dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}
+{"id":"C","title":"Polish","status":"open","priority":2,"issue_type":"task","estimated_minutes":60,"dependencies":[{"issue_id":"C","depends_on_id":"A","type":"blocks"}]}
+{"id":"D","title":"Docs","status":"open","priority":3,"issue_type":"task","estimated_minutes":30,"assignee":"bob"}`)
+
+	type schedulePayload struct {
+		DataHash        string  `json:"data_hash"`
+		MakespanMinutes float64 `json:"makespan_minutes"`
+		Items           []struct {
+			ID          string  `json:"id"`
+			Agent       string  `json:"agent"`
+			StartMinute float64 `json:"start_minute"`
+			EndMinute   float64 `json:"end_minute"`
+		} `json:"items"`
+		CriticalPath []string `json:"critical_path"`
+		Gantt        string   `json:"gantt"`
+	}
+	run := func(args ...string) schedulePayload {
+		t.Helper()
+		cmd := exec.Command(bv, append([]string{"--robot-schedule"}, args...)...)
+		cmd.Dir = env
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%v failed: %v\n%s", args, err, out)
+		}
+		var payload schedulePayload
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		return payload
+	}
+
+	one := run("--agents=1")
+	two := run("--agents=2")
+	if one.DataHash == "" {
+		t.Fatalf("expected data_hash in envelope")
+	}
+	if one.MakespanMinutes != 270 {
+		t.Fatalf("1 agent makespan=%.0f; want 270", one.MakespanMinutes)
+	}
+	if two.MakespanMinutes != 180 {
+		t.Fatalf("2 agents makespan=%.0f; want 180", two.MakespanMinutes)
+	}
+	if len(two.CriticalPath) == 0 || two.CriticalPath[0] != "A" {
+		t.Fatalf("critical_path=%v; want to start with A", two.CriticalPath)
+	}
+	if !strings.HasPrefix(two.Gantt, "gantt\n") || !strings.Contains(two.Gantt, "section agent-2") {
+		t.Fatalf("unexpected gantt chart:\n%s", two.Gantt)
+	}
+
+	named := run("--schedule-assignees=alice=1,bob=1")
+	for _, item := range named.Items {
+		if item.ID == "D" && item.Agent != "bob" {
+			t.Fatalf("D should be pinned to bob, got %s", item.Agent)
+		}
+	}
+
+	cmd := exec.Command(bv, "--robot-schedule", "--schedule-assignees=alice=zero")
+	cmd.Dir = env
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Fatalf("expected invalid --schedule-assignees to fail, got:\n%s", out)
+	}
+}
@@ -0,0 +1,75 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotTriage_ScoringProfile(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	// "hub" blocks two beads; "urgent" is an isolated P0.
+	writeBeads(t, env, `{"id":"hub","title":"Hub","status":"open","priority":4,"issue_type":"task"}
+{"id":"d1","title":"Dep 1","status":"open","priority":4,"issue_type":"task","dependencies":[{"issue_id":"d1","depends_on_id":"hub","type":"blocks"}]}
+{"id":"d2","title":"Dep 2","status":"open","priority":4,"issue_type":"task","dependencies":[{"issue_id":"d2","depends_on_id":"hub","type":"blocks"}]}
+{"id":"urgent","title":"Urgent","status":"open","priority":0,"issue_type":"bug"}`)
+
+	if err := os.MkdirAll(filepath.Join(env, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(env, ".bv", "scoring.yaml"), []byte(`profiles:
+  bugfix:
+    weights: {pagerank: 0, betweenness: 0, blocker_ratio: 0, staleness: 0, priority_boost: 1, time_to_impact: 0, urgency: 0, risk: 0}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, []string) {
+		t.Helper()
+		cmd := exec.Command(bv, append([]string{"--robot-triage"}, args...)...)
+		cmd.Dir = env
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("--robot-triage %v failed: %v\n%s", args, err, out)
+		}
+		var payload struct {
+			Triage struct {
+				Meta struct {
+					ScoringProfile string `json:"scoring_profile"`
+				} `json:"meta"`
+				Recommendations []struct {
+					ID string `json:"id"`
+				} `json:"recommendations"`
+			} `json:"triage"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		var ids []string
+		for _, r := range payload.Triage.Recommendations {
+			ids = append(ids, r.ID)
+		}
+		return payload.Triage.Meta.ScoringProfile, ids
+	}
+
+	profile, ids := run()
+	if profile != "default" || len(ids) == 0 || ids[0] != "hub" {
+		t.Fatalf("default: profile=%q ids=%v", profile, ids)
+	}
+	profile, ids = run("--scoring-profile", "bugfix")
+	if profile != "bugfix" || len(ids) == 0 || ids[0] != "urgent" {
+		t.Fatalf("bugfix: profile=%q ids=%v", profile, ids)
+	}
+
+	cmd := exec.Command(bv, "--robot-triage", "--scoring-profile", "nope")
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(out), `unknown scoring profile "nope"`) {
+		t.Fatalf("expected unknown profile error, got err=%v out=%s", err, out)
+	}
+}
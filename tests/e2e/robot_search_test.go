@@ -81,3 +81,67 @@ func TestRobotSearchContract(t *testing.T) {
 		t.Fatalf("expected usage_hints")
 	}
 }
+
+func TestRobotSearchBlendsKeywordScores(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Refresh token rotation","description":"rotate refresh tokens on every use","status":"open","priority":1,"issue_type":"task","comments":[{"id":1,"issue_id":"A","author":"x","text":"see rfc 6819"}]}
+{"id":"B","title":"Dashboard colors","description":"tweak the palette","status":"open","priority":2,"issue_type":"task"}`)
+
+	run := func(args ...string) []byte {
+		cmd := exec.Command(bv, append([]string{"--robot-search"}, args...)...)
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash", "BV_SEMANTIC_DIM=256")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("robot-search %v failed: %v\n%s", args, err, out)
+		}
+		return out
+	}
+
+	var payload struct {
+		LexicalWeight    float64 `json:"lexical_weight"`
+		LexicalIndexPath string  `json:"lexical_index_path"`
+		LexicalIndex     *struct {
+			Added int `json:"added"`
+		} `json:"lexical_index"`
+		Results []struct {
+			IssueID      string  `json:"issue_id"`
+			LexicalScore float64 `json:"lexical_score"`
+		} `json:"results"`
+	}
+
+	// Blending is opt-in: by default the keyword index is not touched
+	out := run("--search", "rotaton")
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.LexicalWeight != 0 || payload.LexicalIndex != nil {
+		t.Fatalf("keyword blending should be off by default: %+v", payload)
+	}
+
+	// A misspelling only the keyword index can recover
+	out = run("--search", "rotaton", "--search-lexical-weight", "0.35")
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.LexicalWeight != 0.35 || payload.LexicalIndex == nil || payload.LexicalIndex.Added != 2 {
+		t.Fatalf("lexical metadata = %+v", payload)
+	}
+	if _, err := os.Stat(payload.LexicalIndexPath); err != nil {
+		t.Fatalf("lexical index not saved: %v", err)
+	}
+	if len(payload.Results) == 0 || payload.Results[0].IssueID != "A" || payload.Results[0].LexicalScore == 0 {
+		t.Fatalf("expected A first with a keyword score, got %+v", payload.Results)
+	}
+
+	payload.LexicalIndex = nil
+	t.Setenv("BV_SEARCH_LEXICAL_WEIGHT", "0.5")
+	out = run("--search", "rotaton", "--search-lexical-weight", "0")
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if payload.LexicalWeight != 0 || payload.LexicalIndex != nil {
+		t.Fatalf("weight 0 should skip the keyword index: %+v", payload)
+	}
+}
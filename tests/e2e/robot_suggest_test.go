@@ -1,6 +1,12 @@
 package main_test
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
 
 func TestRobotSuggestContract(t *testing.T) {
 	bv := buildBvBinary(t)
@@ -48,3 +54,42 @@ func TestRobotSuggestContract(t *testing.T) {
 		t.Fatalf("suggest data_hash changed between calls: %v vs %v", first.DataHash, second.DataHash)
 	}
 }
+
+func TestRobotSuggestSemanticDuplicates(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	// Reworded duplicates share too few keywords for the jaccard detector alone
+	writeBeads(t, env, `{"id":"A","title":"Login page crashes on Safari","description":"The login page crashes when opened in Safari 17","status":"open","priority":1,"issue_type":"bug","created_at":"2026-01-01T00:00:00Z"}
+{"id":"B","title":"Safari: login page crashes","description":"Opening the login page in Safari 17 crashes it","status":"open","priority":2,"issue_type":"bug","created_at":"2026-01-02T00:00:00Z"}
+{"id":"C","title":"Add CSV export","description":"Export the board as CSV","status":"open","priority":2,"issue_type":"feature","created_at":"2026-01-03T00:00:00Z"}`)
+
+	cmd := exec.Command(bv, "--robot-suggest", "--suggest-type=duplicate")
+	cmd.Dir = env
+	cmd.Env = append(os.Environ(), "BV_SEMANTIC_EMBEDDER=hash")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-suggest failed: %v\n%s", err, out)
+	}
+
+	var resp struct {
+		Suggestions struct {
+			Suggestions []struct {
+				Type        string         `json:"type"`
+				TargetBead  string         `json:"target_bead"`
+				RelatedBead string         `json:"related_bead"`
+				Metadata    map[string]any `json:"metadata"`
+			} `json:"suggestions"`
+		} `json:"suggestions"`
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+
+	for _, s := range resp.Suggestions.Suggestions {
+		method, _ := s.Metadata["method"].(string)
+		if s.TargetBead == "B" && s.RelatedBead == "A" && strings.Contains(method, "semantic") {
+			return
+		}
+	}
+	t.Fatalf("expected B as a semantic duplicate of A, got %s", out)
+}
@@ -0,0 +1,107 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRobotWhere_FiltersRobotCommands(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	writeBeads(t, env, `{"id":"api-1","title":"Auth gateway","status":"open","priority":0,"issue_type":"feature","labels":["api"]}
+{"id":"api-2","title":"Rate limiter","status":"open","priority":2,"issue_type":"task","labels":["api"],"dependencies":[{"issue_id":"api-2","depends_on_id":"api-1","type":"blocks"}]}
+{"id":"api-3","title":"Quota","status":"open","priority":3,"issue_type":"task","labels":["api"]}
+{"id":"ui-1","title":"Dark mode","status":"open","priority":1,"issue_type":"feature","labels":["ui"]}`)
+
+	cmd := exec.Command(bv, "--robot-plan", "--where", "label:api AND (priority<=1 OR blockers>0)")
+	cmd.Dir = env
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-plan --where failed: %v\n%s", err, out)
+	}
+	var payload struct {
+		Plan struct {
+			Tracks []struct {
+				Items []struct {
+					ID string `json:"id"`
+				} `json:"items"`
+			} `json:"tracks"`
+		} `json:"plan"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	var ids []string
+	for _, track := range payload.Plan.Tracks {
+		for _, item := range track.Items {
+			ids = append(ids, item.ID)
+		}
+	}
+	sort.Strings(ids)
+	// api-2 keeps its open blocker because blocker counts use the full set.
+	if got := strings.Join(ids, ","); got != "api-1" {
+		t.Errorf("plan items = %s, want api-1 (api-2 is blocked)", got)
+	}
+}
+
+func TestRobotWhere_ReportsParseErrors(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"a","title":"One","status":"open","priority":1,"issue_type":"task"}`)
+
+	cmd := exec.Command(bv, "--robot-triage", "--where", "status:open AND lable:api")
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected failure, got success:\n%s", out)
+	}
+	for _, want := range []string{"column 17", `did you mean "label"`, "\n" + strings.Repeat(" ", 18) + "^"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("stderr missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRobotWhere_FiltersReloadingRobotCommands(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"a-1","title":"Core","status":"open","priority":1,"issue_type":"task","labels":["core"]}
+{"id":"a-2","title":"Api","status":"open","priority":1,"issue_type":"task","labels":["api"],"dependencies":[{"issue_id":"a-2","depends_on_id":"a-1","type":"blocks"}]}`)
+
+	chain := func(args ...string) []string {
+		t.Helper()
+		cmd := exec.Command(bv, append([]string{"--robot-blocker-chain", "a-2"}, args...)...)
+		cmd.Dir = env
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("--robot-blocker-chain %v failed: %v\n%s", args, err, out)
+		}
+		var payload struct {
+			Result struct {
+				Chain []struct {
+					ID string `json:"id"`
+				} `json:"chain"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		var ids []string
+		for _, item := range payload.Result.Chain {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	if got := strings.Join(chain(), ","); got != "a-2,a-1" {
+		t.Fatalf("unfiltered chain = %s, want a-2,a-1", got)
+	}
+	// The command reloads issues itself; --where must still apply
+	if got := strings.Join(chain("--where", "label:api"), ","); got != "a-2" {
+		t.Errorf("filtered chain = %s, want a-2 (a-1 is outside --where)", got)
+	}
+}
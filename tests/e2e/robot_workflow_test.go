@@ -0,0 +1,71 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRobotTriage_CustomWorkflowStatuses(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	writeBeads(t, env, `{"id":"a","title":"In QA","status":"qa","priority":1,"issue_type":"task"}
+{"id":"b","title":"Staged","status":"staged","priority":1,"issue_type":"task"}
+{"id":"c","title":"Waiting","status":"needs-info","priority":2,"issue_type":"task","dependencies":[{"issue_id":"c","depends_on_id":"b","type":"blocks"}]}
+{"id":"d","title":"Fresh","status":"open","priority":2,"issue_type":"task"}`)
+
+	run := func() map[string]any {
+		t.Helper()
+		cmd := exec.Command(bv, "--robot-triage")
+		cmd.Dir = env
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("--robot-triage failed: %v\n%s", err, out)
+		}
+		var payload struct {
+			Triage struct {
+				QuickRef      map[string]any `json:"quick_ref"`
+				ProjectHealth struct {
+					Counts map[string]any `json:"counts"`
+				} `json:"project_health"`
+			} `json:"triage"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		payload.Triage.QuickRef["total"] = payload.Triage.ProjectHealth.Counts["total"]
+		payload.Triage.QuickRef["closed"] = payload.Triage.ProjectHealth.Counts["closed"]
+		return payload.Triage.QuickRef
+	}
+
+	// Without a workflow the custom statuses are rejected by the loader.
+	if got := run()["total"]; got != float64(1) {
+		t.Fatalf("total without workflow = %v, want 1", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(env, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(env, ".bv", "workflow.yaml"), []byte(`statuses:
+  qa: active
+  staged: done
+  needs-info: blocked
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := run()
+	if ref["total"] != float64(4) || ref["closed"] != float64(1) {
+		t.Errorf("counts = total %v closed %v, want 4 and 1", ref["total"], ref["closed"])
+	}
+	if ref["in_progress_count"] != float64(1) {
+		t.Errorf("in_progress_count = %v, want 1 (qa is active)", ref["in_progress_count"])
+	}
+	// c's only blocker is staged (done), so everything open is actionable.
+	if ref["open_count"] != float64(3) || ref["actionable_count"] != float64(3) {
+		t.Errorf("open/actionable = %v/%v, want 3/3", ref["open_count"], ref["actionable_count"])
+	}
+}
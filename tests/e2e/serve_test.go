@@ -0,0 +1,226 @@
+package main_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestServe_ReloadsAndRevalidates starts bv serve on an ephemeral port,
+// checks ETag revalidation, then edits the beads file and waits for the
+// server to pick the change up.
+func TestServe_ReloadsAndRevalidates(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	beadsPath := filepath.Join(repoDir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeBeads := func(lines ...string) {
+		if err := os.WriteFile(beadsPath, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeBeads(
+		`{"id":"SV-1","title":"Login page","status":"open","priority":1,"issue_type":"feature"}`,
+		`{"id":"SV-2","title":"Session storage","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"SV-2","depends_on_id":"SV-1","type":"blocks"}]}`,
+	)
+
+	cmd := exec.Command(bv, "serve", "--addr", "127.0.0.1:0")
+	cmd.Dir = repoDir
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start bv serve: %v", err)
+	}
+	defer func() { _ = cmd.Process.Kill() }()
+
+	urlCh := make(chan string, 1)
+	go func() {
+		re := regexp.MustCompile(`on (http://\S+)`)
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			if m := re.FindStringSubmatch(scanner.Text()); m != nil {
+				urlCh <- m[1]
+			}
+		}
+	}()
+	var base string
+	select {
+	case base = <-urlCh:
+	case <-time.After(30 * time.Second):
+		t.Fatal("bv serve did not report its address")
+	}
+
+	get := func(path, etag string) (*http.Response, map[string]any) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, base+path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		var body map[string]any
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("GET %s: invalid JSON: %v", path, err)
+			}
+		}
+		return resp, body
+	}
+
+	resp, triage := get("/triage", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/triage status %d", resp.StatusCode)
+	}
+	picks := triage["triage"].(map[string]any)["quick_ref"].(map[string]any)["top_picks"].([]any)
+	if len(picks) == 0 || picks[0].(map[string]any)["id"] != "SV-1" {
+		t.Errorf("top picks = %v, want SV-1 first", picks)
+	}
+
+	resp, _ = get("/plan", "")
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("/plan has no ETag")
+	}
+	if resp, _ := get("/plan", etag); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("revalidation status = %d, want 304", resp.StatusCode)
+	}
+
+	_, health := get("/health", "")
+	before := health["data_hash"]
+	writeBeads(
+		`{"id":"SV-1","title":"Login page","status":"closed","priority":1,"issue_type":"feature"}`,
+		`{"id":"SV-2","title":"Session storage","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"SV-2","depends_on_id":"SV-1","type":"blocks"}]}`,
+	)
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		_, health = get("/health", "")
+		if health["data_hash"] != before {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server did not reload after the beads file changed")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if resp, _ := get("/plan", etag); resp.StatusCode != http.StatusOK {
+		t.Errorf("stale ETag status = %d, want 200", resp.StatusCode)
+	}
+
+	_ = cmd.Process.Signal(syscall.SIGINT)
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("bv serve exited with %v after SIGINT", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("bv serve did not stop on SIGINT")
+	}
+}
+
+func TestServe_RefusesNonLoopback(t *testing.T) {
+	bv := buildBvBinary(t)
+	cmd := exec.Command(bv, "serve", "--addr", "0.0.0.0:0")
+	cmd.Dir = t.TempDir()
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected failure, got success:\n%s", out)
+	}
+	if !strings.Contains(string(out), "loopback") {
+		t.Errorf("error should explain the loopback restriction:\n%s", out)
+	}
+}
+
+// TestServe_RunsLifecycleHooks closes a blocker while bv serve is watching
+// and expects the became-actionable hook to receive the event on stdin.
+func TestServe_RunsLifecycleHooks(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	beadsPath := filepath.Join(repoDir, ".beads", "beads.jsonl")
+	for _, dir := range []string{filepath.Dir(beadsPath), filepath.Join(repoDir, ".bv")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventPath := filepath.Join(repoDir, "event.json")
+	hooksYAML := "hooks:\n  became-actionable:\n    - name: record\n      command: cat > \"$EVENT_FILE\"\n      env:\n        EVENT_FILE: " + eventPath + "\n"
+	if err := os.WriteFile(filepath.Join(repoDir, ".bv", "hooks.yaml"), []byte(hooksYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeBeads := func(blockerStatus string) {
+		lines := `{"id":"HK-1","title":"Schema","status":"` + blockerStatus + `","priority":1,"issue_type":"task"}
+{"id":"HK-2","title":"Migration","status":"open","priority":2,"issue_type":"task","assignee":"agent-7","dependencies":[{"issue_id":"HK-2","depends_on_id":"HK-1","type":"blocks"}]}
+`
+		if err := os.WriteFile(beadsPath, []byte(lines), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeBeads("open")
+
+	cmd := exec.Command(bv, "serve", "--addr", "127.0.0.1:0")
+	cmd.Dir = repoDir
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start bv serve: %v", err)
+	}
+	defer func() { _ = cmd.Process.Kill() }()
+	ready := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		signalled := false
+		for scanner.Scan() {
+			if !signalled && strings.Contains(scanner.Text(), " on http://") {
+				close(ready)
+				signalled = true
+			}
+		}
+	}()
+	select {
+	case <-ready:
+	case <-time.After(30 * time.Second):
+		t.Fatal("bv serve did not start")
+	}
+
+	writeBeads("closed")
+	deadline := time.Now().Add(15 * time.Second)
+	var data []byte
+	for {
+		if data, err = os.ReadFile(eventPath); err == nil && len(data) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("became-actionable hook did not run")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var event map[string]any
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("hook stdin is not JSON: %v\n%s", err, data)
+	}
+	if event["event"] != "became-actionable" || event["issue_id"] != "HK-2" || event["assignee"] != "agent-7" {
+		t.Errorf("event = %v", event)
+	}
+	if cleared, _ := event["cleared_blockers"].([]any); len(cleared) != 1 || cleared[0] != "HK-1" {
+		t.Errorf("cleared_blockers = %v, want [HK-1]", event["cleared_blockers"])
+	}
+}